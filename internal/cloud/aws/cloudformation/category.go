@@ -0,0 +1,46 @@
+package cloudformation
+
+import (
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+)
+
+// StacksCategory represents the CloudFormation stacks category.
+type StacksCategory struct {
+	profile    string
+	region     string
+	operations []cloud.Operation
+}
+
+// NewStacksCategory creates a new CloudFormation stacks category.
+func NewStacksCategory(profile, region string) *StacksCategory {
+	category := &StacksCategory{
+		profile:    profile,
+		region:     region,
+		operations: make([]cloud.Operation, 0),
+	}
+
+	// Register operations
+	category.operations = append(category.operations, NewStackOperation(profile, region))
+
+	return category
+}
+
+// Name returns the category's name.
+func (c *StacksCategory) Name() string {
+	return "Stacks"
+}
+
+// Description returns the category's description.
+func (c *StacksCategory) Description() string {
+	return "CloudFormation Stacks and Change Sets"
+}
+
+// Operations returns all available operations for this category.
+func (c *StacksCategory) Operations() []cloud.Operation {
+	return c.operations
+}
+
+// IsUIVisible returns whether this category should be visible in the UI.
+func (c *StacksCategory) IsUIVisible() bool {
+	return true
+}
@@ -0,0 +1,369 @@
+package cloudformation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+)
+
+// maxStackEvents is how many of the latest events of a stack are read, enough to cover the last
+// few operations of most stacks.
+const maxStackEvents = 300
+
+// Common errors.
+var (
+	ErrLoadConfig        = errors.New("failed to load AWS config")
+	ErrListStacks        = errors.New("failed to list stacks")
+	ErrGetStackEvents    = errors.New("failed to get stack events")
+	ErrDetectStackDrift  = errors.New("failed to detect stack drift")
+	ErrGetDriftDetection = errors.New("failed to get drift detection status")
+	ErrGetResourceDrifts = errors.New("failed to get resource drifts")
+	ErrListChangeSets    = errors.New("failed to list change sets")
+	ErrGetChangeSet      = errors.New("failed to get change set")
+	ErrChangeSetNotFound = errors.New("change set not found")
+	ErrExecuteChangeSet  = errors.New("failed to execute change set")
+)
+
+// StackOperation represents an operation to inspect CloudFormation stacks, detect their drift and
+// review and execute their change sets.
+type StackOperation struct {
+	profile string
+	region  string
+}
+
+// NewStackOperation creates a new stack operation.
+func NewStackOperation(profile, region string) *StackOperation {
+	return &StackOperation{
+		profile: profile,
+		region:  region,
+	}
+}
+
+// Name returns the operation's name.
+func (o *StackOperation) Name() string {
+	return "Manage Stacks"
+}
+
+// Description returns the operation's description.
+func (o *StackOperation) Description() string {
+	return "Stack Events, Drift and Change Sets"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+func (o *StackOperation) IsUIVisible() bool {
+	return true
+}
+
+// ListStacks returns the stacks of the account that aren't deleted with their outputs and
+// parameters, in name order.
+func (o *StackOperation) ListStacks(ctx context.Context) ([]cloud.Stack, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	var stacks []cloud.Stack
+	paginator := cloudformation.NewDescribeStacksPaginator(client, &cloudformation.DescribeStacksInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrListStacks, err)
+		}
+		for _, stack := range output.Stacks {
+			stacks = append(stacks, convertStack(stack))
+		}
+	}
+
+	sort.Slice(stacks, func(i, j int) bool {
+		return stacks[i].Name < stacks[j].Name
+	})
+	return stacks, nil
+}
+
+// GetStackEvents returns the latest events of a stack, newest first.
+func (o *StackOperation) GetStackEvents(ctx context.Context, stack string) ([]cloud.StackEvent, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []cloud.StackEvent
+	paginator := cloudformation.NewDescribeStackEventsPaginator(client, &cloudformation.DescribeStackEventsInput{
+		StackName: aws.String(stack),
+	})
+	for paginator.HasMorePages() && len(events) < maxStackEvents {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrGetStackEvents, err)
+		}
+		for _, event := range output.StackEvents {
+			events = append(events, cloud.StackEvent{
+				Timestamp:    aws.ToTime(event.Timestamp),
+				LogicalID:    aws.ToString(event.LogicalResourceId),
+				PhysicalID:   aws.ToString(event.PhysicalResourceId),
+				ResourceType: aws.ToString(event.ResourceType),
+				Status:       string(event.ResourceStatus),
+				Reason:       aws.ToString(event.ResourceStatusReason),
+			})
+		}
+	}
+	return events[:min(len(events), maxStackEvents)], nil
+}
+
+// DetectStackDrift starts detecting the drift of a stack's resources and returns the ID of the
+// detection, to be polled with GetStackDriftDetection.
+func (o *StackOperation) DetectStackDrift(ctx context.Context, stack string) (string, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := client.DetectStackDrift(ctx, &cloudformation.DetectStackDriftInput{
+		StackName: aws.String(stack),
+	})
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrDetectStackDrift, err)
+	}
+	return aws.ToString(output.StackDriftDetectionId), nil
+}
+
+// GetStackDriftDetection returns the state of a drift detection.
+func (o *StackOperation) GetStackDriftDetection(ctx context.Context, id string) (*cloud.StackDriftDetection, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.DescribeStackDriftDetectionStatus(ctx, &cloudformation.DescribeStackDriftDetectionStatusInput{
+		StackDriftDetectionId: aws.String(id),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetDriftDetection, err)
+	}
+	return &cloud.StackDriftDetection{
+		ID:               id,
+		Status:           string(output.DetectionStatus),
+		Reason:           aws.ToString(output.DetectionStatusReason),
+		StackDriftStatus: string(output.StackDriftStatus),
+		DriftedResources: int(aws.ToInt32(output.DriftedStackResourceCount)),
+	}, nil
+}
+
+// GetStackResourceDrifts returns the resources of a stack that were modified or deleted outside
+// of CloudFormation when drift was last detected, in logical ID order.
+func (o *StackOperation) GetStackResourceDrifts(ctx context.Context, stack string) ([]cloud.StackResourceDrift, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	var drifts []cloud.StackResourceDrift
+	paginator := cloudformation.NewDescribeStackResourceDriftsPaginator(client, &cloudformation.DescribeStackResourceDriftsInput{
+		StackName: aws.String(stack),
+		StackResourceDriftStatusFilters: []types.StackResourceDriftStatus{
+			types.StackResourceDriftStatusModified,
+			types.StackResourceDriftStatusDeleted,
+		},
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrGetResourceDrifts, err)
+		}
+		for _, drift := range output.StackResourceDrifts {
+			converted := cloud.StackResourceDrift{
+				LogicalID:    aws.ToString(drift.LogicalResourceId),
+				PhysicalID:   aws.ToString(drift.PhysicalResourceId),
+				ResourceType: aws.ToString(drift.ResourceType),
+				Status:       string(drift.StackResourceDriftStatus),
+			}
+			for _, difference := range drift.PropertyDifferences {
+				converted.Differences = append(converted.Differences, cloud.PropertyDifference{
+					Path:     aws.ToString(difference.PropertyPath),
+					Type:     string(difference.DifferenceType),
+					Expected: aws.ToString(difference.ExpectedValue),
+					Actual:   aws.ToString(difference.ActualValue),
+				})
+			}
+			drifts = append(drifts, converted)
+		}
+	}
+
+	sort.Slice(drifts, func(i, j int) bool {
+		return drifts[i].LogicalID < drifts[j].LogicalID
+	})
+	return drifts, nil
+}
+
+// ListChangeSets returns the change sets of a stack without their changes, newest first.
+func (o *StackOperation) ListChangeSets(ctx context.Context, stack string) ([]cloud.ChangeSet, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	var changeSets []cloud.ChangeSet
+	paginator := cloudformation.NewListChangeSetsPaginator(client, &cloudformation.ListChangeSetsInput{
+		StackName: aws.String(stack),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrListChangeSets, err)
+		}
+		for _, summary := range output.Summaries {
+			changeSets = append(changeSets, cloud.ChangeSet{
+				Name:            aws.ToString(summary.ChangeSetName),
+				ID:              aws.ToString(summary.ChangeSetId),
+				Status:          string(summary.Status),
+				StatusReason:    aws.ToString(summary.StatusReason),
+				ExecutionStatus: string(summary.ExecutionStatus),
+				Description:     aws.ToString(summary.Description),
+				CreationTime:    aws.ToTime(summary.CreationTime),
+			})
+		}
+	}
+
+	sort.SliceStable(changeSets, func(i, j int) bool {
+		return changeSets[i].CreationTime.After(changeSets[j].CreationTime)
+	})
+	return changeSets, nil
+}
+
+// GetChangeSet returns a change set of a stack with the changes it makes to each resource.
+func (o *StackOperation) GetChangeSet(ctx context.Context, stack, name string) (*cloud.ChangeSet, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	var changeSet *cloud.ChangeSet
+	paginator := cloudformation.NewDescribeChangeSetPaginator(client, &cloudformation.DescribeChangeSetInput{
+		StackName:     aws.String(stack),
+		ChangeSetName: aws.String(name),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		var notFound *types.ChangeSetNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, fmt.Errorf("%w: %s", ErrChangeSetNotFound, name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrGetChangeSet, err)
+		}
+
+		if changeSet == nil {
+			changeSet = &cloud.ChangeSet{
+				Name:            aws.ToString(output.ChangeSetName),
+				ID:              aws.ToString(output.ChangeSetId),
+				Status:          string(output.Status),
+				StatusReason:    aws.ToString(output.StatusReason),
+				ExecutionStatus: string(output.ExecutionStatus),
+				Description:     aws.ToString(output.Description),
+				CreationTime:    aws.ToTime(output.CreationTime),
+			}
+		}
+		for _, change := range output.Changes {
+			if change.ResourceChange != nil {
+				changeSet.Changes = append(changeSet.Changes, convertResourceChange(*change.ResourceChange))
+			}
+		}
+	}
+
+	if changeSet == nil {
+		return nil, fmt.Errorf("%w: %s", ErrChangeSetNotFound, name)
+	}
+	return changeSet, nil
+}
+
+// ExecuteChangeSet updates a stack with a change set. The update runs in the background and
+// shows up in the stack's events.
+func (o *StackOperation) ExecuteChangeSet(ctx context.Context, stack, name string) error {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.ExecuteChangeSet(ctx, &cloudformation.ExecuteChangeSetInput{
+		StackName:     aws.String(stack),
+		ChangeSetName: aws.String(name),
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrExecuteChangeSet, err)
+	}
+	return nil
+}
+
+// Execute executes the operation with the given parameters.
+func (o *StackOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return o.ListStacks(ctx)
+}
+
+// convertStack converts a stack with its outputs and parameters
+func convertStack(stack types.Stack) cloud.Stack {
+	result := cloud.Stack{
+		Name:         aws.ToString(stack.StackName),
+		ID:           aws.ToString(stack.StackId),
+		Status:       string(stack.StackStatus),
+		StatusReason: aws.ToString(stack.StackStatusReason),
+		Description:  aws.ToString(stack.Description),
+		CreationTime: aws.ToTime(stack.CreationTime),
+		LastUpdated:  aws.ToTime(stack.LastUpdatedTime),
+		DriftStatus:  string(types.StackDriftStatusNotChecked),
+	}
+	if stack.DriftInformation != nil {
+		result.DriftStatus = string(stack.DriftInformation.StackDriftStatus)
+		result.DriftChecked = aws.ToTime(stack.DriftInformation.LastCheckTimestamp)
+	}
+
+	for _, output := range stack.Outputs {
+		result.Outputs = append(result.Outputs, cloud.StackOutput{
+			Key:         aws.ToString(output.OutputKey),
+			Value:       aws.ToString(output.OutputValue),
+			Description: aws.ToString(output.Description),
+			ExportName:  aws.ToString(output.ExportName),
+		})
+	}
+	for _, parameter := range stack.Parameters {
+		result.Parameters = append(result.Parameters, cloud.StackParameter{
+			Key:           aws.ToString(parameter.ParameterKey),
+			Value:         aws.ToString(parameter.ParameterValue),
+			ResolvedValue: aws.ToString(parameter.ResolvedValue),
+		})
+	}
+	return result
+}
+
+// convertResourceChange converts the change a change set makes to a resource
+func convertResourceChange(change types.ResourceChange) cloud.ResourceChange {
+	result := cloud.ResourceChange{
+		Action:       string(change.Action),
+		LogicalID:    aws.ToString(change.LogicalResourceId),
+		PhysicalID:   aws.ToString(change.PhysicalResourceId),
+		ResourceType: aws.ToString(change.ResourceType),
+		Replacement:  string(change.Replacement),
+	}
+	for _, scope := range change.Scope {
+		result.Scope = append(result.Scope, string(scope))
+	}
+	return result
+}
+
+// getClient creates a CloudFormation client for the given profile and region.
+func getClient(ctx context.Context, profile, region string) (*cloudformation.Client, error) {
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithSharedConfigProfile(profile),
+		config.WithRegion(region),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLoadConfig, err)
+	}
+	return cloudformation.NewFromConfig(cfg), nil
+}
@@ -0,0 +1,227 @@
+package cloudwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	cw "github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// maxHistoryItems is how many of the latest history items are read for an alarm
+const maxHistoryItems = 100
+
+// MaxMetricQueries is the largest number of queries GetMetricData accepts in one request
+const MaxMetricQueries = 500
+
+// Alarm errors.
+var (
+	ErrListAlarms      = errors.New("failed to list alarms")
+	ErrSetAlarmActions = errors.New("failed to update alarm actions")
+	ErrGetAlarmHistory = errors.New("failed to get alarm history")
+)
+
+// alarmTypes are the types of alarm listed; DescribeAlarms only lists metric alarms unless asked
+var alarmTypes = []cwtypes.AlarmType{cwtypes.AlarmTypeMetricAlarm, cwtypes.AlarmTypeCompositeAlarm}
+
+// AlarmsOperation represents an operation to browse CloudWatch alarms by state.
+type AlarmsOperation struct {
+	profile string
+	region  string
+}
+
+// NewAlarmsOperation creates a new alarms operation.
+func NewAlarmsOperation(profile, region string) *AlarmsOperation {
+	return &AlarmsOperation{
+		profile: profile,
+		region:  region,
+	}
+}
+
+// Name returns the operation's name.
+func (o *AlarmsOperation) Name() string {
+	return "View Alarms"
+}
+
+// Description returns the operation's description.
+func (o *AlarmsOperation) Description() string {
+	return "Browse Alarms by State"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+func (o *AlarmsOperation) IsUIVisible() bool {
+	return true
+}
+
+// ListAlarms returns the metric and composite alarms whose names start with the prefix, with
+// those in alarm first, then those with insufficient data and those that are OK.
+func (o *AlarmsOperation) ListAlarms(ctx context.Context, prefix string) ([]cloud.Alarm, error) {
+	client, err := getAlarmsClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &cw.DescribeAlarmsInput{AlarmTypes: alarmTypes}
+	if prefix != "" {
+		input.AlarmNamePrefix = aws.String(prefix)
+	}
+
+	var alarms []cloud.Alarm
+	paginator := cw.NewDescribeAlarmsPaginator(client, input)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrListAlarms, err)
+		}
+		for _, alarm := range output.MetricAlarms {
+			alarms = append(alarms, cloud.Alarm{
+				Name:           aws.ToString(alarm.AlarmName),
+				ARN:            aws.ToString(alarm.AlarmArn),
+				Type:           cloud.AlarmTypeMetric,
+				Description:    aws.ToString(alarm.AlarmDescription),
+				State:          string(alarm.StateValue),
+				StateReason:    aws.ToString(alarm.StateReason),
+				StateChanged:   stateChanged(alarm.StateTransitionedTimestamp, alarm.StateUpdatedTimestamp),
+				ActionsEnabled: aws.ToBool(alarm.ActionsEnabled),
+				Resource:       metricAlarmResource(alarm),
+			})
+		}
+		for _, alarm := range output.CompositeAlarms {
+			alarms = append(alarms, cloud.Alarm{
+				Name:           aws.ToString(alarm.AlarmName),
+				ARN:            aws.ToString(alarm.AlarmArn),
+				Type:           cloud.AlarmTypeComposite,
+				Description:    aws.ToString(alarm.AlarmDescription),
+				State:          string(alarm.StateValue),
+				StateReason:    aws.ToString(alarm.StateReason),
+				StateChanged:   stateChanged(alarm.StateTransitionedTimestamp, alarm.StateUpdatedTimestamp),
+				ActionsEnabled: aws.ToBool(alarm.ActionsEnabled),
+				Resource:       aws.ToString(alarm.AlarmRule),
+			})
+		}
+	}
+
+	rank := make(map[string]int, len(cloud.AlarmStates))
+	for i, state := range cloud.AlarmStates {
+		rank[state] = i
+	}
+	sort.Slice(alarms, func(i, j int) bool {
+		if rank[alarms[i].State] != rank[alarms[j].State] {
+			return rank[alarms[i].State] < rank[alarms[j].State]
+		}
+		return alarms[i].Name < alarms[j].Name
+	})
+	return alarms, nil
+}
+
+// SetAlarmActions enables or disables the actions of an alarm. Disabled actions mute the alarm:
+// it still changes state, but notifies no one and triggers nothing.
+func (o *AlarmsOperation) SetAlarmActions(ctx context.Context, name string, enabled bool) error {
+	client, err := getAlarmsClient(ctx, o.profile, o.region)
+	if err != nil {
+		return err
+	}
+
+	if enabled {
+		_, err = client.EnableAlarmActions(ctx, &cw.EnableAlarmActionsInput{AlarmNames: []string{name}})
+	} else {
+		_, err = client.DisableAlarmActions(ctx, &cw.DisableAlarmActionsInput{AlarmNames: []string{name}})
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSetAlarmActions, err)
+	}
+	return nil
+}
+
+// GetAlarmHistory returns the latest state changes, configuration updates and actions of an
+// alarm, newest first.
+func (o *AlarmsOperation) GetAlarmHistory(ctx context.Context, name string) ([]cloud.AlarmHistoryItem, error) {
+	client, err := getAlarmsClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.DescribeAlarmHistory(ctx, &cw.DescribeAlarmHistoryInput{
+		AlarmName:  aws.String(name),
+		AlarmTypes: alarmTypes,
+		MaxRecords: aws.Int32(maxHistoryItems),
+		ScanBy:     cwtypes.ScanByTimestampDescending,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetAlarmHistory, err)
+	}
+
+	history := make([]cloud.AlarmHistoryItem, 0, len(output.AlarmHistoryItems))
+	for _, item := range output.AlarmHistoryItems {
+		history = append(history, cloud.AlarmHistoryItem{
+			Timestamp: aws.ToTime(item.Timestamp),
+			Type:      string(item.HistoryItemType),
+			Summary:   aws.ToString(item.HistorySummary),
+		})
+	}
+	return history, nil
+}
+
+// Execute executes the operation with the given parameters.
+func (o *AlarmsOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return o.ListAlarms(ctx, "")
+}
+
+// stateChanged returns when an alarm last moved between states. The transition time is left out
+// for alarms that haven't changed state since they were created.
+func stateChanged(transitioned, updated *time.Time) time.Time {
+	if transitioned != nil {
+		return *transitioned
+	}
+	return aws.ToTime(updated)
+}
+
+// metricAlarmResource returns the metrics a metric alarm watches with their dimensions, such as
+// "AWS/Lambda Errors FunctionName=checkout", or "metric math" for an expression over none
+func metricAlarmResource(alarm cwtypes.MetricAlarm) string {
+	if alarm.MetricName != nil {
+		return formatMetric(aws.ToString(alarm.Namespace), aws.ToString(alarm.MetricName), alarm.Dimensions)
+	}
+
+	var metrics []string
+	for _, query := range alarm.Metrics {
+		if query.MetricStat == nil || query.MetricStat.Metric == nil {
+			continue
+		}
+		metric := query.MetricStat.Metric
+		metrics = append(metrics, formatMetric(aws.ToString(metric.Namespace), aws.ToString(metric.MetricName), metric.Dimensions))
+	}
+	if len(metrics) == 0 {
+		return "metric math"
+	}
+	return strings.Join(metrics, "; ")
+}
+
+// formatMetric returns a metric and its dimensions on a single line
+func formatMetric(namespace, name string, dimensions []cwtypes.Dimension) string {
+	parts := []string{namespace, name}
+	for _, dimension := range dimensions {
+		parts = append(parts, aws.ToString(dimension.Name)+"="+aws.ToString(dimension.Value))
+	}
+	return strings.Join(parts, " ")
+}
+
+// getAlarmsClient creates a new CloudWatch client.
+func getAlarmsClient(ctx context.Context, profile, region string) (*cw.Client, error) {
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithSharedConfigProfile(profile),
+		config.WithRegion(region),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLoadConfig, err)
+	}
+
+	return cw.NewFromConfig(cfg), nil
+}
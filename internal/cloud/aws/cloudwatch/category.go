@@ -0,0 +1,87 @@
+package cloudwatch
+
+import (
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+)
+
+// LogsCategory represents the CloudWatch Logs category.
+type LogsCategory struct {
+	profile    string
+	region     string
+	operations []cloud.Operation
+}
+
+// NewLogsCategory creates a new CloudWatch Logs category.
+func NewLogsCategory(profile, region string) *LogsCategory {
+	category := &LogsCategory{
+		profile:    profile,
+		region:     region,
+		operations: make([]cloud.Operation, 0),
+	}
+
+	// Register operations
+	category.operations = append(category.operations, NewInsightsOperation(profile, region))
+
+	return category
+}
+
+// Name returns the category's name.
+func (c *LogsCategory) Name() string {
+	return "Logs"
+}
+
+// Description returns the category's description.
+func (c *LogsCategory) Description() string {
+	return "CloudWatch Logs"
+}
+
+// Operations returns all available operations for this category.
+func (c *LogsCategory) Operations() []cloud.Operation {
+	return c.operations
+}
+
+// IsUIVisible returns whether this category should be visible in the UI.
+func (c *LogsCategory) IsUIVisible() bool {
+	return true
+}
+
+// AlarmsCategory represents the CloudWatch alarms category.
+type AlarmsCategory struct {
+	profile    string
+	region     string
+	operations []cloud.Operation
+}
+
+// NewAlarmsCategory creates a new CloudWatch alarms category.
+func NewAlarmsCategory(profile, region string) *AlarmsCategory {
+	category := &AlarmsCategory{
+		profile:    profile,
+		region:     region,
+		operations: make([]cloud.Operation, 0),
+	}
+
+	// Register operations
+	category.operations = append(category.operations, NewAlarmsOperation(profile, region))
+
+	return category
+}
+
+// Name returns the category's name.
+func (c *AlarmsCategory) Name() string {
+	return "Alarms"
+}
+
+// Description returns the category's description.
+func (c *AlarmsCategory) Description() string {
+	return "CloudWatch Alarms"
+}
+
+// Operations returns all available operations for this category.
+func (c *AlarmsCategory) Operations() []cloud.Operation {
+	return c.operations
+}
+
+// IsUIVisible returns whether this category should be visible in the UI.
+func (c *AlarmsCategory) IsUIVisible() bool {
+	return true
+}
@@ -0,0 +1,255 @@
+package cloudwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+)
+
+// pointerField is the hidden field Logs Insights adds to every record to fetch the full event with
+const pointerField = "@ptr"
+
+// Common errors.
+var (
+	ErrLoadConfig       = errors.New("failed to load AWS config")
+	ErrListLogGroups    = errors.New("failed to list log groups")
+	ErrStartQuery       = errors.New("failed to start query")
+	ErrGetQueryResults  = errors.New("failed to get query results")
+	ErrListSavedQueries = errors.New("failed to list saved queries")
+	ErrSaveQuery        = errors.New("failed to save query")
+)
+
+// InsightsOperation represents an operation to run Logs Insights queries over log groups.
+type InsightsOperation struct {
+	profile string
+	region  string
+}
+
+// NewInsightsOperation creates a new Logs Insights operation.
+func NewInsightsOperation(profile, region string) *InsightsOperation {
+	return &InsightsOperation{
+		profile: profile,
+		region:  region,
+	}
+}
+
+// Name returns the operation's name.
+func (o *InsightsOperation) Name() string {
+	return "Query Logs"
+}
+
+// Description returns the operation's description.
+func (o *InsightsOperation) Description() string {
+	return "Run Logs Insights Queries"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+func (o *InsightsOperation) IsUIVisible() bool {
+	return true
+}
+
+// ListLogGroups returns the log groups of the account, in name order.
+func (o *InsightsOperation) ListLogGroups(ctx context.Context) ([]cloud.LogGroup, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []cloud.LogGroup
+	paginator := cloudwatchlogs.NewDescribeLogGroupsPaginator(client, &cloudwatchlogs.DescribeLogGroupsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrListLogGroups, err)
+		}
+		for _, group := range output.LogGroups {
+			groups = append(groups, cloud.LogGroup{
+				Name:          aws.ToString(group.LogGroupName),
+				StoredBytes:   aws.ToInt64(group.StoredBytes),
+				RetentionDays: aws.ToInt32(group.RetentionInDays),
+				CreationTime:  time.UnixMilli(aws.ToInt64(group.CreationTime)),
+			})
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+	return groups, nil
+}
+
+// StartQuery starts a query and returns its ID. Logs Insights runs queries in the background,
+// so the results are read with GetQueryResults until the query is done.
+func (o *InsightsOperation) StartQuery(ctx context.Context, query cloud.LogsQuery) (string, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := client.StartQuery(ctx, &cloudwatchlogs.StartQueryInput{
+		LogGroupNames: query.LogGroups,
+		QueryString:   aws.String(query.Query),
+		StartTime:     aws.Int64(query.Start.Unix()),
+		EndTime:       aws.Int64(query.End.Unix()),
+	})
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrStartQuery, err)
+	}
+	return aws.ToString(output.QueryId), nil
+}
+
+// GetQueryResults returns the status of a query and the records it matched so far. Fields are
+// listed in the order they first appear, which is the order the query asked for them in.
+func (o *InsightsOperation) GetQueryResults(ctx context.Context, queryID string) (*cloud.LogsQueryResults, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.GetQueryResults(ctx, &cloudwatchlogs.GetQueryResultsInput{
+		QueryId: aws.String(queryID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetQueryResults, err)
+	}
+
+	results := &cloud.LogsQueryResults{Status: string(output.Status)}
+	if output.Statistics != nil {
+		results.RecordsMatched = output.Statistics.RecordsMatched
+		results.RecordsScanned = output.Statistics.RecordsScanned
+		results.BytesScanned = output.Statistics.BytesScanned
+	}
+
+	columns := make(map[string]int)
+	records := make([]map[string]string, 0, len(output.Results))
+	for _, fields := range output.Results {
+		record := make(map[string]string, len(fields))
+		for _, field := range fields {
+			name := aws.ToString(field.Field)
+			if name == pointerField {
+				continue
+			}
+			if _, ok := columns[name]; !ok {
+				columns[name] = len(results.Fields)
+				results.Fields = append(results.Fields, name)
+			}
+			record[name] = aws.ToString(field.Value)
+		}
+		records = append(records, record)
+	}
+
+	results.Records = make([][]string, 0, len(records))
+	for _, record := range records {
+		values := make([]string, len(results.Fields))
+		for name, value := range record {
+			values[columns[name]] = value
+		}
+		results.Records = append(results.Records, values)
+	}
+	return results, nil
+}
+
+// ListSavedQueries returns the query definitions saved over any of the log groups, in name order.
+func (o *InsightsOperation) ListSavedQueries(ctx context.Context, logGroups []string) ([]cloud.SavedLogsQuery, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]bool, len(logGroups))
+	for _, group := range logGroups {
+		selected[group] = true
+	}
+
+	var queries []cloud.SavedLogsQuery
+	input := &cloudwatchlogs.DescribeQueryDefinitionsInput{
+		QueryLanguage: types.QueryLanguageCwli,
+	}
+	for {
+		output, err := client.DescribeQueryDefinitions(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrListSavedQueries, err)
+		}
+		for _, definition := range output.QueryDefinitions {
+			if !savedOverAny(definition.LogGroupNames, selected) {
+				continue
+			}
+			queries = append(queries, cloud.SavedLogsQuery{
+				ID:        aws.ToString(definition.QueryDefinitionId),
+				Name:      aws.ToString(definition.Name),
+				LogGroups: definition.LogGroupNames,
+				Query:     aws.ToString(definition.QueryString),
+			})
+		}
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	sort.Slice(queries, func(i, j int) bool {
+		return strings.ToLower(queries[i].Name) < strings.ToLower(queries[j].Name)
+	})
+	return queries, nil
+}
+
+// SaveQuery saves a query as a query definition over its log groups and returns its ID. A query
+// with an ID replaces the definition it was loaded from.
+func (o *InsightsOperation) SaveQuery(ctx context.Context, query cloud.SavedLogsQuery) (string, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return "", err
+	}
+
+	input := &cloudwatchlogs.PutQueryDefinitionInput{
+		Name:          aws.String(query.Name),
+		QueryString:   aws.String(query.Query),
+		LogGroupNames: query.LogGroups,
+	}
+	if query.ID != "" {
+		input.QueryDefinitionId = aws.String(query.ID)
+	}
+
+	output, err := client.PutQueryDefinition(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrSaveQuery, err)
+	}
+	return aws.ToString(output.QueryDefinitionId), nil
+}
+
+// Execute executes the operation with the given parameters.
+func (o *InsightsOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return o.ListLogGroups(ctx)
+}
+
+// savedOverAny returns whether a query definition is saved over any of the selected log groups
+func savedOverAny(logGroups []string, selected map[string]bool) bool {
+	for _, group := range logGroups {
+		if selected[group] {
+			return true
+		}
+	}
+	return false
+}
+
+// getClient creates a new CloudWatch Logs client.
+func getClient(ctx context.Context, profile, region string) (*cloudwatchlogs.Client, error) {
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithSharedConfigProfile(profile),
+		config.WithRegion(region),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLoadConfig, err)
+	}
+
+	return cloudwatchlogs.NewFromConfig(cfg), nil
+}
@@ -0,0 +1,42 @@
+package cloudwatch
+
+import (
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+)
+
+// Service represents the CloudWatch service.
+type Service struct {
+	profile    string
+	region     string
+	categories []cloud.Category
+}
+
+// NewService creates a new CloudWatch service.
+func NewService(profile, region string) *Service {
+	service := &Service{
+		profile:    profile,
+		region:     region,
+		categories: make([]cloud.Category, 0),
+	}
+
+	// Register categories
+	service.categories = append(service.categories, NewLogsCategory(profile, region))
+	service.categories = append(service.categories, NewAlarmsCategory(profile, region))
+
+	return service
+}
+
+// Name returns the service's name.
+func (s *Service) Name() string {
+	return "CloudWatch"
+}
+
+// Description returns the service's description.
+func (s *Service) Description() string {
+	return "Logs and Monitoring"
+}
+
+// Categories returns all available categories for this service.
+func (s *Service) Categories() []cloud.Category {
+	return s.categories
+}
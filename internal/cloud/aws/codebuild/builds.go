@@ -0,0 +1,321 @@
+package codebuild
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	logstypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/aws/aws-sdk-go-v2/service/codebuild"
+	"github.com/aws/aws-sdk-go-v2/service/codebuild/types"
+)
+
+const (
+	// maxBatchGet is the most projects or builds BatchGetProjects and BatchGetBuilds accept in one request.
+	maxBatchGet = 100
+
+	// maxRecentBuilds is how many of the latest builds of a project are listed.
+	maxRecentBuilds = 20
+)
+
+// Common errors.
+var (
+	ErrLoadConfig    = errors.New("failed to load AWS config")
+	ErrListProjects  = errors.New("failed to list projects")
+	ErrListBuilds    = errors.New("failed to list builds")
+	ErrGetBuild      = errors.New("failed to get build")
+	ErrBuildNotFound = errors.New("build not found")
+	ErrStartBuild    = errors.New("failed to start build")
+	ErrGetBuildLogs  = errors.New("failed to get build logs")
+)
+
+// BuildOperation represents an operation to browse CodeBuild projects and builds, start builds
+// and follow their logs.
+type BuildOperation struct {
+	profile string
+	region  string
+}
+
+// NewBuildOperation creates a new build operation.
+func NewBuildOperation(profile, region string) *BuildOperation {
+	return &BuildOperation{
+		profile: profile,
+		region:  region,
+	}
+}
+
+// Name returns the operation's name.
+func (o *BuildOperation) Name() string {
+	return "Manage Builds"
+}
+
+// Description returns the operation's description.
+func (o *BuildOperation) Description() string {
+	return "Browse Projects, Start and Watch Builds"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+func (o *BuildOperation) IsUIVisible() bool {
+	return true
+}
+
+// ListProjects returns the projects of the account, in name order.
+func (o *BuildOperation) ListProjects(ctx context.Context) ([]cloud.BuildProject, error) {
+	cfg, err := loadConfig(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+	client := codebuild.NewFromConfig(cfg)
+
+	var names []string
+	paginator := codebuild.NewListProjectsPaginator(client, &codebuild.ListProjectsInput{
+		SortBy:    types.ProjectSortByTypeName,
+		SortOrder: types.SortOrderTypeAscending,
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrListProjects, err)
+		}
+		names = append(names, output.Projects...)
+	}
+
+	var projects []cloud.BuildProject
+	for start := 0; start < len(names); start += maxBatchGet {
+		output, err := client.BatchGetProjects(ctx, &codebuild.BatchGetProjectsInput{
+			Names: names[start:min(start+maxBatchGet, len(names))],
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrListProjects, err)
+		}
+		for _, project := range output.Projects {
+			converted := cloud.BuildProject{
+				Name:          aws.ToString(project.Name),
+				ARN:           aws.ToString(project.Arn),
+				Description:   aws.ToString(project.Description),
+				SourceVersion: aws.ToString(project.SourceVersion),
+				LastModified:  aws.ToTime(project.LastModified),
+			}
+			if project.Source != nil {
+				converted.SourceType = string(project.Source.Type)
+				converted.SourceLocation = aws.ToString(project.Source.Location)
+			}
+			projects = append(projects, converted)
+		}
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Name < projects[j].Name
+	})
+	return projects, nil
+}
+
+// ListBuilds returns the latest builds of a project with their phases, newest first.
+func (o *BuildOperation) ListBuilds(ctx context.Context, project string) ([]cloud.Build, error) {
+	cfg, err := loadConfig(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+	client := codebuild.NewFromConfig(cfg)
+
+	// The first page holds the latest builds
+	output, err := client.ListBuildsForProject(ctx, &codebuild.ListBuildsForProjectInput{
+		ProjectName: aws.String(project),
+		SortOrder:   types.SortOrderTypeDescending,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrListBuilds, err)
+	}
+	ids := output.Ids[:min(maxRecentBuilds, len(output.Ids))]
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	builds, err := getBuilds(ctx, client, ids)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrListBuilds, err)
+	}
+	return builds, nil
+}
+
+// GetBuild returns a build with its phases.
+func (o *BuildOperation) GetBuild(ctx context.Context, id string) (*cloud.Build, error) {
+	cfg, err := loadConfig(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	builds, err := getBuilds(ctx, codebuild.NewFromConfig(cfg), []string{id})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetBuild, err)
+	}
+	if len(builds) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrBuildNotFound, id)
+	}
+	return &builds[0], nil
+}
+
+// StartBuild starts a build of a project, overriding its source version and environment
+// variables for this build only, and returns it.
+func (o *BuildOperation) StartBuild(ctx context.Context, start cloud.BuildStart) (*cloud.Build, error) {
+	cfg, err := loadConfig(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+	client := codebuild.NewFromConfig(cfg)
+
+	input := &codebuild.StartBuildInput{
+		ProjectName: aws.String(start.Project),
+	}
+	if start.SourceVersion != "" {
+		input.SourceVersion = aws.String(start.SourceVersion)
+	}
+	for _, variable := range start.Variables {
+		input.EnvironmentVariablesOverride = append(input.EnvironmentVariablesOverride, types.EnvironmentVariable{
+			Name:  aws.String(variable.Name),
+			Value: aws.String(variable.Value),
+			Type:  types.EnvironmentVariableTypePlaintext,
+		})
+	}
+
+	output, err := client.StartBuild(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStartBuild, err)
+	}
+	build := convertBuild(*output.Build)
+	return &build, nil
+}
+
+// GetBuildLogs returns the lines a build logged to CloudWatch Logs after a token, or from the
+// start for an empty token. Builds that haven't started logging yet have no lines.
+func (o *BuildOperation) GetBuildLogs(ctx context.Context, build cloud.Build, token string) (*cloud.BuildLogs, error) {
+	logs := &cloud.BuildLogs{NextToken: token}
+	if build.LogGroup == "" || build.LogStream == "" {
+		return logs, nil
+	}
+
+	cfg, err := loadConfig(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+	client := cloudwatchlogs.NewFromConfig(cfg)
+
+	// Read until the token stops moving, which is how the end of the stream is reported
+	for {
+		input := &cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(build.LogGroup),
+			LogStreamName: aws.String(build.LogStream),
+			StartFromHead: aws.Bool(true),
+		}
+		if logs.NextToken != "" {
+			input.NextToken = aws.String(logs.NextToken)
+		}
+
+		output, err := client.GetLogEvents(ctx, input)
+		var notFound *logstypes.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			// The stream is created with the first line the build logs
+			return logs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrGetBuildLogs, err)
+		}
+
+		for _, event := range output.Events {
+			logs.Events = append(logs.Events, cloud.BuildLogEvent{
+				Timestamp: time.UnixMilli(aws.ToInt64(event.Timestamp)),
+				Message:   aws.ToString(event.Message),
+			})
+		}
+
+		next := aws.ToString(output.NextForwardToken)
+		if next == "" || next == logs.NextToken {
+			return logs, nil
+		}
+		logs.NextToken = next
+	}
+}
+
+// Execute executes the operation with the given parameters.
+func (o *BuildOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return o.ListProjects(ctx)
+}
+
+// getBuilds returns builds by ID, in the order of the IDs
+func getBuilds(ctx context.Context, client *codebuild.Client, ids []string) ([]cloud.Build, error) {
+	byID := make(map[string]cloud.Build, len(ids))
+	for start := 0; start < len(ids); start += maxBatchGet {
+		output, err := client.BatchGetBuilds(ctx, &codebuild.BatchGetBuildsInput{
+			Ids: ids[start:min(start+maxBatchGet, len(ids))],
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, build := range output.Builds {
+			byID[aws.ToString(build.Id)] = convertBuild(build)
+		}
+	}
+
+	builds := make([]cloud.Build, 0, len(byID))
+	for _, id := range ids {
+		if build, ok := byID[id]; ok {
+			builds = append(builds, build)
+		}
+	}
+	return builds, nil
+}
+
+// convertBuild converts a build and its phases
+func convertBuild(build types.Build) cloud.Build {
+	result := cloud.Build{
+		ID:                    aws.ToString(build.Id),
+		Number:                aws.ToInt64(build.BuildNumber),
+		Project:               aws.ToString(build.ProjectName),
+		Status:                string(build.BuildStatus),
+		CurrentPhase:          aws.ToString(build.CurrentPhase),
+		SourceVersion:         aws.ToString(build.SourceVersion),
+		ResolvedSourceVersion: aws.ToString(build.ResolvedSourceVersion),
+		Initiator:             aws.ToString(build.Initiator),
+		StartTime:             aws.ToTime(build.StartTime),
+		EndTime:               aws.ToTime(build.EndTime),
+	}
+	if build.Logs != nil {
+		result.LogGroup = aws.ToString(build.Logs.GroupName)
+		result.LogStream = aws.ToString(build.Logs.StreamName)
+	}
+
+	for _, phase := range build.Phases {
+		converted := cloud.BuildPhase{
+			Type:      string(phase.PhaseType),
+			Status:    string(phase.PhaseStatus),
+			StartTime: aws.ToTime(phase.StartTime),
+			EndTime:   aws.ToTime(phase.EndTime),
+			Duration:  time.Duration(aws.ToInt64(phase.DurationInSeconds)) * time.Second,
+		}
+		for _, phaseContext := range phase.Contexts {
+			if message := aws.ToString(phaseContext.Message); message != "" {
+				converted.Message = message
+			}
+		}
+		result.Phases = append(result.Phases, converted)
+	}
+	return result
+}
+
+// loadConfig loads the AWS config shared by the CodeBuild and CloudWatch Logs clients.
+func loadConfig(ctx context.Context, profile, region string) (aws.Config, error) {
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithSharedConfigProfile(profile),
+		config.WithRegion(region),
+	)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("%w: %w", ErrLoadConfig, err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,46 @@
+package codebuild
+
+import (
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+)
+
+// BuildsCategory represents the CodeBuild builds category.
+type BuildsCategory struct {
+	profile    string
+	region     string
+	operations []cloud.Operation
+}
+
+// NewBuildsCategory creates a new CodeBuild builds category.
+func NewBuildsCategory(profile, region string) *BuildsCategory {
+	category := &BuildsCategory{
+		profile:    profile,
+		region:     region,
+		operations: make([]cloud.Operation, 0),
+	}
+
+	// Register operations
+	category.operations = append(category.operations, NewBuildOperation(profile, region))
+
+	return category
+}
+
+// Name returns the category's name.
+func (c *BuildsCategory) Name() string {
+	return "Builds"
+}
+
+// Description returns the category's description.
+func (c *BuildsCategory) Description() string {
+	return "CodeBuild Projects and Builds"
+}
+
+// Operations returns all available operations for this category.
+func (c *BuildsCategory) Operations() []cloud.Operation {
+	return c.operations
+}
+
+// IsUIVisible returns whether this category should be visible in the UI.
+func (c *BuildsCategory) IsUIVisible() bool {
+	return true
+}
@@ -0,0 +1,46 @@
+package ec2
+
+import (
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+)
+
+// InstancesCategory represents the EC2 instances category.
+type InstancesCategory struct {
+	profile    string
+	region     string
+	operations []cloud.Operation
+}
+
+// NewInstancesCategory creates a new EC2 instances category.
+func NewInstancesCategory(profile, region string) *InstancesCategory {
+	category := &InstancesCategory{
+		profile:    profile,
+		region:     region,
+		operations: make([]cloud.Operation, 0),
+	}
+
+	// Register operations
+	category.operations = append(category.operations, NewInstanceOperation(profile, region))
+
+	return category
+}
+
+// Name returns the category's name.
+func (c *InstancesCategory) Name() string {
+	return "Instances"
+}
+
+// Description returns the category's description.
+func (c *InstancesCategory) Description() string {
+	return "EC2 Instances"
+}
+
+// Operations returns all available operations for this category.
+func (c *InstancesCategory) Operations() []cloud.Operation {
+	return c.operations
+}
+
+// IsUIVisible returns whether this category should be visible in the UI.
+func (c *InstancesCategory) IsUIVisible() bool {
+	return true
+}
@@ -0,0 +1,336 @@
+package ec2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// Common errors.
+var (
+	ErrLoadConfig         = errors.New("failed to load AWS config")
+	ErrListInstances      = errors.New("failed to list instances")
+	ErrGetInstance        = errors.New("failed to get instance details")
+	ErrInstanceNotFound   = errors.New("instance not found")
+	ErrListVolumes        = errors.New("failed to list volumes")
+	ErrGetInstanceStatus  = errors.New("failed to get instance status")
+	ErrStartInstance      = errors.New("failed to start instance")
+	ErrStopInstance       = errors.New("failed to stop instance")
+	ErrRebootInstance     = errors.New("failed to reboot instance")
+	ErrInvalidInstanceTag = errors.New("a tag value needs a tag key")
+)
+
+// InstanceOperation represents an operation to list, inspect, start, stop and reboot instances.
+type InstanceOperation struct {
+	profile string
+	region  string
+}
+
+// NewInstanceOperation creates a new instance operation.
+func NewInstanceOperation(profile, region string) *InstanceOperation {
+	return &InstanceOperation{
+		profile: profile,
+		region:  region,
+	}
+}
+
+// Name returns the operation's name.
+func (o *InstanceOperation) Name() string {
+	return "Manage Instances"
+}
+
+// Description returns the operation's description.
+func (o *InstanceOperation) Description() string {
+	return "List, Start, Stop and Reboot Instances"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+func (o *InstanceOperation) IsUIVisible() bool {
+	return true
+}
+
+// ListInstances returns the instances matching a filter, in name order with unnamed instances
+// last. The filter is applied by EC2, so only matching instances are listed.
+func (o *InstanceOperation) ListInstances(ctx context.Context, filter cloud.EC2InstanceFilter) ([]cloud.EC2Instance, error) {
+	filters, err := instanceFilters(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	var instances []cloud.EC2Instance
+	paginator := ec2.NewDescribeInstancesPaginator(client, &ec2.DescribeInstancesInput{Filters: filters})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrListInstances, err)
+		}
+		for _, reservation := range output.Reservations {
+			for _, instance := range reservation.Instances {
+				instances = append(instances, toInstance(instance))
+			}
+		}
+	}
+
+	sort.Slice(instances, func(i, j int) bool {
+		a, b := instances[i], instances[j]
+		if (a.Name == "") != (b.Name == "") {
+			return a.Name != ""
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+	return instances, nil
+}
+
+// GetInstanceDetails returns an instance with its security groups, attached volumes and status
+// checks. Status checks are only reported by EC2 while the instance is running.
+func (o *InstanceOperation) GetInstanceDetails(ctx context.Context, instanceID string) (*cloud.EC2InstanceDetails, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+		InstanceIds: []string{instanceID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetInstance, err)
+	}
+	if len(output.Reservations) == 0 || len(output.Reservations[0].Instances) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInstanceNotFound, instanceID)
+	}
+	instance := output.Reservations[0].Instances[0]
+
+	details := &cloud.EC2InstanceDetails{
+		EC2Instance:  toInstance(instance),
+		ImageID:      aws.ToString(instance.ImageId),
+		Platform:     aws.ToString(instance.PlatformDetails),
+		Architecture: string(instance.Architecture),
+		KeyName:      aws.ToString(instance.KeyName),
+		VpcID:        aws.ToString(instance.VpcId),
+		SubnetID:     aws.ToString(instance.SubnetId),
+	}
+	if instance.IamInstanceProfile != nil {
+		details.InstanceProfile = aws.ToString(instance.IamInstanceProfile.Arn)
+	}
+	if instance.StateReason != nil {
+		details.StateReason = aws.ToString(instance.StateReason.Message)
+	}
+	for _, group := range instance.SecurityGroups {
+		details.SecurityGroups = append(details.SecurityGroups, cloud.EC2SecurityGroup{
+			ID:   aws.ToString(group.GroupId),
+			Name: aws.ToString(group.GroupName),
+		})
+	}
+
+	if details.Volumes, err = instanceVolumes(ctx, client, instance); err != nil {
+		return nil, err
+	}
+
+	status, err := client.DescribeInstanceStatus(ctx, &ec2.DescribeInstanceStatusInput{
+		InstanceIds:         []string{instanceID},
+		IncludeAllInstances: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetInstanceStatus, err)
+	}
+	details.SystemStatus = string(types.SummaryStatusNotApplicable)
+	details.InstanceStatus = string(types.SummaryStatusNotApplicable)
+	if len(status.InstanceStatuses) > 0 {
+		checks := status.InstanceStatuses[0]
+		if checks.SystemStatus != nil {
+			details.SystemStatus = string(checks.SystemStatus.Status)
+		}
+		if checks.InstanceStatus != nil {
+			details.InstanceStatus = string(checks.InstanceStatus.Status)
+		}
+	}
+
+	return details, nil
+}
+
+// StartInstance starts a stopped instance and returns the state it moved to.
+func (o *InstanceOperation) StartInstance(ctx context.Context, instanceID string) (string, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := client.StartInstances(ctx, &ec2.StartInstancesInput{
+		InstanceIds: []string{instanceID},
+	})
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrStartInstance, err)
+	}
+	return changedState(output.StartingInstances, cloud.EC2StatePending), nil
+}
+
+// StopInstance stops a running instance and returns the state it moved to.
+func (o *InstanceOperation) StopInstance(ctx context.Context, instanceID string) (string, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := client.StopInstances(ctx, &ec2.StopInstancesInput{
+		InstanceIds: []string{instanceID},
+	})
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrStopInstance, err)
+	}
+	return changedState(output.StoppingInstances, cloud.EC2StateStopping), nil
+}
+
+// RebootInstance reboots a running instance. The instance stays running while it reboots.
+func (o *InstanceOperation) RebootInstance(ctx context.Context, instanceID string) error {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.RebootInstances(ctx, &ec2.RebootInstancesInput{
+		InstanceIds: []string{instanceID},
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRebootInstance, err)
+	}
+	return nil
+}
+
+// Execute executes the operation with the given parameters.
+func (o *InstanceOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	filter, _ := params["filter"].(cloud.EC2InstanceFilter)
+	return o.ListInstances(ctx, filter)
+}
+
+// instanceFilters returns the EC2 filters of an instance filter. A tag key alone matches any
+// instance with the tag, whatever its value.
+func instanceFilters(filter cloud.EC2InstanceFilter) ([]types.Filter, error) {
+	var filters []types.Filter
+	if filter.State != "" {
+		filters = append(filters, types.Filter{
+			Name:   aws.String("instance-state-name"),
+			Values: []string{filter.State},
+		})
+	}
+
+	switch {
+	case filter.TagKey != "" && filter.TagValue != "":
+		filters = append(filters, types.Filter{
+			Name:   aws.String("tag:" + filter.TagKey),
+			Values: []string{filter.TagValue},
+		})
+	case filter.TagKey != "":
+		filters = append(filters, types.Filter{
+			Name:   aws.String("tag-key"),
+			Values: []string{filter.TagKey},
+		})
+	case filter.TagValue != "":
+		return nil, ErrInvalidInstanceTag
+	}
+	return filters, nil
+}
+
+// instanceVolumes returns the EBS volumes attached to an instance, in device order
+func instanceVolumes(ctx context.Context, client *ec2.Client, instance types.Instance) ([]cloud.EC2Volume, error) {
+	devices := make(map[string]types.InstanceBlockDeviceMapping)
+	var volumeIDs []string
+	for _, mapping := range instance.BlockDeviceMappings {
+		if mapping.Ebs == nil {
+			continue
+		}
+		id := aws.ToString(mapping.Ebs.VolumeId)
+		devices[id] = mapping
+		volumeIDs = append(volumeIDs, id)
+	}
+	// Instances backed by instance store have no volumes to describe
+	if len(volumeIDs) == 0 {
+		return nil, nil
+	}
+
+	output, err := client.DescribeVolumes(ctx, &ec2.DescribeVolumesInput{
+		VolumeIds: volumeIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrListVolumes, err)
+	}
+
+	volumes := make([]cloud.EC2Volume, 0, len(output.Volumes))
+	for _, volume := range output.Volumes {
+		id := aws.ToString(volume.VolumeId)
+		mapping := devices[id]
+		volumes = append(volumes, cloud.EC2Volume{
+			ID:                  id,
+			Device:              aws.ToString(mapping.DeviceName),
+			Size:                aws.ToInt32(volume.Size),
+			Type:                string(volume.VolumeType),
+			State:               string(volume.State),
+			Encrypted:           aws.ToBool(volume.Encrypted),
+			DeleteOnTermination: aws.ToBool(mapping.Ebs.DeleteOnTermination),
+		})
+	}
+
+	sort.Slice(volumes, func(i, j int) bool {
+		return volumes[i].Device < volumes[j].Device
+	})
+	return volumes, nil
+}
+
+// toInstance converts an EC2 instance to the instance shown in the instance list
+func toInstance(instance types.Instance) cloud.EC2Instance {
+	result := cloud.EC2Instance{
+		ID:         aws.ToString(instance.InstanceId),
+		Type:       string(instance.InstanceType),
+		PrivateIP:  aws.ToString(instance.PrivateIpAddress),
+		PublicIP:   aws.ToString(instance.PublicIpAddress),
+		LaunchTime: aws.ToTime(instance.LaunchTime),
+		Tags:       make(map[string]string),
+	}
+	if instance.State != nil {
+		result.State = string(instance.State.Name)
+	}
+	if instance.Placement != nil {
+		result.AvailabilityZone = aws.ToString(instance.Placement.AvailabilityZone)
+	}
+	for _, tag := range instance.Tags {
+		result.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	result.Name = result.Tags["Name"]
+	return result
+}
+
+// changedState returns the state an instance moved to, or the state it's expected to move to
+// when EC2 doesn't say
+func changedState(changes []types.InstanceStateChange, expected string) string {
+	if len(changes) == 0 || changes[0].CurrentState == nil {
+		return expected
+	}
+	return string(changes[0].CurrentState.Name)
+}
+
+// getClient creates a new EC2 client.
+func getClient(ctx context.Context, profile, region string) (*ec2.Client, error) {
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithSharedConfigProfile(profile),
+		config.WithRegion(region),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLoadConfig, err)
+	}
+
+	return ec2.NewFromConfig(cfg), nil
+}
@@ -0,0 +1,41 @@
+package ec2
+
+import (
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+)
+
+// Service represents the EC2 service.
+type Service struct {
+	profile    string
+	region     string
+	categories []cloud.Category
+}
+
+// NewService creates a new EC2 service.
+func NewService(profile, region string) *Service {
+	service := &Service{
+		profile:    profile,
+		region:     region,
+		categories: make([]cloud.Category, 0),
+	}
+
+	// Register categories
+	service.categories = append(service.categories, NewInstancesCategory(profile, region))
+
+	return service
+}
+
+// Name returns the service's name.
+func (s *Service) Name() string {
+	return "EC2"
+}
+
+// Description returns the service's description.
+func (s *Service) Description() string {
+	return "Elastic Compute Cloud"
+}
+
+// Categories returns all available categories for this service.
+func (s *Service) Categories() []cloud.Category {
+	return s.categories
+}
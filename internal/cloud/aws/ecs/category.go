@@ -0,0 +1,46 @@
+package ecs
+
+import (
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+)
+
+// ClustersCategory represents the ECS clusters category.
+type ClustersCategory struct {
+	profile    string
+	region     string
+	operations []cloud.Operation
+}
+
+// NewClustersCategory creates a new ECS clusters category.
+func NewClustersCategory(profile, region string) *ClustersCategory {
+	category := &ClustersCategory{
+		profile:    profile,
+		region:     region,
+		operations: make([]cloud.Operation, 0),
+	}
+
+	// Register operations
+	category.operations = append(category.operations, NewServiceOperation(profile, region))
+
+	return category
+}
+
+// Name returns the category's name.
+func (c *ClustersCategory) Name() string {
+	return "Clusters"
+}
+
+// Description returns the category's description.
+func (c *ClustersCategory) Description() string {
+	return "ECS Clusters and Services"
+}
+
+// Operations returns all available operations for this category.
+func (c *ClustersCategory) Operations() []cloud.Operation {
+	return c.operations
+}
+
+// IsUIVisible returns whether this category should be visible in the UI.
+func (c *ClustersCategory) IsUIVisible() bool {
+	return true
+}
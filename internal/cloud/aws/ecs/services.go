@@ -0,0 +1,341 @@
+package ecs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+const (
+	// maxDescribeClusters is the most clusters DescribeClusters accepts in one request.
+	maxDescribeClusters = 100
+
+	// maxDescribeServices is the most services DescribeServices accepts in one request.
+	maxDescribeServices = 10
+
+	// maxDescribeTasks is the most tasks DescribeTasks accepts in one request.
+	maxDescribeTasks = 100
+
+	// maxServiceEvents is how many of the latest events are kept for each service.
+	maxServiceEvents = 10
+)
+
+// Common errors.
+var (
+	ErrLoadConfig         = errors.New("failed to load AWS config")
+	ErrListClusters       = errors.New("failed to list clusters")
+	ErrListServices       = errors.New("failed to list services")
+	ErrListTasks          = errors.New("failed to list tasks")
+	ErrForceDeployment    = errors.New("failed to force a new deployment")
+	ErrUpdateDesiredCount = errors.New("failed to update desired count")
+)
+
+// ServiceOperation represents an operation to browse clusters, services and tasks, and to
+// redeploy and scale services.
+type ServiceOperation struct {
+	profile string
+	region  string
+}
+
+// NewServiceOperation creates a new service operation.
+func NewServiceOperation(profile, region string) *ServiceOperation {
+	return &ServiceOperation{
+		profile: profile,
+		region:  region,
+	}
+}
+
+// Name returns the operation's name.
+func (o *ServiceOperation) Name() string {
+	return "Manage Services"
+}
+
+// Description returns the operation's description.
+func (o *ServiceOperation) Description() string {
+	return "Browse Clusters, Services and Tasks"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+func (o *ServiceOperation) IsUIVisible() bool {
+	return true
+}
+
+// ListClusters returns the clusters of the account with their service and task counts, in name order.
+func (o *ServiceOperation) ListClusters(ctx context.Context) ([]cloud.ECSCluster, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	var arns []string
+	paginator := ecs.NewListClustersPaginator(client, &ecs.ListClustersInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrListClusters, err)
+		}
+		arns = append(arns, output.ClusterArns...)
+	}
+
+	var clusters []cloud.ECSCluster
+	for _, batch := range batches(arns, maxDescribeClusters) {
+		output, err := client.DescribeClusters(ctx, &ecs.DescribeClustersInput{Clusters: batch})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrListClusters, err)
+		}
+		for _, cluster := range output.Clusters {
+			clusters = append(clusters, cloud.ECSCluster{
+				Name:           aws.ToString(cluster.ClusterName),
+				ARN:            aws.ToString(cluster.ClusterArn),
+				Status:         aws.ToString(cluster.Status),
+				ActiveServices: cluster.ActiveServicesCount,
+				RunningTasks:   cluster.RunningTasksCount,
+				PendingTasks:   cluster.PendingTasksCount,
+			})
+		}
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Name < clusters[j].Name
+	})
+	return clusters, nil
+}
+
+// ListServices returns the services of a cluster with their task counts, deployments and latest
+// events, in name order.
+func (o *ServiceOperation) ListServices(ctx context.Context, cluster string) ([]cloud.ECSService, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	var arns []string
+	paginator := ecs.NewListServicesPaginator(client, &ecs.ListServicesInput{Cluster: aws.String(cluster)})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrListServices, err)
+		}
+		arns = append(arns, output.ServiceArns...)
+	}
+
+	var services []cloud.ECSService
+	for _, batch := range batches(arns, maxDescribeServices) {
+		output, err := client.DescribeServices(ctx, &ecs.DescribeServicesInput{
+			Cluster:  aws.String(cluster),
+			Services: batch,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrListServices, err)
+		}
+		for _, service := range output.Services {
+			services = append(services, convertService(cluster, service))
+		}
+	}
+
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Name < services[j].Name
+	})
+	return services, nil
+}
+
+// ListTasks returns the running tasks of a service, newest first, followed by the tasks that
+// stopped recently. ECS only keeps stopped tasks for about an hour.
+func (o *ServiceOperation) ListTasks(ctx context.Context, cluster, service string) ([]cloud.ECSTask, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []cloud.ECSTask
+	for _, desiredStatus := range []types.DesiredStatus{types.DesiredStatusRunning, types.DesiredStatusStopped} {
+		var arns []string
+		paginator := ecs.NewListTasksPaginator(client, &ecs.ListTasksInput{
+			Cluster:       aws.String(cluster),
+			ServiceName:   aws.String(service),
+			DesiredStatus: desiredStatus,
+		})
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrListTasks, err)
+			}
+			arns = append(arns, output.TaskArns...)
+		}
+
+		var described []cloud.ECSTask
+		for _, batch := range batches(arns, maxDescribeTasks) {
+			output, err := client.DescribeTasks(ctx, &ecs.DescribeTasksInput{
+				Cluster: aws.String(cluster),
+				Tasks:   batch,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrListTasks, err)
+			}
+			for _, task := range output.Tasks {
+				described = append(described, convertTask(task))
+			}
+		}
+
+		sort.Slice(described, func(i, j int) bool {
+			if !described[i].StoppedAt.Equal(described[j].StoppedAt) {
+				return described[i].StoppedAt.After(described[j].StoppedAt)
+			}
+			return described[i].StartedAt.After(described[j].StartedAt)
+		})
+		tasks = append(tasks, described...)
+	}
+	return tasks, nil
+}
+
+// ForceNewDeployment starts a deployment of a service that replaces its tasks with new ones
+// running the same task definition, such as to pick up an image pushed to the same tag.
+func (o *ServiceOperation) ForceNewDeployment(ctx context.Context, cluster, service string) error {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.UpdateService(ctx, &ecs.UpdateServiceInput{
+		Cluster:            aws.String(cluster),
+		Service:            aws.String(service),
+		ForceNewDeployment: true,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrForceDeployment, err)
+	}
+	return nil
+}
+
+// SetDesiredCount changes how many tasks of a service should run.
+func (o *ServiceOperation) SetDesiredCount(ctx context.Context, cluster, service string, count int32) error {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.UpdateService(ctx, &ecs.UpdateServiceInput{
+		Cluster:      aws.String(cluster),
+		Service:      aws.String(service),
+		DesiredCount: aws.Int32(count),
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUpdateDesiredCount, err)
+	}
+	return nil
+}
+
+// Execute executes the operation with the given parameters.
+func (o *ServiceOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return o.ListClusters(ctx)
+}
+
+// convertService converts a described service, keeping its primary deployment first and its
+// latest events
+func convertService(cluster string, service types.Service) cloud.ECSService {
+	result := cloud.ECSService{
+		Name:           aws.ToString(service.ServiceName),
+		ARN:            aws.ToString(service.ServiceArn),
+		Cluster:        cluster,
+		Status:         aws.ToString(service.Status),
+		LaunchType:     string(service.LaunchType),
+		TaskDefinition: shortName(aws.ToString(service.TaskDefinition)),
+		DesiredCount:   service.DesiredCount,
+		RunningCount:   service.RunningCount,
+		PendingCount:   service.PendingCount,
+	}
+
+	for _, deployment := range service.Deployments {
+		result.Deployments = append(result.Deployments, cloud.ECSDeployment{
+			ID:                 aws.ToString(deployment.Id),
+			Status:             aws.ToString(deployment.Status),
+			TaskDefinition:     shortName(aws.ToString(deployment.TaskDefinition)),
+			RolloutState:       string(deployment.RolloutState),
+			RolloutStateReason: aws.ToString(deployment.RolloutStateReason),
+			DesiredCount:       deployment.DesiredCount,
+			RunningCount:       deployment.RunningCount,
+			PendingCount:       deployment.PendingCount,
+			FailedTasks:        deployment.FailedTasks,
+			CreatedAt:          aws.ToTime(deployment.CreatedAt),
+			UpdatedAt:          aws.ToTime(deployment.UpdatedAt),
+		})
+	}
+	sort.SliceStable(result.Deployments, func(i, j int) bool {
+		return result.Deployments[i].Status == cloud.ECSPrimaryDeployment && result.Deployments[j].Status != cloud.ECSPrimaryDeployment
+	})
+
+	// Events are listed newest first
+	for i, event := range service.Events {
+		if i == maxServiceEvents {
+			break
+		}
+		result.Events = append(result.Events, cloud.ECSServiceEvent{
+			CreatedAt: aws.ToTime(event.CreatedAt),
+			Message:   aws.ToString(event.Message),
+		})
+	}
+	return result
+}
+
+// convertTask converts a described task and its containers
+func convertTask(task types.Task) cloud.ECSTask {
+	result := cloud.ECSTask{
+		ID:             shortName(aws.ToString(task.TaskArn)),
+		ARN:            aws.ToString(task.TaskArn),
+		TaskDefinition: shortName(aws.ToString(task.TaskDefinitionArn)),
+		LastStatus:     aws.ToString(task.LastStatus),
+		DesiredStatus:  aws.ToString(task.DesiredStatus),
+		HealthStatus:   string(task.HealthStatus),
+		StartedAt:      aws.ToTime(task.StartedAt),
+		StoppedAt:      aws.ToTime(task.StoppedAt),
+		StopCode:       string(task.StopCode),
+		StoppedReason:  aws.ToString(task.StoppedReason),
+	}
+	for _, container := range task.Containers {
+		result.Containers = append(result.Containers, cloud.ECSContainer{
+			Name:         aws.ToString(container.Name),
+			Image:        aws.ToString(container.Image),
+			LastStatus:   aws.ToString(container.LastStatus),
+			HealthStatus: string(container.HealthStatus),
+			ExitCode:     container.ExitCode,
+			Reason:       aws.ToString(container.Reason),
+		})
+	}
+	return result
+}
+
+// shortName returns the last part of an ARN, such as the task ID of a task ARN or the
+// family:revision of a task definition ARN
+func shortName(arn string) string {
+	return arn[strings.LastIndex(arn, "/")+1:]
+}
+
+// batches splits values into batches of up to size values
+func batches(values []string, size int) [][]string {
+	var result [][]string
+	for start := 0; start < len(values); start += size {
+		result = append(result, values[start:min(start+size, len(values))])
+	}
+	return result
+}
+
+// getClient creates a new ECS client.
+func getClient(ctx context.Context, profile, region string) (*ecs.Client, error) {
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithSharedConfigProfile(profile),
+		config.WithRegion(region),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLoadConfig, err)
+	}
+
+	return ecs.NewFromConfig(cfg), nil
+}
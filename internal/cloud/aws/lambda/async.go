@@ -0,0 +1,241 @@
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	awssqs "github.com/HenryOwenz/cloudgate/internal/cloud/aws/sqs"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// Asynchronous invocation errors.
+var (
+	ErrGetAsyncConfig   = errors.New("failed to get asynchronous invocation config")
+	ErrInvalidQueueArn  = errors.New("not an SQS queue ARN")
+	ErrPeekFailedEvents = errors.New("failed to read failed events")
+	ErrRedriveEvent     = errors.New("failed to re-invoke function")
+)
+
+const (
+	// defaultMaxRetryAttempts and defaultMaxEventAge apply to functions without an event invoke config.
+	defaultMaxRetryAttempts = 2
+	defaultMaxEventAge      = 6 * 60 * 60
+)
+
+// AsyncInvokeOperation represents an operation to inspect a function's asynchronous invocations
+// and replay the events that failed.
+type AsyncInvokeOperation struct {
+	profile string
+	region  string
+}
+
+// NewAsyncInvokeOperation creates a new asynchronous invocation operation.
+func NewAsyncInvokeOperation(profile, region string) *AsyncInvokeOperation {
+	return &AsyncInvokeOperation{
+		profile: profile,
+		region:  region,
+	}
+}
+
+// Name returns the operation's name.
+func (o *AsyncInvokeOperation) Name() string {
+	return "Async Invocations"
+}
+
+// Description returns the operation's description.
+func (o *AsyncInvokeOperation) Description() string {
+	return "Inspect and Replay Failed Async Events"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+// Asynchronous invocations are inspected from the function details view rather than as a separate operation.
+func (o *AsyncInvokeOperation) IsUIVisible() bool {
+	return false
+}
+
+// GetAsyncInvokeConfig returns the retry settings and destinations of a function, along with its
+// dead-letter queue. Functions without an event invoke config get the Lambda defaults.
+func (o *AsyncInvokeOperation) GetAsyncInvokeConfig(ctx context.Context, functionName string) (*cloud.AsyncInvokeConfig, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	function, err := client.GetFunctionConfiguration(ctx, &lambda.GetFunctionConfigurationInput{
+		FunctionName: aws.String(functionName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetFunction, err)
+	}
+
+	asyncConfig := &cloud.AsyncInvokeConfig{
+		FunctionName:     functionName,
+		MaxRetryAttempts: defaultMaxRetryAttempts,
+		MaxEventAge:      defaultMaxEventAge,
+	}
+	if function.DeadLetterConfig != nil {
+		asyncConfig.DeadLetterQueue = aws.ToString(function.DeadLetterConfig.TargetArn)
+	}
+
+	output, err := client.GetFunctionEventInvokeConfig(ctx, &lambda.GetFunctionEventInvokeConfigInput{
+		FunctionName: aws.String(functionName),
+	})
+	var notFound *types.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		asyncConfig.IsDefault = true
+		return asyncConfig, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetAsyncConfig, err)
+	}
+
+	if output.MaximumRetryAttempts != nil {
+		asyncConfig.MaxRetryAttempts = *output.MaximumRetryAttempts
+	}
+	if output.MaximumEventAgeInSeconds != nil {
+		asyncConfig.MaxEventAge = *output.MaximumEventAgeInSeconds
+	}
+	if destinations := output.DestinationConfig; destinations != nil {
+		if destinations.OnSuccess != nil {
+			asyncConfig.OnSuccess = aws.ToString(destinations.OnSuccess.Destination)
+		}
+		if destinations.OnFailure != nil {
+			asyncConfig.OnFailure = aws.ToString(destinations.OnFailure.Destination)
+		}
+	}
+
+	return asyncConfig, nil
+}
+
+// PeekFailedEvents returns up to limit failed events from an SQS queue, oldest first as SQS returns
+// them. Messages are made visible again afterwards by awssqs.Peek, so they stay in the queue for
+// their consumers.
+func (o *AsyncInvokeOperation) PeekFailedEvents(ctx context.Context, queueArn string, limit int) ([]cloud.FailedEvent, error) {
+	// arn:aws:sqs:region:account:name
+	parts := strings.Split(queueArn, ":")
+	if len(parts) != 6 || parts[2] != "sqs" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidQueueArn, queueArn)
+	}
+	region, account, queueName := parts[3], parts[4], parts[5]
+
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithSharedConfigProfile(o.profile),
+		config.WithRegion(region),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLoadConfig, err)
+	}
+	client := sqs.NewFromConfig(cfg)
+
+	queue, err := client.GetQueueUrl(ctx, &sqs.GetQueueUrlInput{
+		QueueName:              aws.String(queueName),
+		QueueOwnerAWSAccountId: aws.String(account),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPeekFailedEvents, err)
+	}
+
+	received, err := awssqs.Peek(ctx, client, aws.ToString(queue.QueueUrl), limit)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPeekFailedEvents, err)
+	}
+
+	events := make([]cloud.FailedEvent, 0, len(received))
+	for _, message := range received {
+		events = append(events, toFailedEvent(queueArn, message))
+	}
+	return events, nil
+}
+
+// RedriveEvent invokes a function asynchronously with the payload of a failed event, so the
+// replay is retried and routed to destinations like the original invocation.
+func (o *AsyncInvokeOperation) RedriveEvent(ctx context.Context, functionName string, event cloud.FailedEvent) error {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Invoke(ctx, &lambda.InvokeInput{
+		FunctionName:   aws.String(functionName),
+		InvocationType: types.InvocationTypeEvent,
+		Payload:        []byte(event.Payload),
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRedriveEvent, err)
+	}
+	return nil
+}
+
+// Execute executes the operation with the given parameters.
+func (o *AsyncInvokeOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	functionName, ok := params["functionName"].(string)
+	if !ok {
+		return nil, fmt.Errorf("function name is required")
+	}
+
+	return o.GetAsyncInvokeConfig(ctx, functionName)
+}
+
+// destinationRecord is the record Lambda sends to an on-failure destination.
+type destinationRecord struct {
+	RequestContext struct {
+		RequestID string `json:"requestId"`
+		Condition string `json:"condition"`
+	} `json:"requestContext"`
+	RequestPayload  json.RawMessage `json:"requestPayload"`
+	ResponsePayload json.RawMessage `json:"responsePayload"`
+}
+
+// toFailedEvent converts a message from a failure queue. On-failure destinations receive a record
+// wrapping the event, while dead-letter queues receive the event itself with the error in the
+// message attributes.
+func toFailedEvent(queueArn string, message sqstypes.Message) cloud.FailedEvent {
+	event := cloud.FailedEvent{
+		MessageID: aws.ToString(message.MessageId),
+		QueueArn:  queueArn,
+		Payload:   aws.ToString(message.Body),
+	}
+	if sent, err := strconv.ParseInt(message.Attributes[string(sqstypes.MessageSystemAttributeNameSentTimestamp)], 10, 64); err == nil {
+		event.SentAt = time.UnixMilli(sent).UTC()
+	}
+	event.ReceiveCount, _ = strconv.Atoi(message.Attributes[string(sqstypes.MessageSystemAttributeNameApproximateReceiveCount)])
+
+	var record destinationRecord
+	if err := json.Unmarshal([]byte(event.Payload), &record); err == nil && record.RequestContext.RequestID != "" {
+		event.RequestID = record.RequestContext.RequestID
+		event.Condition = record.RequestContext.Condition
+		event.Payload = string(record.RequestPayload)
+
+		var response struct {
+			ErrorMessage string `json:"errorMessage"`
+		}
+		if err := json.Unmarshal(record.ResponsePayload, &response); err == nil {
+			event.ErrorMessage = response.ErrorMessage
+		}
+		return event
+	}
+
+	event.RequestID = messageAttribute(message, "RequestID")
+	event.Condition = messageAttribute(message, "ErrorCode")
+	event.ErrorMessage = messageAttribute(message, "ErrorMessage")
+	return event
+}
+
+// messageAttribute returns the string value of a message attribute, or "" if it isn't set.
+func messageAttribute(message sqstypes.Message, name string) string {
+	if attribute, ok := message.MessageAttributes[name]; ok {
+		return aws.ToString(attribute.StringValue)
+	}
+	return ""
+}
@@ -22,6 +22,14 @@ func NewWorkflowsCategory(profile, region string) *WorkflowsCategory {
 	// Register operations
 	category.operations = append(category.operations, NewFunctionStatusOperation(profile, region))
 	category.operations = append(category.operations, NewLambdaExecuteOperation(profile, region))
+	category.operations = append(category.operations, NewFunctionConfigurationOperation(profile, region))
+	category.operations = append(category.operations, NewDeployCodeOperation(profile, region))
+	category.operations = append(category.operations, NewFunctionMetricsOperation(profile, region))
+	category.operations = append(category.operations, NewEventSourceMappingOperation(profile, region))
+	category.operations = append(category.operations, NewHygieneReportOperation(profile, region))
+	category.operations = append(category.operations, NewFunctionPackageOperation(profile, region))
+	category.operations = append(category.operations, NewFunctionSecurityOperation(profile, region))
+	category.operations = append(category.operations, NewAsyncInvokeOperation(profile, region))
 
 	return category
 }
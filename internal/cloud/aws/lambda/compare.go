@@ -0,0 +1,121 @@
+package lambda
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+)
+
+// Comparison errors.
+var (
+	ErrCompareFunction = errors.New("failed to compare function")
+	ErrListAliases     = errors.New("failed to list aliases")
+)
+
+// envHashLength is how many hex digits of an environment variable's hash are shown, enough to
+// tell values apart without making them easy to guess.
+const envHashLength = 12
+
+// CompareFunction looks a function up in each target of the options, concurrently, and lines up
+// its configuration. Targets the function can't be loaded from are reported in their snapshot,
+// unless none can be.
+func (o *FunctionStatusOperation) CompareFunction(ctx context.Context, functionName string, options cloud.FunctionCompareOptions) (*cloud.FunctionComparison, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]cloud.FunctionSnapshot, len(options.Targets))
+	forEachConcurrently(len(options.Targets), func(i int) {
+		snapshots[i] = snapshotFunction(ctx, functionName, options.Targets[i], options.HashEnvValues)
+	})
+
+	var errs []string
+	for _, snapshot := range snapshots {
+		if snapshot.Status == nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", snapshot.Target, snapshot.Error))
+		}
+	}
+	if len(errs) == len(snapshots) {
+		return nil, fmt.Errorf("%w: %s", ErrCompareFunction, strings.Join(errs, "; "))
+	}
+
+	return cloud.NewFunctionComparison(functionName, snapshots), nil
+}
+
+// snapshotFunction loads the configuration, environment and aliases of a function in one target.
+func snapshotFunction(ctx context.Context, functionName string, target cloud.CompareTarget, hashEnvValues bool) cloud.FunctionSnapshot {
+	snapshot := cloud.FunctionSnapshot{Target: target}
+
+	client, err := getClient(ctx, target.Profile, target.Region)
+	if err != nil {
+		snapshot.Error = err.Error()
+		return snapshot
+	}
+
+	output, err := client.GetFunction(ctx, &lambda.GetFunctionInput{
+		FunctionName: aws.String(functionName),
+	})
+	if err != nil {
+		snapshot.Error = fmt.Errorf("%w: %w", ErrGetFunction, err).Error()
+		return snapshot
+	}
+	if output.Configuration == nil {
+		snapshot.Error = ErrGetFunction.Error()
+		return snapshot
+	}
+
+	status := toFunctionStatus(*output.Configuration)
+	status.Tags = output.Tags
+	snapshot.Status = &status
+
+	snapshot.Environment = make(map[string]string)
+	if output.Configuration.Environment != nil {
+		for key, value := range output.Configuration.Environment.Variables {
+			if hashEnvValues {
+				snapshot.Environment[key] = hashEnvValue(value)
+			} else {
+				snapshot.Environment[key] = ""
+			}
+		}
+	}
+
+	aliases, err := listAliases(ctx, client, functionName)
+	if err != nil {
+		// The configuration is still worth comparing without the aliases
+		snapshot.Error = err.Error()
+	}
+	snapshot.Aliases = aliases
+
+	return snapshot
+}
+
+// listAliases returns the aliases of a function and the versions they point at.
+func listAliases(ctx context.Context, client *lambda.Client, functionName string) (map[string]string, error) {
+	aliases := make(map[string]string)
+	paginator := lambda.NewListAliasesPaginator(client, &lambda.ListAliasesInput{
+		FunctionName: aws.String(functionName),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return aliases, fmt.Errorf("%w: %w", ErrListAliases, err)
+		}
+		for _, alias := range page.Aliases {
+			aliases[aws.ToString(alias.Name)] = aws.ToString(alias.FunctionVersion)
+		}
+	}
+	return aliases, nil
+}
+
+// hashEnvValue returns a short SHA-256 of an environment variable's value.
+func hashEnvValue(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return "sha256:" + hex.EncodeToString(sum[:])[:envHashLength]
+}
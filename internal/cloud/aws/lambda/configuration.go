@@ -0,0 +1,321 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// Configuration errors.
+var (
+	ErrInvalidConfiguration    = errors.New("invalid function configuration")
+	ErrGetConcurrency          = errors.New("failed to get function concurrency")
+	ErrUpdateConfiguration     = errors.New("failed to update function configuration")
+	ErrUpdateConcurrency       = errors.New("failed to update reserved concurrency")
+	ErrUpdateProvisioned       = errors.New("failed to update provisioned concurrency")
+	ErrGetAccountSettings      = errors.New("failed to get account settings")
+	ErrInsufficientConcurrency = errors.New("insufficient unreserved account concurrency")
+)
+
+// Service limits for function configuration.
+const (
+	minMemory           = 128
+	maxMemory           = 10240
+	minTimeout          = 1
+	maxTimeout          = 900
+	minEphemeralStorage = 512
+	maxEphemeralStorage = 10240
+
+	// minUnreservedConcurrency is the account concurrency Lambda always keeps unreserved.
+	minUnreservedConcurrency = 100
+)
+
+// FunctionConfigurationOperation represents an operation to tune a Lambda function's configuration.
+type FunctionConfigurationOperation struct {
+	profile string
+	region  string
+}
+
+// NewFunctionConfigurationOperation creates a new function configuration operation.
+func NewFunctionConfigurationOperation(profile, region string) *FunctionConfigurationOperation {
+	return &FunctionConfigurationOperation{
+		profile: profile,
+		region:  region,
+	}
+}
+
+// Name returns the operation's name.
+func (o *FunctionConfigurationOperation) Name() string {
+	return "Configure Function"
+}
+
+// Description returns the operation's description.
+func (o *FunctionConfigurationOperation) Description() string {
+	return "Tune Memory, Timeout and Concurrency"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+func (o *FunctionConfigurationOperation) IsUIVisible() bool {
+	return true
+}
+
+// GetFunctionConfiguration returns the full configuration of a function, including concurrency settings.
+func (o *FunctionConfigurationOperation) GetFunctionConfiguration(ctx context.Context, functionName string) (*cloud.FunctionStatus, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.GetFunction(ctx, &lambda.GetFunctionInput{
+		FunctionName: aws.String(functionName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetFunction, err)
+	}
+	if output.Configuration == nil {
+		return nil, fmt.Errorf("%w: no configuration returned for %s", ErrGetFunction, functionName)
+	}
+
+	function := toFunctionStatus(*output.Configuration)
+	function.Tags = output.Tags
+	if output.Concurrency != nil {
+		function.ReservedConcurrency = output.Concurrency.ReservedConcurrentExecutions
+	}
+
+	provisioned, err := listProvisionedConcurrency(ctx, client, functionName)
+	if err != nil {
+		return nil, err
+	}
+	function.ProvisionedConcurrency = provisioned
+
+	return &function, nil
+}
+
+// ValidateConfigUpdate validates an update against the service limits.
+func (o *FunctionConfigurationOperation) ValidateConfigUpdate(current cloud.FunctionStatus, update cloud.FunctionConfigUpdate) error {
+	if update.Memory != nil && (*update.Memory < minMemory || *update.Memory > maxMemory) {
+		return fmt.Errorf("%w: memory must be between %d and %d MB", ErrInvalidConfiguration, minMemory, maxMemory)
+	}
+
+	if update.Timeout != nil && (*update.Timeout < minTimeout || *update.Timeout > maxTimeout) {
+		return fmt.Errorf("%w: timeout must be between %d and %d seconds", ErrInvalidConfiguration, minTimeout, maxTimeout)
+	}
+
+	if update.EphemeralStorage != nil && (*update.EphemeralStorage < minEphemeralStorage || *update.EphemeralStorage > maxEphemeralStorage) {
+		return fmt.Errorf("%w: ephemeral storage must be between %d and %d MB", ErrInvalidConfiguration, minEphemeralStorage, maxEphemeralStorage)
+	}
+
+	if update.ReservedConcurrency != nil && update.RemoveReservedConcurrency {
+		return fmt.Errorf("%w: reserved concurrency cannot be both set and removed", ErrInvalidConfiguration)
+	}
+
+	if update.ReservedConcurrency != nil && *update.ReservedConcurrency < 0 {
+		return fmt.Errorf("%w: reserved concurrency cannot be negative", ErrInvalidConfiguration)
+	}
+
+	// Work out the reserved concurrency that will apply after the update
+	reserved := current.ReservedConcurrency
+	if update.ReservedConcurrency != nil {
+		reserved = update.ReservedConcurrency
+	} else if update.RemoveReservedConcurrency {
+		reserved = nil
+	}
+
+	// Work out the provisioned concurrency that will apply after the update
+	provisioned := make(map[string]int32)
+	for _, config := range current.ProvisionedConcurrency {
+		provisioned[config.Qualifier] = config.Requested
+	}
+	for qualifier, count := range update.ProvisionedConcurrency {
+		if qualifier == "" || qualifier == "$LATEST" {
+			return fmt.Errorf("%w: provisioned concurrency requires a published version or alias", ErrInvalidConfiguration)
+		}
+		if count < 0 {
+			return fmt.Errorf("%w: provisioned concurrency for %s cannot be negative", ErrInvalidConfiguration, qualifier)
+		}
+		provisioned[qualifier] = count
+	}
+
+	if reserved != nil {
+		var total int32
+		for _, count := range provisioned {
+			total += count
+		}
+		if total > *reserved {
+			return fmt.Errorf("%w: provisioned concurrency (%d) exceeds reserved concurrency (%d)", ErrInvalidConfiguration, total, *reserved)
+		}
+	}
+
+	return nil
+}
+
+// UpdateFunctionConfiguration applies the given changes to a function.
+func (o *FunctionConfigurationOperation) UpdateFunctionConfiguration(ctx context.Context, functionName string, update cloud.FunctionConfigUpdate) error {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return err
+	}
+
+	// Update memory, timeout and ephemeral storage in a single call
+	if update.Memory != nil || update.Timeout != nil || update.EphemeralStorage != nil {
+		input := &lambda.UpdateFunctionConfigurationInput{
+			FunctionName: aws.String(functionName),
+			MemorySize:   update.Memory,
+			Timeout:      update.Timeout,
+		}
+		if update.EphemeralStorage != nil {
+			input.EphemeralStorage = &types.EphemeralStorage{Size: update.EphemeralStorage}
+		}
+
+		if _, err := client.UpdateFunctionConfiguration(ctx, input); err != nil {
+			return fmt.Errorf("%w: %w", ErrUpdateConfiguration, err)
+		}
+	}
+
+	// Update reserved concurrency
+	if update.RemoveReservedConcurrency {
+		if _, err := client.DeleteFunctionConcurrency(ctx, &lambda.DeleteFunctionConcurrencyInput{
+			FunctionName: aws.String(functionName),
+		}); err != nil {
+			return fmt.Errorf("%w: %w", ErrUpdateConcurrency, err)
+		}
+	} else if update.ReservedConcurrency != nil {
+		if err := checkUnreservedConcurrency(ctx, client, functionName, *update.ReservedConcurrency); err != nil {
+			return err
+		}
+
+		if _, err := client.PutFunctionConcurrency(ctx, &lambda.PutFunctionConcurrencyInput{
+			FunctionName:                 aws.String(functionName),
+			ReservedConcurrentExecutions: update.ReservedConcurrency,
+		}); err != nil {
+			return fmt.Errorf("%w: %w", ErrUpdateConcurrency, err)
+		}
+	}
+
+	// Update provisioned concurrency in a stable order
+	qualifiers := make([]string, 0, len(update.ProvisionedConcurrency))
+	for qualifier := range update.ProvisionedConcurrency {
+		qualifiers = append(qualifiers, qualifier)
+	}
+	sort.Strings(qualifiers)
+
+	for _, qualifier := range qualifiers {
+		count := update.ProvisionedConcurrency[qualifier]
+		if count == 0 {
+			_, err = client.DeleteProvisionedConcurrencyConfig(ctx, &lambda.DeleteProvisionedConcurrencyConfigInput{
+				FunctionName: aws.String(functionName),
+				Qualifier:    aws.String(qualifier),
+			})
+		} else {
+			_, err = client.PutProvisionedConcurrencyConfig(ctx, &lambda.PutProvisionedConcurrencyConfigInput{
+				FunctionName:                    aws.String(functionName),
+				Qualifier:                       aws.String(qualifier),
+				ProvisionedConcurrentExecutions: aws.Int32(count),
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrUpdateProvisioned, qualifier, err)
+		}
+	}
+
+	return nil
+}
+
+// Execute executes the operation with the given parameters.
+func (o *FunctionConfigurationOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	functionName, ok := params["functionName"].(string)
+	if !ok || functionName == "" {
+		return nil, fmt.Errorf("function name is required")
+	}
+
+	update, ok := params["update"].(cloud.FunctionConfigUpdate)
+	if !ok {
+		return o.GetFunctionConfiguration(ctx, functionName)
+	}
+
+	return nil, o.UpdateFunctionConfiguration(ctx, functionName, update)
+}
+
+// checkUnreservedConcurrency ensures reserving the given concurrency leaves enough unreserved account concurrency.
+func checkUnreservedConcurrency(ctx context.Context, client *lambda.Client, functionName string, reserved int32) error {
+	settings, err := client.GetAccountSettings(ctx, &lambda.GetAccountSettingsInput{})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrGetAccountSettings, err)
+	}
+	if settings.AccountLimit == nil || settings.AccountLimit.UnreservedConcurrentExecutions == nil {
+		return nil
+	}
+
+	// The function's existing reservation is returned to the pool before the new one is applied
+	var existing int32
+	concurrency, err := client.GetFunctionConcurrency(ctx, &lambda.GetFunctionConcurrencyInput{
+		FunctionName: aws.String(functionName),
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrGetConcurrency, err)
+	}
+	if concurrency.ReservedConcurrentExecutions != nil {
+		existing = *concurrency.ReservedConcurrentExecutions
+	}
+
+	available := *settings.AccountLimit.UnreservedConcurrentExecutions + existing - minUnreservedConcurrency
+	if reserved > available {
+		return fmt.Errorf("%w: at most %d can be reserved for this function", ErrInsufficientConcurrency, available)
+	}
+
+	return nil
+}
+
+// listProvisionedConcurrency returns the provisioned concurrency configs of a function.
+func listProvisionedConcurrency(ctx context.Context, client *lambda.Client, functionName string) ([]cloud.ProvisionedConcurrencyConfig, error) {
+	var configs []cloud.ProvisionedConcurrencyConfig
+	var marker *string
+
+	for {
+		output, err := client.ListProvisionedConcurrencyConfigs(ctx, &lambda.ListProvisionedConcurrencyConfigsInput{
+			FunctionName: aws.String(functionName),
+			Marker:       marker,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrGetConcurrency, err)
+		}
+
+		for _, item := range output.ProvisionedConcurrencyConfigs {
+			configs = append(configs, cloud.ProvisionedConcurrencyConfig{
+				Qualifier: qualifierFromArn(aws.ToString(item.FunctionArn)),
+				Requested: aws.ToInt32(item.RequestedProvisionedConcurrentExecutions),
+				Available: aws.ToInt32(item.AvailableProvisionedConcurrentExecutions),
+				Allocated: aws.ToInt32(item.AllocatedProvisionedConcurrentExecutions),
+				Status:    string(item.Status),
+			})
+		}
+
+		if output.NextMarker == nil {
+			break
+		}
+		marker = output.NextMarker
+	}
+
+	sort.Slice(configs, func(i, j int) bool {
+		return configs[i].Qualifier < configs[j].Qualifier
+	})
+
+	return configs, nil
+}
+
+// qualifierFromArn returns the alias or version of a qualified function ARN.
+func qualifierFromArn(arn string) string {
+	// Format: arn:aws:lambda:region:account:function:name:qualifier
+	parts := strings.Split(arn, ":")
+	if len(parts) < 8 {
+		return ""
+	}
+	return parts[7]
+}
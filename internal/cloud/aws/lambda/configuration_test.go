@@ -0,0 +1,120 @@
+package lambda
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+)
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+func TestValidateConfigUpdate(t *testing.T) {
+	current := cloud.FunctionStatus{
+		Name:                "test-function",
+		ReservedConcurrency: int32Ptr(10),
+		ProvisionedConcurrency: []cloud.ProvisionedConcurrencyConfig{
+			{Qualifier: "live", Requested: 5},
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		current cloud.FunctionStatus
+		update  cloud.FunctionConfigUpdate
+		wantErr bool
+	}{
+		{
+			name:   "Memory within limits",
+			update: cloud.FunctionConfigUpdate{Memory: int32Ptr(maxMemory)},
+		},
+		{
+			name:    "Memory below minimum",
+			update:  cloud.FunctionConfigUpdate{Memory: int32Ptr(minMemory - 1)},
+			wantErr: true,
+		},
+		{
+			name:    "Timeout above maximum",
+			update:  cloud.FunctionConfigUpdate{Timeout: int32Ptr(maxTimeout + 1)},
+			wantErr: true,
+		},
+		{
+			name:    "Ephemeral storage below minimum",
+			update:  cloud.FunctionConfigUpdate{EphemeralStorage: int32Ptr(minEphemeralStorage - 1)},
+			wantErr: true,
+		},
+		{
+			name:    "Reserved concurrency set and removed",
+			update:  cloud.FunctionConfigUpdate{ReservedConcurrency: int32Ptr(20), RemoveReservedConcurrency: true},
+			wantErr: true,
+		},
+		{
+			name:    "Negative reserved concurrency",
+			update:  cloud.FunctionConfigUpdate{ReservedConcurrency: int32Ptr(-1)},
+			wantErr: true,
+		},
+		{
+			name:    "Provisioned concurrency on $LATEST",
+			update:  cloud.FunctionConfigUpdate{ProvisionedConcurrency: map[string]int32{"$LATEST": 1}},
+			wantErr: true,
+		},
+		{
+			name:    "Negative provisioned concurrency",
+			update:  cloud.FunctionConfigUpdate{ProvisionedConcurrency: map[string]int32{"beta": -1}},
+			wantErr: true,
+		},
+		{
+			name:    "Provisioned concurrency within the current reservation",
+			current: current,
+			update:  cloud.FunctionConfigUpdate{ProvisionedConcurrency: map[string]int32{"beta": 5}},
+		},
+		{
+			name:    "Provisioned concurrency above the current reservation",
+			current: current,
+			update:  cloud.FunctionConfigUpdate{ProvisionedConcurrency: map[string]int32{"beta": 6}},
+			wantErr: true,
+		},
+		{
+			name:    "Reservation lowered below the provisioned concurrency",
+			current: current,
+			update:  cloud.FunctionConfigUpdate{ReservedConcurrency: int32Ptr(4)},
+			wantErr: true,
+		},
+		{
+			name:    "Reservation removed",
+			current: current,
+			update: cloud.FunctionConfigUpdate{
+				RemoveReservedConcurrency: true,
+				ProvisionedConcurrency:    map[string]int32{"beta": 50},
+			},
+		},
+	}
+
+	operation := NewFunctionConfigurationOperation("default", "us-east-1")
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := operation.ValidateConfigUpdate(tc.current, tc.update)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Expected error %v, got %v", tc.wantErr, err)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidConfiguration) {
+				t.Errorf("Expected an ErrInvalidConfiguration, got %v", err)
+			}
+		})
+	}
+}
+
+func TestQualifierFromArn(t *testing.T) {
+	testCases := map[string]string{
+		"arn:aws:lambda:us-east-1:123456789012:function:test-function:live": "live",
+		"arn:aws:lambda:us-east-1:123456789012:function:test-function:7":    "7",
+		"arn:aws:lambda:us-east-1:123456789012:function:test-function":      "",
+	}
+	for arn, want := range testCases {
+		if got := qualifierFromArn(arn); got != want {
+			t.Errorf("qualifierFromArn(%q) = %q, want %q", arn, got, want)
+		}
+	}
+}
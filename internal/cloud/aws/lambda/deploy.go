@@ -0,0 +1,237 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// Deployment errors.
+var (
+	ErrInvalidDeployRequest = errors.New("invalid deploy request")
+	ErrReadZipFile          = errors.New("failed to read zip file")
+	ErrUpdateFunctionCode   = errors.New("failed to update function code")
+	ErrWaitForUpdate        = errors.New("failed waiting for function update")
+	ErrPublishVersion       = errors.New("failed to publish version")
+	ErrUpdateAlias          = errors.New("failed to update alias")
+)
+
+const (
+	// maxDirectUploadSize is the largest zip archive that can be uploaded without going through S3.
+	maxDirectUploadSize = 50 * 1024 * 1024
+
+	// maxUpdateWait is how long to wait for a code update to finish.
+	maxUpdateWait = 5 * time.Minute
+)
+
+// DeployCodeOperation represents an operation to deploy new code to a Lambda function.
+type DeployCodeOperation struct {
+	profile string
+	region  string
+}
+
+// NewDeployCodeOperation creates a new deploy code operation.
+func NewDeployCodeOperation(profile, region string) *DeployCodeOperation {
+	return &DeployCodeOperation{
+		profile: profile,
+		region:  region,
+	}
+}
+
+// Name returns the operation's name.
+func (o *DeployCodeOperation) Name() string {
+	return "Deploy Code"
+}
+
+// Description returns the operation's description.
+func (o *DeployCodeOperation) Description() string {
+	return "Update Function Code from a Zip, S3 Object or Image"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+func (o *DeployCodeOperation) IsUIVisible() bool {
+	return true
+}
+
+// ValidateDeployRequest validates a deployment request before any changes are made.
+func (o *DeployCodeOperation) ValidateDeployRequest(request cloud.LambdaDeployRequest) error {
+	sources := 0
+	if request.ZipFile != "" {
+		sources++
+	}
+	if request.S3Bucket != "" || request.S3Key != "" {
+		sources++
+	}
+	if request.ImageURI != "" {
+		sources++
+	}
+	if sources != 1 {
+		return fmt.Errorf("%w: exactly one of a zip file, S3 object or image URI is required", ErrInvalidDeployRequest)
+	}
+
+	if (request.S3Bucket == "") != (request.S3Key == "") {
+		return fmt.Errorf("%w: both an S3 bucket and key are required", ErrInvalidDeployRequest)
+	}
+
+	if request.Alias != "" && !request.Publish {
+		return fmt.Errorf("%w: moving an alias requires publishing a version", ErrInvalidDeployRequest)
+	}
+
+	if request.ZipFile != "" {
+		info, err := os.Stat(request.ZipFile)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrReadZipFile, err)
+		}
+		if info.IsDir() {
+			return fmt.Errorf("%w: %s is a directory", ErrInvalidDeployRequest, request.ZipFile)
+		}
+		if info.Size() > maxDirectUploadSize {
+			return fmt.Errorf("%w: %s is larger than 50 MB, upload it to S3 and deploy from there", ErrInvalidDeployRequest, request.ZipFile)
+		}
+	}
+
+	return nil
+}
+
+// DeployFunctionCode updates a function's code and waits for the update to complete.
+func (o *DeployCodeOperation) DeployFunctionCode(ctx context.Context, functionName string, request cloud.LambdaDeployRequest) (*cloud.LambdaDeployResult, error) {
+	if err := o.ValidateDeployRequest(request); err != nil {
+		return nil, err
+	}
+
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &lambda.UpdateFunctionCodeInput{
+		FunctionName: aws.String(functionName),
+	}
+	switch {
+	case request.ZipFile != "":
+		zipFile, err := os.ReadFile(request.ZipFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrReadZipFile, err)
+		}
+		input.ZipFile = zipFile
+	case request.S3Bucket != "":
+		input.S3Bucket = aws.String(request.S3Bucket)
+		input.S3Key = aws.String(request.S3Key)
+	default:
+		input.ImageUri = aws.String(request.ImageURI)
+	}
+
+	if _, err := client.UpdateFunctionCode(ctx, input); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUpdateFunctionCode, err)
+	}
+
+	// Wait for LastUpdateStatus to leave InProgress
+	output, err := lambda.NewFunctionUpdatedV2Waiter(client).WaitForOutput(ctx, &lambda.GetFunctionInput{
+		FunctionName: aws.String(functionName),
+	}, maxUpdateWait)
+	if err != nil {
+		// The waiter only says the update failed, the reason is on the function itself
+		function, getErr := client.GetFunction(ctx, &lambda.GetFunctionInput{
+			FunctionName: aws.String(functionName),
+		})
+		if getErr == nil {
+			if failure := updateFailure(function.Configuration); failure != nil {
+				return nil, failure
+			}
+		}
+		return nil, fmt.Errorf("%w: %w", ErrWaitForUpdate, err)
+	}
+	if output.Configuration == nil {
+		return nil, fmt.Errorf("%w: no configuration returned for %s", ErrGetFunction, functionName)
+	}
+
+	configuration := output.Configuration
+	result := &cloud.LambdaDeployResult{
+		CodeSha256:       aws.ToString(configuration.CodeSha256),
+		LastUpdateStatus: string(configuration.LastUpdateStatus),
+	}
+
+	if !request.Publish {
+		return result, nil
+	}
+
+	// Publish the code we just deployed, failing if someone else deployed in the meantime
+	version, err := client.PublishVersion(ctx, &lambda.PublishVersionInput{
+		FunctionName: aws.String(functionName),
+		CodeSha256:   configuration.CodeSha256,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPublishVersion, err)
+	}
+	result.Version = aws.ToString(version.Version)
+
+	if request.Alias != "" {
+		if err := pointAlias(ctx, client, functionName, request.Alias, result.Version); err != nil {
+			return nil, err
+		}
+		result.Alias = request.Alias
+	}
+
+	return result, nil
+}
+
+// Execute executes the operation with the given parameters.
+func (o *DeployCodeOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	functionName, ok := params["functionName"].(string)
+	if !ok || functionName == "" {
+		return nil, fmt.Errorf("function name is required")
+	}
+
+	request, ok := params["request"].(cloud.LambdaDeployRequest)
+	if !ok {
+		return nil, fmt.Errorf("deploy request is required")
+	}
+
+	return o.DeployFunctionCode(ctx, functionName, request)
+}
+
+// updateFailure returns the error of a failed code update with the reason Lambda gives for it, or
+// nil when the last update didn't fail.
+func updateFailure(configuration *types.FunctionConfiguration) error {
+	if configuration == nil || configuration.LastUpdateStatus != types.LastUpdateStatusFailed {
+		return nil
+	}
+	return fmt.Errorf("%w: update failed with %s: %s",
+		ErrUpdateFunctionCode, configuration.LastUpdateStatusReasonCode, aws.ToString(configuration.LastUpdateStatusReason))
+}
+
+// pointAlias points an alias at a version, creating the alias if it doesn't exist.
+func pointAlias(ctx context.Context, client *lambda.Client, functionName, alias, version string) error {
+	_, err := client.GetAlias(ctx, &lambda.GetAliasInput{
+		FunctionName: aws.String(functionName),
+		Name:         aws.String(alias),
+	})
+
+	var notFound *types.ResourceNotFoundException
+	switch {
+	case errors.As(err, &notFound):
+		_, err = client.CreateAlias(ctx, &lambda.CreateAliasInput{
+			FunctionName:    aws.String(functionName),
+			Name:            aws.String(alias),
+			FunctionVersion: aws.String(version),
+		})
+	case err == nil:
+		_, err = client.UpdateAlias(ctx, &lambda.UpdateAliasInput{
+			FunctionName:    aws.String(functionName),
+			Name:            aws.String(alias),
+			FunctionVersion: aws.String(version),
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrUpdateAlias, alias, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package lambda
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+func TestValidateDeployRequest(t *testing.T) {
+	dir := t.TempDir()
+	zipFile := filepath.Join(dir, "build.zip")
+	if err := os.WriteFile(zipFile, []byte("PK"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name    string
+		request cloud.LambdaDeployRequest
+		wantErr error
+	}{
+		{
+			name:    "Zip file",
+			request: cloud.LambdaDeployRequest{ZipFile: zipFile},
+		},
+		{
+			name:    "S3 object published to an alias",
+			request: cloud.LambdaDeployRequest{S3Bucket: "artifacts", S3Key: "app.zip", Publish: true, Alias: "live"},
+		},
+		{
+			name:    "Image",
+			request: cloud.LambdaDeployRequest{ImageURI: "123456789012.dkr.ecr.us-east-1.amazonaws.com/app:1"},
+		},
+		{
+			name:    "No source",
+			request: cloud.LambdaDeployRequest{},
+			wantErr: ErrInvalidDeployRequest,
+		},
+		{
+			name:    "Two sources",
+			request: cloud.LambdaDeployRequest{ZipFile: zipFile, ImageURI: "app:1"},
+			wantErr: ErrInvalidDeployRequest,
+		},
+		{
+			name:    "S3 bucket without a key",
+			request: cloud.LambdaDeployRequest{S3Bucket: "artifacts"},
+			wantErr: ErrInvalidDeployRequest,
+		},
+		{
+			name:    "Alias without publishing",
+			request: cloud.LambdaDeployRequest{ImageURI: "app:1", Alias: "live"},
+			wantErr: ErrInvalidDeployRequest,
+		},
+		{
+			name:    "Missing zip file",
+			request: cloud.LambdaDeployRequest{ZipFile: filepath.Join(dir, "missing.zip")},
+			wantErr: ErrReadZipFile,
+		},
+		{
+			name:    "Directory as zip file",
+			request: cloud.LambdaDeployRequest{ZipFile: dir},
+			wantErr: ErrInvalidDeployRequest,
+		},
+	}
+
+	operation := NewDeployCodeOperation("default", "us-east-1")
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := operation.ValidateDeployRequest(tc.request)
+			if tc.wantErr == nil && err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Errorf("Expected %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestUpdateFailure(t *testing.T) {
+	if err := updateFailure(&types.FunctionConfiguration{LastUpdateStatus: types.LastUpdateStatusSuccessful}); err != nil {
+		t.Errorf("Expected no error for a successful update, got %v", err)
+	}
+	if err := updateFailure(nil); err != nil {
+		t.Errorf("Expected no error without a configuration, got %v", err)
+	}
+
+	err := updateFailure(&types.FunctionConfiguration{
+		LastUpdateStatus:           types.LastUpdateStatusFailed,
+		LastUpdateStatusReasonCode: types.LastUpdateStatusReasonCodeInvalidImage,
+		LastUpdateStatusReason:     aws.String("The image manifest could not be read"),
+	})
+	if !errors.Is(err, ErrUpdateFunctionCode) || !strings.Contains(err.Error(), "The image manifest could not be read") {
+		t.Errorf("Expected the reason of the failed update, got %v", err)
+	}
+}
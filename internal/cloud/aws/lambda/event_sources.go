@@ -0,0 +1,202 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// Event source mapping errors.
+var (
+	ErrListEventSources  = errors.New("failed to list event source mappings")
+	ErrUpdateEventSource = errors.New("failed to update event source mapping")
+)
+
+// eventSourceTypes maps the service of an event source ARN to a readable source type.
+var eventSourceTypes = map[string]string{
+	"sqs":      "SQS",
+	"kinesis":  "Kinesis",
+	"dynamodb": "DynamoDB",
+	"kafka":    "Kafka",
+	"mq":       "MQ",
+	"rds":      "DocumentDB",
+}
+
+// EventSourceMappingOperation represents an operation to manage Lambda event source mappings.
+type EventSourceMappingOperation struct {
+	profile string
+	region  string
+}
+
+// NewEventSourceMappingOperation creates a new event source mapping operation.
+func NewEventSourceMappingOperation(profile, region string) *EventSourceMappingOperation {
+	return &EventSourceMappingOperation{
+		profile: profile,
+		region:  region,
+	}
+}
+
+// Name returns the operation's name.
+func (o *EventSourceMappingOperation) Name() string {
+	return "Event Source Mappings"
+}
+
+// Description returns the operation's description.
+func (o *EventSourceMappingOperation) Description() string {
+	return "Inspect, Enable and Disable Event Sources"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+func (o *EventSourceMappingOperation) IsUIVisible() bool {
+	return true
+}
+
+// ListEventSourceMappings returns the event source mappings of a function, or of the whole account if functionName is empty.
+func (o *EventSourceMappingOperation) ListEventSourceMappings(ctx context.Context, functionName string) ([]cloud.EventSourceMapping, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &lambda.ListEventSourceMappingsInput{}
+	if functionName != "" {
+		input.FunctionName = aws.String(functionName)
+	}
+
+	var mappings []cloud.EventSourceMapping
+	for {
+		output, err := client.ListEventSourceMappings(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrListEventSources, err)
+		}
+
+		for _, mapping := range output.EventSourceMappings {
+			mappings = append(mappings, toEventSourceMapping(mapping))
+		}
+
+		if output.NextMarker == nil {
+			break
+		}
+		input.Marker = output.NextMarker
+	}
+
+	sort.Slice(mappings, func(i, j int) bool {
+		if mappings[i].FunctionName != mappings[j].FunctionName {
+			return mappings[i].FunctionName < mappings[j].FunctionName
+		}
+		return mappings[i].EventSourceArn < mappings[j].EventSourceArn
+	})
+
+	return mappings, nil
+}
+
+// SetEventSourceMappingEnabled enables or disables an event source mapping.
+func (o *EventSourceMappingOperation) SetEventSourceMappingEnabled(ctx context.Context, uuid string, enabled bool) (*cloud.EventSourceMapping, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.UpdateEventSourceMapping(ctx, &lambda.UpdateEventSourceMappingInput{
+		UUID:    aws.String(uuid),
+		Enabled: aws.Bool(enabled),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUpdateEventSource, err)
+	}
+
+	mapping := toEventSourceMapping(types.EventSourceMappingConfiguration{
+		UUID:                           output.UUID,
+		FunctionArn:                    output.FunctionArn,
+		EventSourceArn:                 output.EventSourceArn,
+		SelfManagedEventSource:         output.SelfManagedEventSource,
+		State:                          output.State,
+		StateTransitionReason:          output.StateTransitionReason,
+		BatchSize:                      output.BatchSize,
+		MaximumBatchingWindowInSeconds: output.MaximumBatchingWindowInSeconds,
+		StartingPosition:               output.StartingPosition,
+		LastProcessingResult:           output.LastProcessingResult,
+		LastModified:                   output.LastModified,
+		FilterCriteria:                 output.FilterCriteria,
+	})
+	return &mapping, nil
+}
+
+// Execute executes the operation with the given parameters.
+func (o *EventSourceMappingOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	if uuid, ok := params["uuid"].(string); ok && uuid != "" {
+		enabled, ok := params["enabled"].(bool)
+		if !ok {
+			return nil, fmt.Errorf("enabled is required")
+		}
+		return o.SetEventSourceMappingEnabled(ctx, uuid, enabled)
+	}
+
+	functionName, _ := params["functionName"].(string)
+	return o.ListEventSourceMappings(ctx, functionName)
+}
+
+// toEventSourceMapping converts an AWS event source mapping to our cloud type.
+func toEventSourceMapping(mapping types.EventSourceMappingConfiguration) cloud.EventSourceMapping {
+	result := cloud.EventSourceMapping{
+		UUID:                 aws.ToString(mapping.UUID),
+		FunctionName:         functionNameFromArn(aws.ToString(mapping.FunctionArn)),
+		EventSourceArn:       aws.ToString(mapping.EventSourceArn),
+		State:                aws.ToString(mapping.State),
+		StateReason:          aws.ToString(mapping.StateTransitionReason),
+		BatchSize:            aws.ToInt32(mapping.BatchSize),
+		BatchingWindow:       aws.ToInt32(mapping.MaximumBatchingWindowInSeconds),
+		StartingPosition:     string(mapping.StartingPosition),
+		LastProcessingResult: aws.ToString(mapping.LastProcessingResult),
+	}
+
+	if mapping.LastModified != nil {
+		result.LastModified = mapping.LastModified.Format("2006-01-02 15:04:05")
+	}
+
+	// Self-managed Kafka clusters have bootstrap servers instead of an ARN
+	if result.EventSourceArn == "" && mapping.SelfManagedEventSource != nil {
+		result.SourceType = "Kafka"
+		result.EventSourceArn = strings.Join(mapping.SelfManagedEventSource.Endpoints["KAFKA_BOOTSTRAP_SERVERS"], ",")
+	} else {
+		result.SourceType = sourceTypeFromArn(result.EventSourceArn)
+	}
+
+	if mapping.FilterCriteria != nil {
+		for _, filter := range mapping.FilterCriteria.Filters {
+			result.FilterCriteria = append(result.FilterCriteria, aws.ToString(filter.Pattern))
+		}
+	}
+
+	return result
+}
+
+// sourceTypeFromArn returns the readable source type of an event source ARN.
+func sourceTypeFromArn(arn string) string {
+	// Format: arn:aws:service:region:account:resource
+	parts := strings.Split(arn, ":")
+	if len(parts) < 3 {
+		return "Unknown"
+	}
+	if sourceType, ok := eventSourceTypes[parts[2]]; ok {
+		return sourceType
+	}
+	return parts[2]
+}
+
+// functionNameFromArn returns the function name of a function ARN.
+func functionNameFromArn(arn string) string {
+	// Format: arn:aws:lambda:region:account:function:name[:qualifier]
+	parts := strings.Split(arn, ":")
+	if len(parts) < 7 {
+		return arn
+	}
+	return parts[6]
+}
@@ -0,0 +1,383 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	awscloudwatch "github.com/HenryOwenz/cloudgate/internal/cloud/aws/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// Hygiene report errors.
+var (
+	ErrHygieneReport = errors.New("failed to generate hygiene report")
+)
+
+const (
+	// defaultUnusedDays is how long a function goes without invocations before it's flagged as unused.
+	defaultUnusedDays = 30
+
+	// defaultMaxPackageSize is the largest zip that can be uploaded directly rather than through S3.
+	defaultMaxPackageSize = 50 * 1024 * 1024
+
+	// deprecationNotice is how long before its deprecation date a runtime is flagged.
+	deprecationNotice = 180 * 24 * time.Hour
+)
+
+// runtimeDeprecations are the dates from which Lambda stops patching each runtime, per the
+// Lambda runtime deprecation policy. Runtimes without an announced date aren't listed.
+//
+// The dates come from the "Supported runtimes" and "Deprecated runtimes" tables of
+// https://docs.aws.amazon.com/lambda/latest/dg/lambda-runtimes.html as of 2026-10-18. AWS adds
+// dates to that page as it announces them; a runtime missing here is reported as supported even
+// once it has been deprecated, so update this table from the page.
+var runtimeDeprecations = map[string]string{
+	"nodejs10.x":    "2021-07-30",
+	"nodejs12.x":    "2023-03-31",
+	"nodejs14.x":    "2023-12-04",
+	"nodejs16.x":    "2024-06-12",
+	"nodejs18.x":    "2025-09-01",
+	"nodejs20.x":    "2026-04-30",
+	"python2.7":     "2021-07-15",
+	"python3.6":     "2022-07-18",
+	"python3.7":     "2023-12-04",
+	"python3.8":     "2024-10-14",
+	"python3.9":     "2025-12-15",
+	"python3.10":    "2026-10-31",
+	"java8":         "2024-01-08",
+	"go1.x":         "2024-01-08",
+	"provided":      "2024-01-08",
+	"ruby2.7":       "2023-12-07",
+	"ruby3.2":       "2026-03-31",
+	"dotnetcore3.1": "2023-04-03",
+	"dotnet6":       "2024-12-20",
+	"dotnet7":       "2024-05-14",
+	"dotnet8":       "2026-11-10",
+}
+
+// severityOrder sorts findings from most to least urgent.
+var severityOrder = map[string]int{
+	cloud.SeverityHigh:   0,
+	cloud.SeverityMedium: 1,
+	cloud.SeverityLow:    2,
+}
+
+// HygieneReportOperation represents an operation to report on deprecated runtimes and risky function settings.
+type HygieneReportOperation struct {
+	profile string
+	region  string
+}
+
+// NewHygieneReportOperation creates a new hygiene report operation.
+func NewHygieneReportOperation(profile, region string) *HygieneReportOperation {
+	return &HygieneReportOperation{
+		profile: profile,
+		region:  region,
+	}
+}
+
+// Name returns the operation's name.
+func (o *HygieneReportOperation) Name() string {
+	return "Hygiene Report"
+}
+
+// Description returns the operation's description.
+func (o *HygieneReportOperation) Description() string {
+	return "Flag Deprecated Runtimes and Risky Settings"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+func (o *HygieneReportOperation) IsUIVisible() bool {
+	return true
+}
+
+// GenerateHygieneReport scans the functions of each region in the options and returns their findings,
+// most urgent first. Regions that can't be scanned are reported as errors, unless none can be.
+func (o *HygieneReportOperation) GenerateHygieneReport(ctx context.Context, options cloud.HygieneReportOptions) (*cloud.HygieneReport, error) {
+	if len(options.Regions) == 0 {
+		options.Regions = []string{o.region}
+	}
+	if options.UnusedDays <= 0 {
+		options.UnusedDays = defaultUnusedDays
+	}
+	if options.MaxPackageSize <= 0 {
+		options.MaxPackageSize = defaultMaxPackageSize
+	}
+
+	report := &cloud.HygieneReport{
+		GeneratedAt: time.Now().UTC(),
+		Regions:     options.Regions,
+		Findings:    []cloud.HygieneFinding{},
+	}
+
+	failedRegions := 0
+	for _, region := range options.Regions {
+		scan := &regionScan{region: region, options: options, now: report.GeneratedAt}
+		if err := o.scanRegion(ctx, scan); err != nil {
+			failedRegions++
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", region, err))
+			continue
+		}
+		report.FunctionsScanned += scan.functions
+		report.Findings = append(report.Findings, scan.findings...)
+		report.Errors = append(report.Errors, scan.errors...)
+	}
+	if failedRegions == len(options.Regions) {
+		return nil, fmt.Errorf("%w: %s", ErrHygieneReport, strings.Join(report.Errors, "; "))
+	}
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		a, b := report.Findings[i], report.Findings[j]
+		if a.Severity != b.Severity {
+			return severityOrder[a.Severity] < severityOrder[b.Severity]
+		}
+		if a.Region != b.Region {
+			return a.Region < b.Region
+		}
+		return a.FunctionName < b.FunctionName
+	})
+
+	return report, nil
+}
+
+// Execute executes the operation with the given parameters.
+func (o *HygieneReportOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	options, _ := params["options"].(cloud.HygieneReportOptions)
+	return o.GenerateHygieneReport(ctx, options)
+}
+
+// regionScan collects the findings of one region. Checks that need an API call per function
+// run concurrently, so findings and errors are guarded by a mutex.
+type regionScan struct {
+	region  string
+	options cloud.HygieneReportOptions
+	now     time.Time
+
+	mu        sync.Mutex
+	functions int
+	findings  []cloud.HygieneFinding
+	errors    []string
+	failed    map[string]bool // Checks that already reported an error
+}
+
+// addFinding records a finding for a function.
+func (s *regionScan) addFinding(function types.FunctionConfiguration, check, severity, detail string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.findings = append(s.findings, cloud.HygieneFinding{
+		Region:       s.region,
+		FunctionName: aws.ToString(function.FunctionName),
+		FunctionArn:  aws.ToString(function.FunctionArn),
+		Runtime:      string(function.Runtime),
+		Check:        check,
+		Severity:     severity,
+		Detail:       detail,
+	})
+}
+
+// addError records that a check couldn't be run, once per check, since the cause is
+// usually a missing permission that affects every function.
+func (s *regionScan) addError(check string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failed == nil {
+		s.failed = make(map[string]bool)
+	}
+	if s.failed[check] {
+		return
+	}
+	s.failed[check] = true
+	s.errors = append(s.errors, fmt.Sprintf("%s: %s check skipped: %v", s.region, strings.ToLower(check), err))
+}
+
+// scanRegion runs every check against the functions of a region.
+func (o *HygieneReportOperation) scanRegion(ctx context.Context, scan *regionScan) error {
+	client, err := getClient(ctx, o.profile, scan.region)
+	if err != nil {
+		return err
+	}
+
+	functions, err := listFunctions(ctx, client)
+	if err != nil {
+		return err
+	}
+	scan.functions = len(functions)
+
+	for _, function := range functions {
+		checkRuntime(scan, function)
+		checkConfiguration(scan, function)
+	}
+
+	forEachConcurrently(len(functions), func(i int) {
+		checkReservedConcurrency(ctx, client, scan, functions[i])
+		checkFailureDestination(ctx, client, scan, functions[i])
+	})
+
+	o.checkUnused(ctx, scan, functions)
+	return nil
+}
+
+// checkRuntime flags runtimes that are deprecated or will be within the notice period.
+func checkRuntime(scan *regionScan, function types.FunctionConfiguration) {
+	runtime := string(function.Runtime)
+	date, ok := runtimeDeprecations[runtime]
+	if !ok {
+		return
+	}
+
+	deprecation, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return
+	}
+
+	switch {
+	case !scan.now.Before(deprecation):
+		scan.addFinding(function, cloud.HygieneCheckDeprecatedRuntime, cloud.SeverityHigh,
+			fmt.Sprintf("%s was deprecated on %s", runtime, date))
+	case deprecation.Sub(scan.now) <= deprecationNotice:
+		scan.addFinding(function, cloud.HygieneCheckDeprecatingRuntime, cloud.SeverityMedium,
+			fmt.Sprintf("%s will be deprecated on %s", runtime, date))
+	}
+}
+
+// checkConfiguration flags settings that can be read from the function configuration alone.
+func checkConfiguration(scan *regionScan, function types.FunctionConfiguration) {
+	if aws.ToInt32(function.Timeout) >= maxTimeout {
+		scan.addFinding(function, cloud.HygieneCheckMaxTimeout, cloud.SeverityMedium,
+			fmt.Sprintf("Timeout is the maximum of %d seconds", maxTimeout))
+	}
+
+	// Image packages report no code size
+	if function.CodeSize > scan.options.MaxPackageSize {
+		scan.addFinding(function, cloud.HygieneCheckOversizedPackage, cloud.SeverityLow,
+			fmt.Sprintf("Package is %.1f MB, over the %.1f MB limit", megabytes(function.CodeSize), megabytes(scan.options.MaxPackageSize)))
+	}
+}
+
+// checkReservedConcurrency flags functions that share the account's unreserved concurrency.
+func checkReservedConcurrency(ctx context.Context, client *lambda.Client, scan *regionScan, function types.FunctionConfiguration) {
+	output, err := client.GetFunctionConcurrency(ctx, &lambda.GetFunctionConcurrencyInput{
+		FunctionName: function.FunctionName,
+	})
+	if err != nil {
+		scan.addError(cloud.HygieneCheckNoReservedConcurrency, err)
+		return
+	}
+	if output.ReservedConcurrentExecutions == nil {
+		scan.addFinding(function, cloud.HygieneCheckNoReservedConcurrency, cloud.SeverityLow,
+			"Uses unreserved account concurrency")
+	}
+}
+
+// checkFailureDestination flags functions whose failed asynchronous invocations are dropped,
+// having neither a dead-letter queue nor an on-failure destination.
+func checkFailureDestination(ctx context.Context, client *lambda.Client, scan *regionScan, function types.FunctionConfiguration) {
+	if function.DeadLetterConfig != nil && aws.ToString(function.DeadLetterConfig.TargetArn) != "" {
+		return
+	}
+
+	output, err := client.GetFunctionEventInvokeConfig(ctx, &lambda.GetFunctionEventInvokeConfigInput{
+		FunctionName: function.FunctionName,
+	})
+	var notFound *types.ResourceNotFoundException
+	if err != nil && !errors.As(err, &notFound) {
+		scan.addError(cloud.HygieneCheckNoFailureDestination, err)
+		return
+	}
+
+	if err == nil && output.DestinationConfig != nil && output.DestinationConfig.OnFailure != nil &&
+		aws.ToString(output.DestinationConfig.OnFailure.Destination) != "" {
+		return
+	}
+	scan.addFinding(function, cloud.HygieneCheckNoFailureDestination, cloud.SeverityMedium,
+		"Failed asynchronous invocations are dropped")
+}
+
+// checkUnused flags functions without invocations in the unused period. Functions modified
+// within the period are skipped, since they may not have been invoked yet.
+func (o *HygieneReportOperation) checkUnused(ctx context.Context, scan *regionScan, functions []types.FunctionConfiguration) {
+	start := scan.now.Add(-time.Duration(scan.options.UnusedDays) * 24 * time.Hour).Truncate(time.Minute)
+
+	var candidates []types.FunctionConfiguration
+	for _, function := range functions {
+		modified, err := time.Parse(lambdaTimeLayout, aws.ToString(function.LastModified))
+		if err == nil && modified.After(start) {
+			continue
+		}
+		candidates = append(candidates, function)
+	}
+	if len(candidates) == 0 {
+		return
+	}
+
+	client, err := getCloudWatchClient(ctx, o.profile, scan.region)
+	if err != nil {
+		scan.addError(cloud.HygieneCheckUnused, err)
+		return
+	}
+
+	// One data point per function, covering the whole period
+	period := int32(scan.now.Sub(start).Seconds()) / 60 * 60
+	invocations := make(map[string]float64, len(candidates))
+	for first := 0; first < len(candidates); first += awscloudwatch.MaxMetricQueries {
+		last := min(first+awscloudwatch.MaxMetricQueries, len(candidates))
+
+		queries := make([]cwtypes.MetricDataQuery, 0, last-first)
+		for i, function := range candidates[first:last] {
+			queries = append(queries, cwtypes.MetricDataQuery{
+				Id: aws.String(fmt.Sprintf("invocations_%d", first+i)),
+				MetricStat: &cwtypes.MetricStat{
+					Metric: &cwtypes.Metric{
+						Namespace:  aws.String("AWS/Lambda"),
+						MetricName: aws.String("Invocations"),
+						Dimensions: []cwtypes.Dimension{
+							{Name: aws.String("FunctionName"), Value: function.FunctionName},
+						},
+					},
+					Period: aws.Int32(period),
+					Stat:   aws.String("Sum"),
+				},
+			})
+		}
+
+		paginator := cloudwatch.NewGetMetricDataPaginator(client, &cloudwatch.GetMetricDataInput{
+			MetricDataQueries: queries,
+			StartTime:         aws.Time(start),
+			EndTime:           aws.Time(scan.now),
+		})
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				scan.addError(cloud.HygieneCheckUnused, fmt.Errorf("%w: %w", ErrGetMetrics, err))
+				return
+			}
+			for _, result := range output.MetricDataResults {
+				for _, value := range result.Values {
+					invocations[aws.ToString(result.Id)] += value
+				}
+			}
+		}
+	}
+
+	for i, function := range candidates {
+		if invocations[fmt.Sprintf("invocations_%d", i)] == 0 {
+			scan.addFinding(function, cloud.HygieneCheckUnused, cloud.SeverityLow,
+				fmt.Sprintf("No invocations in %d days", scan.options.UnusedDays))
+		}
+	}
+}
+
+// megabytes converts a size in bytes to MB.
+func megabytes(size int64) float64 {
+	return float64(size) / (1024 * 1024)
+}
@@ -0,0 +1,109 @@
+package lambda
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+func TestCheckRuntime(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		runtime  types.Runtime
+		check    string
+		severity string
+	}{
+		{runtime: types.RuntimeNodejs16x, check: cloud.HygieneCheckDeprecatedRuntime, severity: cloud.SeverityHigh},
+		{runtime: types.RuntimePython39, check: cloud.HygieneCheckDeprecatedRuntime, severity: cloud.SeverityHigh},
+		{runtime: types.RuntimeNodejs20x, check: cloud.HygieneCheckDeprecatingRuntime, severity: cloud.SeverityMedium},
+		{runtime: types.RuntimeDotnet8},
+		{runtime: types.RuntimeNodejs22x},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.runtime), func(t *testing.T) {
+			scan := &regionScan{region: "us-east-1", now: now}
+			checkRuntime(scan, types.FunctionConfiguration{
+				FunctionName: aws.String("orders"),
+				Runtime:      tc.runtime,
+			})
+
+			if tc.check == "" {
+				if len(scan.findings) != 0 {
+					t.Errorf("Expected no finding, got %+v", scan.findings)
+				}
+				return
+			}
+			if len(scan.findings) != 1 || scan.findings[0].Check != tc.check || scan.findings[0].Severity != tc.severity {
+				t.Errorf("Expected a %s finding of %s severity, got %+v", tc.check, tc.severity, scan.findings)
+			}
+		})
+	}
+}
+
+func TestRuntimeDeprecationDates(t *testing.T) {
+	for runtime, date := range runtimeDeprecations {
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			t.Errorf("Expected the deprecation date of %s to be a date, got %q", runtime, date)
+		}
+	}
+}
+
+func TestCheckConfiguration(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function types.FunctionConfiguration
+		checks   []string
+	}{
+		{
+			name:     "Within limits",
+			function: types.FunctionConfiguration{Timeout: aws.Int32(30), CodeSize: 1024},
+		},
+		{
+			name:     "Maximum timeout",
+			function: types.FunctionConfiguration{Timeout: aws.Int32(maxTimeout)},
+			checks:   []string{cloud.HygieneCheckMaxTimeout},
+		},
+		{
+			name:     "Oversized package",
+			function: types.FunctionConfiguration{Timeout: aws.Int32(30), CodeSize: defaultMaxPackageSize + 1},
+			checks:   []string{cloud.HygieneCheckOversizedPackage},
+		},
+		{
+			name:     "Image without a code size",
+			function: types.FunctionConfiguration{Timeout: aws.Int32(maxTimeout), PackageType: types.PackageTypeImage},
+			checks:   []string{cloud.HygieneCheckMaxTimeout},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scan := &regionScan{options: cloud.HygieneReportOptions{MaxPackageSize: defaultMaxPackageSize}}
+			checkConfiguration(scan, tc.function)
+
+			var checks []string
+			for _, finding := range scan.findings {
+				checks = append(checks, finding.Check)
+			}
+			if len(checks) != len(tc.checks) || (len(checks) > 0 && checks[0] != tc.checks[0]) {
+				t.Errorf("Expected findings %v, got %v", tc.checks, checks)
+			}
+		})
+	}
+}
+
+func TestRegionScanAddError(t *testing.T) {
+	scan := &regionScan{region: "eu-west-1"}
+	scan.addError(cloud.HygieneCheckNoReservedConcurrency, errors.New("access denied"))
+	scan.addError(cloud.HygieneCheckNoReservedConcurrency, errors.New("access denied"))
+	scan.addError(cloud.HygieneCheckUnused, errors.New("access denied"))
+
+	if len(scan.errors) != 2 {
+		t.Errorf("Expected one error per check, got %v", scan.errors)
+	}
+}
@@ -0,0 +1,209 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	awscloudwatch "github.com/HenryOwenz/cloudgate/internal/cloud/aws/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// Metrics errors.
+var (
+	ErrGetMetrics = errors.New("failed to get function metrics")
+)
+
+// metricBuckets is the number of data points in each metric series.
+const metricBuckets = 12
+
+// functionMetricQuery describes one of the metrics collected for each function.
+type functionMetricQuery struct {
+	id     string
+	metric string
+	stat   string
+}
+
+// functionMetricQueries are the metrics collected for each function, in the order of cloud.FunctionMetrics.
+var functionMetricQueries = []functionMetricQuery{
+	{id: "invocations", metric: "Invocations", stat: "Sum"},
+	{id: "errors", metric: "Errors", stat: "Sum"},
+	{id: "throttles", metric: "Throttles", stat: "Sum"},
+	{id: "p50", metric: "Duration", stat: "p50"},
+	{id: "p99", metric: "Duration", stat: "p99"},
+	{id: "concurrency", metric: "ConcurrentExecutions", stat: "Maximum"},
+}
+
+// FunctionMetricsOperation represents an operation to view the recent metrics of Lambda functions.
+type FunctionMetricsOperation struct {
+	profile string
+	region  string
+}
+
+// NewFunctionMetricsOperation creates a new function metrics operation.
+func NewFunctionMetricsOperation(profile, region string) *FunctionMetricsOperation {
+	return &FunctionMetricsOperation{
+		profile: profile,
+		region:  region,
+	}
+}
+
+// Name returns the operation's name.
+func (o *FunctionMetricsOperation) Name() string {
+	return "Function Metrics"
+}
+
+// Description returns the operation's description.
+func (o *FunctionMetricsOperation) Description() string {
+	return "View Recent Lambda Function Metrics"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+// Metrics are shown alongside the function list rather than as a separate operation.
+func (o *FunctionMetricsOperation) IsUIVisible() bool {
+	return false
+}
+
+// GetFunctionMetrics returns the metrics of the given functions over the window, keyed by function name.
+// The queries for all functions are batched into as few GetMetricData requests as possible.
+func (o *FunctionMetricsOperation) GetFunctionMetrics(ctx context.Context, functionNames []string, window time.Duration) (map[string]cloud.FunctionMetrics, error) {
+	metrics := make(map[string]cloud.FunctionMetrics, len(functionNames))
+	if len(functionNames) == 0 {
+		return metrics, nil
+	}
+
+	client, err := getCloudWatchClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	// CloudWatch periods must be whole minutes
+	bucket := (window / metricBuckets).Truncate(time.Minute)
+	if bucket < time.Minute {
+		bucket = time.Minute
+	}
+	end := time.Now().UTC().Truncate(bucket).Add(bucket)
+	start := end.Add(-bucket * metricBuckets)
+
+	for _, name := range functionNames {
+		metrics[name] = cloud.FunctionMetrics{
+			Window:               window,
+			Bucket:               bucket,
+			Invocations:          make([]float64, metricBuckets),
+			Errors:               make([]float64, metricBuckets),
+			Throttles:            make([]float64, metricBuckets),
+			DurationP50:          make([]float64, metricBuckets),
+			DurationP99:          make([]float64, metricBuckets),
+			ConcurrentExecutions: make([]float64, metricBuckets),
+		}
+	}
+
+	functionsPerRequest := awscloudwatch.MaxMetricQueries / len(functionMetricQueries)
+	for first := 0; first < len(functionNames); first += functionsPerRequest {
+		last := min(first+functionsPerRequest, len(functionNames))
+
+		// Map each query ID to the series it fills in
+		var queries []cwtypes.MetricDataQuery
+		targets := make(map[string][]float64)
+		for i, name := range functionNames[first:last] {
+			for _, query := range functionMetricQueries {
+				id := fmt.Sprintf("%s_%d", query.id, first+i)
+				targets[id] = metricSeries(metrics[name], query.id)
+				queries = append(queries, cwtypes.MetricDataQuery{
+					Id: aws.String(id),
+					MetricStat: &cwtypes.MetricStat{
+						Metric: &cwtypes.Metric{
+							Namespace:  aws.String("AWS/Lambda"),
+							MetricName: aws.String(query.metric),
+							Dimensions: []cwtypes.Dimension{
+								{Name: aws.String("FunctionName"), Value: aws.String(name)},
+							},
+						},
+						Period: aws.Int32(int32(bucket.Seconds())),
+						Stat:   aws.String(query.stat),
+					},
+				})
+			}
+		}
+
+		paginator := cloudwatch.NewGetMetricDataPaginator(client, &cloudwatch.GetMetricDataInput{
+			MetricDataQueries: queries,
+			StartTime:         aws.Time(start),
+			EndTime:           aws.Time(end),
+		})
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrGetMetrics, err)
+			}
+
+			for _, result := range output.MetricDataResults {
+				series, ok := targets[aws.ToString(result.Id)]
+				if !ok {
+					continue
+				}
+
+				// Place each value in its bucket, leaving buckets without data as zero
+				for i, timestamp := range result.Timestamps {
+					position := int(timestamp.Sub(start) / bucket)
+					if i < len(result.Values) && position >= 0 && position < metricBuckets {
+						series[position] = result.Values[i]
+					}
+				}
+			}
+		}
+	}
+
+	return metrics, nil
+}
+
+// Execute executes the operation with the given parameters.
+func (o *FunctionMetricsOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	functionNames, ok := params["functionNames"].([]string)
+	if !ok {
+		return nil, fmt.Errorf("function names are required")
+	}
+
+	window, ok := params["window"].(time.Duration)
+	if !ok {
+		window = time.Hour
+	}
+
+	return o.GetFunctionMetrics(ctx, functionNames, window)
+}
+
+// metricSeries returns the series of a function's metrics that a query fills in.
+func metricSeries(metrics cloud.FunctionMetrics, queryID string) []float64 {
+	switch queryID {
+	case "invocations":
+		return metrics.Invocations
+	case "errors":
+		return metrics.Errors
+	case "throttles":
+		return metrics.Throttles
+	case "p50":
+		return metrics.DurationP50
+	case "p99":
+		return metrics.DurationP99
+	default:
+		return metrics.ConcurrentExecutions
+	}
+}
+
+// getCloudWatchClient creates a new CloudWatch client.
+func getCloudWatchClient(ctx context.Context, profile, region string) (*cloudwatch.Client, error) {
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithSharedConfigProfile(profile),
+		config.WithRegion(region),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLoadConfig, err)
+	}
+
+	return cloudwatch.NewFromConfig(cfg), nil
+}
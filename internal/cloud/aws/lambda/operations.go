@@ -5,6 +5,9 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/HenryOwenz/cloudgate/internal/cloud"
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -18,6 +21,9 @@ var (
 	ErrLoadConfig    = errors.New("failed to load AWS config")
 	ErrListFunctions = errors.New("failed to list functions")
 	ErrGetFunction   = errors.New("failed to get function details")
+	ErrListTags      = errors.New("failed to list function tags")
+
+	ErrDescribeThrottled = errors.New("not described while Lambda throttles requests")
 )
 
 // FunctionStatusOperation represents an operation to view Lambda function status.
@@ -66,50 +72,30 @@ func (o *FunctionStatusOperation) GetFunctionStatus(ctx context.Context) ([]clou
 	// Convert to cloud.FunctionStatus
 	functionStatuses := make([]cloud.FunctionStatus, len(functions))
 	for i, function := range functions {
-		memory := int32(0)
-		if function.MemorySize != nil {
-			memory = *function.MemorySize
-		}
-
-		timeout := int32(0)
-		if function.Timeout != nil {
-			timeout = *function.Timeout
-		}
-
-		// CodeSize is not a pointer in the AWS Lambda API
-		codeSize := function.CodeSize
+		functionStatuses[i] = toFunctionStatus(function)
+	}
 
-		// Get architecture (default to x86_64 if not specified)
-		architecture := "x86_64"
-		if len(function.Architectures) > 0 {
-			architecture = string(function.Architectures[0])
-		}
+	return functionStatuses, nil
+}
 
-		// Get log group if available
-		logGroup := ""
-		if function.LoggingConfig != nil && function.LoggingConfig.LogGroup != nil {
-			logGroup = *function.LoggingConfig.LogGroup
-		}
+// DescribeFunctions returns a copy of listed functions with their state and tags filled in.
+// Describing takes two requests per function, so functions are described as they're shown
+// rather than when the account's functions are listed.
+func (o *FunctionStatusOperation) DescribeFunctions(ctx context.Context, functions []cloud.FunctionStatus) ([]cloud.FunctionStatus, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
 
-		functionStatuses[i] = cloud.FunctionStatus{
-			Name:         aws.ToString(function.FunctionName),
-			Runtime:      string(function.Runtime),
-			Memory:       memory,
-			Timeout:      timeout,
-			LastUpdate:   aws.ToString(function.LastModified),
-			Role:         aws.ToString(function.Role),
-			Handler:      aws.ToString(function.Handler),
-			Description:  aws.ToString(function.Description),
-			FunctionArn:  aws.ToString(function.FunctionArn),
-			CodeSize:     codeSize,
-			Version:      aws.ToString(function.Version),
-			PackageType:  string(function.PackageType),
-			Architecture: architecture,
-			LogGroup:     logGroup,
-		}
+	described := make([]cloud.FunctionStatus, len(functions))
+	copy(described, functions)
+	for i := range described {
+		// Errors of an earlier attempt are replaced by those of this one
+		described[i].Errors = nil
 	}
+	describeFunctions(ctx, client, described)
 
-	return functionStatuses, nil
+	return described, nil
 }
 
 // Execute executes the operation with the given parameters.
@@ -117,17 +103,181 @@ func (o *FunctionStatusOperation) Execute(ctx context.Context, params map[string
 	return o.GetFunctionStatus(ctx)
 }
 
+// toFunctionStatus converts a Lambda function configuration to a cloud.FunctionStatus.
+func toFunctionStatus(function types.FunctionConfiguration) cloud.FunctionStatus {
+	memory := int32(0)
+	if function.MemorySize != nil {
+		memory = *function.MemorySize
+	}
+
+	timeout := int32(0)
+	if function.Timeout != nil {
+		timeout = *function.Timeout
+	}
+
+	// CodeSize is not a pointer in the AWS Lambda API
+	codeSize := function.CodeSize
+
+	// Get architecture (default to x86_64 if not specified)
+	architecture := "x86_64"
+	if len(function.Architectures) > 0 {
+		architecture = string(function.Architectures[0])
+	}
+
+	// Get log group if available
+	logGroup := ""
+	if function.LoggingConfig != nil && function.LoggingConfig.LogGroup != nil {
+		logGroup = *function.LoggingConfig.LogGroup
+	}
+
+	// Get ephemeral storage (defaults to 512 MB if not specified)
+	ephemeralStorage := int32(minEphemeralStorage)
+	if function.EphemeralStorage != nil && function.EphemeralStorage.Size != nil {
+		ephemeralStorage = *function.EphemeralStorage.Size
+	}
+
+	status := cloud.FunctionStatus{
+		Name:                   aws.ToString(function.FunctionName),
+		Runtime:                string(function.Runtime),
+		Memory:                 memory,
+		Timeout:                timeout,
+		LastUpdate:             aws.ToString(function.LastModified),
+		Role:                   aws.ToString(function.Role),
+		Handler:                aws.ToString(function.Handler),
+		Description:            aws.ToString(function.Description),
+		FunctionArn:            aws.ToString(function.FunctionArn),
+		CodeSize:               codeSize,
+		Version:                aws.ToString(function.Version),
+		PackageType:            string(function.PackageType),
+		Architecture:           architecture,
+		LogGroup:               logGroup,
+		EphemeralStorage:       ephemeralStorage,
+		State:                  string(function.State),
+		StateReason:            aws.ToString(function.StateReason),
+		LastUpdateStatus:       string(function.LastUpdateStatus),
+		LastUpdateStatusReason: aws.ToString(function.LastUpdateStatusReason),
+		KMSKeyArn:              aws.ToString(function.KMSKeyArn),
+		CodeSha256:             aws.ToString(function.CodeSha256),
+	}
+
+	for _, layer := range function.Layers {
+		status.Layers = append(status.Layers, aws.ToString(layer.Arn))
+	}
+
+	// Functions outside a VPC have an empty VPC config
+	if function.VpcConfig != nil && aws.ToString(function.VpcConfig.VpcId) != "" {
+		status.VpcConfig = &cloud.FunctionVpcConfig{
+			VpcID:            aws.ToString(function.VpcConfig.VpcId),
+			SubnetIDs:        function.VpcConfig.SubnetIds,
+			SecurityGroupIDs: function.VpcConfig.SecurityGroupIds,
+		}
+	}
+
+	if function.DeadLetterConfig != nil {
+		status.DeadLetterTarget = aws.ToString(function.DeadLetterConfig.TargetArn)
+	}
+	if function.TracingConfig != nil {
+		status.TracingMode = string(function.TracingConfig.Mode)
+	}
+	if function.SnapStart != nil {
+		status.SnapStart = string(function.SnapStart.ApplyOn)
+		status.SnapStartStatus = string(function.SnapStart.OptimizationStatus)
+	}
+	if function.RuntimeVersionConfig != nil {
+		status.RuntimeVersion = aws.ToString(function.RuntimeVersionConfig.RuntimeVersionArn)
+	}
+
+	return status
+}
+
+// lambdaTimeLayout is the layout of timestamps such as LastModified, e.g. 2024-06-29T07:10:02.331+0000.
+const lambdaTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// perFunctionConcurrency is how many per-function requests are made at once.
+const perFunctionConcurrency = 4
+
+// forEachConcurrently calls fn for each index up to count, perFunctionConcurrency at a time,
+// and waits for all calls to return.
+func forEachConcurrently(count int, fn func(i int)) {
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, perFunctionConcurrency)
+
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			fn(i)
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+// describeFunctions fills in the state of listed functions, which ListFunctions leaves out, with
+// GetFunctionConfiguration, and their tags with ListTags. What can't be described is kept from
+// ListFunctions and recorded in the function's Errors. Once Lambda throttles the calls, the
+// functions left aren't described at all, rather than adding to the throttling.
+func describeFunctions(ctx context.Context, client *lambda.Client, functions []cloud.FunctionStatus) {
+	var throttled atomic.Bool
+	forEachConcurrently(len(functions), func(i int) {
+		function := &functions[i]
+		if throttled.Load() {
+			function.Errors = append(function.Errors, ErrDescribeThrottled.Error())
+			return
+		}
+
+		output, err := client.GetFunctionConfiguration(ctx, &lambda.GetFunctionConfigurationInput{
+			FunctionName: aws.String(function.Name),
+		})
+		if err == nil {
+			function.State = string(output.State)
+			function.StateReason = aws.ToString(output.StateReason)
+			function.LastUpdateStatus = string(output.LastUpdateStatus)
+			function.LastUpdateStatusReason = aws.ToString(output.LastUpdateStatusReason)
+		} else {
+			function.Errors = append(function.Errors, fmt.Errorf("%w: %w", ErrGetFunction, err).Error())
+		}
+
+		tags, tagsErr := client.ListTags(ctx, &lambda.ListTagsInput{
+			Resource: aws.String(function.FunctionArn),
+		})
+		if tagsErr == nil {
+			function.Tags = tags.Tags
+		} else {
+			function.Errors = append(function.Errors, fmt.Errorf("%w: %w", ErrListTags, tagsErr).Error())
+		}
+
+		var tooManyRequests *types.TooManyRequestsException
+		if errors.As(err, &tooManyRequests) || errors.As(tagsErr, &tooManyRequests) {
+			throttled.Store(true)
+		}
+	})
+}
+
 // getClient creates a new Lambda client.
 func getClient(ctx context.Context, profile, region string) (*lambda.Client, error) {
+	cfg, err := loadConfig(ctx, profile, region)
+	if err != nil {
+		return nil, err
+	}
+
+	return lambda.NewFromConfig(cfg), nil
+}
+
+// loadConfig loads the AWS config for the given profile and region, for operations that need
+// clients of more than one service.
+func loadConfig(ctx context.Context, profile, region string) (aws.Config, error) {
 	cfg, err := config.LoadDefaultConfig(ctx,
 		config.WithSharedConfigProfile(profile),
 		config.WithRegion(region),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %w", ErrLoadConfig, err)
+		return aws.Config{}, fmt.Errorf("%w: %w", ErrLoadConfig, err)
 	}
 
-	return lambda.NewFromConfig(cfg), nil
+	return cfg, nil
 }
 
 // listFunctions returns a list of all Lambda functions.
@@ -157,6 +307,8 @@ func listFunctions(ctx context.Context, client *lambda.Client) ([]types.Function
 // Common errors for Lambda execution.
 var (
 	ErrInvokeFunction = errors.New("failed to invoke function")
+	ErrGetInvokeMode  = errors.New("failed to get function invoke mode")
+	ErrResponseStream = errors.New("failed to read response stream")
 )
 
 // LambdaExecuteOperation represents an operation to execute a Lambda function.
@@ -196,6 +348,24 @@ func (o *LambdaExecuteOperation) ExecuteFunction(ctx context.Context, functionNa
 		return nil, err
 	}
 
+	return invokeFunction(ctx, client, functionName, payload)
+}
+
+// Invoker returns a function that invokes a Lambda function with a client created once, so that
+// repeated invocations such as a benchmark's only make the Invoke request.
+func (o *LambdaExecuteOperation) Invoker(ctx context.Context, functionName string) (cloud.LambdaInvoker, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, payload string) (*cloud.LambdaExecuteResult, error) {
+		return invokeFunction(ctx, client, functionName, payload)
+	}, nil
+}
+
+// invokeFunction invokes a Lambda function with the given payload, including the tail of its log.
+func invokeFunction(ctx context.Context, client *lambda.Client, functionName string, payload string) (*cloud.LambdaExecuteResult, error) {
 	// Invoke the function
 	input := &lambda.InvokeInput{
 		FunctionName: aws.String(functionName),
@@ -229,8 +399,84 @@ func (o *LambdaExecuteOperation) ExecuteFunction(ctx context.Context, functionNa
 		ExecutedVersion: aws.ToString(output.ExecutedVersion),
 		Payload:         payloadStr,
 		LogResult:       logResult,
+		FunctionError:   aws.ToString(output.FunctionError),
+	}
+
+	return result, nil
+}
+
+// IsResponseStreaming returns whether a function's URL is configured to stream responses.
+// Functions without a URL are invoked with buffered responses.
+func (o *LambdaExecuteOperation) IsResponseStreaming(ctx context.Context, functionName string) (bool, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return false, err
+	}
+
+	output, err := client.GetFunctionUrlConfig(ctx, &lambda.GetFunctionUrlConfigInput{
+		FunctionName: aws.String(functionName),
+	})
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("%w: %w", ErrGetInvokeMode, err)
+	}
+
+	return output.InvokeMode == types.InvokeModeResponseStream, nil
+}
+
+// ExecuteFunctionStream executes a Lambda function with response streaming, calling onChunk
+// with each chunk of the payload as it arrives.
+func (o *LambdaExecuteOperation) ExecuteFunctionStream(ctx context.Context, functionName string, payload string, onChunk func(chunk []byte)) (*cloud.LambdaExecuteResult, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.InvokeWithResponseStream(ctx, &lambda.InvokeWithResponseStreamInput{
+		FunctionName: aws.String(functionName),
+		Payload:      []byte(payload),
+		LogType:      types.LogTypeTail, // Include logs in the completion event
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvokeFunction, err)
+	}
+
+	stream := output.GetStream()
+	defer stream.Close()
+
+	result := &cloud.LambdaExecuteResult{
+		StatusCode:      int(output.StatusCode),
+		ExecutedVersion: aws.ToString(output.ExecutedVersion),
+		Streamed:        true,
+	}
+
+	var body strings.Builder
+	for event := range stream.Events() {
+		switch event := event.(type) {
+		case *types.InvokeWithResponseStreamResponseEventMemberPayloadChunk:
+			body.Write(event.Value.Payload)
+			if onChunk != nil {
+				onChunk(event.Value.Payload)
+			}
+		case *types.InvokeWithResponseStreamResponseEventMemberInvokeComplete:
+			result.ErrorCode = aws.ToString(event.Value.ErrorCode)
+			result.ErrorDetails = aws.ToString(event.Value.ErrorDetails)
+			if event.Value.LogResult != nil {
+				if decodedLogs, err := base64.StdEncoding.DecodeString(*event.Value.LogResult); err == nil {
+					result.LogResult = string(decodedLogs)
+				}
+			}
+		}
+	}
+
+	if err := stream.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrResponseStream, err)
 	}
 
+	result.Payload = body.String()
 	return result, nil
 }
 
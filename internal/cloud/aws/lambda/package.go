@@ -0,0 +1,107 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// Package errors.
+var (
+	ErrDownloadPackage = errors.New("failed to download function package")
+	ErrImagePackage    = errors.New("function is deployed as a container image and has no package to download")
+)
+
+// FunctionPackageOperation represents an operation to download the deployment package of a Lambda function.
+type FunctionPackageOperation struct {
+	profile string
+	region  string
+}
+
+// NewFunctionPackageOperation creates a new function package operation.
+func NewFunctionPackageOperation(profile, region string) *FunctionPackageOperation {
+	return &FunctionPackageOperation{
+		profile: profile,
+		region:  region,
+	}
+}
+
+// Name returns the operation's name.
+func (o *FunctionPackageOperation) Name() string {
+	return "Function Package"
+}
+
+// Description returns the operation's description.
+func (o *FunctionPackageOperation) Description() string {
+	return "Download Lambda Deployment Packages"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+// Packages are browsed from the function details view rather than as a separate operation.
+func (o *FunctionPackageOperation) IsUIVisible() bool {
+	return false
+}
+
+// DownloadFunctionPackage downloads the zip archive a function is deployed from, using the
+// presigned URL GetFunction returns for it.
+func (o *FunctionPackageOperation) DownloadFunctionPackage(ctx context.Context, functionName string) (*cloud.FunctionPackage, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.GetFunction(ctx, &lambda.GetFunctionInput{
+		FunctionName: aws.String(functionName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetFunction, err)
+	}
+
+	if output.Configuration != nil && output.Configuration.PackageType == types.PackageTypeImage {
+		return nil, ErrImagePackage
+	}
+	if output.Code == nil || aws.ToString(output.Code.Location) == "" {
+		return nil, fmt.Errorf("%w: no code location for %s", ErrDownloadPackage, functionName)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, aws.ToString(output.Code.Location), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDownloadPackage, err)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDownloadPackage, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s", ErrDownloadPackage, response.Status)
+	}
+	archive, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDownloadPackage, err)
+	}
+
+	codeSha256 := ""
+	if output.Configuration != nil {
+		codeSha256 = aws.ToString(output.Configuration.CodeSha256)
+	}
+	return cloud.NewFunctionPackage(functionName, codeSha256, archive)
+}
+
+// Execute executes the operation with the given parameters.
+func (o *FunctionPackageOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	functionName, ok := params["functionName"].(string)
+	if !ok {
+		return nil, fmt.Errorf("function name is required")
+	}
+
+	return o.DownloadFunctionPackage(ctx, functionName)
+}
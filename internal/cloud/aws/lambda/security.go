@@ -0,0 +1,421 @@
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// Security review errors.
+var (
+	ErrGetFunctionURL = errors.New("failed to get function URL")
+	ErrGetPolicy      = errors.New("failed to get resource-based policy")
+	ErrParsePolicy    = errors.New("failed to parse resource-based policy")
+	ErrListRolePolicy = errors.New("failed to list execution role policies")
+)
+
+// sourceConditionKeys are the condition keys that limit a grant to a specific caller, so a
+// wildcard or service principal that is conditioned on one of them isn't open to everyone.
+var sourceConditionKeys = map[string]bool{
+	"aws:sourcearn":           true,
+	"aws:sourceaccount":       true,
+	"aws:sourceowner":         true,
+	"aws:principalorgid":      true,
+	"aws:principalaccount":    true,
+	"aws:principalarn":        true,
+	"lambda:eventsourcetoken": true,
+}
+
+// highRiskRolePolicies are managed policies that give a function control over the whole account.
+var highRiskRolePolicies = map[string]bool{
+	"AdministratorAccess": true,
+	"PowerUserAccess":     true,
+	"IAMFullAccess":       true,
+}
+
+// FunctionSecurityOperation represents an operation to review who can invoke a Lambda function
+// and what its execution role allows.
+type FunctionSecurityOperation struct {
+	profile string
+	region  string
+}
+
+// NewFunctionSecurityOperation creates a new function security operation.
+func NewFunctionSecurityOperation(profile, region string) *FunctionSecurityOperation {
+	return &FunctionSecurityOperation{
+		profile: profile,
+		region:  region,
+	}
+}
+
+// Name returns the operation's name.
+func (o *FunctionSecurityOperation) Name() string {
+	return "Function Security"
+}
+
+// Description returns the operation's description.
+func (o *FunctionSecurityOperation) Description() string {
+	return "Review Lambda Function Permissions"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+// Security is reviewed from the function details view rather than as a separate operation.
+func (o *FunctionSecurityOperation) IsUIVisible() bool {
+	return false
+}
+
+// GetFunctionSecurity returns the function URL, resource-based policy and execution role policies
+// of a function, with public or over-permissive grants flagged. Parts that can't be loaded, usually
+// for lack of a permission, are reported as errors rather than failing the whole review.
+func (o *FunctionSecurityOperation) GetFunctionSecurity(ctx context.Context, functionName string) (*cloud.FunctionSecurity, error) {
+	cfg, err := loadConfig(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+	client := lambda.NewFromConfig(cfg)
+
+	function, err := client.GetFunctionConfiguration(ctx, &lambda.GetFunctionConfigurationInput{
+		FunctionName: aws.String(functionName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetFunction, err)
+	}
+
+	security := &cloud.FunctionSecurity{
+		FunctionName:     functionName,
+		Role:             aws.ToString(function.Role),
+		PolicyStatements: []cloud.PolicyStatement{},
+		RolePolicies:     []cloud.RolePolicy{},
+		Findings:         []cloud.SecurityFinding{},
+	}
+
+	functionURL, err := getFunctionURL(ctx, client, functionName)
+	if err != nil {
+		security.Errors = append(security.Errors, err.Error())
+	}
+	security.FunctionURL = functionURL
+
+	statements, err := getPolicyStatements(ctx, client, functionName)
+	if err != nil {
+		security.Errors = append(security.Errors, err.Error())
+	}
+	security.PolicyStatements = append(security.PolicyStatements, statements...)
+
+	if security.Role != "" {
+		policies, err := listRolePolicies(ctx, iam.NewFromConfig(cfg), security.Role)
+		if err != nil {
+			security.Errors = append(security.Errors, err.Error())
+		}
+		security.RolePolicies = append(security.RolePolicies, policies...)
+	}
+
+	security.Findings = securityFindings(security)
+	return security, nil
+}
+
+// Execute executes the operation with the given parameters.
+func (o *FunctionSecurityOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	functionName, ok := params["functionName"].(string)
+	if !ok {
+		return nil, fmt.Errorf("function name is required")
+	}
+
+	return o.GetFunctionSecurity(ctx, functionName)
+}
+
+// getFunctionURL returns the function URL of a function, or nil if it has none.
+func getFunctionURL(ctx context.Context, client *lambda.Client, functionName string) (*cloud.FunctionURLConfig, error) {
+	output, err := client.GetFunctionUrlConfig(ctx, &lambda.GetFunctionUrlConfigInput{
+		FunctionName: aws.String(functionName),
+	})
+	var notFound *types.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetFunctionURL, err)
+	}
+
+	functionURL := &cloud.FunctionURLConfig{
+		URL:        aws.ToString(output.FunctionUrl),
+		AuthType:   string(output.AuthType),
+		InvokeMode: string(output.InvokeMode),
+	}
+	if output.Cors != nil {
+		functionURL.Cors = &cloud.FunctionURLCors{
+			AllowOrigins:     output.Cors.AllowOrigins,
+			AllowMethods:     output.Cors.AllowMethods,
+			AllowHeaders:     output.Cors.AllowHeaders,
+			ExposeHeaders:    output.Cors.ExposeHeaders,
+			AllowCredentials: aws.ToBool(output.Cors.AllowCredentials),
+			MaxAge:           aws.ToInt32(output.Cors.MaxAge),
+		}
+	}
+	return functionURL, nil
+}
+
+// getPolicyStatements returns the statements of a function's resource-based policy, or none if
+// it has no policy.
+func getPolicyStatements(ctx context.Context, client *lambda.Client, functionName string) ([]cloud.PolicyStatement, error) {
+	output, err := client.GetPolicy(ctx, &lambda.GetPolicyInput{
+		FunctionName: aws.String(functionName),
+	})
+	var notFound *types.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetPolicy, err)
+	}
+
+	return parsePolicy(aws.ToString(output.Policy))
+}
+
+// policyDocument is an IAM policy document. Statement, Principal, Action and condition values
+// may each be given as a single value or a list, so they're decoded by hand.
+type policyDocument struct {
+	Statement json.RawMessage `json:"Statement"`
+}
+
+// policyStatement is a statement of an IAM policy document.
+type policyStatement struct {
+	Sid       string                                `json:"Sid"`
+	Effect    string                                `json:"Effect"`
+	Principal json.RawMessage                       `json:"Principal"`
+	Action    json.RawMessage                       `json:"Action"`
+	Condition map[string]map[string]json.RawMessage `json:"Condition"`
+}
+
+// parsePolicy parses a resource-based policy into readable statements.
+func parsePolicy(policy string) ([]cloud.PolicyStatement, error) {
+	if policy == "" {
+		return nil, nil
+	}
+
+	var document policyDocument
+	if err := json.Unmarshal([]byte(policy), &document); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrParsePolicy, err)
+	}
+
+	var raw []policyStatement
+	if len(document.Statement) > 0 && document.Statement[0] == '{' {
+		var single policyStatement
+		if err := json.Unmarshal(document.Statement, &single); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrParsePolicy, err)
+		}
+		raw = append(raw, single)
+	} else if len(document.Statement) > 0 {
+		if err := json.Unmarshal(document.Statement, &raw); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrParsePolicy, err)
+		}
+	}
+
+	statements := make([]cloud.PolicyStatement, 0, len(raw))
+	for _, statement := range raw {
+		statements = append(statements, cloud.PolicyStatement{
+			Sid:        statement.Sid,
+			Effect:     statement.Effect,
+			Principals: parsePrincipals(statement.Principal),
+			Actions:    stringOrList(statement.Action),
+			Conditions: parseConditions(statement.Condition),
+		})
+	}
+	return statements, nil
+}
+
+// parsePrincipals returns the principals of a statement, "*" for anyone and otherwise prefixed
+// with their type, e.g. "Service: s3.amazonaws.com".
+func parsePrincipals(principal json.RawMessage) []string {
+	if values := stringOrList(principal); values != nil {
+		return values
+	}
+
+	var typed map[string]json.RawMessage
+	if err := json.Unmarshal(principal, &typed); err != nil {
+		return nil
+	}
+	var principals []string
+	for principalType, value := range typed {
+		for _, id := range stringOrList(value) {
+			if id == "*" {
+				principals = append(principals, "*")
+				continue
+			}
+			principals = append(principals, principalType+": "+id)
+		}
+	}
+	sort.Strings(principals)
+	return principals
+}
+
+// parseConditions returns the conditions of a statement as "operator key value" strings, sorted
+// so they read the same every time.
+func parseConditions(conditions map[string]map[string]json.RawMessage) []string {
+	var parsed []string
+	for operator, keys := range conditions {
+		for key, value := range keys {
+			parsed = append(parsed, fmt.Sprintf("%s %s %s", operator, key, strings.Join(stringOrList(value), ", ")))
+		}
+	}
+	sort.Strings(parsed)
+	return parsed
+}
+
+// stringOrList decodes a JSON value that is either a string or a list of strings. It returns nil
+// for anything else.
+func stringOrList(value json.RawMessage) []string {
+	var single string
+	if err := json.Unmarshal(value, &single); err == nil {
+		return []string{single}
+	}
+	var list []string
+	if err := json.Unmarshal(value, &list); err == nil {
+		return list
+	}
+	return nil
+}
+
+// listRolePolicies returns the managed and inline policies of an execution role.
+func listRolePolicies(ctx context.Context, client *iam.Client, roleArn string) ([]cloud.RolePolicy, error) {
+	role, err := roleName(roleArn)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []cloud.RolePolicy
+	attached := iam.NewListAttachedRolePoliciesPaginator(client, &iam.ListAttachedRolePoliciesInput{
+		RoleName: aws.String(role),
+	})
+	for attached.HasMorePages() {
+		page, err := attached.NextPage(ctx)
+		if err != nil {
+			return policies, fmt.Errorf("%w: %w", ErrListRolePolicy, err)
+		}
+		for _, policy := range page.AttachedPolicies {
+			policies = append(policies, cloud.RolePolicy{
+				Name: aws.ToString(policy.PolicyName),
+				Arn:  aws.ToString(policy.PolicyArn),
+			})
+		}
+	}
+
+	inline := iam.NewListRolePoliciesPaginator(client, &iam.ListRolePoliciesInput{
+		RoleName: aws.String(role),
+	})
+	for inline.HasMorePages() {
+		page, err := inline.NextPage(ctx)
+		if err != nil {
+			return policies, fmt.Errorf("%w: %w", ErrListRolePolicy, err)
+		}
+		for _, name := range page.PolicyNames {
+			policies = append(policies, cloud.RolePolicy{Name: name})
+		}
+	}
+
+	return policies, nil
+}
+
+// roleName returns the name of a role from its ARN, e.g. my-role for
+// arn:aws:iam::123456789012:role/service-role/my-role, dropping any path.
+func roleName(roleArn string) (string, error) {
+	parsed, err := arn.Parse(roleArn)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrListRolePolicy, err)
+	}
+	resourceType, path, found := strings.Cut(parsed.Resource, "/")
+	if parsed.Service != "iam" || resourceType != "role" || !found {
+		return "", fmt.Errorf("%w: %s is not a role ARN", ErrListRolePolicy, roleArn)
+	}
+	return path[strings.LastIndex(path, "/")+1:], nil
+}
+
+// securityFindings flags public or over-permissive grants, most urgent first.
+func securityFindings(security *cloud.FunctionSecurity) []cloud.SecurityFinding {
+	findings := []cloud.SecurityFinding{}
+	add := func(severity, subject, detail string) {
+		findings = append(findings, cloud.SecurityFinding{Severity: severity, Subject: subject, Detail: detail})
+	}
+
+	if url := security.FunctionURL; url != nil {
+		if url.AuthType == string(types.FunctionUrlAuthTypeNone) {
+			add(cloud.SeverityHigh, "Function URL", "Auth type is NONE, so anyone who knows the URL can invoke the function")
+		}
+		if url.Cors != nil && containsString(url.Cors.AllowOrigins, "*") {
+			if url.Cors.AllowCredentials {
+				add(cloud.SeverityHigh, "CORS", "Any origin may call the URL with credentials")
+			} else {
+				add(cloud.SeverityMedium, "CORS", "Any origin may call the URL")
+			}
+		}
+	}
+
+	for i, statement := range security.PolicyStatements {
+		if statement.Effect != "Allow" {
+			continue
+		}
+		subject := statement.Sid
+		if subject == "" {
+			subject = fmt.Sprintf("Statement %d", i+1)
+		}
+
+		restricted := hasSourceCondition(statement.Conditions)
+		for _, principal := range statement.Principals {
+			switch {
+			case principal == "*" && !restricted:
+				add(cloud.SeverityHigh, subject, "Any AWS account or anonymous caller is allowed")
+			case strings.HasPrefix(principal, "Service: ") && !restricted:
+				add(cloud.SeverityMedium, subject, fmt.Sprintf("%s is allowed without a source ARN or account condition", strings.TrimPrefix(principal, "Service: ")))
+			}
+		}
+		for _, action := range statement.Actions {
+			if action == "*" || action == "lambda:*" {
+				add(cloud.SeverityMedium, subject, fmt.Sprintf("Grants every Lambda action (%s)", action))
+			}
+		}
+	}
+
+	for _, policy := range security.RolePolicies {
+		switch {
+		case highRiskRolePolicies[policy.Name]:
+			add(cloud.SeverityHigh, "Execution role", fmt.Sprintf("%s gives the function broad control of the account", policy.Name))
+		case !policy.IsInline() && strings.HasSuffix(policy.Name, "FullAccess"):
+			add(cloud.SeverityMedium, "Execution role", fmt.Sprintf("%s grants full access to a service", policy.Name))
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return severityOrder[findings[i].Severity] < severityOrder[findings[j].Severity]
+	})
+	return findings
+}
+
+// hasSourceCondition returns whether any of a statement's conditions limits it to a specific caller.
+func hasSourceCondition(conditions []string) bool {
+	for _, condition := range conditions {
+		fields := strings.Fields(condition)
+		if len(fields) > 1 && sourceConditionKeys[strings.ToLower(fields[1])] {
+			return true
+		}
+	}
+	return false
+}
+
+// containsString returns whether a list holds a value.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package lambda
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+)
+
+func TestParsePolicy(t *testing.T) {
+	testCases := []struct {
+		name    string
+		policy  string
+		want    []cloud.PolicyStatement
+		wantErr bool
+	}{
+		{
+			name:   "No policy",
+			policy: "",
+		},
+		{
+			name: "Single statement with a service principal and a source condition",
+			policy: `{"Version":"2012-10-17","Statement":{"Sid":"s3","Effect":"Allow",
+				"Principal":{"Service":"s3.amazonaws.com"},"Action":"lambda:InvokeFunction",
+				"Condition":{"ArnLike":{"AWS:SourceArn":"arn:aws:s3:::uploads"}}}}`,
+			want: []cloud.PolicyStatement{{
+				Sid:        "s3",
+				Effect:     "Allow",
+				Principals: []string{"Service: s3.amazonaws.com"},
+				Actions:    []string{"lambda:InvokeFunction"},
+				Conditions: []string{"ArnLike AWS:SourceArn arn:aws:s3:::uploads"},
+			}},
+		},
+		{
+			name: "List of statements with wildcard and account principals",
+			policy: `{"Statement":[
+				{"Effect":"Allow","Principal":"*","Action":["lambda:InvokeFunction","lambda:GetFunction"]},
+				{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::111122223333:root","*"]},"Action":"lambda:*"}]}`,
+			want: []cloud.PolicyStatement{
+				{
+					Effect:     "Allow",
+					Principals: []string{"*"},
+					Actions:    []string{"lambda:InvokeFunction", "lambda:GetFunction"},
+				},
+				{
+					Effect:     "Allow",
+					Principals: []string{"*", "AWS: arn:aws:iam::111122223333:root"},
+					Actions:    []string{"lambda:*"},
+				},
+			},
+		},
+		{
+			name:    "Invalid JSON",
+			policy:  `{"Statement":`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parsePolicy(tc.policy)
+			if tc.wantErr {
+				if !errors.Is(err, ErrParsePolicy) {
+					t.Fatalf("Expected an ErrParsePolicy, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(got) != len(tc.want) || (len(got) > 0 && !reflect.DeepEqual(got, tc.want)) {
+				t.Errorf("Expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSecurityFindings(t *testing.T) {
+	testCases := []struct {
+		name     string
+		security cloud.FunctionSecurity
+		want     []cloud.SecurityFinding
+	}{
+		{
+			name: "Private function",
+			security: cloud.FunctionSecurity{
+				FunctionURL:  &cloud.FunctionURLConfig{AuthType: "AWS_IAM"},
+				RolePolicies: []cloud.RolePolicy{{Name: "AWSLambdaBasicExecutionRole", Arn: "arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole"}},
+			},
+		},
+		{
+			name: "Public URL with credentialed CORS",
+			security: cloud.FunctionSecurity{
+				FunctionURL: &cloud.FunctionURLConfig{
+					AuthType: "NONE",
+					Cors:     &cloud.FunctionURLCors{AllowOrigins: []string{"*"}, AllowCredentials: true},
+				},
+			},
+			want: []cloud.SecurityFinding{
+				{Severity: cloud.SeverityHigh, Subject: "Function URL", Detail: "Auth type is NONE, so anyone who knows the URL can invoke the function"},
+				{Severity: cloud.SeverityHigh, Subject: "CORS", Detail: "Any origin may call the URL with credentials"},
+			},
+		},
+		{
+			name: "Policy statements",
+			security: cloud.FunctionSecurity{
+				PolicyStatements: []cloud.PolicyStatement{
+					{Sid: "anyone", Effect: "Allow", Principals: []string{"*"}, Actions: []string{"lambda:InvokeFunction"}},
+					{Sid: "s3", Effect: "Allow", Principals: []string{"Service: s3.amazonaws.com"}, Actions: []string{"lambda:InvokeFunction"},
+						Conditions: []string{"ArnLike AWS:SourceArn arn:aws:s3:::uploads"}},
+					{Effect: "Allow", Principals: []string{"Service: sns.amazonaws.com"}, Actions: []string{"lambda:*"}},
+					{Sid: "denied", Effect: "Deny", Principals: []string{"*"}, Actions: []string{"*"}},
+				},
+			},
+			want: []cloud.SecurityFinding{
+				{Severity: cloud.SeverityHigh, Subject: "anyone", Detail: "Any AWS account or anonymous caller is allowed"},
+				{Severity: cloud.SeverityMedium, Subject: "Statement 3", Detail: "sns.amazonaws.com is allowed without a source ARN or account condition"},
+				{Severity: cloud.SeverityMedium, Subject: "Statement 3", Detail: "Grants every Lambda action (lambda:*)"},
+			},
+		},
+		{
+			name: "Execution role",
+			security: cloud.FunctionSecurity{
+				RolePolicies: []cloud.RolePolicy{
+					{Name: "AmazonS3FullAccess", Arn: "arn:aws:iam::aws:policy/AmazonS3FullAccess"},
+					{Name: "AdministratorAccess", Arn: "arn:aws:iam::aws:policy/AdministratorAccess"},
+					{Name: "InlineFullAccess"},
+				},
+			},
+			want: []cloud.SecurityFinding{
+				{Severity: cloud.SeverityHigh, Subject: "Execution role", Detail: "AdministratorAccess gives the function broad control of the account"},
+				{Severity: cloud.SeverityMedium, Subject: "Execution role", Detail: "AmazonS3FullAccess grants full access to a service"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := securityFindings(&tc.security)
+			if len(got) != len(tc.want) || (len(got) > 0 && !reflect.DeepEqual(got, tc.want)) {
+				t.Errorf("Expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRoleName(t *testing.T) {
+	testCases := []struct {
+		arn     string
+		want    string
+		wantErr bool
+	}{
+		{arn: "arn:aws:iam::123456789012:role/orders-role", want: "orders-role"},
+		{arn: "arn:aws:iam::123456789012:role/service-role/orders-role", want: "orders-role"},
+		{arn: "arn:aws-cn:iam::123456789012:role/orders-role", want: "orders-role"},
+		{arn: "arn:aws:iam::123456789012:user/orders", wantErr: true},
+		{arn: "orders-role", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.arn, func(t *testing.T) {
+			got, err := roleName(tc.arn)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Expected error %v, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("Expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -5,8 +5,18 @@ import (
 	"fmt"
 
 	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/cloudformation"
+	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/cloudwatch"
+	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/codebuild"
 	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/codepipeline"
+	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/ec2"
+	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/ecs"
 	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/lambda"
+	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/s3"
+	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/secretsmanager"
+	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/sns"
+	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/sqs"
+	"github.com/HenryOwenz/cloudgate/internal/cloud/aws/ssm"
 )
 
 // Common errors
@@ -58,6 +68,16 @@ func (p *Provider) LoadConfig(profile, region string) error {
 	p.services = make([]cloud.Service, 0)
 	p.services = append(p.services, lambda.NewService(profile, region))
 	p.services = append(p.services, codepipeline.NewService(profile, region))
+	p.services = append(p.services, s3.NewService(profile, region))
+	p.services = append(p.services, ec2.NewService(profile, region))
+	p.services = append(p.services, cloudwatch.NewService(profile, region))
+	p.services = append(p.services, ecs.NewService(profile, region))
+	p.services = append(p.services, codebuild.NewService(profile, region))
+	p.services = append(p.services, cloudformation.NewService(profile, region))
+	p.services = append(p.services, ssm.NewService(profile, region))
+	p.services = append(p.services, secretsmanager.NewService(profile, region))
+	p.services = append(p.services, sqs.NewService(profile, region))
+	p.services = append(p.services, sns.NewService(profile, region))
 
 	return nil
 }
@@ -78,6 +98,166 @@ func (p *Provider) GetLambdaExecuteOperation() (cloud.LambdaExecuteOperation, er
 	return lambda.NewLambdaExecuteOperation(p.profile, p.region), nil
 }
 
+// GetLambdaConfigurationOperation returns the Lambda configuration operation
+func (p *Provider) GetLambdaConfigurationOperation() (cloud.LambdaConfigurationOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return lambda.NewFunctionConfigurationOperation(p.profile, p.region), nil
+}
+
+// GetLambdaDeployOperation returns the Lambda code deployment operation
+func (p *Provider) GetLambdaDeployOperation() (cloud.LambdaDeployOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return lambda.NewDeployCodeOperation(p.profile, p.region), nil
+}
+
+// GetLambdaMetricsOperation returns the Lambda metrics operation
+func (p *Provider) GetLambdaMetricsOperation() (cloud.LambdaMetricsOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return lambda.NewFunctionMetricsOperation(p.profile, p.region), nil
+}
+
+// GetLambdaEventSourceOperation returns the Lambda event source mapping operation
+func (p *Provider) GetLambdaEventSourceOperation() (cloud.LambdaEventSourceOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return lambda.NewEventSourceMappingOperation(p.profile, p.region), nil
+}
+
+// GetLambdaHygieneOperation returns the Lambda hygiene report operation
+func (p *Provider) GetLambdaHygieneOperation() (cloud.LambdaHygieneOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return lambda.NewHygieneReportOperation(p.profile, p.region), nil
+}
+
+// GetLambdaPackageOperation returns the Lambda deployment package operation
+func (p *Provider) GetLambdaPackageOperation() (cloud.LambdaPackageOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return lambda.NewFunctionPackageOperation(p.profile, p.region), nil
+}
+
+// GetLambdaSecurityOperation returns the Lambda security review operation
+func (p *Provider) GetLambdaSecurityOperation() (cloud.LambdaSecurityOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return lambda.NewFunctionSecurityOperation(p.profile, p.region), nil
+}
+
+// GetLambdaAsyncOperation returns the Lambda asynchronous invocation operation
+func (p *Provider) GetLambdaAsyncOperation() (cloud.LambdaAsyncOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return lambda.NewAsyncInvokeOperation(p.profile, p.region), nil
+}
+
+// GetS3BrowserOperation returns the S3 bucket and object browser operation
+func (p *Provider) GetS3BrowserOperation() (cloud.S3BrowserOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return s3.NewBucketBrowserOperation(p.profile, p.region), nil
+}
+
+// GetS3TransferOperation returns the S3 object transfer operation
+func (p *Provider) GetS3TransferOperation() (cloud.S3TransferOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return s3.NewObjectTransferOperation(p.profile, p.region), nil
+}
+
+// GetEC2InstanceOperation returns the EC2 instance operation
+func (p *Provider) GetEC2InstanceOperation() (cloud.EC2InstanceOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return ec2.NewInstanceOperation(p.profile, p.region), nil
+}
+
+// GetLogsInsightsOperation returns the CloudWatch Logs Insights operation
+func (p *Provider) GetLogsInsightsOperation() (cloud.LogsInsightsOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return cloudwatch.NewInsightsOperation(p.profile, p.region), nil
+}
+
+// GetAlarmOperation returns the CloudWatch alarm operation
+func (p *Provider) GetAlarmOperation() (cloud.AlarmOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return cloudwatch.NewAlarmsOperation(p.profile, p.region), nil
+}
+
+// GetECSServiceOperation returns the ECS service operation
+func (p *Provider) GetECSServiceOperation() (cloud.ECSServiceOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return ecs.NewServiceOperation(p.profile, p.region), nil
+}
+
+// GetCodeBuildOperation returns the CodeBuild project and build operation
+func (p *Provider) GetCodeBuildOperation() (cloud.CodeBuildOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return codebuild.NewBuildOperation(p.profile, p.region), nil
+}
+
+// GetCloudFormationOperation returns the CloudFormation stack operation
+func (p *Provider) GetCloudFormationOperation() (cloud.CloudFormationOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return cloudformation.NewStackOperation(p.profile, p.region), nil
+}
+
+// GetParameterStoreOperation returns the Systems Manager Parameter Store operation
+func (p *Provider) GetParameterStoreOperation() (cloud.ParameterStoreOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return ssm.NewParameterOperation(p.profile, p.region), nil
+}
+
+// GetSecretsManagerOperation returns the Secrets Manager secret operation
+func (p *Provider) GetSecretsManagerOperation() (cloud.SecretsManagerOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return secretsmanager.NewSecretOperation(p.profile, p.region), nil
+}
+
+// GetQueueOperation returns the SQS queue operation
+func (p *Provider) GetQueueOperation() (cloud.QueueOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return sqs.NewQueueOperation(p.profile, p.region), nil
+}
+
+// GetTopicOperation returns the SNS topic operation
+func (p *Provider) GetTopicOperation() (cloud.TopicOperation, error) {
+	if p.profile == "" || p.region == "" {
+		return nil, ErrNotAuthenticated
+	}
+	return sns.NewTopicOperation(p.profile, p.region), nil
+}
+
 // GetCodePipelineManualApprovalOperation returns the CodePipeline manual approval operation
 func (p *Provider) GetCodePipelineManualApprovalOperation() (cloud.CodePipelineManualApprovalOperation, error) {
 	if p.profile == "" || p.region == "" {
@@ -0,0 +1,247 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// Common errors.
+var (
+	ErrLoadConfig       = errors.New("failed to load AWS config")
+	ErrListBuckets      = errors.New("failed to list buckets")
+	ErrGetBucketRegion  = errors.New("failed to get bucket region")
+	ErrListObjects      = errors.New("failed to list objects")
+	ErrGetObjectDetails = errors.New("failed to get object details")
+)
+
+const (
+	// delimiter groups keys into prefixes that are browsed like directories.
+	delimiter = "/"
+
+	// maxListKeys is the most keys and prefixes S3 returns in a single listing.
+	maxListKeys = 1000
+)
+
+// BucketBrowserOperation represents an operation to browse S3 buckets and the objects in them.
+type BucketBrowserOperation struct {
+	profile string
+	region  string
+}
+
+// NewBucketBrowserOperation creates a new bucket browser operation.
+func NewBucketBrowserOperation(profile, region string) *BucketBrowserOperation {
+	return &BucketBrowserOperation{
+		profile: profile,
+		region:  region,
+	}
+}
+
+// Name returns the operation's name.
+func (o *BucketBrowserOperation) Name() string {
+	return "Browse Buckets"
+}
+
+// Description returns the operation's description.
+func (o *BucketBrowserOperation) Description() string {
+	return "Browse Buckets, Prefixes and Objects"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+func (o *BucketBrowserOperation) IsUIVisible() bool {
+	return true
+}
+
+// ListBuckets returns the buckets of the account, in name order. Buckets live in a region of
+// their own, which is looked up when S3 doesn't return it with the listing.
+func (o *BucketBrowserOperation) ListBuckets(ctx context.Context) ([]cloud.S3Bucket, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	var buckets []cloud.S3Bucket
+	paginator := s3.NewListBucketsPaginator(client, &s3.ListBucketsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrListBuckets, err)
+		}
+		for _, bucket := range output.Buckets {
+			buckets = append(buckets, cloud.S3Bucket{
+				Name:         aws.ToString(bucket.Name),
+				Region:       aws.ToString(bucket.BucketRegion),
+				CreationDate: aws.ToTime(bucket.CreationDate),
+			})
+		}
+	}
+
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Name < buckets[j].Name
+	})
+	return buckets, nil
+}
+
+// ListObjects returns a page of up to limit objects and prefixes directly under a prefix. The
+// continuation token of the page after it is returned with the page, so pages are only listed
+// when they're asked for.
+func (o *BucketBrowserOperation) ListObjects(ctx context.Context, bucket cloud.S3Bucket, prefix, token string, limit int) (*cloud.S3ObjectPage, error) {
+	client, err := bucketClient(ctx, o.profile, o.region, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &s3.ListObjectsV2Input{
+		Bucket:    aws.String(bucket.Name),
+		Delimiter: aws.String(delimiter),
+		MaxKeys:   aws.Int32(int32(min(limit, maxListKeys))),
+	}
+	if prefix != "" {
+		input.Prefix = aws.String(prefix)
+	}
+	if token != "" {
+		input.ContinuationToken = aws.String(token)
+	}
+
+	output, err := client.ListObjectsV2(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrListObjects, err)
+	}
+
+	page := &cloud.S3ObjectPage{Prefix: prefix}
+	for _, commonPrefix := range output.CommonPrefixes {
+		page.Objects = append(page.Objects, cloud.S3Object{
+			Key:      aws.ToString(commonPrefix.Prefix),
+			IsPrefix: true,
+		})
+	}
+	for _, object := range output.Contents {
+		key := aws.ToString(object.Key)
+		// Consoles create an empty object named after a prefix to show it as a folder
+		if key == prefix {
+			continue
+		}
+		page.Objects = append(page.Objects, cloud.S3Object{
+			Key:          key,
+			Size:         aws.ToInt64(object.Size),
+			StorageClass: storageClass(string(object.StorageClass)),
+			LastModified: aws.ToTime(object.LastModified),
+		})
+	}
+	if aws.ToBool(output.IsTruncated) {
+		page.NextToken = aws.ToString(output.NextContinuationToken)
+	}
+
+	return page, nil
+}
+
+// GetObjectDetails returns the properties, user metadata and tags of an object. Tags need a
+// permission of their own, so failing to read them is reported with the details.
+func (o *BucketBrowserOperation) GetObjectDetails(ctx context.Context, bucket cloud.S3Bucket, key string) (*cloud.S3ObjectDetails, error) {
+	client, err := bucketClient(ctx, o.profile, o.region, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket.Name),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetObjectDetails, err)
+	}
+
+	details := &cloud.S3ObjectDetails{
+		Bucket:               bucket.Name,
+		Key:                  key,
+		Size:                 aws.ToInt64(head.ContentLength),
+		ContentType:          aws.ToString(head.ContentType),
+		StorageClass:         storageClass(string(head.StorageClass)),
+		LastModified:         aws.ToTime(head.LastModified),
+		ETag:                 aws.ToString(head.ETag),
+		VersionID:            aws.ToString(head.VersionId),
+		ServerSideEncryption: string(head.ServerSideEncryption),
+		Metadata:             head.Metadata,
+		Tags:                 make(map[string]string),
+	}
+
+	tagging, err := client.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
+		Bucket: aws.String(bucket.Name),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		details.TagsError = err.Error()
+		return details, nil
+	}
+	for _, tag := range tagging.TagSet {
+		details.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+
+	return details, nil
+}
+
+// Execute executes the operation with the given parameters.
+func (o *BucketBrowserOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return o.ListBuckets(ctx)
+}
+
+// bucketClient creates a client for the region a bucket lives in, as S3 refuses requests for
+// buckets sent to another region.
+func bucketClient(ctx context.Context, profile, region string, bucket cloud.S3Bucket) (*s3.Client, error) {
+	if bucket.Region != "" {
+		return getClient(ctx, profile, bucket.Region)
+	}
+
+	client, err := getClient(ctx, profile, region)
+	if err != nil {
+		return nil, err
+	}
+	location, err := client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{
+		Bucket: aws.String(bucket.Name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetBucketRegion, err)
+	}
+	return getClient(ctx, profile, bucketRegion(location.LocationConstraint))
+}
+
+// getClient creates a new S3 client.
+func getClient(ctx context.Context, profile, region string) (*s3.Client, error) {
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithSharedConfigProfile(profile),
+		config.WithRegion(region),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLoadConfig, err)
+	}
+
+	return s3.NewFromConfig(cfg), nil
+}
+
+// bucketRegion returns the region of a bucket's location constraint, which is empty for
+// us-east-1 and EU for the oldest buckets in eu-west-1.
+func bucketRegion(constraint types.BucketLocationConstraint) string {
+	switch constraint {
+	case "":
+		return "us-east-1"
+	case types.BucketLocationConstraintEu:
+		return "eu-west-1"
+	default:
+		return string(constraint)
+	}
+}
+
+// storageClass returns the storage class of an object, which S3 leaves out for STANDARD.
+func storageClass(class string) string {
+	if class == "" {
+		return string(types.StorageClassStandard)
+	}
+	return class
+}
@@ -0,0 +1,47 @@
+package s3
+
+import (
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+)
+
+// BucketsCategory represents the S3 buckets category.
+type BucketsCategory struct {
+	profile    string
+	region     string
+	operations []cloud.Operation
+}
+
+// NewBucketsCategory creates a new S3 buckets category.
+func NewBucketsCategory(profile, region string) *BucketsCategory {
+	category := &BucketsCategory{
+		profile:    profile,
+		region:     region,
+		operations: make([]cloud.Operation, 0),
+	}
+
+	// Register operations
+	category.operations = append(category.operations, NewBucketBrowserOperation(profile, region))
+	category.operations = append(category.operations, NewObjectTransferOperation(profile, region))
+
+	return category
+}
+
+// Name returns the category's name.
+func (c *BucketsCategory) Name() string {
+	return "Buckets"
+}
+
+// Description returns the category's description.
+func (c *BucketsCategory) Description() string {
+	return "S3 Buckets and Objects"
+}
+
+// Operations returns all available operations for this category.
+func (c *BucketsCategory) Operations() []cloud.Operation {
+	return c.operations
+}
+
+// IsUIVisible returns whether this category should be visible in the UI.
+func (c *BucketsCategory) IsUIVisible() bool {
+	return true
+}
@@ -0,0 +1,351 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+// Transfer errors.
+var (
+	ErrDownloadObject = errors.New("failed to download object")
+	ErrUploadFile     = errors.New("failed to upload file")
+	ErrPresignObject  = errors.New("failed to presign object URL")
+	ErrPreviewObject  = errors.New("failed to preview object")
+	ErrPresignMethod  = errors.New("unsupported presign method")
+)
+
+const (
+	// minUploadPartSize is the size of the parts large files are uploaded in, unless they need
+	// larger parts to stay within maxUploadParts. Files up to this size are uploaded in a single
+	// request.
+	minUploadPartSize = 8 * 1024 * 1024
+
+	// maxUploadParts is the most parts S3 accepts in a multipart upload.
+	maxUploadParts = 10000
+
+	// maxObjectSize is the largest object S3 stores, 5 TiB.
+	maxObjectSize = 5 * 1024 * 1024 * 1024 * 1024
+
+	// defaultPreviewLimit is how much of an object Execute previews.
+	defaultPreviewLimit = 64 * 1024
+)
+
+// ObjectTransferOperation represents an operation to download, upload, presign and preview objects.
+type ObjectTransferOperation struct {
+	profile string
+	region  string
+}
+
+// NewObjectTransferOperation creates a new object transfer operation.
+func NewObjectTransferOperation(profile, region string) *ObjectTransferOperation {
+	return &ObjectTransferOperation{
+		profile: profile,
+		region:  region,
+	}
+}
+
+// Name returns the operation's name.
+func (o *ObjectTransferOperation) Name() string {
+	return "Transfer Objects"
+}
+
+// Description returns the operation's description.
+func (o *ObjectTransferOperation) Description() string {
+	return "Download, Upload, Presign and Preview Objects"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+// Objects are transferred from the bucket browser rather than as a separate operation.
+func (o *ObjectTransferOperation) IsUIVisible() bool {
+	return false
+}
+
+// DownloadObject writes an object to a local file, creating the directories above it. A file
+// left half written by a failed download is removed.
+func (o *ObjectTransferOperation) DownloadObject(ctx context.Context, bucket cloud.S3Bucket, key, localPath string, progress cloud.S3TransferProgress) error {
+	client, err := bucketClient(ctx, o.profile, o.region, bucket)
+	if err != nil {
+		return err
+	}
+
+	output, err := client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket.Name),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrDownloadObject, err)
+	}
+	defer output.Body.Close()
+
+	if err := os.MkdirAll(filepath.Dir(localPath), 0o755); err != nil {
+		return fmt.Errorf("%w: %w", ErrDownloadObject, err)
+	}
+	file, err := os.Create(localPath)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrDownloadObject, err)
+	}
+
+	writer := &progressWriter{writer: file, total: aws.ToInt64(output.ContentLength), progress: progress}
+	progress(0, writer.total)
+	_, err = io.Copy(writer, output.Body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(localPath)
+		return fmt.Errorf("%w: %w", ErrDownloadObject, err)
+	}
+	return nil
+}
+
+// UploadFiles uploads the files of an upload one after the other. Files larger than a part are
+// uploaded in parts, and progress is reported as each part or file is stored.
+func (o *ObjectTransferOperation) UploadFiles(ctx context.Context, bucket cloud.S3Bucket, upload *cloud.S3Upload, progress cloud.S3TransferProgress) error {
+	// Check every file first, so an upload doesn't fail after storing some of them
+	for _, file := range upload.Files {
+		if file.Size > maxObjectSize {
+			return fmt.Errorf("%w: %s: larger than the 5 TiB limit of an S3 object", ErrUploadFile, file.Path)
+		}
+	}
+
+	client, err := bucketClient(ctx, o.profile, o.region, bucket)
+	if err != nil {
+		return err
+	}
+
+	var done int64
+	progress(done, upload.Size)
+	for _, file := range upload.Files {
+		err := uploadFile(ctx, client, bucket.Name, file, func(n int64) {
+			done += n
+			progress(done, upload.Size)
+		})
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrUploadFile, file.Path, err)
+		}
+	}
+	return nil
+}
+
+// PresignObject returns a URL that gets or puts an object until it expires. Anyone holding the
+// URL can use it with the permissions of the profile that signed it.
+func (o *ObjectTransferOperation) PresignObject(ctx context.Context, bucket cloud.S3Bucket, request cloud.S3PresignRequest) (*cloud.S3PresignedURL, error) {
+	client, err := bucketClient(ctx, o.profile, o.region, bucket)
+	if err != nil {
+		return nil, err
+	}
+	presignClient := s3.NewPresignClient(client, s3.WithPresignExpires(request.Expiry))
+
+	var url string
+	switch request.Method {
+	case cloud.S3PresignGet:
+		presigned, err := presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(bucket.Name),
+			Key:    aws.String(request.Key),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrPresignObject, err)
+		}
+		url = presigned.URL
+	case cloud.S3PresignPut:
+		presigned, err := presignClient.PresignPutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(bucket.Name),
+			Key:    aws.String(request.Key),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrPresignObject, err)
+		}
+		url = presigned.URL
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrPresignMethod, request.Method)
+	}
+
+	return &cloud.S3PresignedURL{
+		Method:  request.Method,
+		URL:     url,
+		Expires: time.Now().Add(request.Expiry),
+	}, nil
+}
+
+// PreviewObject reads up to limit bytes from the start of an object with a ranged request, so
+// previewing a large object doesn't download all of it.
+func (o *ObjectTransferOperation) PreviewObject(ctx context.Context, bucket cloud.S3Bucket, key string, limit int64) (*cloud.S3ObjectPreview, error) {
+	client, err := bucketClient(ctx, o.profile, o.region, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket.Name),
+		Key:    aws.String(key),
+		Range:  aws.String(fmt.Sprintf("bytes=0-%d", limit-1)),
+	})
+	// S3 refuses any range of an empty object
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode() == "InvalidRange" {
+		return &cloud.S3ObjectPreview{Key: key}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPreviewObject, err)
+	}
+	defer output.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(output.Body, limit))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPreviewObject, err)
+	}
+
+	size := objectSize(aws.ToString(output.ContentRange), int64(len(data)))
+	return &cloud.S3ObjectPreview{
+		Key:         key,
+		ContentType: aws.ToString(output.ContentType),
+		Size:        size,
+		Data:        data,
+		Truncated:   size > int64(len(data)),
+	}, nil
+}
+
+// Execute executes the operation with the given parameters.
+func (o *ObjectTransferOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	bucket, ok := params["bucket"].(cloud.S3Bucket)
+	if !ok {
+		return nil, fmt.Errorf("bucket is required")
+	}
+	key, ok := params["key"].(string)
+	if !ok {
+		return nil, fmt.Errorf("key is required")
+	}
+
+	return o.PreviewObject(ctx, bucket, key, defaultPreviewLimit)
+}
+
+// uploadFile uploads a single file, in parts when it's larger than a part, and reports the bytes
+// of each part once it's stored. A multipart upload that fails is aborted so its parts aren't
+// left behind to be billed.
+func uploadFile(ctx context.Context, client *s3.Client, bucket string, file cloud.S3UploadFile, stored func(n int64)) error {
+	f, err := os.Open(file.Path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if file.Size <= minUploadPartSize {
+		_, err := client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:        aws.String(bucket),
+			Key:           aws.String(file.Key),
+			Body:          io.NewSectionReader(f, 0, file.Size),
+			ContentLength: aws.Int64(file.Size),
+		})
+		if err != nil {
+			return err
+		}
+		stored(file.Size)
+		return nil
+	}
+
+	created, err := client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:            aws.String(bucket),
+		Key:               aws.String(file.Key),
+		ChecksumAlgorithm: types.ChecksumAlgorithmCrc32,
+	})
+	if err != nil {
+		return err
+	}
+
+	parts, err := uploadParts(ctx, client, bucket, file, aws.ToString(created.UploadId), f, stored)
+	if err == nil {
+		_, err = client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:          aws.String(bucket),
+			Key:             aws.String(file.Key),
+			UploadId:        created.UploadId,
+			MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+		})
+	}
+	if err != nil {
+		// Abort with a fresh context, as the upload may have failed because ctx was cancelled
+		client.AbortMultipartUpload(context.Background(), &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(bucket),
+			Key:      aws.String(file.Key),
+			UploadId: created.UploadId,
+		})
+		return err
+	}
+	return nil
+}
+
+// uploadParts uploads the parts of a file in order and returns them for completing the upload
+func uploadParts(ctx context.Context, client *s3.Client, bucket string, file cloud.S3UploadFile, uploadID string, f *os.File, stored func(n int64)) ([]types.CompletedPart, error) {
+	partSize := uploadPartSize(file.Size)
+	var parts []types.CompletedPart
+	for offset, number := int64(0), int32(1); offset < file.Size; offset, number = offset+partSize, number+1 {
+		size := min(partSize, file.Size-offset)
+		output, err := client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:            aws.String(bucket),
+			Key:               aws.String(file.Key),
+			UploadId:          aws.String(uploadID),
+			PartNumber:        aws.Int32(number),
+			Body:              io.NewSectionReader(f, offset, size),
+			ContentLength:     aws.Int64(size),
+			ChecksumAlgorithm: types.ChecksumAlgorithmCrc32,
+		})
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, types.CompletedPart{
+			ETag:          output.ETag,
+			PartNumber:    aws.Int32(number),
+			ChecksumCRC32: output.ChecksumCRC32,
+		})
+		stored(size)
+	}
+	return parts, nil
+}
+
+// uploadPartSize returns the size of the parts a file is uploaded in: minUploadPartSize, or the
+// smallest size that uploads the file in maxUploadParts parts when that's larger.
+func uploadPartSize(fileSize int64) int64 {
+	return max(minUploadPartSize, (fileSize+maxUploadParts-1)/maxUploadParts)
+}
+
+// objectSize returns the size of a whole object from the Content-Range of a ranged request,
+// such as "bytes 0-99/1234", or the bytes read when S3 returned the object without a range.
+func objectSize(contentRange string, read int64) int64 {
+	_, total, ok := strings.Cut(contentRange, "/")
+	if !ok {
+		return read
+	}
+	size, err := strconv.ParseInt(total, 10, 64)
+	if err != nil {
+		return read
+	}
+	return size
+}
+
+// progressWriter reports the bytes written through it
+type progressWriter struct {
+	writer   io.Writer
+	written  int64
+	total    int64
+	progress cloud.S3TransferProgress
+}
+
+// Write writes to the underlying writer and reports the bytes written so far
+func (w *progressWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.written += int64(n)
+	w.progress(w.written, w.total)
+	return n, err
+}
@@ -0,0 +1,53 @@
+package s3
+
+import "testing"
+
+func TestUploadPartSize(t *testing.T) {
+	const gib = 1024 * 1024 * 1024
+
+	testCases := []struct {
+		name     string
+		fileSize int64
+		want     int64
+	}{
+		{name: "Empty file", fileSize: 0, want: minUploadPartSize},
+		{name: "One part", fileSize: minUploadPartSize, want: minUploadPartSize},
+		{name: "Largest file in minimum parts", fileSize: minUploadPartSize * maxUploadParts, want: minUploadPartSize},
+		{name: "One byte over the minimum parts", fileSize: minUploadPartSize*maxUploadParts + 1, want: minUploadPartSize + 1},
+		{name: "100 GiB", fileSize: 100 * gib, want: 10737419},
+		{name: "Largest object", fileSize: maxObjectSize, want: 549755814},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := uploadPartSize(tc.fileSize)
+			if got != tc.want {
+				t.Errorf("Expected a part size of %d, got %d", tc.want, got)
+			}
+			if parts := (tc.fileSize + got - 1) / got; parts > maxUploadParts {
+				t.Errorf("Expected at most %d parts, got %d", maxUploadParts, parts)
+			}
+		})
+	}
+}
+
+func TestObjectSize(t *testing.T) {
+	testCases := []struct {
+		name         string
+		contentRange string
+		read         int64
+		want         int64
+	}{
+		{name: "Ranged request", contentRange: "bytes 0-99/1234", read: 100, want: 1234},
+		{name: "Whole object", contentRange: "", read: 42, want: 42},
+		{name: "Unknown size", contentRange: "bytes 0-99/*", read: 100, want: 100},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := objectSize(tc.contentRange, tc.read); got != tc.want {
+				t.Errorf("Expected a size of %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package secretsmanager
+
+import (
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+)
+
+// SecretsCategory represents the Secrets Manager secrets category.
+type SecretsCategory struct {
+	profile    string
+	region     string
+	operations []cloud.Operation
+}
+
+// NewSecretsCategory creates a new Secrets Manager secrets category.
+func NewSecretsCategory(profile, region string) *SecretsCategory {
+	category := &SecretsCategory{
+		profile:    profile,
+		region:     region,
+		operations: make([]cloud.Operation, 0),
+	}
+
+	// Register operations
+	category.operations = append(category.operations, NewSecretOperation(profile, region))
+
+	return category
+}
+
+// Name returns the category's name.
+func (c *SecretsCategory) Name() string {
+	return "Secrets"
+}
+
+// Description returns the category's description.
+func (c *SecretsCategory) Description() string {
+	return "Secret Values and Rotation Status"
+}
+
+// Operations returns all available operations for this category.
+func (c *SecretsCategory) Operations() []cloud.Operation {
+	return c.operations
+}
+
+// IsUIVisible returns whether this category should be visible in the UI.
+func (c *SecretsCategory) IsUIVisible() bool {
+	return true
+}
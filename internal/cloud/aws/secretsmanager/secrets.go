@@ -0,0 +1,166 @@
+package secretsmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+)
+
+// Common errors.
+var (
+	ErrLoadConfig     = errors.New("failed to load AWS config")
+	ErrListSecrets    = errors.New("failed to list secrets")
+	ErrGetSecretValue = errors.New("failed to get secret value")
+	ErrSecretNotFound = errors.New("secret not found")
+	ErrRotateSecret   = errors.New("failed to rotate secret")
+)
+
+// SecretOperation represents an operation to inspect Secrets Manager secrets and their rotation,
+// reveal their values and rotate them.
+type SecretOperation struct {
+	profile string
+	region  string
+}
+
+// NewSecretOperation creates a new secret operation.
+func NewSecretOperation(profile, region string) *SecretOperation {
+	return &SecretOperation{
+		profile: profile,
+		region:  region,
+	}
+}
+
+// Name returns the operation's name.
+func (o *SecretOperation) Name() string {
+	return "Manage Secrets"
+}
+
+// Description returns the operation's description.
+func (o *SecretOperation) Description() string {
+	return "Rotation Status, Reveal and Rotate"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+func (o *SecretOperation) IsUIVisible() bool {
+	return true
+}
+
+// ListSecrets returns the secrets of the account that aren't scheduled for deletion, without their
+// values, in name order.
+func (o *SecretOperation) ListSecrets(ctx context.Context) ([]cloud.Secret, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	var secrets []cloud.Secret
+	paginator := secretsmanager.NewListSecretsPaginator(client, &secretsmanager.ListSecretsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrListSecrets, err)
+		}
+		for _, secret := range output.SecretList {
+			secrets = append(secrets, convertSecret(secret))
+		}
+	}
+
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
+	return secrets, nil
+}
+
+// GetSecretValue returns the current value of a secret. Only the size of a binary secret is kept.
+func (o *SecretOperation) GetSecretValue(ctx context.Context, secretID string) (*cloud.SecretValue, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretID),
+	})
+	var notFound *types.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		return nil, fmt.Errorf("%w: %s", ErrSecretNotFound, secretID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetSecretValue, err)
+	}
+
+	return &cloud.SecretValue{
+		VersionID: aws.ToString(output.VersionId),
+		Stages:    output.VersionStages,
+		Created:   aws.ToTime(output.CreatedDate),
+		Value:     aws.ToString(output.SecretString),
+		Binary:    output.SecretString == nil && output.SecretBinary != nil,
+		Size:      len(output.SecretBinary),
+	}, nil
+}
+
+// RotateSecret starts rotating a secret right away with the rotation function and schedule it's
+// configured with. The rotation runs in the background.
+func (o *SecretOperation) RotateSecret(ctx context.Context, secretID string) error {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.RotateSecret(ctx, &secretsmanager.RotateSecretInput{
+		SecretId:          aws.String(secretID),
+		RotateImmediately: aws.Bool(true),
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRotateSecret, err)
+	}
+	return nil
+}
+
+// Execute executes the operation with the given parameters.
+func (o *SecretOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return o.ListSecrets(ctx)
+}
+
+// convertSecret converts a secret with its rotation configuration
+func convertSecret(secret types.SecretListEntry) cloud.Secret {
+	result := cloud.Secret{
+		Name:              aws.ToString(secret.Name),
+		ARN:               aws.ToString(secret.ARN),
+		Description:       aws.ToString(secret.Description),
+		KMSKeyID:          aws.ToString(secret.KmsKeyId),
+		OwningService:     aws.ToString(secret.OwningService),
+		CreatedDate:       aws.ToTime(secret.CreatedDate),
+		LastChanged:       aws.ToTime(secret.LastChangedDate),
+		LastRotated:       aws.ToTime(secret.LastRotatedDate),
+		LastAccessed:      aws.ToTime(secret.LastAccessedDate),
+		RotationEnabled:   aws.ToBool(secret.RotationEnabled),
+		RotationLambdaARN: aws.ToString(secret.RotationLambdaARN),
+		NextRotation:      aws.ToTime(secret.NextRotationDate),
+	}
+	if rules := secret.RotationRules; rules != nil {
+		result.RotationAfterDays = aws.ToInt64(rules.AutomaticallyAfterDays)
+		result.RotationSchedule = aws.ToString(rules.ScheduleExpression)
+		result.RotationWindow = aws.ToString(rules.Duration)
+	}
+	return result
+}
+
+// getClient creates a Secrets Manager client for the given profile and region.
+func getClient(ctx context.Context, profile, region string) (*secretsmanager.Client, error) {
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithSharedConfigProfile(profile),
+		config.WithRegion(region),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLoadConfig, err)
+	}
+	return secretsmanager.NewFromConfig(cfg), nil
+}
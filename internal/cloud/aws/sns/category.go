@@ -0,0 +1,46 @@
+package sns
+
+import (
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+)
+
+// TopicsCategory represents the SNS topics category.
+type TopicsCategory struct {
+	profile    string
+	region     string
+	operations []cloud.Operation
+}
+
+// NewTopicsCategory creates a new SNS topics category.
+func NewTopicsCategory(profile, region string) *TopicsCategory {
+	category := &TopicsCategory{
+		profile:    profile,
+		region:     region,
+		operations: make([]cloud.Operation, 0),
+	}
+
+	// Register operations
+	category.operations = append(category.operations, NewTopicOperation(profile, region))
+
+	return category
+}
+
+// Name returns the category's name.
+func (c *TopicsCategory) Name() string {
+	return "Topics"
+}
+
+// Description returns the category's description.
+func (c *TopicsCategory) Description() string {
+	return "Topics, Subscriptions and Test Messages"
+}
+
+// Operations returns all available operations for this category.
+func (c *TopicsCategory) Operations() []cloud.Operation {
+	return c.operations
+}
+
+// IsUIVisible returns whether this category should be visible in the UI.
+func (c *TopicsCategory) IsUIVisible() bool {
+	return true
+}
@@ -0,0 +1,242 @@
+package sns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+)
+
+// Common errors.
+var (
+	ErrLoadConfig        = errors.New("failed to load AWS config")
+	ErrListTopics        = errors.New("failed to list topics")
+	ErrGetTopic          = errors.New("failed to get topic attributes")
+	ErrListSubscriptions = errors.New("failed to list subscriptions")
+	ErrGetSubscription   = errors.New("failed to get subscription attributes")
+	ErrPublishMessage    = errors.New("failed to publish message")
+)
+
+// ARNs SNS returns in place of a subscription's ARN.
+const (
+	pendingConfirmation = "PendingConfirmation"
+	deletedSubscription = "Deleted"
+)
+
+// TopicOperation represents an operation to inspect SNS topics and their subscriptions, and to
+// publish test messages.
+type TopicOperation struct {
+	profile string
+	region  string
+}
+
+// NewTopicOperation creates a new topic operation.
+func NewTopicOperation(profile, region string) *TopicOperation {
+	return &TopicOperation{
+		profile: profile,
+		region:  region,
+	}
+}
+
+// Name returns the operation's name.
+func (o *TopicOperation) Name() string {
+	return "Manage Topics"
+}
+
+// Description returns the operation's description.
+func (o *TopicOperation) Description() string {
+	return "Subscriptions, Filter Policies and Test Publish"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+func (o *TopicOperation) IsUIVisible() bool {
+	return true
+}
+
+// ListTopics returns the topics of the account with their subscription counts, in name order.
+func (o *TopicOperation) ListTopics(ctx context.Context) ([]cloud.Topic, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	var topics []cloud.Topic
+	paginator := sns.NewListTopicsPaginator(client, &sns.ListTopicsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrListTopics, err)
+		}
+		for _, topic := range output.Topics {
+			attributes, err := client.GetTopicAttributes(ctx, &sns.GetTopicAttributesInput{
+				TopicArn: topic.TopicArn,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrGetTopic, err)
+			}
+			topics = append(topics, convertTopic(aws.ToString(topic.TopicArn), attributes.Attributes))
+		}
+	}
+
+	sort.Slice(topics, func(i, j int) bool {
+		return topics[i].Name < topics[j].Name
+	})
+	return topics, nil
+}
+
+// ListSubscriptions returns the subscriptions to a topic with their filter policies, confirmed
+// subscriptions first, leaving out those being deleted. The attributes of a subscription pending confirmation can't be read, so
+// it has no filter policy.
+func (o *TopicOperation) ListSubscriptions(ctx context.Context, topicARN string) ([]cloud.Subscription, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscriptions []cloud.Subscription
+	paginator := sns.NewListSubscriptionsByTopicPaginator(client, &sns.ListSubscriptionsByTopicInput{
+		TopicArn: aws.String(topicARN),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrListSubscriptions, err)
+		}
+		for _, item := range output.Subscriptions {
+			subscription := cloud.Subscription{
+				ARN:      aws.ToString(item.SubscriptionArn),
+				Protocol: aws.ToString(item.Protocol),
+				Endpoint: aws.ToString(item.Endpoint),
+				Owner:    aws.ToString(item.Owner),
+			}
+			if subscription.ARN == deletedSubscription {
+				continue
+			}
+			if subscription.ARN == pendingConfirmation {
+				subscription.ARN = ""
+				subscription.PendingConfirmation = true
+				subscriptions = append(subscriptions, subscription)
+				continue
+			}
+
+			attributes, err := client.GetSubscriptionAttributes(ctx, &sns.GetSubscriptionAttributesInput{
+				SubscriptionArn: item.SubscriptionArn,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrGetSubscription, err)
+			}
+			setSubscriptionAttributes(&subscription, attributes.Attributes)
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+
+	sort.SliceStable(subscriptions, func(i, j int) bool {
+		return !subscriptions[i].PendingConfirmation && subscriptions[j].PendingConfirmation
+	})
+	return subscriptions, nil
+}
+
+// PublishMessage publishes a message to a topic and returns its ID. Messages published to a FIFO
+// topic are put in a message group of their own, and are never deduplicated.
+func (o *TopicOperation) PublishMessage(ctx context.Context, topic cloud.Topic, message cloud.TopicMessage) (string, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return "", err
+	}
+
+	input := &sns.PublishInput{
+		TopicArn: aws.String(topic.ARN),
+		Message:  aws.String(message.Body),
+	}
+	if message.Subject != "" {
+		input.Subject = aws.String(message.Subject)
+	}
+	if len(message.Attributes) > 0 {
+		input.MessageAttributes = make(map[string]types.MessageAttributeValue, len(message.Attributes))
+		for name, attribute := range message.Attributes {
+			input.MessageAttributes[name] = types.MessageAttributeValue{
+				DataType:    aws.String(attribute.DataType),
+				StringValue: aws.String(attribute.Value),
+			}
+		}
+	}
+	if topic.FIFO {
+		input.MessageGroupId = aws.String(cloud.TestMessageGroup)
+		input.MessageDeduplicationId = aws.String(strconv.FormatInt(time.Now().UnixNano(), 10))
+	}
+
+	output, err := client.Publish(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrPublishMessage, err)
+	}
+	return aws.ToString(output.MessageId), nil
+}
+
+// Execute executes the operation with the given parameters.
+func (o *TopicOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return o.ListTopics(ctx)
+}
+
+// convertTopic converts the attributes of a topic to a cloud.Topic.
+func convertTopic(arn string, attributes map[string]string) cloud.Topic {
+	return cloud.Topic{
+		Name:                      arn[strings.LastIndex(arn, ":")+1:],
+		ARN:                       arn,
+		DisplayName:               attributes["DisplayName"],
+		FIFO:                      attributes["FifoTopic"] == "true",
+		ContentBasedDeduplication: attributes["ContentBasedDeduplication"] == "true",
+		KMSKeyID:                  attributes["KmsMasterKeyId"],
+		Confirmed:                 intAttribute(attributes, "SubscriptionsConfirmed"),
+		Pending:                   intAttribute(attributes, "SubscriptionsPending"),
+		Deleted:                   intAttribute(attributes, "SubscriptionsDeleted"),
+	}
+}
+
+// setSubscriptionAttributes sets the filter policy and delivery settings of a subscription from
+// its attributes.
+func setSubscriptionAttributes(subscription *cloud.Subscription, attributes map[string]string) {
+	subscription.PendingConfirmation = attributes["PendingConfirmation"] == "true"
+	subscription.RawMessageDelivery = attributes["RawMessageDelivery"] == "true"
+	if policy := attributes["FilterPolicy"]; policy != "" {
+		subscription.FilterPolicy = policy
+		subscription.FilterPolicyScope = attributes["FilterPolicyScope"]
+		if subscription.FilterPolicyScope == "" {
+			subscription.FilterPolicyScope = "MessageAttributes"
+		}
+	}
+
+	var redrivePolicy struct {
+		DeadLetterTargetArn string `json:"deadLetterTargetArn"`
+	}
+	if policy := attributes["RedrivePolicy"]; policy != "" && json.Unmarshal([]byte(policy), &redrivePolicy) == nil {
+		subscription.DeadLetterQueue = redrivePolicy.DeadLetterTargetArn
+	}
+}
+
+// intAttribute returns a numeric attribute of a topic, or 0 when it's missing.
+func intAttribute(attributes map[string]string, name string) int64 {
+	value, _ := strconv.ParseInt(attributes[name], 10, 64)
+	return value
+}
+
+// getClient creates an SNS client for the given profile and region.
+func getClient(ctx context.Context, profile, region string) (*sns.Client, error) {
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithSharedConfigProfile(profile),
+		config.WithRegion(region),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLoadConfig, err)
+	}
+	return sns.NewFromConfig(cfg), nil
+}
@@ -0,0 +1,46 @@
+package sqs
+
+import (
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+)
+
+// QueuesCategory represents the SQS queues category.
+type QueuesCategory struct {
+	profile    string
+	region     string
+	operations []cloud.Operation
+}
+
+// NewQueuesCategory creates a new SQS queues category.
+func NewQueuesCategory(profile, region string) *QueuesCategory {
+	category := &QueuesCategory{
+		profile:    profile,
+		region:     region,
+		operations: make([]cloud.Operation, 0),
+	}
+
+	// Register operations
+	category.operations = append(category.operations, NewQueueOperation(profile, region))
+
+	return category
+}
+
+// Name returns the category's name.
+func (c *QueuesCategory) Name() string {
+	return "Queues"
+}
+
+// Description returns the category's description.
+func (c *QueuesCategory) Description() string {
+	return "Queue Depth, Messages and Dead-Letter Queues"
+}
+
+// Operations returns all available operations for this category.
+func (c *QueuesCategory) Operations() []cloud.Operation {
+	return c.operations
+}
+
+// IsUIVisible returns whether this category should be visible in the UI.
+func (c *QueuesCategory) IsUIVisible() bool {
+	return true
+}
@@ -0,0 +1,81 @@
+package sqs
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+const (
+	// maxReceiveMessages is the largest number of messages ReceiveMessage returns at once.
+	maxReceiveMessages = 10
+
+	// peekVisibilityTimeout hides peeked messages from consumers until they're released, in
+	// seconds, so a message isn't returned twice by the same peek.
+	peekVisibilityTimeout = 30
+)
+
+// Peek returns up to limit messages of a queue, oldest first as SQS returns them, with all their
+// attributes. The messages are made visible again before Peek returns, even when ctx is cancelled,
+// so they stay in the queue for their consumers; each peek still counts as a receive towards the
+// queue's max receive count.
+func Peek(ctx context.Context, client *sqs.Client, queueURL string, limit int) ([]types.Message, error) {
+	var messages []types.Message
+	var received []types.Message
+	seen := make(map[string]bool)
+	defer func() {
+		// Use a fresh context, so the messages are released even if the peek was cancelled
+		releaseMessages(context.Background(), client, queueURL, received)
+	}()
+
+	for len(messages) < limit {
+		output, err := client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:                    aws.String(queueURL),
+			MaxNumberOfMessages:         int32(min(maxReceiveMessages, limit-len(messages))),
+			VisibilityTimeout:           peekVisibilityTimeout,
+			WaitTimeSeconds:             1,
+			MessageAttributeNames:       []string{"All"},
+			MessageSystemAttributeNames: []types.MessageSystemAttributeName{types.MessageSystemAttributeNameAll},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(output.Messages) == 0 {
+			break
+		}
+
+		received = append(received, output.Messages...)
+		for _, message := range output.Messages {
+			// A slow peek can outlast the visibility timeout and see a message again
+			if id := aws.ToString(message.MessageId); !seen[id] {
+				seen[id] = true
+				messages = append(messages, message)
+			}
+		}
+	}
+
+	return messages, nil
+}
+
+// releaseMessages makes peeked messages visible again.
+func releaseMessages(ctx context.Context, client *sqs.Client, queueURL string, messages []types.Message) {
+	for start := 0; start < len(messages); start += maxReceiveMessages {
+		end := min(start+maxReceiveMessages, len(messages))
+		entries := make([]types.ChangeMessageVisibilityBatchRequestEntry, 0, end-start)
+		for i, message := range messages[start:end] {
+			entries = append(entries, types.ChangeMessageVisibilityBatchRequestEntry{
+				Id:                aws.String(strconv.Itoa(i)),
+				ReceiptHandle:     message.ReceiptHandle,
+				VisibilityTimeout: 0,
+			})
+		}
+		// The messages become visible on their own once the timeout passes, so errors are ignored
+		_, _ = client.ChangeMessageVisibilityBatch(ctx, &sqs.ChangeMessageVisibilityBatchInput{
+			QueueUrl: aws.String(queueURL),
+			Entries:  entries,
+		})
+	}
+}
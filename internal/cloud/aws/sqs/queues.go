@@ -0,0 +1,393 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	awscloudwatch "github.com/HenryOwenz/cloudgate/internal/cloud/aws/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// Common errors.
+var (
+	ErrLoadConfig      = errors.New("failed to load AWS config")
+	ErrListQueues      = errors.New("failed to list queues")
+	ErrGetQueue        = errors.New("failed to get queue attributes")
+	ErrGetQueueMetrics = errors.New("failed to get queue metrics")
+	ErrPeekMessages    = errors.New("failed to peek messages")
+	ErrSendMessage     = errors.New("failed to send message")
+	ErrPurgeQueue      = errors.New("failed to purge queue")
+	ErrStartRedrive    = errors.New("failed to start redrive")
+	ErrRedriveStatus   = errors.New("failed to get redrive status")
+	ErrRedriveFailed   = errors.New("redrive failed")
+	ErrRedriveStopped  = errors.New("redrive was cancelled")
+)
+
+const (
+	// metricWindow is how far back the age of the oldest message is looked up.
+	metricWindow = 15 * time.Minute
+
+	// redrivePollInterval is how often a running redrive is checked for progress.
+	redrivePollInterval = 2 * time.Second
+)
+
+// Statuses of a message move task.
+const (
+	moveTaskRunning   = "RUNNING"
+	moveTaskCompleted = "COMPLETED"
+	moveTaskFailed    = "FAILED"
+)
+
+// QueueOperation represents an operation to inspect SQS queues and their messages, and to send,
+// purge and redrive messages.
+type QueueOperation struct {
+	profile string
+	region  string
+}
+
+// NewQueueOperation creates a new queue operation.
+func NewQueueOperation(profile, region string) *QueueOperation {
+	return &QueueOperation{
+		profile: profile,
+		region:  region,
+	}
+}
+
+// Name returns the operation's name.
+func (o *QueueOperation) Name() string {
+	return "Manage Queues"
+}
+
+// Description returns the operation's description.
+func (o *QueueOperation) Description() string {
+	return "Queue Depth, Peek, Purge and Redrive"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+func (o *QueueOperation) IsUIVisible() bool {
+	return true
+}
+
+// ListQueues returns the queues of the account with their approximate message counts and
+// dead-letter queues, in name order. The age of the oldest message comes from CloudWatch, as SQS
+// doesn't report it; queues whose age couldn't be read are returned with the error instead.
+func (o *QueueOperation) ListQueues(ctx context.Context) ([]cloud.Queue, error) {
+	cfg, err := loadConfig(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+	client := sqs.NewFromConfig(cfg)
+
+	var queues []cloud.Queue
+	paginator := sqs.NewListQueuesPaginator(client, &sqs.ListQueuesInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrListQueues, err)
+		}
+		for _, url := range output.QueueUrls {
+			attributes, err := client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+				QueueUrl:       aws.String(url),
+				AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameAll},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrGetQueue, err)
+			}
+			queues = append(queues, convertQueue(url, attributes.Attributes))
+		}
+	}
+
+	sort.Slice(queues, func(i, j int) bool {
+		return queues[i].Name < queues[j].Name
+	})
+
+	// Link each dead-letter queue to the queues that use it
+	positions := make(map[string]int, len(queues))
+	for i, queue := range queues {
+		positions[queue.ARN] = i
+	}
+	for _, queue := range queues {
+		if i, ok := positions[queue.DeadLetterQueue]; ok {
+			queues[i].SourceQueues = append(queues[i].SourceQueues, queue.ARN)
+		}
+	}
+
+	setOldestMessageAges(ctx, cloudwatch.NewFromConfig(cfg), queues)
+	return queues, nil
+}
+
+// PeekMessages returns up to limit messages of a queue, oldest first as SQS returns them. The
+// messages are made visible again afterwards, so they stay in the queue for their consumers,
+// though each peek counts as a receive towards the queue's max receive count.
+func (o *QueueOperation) PeekMessages(ctx context.Context, queueURL string, limit int) ([]cloud.QueueMessage, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	received, err := Peek(ctx, client, queueURL, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPeekMessages, err)
+	}
+
+	messages := make([]cloud.QueueMessage, 0, len(received))
+	for _, message := range received {
+		messages = append(messages, convertMessage(message))
+	}
+	return messages, nil
+}
+
+// SendMessage sends a message to a queue and returns its ID. Messages sent to a FIFO queue are
+// put in a message group of their own, and are never deduplicated.
+func (o *QueueOperation) SendMessage(ctx context.Context, queue cloud.Queue, body string) (string, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return "", err
+	}
+
+	input := &sqs.SendMessageInput{
+		QueueUrl:    aws.String(queue.URL),
+		MessageBody: aws.String(body),
+	}
+	if queue.FIFO {
+		input.MessageGroupId = aws.String(cloud.TestMessageGroup)
+		input.MessageDeduplicationId = aws.String(strconv.FormatInt(time.Now().UnixNano(), 10))
+	}
+
+	output, err := client.SendMessage(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrSendMessage, err)
+	}
+	return aws.ToString(output.MessageId), nil
+}
+
+// PurgeQueue deletes all the messages of a queue. SQS takes up to a minute to delete them.
+func (o *QueueOperation) PurgeQueue(ctx context.Context, queueURL string) error {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.PurgeQueue(ctx, &sqs.PurgeQueueInput{
+		QueueUrl: aws.String(queueURL),
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrPurgeQueue, err)
+	}
+	return nil
+}
+
+// RedriveMessages starts a message move task that moves the messages of a dead-letter queue back
+// to the queues they came from, and follows it until it's done, reporting how many messages were
+// moved out of how many. It returns how many messages were moved.
+func (o *QueueOperation) RedriveMessages(ctx context.Context, queueARN string, progress func(moved, total int64)) (int64, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = client.StartMessageMoveTask(ctx, &sqs.StartMessageMoveTaskInput{
+		SourceArn: aws.String(queueARN),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrStartRedrive, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(redrivePollInterval):
+		}
+
+		// The latest task of the queue is the one just started
+		output, err := client.ListMessageMoveTasks(ctx, &sqs.ListMessageMoveTasksInput{
+			SourceArn:  aws.String(queueARN),
+			MaxResults: aws.Int32(1),
+		})
+		if err != nil {
+			return 0, fmt.Errorf("%w: %w", ErrRedriveStatus, err)
+		}
+		if len(output.Results) == 0 {
+			continue
+		}
+
+		task := output.Results[0]
+		moved, total := task.ApproximateNumberOfMessagesMoved, aws.ToInt64(task.ApproximateNumberOfMessagesToMove)
+		switch aws.ToString(task.Status) {
+		case moveTaskRunning:
+			// The number of messages to move is only known once the task has started
+			if total > 0 {
+				progress(moved, total)
+			}
+		case moveTaskCompleted:
+			progress(moved, max(moved, total))
+			return moved, nil
+		case moveTaskFailed:
+			return moved, fmt.Errorf("%w: %s", ErrRedriveFailed, aws.ToString(task.FailureReason))
+		default:
+			return moved, ErrRedriveStopped
+		}
+	}
+}
+
+// Execute executes the operation with the given parameters.
+func (o *QueueOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return o.ListQueues(ctx)
+}
+
+// redrivePolicy is the RedrivePolicy attribute of a queue with a dead-letter queue.
+type redrivePolicy struct {
+	DeadLetterTargetArn string          `json:"deadLetterTargetArn"`
+	MaxReceiveCount     json.RawMessage `json:"maxReceiveCount"`
+}
+
+// convertQueue converts the attributes of a queue
+func convertQueue(url string, attributes map[string]string) cloud.Queue {
+	queue := cloud.Queue{
+		Name:              url[strings.LastIndex(url, "/")+1:],
+		URL:               url,
+		ARN:               attributes[string(types.QueueAttributeNameQueueArn)],
+		FIFO:              attributes[string(types.QueueAttributeNameFifoQueue)] == "true",
+		Visible:           intAttribute(attributes, types.QueueAttributeNameApproximateNumberOfMessages),
+		InFlight:          intAttribute(attributes, types.QueueAttributeNameApproximateNumberOfMessagesNotVisible),
+		Delayed:           intAttribute(attributes, types.QueueAttributeNameApproximateNumberOfMessagesDelayed),
+		VisibilityTimeout: time.Duration(intAttribute(attributes, types.QueueAttributeNameVisibilityTimeout)) * time.Second,
+		RetentionPeriod:   time.Duration(intAttribute(attributes, types.QueueAttributeNameMessageRetentionPeriod)) * time.Second,
+		Delay:             time.Duration(intAttribute(attributes, types.QueueAttributeNameDelaySeconds)) * time.Second,
+		Created:           timeAttribute(attributes, types.QueueAttributeNameCreatedTimestamp),
+		LastModified:      timeAttribute(attributes, types.QueueAttributeNameLastModifiedTimestamp),
+	}
+
+	var policy redrivePolicy
+	if err := json.Unmarshal([]byte(attributes[string(types.QueueAttributeNameRedrivePolicy)]), &policy); err == nil {
+		queue.DeadLetterQueue = policy.DeadLetterTargetArn
+		// The count is a number or a string depending on how the policy was set
+		queue.MaxReceiveCount, _ = strconv.ParseInt(strings.Trim(string(policy.MaxReceiveCount), `"`), 10, 64)
+	}
+	return queue
+}
+
+// convertMessage converts a received message
+func convertMessage(message types.Message) cloud.QueueMessage {
+	result := cloud.QueueMessage{
+		ID:         aws.ToString(message.MessageId),
+		Body:       aws.ToString(message.Body),
+		GroupID:    message.Attributes[string(types.MessageSystemAttributeNameMessageGroupId)],
+		Attributes: make(map[string]string),
+	}
+	if sent, err := strconv.ParseInt(message.Attributes[string(types.MessageSystemAttributeNameSentTimestamp)], 10, 64); err == nil {
+		result.SentAt = time.UnixMilli(sent).UTC()
+	}
+	result.ReceiveCount, _ = strconv.Atoi(message.Attributes[string(types.MessageSystemAttributeNameApproximateReceiveCount)])
+	for name, attribute := range message.MessageAttributes {
+		if attribute.StringValue != nil {
+			result.Attributes[name] = aws.ToString(attribute.StringValue)
+		}
+	}
+	return result
+}
+
+// intAttribute returns a numeric attribute of a queue, or 0 if it isn't set.
+func intAttribute(attributes map[string]string, name types.QueueAttributeName) int64 {
+	value, _ := strconv.ParseInt(attributes[string(name)], 10, 64)
+	return value
+}
+
+// timeAttribute returns a timestamp attribute of a queue, in seconds since the epoch, or the zero
+// time if it isn't set.
+func timeAttribute(attributes map[string]string, name types.QueueAttributeName) time.Time {
+	if seconds := intAttribute(attributes, name); seconds > 0 {
+		return time.Unix(seconds, 0).UTC()
+	}
+	return time.Time{}
+}
+
+// setOldestMessageAges sets the age of the oldest message of each queue to the latest value of its
+// ApproximateAgeOfOldestMessage metric, batching the queries into as few requests as possible. The
+// queues of a batch that fails get the error instead of an age.
+func setOldestMessageAges(ctx context.Context, client *cloudwatch.Client, queues []cloud.Queue) {
+	end := time.Now().UTC()
+	for first := 0; first < len(queues); first += awscloudwatch.MaxMetricQueries {
+		last := min(first+awscloudwatch.MaxMetricQueries, len(queues))
+
+		queries := make([]cwtypes.MetricDataQuery, 0, last-first)
+		for i := first; i < last; i++ {
+			queries = append(queries, cwtypes.MetricDataQuery{
+				Id: aws.String(fmt.Sprintf("age_%d", i)),
+				MetricStat: &cwtypes.MetricStat{
+					Metric: &cwtypes.Metric{
+						Namespace:  aws.String("AWS/SQS"),
+						MetricName: aws.String("ApproximateAgeOfOldestMessage"),
+						Dimensions: []cwtypes.Dimension{
+							{Name: aws.String("QueueName"), Value: aws.String(queues[i].Name)},
+						},
+					},
+					Period: aws.Int32(60),
+					Stat:   aws.String("Maximum"),
+				},
+			})
+		}
+
+		paginator := cloudwatch.NewGetMetricDataPaginator(client, &cloudwatch.GetMetricDataInput{
+			MetricDataQueries: queries,
+			StartTime:         aws.Time(end.Add(-metricWindow)),
+			EndTime:           aws.Time(end),
+			ScanBy:            cwtypes.ScanByTimestampDescending,
+		})
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				// Drop the ages of earlier pages too, so no queue of the batch shows a partial one
+				for i := first; i < last; i++ {
+					queues[i].OldestMessageAge = 0
+					queues[i].Errors = append(queues[i].Errors, fmt.Errorf("%w: %w", ErrGetQueueMetrics, err).Error())
+				}
+				break
+			}
+			for _, result := range output.MetricDataResults {
+				var i int
+				if _, err := fmt.Sscanf(aws.ToString(result.Id), "age_%d", &i); err != nil || i >= len(queues) {
+					continue
+				}
+				// The newest value comes first
+				if len(result.Values) > 0 && queues[i].OldestMessageAge == 0 {
+					queues[i].OldestMessageAge = time.Duration(result.Values[0]) * time.Second
+				}
+			}
+		}
+	}
+}
+
+// loadConfig loads the AWS config for the given profile and region.
+func loadConfig(ctx context.Context, profile, region string) (aws.Config, error) {
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithSharedConfigProfile(profile),
+		config.WithRegion(region),
+	)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("%w: %w", ErrLoadConfig, err)
+	}
+	return cfg, nil
+}
+
+// getClient creates an SQS client for the given profile and region.
+func getClient(ctx context.Context, profile, region string) (*sqs.Client, error) {
+	cfg, err := loadConfig(ctx, profile, region)
+	if err != nil {
+		return nil, err
+	}
+	return sqs.NewFromConfig(cfg), nil
+}
@@ -0,0 +1,83 @@
+package sqs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConvertQueue(t *testing.T) {
+	const url = "https://sqs.us-east-1.amazonaws.com/123456789012/orders.fifo"
+
+	testCases := []struct {
+		name            string
+		attributes      map[string]string
+		wantFIFO        bool
+		wantVisible     int64
+		wantTimeout     time.Duration
+		wantCreated     time.Time
+		wantDeadLetter  string
+		wantMaxReceives int64
+	}{
+		{
+			name: "Counts and settings",
+			attributes: map[string]string{
+				"FifoQueue":                   "true",
+				"ApproximateNumberOfMessages": "12",
+				"VisibilityTimeout":           "30",
+				"CreatedTimestamp":            "1700000000",
+			},
+			wantFIFO:    true,
+			wantVisible: 12,
+			wantTimeout: 30 * time.Second,
+			wantCreated: time.Unix(1700000000, 0).UTC(),
+		},
+		{
+			name: "Redrive policy with a numeric count",
+			attributes: map[string]string{
+				"RedrivePolicy": `{"deadLetterTargetArn":"arn:aws:sqs:us-east-1:123456789012:orders-dlq","maxReceiveCount":5}`,
+			},
+			wantDeadLetter:  "arn:aws:sqs:us-east-1:123456789012:orders-dlq",
+			wantMaxReceives: 5,
+		},
+		{
+			name: "Redrive policy with a string count",
+			attributes: map[string]string{
+				"RedrivePolicy": `{"deadLetterTargetArn":"arn:aws:sqs:us-east-1:123456789012:orders-dlq","maxReceiveCount":"3"}`,
+			},
+			wantDeadLetter:  "arn:aws:sqs:us-east-1:123456789012:orders-dlq",
+			wantMaxReceives: 3,
+		},
+		{
+			name: "Malformed attributes",
+			attributes: map[string]string{
+				"ApproximateNumberOfMessages": "many",
+				"RedrivePolicy":               "{",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			queue := convertQueue(url, tc.attributes)
+			if queue.Name != "orders.fifo" || queue.URL != url {
+				t.Errorf("Expected the name and URL of the queue, got %q and %q", queue.Name, queue.URL)
+			}
+			if queue.FIFO != tc.wantFIFO {
+				t.Errorf("Expected FIFO %v, got %v", tc.wantFIFO, queue.FIFO)
+			}
+			if queue.Visible != tc.wantVisible {
+				t.Errorf("Expected %d visible messages, got %d", tc.wantVisible, queue.Visible)
+			}
+			if queue.VisibilityTimeout != tc.wantTimeout {
+				t.Errorf("Expected a visibility timeout of %s, got %s", tc.wantTimeout, queue.VisibilityTimeout)
+			}
+			if !queue.Created.Equal(tc.wantCreated) {
+				t.Errorf("Expected created at %s, got %s", tc.wantCreated, queue.Created)
+			}
+			if queue.DeadLetterQueue != tc.wantDeadLetter || queue.MaxReceiveCount != tc.wantMaxReceives {
+				t.Errorf("Expected dead-letter queue %q after %d receives, got %q after %d",
+					tc.wantDeadLetter, tc.wantMaxReceives, queue.DeadLetterQueue, queue.MaxReceiveCount)
+			}
+		})
+	}
+}
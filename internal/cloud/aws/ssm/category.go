@@ -0,0 +1,46 @@
+package ssm
+
+import (
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+)
+
+// ParameterStoreCategory represents the Parameter Store category.
+type ParameterStoreCategory struct {
+	profile    string
+	region     string
+	operations []cloud.Operation
+}
+
+// NewParameterStoreCategory creates a new Parameter Store category.
+func NewParameterStoreCategory(profile, region string) *ParameterStoreCategory {
+	category := &ParameterStoreCategory{
+		profile:    profile,
+		region:     region,
+		operations: make([]cloud.Operation, 0),
+	}
+
+	// Register operations
+	category.operations = append(category.operations, NewParameterOperation(profile, region))
+
+	return category
+}
+
+// Name returns the category's name.
+func (c *ParameterStoreCategory) Name() string {
+	return "Parameters"
+}
+
+// Description returns the category's description.
+func (c *ParameterStoreCategory) Description() string {
+	return "Parameter Store Values and History"
+}
+
+// Operations returns all available operations for this category.
+func (c *ParameterStoreCategory) Operations() []cloud.Operation {
+	return c.operations
+}
+
+// IsUIVisible returns whether this category should be visible in the UI.
+func (c *ParameterStoreCategory) IsUIVisible() bool {
+	return true
+}
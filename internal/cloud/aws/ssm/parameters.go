@@ -0,0 +1,224 @@
+package ssm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// Common errors.
+var (
+	ErrLoadConfig          = errors.New("failed to load AWS config")
+	ErrListParameters      = errors.New("failed to list parameters")
+	ErrGetParameter        = errors.New("failed to get parameter")
+	ErrGetHistory          = errors.New("failed to get parameter history")
+	ErrParameterNotFound   = errors.New("parameter not found")
+	ErrParameterChanged    = errors.New("parameter was changed since it was read")
+	ErrPutParameterVersion = errors.New("failed to put parameter version")
+)
+
+// ParameterOperation represents an operation to browse the parameters of Parameter Store, read
+// their values and history and put new versions of them.
+type ParameterOperation struct {
+	profile string
+	region  string
+}
+
+// NewParameterOperation creates a new parameter operation.
+func NewParameterOperation(profile, region string) *ParameterOperation {
+	return &ParameterOperation{
+		profile: profile,
+		region:  region,
+	}
+}
+
+// Name returns the operation's name.
+func (o *ParameterOperation) Name() string {
+	return "Manage Parameters"
+}
+
+// Description returns the operation's description.
+func (o *ParameterOperation) Description() string {
+	return "Browse, Reveal and Edit Parameters"
+}
+
+// IsUIVisible returns whether this operation should be visible in the UI.
+func (o *ParameterOperation) IsUIVisible() bool {
+	return true
+}
+
+// ListParameters returns the parameters of the account without their values, in name order.
+func (o *ParameterOperation) ListParameters(ctx context.Context) ([]cloud.Parameter, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	var parameters []cloud.Parameter
+	paginator := ssm.NewDescribeParametersPaginator(client, &ssm.DescribeParametersInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrListParameters, err)
+		}
+		for _, parameter := range output.Parameters {
+			parameters = append(parameters, cloud.Parameter{
+				Name:             aws.ToString(parameter.Name),
+				Type:             string(parameter.Type),
+				Version:          parameter.Version,
+				LastModified:     aws.ToTime(parameter.LastModifiedDate),
+				LastModifiedUser: aws.ToString(parameter.LastModifiedUser),
+				Description:      aws.ToString(parameter.Description),
+				KeyID:            aws.ToString(parameter.KeyId),
+				Tier:             string(parameter.Tier),
+				DataType:         aws.ToString(parameter.DataType),
+				AllowedPattern:   aws.ToString(parameter.AllowedPattern),
+			})
+		}
+	}
+
+	sort.Slice(parameters, func(i, j int) bool {
+		return parameters[i].Name < parameters[j].Name
+	})
+	return parameters, nil
+}
+
+// GetParameterValue returns the value of the latest version of a parameter. A SecureString is
+// only decrypted when asked to, which needs access to its KMS key.
+func (o *ParameterOperation) GetParameterValue(ctx context.Context, name string, decrypt bool) (*cloud.ParameterValue, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(name),
+		WithDecryption: aws.Bool(decrypt),
+	})
+	var notFound *types.ParameterNotFound
+	if errors.As(err, &notFound) {
+		return nil, fmt.Errorf("%w: %s", ErrParameterNotFound, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGetParameter, err)
+	}
+
+	parameter := output.Parameter
+	return &cloud.ParameterValue{
+		Name:         aws.ToString(parameter.Name),
+		Type:         string(parameter.Type),
+		Version:      parameter.Version,
+		Value:        aws.ToString(parameter.Value),
+		Decrypted:    decrypt && parameter.Type == types.ParameterTypeSecureString,
+		LastModified: aws.ToTime(parameter.LastModifiedDate),
+	}, nil
+}
+
+// GetParameterHistory returns the versions of a parameter that are kept, newest first.
+func (o *ParameterOperation) GetParameterHistory(ctx context.Context, name string, decrypt bool) ([]cloud.ParameterVersion, error) {
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []cloud.ParameterVersion
+	paginator := ssm.NewGetParameterHistoryPaginator(client, &ssm.GetParameterHistoryInput{
+		Name:           aws.String(name),
+		WithDecryption: aws.Bool(decrypt),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		var notFound *types.ParameterNotFound
+		if errors.As(err, &notFound) {
+			return nil, fmt.Errorf("%w: %s", ErrParameterNotFound, name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrGetHistory, err)
+		}
+		for _, version := range output.Parameters {
+			versions = append(versions, cloud.ParameterVersion{
+				Version:          version.Version,
+				Value:            aws.ToString(version.Value),
+				LastModified:     aws.ToTime(version.LastModifiedDate),
+				LastModifiedUser: aws.ToString(version.LastModifiedUser),
+				Description:      aws.ToString(version.Description),
+				Labels:           version.Labels,
+			})
+		}
+	}
+
+	// The history is listed oldest first
+	slices.Reverse(versions)
+	return versions, nil
+}
+
+// PutParameterVersion puts a new version of a parameter with a value. The type, KMS key, tier,
+// data type, allowed pattern and description of the parameter are kept. To not overwrite a
+// version put by someone else, nothing is put if the latest version isn't the one that was read.
+func (o *ParameterOperation) PutParameterVersion(ctx context.Context, parameter cloud.Parameter, value string) (int64, error) {
+	latest, err := o.GetParameterValue(ctx, parameter.Name, false)
+	if err != nil {
+		return 0, err
+	}
+	if latest.Version != parameter.Version {
+		return 0, fmt.Errorf("%w: version %d of %s is now the latest", ErrParameterChanged, latest.Version, parameter.Name)
+	}
+
+	client, err := getClient(ctx, o.profile, o.region)
+	if err != nil {
+		return 0, err
+	}
+
+	input := &ssm.PutParameterInput{
+		Name:      aws.String(parameter.Name),
+		Value:     aws.String(value),
+		Type:      types.ParameterType(parameter.Type),
+		Overwrite: aws.Bool(true),
+	}
+	if parameter.Secure() && parameter.KeyID != "" {
+		input.KeyId = aws.String(parameter.KeyID)
+	}
+	if parameter.Tier != "" {
+		input.Tier = types.ParameterTier(parameter.Tier)
+	}
+	if parameter.DataType != "" {
+		input.DataType = aws.String(parameter.DataType)
+	}
+	if parameter.AllowedPattern != "" {
+		input.AllowedPattern = aws.String(parameter.AllowedPattern)
+	}
+	if parameter.Description != "" {
+		input.Description = aws.String(parameter.Description)
+	}
+
+	output, err := client.PutParameter(ctx, input)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrPutParameterVersion, err)
+	}
+	return output.Version, nil
+}
+
+// Execute executes the operation with the given parameters.
+func (o *ParameterOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return o.ListParameters(ctx)
+}
+
+// getClient creates a Systems Manager client for the given profile and region.
+func getClient(ctx context.Context, profile, region string) (*ssm.Client, error) {
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithSharedConfigProfile(profile),
+		config.WithRegion(region),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLoadConfig, err)
+	}
+	return ssm.NewFromConfig(cfg), nil
+}
@@ -0,0 +1,278 @@
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Benchmark limits, to keep a mistyped count from running up a bill
+const (
+	MaxBenchInvocations = 1000
+	MaxBenchConcurrency = 100
+)
+
+// benchHistogramBuckets is the number of billed-duration buckets in a benchmark report
+const benchHistogramBuckets = 8
+
+var (
+	// ErrBenchInvocations is returned when the number of invocations is out of range
+	ErrBenchInvocations = fmt.Errorf("invocations must be between 1 and %d", MaxBenchInvocations)
+
+	// ErrBenchConcurrency is returned when the concurrency is out of range
+	ErrBenchConcurrency = fmt.Errorf("concurrency must be between 1 and %d", MaxBenchConcurrency)
+
+	// ErrBenchFailed is returned when every invocation of a benchmark failed to be made
+	ErrBenchFailed = errors.New("every invocation failed")
+)
+
+// The REPORT line Lambda adds to the tail of the log of every invocation
+var (
+	billedDurationPattern = regexp.MustCompile(`Billed Duration: ([0-9.]+) ms`)
+	initDurationPattern   = regexp.MustCompile(`Init Duration: ([0-9.]+) ms`)
+)
+
+// LambdaBenchOptions represents how many times a function is invoked and how many invocations run at once
+type LambdaBenchOptions struct {
+	Invocations int
+	Concurrency int
+}
+
+// Validate checks the options against the benchmark limits
+func (o LambdaBenchOptions) Validate() error {
+	if o.Invocations < 1 || o.Invocations > MaxBenchInvocations {
+		return ErrBenchInvocations
+	}
+	if o.Concurrency < 1 || o.Concurrency > MaxBenchConcurrency {
+		return ErrBenchConcurrency
+	}
+	return nil
+}
+
+// LambdaBenchInvocation represents the outcome of a single benchmark invocation
+type LambdaBenchInvocation struct {
+	Latency        time.Duration // Round trip as seen by the client
+	BilledDuration float64       // Milliseconds, from the REPORT log line
+	InitDuration   float64       // Milliseconds, only reported for cold starts
+	Error          string        // Request error or function error, if the invocation failed
+}
+
+// ColdStart returns whether the invocation had to initialize a new execution environment
+func (i LambdaBenchInvocation) ColdStart() bool {
+	return i.InitDuration > 0
+}
+
+// LambdaBenchHistogramBucket represents the invocations whose billed duration fell in [Low, High)
+type LambdaBenchHistogramBucket struct {
+	Low         float64
+	High        float64
+	Count       int
+	TotalBilled float64 // Milliseconds billed for the invocations in the bucket
+}
+
+// LambdaBenchResult represents the outcome of a benchmark
+type LambdaBenchResult struct {
+	FunctionName string
+	Options      LambdaBenchOptions
+	Elapsed      time.Duration
+	Invocations  []LambdaBenchInvocation
+}
+
+// Errors returns the number of failed invocations
+func (r *LambdaBenchResult) Errors() int {
+	count := 0
+	for _, invocation := range r.Invocations {
+		if invocation.Error != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// ErrorRate returns the share of failed invocations, between 0 and 1
+func (r *LambdaBenchResult) ErrorRate() float64 {
+	if len(r.Invocations) == 0 {
+		return 0
+	}
+	return float64(r.Errors()) / float64(len(r.Invocations))
+}
+
+// ColdStarts returns the number of invocations that reported an init duration
+func (r *LambdaBenchResult) ColdStarts() int {
+	count := 0
+	for _, invocation := range r.Invocations {
+		if invocation.ColdStart() {
+			count++
+		}
+	}
+	return count
+}
+
+// TotalBilled returns the milliseconds billed across all invocations
+func (r *LambdaBenchResult) TotalBilled() float64 {
+	total := 0.0
+	for _, invocation := range r.Invocations {
+		total += invocation.BilledDuration
+	}
+	return total
+}
+
+// LatencyPercentile returns the latency below which p percent of the invocations completed,
+// using the nearest-rank method
+func (r *LambdaBenchResult) LatencyPercentile(p float64) time.Duration {
+	if len(r.Invocations) == 0 {
+		return 0
+	}
+
+	latencies := make([]time.Duration, 0, len(r.Invocations))
+	for _, invocation := range r.Invocations {
+		latencies = append(latencies, invocation.Latency)
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	rank := int(math.Ceil(p / 100 * float64(len(latencies))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(latencies) {
+		rank = len(latencies)
+	}
+	return latencies[rank-1]
+}
+
+// BilledHistogram groups the invocations that reported a billed duration into equal-width buckets,
+// from the shortest to the longest billed duration
+func (r *LambdaBenchResult) BilledHistogram() []LambdaBenchHistogramBucket {
+	var durations []float64
+	for _, invocation := range r.Invocations {
+		if invocation.BilledDuration > 0 {
+			durations = append(durations, invocation.BilledDuration)
+		}
+	}
+	if len(durations) == 0 {
+		return nil
+	}
+
+	low, high := durations[0], durations[0]
+	for _, duration := range durations {
+		low = math.Min(low, duration)
+		high = math.Max(high, duration)
+	}
+
+	// Durations are billed by the millisecond, so buckets are at least a millisecond wide
+	width := math.Max(math.Ceil((high-low+1)/benchHistogramBuckets), 1)
+	count := int(math.Ceil((high - low + 1) / width))
+	buckets := make([]LambdaBenchHistogramBucket, count)
+	for i := range buckets {
+		buckets[i].Low = low + float64(i)*width
+		buckets[i].High = buckets[i].Low + width
+	}
+
+	for _, duration := range durations {
+		i := int((duration - low) / width)
+		if i >= count {
+			i = count - 1
+		}
+		buckets[i].Count++
+		buckets[i].TotalBilled += duration
+	}
+	return buckets
+}
+
+// RunLambdaBench invokes a function options.Invocations times with the same payload, running
+// options.Concurrency invocations at once. The client is set up before the first invocation, so
+// latencies only cover the invoke requests. Failed invocations are recorded in the result rather
+// than stopping the benchmark; an error is only returned if none of the invocations could be made.
+func RunLambdaBench(ctx context.Context, operation LambdaExecuteOperation, functionName, payload string, options LambdaBenchOptions) (*LambdaBenchResult, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	invoke, err := operation.Invoker(ctx, functionName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &LambdaBenchResult{
+		FunctionName: functionName,
+		Options:      options,
+		Invocations:  make([]LambdaBenchInvocation, options.Invocations),
+	}
+
+	var (
+		wg         sync.WaitGroup
+		mu         sync.Mutex
+		requestErr error
+		failed     int
+	)
+	indexes := make(chan int)
+	started := time.Now()
+	for worker := 0; worker < options.Concurrency; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				invocation, err := invokeForBench(ctx, invoke, payload)
+				result.Invocations[i] = invocation
+				if err != nil {
+					mu.Lock()
+					requestErr = err
+					failed++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for i := 0; i < options.Invocations; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	result.Elapsed = time.Since(started)
+
+	if failed == options.Invocations {
+		return nil, fmt.Errorf("%w: %w", ErrBenchFailed, requestErr)
+	}
+	return result, nil
+}
+
+// invokeForBench invokes a function once, timing the round trip and reading the billed and init
+// durations from the tail of its log. The error is only set if the request itself failed.
+func invokeForBench(ctx context.Context, invoke LambdaInvoker, payload string) (LambdaBenchInvocation, error) {
+	started := time.Now()
+	executeResult, err := invoke(ctx, payload)
+	invocation := LambdaBenchInvocation{Latency: time.Since(started)}
+	if err != nil {
+		invocation.Error = err.Error()
+		return invocation, err
+	}
+
+	invocation.BilledDuration = parseLogDuration(billedDurationPattern, executeResult.LogResult)
+	invocation.InitDuration = parseLogDuration(initDurationPattern, executeResult.LogResult)
+	switch {
+	case executeResult.FunctionError != "":
+		invocation.Error = executeResult.FunctionError
+	case executeResult.StatusCode >= 300:
+		invocation.Error = fmt.Sprintf("status %d", executeResult.StatusCode)
+	}
+	return invocation, nil
+}
+
+// parseLogDuration returns the milliseconds matched by a pattern in a log, or 0 if there's no match
+func parseLogDuration(pattern *regexp.Regexp, logs string) float64 {
+	match := pattern.FindStringSubmatch(logs)
+	if match == nil {
+		return 0
+	}
+	duration, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0
+	}
+	return duration
+}
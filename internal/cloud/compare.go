@@ -0,0 +1,216 @@
+package cloud
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MinCompareTargets is the fewest targets a comparison makes sense with
+const MinCompareTargets = 2
+
+var (
+	// ErrCompareTarget is returned when a target isn't given as profile/region
+	ErrCompareTarget = errors.New("targets must be given as profile/region")
+
+	// ErrCompareTargets is returned when there are too few targets to compare
+	ErrCompareTargets = fmt.Errorf("at least %d targets are needed to compare", MinCompareTargets)
+)
+
+// Values shown in a comparison for settings a target doesn't have
+const (
+	CompareValueMissing     = "(missing)"
+	CompareValueUnavailable = "-"
+)
+
+// CompareTarget represents an account and region to look a function up in, by the profile used to reach it
+type CompareTarget struct {
+	Profile string
+	Region  string
+}
+
+// String returns the target as profile/region
+func (t CompareTarget) String() string {
+	return t.Profile + "/" + t.Region
+}
+
+// ParseCompareTarget parses a target given as profile/region
+func ParseCompareTarget(value string) (CompareTarget, error) {
+	profile, region, found := strings.Cut(strings.TrimSpace(value), "/")
+	profile, region = strings.TrimSpace(profile), strings.TrimSpace(region)
+	if !found || profile == "" || region == "" || strings.Contains(region, "/") {
+		return CompareTarget{}, fmt.Errorf("%w: %q", ErrCompareTarget, value)
+	}
+	return CompareTarget{Profile: profile, Region: region}, nil
+}
+
+// FunctionCompareOptions represents where a function is compared and how much of its environment is shown
+type FunctionCompareOptions struct {
+	Targets []CompareTarget
+
+	// HashEnvValues shows a short hash of each environment variable's value, so differing values
+	// stand out without being revealed. Otherwise only the keys are compared.
+	HashEnvValues bool
+}
+
+// Validate checks that there are enough targets and that none is given twice
+func (o FunctionCompareOptions) Validate() error {
+	if len(o.Targets) < MinCompareTargets {
+		return ErrCompareTargets
+	}
+	seen := make(map[CompareTarget]bool)
+	for _, target := range o.Targets {
+		if seen[target] {
+			return fmt.Errorf("%w: %s is given twice", ErrCompareTarget, target)
+		}
+		seen[target] = true
+	}
+	return nil
+}
+
+// FunctionSnapshot represents the configuration of a function in one target
+type FunctionSnapshot struct {
+	Target      CompareTarget
+	Status      *FunctionStatus   // Nil when the function couldn't be loaded
+	Environment map[string]string // Environment variable keys, with hashed values or empty ones
+	Aliases     map[string]string // Alias names and the versions they point at
+	Error       string            // Why the function, or part of it, couldn't be loaded
+}
+
+// ComparedField represents one setting of a function and its value in each target
+type ComparedField struct {
+	Name    string
+	Values  []string // One per target, in the order of the targets
+	Differs bool     // Whether the targets the function was loaded from disagree
+}
+
+// FunctionComparison represents a function's configuration side by side across targets
+type FunctionComparison struct {
+	FunctionName string
+	Snapshots    []FunctionSnapshot
+	Fields       []ComparedField
+}
+
+// NewFunctionComparison lines up the settings of a function across targets: its runtime and
+// sizing, layers, code, then a field per environment variable and alias found in any target
+func NewFunctionComparison(functionName string, snapshots []FunctionSnapshot) *FunctionComparison {
+	comparison := &FunctionComparison{
+		FunctionName: functionName,
+		Snapshots:    snapshots,
+	}
+
+	settings := []struct {
+		name  string
+		value func(status *FunctionStatus) string
+	}{
+		{"Runtime", func(s *FunctionStatus) string { return s.Runtime }},
+		{"Handler", func(s *FunctionStatus) string { return s.Handler }},
+		{"Architecture", func(s *FunctionStatus) string { return s.Architecture }},
+		{"Package Type", func(s *FunctionStatus) string { return s.PackageType }},
+		{"Memory", func(s *FunctionStatus) string { return fmt.Sprintf("%d MB", s.Memory) }},
+		{"Timeout", func(s *FunctionStatus) string { return fmt.Sprintf("%d seconds", s.Timeout) }},
+		{"Ephemeral Storage", func(s *FunctionStatus) string { return fmt.Sprintf("%d MB", s.EphemeralStorage) }},
+		{"Tracing", func(s *FunctionStatus) string { return s.TracingMode }},
+		{"Layers", func(s *FunctionStatus) string { return layerNames(s.Layers) }},
+		{"Code SHA256", func(s *FunctionStatus) string { return s.CodeSha256 }},
+	}
+	for _, setting := range settings {
+		comparison.addField(setting.name, func(snapshot FunctionSnapshot) string {
+			return setting.value(snapshot.Status)
+		})
+	}
+
+	for _, key := range snapshotKeys(snapshots, func(s FunctionSnapshot) map[string]string { return s.Environment }) {
+		comparison.addField("Env: "+key, func(snapshot FunctionSnapshot) string {
+			value, ok := snapshot.Environment[key]
+			switch {
+			case !ok:
+				return CompareValueMissing
+			case value == "":
+				return "set"
+			default:
+				return value
+			}
+		})
+	}
+
+	for _, alias := range snapshotKeys(snapshots, func(s FunctionSnapshot) map[string]string { return s.Aliases }) {
+		comparison.addField("Alias: "+alias, func(snapshot FunctionSnapshot) string {
+			if version, ok := snapshot.Aliases[alias]; ok {
+				return version
+			}
+			return CompareValueMissing
+		})
+	}
+
+	return comparison
+}
+
+// Differences returns the number of fields the targets disagree on
+func (c *FunctionComparison) Differences() int {
+	count := 0
+	for _, field := range c.Fields {
+		if field.Differs {
+			count++
+		}
+	}
+	return count
+}
+
+// addField adds a field with its value in each target. Targets the function couldn't be loaded
+// from show no value and are left out when deciding whether the field differs.
+func (c *FunctionComparison) addField(name string, value func(snapshot FunctionSnapshot) string) {
+	field := ComparedField{Name: name, Values: make([]string, len(c.Snapshots))}
+	first := ""
+	loaded := 0
+	for i, snapshot := range c.Snapshots {
+		if snapshot.Status == nil {
+			field.Values[i] = CompareValueUnavailable
+			continue
+		}
+		field.Values[i] = value(snapshot)
+		if loaded == 0 {
+			first = field.Values[i]
+		} else if field.Values[i] != first {
+			field.Differs = true
+		}
+		loaded++
+	}
+	c.Fields = append(c.Fields, field)
+}
+
+// snapshotKeys returns the sorted keys of a map found in any of the snapshots
+func snapshotKeys(snapshots []FunctionSnapshot, values func(FunctionSnapshot) map[string]string) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, snapshot := range snapshots {
+		for key := range values(snapshot) {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// layerNames returns the name and version of each layer, leaving out the account and region
+// in its ARN so the same layer matches across targets
+func layerNames(arns []string) string {
+	if len(arns) == 0 {
+		return "none"
+	}
+	names := make([]string, len(arns))
+	for i, arn := range arns {
+		// arn:aws:lambda:region:account:layer:name:version
+		parts := strings.Split(arn, ":")
+		if len(parts) >= 8 {
+			names[i] = parts[6] + ":" + parts[7]
+		} else {
+			names[i] = arn
+		}
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,182 @@
+package cloud
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrInvalidPackage is returned when a deployment package isn't a zip archive
+	ErrInvalidPackage = errors.New("invalid deployment package")
+
+	// ErrPackageFileNotFound is returned when a path isn't a file in the deployment package
+	ErrPackageFileNotFound = errors.New("file not found in deployment package")
+
+	// ErrUnsafePackagePath is returned when an entry of a deployment package would be extracted
+	// outside the target directory
+	ErrUnsafePackagePath = errors.New("unsafe path in deployment package")
+)
+
+// PackageFile represents a file in a deployment package
+type PackageFile struct {
+	Path string // Slash-separated path from the root of the package
+	Size uint64 // Uncompressed size in bytes
+	Mode os.FileMode
+}
+
+// PackageEntry represents a directory or file directly inside a directory of a deployment package
+type PackageEntry struct {
+	Name  string
+	Path  string
+	IsDir bool
+	Size  uint64 // Uncompressed size, summed over the files below a directory
+}
+
+// FunctionPackage represents the zip archive a Lambda function was deployed from, held in memory
+type FunctionPackage struct {
+	FunctionName string
+	CodeSha256   string
+	Size         int64 // Size of the zip archive in bytes
+	Files        []PackageFile
+
+	reader *zip.Reader
+	files  map[string]*zip.File
+}
+
+// NewFunctionPackage reads the file list of a zip archive
+func NewFunctionPackage(functionName, codeSha256 string, archive []byte) (*FunctionPackage, error) {
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPackage, err)
+	}
+
+	pkg := &FunctionPackage{
+		FunctionName: functionName,
+		CodeSha256:   codeSha256,
+		Size:         int64(len(archive)),
+		reader:       reader,
+		files:        make(map[string]*zip.File, len(reader.File)),
+	}
+	for _, file := range reader.File {
+		// Directories are implied by the paths of the files inside them
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		pkg.Files = append(pkg.Files, PackageFile{
+			Path: file.Name,
+			Size: file.UncompressedSize64,
+			Mode: file.Mode(),
+		})
+		pkg.files[file.Name] = file
+	}
+	sort.Slice(pkg.Files, func(i, j int) bool { return pkg.Files[i].Path < pkg.Files[j].Path })
+
+	return pkg, nil
+}
+
+// List returns the directories and files directly inside a directory of the package, directories
+// first. The root directory is "".
+func (p *FunctionPackage) List(dir string) []PackageEntry {
+	prefix := ""
+	if dir != "" {
+		prefix = strings.TrimSuffix(dir, "/") + "/"
+	}
+
+	dirs := make(map[string]*PackageEntry)
+	var entries []PackageEntry
+	for _, file := range p.Files {
+		if !strings.HasPrefix(file.Path, prefix) {
+			continue
+		}
+
+		name, rest, nested := strings.Cut(strings.TrimPrefix(file.Path, prefix), "/")
+		if !nested {
+			entries = append(entries, PackageEntry{Name: name, Path: file.Path, Size: file.Size})
+			continue
+		}
+		if rest == "" {
+			continue
+		}
+		if entry, ok := dirs[name]; ok {
+			entry.Size += file.Size
+			continue
+		}
+		dirs[name] = &PackageEntry{Name: name, Path: prefix + name, IsDir: true, Size: file.Size}
+	}
+
+	listed := make([]PackageEntry, 0, len(dirs)+len(entries))
+	for _, entry := range dirs {
+		listed = append(listed, *entry)
+	}
+	sort.Slice(listed, func(i, j int) bool { return listed[i].Name < listed[j].Name })
+	return append(listed, entries...)
+}
+
+// ReadFile returns the contents of a file in the package
+func (p *FunctionPackage) ReadFile(name string) ([]byte, error) {
+	file, ok := p.files[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrPackageFileNotFound, name)
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// Extract writes the files of the package below a directory, creating it if needed, and returns
+// the number of files written. Entries that would land outside the directory are refused before
+// anything is written.
+func (p *FunctionPackage) Extract(dir string) (int, error) {
+	for _, file := range p.Files {
+		if !filepath.IsLocal(filepath.FromSlash(path.Clean(file.Path))) {
+			return 0, fmt.Errorf("%w: %s", ErrUnsafePackagePath, file.Path)
+		}
+	}
+
+	for i, file := range p.Files {
+		if err := p.extractFile(dir, file); err != nil {
+			return i, err
+		}
+	}
+	return len(p.Files), nil
+}
+
+// extractFile writes a single file of the package below a directory, keeping its permissions
+func (p *FunctionPackage) extractFile(dir string, file PackageFile) error {
+	target := filepath.Join(dir, filepath.FromSlash(file.Path))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	reader, err := p.files[file.Path].Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	mode := file.Mode.Perm()
+	if mode == 0 {
+		mode = 0o644
+	}
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, reader); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
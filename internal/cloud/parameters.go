@@ -0,0 +1,59 @@
+package cloud
+
+import (
+	"sort"
+	"strings"
+)
+
+// ParameterRootPath is the root of the Parameter Store hierarchy
+const ParameterRootPath = "/"
+
+// ParameterEntry represents a path or parameter directly below a path of Parameter Store
+type ParameterEntry struct {
+	Name      string // Name relative to the path being listed
+	Path      string // Full path of a path, or full name of a parameter
+	IsPath    bool
+	Count     int        // Number of parameters anywhere below a path
+	Parameter *Parameter // Set for parameters
+}
+
+// ListParameterPath returns the paths and parameters directly below a path, paths first, from
+// parameters in name order. Parameters whose names aren't part of a hierarchy are listed at the
+// root path.
+func ListParameterPath(parameters []Parameter, parameterPath string) []ParameterEntry {
+	prefix := strings.TrimSuffix(parameterPath, "/") + "/"
+
+	paths := make(map[string]*ParameterEntry)
+	var entries []ParameterEntry
+	for i := range parameters {
+		parameter := &parameters[i]
+		if parameterPath == ParameterRootPath && !strings.HasPrefix(parameter.Name, "/") {
+			entries = append(entries, ParameterEntry{Name: parameter.Name, Path: parameter.Name, Parameter: parameter})
+			continue
+		}
+		if !strings.HasPrefix(parameter.Name, prefix) {
+			continue
+		}
+
+		name, rest, nested := strings.Cut(strings.TrimPrefix(parameter.Name, prefix), "/")
+		if !nested {
+			entries = append(entries, ParameterEntry{Name: name, Path: parameter.Name, Parameter: parameter})
+			continue
+		}
+		if name == "" || rest == "" {
+			continue
+		}
+		if entry, ok := paths[name]; ok {
+			entry.Count++
+			continue
+		}
+		paths[name] = &ParameterEntry{Name: name, Path: prefix + name, IsPath: true, Count: 1}
+	}
+
+	listed := make([]ParameterEntry, 0, len(paths)+len(entries))
+	for _, entry := range paths {
+		listed = append(listed, *entry)
+	}
+	sort.Slice(listed, func(i, j int) bool { return listed[i].Name < listed[j].Name })
+	return append(listed, entries...)
+}
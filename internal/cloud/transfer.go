@@ -0,0 +1,144 @@
+package cloud
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// S3PresignGet is the method of a presigned URL that downloads an object
+	S3PresignGet = "GET"
+
+	// S3PresignPut is the method of a presigned URL that uploads an object
+	S3PresignPut = "PUT"
+
+	// MaxS3PresignExpiry is the longest a presigned URL can stay valid, a limit of Signature Version 4
+	MaxS3PresignExpiry = 7 * 24 * time.Hour
+)
+
+var (
+	// ErrNothingToUpload is returned when a local path has no files to upload
+	ErrNothingToUpload = errors.New("no files to upload")
+
+	// ErrInvalidPresignExpiry is returned when a presigned URL would expire too soon or too late
+	ErrInvalidPresignExpiry = errors.New("invalid expiry")
+)
+
+// S3TransferProgress reports how many bytes of a transfer are done out of its total
+type S3TransferProgress func(done, total int64)
+
+// S3UploadFile represents a local file and the key it's uploaded to
+type S3UploadFile struct {
+	Path string // Local path of the file
+	Key  string
+	Size int64
+}
+
+// S3Upload represents the local files uploaded below a prefix of a bucket
+type S3Upload struct {
+	LocalPath string // File or directory the upload was planned from
+	Prefix    string
+	Files     []S3UploadFile // In the order they're uploaded
+	Size      int64          // Size of all the files in bytes
+}
+
+// NewS3Upload lists the files to upload from a local file or directory. A file is uploaded
+// under its name below the prefix; a directory keeps its name and the layout below it, like
+// dropping a folder into a bucket. Anything but regular files is skipped.
+func NewS3Upload(localPath, prefix string) (*S3Upload, error) {
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return nil, err
+	}
+
+	upload := &S3Upload{LocalPath: localPath, Prefix: prefix}
+	if !info.IsDir() {
+		upload.add(localPath, prefix+filepath.Base(localPath), info.Size())
+		return upload, nil
+	}
+
+	root := filepath.Clean(localPath)
+	base := prefix + filepath.Base(root) + "/"
+	err = filepath.WalkDir(root, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		relative, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+		upload.add(file, base+filepath.ToSlash(relative), info.Size())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(upload.Files) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNothingToUpload, localPath)
+	}
+	return upload, nil
+}
+
+// add adds a file to the upload
+func (u *S3Upload) add(file, key string, size int64) {
+	u.Files = append(u.Files, S3UploadFile{Path: file, Key: key, Size: size})
+	u.Size += size
+}
+
+// S3DownloadPath returns where an object is downloaded to: below a directory that already
+// exists, under the last part of its key, or at the path itself otherwise
+func S3DownloadPath(localPath, key string) string {
+	if info, err := os.Stat(localPath); err == nil && info.IsDir() {
+		return filepath.Join(localPath, path.Base(key))
+	}
+	return localPath
+}
+
+// ParseS3PresignExpiry parses how long a presigned URL stays valid, such as 15m or 24h
+func ParseS3PresignExpiry(value string) (time.Duration, error) {
+	expiry, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidPresignExpiry, err)
+	}
+	if expiry < time.Second || expiry > MaxS3PresignExpiry {
+		return 0, fmt.Errorf("%w: %s, must be between 1s and %s", ErrInvalidPresignExpiry, value, MaxS3PresignExpiry)
+	}
+	return expiry, nil
+}
+
+// S3PresignRequest represents the object, method and lifetime of a presigned URL
+type S3PresignRequest struct {
+	Key    string
+	Method string // S3PresignGet or S3PresignPut
+	Expiry time.Duration
+}
+
+// S3PresignedURL represents a URL that gets or puts an object without credentials until it expires
+type S3PresignedURL struct {
+	Method  string
+	URL     string
+	Expires time.Time
+}
+
+// S3ObjectPreview represents the start of an object, read to show it inline
+type S3ObjectPreview struct {
+	Key         string
+	ContentType string
+	Size        int64  // Size of the whole object
+	Data        []byte // Up to the preview limit from the start of the object
+	Truncated   bool   // Whether the object is larger than the data read
+}
@@ -2,6 +2,8 @@ package cloud
 
 import (
 	"context"
+	"strings"
+	"time"
 )
 
 // Provider represents a cloud provider.
@@ -27,6 +29,66 @@ type Provider interface {
 	// GetLambdaExecuteOperation returns the Lambda execute operation
 	GetLambdaExecuteOperation() (LambdaExecuteOperation, error)
 
+	// GetLambdaConfigurationOperation returns the Lambda configuration operation
+	GetLambdaConfigurationOperation() (LambdaConfigurationOperation, error)
+
+	// GetLambdaDeployOperation returns the Lambda code deployment operation
+	GetLambdaDeployOperation() (LambdaDeployOperation, error)
+
+	// GetLambdaMetricsOperation returns the Lambda metrics operation
+	GetLambdaMetricsOperation() (LambdaMetricsOperation, error)
+
+	// GetLambdaEventSourceOperation returns the Lambda event source mapping operation
+	GetLambdaEventSourceOperation() (LambdaEventSourceOperation, error)
+
+	// GetLambdaHygieneOperation returns the Lambda hygiene report operation
+	GetLambdaHygieneOperation() (LambdaHygieneOperation, error)
+
+	// GetLambdaPackageOperation returns the Lambda deployment package operation
+	GetLambdaPackageOperation() (LambdaPackageOperation, error)
+
+	// GetLambdaSecurityOperation returns the Lambda security review operation
+	GetLambdaSecurityOperation() (LambdaSecurityOperation, error)
+
+	// GetLambdaAsyncOperation returns the Lambda asynchronous invocation operation
+	GetLambdaAsyncOperation() (LambdaAsyncOperation, error)
+
+	// GetS3BrowserOperation returns the S3 bucket and object browser operation
+	GetS3BrowserOperation() (S3BrowserOperation, error)
+
+	// GetS3TransferOperation returns the S3 object transfer operation
+	GetS3TransferOperation() (S3TransferOperation, error)
+
+	// GetEC2InstanceOperation returns the EC2 instance operation
+	GetEC2InstanceOperation() (EC2InstanceOperation, error)
+
+	// GetLogsInsightsOperation returns the CloudWatch Logs Insights operation
+	GetLogsInsightsOperation() (LogsInsightsOperation, error)
+
+	// GetAlarmOperation returns the CloudWatch alarm operation
+	GetAlarmOperation() (AlarmOperation, error)
+
+	// GetECSServiceOperation returns the ECS service operation
+	GetECSServiceOperation() (ECSServiceOperation, error)
+
+	// GetCodeBuildOperation returns the CodeBuild project and build operation
+	GetCodeBuildOperation() (CodeBuildOperation, error)
+
+	// GetCloudFormationOperation returns the CloudFormation stack operation
+	GetCloudFormationOperation() (CloudFormationOperation, error)
+
+	// GetParameterStoreOperation returns the Systems Manager Parameter Store operation
+	GetParameterStoreOperation() (ParameterStoreOperation, error)
+
+	// GetSecretsManagerOperation returns the Secrets Manager secret operation
+	GetSecretsManagerOperation() (SecretsManagerOperation, error)
+
+	// GetQueueOperation returns the SQS queue operation
+	GetQueueOperation() (QueueOperation, error)
+
+	// GetTopicOperation returns the SNS topic operation
+	GetTopicOperation() (TopicOperation, error)
+
 	// GetCodePipelineManualApprovalOperation returns the CodePipeline manual approval operation
 	GetCodePipelineManualApprovalOperation() (CodePipelineManualApprovalOperation, error)
 
@@ -161,14 +223,975 @@ type FunctionStatus struct {
 	PackageType  string
 	Architecture string
 	LogGroup     string
+
+	// Storage and concurrency settings
+	EphemeralStorage       int32  // Size of /tmp in MB
+	ReservedConcurrency    *int32 // nil when the function uses unreserved account concurrency
+	ProvisionedConcurrency []ProvisionedConcurrencyConfig
+
+	// Lifecycle state; a function can only be invoked when Active and not mid-update
+	State                  string
+	StateReason            string
+	LastUpdateStatus       string
+	LastUpdateStatusReason string
+
+	// Dependencies and integrations
+	Layers           []string // Layer version ARNs
+	VpcConfig        *FunctionVpcConfig
+	DeadLetterTarget string // ARN of the dead-letter queue or topic
+	TracingMode      string
+	SnapStart        string // When snapshots are taken, e.g. PublishedVersions
+	SnapStartStatus  string
+	KMSKeyArn        string
+	RuntimeVersion   string // ARN of the runtime version in use
+	Tags             map[string]string
+	CodeSha256       string
+
+	Errors []string // Parts of the function that couldn't be described
+}
+
+// FunctionVpcConfig represents the VPC a Lambda function is attached to
+type FunctionVpcConfig struct {
+	VpcID            string
+	SubnetIDs        []string
+	SecurityGroupIDs []string
+}
+
+// ProvisionedConcurrencyConfig represents the provisioned concurrency of a function alias or version
+type ProvisionedConcurrencyConfig struct {
+	Qualifier string
+	Requested int32
+	Available int32
+	Allocated int32
+	Status    string
+}
+
+// FunctionConfigUpdate represents a set of changes to a Lambda function's configuration.
+// Nil fields are left unchanged.
+type FunctionConfigUpdate struct {
+	Memory           *int32
+	Timeout          *int32
+	EphemeralStorage *int32
+
+	// ReservedConcurrency sets the reserved concurrency; RemoveReservedConcurrency removes it instead
+	ReservedConcurrency       *int32
+	RemoveReservedConcurrency bool
+
+	// ProvisionedConcurrency maps an alias or version to its provisioned concurrency; 0 removes the config
+	ProvisionedConcurrency map[string]int32
 }
 
+// IsEmpty returns whether the update contains no changes
+func (u FunctionConfigUpdate) IsEmpty() bool {
+	return u.Memory == nil &&
+		u.Timeout == nil &&
+		u.EphemeralStorage == nil &&
+		u.ReservedConcurrency == nil &&
+		!u.RemoveReservedConcurrency &&
+		len(u.ProvisionedConcurrency) == 0
+}
+
+// LambdaInvoker invokes a function with a client that's set up once, so each call only makes the
+// invoke request
+type LambdaInvoker func(ctx context.Context, payload string) (*LambdaExecuteResult, error)
+
 // LambdaExecuteResult represents the result of a Lambda function execution
 type LambdaExecuteResult struct {
 	StatusCode      int
 	ExecutedVersion string
 	Payload         string
 	LogResult       string
+	FunctionError   string // Set when the function returned an error, e.g. Unhandled
+
+	// Set for invocations that streamed their response
+	Streamed     bool
+	ErrorCode    string // Error reported when the stream completed, if any
+	ErrorDetails string
+}
+
+// LambdaDeployRequest represents a request to deploy new code to a Lambda function.
+// Exactly one of ZipFile, S3Bucket/S3Key or ImageURI must be set.
+type LambdaDeployRequest struct {
+	ZipFile  string // Path to a local zip archive
+	S3Bucket string
+	S3Key    string
+	ImageURI string
+
+	// Publish publishes a new version once the update completes
+	Publish bool
+
+	// Alias is pointed at the published version, and is created if it doesn't exist
+	Alias string
+}
+
+// Source returns a short description of where the code is deployed from
+func (r LambdaDeployRequest) Source() string {
+	switch {
+	case r.ZipFile != "":
+		return r.ZipFile
+	case r.S3Bucket != "" || r.S3Key != "":
+		return "s3://" + r.S3Bucket + "/" + r.S3Key
+	default:
+		return r.ImageURI
+	}
+}
+
+// SetS3Object sets the S3 source from a "bucket/key" or "s3://bucket/key" location,
+// returning false if the location is malformed
+func (r *LambdaDeployRequest) SetS3Object(location string) bool {
+	bucket, key, found := strings.Cut(strings.TrimPrefix(location, "s3://"), "/")
+	if !found || bucket == "" || key == "" {
+		return false
+	}
+	r.S3Bucket = bucket
+	r.S3Key = key
+	return true
+}
+
+// LambdaDeployResult represents the result of a Lambda code deployment
+type LambdaDeployResult struct {
+	CodeSha256       string
+	LastUpdateStatus string
+	Version          string // Empty unless a version was published
+	Alias            string // Empty unless an alias was moved
+}
+
+// FunctionMetrics represents the recent CloudWatch metrics of a Lambda function.
+// Each series holds one value per bucket, oldest first, with missing buckets as zero.
+type FunctionMetrics struct {
+	Window time.Duration // How far back the series go
+	Bucket time.Duration // Length of each bucket
+
+	Invocations          []float64 // Sum
+	Errors               []float64 // Sum
+	Throttles            []float64 // Sum
+	DurationP50          []float64 // Milliseconds
+	DurationP99          []float64 // Milliseconds
+	ConcurrentExecutions []float64 // Maximum
+}
+
+// EventSourceMapping represents a Lambda event source mapping, e.g. an SQS queue or Kinesis stream
+type EventSourceMapping struct {
+	UUID                 string
+	FunctionName         string
+	EventSourceArn       string
+	SourceType           string // SQS, Kinesis, DynamoDB, Kafka, MQ or DocumentDB
+	State                string // Enabled, Disabled, Enabling, Disabling, Creating, Updating or Deleting
+	StateReason          string
+	BatchSize            int32
+	BatchingWindow       int32 // Maximum batching window in seconds
+	StartingPosition     string
+	LastProcessingResult string
+	LastModified         string
+	FilterCriteria       []string // Filter patterns as JSON
+}
+
+// IsEnabled returns whether the mapping is enabled or being enabled
+func (e EventSourceMapping) IsEnabled() bool {
+	return e.State == "Enabled" || e.State == "Enabling"
+}
+
+// Hygiene checks flagged by the Lambda hygiene report
+const (
+	HygieneCheckDeprecatedRuntime     = "Deprecated runtime"
+	HygieneCheckDeprecatingRuntime    = "Runtime deprecating soon"
+	HygieneCheckNoReservedConcurrency = "No reserved concurrency"
+	HygieneCheckMaxTimeout            = "Timeout at maximum"
+	HygieneCheckUnused                = "Unused"
+	HygieneCheckOversizedPackage      = "Oversized package"
+	HygieneCheckNoFailureDestination  = "No DLQ or failure destination"
+)
+
+// Severities of hygiene findings, from most to least urgent
+const (
+	SeverityHigh   = "High"
+	SeverityMedium = "Medium"
+	SeverityLow    = "Low"
+)
+
+// HygieneReportOptions represents the scope and thresholds of a Lambda hygiene report
+type HygieneReportOptions struct {
+	Regions        []string // Regions to scan; empty scans the configured region
+	UnusedDays     int      // Functions without invocations for this many days are flagged as unused
+	MaxPackageSize int64    // Packages larger than this many bytes are flagged as oversized
+}
+
+// HygieneFinding represents one issue found with a Lambda function
+type HygieneFinding struct {
+	Region       string `json:"region"`
+	FunctionName string `json:"functionName"`
+	FunctionArn  string `json:"functionArn"`
+	Runtime      string `json:"runtime,omitempty"`
+	Check        string `json:"check"`
+	Severity     string `json:"severity"`
+	Detail       string `json:"detail"`
+}
+
+// HygieneReport represents the findings of a scan of Lambda functions across regions
+type HygieneReport struct {
+	GeneratedAt      time.Time        `json:"generatedAt"`
+	Regions          []string         `json:"regions"`
+	FunctionsScanned int              `json:"functionsScanned"`
+	Findings         []HygieneFinding `json:"findings"`
+	Errors           []string         `json:"errors,omitempty"` // Regions or checks that couldn't be scanned
+}
+
+// FunctionURLConfig represents the function URL of a Lambda function
+type FunctionURLConfig struct {
+	URL        string
+	AuthType   string // AWS_IAM or NONE
+	InvokeMode string // BUFFERED or RESPONSE_STREAM
+	Cors       *FunctionURLCors
+}
+
+// FunctionURLCors represents the CORS settings of a function URL
+type FunctionURLCors struct {
+	AllowOrigins     []string
+	AllowMethods     []string
+	AllowHeaders     []string
+	ExposeHeaders    []string
+	AllowCredentials bool
+	MaxAge           int32
+}
+
+// PolicyStatement represents one statement of a function's resource-based policy
+type PolicyStatement struct {
+	Sid        string
+	Effect     string
+	Principals []string
+	Actions    []string
+	Conditions []string // Each condition as "operator key value", e.g. "StringEquals AWS:SourceAccount 123456789012"
+}
+
+// RolePolicy represents a policy of a function's execution role
+type RolePolicy struct {
+	Name string
+	Arn  string // Empty for inline policies
+}
+
+// IsInline returns whether the policy is embedded in the role rather than attached
+func (p RolePolicy) IsInline() bool {
+	return p.Arn == ""
+}
+
+// SecurityFinding represents a public or over-permissive grant on a function
+type SecurityFinding struct {
+	Severity string
+	Subject  string // What the finding is about, e.g. "Function URL" or a statement's Sid
+	Detail   string
+}
+
+// FunctionSecurity represents who can invoke a function and what the function itself may do
+type FunctionSecurity struct {
+	FunctionName     string
+	Role             string
+	FunctionURL      *FunctionURLConfig // Nil when the function has no URL
+	PolicyStatements []PolicyStatement
+	RolePolicies     []RolePolicy
+	Findings         []SecurityFinding // Most urgent first
+	Errors           []string          // Parts of the review that couldn't be loaded
+}
+
+// AsyncInvokeConfig represents how a function retries asynchronous invocations and where it
+// sends their outcome
+type AsyncInvokeConfig struct {
+	FunctionName     string
+	MaxRetryAttempts int32 // 0 to 2
+	MaxEventAge      int32 // Seconds an event is kept for retries, 60 to 21600
+	IsDefault        bool  // Whether the function has no config of its own and uses the defaults
+	OnSuccess        string
+	OnFailure        string
+	DeadLetterQueue  string
+}
+
+// FailedEventQueues returns the SQS queues failed events end up in: the on-failure destination
+// and the dead-letter queue, when they are queues
+func (c AsyncInvokeConfig) FailedEventQueues() []string {
+	var queues []string
+	for _, target := range []string{c.OnFailure, c.DeadLetterQueue} {
+		if strings.HasPrefix(target, "arn:aws:sqs:") && !containsValue(queues, target) {
+			queues = append(queues, target)
+		}
+	}
+	return queues
+}
+
+// FailedEvent represents an asynchronous invocation that failed, as found in a failure queue
+type FailedEvent struct {
+	MessageID    string
+	QueueArn     string
+	SentAt       time.Time
+	ReceiveCount int
+	RequestID    string // Request ID of the failed invocation
+	Condition    string // Why the event was given up on, e.g. RetriesExhausted or EventAgeExceeded
+	ErrorMessage string
+	Payload      string // The event the function was invoked with
+}
+
+// S3Bucket represents an S3 bucket and the region it lives in
+type S3Bucket struct {
+	Name         string
+	Region       string
+	CreationDate time.Time
+}
+
+// S3Object represents an object in a bucket listing, or a common prefix shown like a directory
+type S3Object struct {
+	Key          string // Full key, ending in the delimiter for prefixes
+	IsPrefix     bool
+	Size         int64
+	StorageClass string
+	LastModified time.Time
+}
+
+// Name returns the object's key relative to the prefix it's listed under
+func (o S3Object) Name(prefix string) string {
+	return strings.TrimPrefix(o.Key, prefix)
+}
+
+// S3ObjectPage represents one page of the objects and prefixes directly under a prefix
+type S3ObjectPage struct {
+	Prefix    string
+	Objects   []S3Object // Prefixes first, then objects, each in key order
+	NextToken string     // Continuation token of the next page, empty on the last page
+}
+
+// S3ObjectDetails represents the properties, user metadata and tags of an object
+type S3ObjectDetails struct {
+	Bucket               string
+	Key                  string
+	Size                 int64
+	ContentType          string
+	StorageClass         string
+	LastModified         time.Time
+	ETag                 string
+	VersionID            string
+	ServerSideEncryption string
+	Metadata             map[string]string
+	Tags                 map[string]string
+	TagsError            string // Why the tags couldn't be loaded, e.g. missing permissions
+}
+
+// EC2 instance states, as named by the EC2 API
+const (
+	EC2StatePending      = "pending"
+	EC2StateRunning      = "running"
+	EC2StateStopping     = "stopping"
+	EC2StateStopped      = "stopped"
+	EC2StateShuttingDown = "shutting-down"
+	EC2StateTerminated   = "terminated"
+)
+
+// EC2InstanceStates lists the instance states in the order they can be filtered by
+var EC2InstanceStates = []string{
+	EC2StateRunning,
+	EC2StateStopped,
+	EC2StatePending,
+	EC2StateStopping,
+	EC2StateShuttingDown,
+	EC2StateTerminated,
+}
+
+// EC2InstanceFilter narrows the instances listed to a state and a tag
+type EC2InstanceFilter struct {
+	State    string // Empty for every state
+	TagKey   string // Empty for any tags
+	TagValue string // Empty for any value of TagKey
+}
+
+// Tag returns the tag filter as key=value, the key alone when any value matches, or "" for none
+func (f EC2InstanceFilter) Tag() string {
+	if f.TagValue == "" {
+		return f.TagKey
+	}
+	return f.TagKey + "=" + f.TagValue
+}
+
+// EC2Instance represents an EC2 instance in the instance list
+type EC2Instance struct {
+	ID               string
+	Name             string // Value of the Name tag
+	State            string
+	Type             string
+	AvailabilityZone string
+	PrivateIP        string
+	PublicIP         string
+	LaunchTime       time.Time
+	Tags             map[string]string
+}
+
+// EC2SecurityGroup represents a security group attached to an instance
+type EC2SecurityGroup struct {
+	ID   string
+	Name string
+}
+
+// EC2Volume represents an EBS volume attached to an instance
+type EC2Volume struct {
+	ID                  string
+	Device              string
+	Size                int32 // GiB
+	Type                string
+	State               string
+	Encrypted           bool
+	DeleteOnTermination bool
+}
+
+// EC2InstanceDetails represents an instance with its network, storage and status checks
+type EC2InstanceDetails struct {
+	EC2Instance
+	ImageID         string
+	Platform        string
+	Architecture    string
+	KeyName         string
+	VpcID           string
+	SubnetID        string
+	InstanceProfile string // ARN of the IAM instance profile
+	StateReason     string // Why the instance last changed state, e.g. a user initiated stop
+	SecurityGroups  []EC2SecurityGroup
+	Volumes         []EC2Volume
+	SystemStatus    string // System status check, "not-applicable" while the instance isn't running
+	InstanceStatus  string // Instance status check
+}
+
+// Logs Insights query statuses, as named by the CloudWatch Logs API
+const (
+	LogsQueryScheduled = "Scheduled"
+	LogsQueryRunning   = "Running"
+	LogsQueryComplete  = "Complete"
+	LogsQueryFailed    = "Failed"
+	LogsQueryCancelled = "Cancelled"
+	LogsQueryTimeout   = "Timeout"
+	LogsQueryUnknown   = "Unknown"
+)
+
+// LogGroup represents a CloudWatch Logs log group
+type LogGroup struct {
+	Name          string
+	StoredBytes   int64
+	RetentionDays int32 // 0 when events never expire
+	CreationTime  time.Time
+}
+
+// LogsQuery represents a Logs Insights query over log groups and a time range
+type LogsQuery struct {
+	LogGroups []string
+	Query     string
+	Start     time.Time
+	End       time.Time
+}
+
+// LogsQueryResults represents the progress of a Logs Insights query and the records matched so far
+type LogsQueryResults struct {
+	Status         string
+	RecordsMatched float64
+	RecordsScanned float64
+	BytesScanned   float64
+	Fields         []string   // Fields in the order they first appear, without the hidden @ptr
+	Records        [][]string // Values of each record in the order of Fields, "" where a record has none
+}
+
+// Done returns whether the query stopped running, whether it completed or not
+func (r *LogsQueryResults) Done() bool {
+	return r.Status != LogsQueryScheduled && r.Status != LogsQueryRunning
+}
+
+// SavedLogsQuery represents a query saved as a CloudWatch Logs query definition
+type SavedLogsQuery struct {
+	ID        string // Empty for a query that isn't saved yet
+	Name      string
+	LogGroups []string
+	Query     string
+}
+
+// CloudWatch alarm states, as named by the CloudWatch API
+const (
+	AlarmStateAlarm            = "ALARM"
+	AlarmStateInsufficientData = "INSUFFICIENT_DATA"
+	AlarmStateOK               = "OK"
+)
+
+// AlarmStates lists the alarm states in the order alarms are grouped in, those in alarm first
+var AlarmStates = []string{
+	AlarmStateAlarm,
+	AlarmStateInsufficientData,
+	AlarmStateOK,
+}
+
+// CloudWatch alarm types
+const (
+	AlarmTypeMetric    = "Metric"
+	AlarmTypeComposite = "Composite"
+)
+
+// Alarm represents a CloudWatch metric or composite alarm
+type Alarm struct {
+	Name           string
+	ARN            string
+	Type           string // AlarmTypeMetric or AlarmTypeComposite
+	Description    string
+	State          string
+	StateReason    string
+	StateChanged   time.Time // When the alarm last moved between states
+	ActionsEnabled bool      // Alarms with their actions disabled are muted: their state changes notify no one
+	Resource       string    // Metric and dimensions a metric alarm watches, or the rule of a composite alarm
+}
+
+// AlarmHistoryItem represents a state change, configuration update or action of an alarm
+type AlarmHistoryItem struct {
+	Timestamp time.Time
+	Type      string // StateUpdate, ConfigurationUpdate or Action
+	Summary   string
+}
+
+// ECS deployment rollout states, as named by the ECS API
+const (
+	ECSRolloutInProgress = "IN_PROGRESS"
+	ECSRolloutCompleted  = "COMPLETED"
+	ECSRolloutFailed     = "FAILED"
+)
+
+// ECSPrimaryDeployment is the status of the deployment a service is rolling out, or last rolled out
+const ECSPrimaryDeployment = "PRIMARY"
+
+// ECSCluster represents an ECS cluster
+type ECSCluster struct {
+	Name           string
+	ARN            string
+	Status         string
+	ActiveServices int32
+	RunningTasks   int32
+	PendingTasks   int32
+}
+
+// ECSService represents an ECS service with its deployments and recent events
+type ECSService struct {
+	Name           string
+	ARN            string
+	Cluster        string // Name of the cluster the service runs in
+	Status         string
+	LaunchType     string // Empty for services using a capacity provider strategy
+	TaskDefinition string
+	DesiredCount   int32
+	RunningCount   int32
+	PendingCount   int32
+	Deployments    []ECSDeployment   // The primary deployment first
+	Events         []ECSServiceEvent // Newest first
+}
+
+// Rollout returns the primary deployment of the service, or nil for a service without deployments
+func (s ECSService) Rollout() *ECSDeployment {
+	for i := range s.Deployments {
+		if s.Deployments[i].Status == ECSPrimaryDeployment {
+			return &s.Deployments[i]
+		}
+	}
+	return nil
+}
+
+// ECSDeployment represents a deployment of a service, rolling out a task definition
+type ECSDeployment struct {
+	ID                 string
+	Status             string // PRIMARY for the latest deployment, ACTIVE for those it's replacing
+	TaskDefinition     string
+	RolloutState       string // Empty for services not using the rolling update deployment type
+	RolloutStateReason string
+	DesiredCount       int32
+	RunningCount       int32
+	PendingCount       int32
+	FailedTasks        int32
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// ECSServiceEvent represents an event ECS reported for a service, such as reaching a steady state
+type ECSServiceEvent struct {
+	CreatedAt time.Time
+	Message   string
+}
+
+// ECSTask represents a running or recently stopped task of a service
+type ECSTask struct {
+	ID             string // Last part of the task ARN
+	ARN            string
+	TaskDefinition string
+	LastStatus     string
+	DesiredStatus  string
+	HealthStatus   string
+	StartedAt      time.Time
+	StoppedAt      time.Time
+	StopCode       string
+	StoppedReason  string
+	Containers     []ECSContainer
+}
+
+// ECSContainer represents a container of a task
+type ECSContainer struct {
+	Name         string
+	Image        string
+	LastStatus   string
+	HealthStatus string
+	ExitCode     *int32 // Set once the container has exited
+	Reason       string // Why the container stopped, if it did abnormally
+}
+
+// CodeBuild build and phase statuses, as named by the CodeBuild API
+const (
+	BuildStatusInProgress = "IN_PROGRESS"
+	BuildStatusSucceeded  = "SUCCEEDED"
+	BuildStatusFailed     = "FAILED"
+	BuildStatusFault      = "FAULT"
+	BuildStatusTimedOut   = "TIMED_OUT"
+	BuildStatusStopped    = "STOPPED"
+)
+
+// BuildProject represents a CodeBuild project
+type BuildProject struct {
+	Name           string
+	ARN            string
+	Description    string
+	SourceType     string // Such as GITHUB, CODECOMMIT or CODEPIPELINE
+	SourceLocation string // Empty for projects whose source comes from a pipeline
+	SourceVersion  string // Version built when a build doesn't override it
+	LastModified   time.Time
+}
+
+// Build represents a build of a CodeBuild project
+type Build struct {
+	ID                    string
+	Number                int64
+	Project               string
+	Status                string
+	CurrentPhase          string
+	SourceVersion         string // Version the build was asked for, empty for the project's default
+	ResolvedSourceVersion string // Commit the source version resolved to
+	Initiator             string // Such as an IAM user or codepipeline/<pipeline name>
+	StartTime             time.Time
+	EndTime               time.Time // Zero while the build is running
+	Phases                []BuildPhase
+	LogGroup              string // CloudWatch Logs group and stream of the build, empty until it starts logging
+	LogStream             string
+}
+
+// Done returns whether the build stopped running, whether it succeeded or not
+func (b *Build) Done() bool {
+	return b.Status != BuildStatusInProgress
+}
+
+// Duration returns how long the build ran, or has been running so far
+func (b *Build) Duration() time.Duration {
+	if b.StartTime.IsZero() {
+		return 0
+	}
+	if b.EndTime.IsZero() {
+		return time.Since(b.StartTime)
+	}
+	return b.EndTime.Sub(b.StartTime)
+}
+
+// BuildPhase represents a phase of a build, such as INSTALL or BUILD
+type BuildPhase struct {
+	Type      string
+	Status    string // Empty while the phase is running
+	StartTime time.Time
+	EndTime   time.Time
+	Duration  time.Duration
+	Message   string // Why the phase failed, if it did
+}
+
+// BuildEnvironmentVariable represents a plaintext environment variable overriding the project's
+type BuildEnvironmentVariable struct {
+	Name  string
+	Value string
+}
+
+// BuildStart represents the build to start for a project and what it overrides
+type BuildStart struct {
+	Project       string
+	SourceVersion string // Empty to build the project's default source version
+	Variables     []BuildEnvironmentVariable
+}
+
+// BuildLogEvent represents a line of a build's log
+type BuildLogEvent struct {
+	Timestamp time.Time
+	Message   string
+}
+
+// BuildLogs represents the log lines of a build read from a token, and the token to read the next
+// lines from
+type BuildLogs struct {
+	Events    []BuildLogEvent
+	NextToken string
+}
+
+// CloudFormation drift detection and change set states, as named by the CloudFormation API
+const (
+	DriftDetectionInProgress = "DETECTION_IN_PROGRESS"
+	DriftDetectionComplete   = "DETECTION_COMPLETE"
+	DriftDetectionFailed     = "DETECTION_FAILED"
+
+	ResourceDriftInSync   = "IN_SYNC"
+	ResourceDriftModified = "MODIFIED"
+	ResourceDriftDeleted  = "DELETED"
+
+	ChangeSetExecutionAvailable = "AVAILABLE"
+)
+
+// Stack represents a CloudFormation stack with its outputs and parameters
+type Stack struct {
+	Name         string
+	ID           string
+	Status       string
+	StatusReason string
+	Description  string
+	CreationTime time.Time
+	LastUpdated  time.Time // Zero for stacks never updated
+	DriftStatus  string    // Such as DRIFTED or IN_SYNC, NOT_CHECKED until drift is detected
+	DriftChecked time.Time
+	Outputs      []StackOutput
+	Parameters   []StackParameter
+}
+
+// StackOutput represents a value a stack outputs
+type StackOutput struct {
+	Key         string
+	Value       string
+	Description string
+	ExportName  string // Empty for outputs that aren't exported
+}
+
+// StackParameter represents a parameter a stack was deployed with
+type StackParameter struct {
+	Key           string
+	Value         string
+	ResolvedValue string // Set for parameters read from SSM Parameter Store
+}
+
+// StackEvent represents an event of a stack or one of its resources
+type StackEvent struct {
+	Timestamp    time.Time
+	LogicalID    string
+	PhysicalID   string
+	ResourceType string
+	Status       string
+	Reason       string
+}
+
+// Failed returns whether the event reports an operation failing, such as CREATE_FAILED
+func (e *StackEvent) Failed() bool {
+	return strings.HasSuffix(e.Status, "_FAILED")
+}
+
+// StackDriftDetection represents a drift detection of a stack, running or finished
+type StackDriftDetection struct {
+	ID               string
+	Status           string
+	Reason           string // Why the detection failed, if it did
+	StackDriftStatus string
+	DriftedResources int
+}
+
+// Done returns whether the detection finished, whether it succeeded or not
+func (d *StackDriftDetection) Done() bool {
+	return d.Status != DriftDetectionInProgress
+}
+
+// StackResourceDrift represents how a resource of a stack differs from its template
+type StackResourceDrift struct {
+	LogicalID    string
+	PhysicalID   string
+	ResourceType string
+	Status       string // IN_SYNC, MODIFIED, DELETED or NOT_CHECKED
+	Differences  []PropertyDifference
+}
+
+// PropertyDifference represents a property of a resource that drifted
+type PropertyDifference struct {
+	Path     string
+	Type     string // ADD, REMOVE or NOT_EQUAL
+	Expected string
+	Actual   string
+}
+
+// ChangeSet represents a change set of a stack and the resource changes it holds
+type ChangeSet struct {
+	Name            string
+	ID              string
+	Status          string
+	StatusReason    string
+	ExecutionStatus string
+	Description     string
+	CreationTime    time.Time
+	Changes         []ResourceChange // Only set for a described change set
+}
+
+// Executable returns whether the change set can be executed
+func (c *ChangeSet) Executable() bool {
+	return c.ExecutionStatus == ChangeSetExecutionAvailable
+}
+
+// ResourceChange represents the change a change set makes to a resource
+type ResourceChange struct {
+	Action       string // Add, Modify, Remove, Import or Dynamic
+	LogicalID    string
+	PhysicalID   string
+	ResourceType string
+	Replacement  string   // True, False or Conditional for modified resources
+	Scope        []string // What's modified, such as Properties or Tags
+}
+
+// SSM parameter types, as named by the Systems Manager API
+const (
+	ParameterTypeString       = "String"
+	ParameterTypeStringList   = "StringList"
+	ParameterTypeSecureString = "SecureString"
+)
+
+// Parameter represents a parameter in Systems Manager Parameter Store, without its value
+type Parameter struct {
+	Name             string // Full name, with its path for parameters in a hierarchy
+	Type             string
+	Version          int64
+	LastModified     time.Time
+	LastModifiedUser string
+	Description      string
+	KeyID            string // KMS key a SecureString is encrypted with
+	Tier             string
+	DataType         string
+	AllowedPattern   string
+}
+
+// Secure returns whether the parameter's value is encrypted
+func (p *Parameter) Secure() bool {
+	return p.Type == ParameterTypeSecureString
+}
+
+// ParameterValue represents the value of the latest version of a parameter
+type ParameterValue struct {
+	Name         string
+	Type         string
+	Version      int64
+	Value        string // Encrypted for a SecureString that isn't decrypted
+	Decrypted    bool
+	LastModified time.Time
+}
+
+// ParameterVersion represents a version in the history of a parameter
+type ParameterVersion struct {
+	Version          int64
+	Value            string // Encrypted for a SecureString that isn't decrypted
+	LastModified     time.Time
+	LastModifiedUser string
+	Description      string
+	Labels           []string
+}
+
+// Secret represents a secret in Secrets Manager, without its value
+type Secret struct {
+	Name              string
+	ARN               string
+	Description       string
+	KMSKeyID          string // Empty for the account's default key
+	OwningService     string // Service that manages the secret, such as rds
+	CreatedDate       time.Time
+	LastChanged       time.Time
+	LastRotated       time.Time // Zero for secrets never rotated
+	LastAccessed      time.Time // Only the day is kept by Secrets Manager
+	RotationEnabled   bool
+	RotationLambdaARN string // Empty for secrets rotated by the service that manages them
+	RotationAfterDays int64  // Set for rotations scheduled a number of days apart
+	RotationSchedule  string // Set for rotations scheduled with a cron() or rate() expression
+	RotationWindow    string // Length of the window rotations run in, such as 3h
+	NextRotation      time.Time
+}
+
+// SecretValue represents the current version of a secret's value
+type SecretValue struct {
+	VersionID string
+	Stages    []string
+	Created   time.Time
+	Value     string // Empty for binary secrets
+	Binary    bool
+	Size      int // Size of a binary secret in bytes
+}
+
+// TestMessageGroup is the message group of the test messages sent to FIFO queues and topics
+const TestMessageGroup = "cloudgate-test"
+
+// Queue represents an SQS queue with the approximate number of messages it holds
+type Queue struct {
+	Name              string
+	URL               string
+	ARN               string
+	FIFO              bool
+	Visible           int64         // Messages available to receive
+	InFlight          int64         // Messages received but not yet deleted
+	Delayed           int64         // Messages not yet available because of a delay
+	OldestMessageAge  time.Duration // Zero without recent CloudWatch data
+	VisibilityTimeout time.Duration
+	RetentionPeriod   time.Duration
+	Delay             time.Duration
+	Created           time.Time
+	LastModified      time.Time
+	DeadLetterQueue   string   // ARN of the queue messages move to after MaxReceiveCount receives
+	MaxReceiveCount   int64    // Set with DeadLetterQueue
+	SourceQueues      []string // ARNs of the queues this queue is the dead-letter queue of
+	Errors            []string // Parts of the queue that couldn't be loaded, such as its message age
+}
+
+// IsDeadLetterQueue returns whether other queues move their failed messages to the queue
+func (q *Queue) IsDeadLetterQueue() bool {
+	return len(q.SourceQueues) > 0
+}
+
+// QueueMessage represents a message peeked from a queue
+type QueueMessage struct {
+	ID           string
+	Body         string
+	SentAt       time.Time
+	ReceiveCount int // Includes the receive of the peek
+	GroupID      string
+	Attributes   map[string]string // Message attributes with a string value
+}
+
+// Topic represents an SNS topic with the number of its subscriptions
+type Topic struct {
+	Name                      string
+	ARN                       string
+	DisplayName               string
+	FIFO                      bool
+	ContentBasedDeduplication bool
+	KMSKeyID                  string
+	Confirmed                 int64 // Subscriptions confirmed
+	Pending                   int64 // Subscriptions pending confirmation
+	Deleted                   int64 // Subscriptions deleted but not yet cleaned up
+}
+
+// Subscription represents a subscription to an SNS topic
+type Subscription struct {
+	ARN                 string // Empty while the subscription is pending confirmation
+	Protocol            string
+	Endpoint            string
+	Owner               string
+	PendingConfirmation bool
+	FilterPolicy        string // JSON filter policy, empty when every message is delivered
+	FilterPolicyScope   string // MessageAttributes or MessageBody, set with FilterPolicy
+	RawMessageDelivery  bool
+	DeadLetterQueue     string // ARN of the queue undeliverable messages move to
+}
+
+// TopicMessage represents a message to publish to a topic
+type TopicMessage struct {
+	Subject    string
+	Body       string
+	Attributes map[string]TopicMessageAttribute
+}
+
+// TopicMessageAttribute represents an attribute of a message published to a topic
+type TopicMessageAttribute struct {
+	DataType string // String, Number or String.Array
+	Value    string
 }
 
 // CodePipelineManualApprovalOperation represents a manual approval operation for AWS CodePipeline
@@ -204,6 +1227,12 @@ type FunctionStatusOperation interface {
 
 	// GetFunctionStatus returns the status of all Lambda functions
 	GetFunctionStatus(ctx context.Context) ([]FunctionStatus, error)
+
+	// DescribeFunctions returns listed functions with their state and tags, which listing leaves out
+	DescribeFunctions(ctx context.Context, functions []FunctionStatus) ([]FunctionStatus, error)
+
+	// CompareFunction looks a function up in each target of the options and lines up its configuration
+	CompareFunction(ctx context.Context, functionName string, options FunctionCompareOptions) (*FunctionComparison, error)
 }
 
 // LambdaExecuteOperation represents an operation to execute a Lambda function
@@ -212,4 +1241,335 @@ type LambdaExecuteOperation interface {
 
 	// ExecuteFunction executes a Lambda function with the given payload
 	ExecuteFunction(ctx context.Context, functionName string, payload string) (*LambdaExecuteResult, error)
+
+	// Invoker returns a LambdaInvoker for a function, for invoking it repeatedly
+	Invoker(ctx context.Context, functionName string) (LambdaInvoker, error)
+
+	// IsResponseStreaming returns whether a function is configured to stream its response
+	IsResponseStreaming(ctx context.Context, functionName string) (bool, error)
+
+	// ExecuteFunctionStream executes a Lambda function with response streaming, calling onChunk
+	// with each chunk as it arrives. The result holds the whole payload once the stream closes.
+	ExecuteFunctionStream(ctx context.Context, functionName string, payload string, onChunk func(chunk []byte)) (*LambdaExecuteResult, error)
+}
+
+// LambdaConfigurationOperation represents an operation to tune a Lambda function's configuration
+type LambdaConfigurationOperation interface {
+	UIOperation
+
+	// GetFunctionConfiguration returns the full configuration of a function, including concurrency settings
+	GetFunctionConfiguration(ctx context.Context, functionName string) (*FunctionStatus, error)
+
+	// ValidateConfigUpdate validates an update against the service limits
+	ValidateConfigUpdate(current FunctionStatus, update FunctionConfigUpdate) error
+
+	// UpdateFunctionConfiguration applies the given changes to a function
+	UpdateFunctionConfiguration(ctx context.Context, functionName string, update FunctionConfigUpdate) error
+}
+
+// LambdaDeployOperation represents an operation to deploy new code to a Lambda function
+type LambdaDeployOperation interface {
+	UIOperation
+
+	// ValidateDeployRequest validates a deployment request before any changes are made
+	ValidateDeployRequest(request LambdaDeployRequest) error
+
+	// DeployFunctionCode updates a function's code and waits for the update to complete
+	DeployFunctionCode(ctx context.Context, functionName string, request LambdaDeployRequest) (*LambdaDeployResult, error)
+}
+
+// LambdaMetricsOperation represents an operation to view the recent metrics of Lambda functions
+type LambdaMetricsOperation interface {
+	UIOperation
+
+	// GetFunctionMetrics returns the metrics of the given functions over the window, keyed by function name
+	GetFunctionMetrics(ctx context.Context, functionNames []string, window time.Duration) (map[string]FunctionMetrics, error)
+}
+
+// LambdaEventSourceOperation represents an operation to manage Lambda event source mappings
+type LambdaEventSourceOperation interface {
+	UIOperation
+
+	// ListEventSourceMappings returns the event source mappings of a function, or of the whole account if functionName is empty
+	ListEventSourceMappings(ctx context.Context, functionName string) ([]EventSourceMapping, error)
+
+	// SetEventSourceMappingEnabled enables or disables an event source mapping
+	SetEventSourceMappingEnabled(ctx context.Context, uuid string, enabled bool) (*EventSourceMapping, error)
+}
+
+// LambdaHygieneOperation represents an operation to report on deprecated runtimes and risky Lambda settings
+type LambdaHygieneOperation interface {
+	UIOperation
+
+	// GenerateHygieneReport scans the functions of each region in the options and returns their findings
+	GenerateHygieneReport(ctx context.Context, options HygieneReportOptions) (*HygieneReport, error)
+}
+
+// LambdaPackageOperation represents an operation to download the deployment package of a Lambda function
+type LambdaPackageOperation interface {
+	UIOperation
+
+	// DownloadFunctionPackage downloads the zip archive a function is deployed from
+	DownloadFunctionPackage(ctx context.Context, functionName string) (*FunctionPackage, error)
+}
+
+// LambdaSecurityOperation represents an operation to review who can invoke a Lambda function and with what permissions it runs
+type LambdaSecurityOperation interface {
+	UIOperation
+
+	// GetFunctionSecurity returns the function URL, resource-based policy and execution role policies of a function
+	GetFunctionSecurity(ctx context.Context, functionName string) (*FunctionSecurity, error)
+}
+
+// LambdaAsyncOperation represents an operation to inspect asynchronous invocations and replay failed events
+type LambdaAsyncOperation interface {
+	UIOperation
+
+	// GetAsyncInvokeConfig returns the retry settings and destinations of a function
+	GetAsyncInvokeConfig(ctx context.Context, functionName string) (*AsyncInvokeConfig, error)
+
+	// PeekFailedEvents returns up to limit failed events from an SQS queue, leaving them in the queue
+	PeekFailedEvents(ctx context.Context, queueArn string, limit int) ([]FailedEvent, error)
+
+	// RedriveEvent invokes a function asynchronously with the payload of a failed event
+	RedriveEvent(ctx context.Context, functionName string, event FailedEvent) error
+}
+
+// S3BrowserOperation represents an operation to browse S3 buckets and objects
+type S3BrowserOperation interface {
+	UIOperation
+
+	// ListBuckets returns the buckets of the account, in name order
+	ListBuckets(ctx context.Context) ([]S3Bucket, error)
+
+	// ListObjects returns a page of up to limit objects and prefixes directly under a prefix,
+	// continuing from the token of a previous page or from the start when it's empty
+	ListObjects(ctx context.Context, bucket S3Bucket, prefix, token string, limit int) (*S3ObjectPage, error)
+
+	// GetObjectDetails returns the properties, metadata and tags of an object
+	GetObjectDetails(ctx context.Context, bucket S3Bucket, key string) (*S3ObjectDetails, error)
+}
+
+// S3TransferOperation represents an operation to move objects between S3 and the local disk
+type S3TransferOperation interface {
+	UIOperation
+
+	// DownloadObject writes an object to a local file, reporting progress as it's written
+	DownloadObject(ctx context.Context, bucket S3Bucket, key, localPath string, progress S3TransferProgress) error
+
+	// UploadFiles uploads the files of an upload, in parts for large files, reporting progress
+	// across all of them
+	UploadFiles(ctx context.Context, bucket S3Bucket, upload *S3Upload, progress S3TransferProgress) error
+
+	// PresignObject returns a URL that gets or puts an object without credentials until it expires
+	PresignObject(ctx context.Context, bucket S3Bucket, request S3PresignRequest) (*S3PresignedURL, error)
+
+	// PreviewObject returns up to limit bytes from the start of an object
+	PreviewObject(ctx context.Context, bucket S3Bucket, key string, limit int64) (*S3ObjectPreview, error)
+}
+
+// EC2InstanceOperation represents an operation to list, inspect, start, stop and reboot EC2 instances
+type EC2InstanceOperation interface {
+	UIOperation
+
+	// ListInstances returns the instances matching a filter, in name order
+	ListInstances(ctx context.Context, filter EC2InstanceFilter) ([]EC2Instance, error)
+
+	// GetInstanceDetails returns an instance with its security groups, volumes and status checks
+	GetInstanceDetails(ctx context.Context, instanceID string) (*EC2InstanceDetails, error)
+
+	// StartInstance starts a stopped instance and returns the state it moved to
+	StartInstance(ctx context.Context, instanceID string) (string, error)
+
+	// StopInstance stops a running instance and returns the state it moved to
+	StopInstance(ctx context.Context, instanceID string) (string, error)
+
+	// RebootInstance reboots a running instance
+	RebootInstance(ctx context.Context, instanceID string) error
+}
+
+// LogsInsightsOperation represents an operation to run Logs Insights queries and manage saved queries
+type LogsInsightsOperation interface {
+	UIOperation
+
+	// ListLogGroups returns the log groups of the account, in name order
+	ListLogGroups(ctx context.Context) ([]LogGroup, error)
+
+	// StartQuery starts a query and returns its ID to get the results with
+	StartQuery(ctx context.Context, query LogsQuery) (string, error)
+
+	// GetQueryResults returns the status of a query and the records it matched so far
+	GetQueryResults(ctx context.Context, queryID string) (*LogsQueryResults, error)
+
+	// ListSavedQueries returns the saved queries over any of the log groups, in name order
+	ListSavedQueries(ctx context.Context, logGroups []string) ([]SavedLogsQuery, error)
+
+	// SaveQuery saves a query, replacing the saved query with its ID if it has one, and returns its ID
+	SaveQuery(ctx context.Context, query SavedLogsQuery) (string, error)
+}
+
+// AlarmOperation represents an operation to list, mute and unmute CloudWatch alarms and read their history
+type AlarmOperation interface {
+	UIOperation
+
+	// ListAlarms returns the metric and composite alarms whose names start with a prefix, or every
+	// alarm for an empty prefix, grouped in the order of AlarmStates and in name order within a state
+	ListAlarms(ctx context.Context, prefix string) ([]Alarm, error)
+
+	// SetAlarmActions enables or disables the actions of an alarm without changing its state
+	SetAlarmActions(ctx context.Context, name string, enabled bool) error
+
+	// GetAlarmHistory returns the recent history of an alarm, newest first
+	GetAlarmHistory(ctx context.Context, name string) ([]AlarmHistoryItem, error)
+}
+
+// ECSServiceOperation represents an operation to browse ECS clusters, services and tasks, and to
+// redeploy and scale services
+type ECSServiceOperation interface {
+	UIOperation
+
+	// ListClusters returns the clusters of the account, in name order
+	ListClusters(ctx context.Context) ([]ECSCluster, error)
+
+	// ListServices returns the services of a cluster with their deployments and events, in name order
+	ListServices(ctx context.Context, cluster string) ([]ECSService, error)
+
+	// ListTasks returns the running and recently stopped tasks of a service, running tasks first
+	ListTasks(ctx context.Context, cluster, service string) ([]ECSTask, error)
+
+	// ForceNewDeployment starts a deployment of a service that replaces its tasks with new ones
+	// running the same task definition
+	ForceNewDeployment(ctx context.Context, cluster, service string) error
+
+	// SetDesiredCount changes how many tasks of a service should run
+	SetDesiredCount(ctx context.Context, cluster, service string, count int32) error
+}
+
+// CodeBuildOperation represents an operation to browse CodeBuild projects and builds, start builds
+// and follow their logs
+type CodeBuildOperation interface {
+	UIOperation
+
+	// ListProjects returns the projects of the account, in name order
+	ListProjects(ctx context.Context) ([]BuildProject, error)
+
+	// ListBuilds returns the recent builds of a project, newest first
+	ListBuilds(ctx context.Context, project string) ([]Build, error)
+
+	// GetBuild returns a build with its phases
+	GetBuild(ctx context.Context, id string) (*Build, error)
+
+	// StartBuild starts a build of a project and returns it
+	StartBuild(ctx context.Context, start BuildStart) (*Build, error)
+
+	// GetBuildLogs returns the log lines of a build written after a token, or from the start for an
+	// empty token
+	GetBuildLogs(ctx context.Context, build Build, token string) (*BuildLogs, error)
+}
+
+// CloudFormationOperation represents an operation to inspect CloudFormation stacks, detect their
+// drift and review and execute their change sets
+type CloudFormationOperation interface {
+	UIOperation
+
+	// ListStacks returns the stacks of the account that aren't deleted, in name order
+	ListStacks(ctx context.Context) ([]Stack, error)
+
+	// GetStackEvents returns the latest events of a stack, newest first
+	GetStackEvents(ctx context.Context, stack string) ([]StackEvent, error)
+
+	// DetectStackDrift starts detecting the drift of a stack and returns the ID of the detection
+	DetectStackDrift(ctx context.Context, stack string) (string, error)
+
+	// GetStackDriftDetection returns the state of a drift detection
+	GetStackDriftDetection(ctx context.Context, id string) (*StackDriftDetection, error)
+
+	// GetStackResourceDrifts returns the resources of a stack that drifted in the last detection
+	GetStackResourceDrifts(ctx context.Context, stack string) ([]StackResourceDrift, error)
+
+	// ListChangeSets returns the change sets of a stack, newest first
+	ListChangeSets(ctx context.Context, stack string) ([]ChangeSet, error)
+
+	// GetChangeSet returns a change set of a stack with its resource changes
+	GetChangeSet(ctx context.Context, stack, name string) (*ChangeSet, error)
+
+	// ExecuteChangeSet updates a stack with a change set
+	ExecuteChangeSet(ctx context.Context, stack, name string) error
+}
+
+// ParameterStoreOperation represents operations on Systems Manager Parameter Store
+type ParameterStoreOperation interface {
+	UIOperation
+
+	// ListParameters returns the parameters of the account without their values, in name order
+	ListParameters(ctx context.Context) ([]Parameter, error)
+
+	// GetParameterValue returns the value of a parameter, decrypting a SecureString if asked to
+	GetParameterValue(ctx context.Context, name string, decrypt bool) (*ParameterValue, error)
+
+	// GetParameterHistory returns the versions of a parameter, newest first
+	GetParameterHistory(ctx context.Context, name string, decrypt bool) ([]ParameterVersion, error)
+
+	// PutParameterVersion puts a new version of a parameter with a value, keeping its type and
+	// settings, unless the parameter was changed since the given version. It returns the new version.
+	PutParameterVersion(ctx context.Context, parameter Parameter, value string) (int64, error)
+}
+
+// SecretsManagerOperation represents operations on Secrets Manager secrets
+type SecretsManagerOperation interface {
+	UIOperation
+
+	// ListSecrets returns the secrets of the account without their values, in name order
+	ListSecrets(ctx context.Context) ([]Secret, error)
+
+	// GetSecretValue returns the current value of a secret
+	GetSecretValue(ctx context.Context, secretID string) (*SecretValue, error)
+
+	// RotateSecret starts rotating a secret with its rotation configuration
+	RotateSecret(ctx context.Context, secretID string) error
+}
+
+// QueueOperation represents operations on SQS queues
+type QueueOperation interface {
+	UIOperation
+
+	// ListQueues returns the queues of the account with their message counts, in name order
+	ListQueues(ctx context.Context) ([]Queue, error)
+
+	// PeekMessages returns up to limit messages of a queue, leaving them in the queue
+	PeekMessages(ctx context.Context, queueURL string, limit int) ([]QueueMessage, error)
+
+	// SendMessage sends a message to a queue and returns its ID
+	SendMessage(ctx context.Context, queue Queue, body string) (string, error)
+
+	// PurgeQueue deletes all the messages of a queue
+	PurgeQueue(ctx context.Context, queueURL string) error
+
+	// RedriveMessages moves the messages of a dead-letter queue back to their source queues,
+	// reporting how many were moved until it's done, and returns how many were moved
+	RedriveMessages(ctx context.Context, queueARN string, progress func(moved, total int64)) (int64, error)
+}
+
+// TopicOperation represents operations on SNS topics
+type TopicOperation interface {
+	UIOperation
+
+	// ListTopics returns the topics of the account with their subscription counts, in name order
+	ListTopics(ctx context.Context) ([]Topic, error)
+
+	// ListSubscriptions returns the subscriptions to a topic with their filter policies
+	ListSubscriptions(ctx context.Context, topicARN string) ([]Subscription, error)
+
+	// PublishMessage publishes a message to a topic and returns its ID
+	PublishMessage(ctx context.Context, topic Topic, message TopicMessage) (string, error)
+}
+
+// containsValue returns whether a list holds a value
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
 }
@@ -157,6 +157,106 @@ func (w *AWSProviderWrapper) GetLambdaExecuteOperation() (cloud.LambdaExecuteOpe
 	return w.provider.GetLambdaExecuteOperation()
 }
 
+// GetLambdaConfigurationOperation returns the Lambda configuration operation
+func (w *AWSProviderWrapper) GetLambdaConfigurationOperation() (cloud.LambdaConfigurationOperation, error) {
+	return w.provider.GetLambdaConfigurationOperation()
+}
+
+// GetLambdaDeployOperation returns the Lambda code deployment operation
+func (w *AWSProviderWrapper) GetLambdaDeployOperation() (cloud.LambdaDeployOperation, error) {
+	return w.provider.GetLambdaDeployOperation()
+}
+
+// GetLambdaMetricsOperation returns the Lambda metrics operation
+func (w *AWSProviderWrapper) GetLambdaMetricsOperation() (cloud.LambdaMetricsOperation, error) {
+	return w.provider.GetLambdaMetricsOperation()
+}
+
+// GetLambdaEventSourceOperation returns the Lambda event source mapping operation
+func (w *AWSProviderWrapper) GetLambdaEventSourceOperation() (cloud.LambdaEventSourceOperation, error) {
+	return w.provider.GetLambdaEventSourceOperation()
+}
+
+// GetLambdaHygieneOperation returns the Lambda hygiene report operation
+func (w *AWSProviderWrapper) GetLambdaHygieneOperation() (cloud.LambdaHygieneOperation, error) {
+	return w.provider.GetLambdaHygieneOperation()
+}
+
+// GetLambdaPackageOperation returns the Lambda deployment package operation
+func (w *AWSProviderWrapper) GetLambdaPackageOperation() (cloud.LambdaPackageOperation, error) {
+	return w.provider.GetLambdaPackageOperation()
+}
+
+// GetLambdaSecurityOperation returns the Lambda security review operation
+func (w *AWSProviderWrapper) GetLambdaSecurityOperation() (cloud.LambdaSecurityOperation, error) {
+	return w.provider.GetLambdaSecurityOperation()
+}
+
+// GetLambdaAsyncOperation returns the Lambda asynchronous invocation operation
+func (w *AWSProviderWrapper) GetLambdaAsyncOperation() (cloud.LambdaAsyncOperation, error) {
+	return w.provider.GetLambdaAsyncOperation()
+}
+
+// GetS3BrowserOperation returns the S3 bucket and object browser operation
+func (w *AWSProviderWrapper) GetS3BrowserOperation() (cloud.S3BrowserOperation, error) {
+	return w.provider.GetS3BrowserOperation()
+}
+
+// GetS3TransferOperation returns the S3 object transfer operation
+func (w *AWSProviderWrapper) GetS3TransferOperation() (cloud.S3TransferOperation, error) {
+	return w.provider.GetS3TransferOperation()
+}
+
+// GetEC2InstanceOperation returns the EC2 instance operation
+func (w *AWSProviderWrapper) GetEC2InstanceOperation() (cloud.EC2InstanceOperation, error) {
+	return w.provider.GetEC2InstanceOperation()
+}
+
+// GetLogsInsightsOperation returns the CloudWatch Logs Insights operation
+func (w *AWSProviderWrapper) GetLogsInsightsOperation() (cloud.LogsInsightsOperation, error) {
+	return w.provider.GetLogsInsightsOperation()
+}
+
+// GetAlarmOperation returns the CloudWatch alarm operation
+func (w *AWSProviderWrapper) GetAlarmOperation() (cloud.AlarmOperation, error) {
+	return w.provider.GetAlarmOperation()
+}
+
+// GetECSServiceOperation returns the ECS service operation
+func (w *AWSProviderWrapper) GetECSServiceOperation() (cloud.ECSServiceOperation, error) {
+	return w.provider.GetECSServiceOperation()
+}
+
+// GetCodeBuildOperation returns the CodeBuild project and build operation
+func (w *AWSProviderWrapper) GetCodeBuildOperation() (cloud.CodeBuildOperation, error) {
+	return w.provider.GetCodeBuildOperation()
+}
+
+// GetCloudFormationOperation returns the CloudFormation stack operation
+func (w *AWSProviderWrapper) GetCloudFormationOperation() (cloud.CloudFormationOperation, error) {
+	return w.provider.GetCloudFormationOperation()
+}
+
+// GetParameterStoreOperation returns the Systems Manager Parameter Store operation
+func (w *AWSProviderWrapper) GetParameterStoreOperation() (cloud.ParameterStoreOperation, error) {
+	return w.provider.GetParameterStoreOperation()
+}
+
+// GetSecretsManagerOperation returns the Secrets Manager secret operation
+func (w *AWSProviderWrapper) GetSecretsManagerOperation() (cloud.SecretsManagerOperation, error) {
+	return w.provider.GetSecretsManagerOperation()
+}
+
+// GetQueueOperation returns the SQS queue operation
+func (w *AWSProviderWrapper) GetQueueOperation() (cloud.QueueOperation, error) {
+	return w.provider.GetQueueOperation()
+}
+
+// GetTopicOperation returns the SNS topic operation
+func (w *AWSProviderWrapper) GetTopicOperation() (cloud.TopicOperation, error) {
+	return w.provider.GetTopicOperation()
+}
+
 // GetAuthenticationMethods returns the available authentication methods
 func (w *AWSProviderWrapper) GetAuthenticationMethods() []string {
 	return w.provider.GetAuthenticationMethods()
@@ -1,8 +1,15 @@
 package commands
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
 )
 
 func TestVersionCommand(t *testing.T) {
@@ -61,3 +68,142 @@ func TestUpgradeCloudgateOSDetection(t *testing.T) {
 		}
 	}
 }
+
+func TestLambdaCommand(t *testing.T) {
+	// Test that the command is properly configured
+	cmd := NewLambdaCmd()
+
+	if cmd.Use != "lambda" {
+		t.Errorf("Expected command use to be 'lambda', got '%s'", cmd.Use)
+	}
+
+	if cmd.Short == "" {
+		t.Error("Command short description should not be empty")
+	}
+
+	if cmd.Long == "" {
+		t.Error("Command long description should not be empty")
+	}
+
+	deployCmd, _, err := cmd.Find([]string{"deploy"})
+	if err != nil || deployCmd.Name() != "deploy" {
+		t.Fatalf("Expected 'deploy' subcommand to be registered")
+	}
+
+	if deployCmd.Run == nil {
+		t.Error("Command run function should not be nil")
+	}
+
+	for _, name := range []string{"zip", "s3", "image", "publish", "alias", "profile", "region"} {
+		if deployCmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected '%s' flag to be defined", name)
+		}
+	}
+
+	benchCmd, _, err := cmd.Find([]string{"bench"})
+	if err != nil || benchCmd.Name() != "bench" {
+		t.Fatalf("Expected 'bench' subcommand to be registered")
+	}
+
+	for _, name := range []string{"payload", "payload-file", "invocations", "concurrency", "profile", "region"} {
+		if benchCmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected '%s' flag to be defined", name)
+		}
+	}
+}
+
+func TestReadBenchPayload(t *testing.T) {
+	payload, err := readBenchPayload(`{"id": 1}`, "")
+	if err != nil || payload != `{"id": 1}` {
+		t.Errorf("Expected the payload flag, got %q, %v", payload, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "event.json")
+	if err := os.WriteFile(path, []byte(`{"id": 2}`), 0o600); err != nil {
+		t.Fatalf("Failed to write payload file: %v", err)
+	}
+	payload, err = readBenchPayload("{}", path)
+	if err != nil || payload != `{"id": 2}` {
+		t.Errorf("Expected the payload file, got %q, %v", payload, err)
+	}
+
+	if _, err := readBenchPayload("{}", filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("Expected an error for a missing file")
+	}
+}
+
+func TestPrintBenchResult(t *testing.T) {
+	result := &cloud.LambdaBenchResult{
+		FunctionName: "orders",
+		Options:      cloud.LambdaBenchOptions{Invocations: 4, Concurrency: 2},
+		Elapsed:      time.Second,
+		Invocations: []cloud.LambdaBenchInvocation{
+			{Latency: 400 * time.Millisecond, BilledDuration: 300, InitDuration: 180},
+			{Latency: 100 * time.Millisecond, BilledDuration: 50},
+			{Latency: 120 * time.Millisecond, BilledDuration: 60},
+			{Latency: 90 * time.Millisecond, Error: "Unhandled"},
+		},
+	}
+
+	var out bytes.Buffer
+	printBenchResult(&out, result)
+	for _, expected := range []string{
+		"Invocations: 4 (concurrency 2) in 1s",
+		"Errors:      1 (25.0%)",
+		"Cold starts: 1",
+		"p50 100ms  p90 400ms  p99 400ms  max 400ms",
+		"Billed:      410 ms total",
+		"Billed duration (ms)",
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, out.String())
+		}
+	}
+	if bars := strings.Count(out.String(), "█"); bars == 0 {
+		t.Errorf("Expected a histogram, got:\n%s", out.String())
+	}
+}
+
+func TestNewDeployRequest(t *testing.T) {
+	testCases := []struct {
+		name      string
+		zipFile   string
+		s3Object  string
+		imageURI  string
+		publish   bool
+		alias     string
+		expectErr bool
+		bucket    string
+		key       string
+		expectPub bool
+	}{
+		{name: "Zip file", zipFile: "./build.zip"},
+		{name: "S3 object", s3Object: "my-bucket/builds/app.zip", bucket: "my-bucket", key: "builds/app.zip"},
+		{name: "S3 URL", s3Object: "s3://my-bucket/app.zip", bucket: "my-bucket", key: "app.zip"},
+		{name: "Invalid S3 object", s3Object: "my-bucket", expectErr: true},
+		{name: "Alias implies publish", imageURI: "repo/app:latest", alias: "live", expectPub: true},
+		{name: "Publish", zipFile: "./build.zip", publish: true, expectPub: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request, err := newDeployRequest(tc.zipFile, tc.s3Object, tc.imageURI, tc.publish, tc.alias)
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("Expected an error for %q", tc.s3Object)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if request.S3Bucket != tc.bucket || request.S3Key != tc.key {
+				t.Errorf("Expected S3 object %s/%s, got %s/%s", tc.bucket, tc.key, request.S3Bucket, request.S3Key)
+			}
+			if request.Publish != tc.expectPub {
+				t.Errorf("Expected publish to be %v, got %v", tc.expectPub, request.Publish)
+			}
+		})
+	}
+}
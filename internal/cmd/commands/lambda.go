@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/cloudproviders"
+	"github.com/spf13/cobra"
+)
+
+// NewLambdaCmd creates a new lambda command
+func NewLambdaCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lambda",
+		Short: "Manage AWS Lambda functions",
+		Long:  `Manage AWS Lambda functions from the command line without opening the UI.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			_ = cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(NewLambdaDeployCmd())
+	cmd.AddCommand(NewLambdaBenchCmd())
+
+	return cmd
+}
+
+// NewLambdaDeployCmd creates a new lambda deploy command
+func NewLambdaDeployCmd() *cobra.Command {
+	var (
+		zipFile  string
+		s3Object string
+		imageURI string
+		publish  bool
+		alias    string
+		profile  string
+		region   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "deploy <function>",
+		Short: "Deploy new code to a Lambda function",
+		Long: `Deploy new code to a Lambda function from a local zip archive, an S3 object or a container image.
+
+The command waits for the update to complete, optionally publishes a new version
+and points an alias at it, then prints the new CodeSha256.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			request, err := newDeployRequest(zipFile, s3Object, imageURI, publish, alias)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			result, err := deployLambdaCode(args[0], request, profile, region)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error deploying %s: %v\n", args[0], err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Update status: %s\n", result.LastUpdateStatus)
+			fmt.Printf("CodeSha256: %s\n", result.CodeSha256)
+			if result.Version != "" {
+				fmt.Printf("Published version: %s\n", result.Version)
+			}
+			if result.Alias != "" {
+				fmt.Printf("Alias %s → %s\n", result.Alias, result.Version)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&zipFile, "zip", "", "Path to a local zip archive")
+	cmd.Flags().StringVar(&s3Object, "s3", "", "S3 object holding the zip archive, as bucket/key")
+	cmd.Flags().StringVar(&imageURI, "image", "", "Container image URI")
+	cmd.Flags().BoolVar(&publish, "publish", false, "Publish a new version once the update completes")
+	cmd.Flags().StringVar(&alias, "alias", "", "Point this alias at the published version (implies --publish)")
+	cmd.Flags().StringVar(&profile, "profile", os.Getenv("AWS_PROFILE"), "AWS profile to use")
+	cmd.Flags().StringVar(&region, "region", os.Getenv("AWS_REGION"), "AWS region to use")
+	cmd.MarkFlagsMutuallyExclusive("zip", "s3", "image")
+	cmd.MarkFlagsOneRequired("zip", "s3", "image")
+
+	return cmd
+}
+
+// newDeployRequest builds a deploy request from the command line flags
+func newDeployRequest(zipFile, s3Object, imageURI string, publish bool, alias string) (cloud.LambdaDeployRequest, error) {
+	request := cloud.LambdaDeployRequest{
+		ZipFile:  zipFile,
+		ImageURI: imageURI,
+		Publish:  publish || alias != "",
+		Alias:    alias,
+	}
+
+	if s3Object != "" && !request.SetS3Object(s3Object) {
+		return request, fmt.Errorf("invalid S3 object %q, expected bucket/key", s3Object)
+	}
+
+	return request, nil
+}
+
+// deployLambdaCode deploys code to a function using the AWS provider
+func deployLambdaCode(functionName string, request cloud.LambdaDeployRequest, profile, region string) (*cloud.LambdaDeployResult, error) {
+	provider, err := newAWSProvider(profile, region)
+	if err != nil {
+		return nil, err
+	}
+
+	deployOperation, err := provider.GetLambdaDeployOperation()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := deployOperation.ValidateDeployRequest(request); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Deploying %s to %s...\n", request.Source(), functionName)
+	return deployOperation.DeployFunctionCode(context.Background(), functionName, request)
+}
+
+// newAWSProvider creates an AWS provider for a profile and region, defaulting to the default profile
+func newAWSProvider(profile, region string) (cloud.Provider, error) {
+	if profile == "" {
+		profile = "default"
+	}
+	if region == "" {
+		return nil, fmt.Errorf("a region is required, set --region or AWS_REGION")
+	}
+
+	registry := cloud.NewProviderRegistry()
+	cloudproviders.InitializeProviders(registry)
+
+	return cloudproviders.CreateProvider(registry, "AWS", profile, region)
+}
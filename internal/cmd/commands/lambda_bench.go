@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/spf13/cobra"
+)
+
+// histogramBarWidth is the width of the longest bar of the billed-duration histogram
+const histogramBarWidth = 40
+
+// NewLambdaBenchCmd creates a new lambda bench command
+func NewLambdaBenchCmd() *cobra.Command {
+	var (
+		payload     string
+		payloadFile string
+		invocations int
+		concurrency int
+		profile     string
+		region      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "bench <function>",
+		Short: "Benchmark a Lambda function with concurrent invocations",
+		Long: `Invoke a Lambda function a number of times with the same payload, running several
+invocations at once, to help choose its memory size.
+
+The command reports latency percentiles, cold starts, the error rate and a histogram
+of billed durations read from the tail of each invocation's log.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			body, err := readBenchPayload(payload, payloadFile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			options := cloud.LambdaBenchOptions{Invocations: invocations, Concurrency: concurrency}
+			result, err := benchLambdaFunction(args[0], body, options, profile, region)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error benchmarking %s: %v\n", args[0], err)
+				os.Exit(1)
+			}
+
+			printBenchResult(os.Stdout, result)
+		},
+	}
+
+	cmd.Flags().StringVar(&payload, "payload", "{}", "JSON payload to invoke the function with")
+	cmd.Flags().StringVar(&payloadFile, "payload-file", "", "Path to a file holding the JSON payload")
+	cmd.Flags().IntVarP(&invocations, "invocations", "n", 10, "Number of invocations")
+	cmd.Flags().IntVarP(&concurrency, "concurrency", "c", 1, "Number of invocations to run at once")
+	cmd.Flags().StringVar(&profile, "profile", os.Getenv("AWS_PROFILE"), "AWS profile to use")
+	cmd.Flags().StringVar(&region, "region", os.Getenv("AWS_REGION"), "AWS region to use")
+	cmd.MarkFlagsMutuallyExclusive("payload", "payload-file")
+
+	return cmd
+}
+
+// readBenchPayload returns the payload given on the command line, or read from a file
+func readBenchPayload(payload, payloadFile string) (string, error) {
+	if payloadFile == "" {
+		return payload, nil
+	}
+
+	data, err := os.ReadFile(payloadFile)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// benchLambdaFunction benchmarks a function using the AWS provider
+func benchLambdaFunction(functionName, payload string, options cloud.LambdaBenchOptions, profile, region string) (*cloud.LambdaBenchResult, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	provider, err := newAWSProvider(profile, region)
+	if err != nil {
+		return nil, err
+	}
+
+	executeOperation, err := provider.GetLambdaExecuteOperation()
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Invoking %s %d times, %d at a time...\n", functionName, options.Invocations, options.Concurrency)
+	return cloud.RunLambdaBench(context.Background(), executeOperation, functionName, payload, options)
+}
+
+// printBenchResult prints the summary of a benchmark followed by its billed-duration histogram
+func printBenchResult(w io.Writer, result *cloud.LambdaBenchResult) {
+	count := len(result.Invocations)
+	fmt.Fprintf(w, "Invocations: %d (concurrency %d) in %s\n", count, result.Options.Concurrency, result.Elapsed.Round(time.Millisecond))
+	fmt.Fprintf(w, "Errors:      %d (%.1f%%)\n", result.Errors(), result.ErrorRate()*100)
+	fmt.Fprintf(w, "Cold starts: %d\n", result.ColdStarts())
+	fmt.Fprintf(w, "Latency:     p50 %s  p90 %s  p99 %s  max %s\n",
+		result.LatencyPercentile(50).Round(time.Millisecond), result.LatencyPercentile(90).Round(time.Millisecond),
+		result.LatencyPercentile(99).Round(time.Millisecond), result.LatencyPercentile(100).Round(time.Millisecond))
+	fmt.Fprintf(w, "Billed:      %.0f ms total\n", result.TotalBilled())
+
+	buckets := result.BilledHistogram()
+	if len(buckets) == 0 {
+		return
+	}
+
+	most := 0
+	for _, bucket := range buckets {
+		most = max(most, bucket.Count)
+	}
+
+	fmt.Fprintln(w, "\nBilled duration (ms)")
+	for _, bucket := range buckets {
+		bar := strings.Repeat("█", bucket.Count*histogramBarWidth/most)
+		fmt.Fprintf(w, "%6.0f - %-6.0f %-*s %d (%.0f ms)\n", bucket.Low, bucket.High, histogramBarWidth, bar, bucket.Count, bucket.TotalBilled)
+	}
+}
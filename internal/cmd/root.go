@@ -74,4 +74,5 @@ func init() {
 	// Add commands
 	rootCmd.AddCommand(commands.NewUpgradeCmd())
 	rootCmd.AddCommand(commands.NewVersionCmd())
+	rootCmd.AddCommand(commands.NewLambdaCmd())
 }
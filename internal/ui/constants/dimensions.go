@@ -9,11 +9,16 @@ const (
 	TableWideWidth    = 40
 	TableNarrowWidth  = 20
 	TableDescWidth    = 50
+	TableMetricWidth  = 19 // Sparkline plus a total
+	TableCompactWidth = 12
 
 	// Text input dimensions
 	TextInputWidth     = 50
 	TextInputCharLimit = 100
 
+	// Progress bar dimensions
+	ProgressBarWidth = 30
+
 	// App dimensions
 	AppContentLines = 5 // Number of content lines in the UI layout
 
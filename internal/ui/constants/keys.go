@@ -42,6 +42,41 @@ const (
 	// Search keys
 	KeySearch    = "/"
 	KeyBackspace = "backspace"
+
+	// Metrics keys
+	KeyToggleMetrics = "m"
+	KeyMetricsWindow = "t"
+
+	// Function details keys
+	KeyBrowsePackage = "o"
+	KeySecurity      = "a"
+	KeyCompare       = "v"
+	KeyAsyncInvoke   = "r"
+
+	// Lambda response keys
+	KeyCollapse   = "c"
+	KeyExpand     = "e"
+	KeyToggleLogs = KeyTab
+
+	// Lambda execution keys
+	KeyInvokeMode = "s"
+	KeyBenchmark  = "p"
+
+	// Report keys
+	KeyExport = "x"
+
+	// S3 object keys; objects are downloaded with KeyExport
+	KeyUpload  = "i"
+	KeyPresign = "s"
+	KeyPreview = "p"
+
+	// Logs Insights editor keys; results are exported with KeyExport
+	KeyTimeRange    = "t"
+	KeySavedQueries = "o"
+	KeySaveQuery    = "w"
+
+	// Secrets Manager keys
+	KeyRevealSecret = "v"
 )
 
 // Authentication method constants
@@ -6,12 +6,78 @@ const (
 	MsgAppDescription = "A simple tool to manage your cloud resources"
 
 	// Loading messages
-	MsgLoadingApprovals  = "Loading approvals..."
-	MsgLoadingPipelines  = "Loading pipelines..."
-	MsgLoadingFunctions  = "Loading functions..."
-	MsgStartingPipeline  = "Starting pipeline..."
-	MsgExecutingApproval = "Executing approval action..."
-	MsgExecutingLambda   = "Executing Lambda function..."
+	MsgLoadingApprovals    = "Loading approvals..."
+	MsgLoadingPipelines    = "Loading pipelines..."
+	MsgLoadingFunctions    = "Loading functions..."
+	MsgStartingPipeline    = "Starting pipeline..."
+	MsgExecutingApproval   = "Executing approval action..."
+	MsgExecutingLambda     = "Executing Lambda function..."
+	MsgLoadingConfig       = "Loading function configuration..."
+	MsgApplyingChanges     = "Applying changes..."
+	MsgDeployingCode       = "Deploying function code..."
+	MsgLoadingEventSources = "Loading event source mappings..."
+	MsgUpdatingEventSource = "Updating event source mapping..."
+	MsgGeneratingReport    = "Scanning Lambda functions..."
+	MsgRunningBenchmark    = "Benchmarking Lambda function..."
+	MsgDownloadingPackage  = "Downloading deployment package..."
+	MsgLoadingSecurity     = "Loading function permissions..."
+	MsgComparingFunction   = "Comparing function across targets..."
+	MsgLoadingAsyncConfig  = "Loading asynchronous invocation config..."
+	MsgPeekingEvents       = "Reading failed events..."
+	MsgRedrivingEvent      = "Re-invoking function..."
+	MsgLoadingBuckets      = "Loading buckets..."
+	MsgListingObjects      = "Listing objects..."
+	MsgLoadingObject       = "Loading object details..."
+	MsgDownloadingObject   = "Downloading object..."
+	MsgUploadingFiles      = "Uploading files..."
+	MsgPresigningURL       = "Presigning URL..."
+	MsgLoadingPreview      = "Loading object preview..."
+	MsgLoadingInstances    = "Loading instances..."
+	MsgLoadingInstance     = "Loading instance details..."
+	MsgStartingInstance    = "Starting instance..."
+	MsgStoppingInstance    = "Stopping instance..."
+	MsgRebootingInstance   = "Rebooting instance..."
+	MsgLoadingLogGroups    = "Loading log groups..."
+	MsgStartingQuery       = "Starting query..."
+	MsgRunningQuery        = "Running query..."
+	MsgQueryProgress       = "%s records matched of %s scanned (%s)"
+	MsgLoadingSavedQueries = "Loading saved queries..."
+	MsgSavingQuery         = "Saving query..."
+	MsgLoadingAlarms       = "Loading alarms..."
+	MsgLoadingAlarmHistory = "Loading alarm history..."
+	MsgMutingAlarm         = "Disabling alarm actions..."
+	MsgUnmutingAlarm       = "Enabling alarm actions..."
+	MsgLoadingClusters     = "Loading clusters..."
+	MsgLoadingECSServices  = "Loading services..."
+	MsgLoadingTasks        = "Loading tasks..."
+	MsgForcingDeployment   = "Starting a new deployment..."
+	MsgScalingService      = "Updating desired count..."
+	MsgLoadingProjects     = "Loading build projects..."
+	MsgLoadingBuilds       = "Loading builds..."
+	MsgStartingBuild       = "Starting build..."
+	MsgLoadingStacks       = "Loading stacks..."
+	MsgLoadingStackEvents  = "Loading stack events..."
+	MsgStartingDrift       = "Starting drift detection..."
+	MsgDetectingDrift      = "Detecting drift..."
+	MsgLoadingChangeSets   = "Loading change sets..."
+	MsgLoadingChangeSet    = "Loading change set..."
+	MsgExecutingChangeSet  = "Executing change set..."
+	MsgLoadingParameters   = "Loading parameters..."
+	MsgLoadingParameter    = "Loading parameter..."
+	MsgDecryptingParameter = "Decrypting value..."
+	MsgLoadingHistory      = "Loading parameter history..."
+	MsgPuttingParameter    = "Putting new version..."
+	MsgLoadingSecrets      = "Loading secrets..."
+	MsgRevealingSecret     = "Revealing value..."
+	MsgRotatingSecret      = "Starting rotation..."
+	MsgLoadingQueues       = "Loading queues..."
+	MsgPeekingMessages     = "Peeking messages..."
+	MsgSendingMessage      = "Sending message..."
+	MsgPurgingQueue        = "Purging queue..."
+	MsgRedrivingMessages   = "Redriving messages..."
+	MsgLoadingTopics       = "Loading topics..."
+	MsgLoadingSubscribers  = "Loading subscriptions..."
+	MsgPublishingMessage   = "Publishing message..."
 
 	// Input placeholders
 	MsgEnterProfile          = "Enter AWS profile name..."
@@ -21,12 +87,69 @@ const (
 	MsgEnterRejectionComment = "Enter rejection comment..."
 	MsgEnterCommitID         = "Enter commit ID..."
 	MsgEnterLambdaPayload    = "Enter Lambda JSON payload..."
+	MsgEnterMemory           = "Enter memory in MB (128-10240)..."
+	MsgEnterTimeout          = "Enter timeout in seconds (1-900)..."
+	MsgEnterEphemeral        = "Enter ephemeral storage in MB (512-10240)..."
+	MsgEnterReserved         = "Enter reserved concurrency, or 'none' to remove..."
+	MsgEnterProvisioned      = "Enter provisioned concurrency, or 0 to remove..."
+	MsgEnterNewProvisioned   = "Enter alias or version and count, e.g. live=5..."
+	MsgEnterResponseQuery    = "Enter a path, e.g. .items[0].id or .Records[].body (empty clears)..."
+	MsgEnterZipFile          = "Enter path to a local zip archive..."
+	MsgEnterS3Object         = "Enter S3 object as bucket/key..."
+	MsgEnterImageURI         = "Enter container image URI..."
+	MsgEnterAlias            = "Enter alias to point at the new version..."
+	MsgEnterRegions          = "Enter regions separated by commas, e.g. us-east-1, eu-west-1..."
+	MsgEnterUnusedDays       = "Enter days without invocations (1-455)..."
+	MsgEnterMaxPackageSize   = "Enter package size limit in MB..."
+	MsgEnterExportPath       = "Enter file to export to, ending in .csv or .json..."
+	MsgEnterInvocations      = "Enter number of invocations (1-1000)..."
+	MsgEnterConcurrency      = "Enter number of invocations to run at once (1-100)..."
+	MsgEnterExtractPath      = "Enter directory to extract the package to..."
+	MsgEnterTargets          = "Enter profile/region targets separated by commas, e.g. staging/us-east-1, prod/us-east-1..."
+	MsgEnterDownloadPath     = "Enter file or existing directory to download the object to..."
+	MsgEnterUploadPath       = "Enter local file or directory to upload..."
+	MsgEnterPresignKey       = "Enter the key of the object..."
+	MsgEnterPresignExpiry    = "Enter how long the URL stays valid, e.g. 15m or 24h (up to 168h)..."
+	MsgEnterInstanceTag      = "Enter tag as key=value, or key for any value (empty clears)..."
+	MsgEnterLogsQuery        = "Enter Logs Insights query..."
+	MsgEnterQueryName        = "Enter a name to save the query as..."
+	MsgEnterResultsPath      = "Enter CSV file to export the results to..."
+	MsgEnterDesiredCount     = "Enter how many tasks should run..."
+	MsgEnterSourceVersion    = "Enter a branch, tag or commit, empty for the project's default..."
+	MsgEnterBuildVariable    = "Enter an environment variable as NAME=value..."
+	MsgEnterVariableValue    = "Enter the value of the variable, empty to remove it..."
+	MsgEnterParameterValue   = "Enter the new value of the parameter..."
+	MsgEnterMessageBody      = "Enter the body of the test message..."
+	MsgEnterPurgeQueueName   = "Type the name of the queue to purge all its messages..."
 
 	// Success messages
 	MsgApprovalSuccess      = "Successfully approved pipeline: %s, stage: %s, action: %s"
 	MsgRejectionSuccess     = "Successfully rejected pipeline: %s, stage: %s, action: %s"
 	MsgPipelineStartSuccess = "Successfully started pipeline: %s"
 	MsgLambdaExecuteSuccess = "Successfully executed Lambda function: %s"
+	MsgConfigUpdateSuccess  = "Successfully updated configuration of Lambda function: %s"
+	MsgDeploySuccess        = "Successfully deployed Lambda function: %s, CodeSha256: %s"
+	MsgEventSourceSuccess   = "Event source mapping %s for %s is now %s"
+	MsgExportSuccess        = "Exported %d findings to %s"
+	MsgExtractSuccess       = "Extracted %d files to %s"
+	MsgRedriveSuccess       = "Re-invoked %s asynchronously with failed event %s"
+	MsgDownloadSuccess      = "Downloaded s3://%s/%s to %s"
+	MsgUploadSuccess        = "Uploaded %d files to s3://%s/%s"
+	MsgInstanceStateSuccess = "Instance %s is now %s"
+	MsgRebootSuccess        = "Rebooted instance %s"
+	MsgQuerySavedSuccess    = "Saved query %s"
+	MsgResultsExportSuccess = "Exported %d records to %s"
+	MsgAlarmMutedSuccess    = "Disabled the actions of alarm %s"
+	MsgAlarmUnmutedSuccess  = "Enabled the actions of alarm %s"
+	MsgForceDeploySuccess   = "Started a new deployment of service %s"
+	MsgScaleSuccess         = "Desired count of service %s is now %d"
+	MsgChangeSetSuccess     = "Started updating stack %s with change set %s"
+	MsgParameterPutSuccess  = "Put version %d of parameter %s"
+	MsgRotateSecretSuccess  = "Started rotating secret %s"
+	MsgMessageSentSuccess   = "Sent message %s to queue %s"
+	MsgPurgeSuccess         = "Purging queue %s; its messages can take up to a minute to be deleted"
+	MsgQueueRedriveSuccess  = "Moved %d messages from %s back to their source queues"
+	MsgPublishSuccess       = "Published message %s to topic %s"
 
 	// Error messages
 	MsgErrorGeneric       = "Error: %s"
@@ -38,4 +161,269 @@ const (
 	MsgErrorEmptyCommitID = "Commit ID cannot be empty"
 	MsgErrorEmptyComment  = "Comment cannot be empty"
 	MsgErrorInvalidJSON   = "Invalid JSON payload"
+	MsgErrorInvalidNumber = "Invalid number: %s"
+	MsgErrorInvalidPair   = "Expected alias or version and count, e.g. live=5"
+	MsgErrorNoChanges     = "No configuration changes to review"
+	MsgErrorNoSource      = "Choose a zip file, S3 object or image URI to deploy"
+	MsgErrorInvalidS3     = "Invalid S3 object %s, expected bucket/key"
+	MsgErrorNoEventSource = "No event source mapping selected"
+	MsgErrorJSONSyntax    = "Invalid JSON at line %d, column %d: %s"
+	MsgErrorInvalidQuery  = "Invalid query %s: %s"
+	MsgErrorUnusedDays    = "Days without invocations must be between 1 and 455"
+	MsgErrorExportFormat  = "Export files must end in .csv or .json"
+	MsgErrorNoReport      = "No hygiene report to export"
+	MsgErrorNoPackage     = "No deployment package to extract"
+	MsgErrorEmptyPath     = "Directory cannot be empty"
+	MsgErrorNoEvent       = "No failed event selected"
+	MsgErrorNoBucket      = "No bucket selected"
+	MsgErrorNoObject      = "Select an object, not a prefix"
+	MsgErrorEmptyKey      = "Key cannot be empty"
+	MsgErrorNoInstance    = "No instance selected"
+	MsgErrorInvalidTag    = "Invalid tag %s, expected key=value or key"
+	MsgErrorNoLogGroups   = "Select at least one log group"
+	MsgErrorManyLogGroups = "A query can span up to %d log groups, %d are selected"
+	MsgErrorEmptyQuery    = "Query cannot be empty"
+	MsgErrorEmptyName     = "Name cannot be empty"
+	MsgErrorQueryStatus   = "Query ended with status %s"
+	MsgErrorNoResults     = "No query results to export"
+	MsgErrorNoAlarm       = "No alarm selected"
+	MsgErrorNoECSService  = "No service selected"
+	MsgErrorDesiredCount  = "Desired count must be a whole number of 0 or more"
+	MsgErrorNoBuild       = "No build selected"
+	MsgErrorNoProject     = "No build project selected"
+	MsgErrorBuildVariable = "Environment variables must be entered as NAME=value"
+	MsgErrorNoStack       = "No stack selected"
+	MsgErrorNoChangeSet   = "No change set selected"
+	MsgErrorDriftStatus   = "Drift detection failed: %s"
+	MsgErrorNoParameter   = "No parameter selected"
+	MsgErrorRevealFirst   = "Reveal the value of the SecureString before putting a new version"
+	MsgErrorEmptyValue    = "Value cannot be empty"
+	MsgErrorSameValue     = "The new value is the same as the current one"
+	MsgErrorNoSecret      = "No secret selected"
+	MsgErrorNoRotation    = "Rotation isn't enabled for this secret"
+	MsgErrorNoQueue       = "No queue selected"
+	MsgErrorEmptyMessage  = "Message body cannot be empty"
+	MsgErrorPurgeName     = "Type %s exactly to purge the queue"
+	MsgErrorNotDLQ        = "Queue %s isn't the dead-letter queue of any queue"
+	MsgErrorNoTopic       = "No topic selected"
+	MsgErrorTopicMessage  = "Invalid test message: %s"
+	MsgErrorAttributeType = "Attribute %s must be a string, a number or an array"
+)
+
+// Lambda configuration settings shown in the configuration form
+const (
+	SettingMemory              = "Memory"
+	SettingTimeout             = "Timeout"
+	SettingEphemeralStorage    = "Ephemeral Storage"
+	SettingReservedConcurrency = "Reserved Concurrency"
+	SettingProvisionedPrefix   = "Provisioned: "
+	SettingAddProvisioned      = "Add Provisioned Concurrency"
+	SettingReviewChanges       = "Review Changes"
+)
+
+// Lambda deployment settings shown in the deploy form
+const (
+	SettingZipFile        = "Zip File"
+	SettingS3Object       = "S3 Object"
+	SettingImageURI       = "Image URI"
+	SettingPublishVersion = "Publish Version"
+	SettingAlias          = "Alias"
+	SettingReviewDeploy   = "Review Deployment"
+)
+
+// Event source mapping scopes and actions
+const (
+	ScopeAllFunctions    = "All Functions"
+	ScopeSingleFunction  = "Single Function"
+	ActionEnableMapping  = "Enable Mapping"
+	ActionDisableMapping = "Disable Mapping"
+)
+
+// Lambda hygiene report settings shown in the report options form
+const (
+	SettingRegions        = "Regions"
+	SettingUnusedDays     = "Unused After"
+	SettingMaxPackageSize = "Package Size Limit"
+	SettingRunReport      = "Run Report"
+
+	// Thresholds the options form starts with
+	DefaultUnusedDays     = 30
+	DefaultMaxPackageSize = 50 // MB, the largest zip that can be uploaded without S3
+)
+
+// Lambda benchmark settings shown in the benchmark options form
+const (
+	SettingInvocations  = "Invocations"
+	SettingConcurrency  = "Concurrency"
+	SettingRunBenchmark = "Run Benchmark"
+
+	// Settings the options form starts with
+	DefaultBenchInvocations = 20
+	DefaultBenchConcurrency = 5
+)
+
+// Function comparison settings shown in the compare options form
+const (
+	SettingTargets    = "Targets"
+	SettingEnvValues  = "Environment Values"
+	SettingRunCompare = "Run Comparison"
+
+	// How environment variables are compared
+	EnvValuesKeysOnly = "Keys only"
+	EnvValuesHashed   = "Keys and hashed values"
+)
+
+// Asynchronous invocation settings and actions
+const (
+	SettingMaxRetryAttempts = "Max Retry Attempts"
+	SettingMaxEventAge      = "Max Event Age"
+	SettingOnSuccess        = "On Success"
+	SettingOnFailure        = "On Failure"
+	SettingDeadLetterQueue  = "Dead-Letter Queue"
+	ActionPeekFailedEvents  = "Peek Failed Events"
+
+	// MaxFailedEvents is the most failed events read from a queue at once
+	MaxFailedEvents = 50
+)
+
+// S3ObjectsPageSize is the most objects and prefixes listed per page of the S3 browser
+const S3ObjectsPageSize = 100
+
+// Paths entered in the S3 browser
+const (
+	S3InputDownload = "Download To"
+	S3InputUpload   = "Upload From"
+)
+
+// S3 presigned URL settings shown in the presign form
+const (
+	SettingPresignMethod = "Method"
+	SettingPresignKey    = "Key"
+	SettingPresignExpiry = "Expires In"
+	SettingPresignURL    = "Generate URL"
+
+	// DefaultPresignExpiry is how long presigned URLs stay valid unless another expiry is entered
+	DefaultPresignExpiry = "15m"
+)
+
+// S3PreviewMaxSize is the most bytes read from the start of an object to preview it
+const S3PreviewMaxSize = 64 * 1024
+
+// EC2 instance filters shown in the filter form, and the actions of the instance details view
+const (
+	SettingInstanceState = "State"
+	SettingInstanceTag   = "Tag"
+	SettingListInstances = "List Instances"
+	ActionStartInstance  = "Start Instance"
+	ActionStopInstance   = "Stop Instance"
+	ActionRebootInstance = "Reboot Instance"
+
+	// FilterAllStates is shown for the state filter when instances in every state are listed
+	FilterAllStates = "All"
+)
+
+// Logs Insights rows of the log group list
+const (
+	SettingWriteQuery = "Write Query"
+
+	// LogGroupSelected marks the log groups the query runs over
+	LogGroupSelected = "✓ "
+
+	// MaxQueryLogGroups is how many log groups Logs Insights can query at once
+	MaxQueryLogGroups = 50
+)
+
+// Actions of the alarm details view, and the rows alarms are cross-linked into function and
+// pipeline details with
+const (
+	ActionMuteAlarm    = "Mute Alarm"
+	ActionUnmuteAlarm  = "Unmute Alarm"
+	ActionAlarmHistory = "View History"
+
+	// AlarmRowPrefix starts the rows of alarms that share a prefix with a function or pipeline
+	AlarmRowPrefix = "Alarm: "
+)
+
+// Actions of the ECS service details view
+const (
+	ActionViewTasks       = "View Tasks"
+	ActionForceDeployment = "Force New Deployment"
+	ActionScaleService    = "Scale Desired Count"
+)
+
+// CodeBuild rows of the build list and start form, and the actions of the build details view
+const (
+	ActionStartBuild     = "Start Build"
+	SettingSourceVersion = "Source Version"
+	SettingAddVariable   = "Add Variable"
+	ActionBuildLogs      = "View Logs"
+
+	// VariableRowPrefix starts the rows of the environment variables a build overrides
+	VariableRowPrefix = "Variable: "
+
+	// MaxBuildLogLines is how many of the latest lines of a build's log are kept
+	MaxBuildLogLines = 5000
+)
+
+// Actions of the CloudFormation stack details and change set views, and the rows of a stack's
+// outputs and parameters
+const (
+	ActionStackEvents      = "View Events"
+	ActionDetectDrift      = "Detect Drift"
+	ActionChangeSets       = "Change Sets"
+	ActionExecuteChangeSet = "Execute Change Set"
+	OutputRowPrefix        = "Output: "
+	ParameterRowPrefix     = "Parameter: "
+)
+
+// Actions of the Parameter Store parameter details view
+const (
+	ActionRevealValue      = "Reveal Value"
+	ActionParameterHistory = "View History"
+	ActionPutVersion       = "Put New Version"
+)
+
+// Actions of the Secrets Manager secret details view, and the rows of the keys of a JSON secret
+const (
+	ActionRotateSecret = "Rotate Now"
+	SecretKeyRowPrefix = "Key: "
+)
+
+// Actions of the SQS queue details view, and the rows of the queues a dead-letter queue serves
+const (
+	ActionPeekMessages    = "Peek Messages"
+	ActionSendMessage     = "Send Test Message"
+	ActionPurgeQueue      = "Purge Queue"
+	ActionRedriveMessages = "Redrive Messages"
+	SourceQueueRowPrefix  = "Source Queue: "
+
+	// MaxPeekedMessages is how many messages a peek returns at most
+	MaxPeekedMessages = 10
+)
+
+// Actions of the SNS topic details view
+const (
+	ActionViewSubscriptions = "View Subscriptions"
+	ActionPublishMessage    = "Publish Test Message"
+)
+
+// DefaultTopicMessage is the payload the editor of a test message for a topic starts with: the
+// message, which is sent as is when it's a string and as JSON otherwise, an optional subject and
+// the message attributes subscriptions can filter on
+const DefaultTopicMessage = `{
+  "subject": "",
+  "message": {},
+  "attributes": {}
+}`
+
+// DefaultLogsQuery is the query the editor starts with, listing the latest events
+const DefaultLogsQuery = `fields @timestamp, @message, @logStream
+| sort @timestamp desc
+| limit 100`
+
+// Lambda invoke modes selectable in the execution view
+const (
+	InvokeModeAuto      = "AUTO"
+	InvokeModeBuffered  = "BUFFERED"
+	InvokeModeStreaming = "STREAMING"
 )
@@ -0,0 +1,35 @@
+package constants
+
+import "time"
+
+// Windows covered by the Lambda function metrics
+const (
+	MetricsWindowShort = time.Hour
+	MetricsWindowLong  = 24 * time.Hour
+)
+
+// Time ranges a Logs Insights query can cover, ending when it runs
+var LogsTimeRanges = []time.Duration{
+	15 * time.Minute,
+	time.Hour,
+	3 * time.Hour,
+	12 * time.Hour,
+	24 * time.Hour,
+	3 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+}
+
+// LogsQueryPollInterval is how often a running Logs Insights query is checked for results
+const LogsQueryPollInterval = time.Second
+
+// BuildLogsPollInterval is how often a followed build is checked for new log lines
+const BuildLogsPollInterval = 2 * time.Second
+
+// DriftDetectionPollInterval is how often a running stack drift detection is checked for results
+const DriftDetectionPollInterval = 2 * time.Second
+
+// SecretRevealTimeout is how long a revealed secret value stays shown before it's hidden again
+const SecretRevealTimeout = 30 * time.Second
+
+// MetricsLoading is shown in place of metrics that are still being fetched
+const MetricsLoading = "…"
@@ -2,24 +2,87 @@ package constants
 
 // Title constants for different views
 const (
-	TitleProviders       = "Select Cloud Provider"
-	TitleAWSConfig       = "AWS Configuration"
-	TitleSelectProfile   = "Select AWS Profile"
-	TitleSelectRegion    = "Select AWS Region"
-	TitleSelectService   = "Select AWS Service"
-	TitleSelectCategory  = "Select Category"
-	TitleSelectOperation = "Select Operation"
-	TitleApprovals       = "Pipeline Approvals"
-	TitleConfirmation    = "Execute Action"
-	TitleSummary         = "Enter Comment"
-	TitleExecutingAction = "Execute Action"
-	TitlePipelineStatus  = "Select Pipeline"
-	TitlePipelineStages  = "Pipeline Stages"
-	TitleError           = "Error"
-	TitleSuccess         = "Success"
-	TitleHelp            = "Help"
-	TitleFunctionStatus  = "Lambda Functions"
-	TitleFunctionDetails = "Function Details"
-	TitleLambdaExecute   = "Lambda Payload (JSON)"
-	TitleLambdaResponse  = "Lambda Response"
+	TitleProviders           = "Select Cloud Provider"
+	TitleAWSConfig           = "AWS Configuration"
+	TitleSelectProfile       = "Select AWS Profile"
+	TitleSelectRegion        = "Select AWS Region"
+	TitleSelectService       = "Select AWS Service"
+	TitleSelectCategory      = "Select Category"
+	TitleSelectOperation     = "Select Operation"
+	TitleApprovals           = "Pipeline Approvals"
+	TitleConfirmation        = "Execute Action"
+	TitleSummary             = "Enter Comment"
+	TitleExecutingAction     = "Execute Action"
+	TitlePipelineStatus      = "Select Pipeline"
+	TitlePipelineStages      = "Pipeline Stages"
+	TitleError               = "Error"
+	TitleSuccess             = "Success"
+	TitleHelp                = "Help"
+	TitleFunctionStatus      = "Lambda Functions"
+	TitleFunctionDetails     = "Function Details"
+	TitleLambdaExecute       = "Lambda Payload (JSON)"
+	TitleLambdaResponse      = "Lambda Response"
+	TitleLambdaConfig        = "Function Configuration"
+	TitleLambdaDeploy        = "Deploy Function Code"
+	TitleEventSourceScope    = "Select Mapping Scope"
+	TitleEventSourceMappings = "Event Source Mappings"
+	TitleEventSourceDetails  = "Event Source Mapping"
+	TitleHygieneOptions      = "Hygiene Report Options"
+	TitleHygieneReport       = "Lambda Hygiene Report"
+	TitleLambdaBench         = "Benchmark Options"
+	TitleLambdaBenchResult   = "Benchmark Results"
+	TitlePackageBrowser      = "Deployment Package"
+	TitleFunctionSecurity    = "Function Security"
+	TitleCompareOptions      = "Compare Across Accounts"
+	TitleFunctionCompare     = "Function Comparison"
+	TitleAsyncConfig         = "Async Invocations"
+	TitleFailedEvents        = "Failed Events"
+	TitleS3Buckets           = "S3 Buckets"
+	TitleS3Objects           = "S3 Objects"
+	TitleS3ObjectDetails     = "Object Details"
+	TitleS3Presign           = "Presigned URL"
+	TitleS3Preview           = "Object Preview"
+	TitleEC2Filters          = "Instance Filters"
+	TitleEC2Instances        = "EC2 Instances"
+	TitleEC2InstanceDetails  = "Instance Details"
+	TitleLogGroups           = "Log Groups"
+	TitleLogsQuery           = "Logs Insights Query"
+	TitleSavedQueries        = "Saved Queries"
+	TitleLogsResults         = "Query Results"
+	TitleAlarms              = "CloudWatch Alarms"
+	TitleAlarmDetails        = "Alarm Details"
+	TitleAlarmHistory        = "Alarm History"
+	TitleECSClusters         = "ECS Clusters"
+	TitleECSServices         = "ECS Services"
+	TitleECSServiceDetails   = "Service Details"
+	TitleECSTasks            = "ECS Tasks"
+	TitleECSTaskDetails      = "Task Details"
+	TitleBuildProjects       = "CodeBuild Projects"
+	TitleBuilds              = "Builds"
+	TitleStartBuild          = "Start Build"
+	TitleBuildDetails        = "Build Details"
+	TitleBuildLogs           = "Build Logs"
+	TitleStacks              = "CloudFormation Stacks"
+	TitleStackDetails        = "Stack Details"
+	TitleStackEvents         = "Stack Events"
+	TitleStackDrift          = "Drifted Resources"
+	TitleResourceDrift       = "Resource Drift"
+	TitleChangeSets          = "Change Sets"
+	TitleChangeSetDetails    = "Change Set"
+	TitleParameters          = "Parameter Store"
+	TitleParameterDetails    = "Parameter Details"
+	TitleParameterHistory    = "Parameter History"
+	TitleParameterEdit       = "New Parameter Version"
+	TitleSecrets             = "Secrets Manager Secrets"
+	TitleSecretDetails       = "Secret Details"
+	TitleQueues              = "SQS Queues"
+	TitleQueueDetails        = "Queue Details"
+	TitleQueueMessages       = "Peeked Messages"
+	TitleQueueMessage        = "Message"
+	TitleQueueSend           = "Test Message"
+	TitleTopics              = "SNS Topics"
+	TitleTopicDetails        = "Topic Details"
+	TitleSubscriptions       = "Subscriptions"
+	TitleSubscription        = "Subscription"
+	TitleTopicPublish        = "Test Message"
 )
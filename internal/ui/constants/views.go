@@ -30,4 +30,68 @@ const (
 	ViewFunctionDetails
 	ViewLambdaExecute
 	ViewLambdaResponse
+	ViewLambdaConfig
+	ViewLambdaDeploy
+	ViewEventSourceScope
+	ViewEventSourceMappings
+	ViewEventSourceDetails
+	ViewHygieneOptions
+	ViewHygieneReport
+	ViewLambdaBench
+	ViewLambdaBenchResult
+	ViewPackageBrowser
+	ViewPackageFile
+	ViewFunctionSecurity
+	ViewCompareOptions
+	ViewFunctionCompare
+	ViewAsyncConfig
+	ViewFailedEvents
+	ViewS3Buckets
+	ViewS3Objects
+	ViewS3ObjectDetails
+	ViewS3Presign
+	ViewS3Preview
+	ViewEC2Filters
+	ViewEC2Instances
+	ViewEC2InstanceDetails
+	ViewLogGroups
+	ViewLogsQuery
+	ViewSavedQueries
+	ViewLogsResults
+	ViewAlarms
+	ViewAlarmDetails
+	ViewAlarmHistory
+	ViewECSClusters
+	ViewECSServices
+	ViewECSServiceDetails
+	ViewECSTasks
+	ViewECSTaskDetails
+	ViewBuildProjects
+	ViewBuilds
+	ViewStartBuild
+	ViewBuildDetails
+	ViewBuildLogs
+	ViewStacks
+	ViewStackDetails
+	ViewStackEvents
+	ViewStackDrift
+	ViewResourceDrift
+	ViewChangeSets
+	ViewChangeSetDetails
+	ViewParameters
+	ViewParameterDetails
+	ViewParameterHistory
+	ViewParameterEdit
+	ViewSecrets
+	ViewSecretDetails
+	ViewQueues
+	ViewQueueDetails
+	ViewQueueMessages
+	ViewQueueMessage
+	ViewQueueSend
+	ViewTopics
+	ViewTopicDetails
+	ViewSubscriptions
+	ViewSubscription
+	ViewTopicPublish
 )
@@ -1,8 +1,12 @@
 package integration
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/HenryOwenz/cloudgate/internal/cloud"
 )
@@ -94,6 +98,106 @@ func (p *MockAWSProvider) GetLambdaExecuteOperation() (cloud.LambdaExecuteOperat
 	return &MockLambdaExecuteOperation{}, nil
 }
 
+// GetLambdaConfigurationOperation returns an operation for tuning Lambda function configuration
+func (p *MockAWSProvider) GetLambdaConfigurationOperation() (cloud.LambdaConfigurationOperation, error) {
+	return &MockLambdaConfigurationOperation{}, nil
+}
+
+// GetLambdaDeployOperation returns an operation for deploying Lambda function code
+func (p *MockAWSProvider) GetLambdaDeployOperation() (cloud.LambdaDeployOperation, error) {
+	return &MockLambdaDeployOperation{}, nil
+}
+
+// GetLambdaMetricsOperation returns an operation for viewing Lambda function metrics
+func (p *MockAWSProvider) GetLambdaMetricsOperation() (cloud.LambdaMetricsOperation, error) {
+	return &MockLambdaMetricsOperation{}, nil
+}
+
+// GetLambdaEventSourceOperation returns an operation for managing Lambda event source mappings
+func (p *MockAWSProvider) GetLambdaEventSourceOperation() (cloud.LambdaEventSourceOperation, error) {
+	return &MockLambdaEventSourceOperation{}, nil
+}
+
+// GetLambdaHygieneOperation returns an operation for reporting on Lambda function hygiene
+func (p *MockAWSProvider) GetLambdaHygieneOperation() (cloud.LambdaHygieneOperation, error) {
+	return &MockLambdaHygieneOperation{}, nil
+}
+
+// GetLambdaPackageOperation returns an operation for downloading Lambda deployment packages
+func (p *MockAWSProvider) GetLambdaPackageOperation() (cloud.LambdaPackageOperation, error) {
+	return &MockLambdaPackageOperation{}, nil
+}
+
+// GetLambdaSecurityOperation returns an operation for reviewing Lambda function permissions
+func (p *MockAWSProvider) GetLambdaSecurityOperation() (cloud.LambdaSecurityOperation, error) {
+	return &MockLambdaSecurityOperation{}, nil
+}
+
+// GetLambdaAsyncOperation returns an operation for inspecting asynchronous invocations
+func (p *MockAWSProvider) GetLambdaAsyncOperation() (cloud.LambdaAsyncOperation, error) {
+	return &MockLambdaAsyncOperation{}, nil
+}
+
+// GetS3BrowserOperation returns an operation for browsing S3 buckets
+func (p *MockAWSProvider) GetS3BrowserOperation() (cloud.S3BrowserOperation, error) {
+	return &MockS3BrowserOperation{}, nil
+}
+
+// GetS3TransferOperation returns an operation for transferring S3 objects
+func (p *MockAWSProvider) GetS3TransferOperation() (cloud.S3TransferOperation, error) {
+	return &MockS3TransferOperation{}, nil
+}
+
+// GetEC2InstanceOperation returns an operation for managing EC2 instances
+func (p *MockAWSProvider) GetEC2InstanceOperation() (cloud.EC2InstanceOperation, error) {
+	return &MockEC2InstanceOperation{}, nil
+}
+
+// GetLogsInsightsOperation returns an operation for running Logs Insights queries
+func (p *MockAWSProvider) GetLogsInsightsOperation() (cloud.LogsInsightsOperation, error) {
+	return &MockLogsInsightsOperation{}, nil
+}
+
+// GetAlarmOperation returns an operation for browsing CloudWatch alarms
+func (p *MockAWSProvider) GetAlarmOperation() (cloud.AlarmOperation, error) {
+	return &MockAlarmOperation{}, nil
+}
+
+// GetECSServiceOperation returns an operation for browsing ECS clusters, services and tasks
+func (p *MockAWSProvider) GetECSServiceOperation() (cloud.ECSServiceOperation, error) {
+	return &MockECSServiceOperation{}, nil
+}
+
+// GetCodeBuildOperation returns an operation for browsing CodeBuild projects and builds
+func (p *MockAWSProvider) GetCodeBuildOperation() (cloud.CodeBuildOperation, error) {
+	return &MockCodeBuildOperation{}, nil
+}
+
+// GetCloudFormationOperation returns an operation for inspecting CloudFormation stacks
+func (p *MockAWSProvider) GetCloudFormationOperation() (cloud.CloudFormationOperation, error) {
+	return &MockCloudFormationOperation{}, nil
+}
+
+// GetParameterStoreOperation returns an operation for browsing Parameter Store
+func (p *MockAWSProvider) GetParameterStoreOperation() (cloud.ParameterStoreOperation, error) {
+	return &MockParameterStoreOperation{}, nil
+}
+
+// GetSecretsManagerOperation returns an operation for inspecting Secrets Manager secrets
+func (p *MockAWSProvider) GetSecretsManagerOperation() (cloud.SecretsManagerOperation, error) {
+	return &MockSecretsManagerOperation{}, nil
+}
+
+// GetQueueOperation returns an operation for inspecting SQS queues
+func (p *MockAWSProvider) GetQueueOperation() (cloud.QueueOperation, error) {
+	return &MockQueueOperation{}, nil
+}
+
+// GetTopicOperation returns an operation for inspecting SNS topics
+func (p *MockAWSProvider) GetTopicOperation() (cloud.TopicOperation, error) {
+	return &MockTopicOperation{}, nil
+}
+
 // GetAuthenticationMethods returns available authentication methods
 func (p *MockAWSProvider) GetAuthenticationMethods() []string {
 	return []string{"profile", "access_key"}
@@ -253,6 +357,38 @@ func (o *MockFunctionStatusOperation) GetFunctionStatus(ctx context.Context) ([]
 	}, nil
 }
 
+func (o *MockFunctionStatusOperation) DescribeFunctions(ctx context.Context, functions []cloud.FunctionStatus) ([]cloud.FunctionStatus, error) {
+	described := make([]cloud.FunctionStatus, len(functions))
+	for i, function := range functions {
+		function.State = "Active"
+		function.LastUpdateStatus = "Successful"
+		described[i] = function
+	}
+	return described, nil
+}
+
+func (o *MockFunctionStatusOperation) CompareFunction(ctx context.Context, functionName string, options cloud.FunctionCompareOptions) (*cloud.FunctionComparison, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]cloud.FunctionSnapshot, len(options.Targets))
+	for i, target := range options.Targets {
+		snapshots[i] = cloud.FunctionSnapshot{
+			Target: target,
+			Status: &cloud.FunctionStatus{
+				Name:    functionName,
+				Runtime: "nodejs14.x",
+				Memory:  int32(128 * (i + 1)),
+				Timeout: 30,
+			},
+			Environment: map[string]string{"TABLE_NAME": ""},
+			Aliases:     map[string]string{"live": "1"},
+		}
+	}
+	return cloud.NewFunctionComparison(functionName, snapshots), nil
+}
+
 // MockCodePipelineManualApprovalOperation implements cloud.CodePipelineManualApprovalOperation for testing
 type MockCodePipelineManualApprovalOperation struct{}
 
@@ -405,6 +541,943 @@ func (o *MockLambdaExecuteOperation) ExecuteFunction(ctx context.Context, functi
 	}, nil
 }
 
+func (o *MockLambdaExecuteOperation) Invoker(ctx context.Context, functionName string) (cloud.LambdaInvoker, error) {
+	return func(ctx context.Context, payload string) (*cloud.LambdaExecuteResult, error) {
+		return o.ExecuteFunction(ctx, functionName, payload)
+	}, nil
+}
+
+func (o *MockLambdaExecuteOperation) IsResponseStreaming(ctx context.Context, functionName string) (bool, error) {
+	return false, nil
+}
+
+func (o *MockLambdaExecuteOperation) ExecuteFunctionStream(ctx context.Context, functionName string, payload string, onChunk func(chunk []byte)) (*cloud.LambdaExecuteResult, error) {
+	// Mock implementation streaming the payload in two chunks
+	chunks := []string{`{"statusCode": 200, `, `"body": "Function streamed successfully"}`}
+	for _, chunk := range chunks {
+		if onChunk != nil {
+			onChunk([]byte(chunk))
+		}
+	}
+	return &cloud.LambdaExecuteResult{
+		StatusCode:      200,
+		ExecutedVersion: "$LATEST",
+		Payload:         chunks[0] + chunks[1],
+		Streamed:        true,
+	}, nil
+}
+
+// MockLambdaConfigurationOperation implements cloud.LambdaConfigurationOperation for testing
+type MockLambdaConfigurationOperation struct{}
+
+func (o *MockLambdaConfigurationOperation) Name() string {
+	return "Configure Function"
+}
+
+func (o *MockLambdaConfigurationOperation) Description() string {
+	return "Tune Memory, Timeout and Concurrency"
+}
+
+func (o *MockLambdaConfigurationOperation) IsUIVisible() bool {
+	return true
+}
+
+func (o *MockLambdaConfigurationOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	functionName, ok := params["functionName"].(string)
+	if !ok {
+		return nil, fmt.Errorf("function name is required")
+	}
+
+	return o.GetFunctionConfiguration(ctx, functionName)
+}
+
+func (o *MockLambdaConfigurationOperation) GetFunctionConfiguration(ctx context.Context, functionName string) (*cloud.FunctionStatus, error) {
+	return &cloud.FunctionStatus{
+		Name:             functionName,
+		Runtime:          "nodejs14.x",
+		Memory:           128,
+		Timeout:          30,
+		EphemeralStorage: 512,
+	}, nil
+}
+
+func (o *MockLambdaConfigurationOperation) ValidateConfigUpdate(current cloud.FunctionStatus, update cloud.FunctionConfigUpdate) error {
+	return nil
+}
+
+func (o *MockLambdaConfigurationOperation) UpdateFunctionConfiguration(ctx context.Context, functionName string, update cloud.FunctionConfigUpdate) error {
+	return nil
+}
+
+// MockLambdaDeployOperation implements cloud.LambdaDeployOperation for testing
+type MockLambdaDeployOperation struct{}
+
+func (o *MockLambdaDeployOperation) Name() string {
+	return "Deploy Code"
+}
+
+func (o *MockLambdaDeployOperation) Description() string {
+	return "Update Function Code from a Zip, S3 Object or Image"
+}
+
+func (o *MockLambdaDeployOperation) IsUIVisible() bool {
+	return true
+}
+
+func (o *MockLambdaDeployOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	functionName, ok := params["functionName"].(string)
+	if !ok {
+		return nil, fmt.Errorf("function name is required")
+	}
+
+	request, ok := params["request"].(cloud.LambdaDeployRequest)
+	if !ok {
+		return nil, fmt.Errorf("deploy request is required")
+	}
+
+	return o.DeployFunctionCode(ctx, functionName, request)
+}
+
+func (o *MockLambdaDeployOperation) ValidateDeployRequest(request cloud.LambdaDeployRequest) error {
+	return nil
+}
+
+func (o *MockLambdaDeployOperation) DeployFunctionCode(ctx context.Context, functionName string, request cloud.LambdaDeployRequest) (*cloud.LambdaDeployResult, error) {
+	result := &cloud.LambdaDeployResult{
+		CodeSha256:       "mock-code-sha256",
+		LastUpdateStatus: "Successful",
+	}
+	if request.Publish {
+		result.Version = "2"
+		result.Alias = request.Alias
+	}
+	return result, nil
+}
+
+// MockLambdaMetricsOperation implements cloud.LambdaMetricsOperation for testing
+type MockLambdaMetricsOperation struct{}
+
+func (o *MockLambdaMetricsOperation) Name() string {
+	return "Function Metrics"
+}
+
+func (o *MockLambdaMetricsOperation) Description() string {
+	return "View Recent Lambda Function Metrics"
+}
+
+func (o *MockLambdaMetricsOperation) IsUIVisible() bool {
+	return false
+}
+
+func (o *MockLambdaMetricsOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	functionNames, ok := params["functionNames"].([]string)
+	if !ok {
+		return nil, fmt.Errorf("function names are required")
+	}
+
+	return o.GetFunctionMetrics(ctx, functionNames, time.Hour)
+}
+
+func (o *MockLambdaMetricsOperation) GetFunctionMetrics(ctx context.Context, functionNames []string, window time.Duration) (map[string]cloud.FunctionMetrics, error) {
+	metrics := make(map[string]cloud.FunctionMetrics, len(functionNames))
+	for _, name := range functionNames {
+		metrics[name] = cloud.FunctionMetrics{
+			Window:               window,
+			Bucket:               window / 4,
+			Invocations:          []float64{10, 20, 30, 40},
+			Errors:               []float64{0, 0, 1, 0},
+			Throttles:            []float64{0, 0, 0, 0},
+			DurationP50:          []float64{45, 50, 48, 52},
+			DurationP99:          []float64{120, 300, 250, 180},
+			ConcurrentExecutions: []float64{1, 2, 3, 2},
+		}
+	}
+	return metrics, nil
+}
+
+// MockLambdaEventSourceOperation implements cloud.LambdaEventSourceOperation for testing
+type MockLambdaEventSourceOperation struct{}
+
+func (o *MockLambdaEventSourceOperation) Name() string {
+	return "Event Source Mappings"
+}
+
+func (o *MockLambdaEventSourceOperation) Description() string {
+	return "Inspect, Enable and Disable Event Sources"
+}
+
+func (o *MockLambdaEventSourceOperation) IsUIVisible() bool {
+	return true
+}
+
+func (o *MockLambdaEventSourceOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	functionName, _ := params["functionName"].(string)
+	return o.ListEventSourceMappings(ctx, functionName)
+}
+
+func (o *MockLambdaEventSourceOperation) ListEventSourceMappings(ctx context.Context, functionName string) ([]cloud.EventSourceMapping, error) {
+	if functionName == "" {
+		functionName = "test-function-1"
+	}
+	return []cloud.EventSourceMapping{
+		{
+			UUID:                 "mock-uuid",
+			FunctionName:         functionName,
+			EventSourceArn:       "arn:aws:sqs:us-east-1:123456789012:test-queue",
+			SourceType:           "SQS",
+			State:                "Enabled",
+			BatchSize:            10,
+			LastProcessingResult: "OK",
+		},
+	}, nil
+}
+
+func (o *MockLambdaEventSourceOperation) SetEventSourceMappingEnabled(ctx context.Context, uuid string, enabled bool) (*cloud.EventSourceMapping, error) {
+	state := "Disabling"
+	if enabled {
+		state = "Enabling"
+	}
+	return &cloud.EventSourceMapping{UUID: uuid, State: state}, nil
+}
+
+// MockLambdaHygieneOperation implements cloud.LambdaHygieneOperation for testing
+type MockLambdaHygieneOperation struct{}
+
+func (o *MockLambdaHygieneOperation) Name() string {
+	return "Hygiene Report"
+}
+
+func (o *MockLambdaHygieneOperation) Description() string {
+	return "Flag Deprecated Runtimes and Risky Settings"
+}
+
+func (o *MockLambdaHygieneOperation) IsUIVisible() bool {
+	return true
+}
+
+func (o *MockLambdaHygieneOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return o.GenerateHygieneReport(ctx, cloud.HygieneReportOptions{})
+}
+
+func (o *MockLambdaHygieneOperation) GenerateHygieneReport(ctx context.Context, options cloud.HygieneReportOptions) (*cloud.HygieneReport, error) {
+	regions := options.Regions
+	if len(regions) == 0 {
+		regions = []string{"us-east-1"}
+	}
+	return &cloud.HygieneReport{
+		GeneratedAt:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Regions:          regions,
+		FunctionsScanned: 2,
+		Findings: []cloud.HygieneFinding{
+			{
+				Region:       regions[0],
+				FunctionName: "test-function-1",
+				FunctionArn:  "arn:aws:lambda:us-east-1:123456789012:function:test-function-1",
+				Runtime:      "python3.8",
+				Check:        cloud.HygieneCheckDeprecatedRuntime,
+				Severity:     cloud.SeverityHigh,
+				Detail:       "python3.8 was deprecated on 2024-10-14",
+			},
+		},
+	}, nil
+}
+
+// MockLambdaPackageOperation implements cloud.LambdaPackageOperation for testing
+type MockLambdaPackageOperation struct{}
+
+func (o *MockLambdaPackageOperation) Name() string {
+	return "Function Package"
+}
+
+func (o *MockLambdaPackageOperation) Description() string {
+	return "Download Lambda Deployment Packages"
+}
+
+func (o *MockLambdaPackageOperation) IsUIVisible() bool {
+	return false
+}
+
+func (o *MockLambdaPackageOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	functionName, _ := params["functionName"].(string)
+	return o.DownloadFunctionPackage(ctx, functionName)
+}
+
+func (o *MockLambdaPackageOperation) DownloadFunctionPackage(ctx context.Context, functionName string) (*cloud.FunctionPackage, error) {
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	for name, content := range map[string]string{
+		"index.js":                       "exports.handler = async () => ({ statusCode: 200 });\n",
+		"node_modules/uuid/package.json": "{\"name\": \"uuid\"}\n",
+	} {
+		file, err := writer.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := file.Write([]byte(content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return cloud.NewFunctionPackage(functionName, "mock-sha256", archive.Bytes())
+}
+
+// MockLambdaSecurityOperation implements cloud.LambdaSecurityOperation for testing
+type MockLambdaSecurityOperation struct{}
+
+func (o *MockLambdaSecurityOperation) Name() string {
+	return "Function Security"
+}
+
+func (o *MockLambdaSecurityOperation) Description() string {
+	return "Review Lambda Function Permissions"
+}
+
+func (o *MockLambdaSecurityOperation) IsUIVisible() bool {
+	return false
+}
+
+func (o *MockLambdaSecurityOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	functionName, _ := params["functionName"].(string)
+	return o.GetFunctionSecurity(ctx, functionName)
+}
+
+func (o *MockLambdaSecurityOperation) GetFunctionSecurity(ctx context.Context, functionName string) (*cloud.FunctionSecurity, error) {
+	return &cloud.FunctionSecurity{
+		FunctionName: functionName,
+		Role:         "arn:aws:iam::123456789012:role/" + functionName + "-role",
+		FunctionURL: &cloud.FunctionURLConfig{
+			URL:        "https://abcdefghij.lambda-url.us-east-1.on.aws/",
+			AuthType:   "NONE",
+			InvokeMode: "BUFFERED",
+		},
+		PolicyStatements: []cloud.PolicyStatement{
+			{
+				Sid:        "FunctionURLAllowPublicAccess",
+				Effect:     "Allow",
+				Principals: []string{"*"},
+				Actions:    []string{"lambda:InvokeFunctionUrl"},
+				Conditions: []string{"StringEquals lambda:FunctionUrlAuthType NONE"},
+			},
+		},
+		RolePolicies: []cloud.RolePolicy{
+			{Name: "AWSLambdaBasicExecutionRole", Arn: "arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole"},
+		},
+		Findings: []cloud.SecurityFinding{
+			{Severity: cloud.SeverityHigh, Subject: "Function URL", Detail: "Anyone on the internet can invoke the function URL"},
+		},
+	}, nil
+}
+
+// MockLambdaAsyncOperation implements cloud.LambdaAsyncOperation for testing
+type MockLambdaAsyncOperation struct{}
+
+func (o *MockLambdaAsyncOperation) Name() string {
+	return "Async Invocations"
+}
+
+func (o *MockLambdaAsyncOperation) Description() string {
+	return "Inspect and Replay Failed Async Events"
+}
+
+func (o *MockLambdaAsyncOperation) IsUIVisible() bool {
+	return false
+}
+
+func (o *MockLambdaAsyncOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	functionName, _ := params["functionName"].(string)
+	return o.GetAsyncInvokeConfig(ctx, functionName)
+}
+
+func (o *MockLambdaAsyncOperation) GetAsyncInvokeConfig(ctx context.Context, functionName string) (*cloud.AsyncInvokeConfig, error) {
+	return &cloud.AsyncInvokeConfig{
+		FunctionName:     functionName,
+		MaxRetryAttempts: 2,
+		MaxEventAge:      21600,
+		OnFailure:        "arn:aws:sqs:us-east-1:123456789012:" + functionName + "-failures",
+	}, nil
+}
+
+func (o *MockLambdaAsyncOperation) PeekFailedEvents(ctx context.Context, queueArn string, limit int) ([]cloud.FailedEvent, error) {
+	return []cloud.FailedEvent{
+		{
+			MessageID:    "mock-message-1",
+			QueueArn:     queueArn,
+			SentAt:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			ReceiveCount: 1,
+			RequestID:    "mock-request-1",
+			Condition:    "RetriesExhausted",
+			ErrorMessage: "Task timed out after 3.00 seconds",
+			Payload:      `{"orderId": "1234"}`,
+		},
+	}, nil
+}
+
+func (o *MockLambdaAsyncOperation) RedriveEvent(ctx context.Context, functionName string, event cloud.FailedEvent) error {
+	return nil
+}
+
+// MockS3BrowserOperation implements cloud.S3BrowserOperation for testing
+type MockS3BrowserOperation struct{}
+
+func (o *MockS3BrowserOperation) Name() string {
+	return "Browse Buckets"
+}
+
+func (o *MockS3BrowserOperation) Description() string {
+	return "Browse Buckets, Prefixes and Objects"
+}
+
+func (o *MockS3BrowserOperation) IsUIVisible() bool {
+	return true
+}
+
+func (o *MockS3BrowserOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return o.ListBuckets(ctx)
+}
+
+func (o *MockS3BrowserOperation) ListBuckets(ctx context.Context) ([]cloud.S3Bucket, error) {
+	return []cloud.S3Bucket{
+		{Name: "mock-bucket", Region: "us-east-1", CreationDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}, nil
+}
+
+func (o *MockS3BrowserOperation) ListObjects(ctx context.Context, bucket cloud.S3Bucket, prefix, token string, limit int) (*cloud.S3ObjectPage, error) {
+	return &cloud.S3ObjectPage{
+		Prefix: prefix,
+		Objects: []cloud.S3Object{
+			{Key: prefix + "logs/", IsPrefix: true},
+			{Key: prefix + "index.html", Size: 1024, StorageClass: "STANDARD", LastModified: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}, nil
+}
+
+func (o *MockS3BrowserOperation) GetObjectDetails(ctx context.Context, bucket cloud.S3Bucket, key string) (*cloud.S3ObjectDetails, error) {
+	return &cloud.S3ObjectDetails{
+		Bucket:       bucket.Name,
+		Key:          key,
+		Size:         1024,
+		ContentType:  "text/html",
+		StorageClass: "STANDARD",
+		LastModified: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		ETag:         `"mock-etag"`,
+		Metadata:     map[string]string{"owner": "web"},
+		Tags:         map[string]string{"env": "test"},
+	}, nil
+}
+
+// MockS3TransferOperation implements cloud.S3TransferOperation for testing
+type MockS3TransferOperation struct{}
+
+func (o *MockS3TransferOperation) Name() string {
+	return "Transfer Objects"
+}
+
+func (o *MockS3TransferOperation) Description() string {
+	return "Download, Upload, Presign and Preview Objects"
+}
+
+func (o *MockS3TransferOperation) IsUIVisible() bool {
+	return false
+}
+
+func (o *MockS3TransferOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func (o *MockS3TransferOperation) DownloadObject(ctx context.Context, bucket cloud.S3Bucket, key, localPath string, progress cloud.S3TransferProgress) error {
+	progress(1024, 1024)
+	return nil
+}
+
+func (o *MockS3TransferOperation) UploadFiles(ctx context.Context, bucket cloud.S3Bucket, upload *cloud.S3Upload, progress cloud.S3TransferProgress) error {
+	progress(upload.Size, upload.Size)
+	return nil
+}
+
+func (o *MockS3TransferOperation) PresignObject(ctx context.Context, bucket cloud.S3Bucket, request cloud.S3PresignRequest) (*cloud.S3PresignedURL, error) {
+	return &cloud.S3PresignedURL{
+		Method:  request.Method,
+		URL:     "https://" + bucket.Name + ".s3.amazonaws.com/" + request.Key + "?X-Amz-Signature=mock",
+		Expires: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(request.Expiry),
+	}, nil
+}
+
+func (o *MockS3TransferOperation) PreviewObject(ctx context.Context, bucket cloud.S3Bucket, key string, limit int64) (*cloud.S3ObjectPreview, error) {
+	return &cloud.S3ObjectPreview{
+		Key:         key,
+		ContentType: "application/json",
+		Size:        17,
+		Data:        []byte(`{"mock": "value"}`),
+	}, nil
+}
+
+// MockEC2InstanceOperation implements cloud.EC2InstanceOperation for testing
+type MockEC2InstanceOperation struct{}
+
+func (o *MockEC2InstanceOperation) Name() string {
+	return "Manage Instances"
+}
+
+func (o *MockEC2InstanceOperation) Description() string {
+	return "List, Start, Stop and Reboot Instances"
+}
+
+func (o *MockEC2InstanceOperation) IsUIVisible() bool {
+	return true
+}
+
+func (o *MockEC2InstanceOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return o.ListInstances(ctx, cloud.EC2InstanceFilter{})
+}
+
+func (o *MockEC2InstanceOperation) ListInstances(ctx context.Context, filter cloud.EC2InstanceFilter) ([]cloud.EC2Instance, error) {
+	return []cloud.EC2Instance{
+		{
+			ID:               "i-0123456789abcdef0",
+			Name:             "mock-web",
+			State:            cloud.EC2StateRunning,
+			Type:             "t3.micro",
+			AvailabilityZone: "us-east-1a",
+			PrivateIP:        "10.0.0.10",
+			LaunchTime:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Tags:             map[string]string{"Name": "mock-web"},
+		},
+	}, nil
+}
+
+func (o *MockEC2InstanceOperation) GetInstanceDetails(ctx context.Context, instanceID string) (*cloud.EC2InstanceDetails, error) {
+	instances, _ := o.ListInstances(ctx, cloud.EC2InstanceFilter{})
+	return &cloud.EC2InstanceDetails{
+		EC2Instance:    instances[0],
+		ImageID:        "ami-0123456789abcdef0",
+		SecurityGroups: []cloud.EC2SecurityGroup{{ID: "sg-0123456789abcdef0", Name: "web"}},
+		Volumes:        []cloud.EC2Volume{{ID: "vol-0123456789abcdef0", Device: "/dev/xvda", Size: 8, Type: "gp3", State: "in-use"}},
+		SystemStatus:   "ok",
+		InstanceStatus: "ok",
+	}, nil
+}
+
+func (o *MockEC2InstanceOperation) StartInstance(ctx context.Context, instanceID string) (string, error) {
+	return cloud.EC2StatePending, nil
+}
+
+func (o *MockEC2InstanceOperation) StopInstance(ctx context.Context, instanceID string) (string, error) {
+	return cloud.EC2StateStopping, nil
+}
+
+func (o *MockEC2InstanceOperation) RebootInstance(ctx context.Context, instanceID string) error {
+	return nil
+}
+
+// MockLogsInsightsOperation implements cloud.LogsInsightsOperation for testing
+type MockLogsInsightsOperation struct{}
+
+func (o *MockLogsInsightsOperation) Name() string {
+	return "Query Logs"
+}
+
+func (o *MockLogsInsightsOperation) Description() string {
+	return "Run Logs Insights Queries"
+}
+
+func (o *MockLogsInsightsOperation) IsUIVisible() bool {
+	return true
+}
+
+func (o *MockLogsInsightsOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return o.ListLogGroups(ctx)
+}
+
+func (o *MockLogsInsightsOperation) ListLogGroups(ctx context.Context) ([]cloud.LogGroup, error) {
+	return []cloud.LogGroup{
+		{Name: "/aws/lambda/test-function", StoredBytes: 2048, RetentionDays: 14},
+	}, nil
+}
+
+func (o *MockLogsInsightsOperation) StartQuery(ctx context.Context, query cloud.LogsQuery) (string, error) {
+	return "query-1", nil
+}
+
+func (o *MockLogsInsightsOperation) GetQueryResults(ctx context.Context, queryID string) (*cloud.LogsQueryResults, error) {
+	return &cloud.LogsQueryResults{
+		Status:         cloud.LogsQueryComplete,
+		RecordsMatched: 1,
+		RecordsScanned: 10,
+		Fields:         []string{"@timestamp", "@message"},
+		Records:        [][]string{{"2025-01-01 00:00:00.000", "START RequestId: 1"}},
+	}, nil
+}
+
+func (o *MockLogsInsightsOperation) ListSavedQueries(ctx context.Context, logGroups []string) ([]cloud.SavedLogsQuery, error) {
+	return nil, nil
+}
+
+func (o *MockLogsInsightsOperation) SaveQuery(ctx context.Context, query cloud.SavedLogsQuery) (string, error) {
+	return "definition-1", nil
+}
+
+// MockAlarmOperation implements cloud.AlarmOperation for testing
+type MockAlarmOperation struct{}
+
+func (o *MockAlarmOperation) Name() string {
+	return "View Alarms"
+}
+
+func (o *MockAlarmOperation) Description() string {
+	return "Browse Alarms by State"
+}
+
+func (o *MockAlarmOperation) IsUIVisible() bool {
+	return true
+}
+
+func (o *MockAlarmOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return o.ListAlarms(ctx, "")
+}
+
+func (o *MockAlarmOperation) ListAlarms(ctx context.Context, prefix string) ([]cloud.Alarm, error) {
+	alarms := []cloud.Alarm{
+		{Name: "test-function-errors", Type: cloud.AlarmTypeMetric, State: cloud.AlarmStateAlarm, ActionsEnabled: true,
+			Resource: "AWS/Lambda Errors FunctionName=test-function"},
+		{Name: "test-pipeline-failures", Type: cloud.AlarmTypeMetric, State: cloud.AlarmStateOK, ActionsEnabled: true},
+	}
+	var matching []cloud.Alarm
+	for _, alarm := range alarms {
+		if strings.HasPrefix(alarm.Name, prefix) {
+			matching = append(matching, alarm)
+		}
+	}
+	return matching, nil
+}
+
+func (o *MockAlarmOperation) SetAlarmActions(ctx context.Context, name string, enabled bool) error {
+	return nil
+}
+
+func (o *MockAlarmOperation) GetAlarmHistory(ctx context.Context, name string) ([]cloud.AlarmHistoryItem, error) {
+	return []cloud.AlarmHistoryItem{
+		{Type: "StateUpdate", Summary: "Alarm updated from OK to ALARM"},
+	}, nil
+}
+
+// MockECSServiceOperation implements cloud.ECSServiceOperation for testing
+type MockECSServiceOperation struct{}
+
+func (o *MockECSServiceOperation) Name() string {
+	return "Manage Services"
+}
+
+func (o *MockECSServiceOperation) Description() string {
+	return "Browse Clusters, Services and Tasks"
+}
+
+func (o *MockECSServiceOperation) IsUIVisible() bool {
+	return true
+}
+
+func (o *MockECSServiceOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return o.ListClusters(ctx)
+}
+
+func (o *MockECSServiceOperation) ListClusters(ctx context.Context) ([]cloud.ECSCluster, error) {
+	return []cloud.ECSCluster{
+		{Name: "test-cluster", Status: "ACTIVE", ActiveServices: 1, RunningTasks: 2},
+	}, nil
+}
+
+func (o *MockECSServiceOperation) ListServices(ctx context.Context, cluster string) ([]cloud.ECSService, error) {
+	return []cloud.ECSService{
+		{
+			Name:           "test-service",
+			Cluster:        cluster,
+			Status:         "ACTIVE",
+			TaskDefinition: "test-service:3",
+			DesiredCount:   2,
+			RunningCount:   2,
+			Deployments: []cloud.ECSDeployment{
+				{ID: "ecs-svc/1", Status: cloud.ECSPrimaryDeployment, RolloutState: cloud.ECSRolloutCompleted, DesiredCount: 2, RunningCount: 2},
+			},
+			Events: []cloud.ECSServiceEvent{
+				{Message: "(service test-service) has reached a steady state."},
+			},
+		},
+	}, nil
+}
+
+func (o *MockECSServiceOperation) ListTasks(ctx context.Context, cluster, service string) ([]cloud.ECSTask, error) {
+	return []cloud.ECSTask{
+		{ID: "task-1", LastStatus: "RUNNING", DesiredStatus: "RUNNING", Containers: []cloud.ECSContainer{{Name: "app", LastStatus: "RUNNING"}}},
+	}, nil
+}
+
+func (o *MockECSServiceOperation) ForceNewDeployment(ctx context.Context, cluster, service string) error {
+	return nil
+}
+
+func (o *MockECSServiceOperation) SetDesiredCount(ctx context.Context, cluster, service string, count int32) error {
+	return nil
+}
+
+// MockCodeBuildOperation implements cloud.CodeBuildOperation for testing
+type MockCodeBuildOperation struct{}
+
+func (o *MockCodeBuildOperation) Name() string {
+	return "Manage Builds"
+}
+
+func (o *MockCodeBuildOperation) Description() string {
+	return "Browse Projects, Start and Watch Builds"
+}
+
+func (o *MockCodeBuildOperation) IsUIVisible() bool {
+	return true
+}
+
+func (o *MockCodeBuildOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return o.ListProjects(ctx)
+}
+
+func (o *MockCodeBuildOperation) ListProjects(ctx context.Context) ([]cloud.BuildProject, error) {
+	return []cloud.BuildProject{
+		{Name: "test-project", SourceType: "CODEPIPELINE"},
+	}, nil
+}
+
+func (o *MockCodeBuildOperation) ListBuilds(ctx context.Context, project string) ([]cloud.Build, error) {
+	return []cloud.Build{
+		{ID: project + ":1", Number: 1, Project: project, Status: cloud.BuildStatusSucceeded, Initiator: "codepipeline/test-pipeline"},
+	}, nil
+}
+
+func (o *MockCodeBuildOperation) GetBuild(ctx context.Context, id string) (*cloud.Build, error) {
+	return &cloud.Build{ID: id, Number: 1, Project: "test-project", Status: cloud.BuildStatusSucceeded}, nil
+}
+
+func (o *MockCodeBuildOperation) StartBuild(ctx context.Context, start cloud.BuildStart) (*cloud.Build, error) {
+	return &cloud.Build{ID: start.Project + ":2", Number: 2, Project: start.Project, Status: cloud.BuildStatusInProgress}, nil
+}
+
+func (o *MockCodeBuildOperation) GetBuildLogs(ctx context.Context, build cloud.Build, token string) (*cloud.BuildLogs, error) {
+	return &cloud.BuildLogs{NextToken: token}, nil
+}
+
+// MockCloudFormationOperation implements cloud.CloudFormationOperation for testing
+type MockCloudFormationOperation struct{}
+
+func (o *MockCloudFormationOperation) Name() string {
+	return "Manage Stacks"
+}
+
+func (o *MockCloudFormationOperation) Description() string {
+	return "Stack Events, Drift and Change Sets"
+}
+
+func (o *MockCloudFormationOperation) IsUIVisible() bool {
+	return true
+}
+
+func (o *MockCloudFormationOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return o.ListStacks(ctx)
+}
+
+func (o *MockCloudFormationOperation) ListStacks(ctx context.Context) ([]cloud.Stack, error) {
+	return []cloud.Stack{
+		{Name: "test-stack", Status: "UPDATE_COMPLETE", DriftStatus: "NOT_CHECKED"},
+	}, nil
+}
+
+func (o *MockCloudFormationOperation) GetStackEvents(ctx context.Context, stack string) ([]cloud.StackEvent, error) {
+	return []cloud.StackEvent{
+		{LogicalID: stack, ResourceType: "AWS::CloudFormation::Stack", Status: "UPDATE_COMPLETE"},
+	}, nil
+}
+
+func (o *MockCloudFormationOperation) DetectStackDrift(ctx context.Context, stack string) (string, error) {
+	return "test-detection", nil
+}
+
+func (o *MockCloudFormationOperation) GetStackDriftDetection(ctx context.Context, id string) (*cloud.StackDriftDetection, error) {
+	return &cloud.StackDriftDetection{ID: id, Status: cloud.DriftDetectionComplete, StackDriftStatus: "IN_SYNC"}, nil
+}
+
+func (o *MockCloudFormationOperation) GetStackResourceDrifts(ctx context.Context, stack string) ([]cloud.StackResourceDrift, error) {
+	return nil, nil
+}
+
+func (o *MockCloudFormationOperation) ListChangeSets(ctx context.Context, stack string) ([]cloud.ChangeSet, error) {
+	return nil, nil
+}
+
+func (o *MockCloudFormationOperation) GetChangeSet(ctx context.Context, stack, name string) (*cloud.ChangeSet, error) {
+	return &cloud.ChangeSet{Name: name, Status: "CREATE_COMPLETE", ExecutionStatus: cloud.ChangeSetExecutionAvailable}, nil
+}
+
+func (o *MockCloudFormationOperation) ExecuteChangeSet(ctx context.Context, stack, name string) error {
+	return nil
+}
+
+// MockParameterStoreOperation implements cloud.ParameterStoreOperation for testing
+type MockParameterStoreOperation struct{}
+
+func (o *MockParameterStoreOperation) Name() string {
+	return "Manage Parameters"
+}
+
+func (o *MockParameterStoreOperation) Description() string {
+	return "Browse, Reveal and Edit Parameters"
+}
+
+func (o *MockParameterStoreOperation) IsUIVisible() bool {
+	return true
+}
+
+func (o *MockParameterStoreOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return o.ListParameters(ctx)
+}
+
+func (o *MockParameterStoreOperation) ListParameters(ctx context.Context) ([]cloud.Parameter, error) {
+	return []cloud.Parameter{
+		{Name: "/test/parameter", Type: cloud.ParameterTypeString, Version: 1},
+	}, nil
+}
+
+func (o *MockParameterStoreOperation) GetParameterValue(ctx context.Context, name string, decrypt bool) (*cloud.ParameterValue, error) {
+	return &cloud.ParameterValue{Name: name, Type: cloud.ParameterTypeString, Version: 1, Value: "test-value"}, nil
+}
+
+func (o *MockParameterStoreOperation) GetParameterHistory(ctx context.Context, name string, decrypt bool) ([]cloud.ParameterVersion, error) {
+	return []cloud.ParameterVersion{{Version: 1, Value: "test-value"}}, nil
+}
+
+func (o *MockParameterStoreOperation) PutParameterVersion(ctx context.Context, parameter cloud.Parameter, value string) (int64, error) {
+	return parameter.Version + 1, nil
+}
+
+// MockSecretsManagerOperation implements cloud.SecretsManagerOperation for testing
+type MockSecretsManagerOperation struct{}
+
+func (o *MockSecretsManagerOperation) Name() string {
+	return "Manage Secrets"
+}
+
+func (o *MockSecretsManagerOperation) Description() string {
+	return "Rotation Status, Reveal and Rotate"
+}
+
+func (o *MockSecretsManagerOperation) IsUIVisible() bool {
+	return true
+}
+
+func (o *MockSecretsManagerOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return o.ListSecrets(ctx)
+}
+
+func (o *MockSecretsManagerOperation) ListSecrets(ctx context.Context) ([]cloud.Secret, error) {
+	return []cloud.Secret{
+		{Name: "test/secret", ARN: "arn:aws:secretsmanager:us-east-1:123456789012:secret:test/secret-AbCdEf"},
+	}, nil
+}
+
+func (o *MockSecretsManagerOperation) GetSecretValue(ctx context.Context, secretID string) (*cloud.SecretValue, error) {
+	return &cloud.SecretValue{VersionID: "test-version", Stages: []string{"AWSCURRENT"}, Value: "test-value"}, nil
+}
+
+func (o *MockSecretsManagerOperation) RotateSecret(ctx context.Context, secretID string) error {
+	return nil
+}
+
+// MockQueueOperation implements cloud.QueueOperation for testing
+type MockQueueOperation struct{}
+
+func (o *MockQueueOperation) Name() string {
+	return "Manage Queues"
+}
+
+func (o *MockQueueOperation) Description() string {
+	return "Queue Depth, Peek, Purge and Redrive"
+}
+
+func (o *MockQueueOperation) IsUIVisible() bool {
+	return true
+}
+
+func (o *MockQueueOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return o.ListQueues(ctx)
+}
+
+func (o *MockQueueOperation) ListQueues(ctx context.Context) ([]cloud.Queue, error) {
+	return []cloud.Queue{
+		{
+			Name:    "test-queue",
+			URL:     "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue",
+			ARN:     "arn:aws:sqs:us-east-1:123456789012:test-queue",
+			Visible: 1,
+		},
+	}, nil
+}
+
+func (o *MockQueueOperation) PeekMessages(ctx context.Context, queueURL string, limit int) ([]cloud.QueueMessage, error) {
+	return []cloud.QueueMessage{{ID: "test-message", Body: "test-body", ReceiveCount: 1}}, nil
+}
+
+func (o *MockQueueOperation) SendMessage(ctx context.Context, queue cloud.Queue, body string) (string, error) {
+	return "test-message", nil
+}
+
+func (o *MockQueueOperation) PurgeQueue(ctx context.Context, queueURL string) error {
+	return nil
+}
+
+func (o *MockQueueOperation) RedriveMessages(ctx context.Context, queueARN string, progress func(moved, total int64)) (int64, error) {
+	progress(1, 1)
+	return 1, nil
+}
+
+// MockTopicOperation implements cloud.TopicOperation for testing
+type MockTopicOperation struct{}
+
+func (o *MockTopicOperation) Name() string {
+	return "Manage Topics"
+}
+
+func (o *MockTopicOperation) Description() string {
+	return "Subscriptions, Filter Policies and Test Publish"
+}
+
+func (o *MockTopicOperation) IsUIVisible() bool {
+	return true
+}
+
+func (o *MockTopicOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return o.ListTopics(ctx)
+}
+
+func (o *MockTopicOperation) ListTopics(ctx context.Context) ([]cloud.Topic, error) {
+	return []cloud.Topic{
+		{
+			Name:      "test-topic",
+			ARN:       "arn:aws:sns:us-east-1:123456789012:test-topic",
+			Confirmed: 1,
+		},
+	}, nil
+}
+
+func (o *MockTopicOperation) ListSubscriptions(ctx context.Context, topicARN string) ([]cloud.Subscription, error) {
+	return []cloud.Subscription{
+		{
+			ARN:      topicARN + ":test-subscription",
+			Protocol: "sqs",
+			Endpoint: "arn:aws:sqs:us-east-1:123456789012:test-queue",
+		},
+	}, nil
+}
+
+func (o *MockTopicOperation) PublishMessage(ctx context.Context, topic cloud.Topic, message cloud.TopicMessage) (string, error) {
+	return "test-message", nil
+}
+
 // MockService implements cloud.Service for testing
 type MockService struct {
 	name        string
@@ -1,7 +1,9 @@
 package model
 
 import (
+	"context"
 	"sort"
+	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
@@ -89,6 +91,22 @@ type Model struct {
 	LambdaPayload string
 	LambdaResult  *cloud.LambdaExecuteResult
 
+	// Lambda payload validation and response display state
+	LambdaPayloadError  *JSONSyntaxError // Position of the error found when validating the payload
+	LambdaResponseQuery string           // jq-style path applied to the response
+	LambdaResponseDepth int              // Nesting depth shown expanded; 0 expands everything
+	ShowLambdaLogs      bool             // Show the log pane instead of the response
+
+	// Lambda response streaming state
+	LambdaInvokeMode   string             // Auto, buffered or streaming invocation
+	LambdaStreamID     int                // Number of the latest invocation
+	IsLambdaStreaming  bool               // A streamed response is still arriving
+	LambdaInvokeCancel context.CancelFunc // Stops the latest invocation, such as when its response is left
+
+	// Invoke mode looked up in auto mode, kept so it isn't looked up on every invocation
+	ResponseStreamingFunction string // Function the invoke mode was looked up for
+	ResponseStreaming         bool   // Whether the function's URL streams its response
+
 	// Operation flow tracking
 	IsExecuteLambdaFlow bool
 
@@ -97,6 +115,153 @@ type Model struct {
 
 	// Lambda input mode (when true, Enter adds new lines; when false, Enter executes)
 	IsLambdaInputMode bool
+
+	// Lambda configuration state
+	FunctionConfig       *cloud.FunctionStatus      // Full configuration of the selected function
+	FunctionConfigUpdate cloud.FunctionConfigUpdate // Changes staged in the configuration form
+	FunctionConfigField  string                     // Setting currently being edited
+
+	// Lambda deployment state
+	DeployRequest cloud.LambdaDeployRequest // Deployment staged in the deploy form
+	DeployField   string                    // Setting currently being edited
+
+	// Lambda metrics state
+	ShowFunctionMetrics   bool                              // Show metric columns in the function list
+	FunctionMetricsWindow time.Duration                     // Window the metrics cover
+	FunctionMetrics       map[string]*cloud.FunctionMetrics // Metrics by function name; nil while loading
+
+	// Functions whose state and tags were requested, as they're loaded for the shown functions only
+	DescribedFunctions map[string]bool
+
+	// Lambda event source mapping state
+	EventSourceMappings []cloud.EventSourceMapping // Mappings in the current scope
+	SelectedEventSource *cloud.EventSourceMapping  // Mapping shown in the details view
+	EventSourceFunction string                     // Function the mappings are scoped to; empty for all functions
+
+	// Lambda hygiene report state
+	HygieneOptions cloud.HygieneReportOptions // Scope and thresholds of the next report
+	HygieneField   string                     // Setting currently being edited
+	HygieneReport  *cloud.HygieneReport       // Findings of the last report
+
+	// Lambda benchmark state
+	BenchOptions cloud.LambdaBenchOptions // Invocations and concurrency of the next benchmark
+	BenchField   string                   // Setting currently being edited
+	BenchResult  *cloud.LambdaBenchResult // Outcome of the last benchmark
+
+	// Lambda deployment package state
+	FunctionPackage *cloud.FunctionPackage // Package of the selected function, once downloaded
+	PackageDir      string                 // Directory shown in the package browser; empty for the root
+	PackageFilePath string                 // File shown in the package file viewer
+
+	// Lambda security review state
+	FunctionSecurity *cloud.FunctionSecurity // URL, policies and findings of the selected function
+
+	// Function comparison state
+	CompareOptions     cloud.FunctionCompareOptions // Targets of the next comparison
+	CompareField       string                       // Setting currently being edited
+	FunctionComparison *cloud.FunctionComparison    // Outcome of the last comparison
+
+	// Asynchronous invocation state
+	AsyncConfig      *cloud.AsyncInvokeConfig // Retry settings and destinations of the selected function
+	FailedEventQueue string                   // Queue the failed events were read from
+	FailedEvents     []cloud.FailedEvent      // Events peeked from the failure queue
+
+	// S3 browser state
+	S3Buckets       []cloud.S3Bucket       // Buckets of the account
+	SelectedBucket  *cloud.S3Bucket        // Bucket being browsed
+	S3Listings      []S3Listing            // Prefixes drilled through, the current one last
+	S3ObjectDetails *cloud.S3ObjectDetails // Object shown in the details view
+
+	// S3 transfer state
+	S3InputField   string                 // Path or setting being entered in the browser or presign form
+	S3Presign      cloud.S3PresignRequest // URL staged in the presign form
+	S3PresignedURL *cloud.S3PresignedURL  // Last URL generated in the presign form
+	S3Preview      *cloud.S3ObjectPreview // Object shown in the preview
+
+	// EC2 instance state
+	EC2Filter          cloud.EC2InstanceFilter   // Filter staged in the filter form
+	EC2Instances       []cloud.EC2Instance       // Instances matching the filter
+	EC2InstanceDetails *cloud.EC2InstanceDetails // Instance shown in the details view
+
+	// Logs Insights state
+	LogGroups            []cloud.LogGroup        // Log groups of the account
+	SelectedLogGroups    []string                // Log groups the query runs over, in the order they were selected
+	LogsTimeRange        time.Duration           // How far back from now the query reaches
+	IsLogsQueryInputMode bool                    // Keys go to the query editor instead of running commands
+	LogsQueryID          string                  // Query being run; results of other queries are ignored
+	LogsQueryResults     *cloud.LogsQueryResults // Status and records of the last query
+	SavedQueries         []cloud.SavedLogsQuery  // Queries saved over the selected log groups
+	LogsSavedQuery       *cloud.SavedLogsQuery   // Saved query loaded in the editor, or last saved from it
+
+	// CloudWatch alarm state
+	Alarms        []cloud.Alarm            // Alarms of the account, grouped by state
+	SelectedAlarm *cloud.Alarm             // Alarm shown in the details view
+	AlarmBackView constants.View           // View the details view was opened from
+	AlarmHistory  []cloud.AlarmHistoryItem // History of the selected alarm
+	LinkedAlarms  map[string][]cloud.Alarm // Alarms by the function or pipeline name they start with; present once requested
+
+	// ECS state
+	ECSClusters        []cloud.ECSCluster // Clusters of the account
+	ECSCluster         string             // Cluster whose services are listed
+	ECSServices        []cloud.ECSService // Services of the cluster
+	SelectedECSService *cloud.ECSService  // Service shown in the details view
+	ECSTasks           []cloud.ECSTask    // Running and recently stopped tasks of the service
+	SelectedECSTask    *cloud.ECSTask     // Task shown in the details view
+
+	// CodeBuild state
+	BuildProjects   []cloud.BuildProject  // Projects of the account
+	BuildProject    *cloud.BuildProject   // Project whose builds are listed
+	Builds          []cloud.Build         // Latest builds of the project
+	SelectedBuild   *cloud.Build          // Build shown in the details and log views, refreshed while it's followed
+	BuildStart      cloud.BuildStart      // Build staged in the start form
+	BuildInputField string                // Setting or variable being entered in the start form
+	BuildLogs       []cloud.BuildLogEvent // Lines of the selected build's log read so far
+	BuildLogsToken  string                // Token to read the next lines of the log from
+	BuildFollowID   string                // Build whose log is being followed; results for other builds are ignored
+
+	// CloudFormation state
+	Stacks                []cloud.Stack              // Stacks of the account
+	SelectedStack         *cloud.Stack               // Stack shown in the details view and the views it leads to
+	StackEvents           []cloud.StackEvent         // Latest events of the stack, newest first
+	StackDriftID          string                     // Drift detection being polled; results of other detections are ignored
+	StackDriftDetection   *cloud.StackDriftDetection // Result of the stack's last drift detection
+	StackDrifts           []cloud.StackResourceDrift // Resources of the stack that drifted
+	SelectedResourceDrift *cloud.StackResourceDrift  // Drifted resource whose property differences are shown
+	ChangeSets            []cloud.ChangeSet          // Change sets of the stack
+	SelectedChangeSet     *cloud.ChangeSet           // Change set whose resource changes are shown
+
+	// Parameter Store state
+	Parameters           []cloud.Parameter        // Parameters of the account, without their values
+	ParameterPath        string                   // Path being browsed, "/" for the root
+	SelectedParameter    *cloud.Parameter         // Parameter shown in the details view and the views it leads to
+	ParameterValue       *cloud.ParameterValue    // Value of the parameter, decrypted once revealed
+	ParameterHistory     []cloud.ParameterVersion // Versions of the parameter, newest first
+	IsParameterInputMode bool                     // Keys go to the value editor instead of running commands
+
+	// Secrets Manager state
+	Secrets        []cloud.Secret     // Secrets of the account, without their values
+	SelectedSecret *cloud.Secret      // Secret shown in the details view
+	SecretValue    *cloud.SecretValue // Value of the secret while it's revealed
+	SecretKeys     []string           // Keys of a JSON secret, still listed once its value is hidden
+	SecretRevealID int                // Reveal the value is shown for; timeouts of earlier reveals are ignored
+
+	// SQS state
+	Queues               []cloud.Queue        // Queues of the account
+	SelectedQueue        *cloud.Queue         // Queue shown in the details view and the views it leads to
+	QueueMessages        []cloud.QueueMessage // Messages peeked from the queue
+	SelectedQueueMessage *cloud.QueueMessage  // Message whose body is shown
+	IsQueueInputMode     bool                 // Keys go to the test message editor instead of running commands
+
+	// SNS state
+	Topics               []cloud.Topic        // Topics of the account
+	SelectedTopic        *cloud.Topic         // Topic shown in the details view and the views it leads to
+	Subscriptions        []cloud.Subscription // Subscriptions to the topic
+	SelectedSubscription *cloud.Subscription  // Subscription whose filter policy is shown
+	IsTopicInputMode     bool                 // Keys go to the test message editor instead of running commands
+
+	// Change awaiting confirmation in the executing action view, and its progress once running
+	PendingAction  *PendingAction
+	ActionProgress *ActionProgress
 }
 
 // ProviderState represents the state of the selected provider, service, category, and operation
@@ -224,6 +389,9 @@ func New() *Model {
 		},
 		PageSize: 5,
 
+		FunctionMetricsWindow: constants.MetricsWindowShort,
+		LambdaInvokeMode:      constants.InvokeModeAuto,
+
 		// Initialize search state
 		Search: SearchState{
 			IsActive:      false,
@@ -359,6 +527,50 @@ func (m *Model) Clone() *Model {
 		newModel.InputState.OperationState[k] = v
 	}
 
+	// Deep copy loaded metrics
+	if m.FunctionMetrics != nil {
+		newModel.FunctionMetrics = make(map[string]*cloud.FunctionMetrics, len(m.FunctionMetrics))
+		for k, v := range m.FunctionMetrics {
+			newModel.FunctionMetrics[k] = v
+		}
+	}
+
+	// Deep copy the functions whose state and tags were requested
+	if m.DescribedFunctions != nil {
+		newModel.DescribedFunctions = make(map[string]bool, len(m.DescribedFunctions))
+		for k, v := range m.DescribedFunctions {
+			newModel.DescribedFunctions[k] = v
+		}
+	}
+
+	// Deep copy loaded linked alarms
+	if m.LinkedAlarms != nil {
+		newModel.LinkedAlarms = make(map[string][]cloud.Alarm, len(m.LinkedAlarms))
+		for k, v := range m.LinkedAlarms {
+			newModel.LinkedAlarms[k] = v
+		}
+	}
+
+	// Deep copy the environment variables staged in the start build form
+	if m.BuildStart.Variables != nil {
+		newModel.BuildStart.Variables = make([]cloud.BuildEnvironmentVariable, len(m.BuildStart.Variables))
+		copy(newModel.BuildStart.Variables, m.BuildStart.Variables)
+	}
+
+	// Deep copy staged configuration changes
+	if m.FunctionConfigUpdate.ProvisionedConcurrency != nil {
+		newModel.FunctionConfigUpdate.ProvisionedConcurrency = make(map[string]int32)
+		for k, v := range m.FunctionConfigUpdate.ProvisionedConcurrency {
+			newModel.FunctionConfigUpdate.ProvisionedConcurrency[k] = v
+		}
+	}
+
+	// Deep copy the prefixes drilled through, so going up in a clone leaves the original intact
+	if len(m.S3Listings) > 0 {
+		newModel.S3Listings = make([]S3Listing, len(m.S3Listings))
+		copy(newModel.S3Listings, m.S3Listings)
+	}
+
 	// Deep copy search state
 	if len(m.Search.FilteredItems) > 0 {
 		newModel.Search.FilteredItems = make([]interface{}, len(m.Search.FilteredItems))
@@ -1,7 +1,11 @@
 package model
 
 import (
+	"context"
+	"time"
+
 	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
 )
 
 // Service represents a cloud service
@@ -101,10 +105,344 @@ type FunctionStatusMsg struct {
 
 // LambdaExecuteResultMsg represents the result of a Lambda execution
 type LambdaExecuteResultMsg struct {
-	Result *cloud.LambdaExecuteResult
+	Result            *cloud.LambdaExecuteResult
+	Err               error
+	ResponseStreaming *bool // Invoke mode of the function, when it was looked up
+}
+
+// LambdaStreamMsg represents a chunk of a streamed Lambda response, or the end of the stream
+type LambdaStreamMsg struct {
+	StreamID int                        // Invocation the message belongs to
+	Chunk    string                     // Payload received since the previous message
+	Done     bool                       // Set on the last message of the stream
+	Result   *cloud.LambdaExecuteResult // Final result, set when Done
+	Err      error
+	Stream   <-chan LambdaStreamMsg // Channel the next message is read from
+
+	ResponseStreaming *bool // Invoke mode of the function, when it was looked up; first message only
+}
+
+// LambdaConfigurationMsg represents a message containing the full configuration of a function
+type LambdaConfigurationMsg struct {
+	Function *cloud.FunctionStatus
+}
+
+// FunctionDescriptionsMsg represents a message containing functions with their state and tags
+type FunctionDescriptionsMsg struct {
+	Functions []FunctionStatus
+}
+
+// FunctionMetricsMsg represents a message containing the metrics of a set of functions
+type FunctionMetricsMsg struct {
+	FunctionNames []string
+	Window        time.Duration
+	Metrics       map[string]cloud.FunctionMetrics
+	Err           error
+}
+
+// EventSourceMappingsMsg represents a message containing event source mappings
+type EventSourceMappingsMsg struct {
+	Mappings []cloud.EventSourceMapping
+}
+
+// HygieneReportMsg represents a message containing a Lambda hygiene report
+type HygieneReportMsg struct {
+	Report *cloud.HygieneReport
+}
+
+// LambdaBenchMsg represents a message containing the outcome of a Lambda benchmark
+type LambdaBenchMsg struct {
+	Result *cloud.LambdaBenchResult
+}
+
+// FunctionPackageMsg represents a message containing a downloaded deployment package
+type FunctionPackageMsg struct {
+	Package *cloud.FunctionPackage
+}
+
+// FunctionSecurityMsg represents a message containing the security review of a function
+type FunctionSecurityMsg struct {
+	Security *cloud.FunctionSecurity
+}
+
+// FunctionComparisonMsg represents a message containing a function compared across targets
+type FunctionComparisonMsg struct {
+	Comparison *cloud.FunctionComparison
+}
+
+// AsyncInvokeConfigMsg represents a message containing a function's asynchronous invocation config
+type AsyncInvokeConfigMsg struct {
+	Config *cloud.AsyncInvokeConfig
+}
+
+// FailedEventsMsg represents a message containing the failed events peeked from a queue
+type FailedEventsMsg struct {
+	QueueArn string
+	Events   []cloud.FailedEvent
+}
+
+// JSONSyntaxError represents the position of a syntax error in a JSON document
+type JSONSyntaxError struct {
+	Line    int // 1-based line of the offending character
+	Column  int // 1-based column of the offending character, in runes
+	Message string
+}
+
+// PendingAction represents a change that is applied once confirmed in the executing action view
+type PendingAction struct {
+	Description string                                    // Shown next to the Execute action
+	Details     []string                                  // Before/after lines shown as context
+	LoadingMsg  string                                    // Shown while the action runs; defaults to MsgApplyingChanges
+	BackView    constants.View                            // View to return to when navigating back
+	Run         func(ctx context.Context) (string, error) // Applies the change and returns a success message
+
+	// Used in place of Run by actions that report their progress, such as transfers
+	RunWithProgress func(ctx context.Context, progress func(done, total int64)) (string, error)
+}
+
+// ActionProgress represents how far a running pending action has got
+type ActionProgress struct {
+	Done  int64
+	Total int64
+}
+
+// S3Listing represents a prefix of a bucket being browsed, listed one page at a time
+type S3Listing struct {
+	Page       *cloud.S3ObjectPage // Page currently shown
+	PageTokens []string            // Token each page up to the current one was listed with, "" for the first
+}
+
+// PageNumber returns the number of the page currently shown, starting at 1
+func (l S3Listing) PageNumber() int {
+	return len(l.PageTokens)
+}
+
+// S3BucketsMsg represents a message containing the buckets of the account
+type S3BucketsMsg struct {
+	Buckets []cloud.S3Bucket
+}
+
+// S3ObjectsPageMsg represents a message containing a page of a bucket listing
+type S3ObjectsPageMsg struct {
+	Page       *cloud.S3ObjectPage
+	PageTokens []string // Tokens of the listing once the page is shown
+	NewPrefix  bool     // Whether the page opens a prefix below the current one
+}
+
+// S3ObjectDetailsMsg represents a message containing the details of an object
+type S3ObjectDetailsMsg struct {
+	Details *cloud.S3ObjectDetails
+}
+
+// S3PresignedURLMsg represents a message containing a presigned URL
+type S3PresignedURLMsg struct {
+	URL *cloud.S3PresignedURL
+}
+
+// S3ObjectPreviewMsg represents a message containing the start of an object
+type S3ObjectPreviewMsg struct {
+	Preview *cloud.S3ObjectPreview
+}
+
+// EC2InstancesMsg represents a message containing the instances matching a filter
+type EC2InstancesMsg struct {
+	Instances []cloud.EC2Instance
+}
+
+// EC2InstanceDetailsMsg represents a message containing the details of an instance
+type EC2InstanceDetailsMsg struct {
+	Details *cloud.EC2InstanceDetails
+}
+
+// LogGroupsMsg represents a message containing the log groups of the account
+type LogGroupsMsg struct {
+	LogGroups []cloud.LogGroup
+}
+
+// LogsQueryStartedMsg represents a message containing the ID of a query that started running
+type LogsQueryStartedMsg struct {
+	QueryID string
+}
+
+// LogsQueryResultsMsg represents a message containing the progress of a running query
+type LogsQueryResultsMsg struct {
+	QueryID string
+	Results *cloud.LogsQueryResults
+	Err     error
+}
+
+// SavedQueriesMsg represents a message containing the queries saved over the selected log groups
+type SavedQueriesMsg struct {
+	Queries []cloud.SavedLogsQuery
+}
+
+// LogsQuerySavedMsg represents a message containing a query that was saved
+type LogsQuerySavedMsg struct {
+	Query cloud.SavedLogsQuery
+}
+
+// AlarmsMsg represents a message containing the alarms of the account
+type AlarmsMsg struct {
+	Alarms []cloud.Alarm
+}
+
+// AlarmHistoryMsg represents a message containing the history of an alarm
+type AlarmHistoryMsg struct {
+	History []cloud.AlarmHistoryItem
+}
+
+// ECSClustersMsg represents a message containing the ECS clusters of the account
+type ECSClustersMsg struct {
+	Clusters []cloud.ECSCluster
+}
+
+// ECSServicesMsg represents a message containing the services of an ECS cluster
+type ECSServicesMsg struct {
+	Cluster  string
+	Services []cloud.ECSService
+}
+
+// ECSTasksMsg represents a message containing the tasks of an ECS service
+type ECSTasksMsg struct {
+	Tasks []cloud.ECSTask
+}
+
+// BuildProjectsMsg represents a message containing the CodeBuild projects of the account
+type BuildProjectsMsg struct {
+	Projects []cloud.BuildProject
+}
+
+// BuildsMsg represents a message containing the latest builds of a project
+type BuildsMsg struct {
+	Project cloud.BuildProject
+	Builds  []cloud.Build
+}
+
+// BuildStartedMsg represents a message containing a build that was started
+type BuildStartedMsg struct {
+	Build cloud.Build
+}
+
+// BuildLogsMsg represents a message containing the state of a followed build and the log lines it
+// wrote since the last message
+type BuildLogsMsg struct {
+	BuildID string
+	Build   *cloud.Build
+	Logs    *cloud.BuildLogs
+	Err     error
+}
+
+// StacksMsg represents a message containing the CloudFormation stacks of the account
+type StacksMsg struct {
+	Stacks []cloud.Stack
+}
+
+// StackEventsMsg represents a message containing the latest events of a stack
+type StackEventsMsg struct {
+	Stack  string
+	Events []cloud.StackEvent
+}
+
+// StackDriftStartedMsg represents a message containing the ID of a drift detection that started
+type StackDriftStartedMsg struct {
+	DetectionID string
+}
+
+// StackDriftMsg represents a message containing the state of a running drift detection, and the
+// drifted resources once it's complete
+type StackDriftMsg struct {
+	DetectionID string
+	Detection   *cloud.StackDriftDetection
+	Drifts      []cloud.StackResourceDrift
+	Err         error
+}
+
+// ChangeSetsMsg represents a message containing the change sets of a stack
+type ChangeSetsMsg struct {
+	Stack      string
+	ChangeSets []cloud.ChangeSet
+}
+
+// ChangeSetMsg represents a message containing a change set with its resource changes
+type ChangeSetMsg struct {
+	ChangeSet *cloud.ChangeSet
+}
+
+// ParametersMsg represents a message containing the parameters of the account
+type ParametersMsg struct {
+	Parameters []cloud.Parameter
+}
+
+// ParameterValueMsg represents a message containing the value of a parameter
+type ParameterValueMsg struct {
+	Parameter cloud.Parameter
+	Value     *cloud.ParameterValue
+}
+
+// ParameterHistoryMsg represents a message containing the versions of a parameter
+type ParameterHistoryMsg struct {
+	Versions []cloud.ParameterVersion
+}
+
+// SecretsMsg represents a message containing the secrets of the account
+type SecretsMsg struct {
+	Secrets []cloud.Secret
+}
+
+// SecretValueMsg represents a message containing the revealed value of a secret
+type SecretValueMsg struct {
+	SecretID string
+	Value    *cloud.SecretValue
+}
+
+// SecretHideMsg represents the end of the time a revealed secret value is shown for
+type SecretHideMsg struct {
+	RevealID int
+}
+
+// QueuesMsg represents a message containing the queues of the account
+type QueuesMsg struct {
+	Queues []cloud.Queue
+}
+
+// QueueMessagesMsg represents a message containing the messages peeked from a queue
+type QueueMessagesMsg struct {
+	Messages []cloud.QueueMessage
+}
+
+// TopicsMsg represents a message containing the SNS topics of the account
+type TopicsMsg struct {
+	Topics []cloud.Topic
+}
+
+// SubscriptionsMsg represents a message containing the subscriptions to a topic
+type SubscriptionsMsg struct {
+	Subscriptions []cloud.Subscription
+}
+
+// LinkedAlarmsMsg represents a message containing the alarms that share a prefix with a function or pipeline
+type LinkedAlarmsMsg struct {
+	Name   string // Function or pipeline name the alarms were listed by
+	Alarms []cloud.Alarm
 	Err    error
 }
 
+// ActionResultMsg represents the result of a pending action
+type ActionResultMsg struct {
+	Message string
+	Err     error
+}
+
+// ActionProgressMsg represents the progress of a pending action that reports it, or its result
+type ActionProgressMsg struct {
+	Done     int64
+	Total    int64
+	Finished bool   // Set on the last message, once the action has finished
+	Message  string // Success message, set when Finished
+	Err      error
+	Stream   <-chan ActionProgressMsg // Channel the next message is read from
+}
+
 // FunctionsPageMsg represents a message containing a page of functions
 type FunctionsPageMsg struct {
 	Functions     []FunctionStatus
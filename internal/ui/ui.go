@@ -155,6 +155,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		newModel.core.Provider = msg.Provider
 		newModel.core.CurrentView = constants.ViewFunctionStatus
 		newModel.core.IsLoading = false
+		newModel.core.FunctionMetrics = nil
+		newModel.core.DescribedFunctions = nil
 
 		// Sort functions by name in ascending order (case-insensitive)
 		// This preserves the original case of function names in the display
@@ -202,11 +204,219 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			newModel.core.Pagination.TotalItems = int64(len(newModel.core.Pagination.AllItems))
 		}
 
+		return newModel, tea.Batch(update.LoadFunctionMetrics(newModel.core), update.LoadFunctionDescriptions(newModel.core))
+	case model.FunctionMetricsMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleFunctionMetrics(newModel.core, msg)
+		return newModel, nil
+	case model.FunctionDescriptionsMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleFunctionDescriptions(newModel.core, msg)
+		return newModel, nil
+	case model.LambdaConfigurationMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleLambdaConfiguration(newModel.core, msg)
+		return newModel, nil
+	case model.EventSourceMappingsMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleEventSourceMappings(newModel.core, msg)
+		return newModel, nil
+	case model.HygieneReportMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleHygieneReport(newModel.core, msg)
+		return newModel, nil
+	case model.LambdaBenchMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleLambdaBenchResult(newModel.core, msg)
+		return newModel, nil
+	case model.FunctionPackageMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleFunctionPackage(newModel.core, msg)
+		return newModel, nil
+	case model.FunctionSecurityMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleFunctionSecurity(newModel.core, msg)
+		return newModel, nil
+	case model.FunctionComparisonMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleFunctionComparison(newModel.core, msg)
+		return newModel, nil
+	case model.AsyncInvokeConfigMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleAsyncInvokeConfig(newModel.core, msg)
+		return newModel, nil
+	case model.FailedEventsMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleFailedEvents(newModel.core, msg)
+		return newModel, nil
+	case model.S3BucketsMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleS3BucketsResult(newModel.core, msg)
+		return newModel, nil
+	case model.S3ObjectsPageMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleS3ObjectsPage(newModel.core, msg)
+		return newModel, nil
+	case model.S3ObjectDetailsMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleS3ObjectDetails(newModel.core, msg)
+		return newModel, nil
+	case model.S3PresignedURLMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleS3PresignedURL(newModel.core, msg)
+		return newModel, nil
+	case model.S3ObjectPreviewMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleS3ObjectPreview(newModel.core, msg)
+		return newModel, nil
+	case model.EC2InstancesMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleEC2Instances(newModel.core, msg)
+		return newModel, nil
+	case model.EC2InstanceDetailsMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleEC2InstanceDetails(newModel.core, msg)
+		return newModel, nil
+	case model.LogGroupsMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleLogGroups(newModel.core, msg)
+		return newModel, nil
+	case model.LogsQueryStartedMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleLogsQueryStarted(newModel.core, msg)
+		return newModel, update.PollLogsQuery(newModel.core, msg.QueryID)
+	case model.LogsQueryResultsMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleLogsQueryResults(newModel.core, msg)
+		return newModel, update.PollLogsQuery(newModel.core, msg.QueryID)
+	case model.SavedQueriesMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleSavedQueries(newModel.core, msg)
+		return newModel, nil
+	case model.LogsQuerySavedMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleLogsQuerySaved(newModel.core, msg)
+		return newModel, nil
+	case model.AlarmsMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleAlarms(newModel.core, msg)
+		return newModel, nil
+	case model.AlarmHistoryMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleAlarmHistory(newModel.core, msg)
+		return newModel, nil
+	case model.LinkedAlarmsMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleLinkedAlarms(newModel.core, msg)
+		return newModel, nil
+	case model.ECSClustersMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleECSClusters(newModel.core, msg)
+		return newModel, nil
+	case model.ECSServicesMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleECSServices(newModel.core, msg)
+		return newModel, nil
+	case model.ECSTasksMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleECSTasks(newModel.core, msg)
+		return newModel, nil
+	case model.BuildProjectsMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleBuildProjects(newModel.core, msg)
+		return newModel, nil
+	case model.BuildsMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleBuilds(newModel.core, msg)
+		return newModel, nil
+	case model.BuildStartedMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleBuildStarted(newModel.core, msg)
+		return newModel, update.PollBuildLogs(newModel.core, msg.Build.ID)
+	case model.BuildLogsMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleBuildLogs(newModel.core, msg)
+		return newModel, update.PollBuildLogs(newModel.core, msg.BuildID)
+	case model.StacksMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleStacks(newModel.core, msg)
+		return newModel, nil
+	case model.StackEventsMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleStackEvents(newModel.core, msg)
+		return newModel, nil
+	case model.StackDriftStartedMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleStackDriftStarted(newModel.core, msg)
+		return newModel, update.PollStackDrift(newModel.core, msg.DetectionID)
+	case model.StackDriftMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleStackDrift(newModel.core, msg)
+		return newModel, update.PollStackDrift(newModel.core, msg.DetectionID)
+	case model.ChangeSetsMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleChangeSets(newModel.core, msg)
+		return newModel, nil
+	case model.ChangeSetMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleChangeSet(newModel.core, msg)
+		return newModel, nil
+	case model.ParametersMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleParameters(newModel.core, msg)
+		return newModel, nil
+	case model.ParameterValueMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleParameterValue(newModel.core, msg)
+		return newModel, nil
+	case model.ParameterHistoryMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleParameterHistory(newModel.core, msg)
+		return newModel, nil
+	case model.SecretsMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleSecrets(newModel.core, msg)
+		return newModel, nil
+	case model.SecretValueMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleSecretValue(newModel.core, msg)
+		return newModel, update.HideSecretAfterTimeout(newModel.core)
+	case model.SecretHideMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleSecretHide(newModel.core, msg)
+		return newModel, nil
+	case model.QueuesMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleQueues(newModel.core, msg)
 		return newModel, nil
+	case model.QueueMessagesMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleQueueMessages(newModel.core, msg)
+		return newModel, nil
+	case model.TopicsMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleTopics(newModel.core, msg)
+		return newModel, nil
+	case model.SubscriptionsMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleSubscriptions(newModel.core, msg)
+		return newModel, nil
+	case model.ActionResultMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleActionResult(newModel.core, msg)
+		return newModel, nil
+	case model.ActionProgressMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleActionProgress(newModel.core, msg)
+		return newModel, update.WaitForActionProgress(msg)
 	case model.LambdaExecuteResultMsg:
 		newModel := m.Clone()
 		newModel.core = update.HandleLambdaExecuteResult(newModel.core, &msg)
 		return newModel, nil
+	case model.LambdaStreamMsg:
+		newModel := m.Clone()
+		newModel.core = update.HandleLambdaStream(newModel.core, msg)
+		return newModel, update.WaitForLambdaStream(msg)
 	case spinner.TickMsg:
 		newModel := m.Clone()
 		var cmd tea.Cmd
@@ -218,10 +428,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		// Ignore navigation key presses when loading
 		if m.core.IsLoading {
-			// Only allow quit commands during loading
+			// Only allow quit commands during loading, hiding a revealed secret value so that it
+			// isn't left on the terminal
 			switch msg.String() {
 			case constants.KeyCtrlC, constants.KeyQ:
-				return m, tea.Quit
+				return Model{core: update.HideSecretValue(m.core)}, tea.Quit
 			default:
 				// Ignore all other key presses during loading
 				return m, nil
@@ -230,14 +441,70 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Special handling for Lambda response view
 		if m.core.CurrentView == constants.ViewLambdaResponse {
+			// While a query is being entered, keys go to the text input
+			if m.core.ManualInput {
+				switch msg.String() {
+				case constants.KeyCtrlC:
+					return m, tea.Quit
+				case constants.KeyEnter:
+					modelWrapper, cmd := update.HandleLambdaResponseQuery(m.core, m.core.TextInput.Value())
+					if wrapper, ok := modelWrapper.(update.ModelWrapper); ok {
+						return Model{core: wrapper.Model}, cmd
+					}
+					return modelWrapper, cmd
+				case constants.KeyEsc:
+					newModel := m.Clone()
+					newModel.core.ManualInput = false
+					newModel.core.ResetTextInput()
+					return newModel, nil
+				default:
+					newModel := m.Clone()
+					var cmd tea.Cmd
+					newModel.core.TextInput, cmd = newModel.core.TextInput.Update(msg)
+					return newModel, cmd
+				}
+			}
+
 			// Handle quit and back navigation
 			switch msg.String() {
 			case constants.KeyQ, constants.KeyCtrlC:
 				return m, tea.Quit
+			case constants.KeySearch, constants.KeyCollapse, constants.KeyExpand, constants.KeyToggleLogs:
+				modelWrapper, cmd := update.HandleLambdaResponseKey(m.core, msg.String())
+				if wrapper, ok := modelWrapper.(update.ModelWrapper); ok {
+					return Model{core: wrapper.Model}, cmd
+				}
+				return modelWrapper, cmd
 			case constants.KeyEsc, constants.KeyAltBack:
 				// Navigate back to the Lambda execution view
+				return Model{core: update.HandleLambdaResponseBack(m.core)}, nil
+			default:
+				// Pass ALL other keys to the viewport
 				newModel := m.Clone()
-				newModel.core.CurrentView = constants.ViewLambdaExecute
+				var cmd tea.Cmd
+				newModel.core.Viewport, cmd = newModel.core.Viewport.Update(msg)
+				return newModel, cmd
+			}
+		}
+
+		// Special handling for the package file viewer, the S3 object preview, the stack event
+		// timeline and the queue message and subscription viewers
+		if m.core.CurrentView == constants.ViewPackageFile || m.core.CurrentView == constants.ViewS3Preview ||
+			m.core.CurrentView == constants.ViewStackEvents || m.core.CurrentView == constants.ViewQueueMessage ||
+			m.core.CurrentView == constants.ViewSubscription {
+			switch msg.String() {
+			case constants.KeyQ, constants.KeyCtrlC:
+				return m, tea.Quit
+			case constants.KeyEsc, constants.KeyAltBack:
+				// Navigate back to the browser, keeping its cursor
+				return Model{core: update.NavigateBack(m.core)}, nil
+			case constants.KeyGotoTop:
+				newModel := m.Clone()
+				newModel.core.Viewport.GotoTop()
+				return newModel, nil
+			case constants.KeyGotoBottom:
+				newModel := m.Clone()
+				newModel.core.Viewport.GotoBottom()
 				return newModel, nil
 			default:
 				// Pass ALL other keys to the viewport
@@ -248,6 +515,44 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Special handling for the Logs Insights query editor, unless an error is shown
+		if m.core.CurrentView == constants.ViewLogsQuery && m.core.Err == nil {
+			modelWrapper, cmd := update.HandleLogsQueryKey(m.core, msg)
+			if wrapper, ok := modelWrapper.(update.ModelWrapper); ok {
+				newModel := Model{core: wrapper.Model}
+				if newModel.core.IsLoading {
+					return newModel, tea.Batch(cmd, newModel.core.Spinner.Tick)
+				}
+				return newModel, cmd
+			}
+			return modelWrapper, cmd
+		}
+
+		// Special handling for the editor of a new parameter version, unless an error is shown
+		if m.core.CurrentView == constants.ViewParameterEdit && m.core.Err == nil {
+			modelWrapper, cmd := update.HandleParameterEditKey(m.core, msg)
+			if wrapper, ok := modelWrapper.(update.ModelWrapper); ok {
+				return Model{core: wrapper.Model}, cmd
+			}
+			return modelWrapper, cmd
+		}
+
+		// Special handling for the editors of test messages, unless an error is shown
+		if m.core.CurrentView == constants.ViewQueueSend && m.core.Err == nil {
+			modelWrapper, cmd := update.HandleQueueSendKey(m.core, msg)
+			if wrapper, ok := modelWrapper.(update.ModelWrapper); ok {
+				return Model{core: wrapper.Model}, cmd
+			}
+			return modelWrapper, cmd
+		}
+		if m.core.CurrentView == constants.ViewTopicPublish && m.core.Err == nil {
+			modelWrapper, cmd := update.HandleTopicPublishKey(m.core, msg)
+			if wrapper, ok := modelWrapper.(update.ModelWrapper); ok {
+				return Model{core: wrapper.Model}, cmd
+			}
+			return modelWrapper, cmd
+		}
+
 		// Special handling for Lambda execution view
 		if m.core.CurrentView == constants.ViewLambdaExecute {
 			// Handle quit and back navigation
@@ -293,6 +598,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				newModel.core.TextArea, cmd = newModel.core.TextArea.Update(msg)
 				newModel.core.LambdaPayload = newModel.core.TextArea.Value()
 				return newModel, cmd
+			case constants.KeyInvokeMode:
+				// In command mode, cycle the invoke mode
+				if !m.core.IsLambdaInputMode {
+					modelWrapper, cmd := update.HandleLambdaInvokeMode(m.core)
+					if wrapper, ok := modelWrapper.(update.ModelWrapper); ok {
+						return Model{core: wrapper.Model}, cmd
+					}
+					return modelWrapper, cmd
+				}
+				newModel := m.Clone()
+				var cmd tea.Cmd
+				newModel.core.TextArea, cmd = newModel.core.TextArea.Update(msg)
+				newModel.core.LambdaPayload = newModel.core.TextArea.Value()
+				return newModel, cmd
+			case constants.KeyBenchmark:
+				// In command mode, open the benchmark options for the payload
+				if !m.core.IsLambdaInputMode {
+					modelWrapper, cmd := update.HandleLambdaBench(m.core)
+					if wrapper, ok := modelWrapper.(update.ModelWrapper); ok {
+						return Model{core: wrapper.Model}, cmd
+					}
+					return modelWrapper, cmd
+				}
+				newModel := m.Clone()
+				var cmd tea.Cmd
+				newModel.core.TextArea, cmd = newModel.core.TextArea.Update(msg)
+				newModel.core.LambdaPayload = newModel.core.TextArea.Value()
+				return newModel, cmd
 			case "i":
 				// Enter input mode if not already in it
 				if !m.core.IsLambdaInputMode {
@@ -419,12 +752,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Exit search mode
 				newModel := m.Clone()
 				newModel.core = update.DeactivateSearch(newModel.core)
-				return newModel, nil
+				return newModel, tea.Batch(update.LoadFunctionMetrics(newModel.core), update.LoadFunctionDescriptions(newModel.core))
 			case constants.KeyEnter:
 				// Confirm search and exit search mode
 				newModel := m.Clone()
 				newModel.core.Search.IsActive = false
-				return newModel, nil
+				return newModel, tea.Batch(update.LoadFunctionMetrics(newModel.core), update.LoadFunctionDescriptions(newModel.core))
 			case constants.KeyBackspace:
 				// Handle backspace in search query
 				if len(m.core.Search.Query) > 0 {
@@ -447,6 +780,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Special handling for the secret details: the value is revealed with its own key, and hidden
+		// before quitting so that it isn't left on the terminal
+		if m.core.CurrentView == constants.ViewSecretDetails {
+			switch msg.String() {
+			case constants.KeyQ, constants.KeyCtrlC:
+				return Model{core: update.HideSecretValue(m.core)}, tea.Quit
+			case constants.KeyRevealSecret:
+				if m.core.Err == nil {
+					modelWrapper, cmd := update.HandleSecretRevealKey(m.core)
+					if wrapper, ok := modelWrapper.(update.ModelWrapper); ok {
+						newModel := Model{core: wrapper.Model}
+						if newModel.core.IsLoading {
+							return newModel, tea.Batch(cmd, newModel.core.Spinner.Tick)
+						}
+						return newModel, cmd
+					}
+					return modelWrapper, cmd
+				}
+			}
+		}
+
 		// Handle key presses when not loading
 		switch msg.String() {
 		case constants.KeyCtrlC, constants.KeyQ:
@@ -613,6 +967,129 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return newModel, nil
 			}
 			return m, nil
+		// Add metrics key handlers
+		case constants.KeyToggleMetrics, constants.KeyMetricsWindow:
+			// If in text input mode, pass the key to the text input
+			if m.core.ManualInput {
+				newModel := m.Clone()
+				var cmd tea.Cmd
+				newModel.core.TextInput, cmd = newModel.core.TextInput.Update(msg)
+				return newModel, cmd
+			}
+			modelWrapper, cmd := update.HandleFunctionMetricsKey(m.core, msg.String())
+			if wrapper, ok := modelWrapper.(update.ModelWrapper); ok {
+				return Model{core: wrapper.Model}, cmd
+			}
+			return modelWrapper, cmd
+		// Add package browsing key handler
+		case constants.KeyBrowsePackage:
+			// If in text input mode, pass the key to the text input
+			if m.core.ManualInput {
+				newModel := m.Clone()
+				var cmd tea.Cmd
+				newModel.core.TextInput, cmd = newModel.core.TextInput.Update(msg)
+				return newModel, cmd
+			}
+			modelWrapper, cmd := update.HandlePackageDownload(m.core)
+			if wrapper, ok := modelWrapper.(update.ModelWrapper); ok {
+				newModel := Model{core: wrapper.Model}
+				if newModel.core.IsLoading {
+					return newModel, tea.Batch(cmd, newModel.core.Spinner.Tick)
+				}
+				return newModel, cmd
+			}
+			return modelWrapper, cmd
+		// Add security review key handler
+		case constants.KeySecurity:
+			// If in text input mode, pass the key to the text input
+			if m.core.ManualInput {
+				newModel := m.Clone()
+				var cmd tea.Cmd
+				newModel.core.TextInput, cmd = newModel.core.TextInput.Update(msg)
+				return newModel, cmd
+			}
+			modelWrapper, cmd := update.HandleSecurityLoad(m.core)
+			if wrapper, ok := modelWrapper.(update.ModelWrapper); ok {
+				newModel := Model{core: wrapper.Model}
+				if newModel.core.IsLoading {
+					return newModel, tea.Batch(cmd, newModel.core.Spinner.Tick)
+				}
+				return newModel, cmd
+			}
+			return modelWrapper, cmd
+		// Add compare key handler
+		case constants.KeyCompare:
+			// If in text input mode, pass the key to the text input
+			if m.core.ManualInput {
+				newModel := m.Clone()
+				var cmd tea.Cmd
+				newModel.core.TextInput, cmd = newModel.core.TextInput.Update(msg)
+				return newModel, cmd
+			}
+			modelWrapper, cmd := update.HandleCompareOptions(m.core)
+			if wrapper, ok := modelWrapper.(update.ModelWrapper); ok {
+				return Model{core: wrapper.Model}, cmd
+			}
+			return modelWrapper, cmd
+		// Add async invocations key handler
+		case constants.KeyAsyncInvoke:
+			// If in text input mode, pass the key to the text input
+			if m.core.ManualInput {
+				newModel := m.Clone()
+				var cmd tea.Cmd
+				newModel.core.TextInput, cmd = newModel.core.TextInput.Update(msg)
+				return newModel, cmd
+			}
+			modelWrapper, cmd := update.HandleAsyncConfigLoad(m.core)
+			if wrapper, ok := modelWrapper.(update.ModelWrapper); ok {
+				newModel := Model{core: wrapper.Model}
+				if newModel.core.IsLoading {
+					return newModel, tea.Batch(cmd, newModel.core.Spinner.Tick)
+				}
+				return newModel, cmd
+			}
+			return modelWrapper, cmd
+		// Add export key handler
+		case constants.KeyExport:
+			// If in text input mode, pass the key to the text input
+			if m.core.ManualInput {
+				newModel := m.Clone()
+				var cmd tea.Cmd
+				newModel.core.TextInput, cmd = newModel.core.TextInput.Update(msg)
+				return newModel, cmd
+			}
+			modelWrapper, cmd := update.HandleExportKey(m.core)
+			if wrapper, ok := modelWrapper.(update.ModelWrapper); ok {
+				return Model{core: wrapper.Model}, cmd
+			}
+			return modelWrapper, cmd
+		// Add S3 transfer key handlers
+		case constants.KeyUpload, constants.KeyPresign, constants.KeyPreview:
+			// If in text input mode, pass the key to the text input
+			if m.core.ManualInput {
+				newModel := m.Clone()
+				var cmd tea.Cmd
+				newModel.core.TextInput, cmd = newModel.core.TextInput.Update(msg)
+				return newModel, cmd
+			}
+			var modelWrapper tea.Model
+			var cmd tea.Cmd
+			switch msg.String() {
+			case constants.KeyUpload:
+				modelWrapper, cmd = update.HandleS3UploadKey(m.core)
+			case constants.KeyPresign:
+				modelWrapper, cmd = update.HandleS3PresignKey(m.core)
+			default:
+				modelWrapper, cmd = update.HandleS3PreviewKey(m.core)
+			}
+			if wrapper, ok := modelWrapper.(update.ModelWrapper); ok {
+				newModel := Model{core: wrapper.Model}
+				if newModel.core.IsLoading {
+					return newModel, tea.Batch(cmd, newModel.core.Spinner.Tick)
+				}
+				return newModel, cmd
+			}
+			return modelWrapper, cmd
 		// Add pagination key handlers
 		case constants.KeyPreviousPage, constants.KeyNextPage, constants.KeyArrowPreviousPage, constants.KeyArrowNextPage:
 			// If in text input mode, pass the key to the text input
@@ -622,6 +1099,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				newModel.core.TextInput, cmd = newModel.core.TextInput.Update(msg)
 				return newModel, cmd
 			}
+			// S3 listings are paged with continuation tokens, one page at a time
+			if m.core.CurrentView == constants.ViewS3Objects {
+				keyString := msg.String()
+				if keyString == constants.KeyArrowPreviousPage {
+					keyString = constants.KeyPreviousPage
+				} else if keyString == constants.KeyArrowNextPage {
+					keyString = constants.KeyNextPage
+				}
+				modelWrapper, cmd := update.HandleS3PageKey(m.core, keyString)
+				if wrapper, ok := modelWrapper.(update.ModelWrapper); ok {
+					newModel := Model{core: wrapper.Model}
+					if newModel.core.IsLoading {
+						return newModel, tea.Batch(cmd, newModel.core.Spinner.Tick)
+					}
+					return newModel, cmd
+				}
+				return modelWrapper, cmd
+			}
 			// Handle pagination key presses if in a paginated view
 			if view.IsPaginatedView(m.core.CurrentView) {
 				// Map arrow keys to their vim-style equivalents for the handler
@@ -716,7 +1211,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case model.FunctionsPageMsg:
 		newModel := m.Clone()
 		newModel.core = update.HandleFunctionStatusPagination(newModel.core, msg)
-		return newModel, nil
+		return newModel, tea.Batch(update.LoadFunctionMetrics(newModel.core), update.LoadFunctionDescriptions(newModel.core))
 	case model.PipelinesPageMsg:
 		newModel := m.Clone()
 		newModel.core = update.HandlePipelineStatusPagination(newModel.core, msg)
@@ -727,7 +1222,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return newModel, nil
 	case tea.MouseMsg:
 		// If we're in the Lambda response view, pass mouse events to the viewport
-		if m.core.CurrentView == constants.ViewLambdaResponse {
+		if m.core.CurrentView == constants.ViewLambdaResponse || m.core.CurrentView == constants.ViewPackageFile ||
+			m.core.CurrentView == constants.ViewS3Preview || m.core.CurrentView == constants.ViewStackEvents ||
+			m.core.CurrentView == constants.ViewQueueMessage || m.core.CurrentView == constants.ViewSubscription {
 			newModel := m.Clone()
 			var cmd tea.Cmd
 			newModel.core.Viewport, cmd = newModel.core.Viewport.Update(msg)
@@ -3,6 +3,7 @@ package ui
 import (
 	"testing"
 
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
 	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
 	"github.com/HenryOwenz/cloudgate/internal/ui/model"
 	"github.com/HenryOwenz/cloudgate/internal/ui/view"
@@ -333,3 +334,24 @@ func createMockItems(count int) []interface{} {
 	}
 	return items
 }
+
+// TestQuitWhileLoadingHidesSecret tests that quitting while loading hides a revealed secret value
+func TestQuitWhileLoadingHidesSecret(t *testing.T) {
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune(constants.KeyQ)},
+		{Type: tea.KeyCtrlC},
+	} {
+		core := model.New()
+		core.CurrentView = constants.ViewSecretDetails
+		core.SecretValue = &cloud.SecretValue{Value: "hunter2"}
+		core.IsLoading = true
+
+		result, cmd := Model{core: core}.Update(key)
+		if cmd == nil {
+			t.Fatalf("Expected %s to quit", key)
+		}
+		if result.(Model).core.SecretValue != nil {
+			t.Errorf("Expected %s to hide the secret value before quitting", key)
+		}
+	}
+}
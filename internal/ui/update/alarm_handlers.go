@@ -0,0 +1,257 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleAlarmsLoad lists every alarm of the account for the alarm dashboard
+func HandleAlarmsLoad(m *model.Model) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingAlarms
+
+	return WrapModel(newModel), func() tea.Msg {
+		alarmOperation, err := getAlarmOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		alarms, err := alarmOperation.ListAlarms(context.Background(), "")
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.AlarmsMsg{Alarms: alarms}
+	}
+}
+
+// HandleAlarms shows the alarms grouped by state, those in alarm first
+func HandleAlarms(m *model.Model, msg model.AlarmsMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.Alarms = msg.Alarms
+	newModel.SelectedAlarm = nil
+	newModel.AlarmHistory = nil
+	// Alarms cross-linked before may have changed state since
+	newModel.LinkedAlarms = nil
+	newModel.CurrentView = constants.ViewAlarms
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleAlarmSelection shows the details of the selected alarm
+func HandleAlarmSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	// Rows are in the order of the alarms
+	cursor := m.Table.Cursor()
+	if len(m.Table.Rows()) == 0 || cursor < 0 || cursor >= len(m.Alarms) {
+		return WrapModel(m), nil
+	}
+	return showAlarmDetails(m, m.Alarms[cursor], constants.ViewAlarms)
+}
+
+// HandleLinkedAlarmSelection shows the details of an alarm selected in the function or pipeline
+// details it's cross-linked into; other rows do nothing
+func HandleLinkedAlarmSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 || !strings.HasPrefix(selected[0], constants.AlarmRowPrefix) {
+		return WrapModel(m), nil
+	}
+
+	name := strings.TrimPrefix(selected[0], constants.AlarmRowPrefix)
+	for _, alarm := range m.LinkedAlarms[view.LinkedAlarmsName(m)] {
+		if alarm.Name == name {
+			return showAlarmDetails(m, alarm, m.CurrentView)
+		}
+	}
+	return WrapModel(m), nil
+}
+
+// HandleAlarmDetailsSelection handles the selection of a row in the details view; only the
+// mute, unmute and history action rows do anything
+func HandleAlarmDetailsSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 {
+		return WrapModel(m), nil
+	}
+
+	switch selected[0] {
+	case constants.ActionMuteAlarm, constants.ActionUnmuteAlarm:
+		return HandleAlarmActions(m, selected[0] == constants.ActionUnmuteAlarm)
+	case constants.ActionAlarmHistory:
+		return HandleAlarmHistoryLoad(m)
+	default:
+		return WrapModel(m), nil
+	}
+}
+
+// HandleAlarmActions asks for confirmation before disabling the actions of the selected alarm to
+// mute it, or enabling them again
+func HandleAlarmActions(m *model.Model, enabled bool) (tea.Model, tea.Cmd) {
+	if m.SelectedAlarm == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoAlarm)}
+		}
+	}
+
+	alarmOperation, err := getAlarmOperation(m)
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	alarm := *m.SelectedAlarm
+	action, loadingMsg, successMsg := constants.ActionMuteAlarm, constants.MsgMutingAlarm, constants.MsgAlarmMutedSuccess
+	if enabled {
+		action, loadingMsg, successMsg = constants.ActionUnmuteAlarm, constants.MsgUnmutingAlarm, constants.MsgAlarmUnmutedSuccess
+	}
+
+	newModel := m.Clone()
+	newModel.PendingAction = &model.PendingAction{
+		Description: fmt.Sprintf("%s %s", action, alarm.Name),
+		Details: []string{
+			fmt.Sprintf("Alarm: %s", alarm.Name),
+			fmt.Sprintf("State: %s", alarm.State),
+			fmt.Sprintf("Actions: %s", view.AlarmActionsLabel(alarm.ActionsEnabled)),
+		},
+		LoadingMsg: loadingMsg,
+		BackView:   constants.ViewAlarmDetails,
+		Run: func(ctx context.Context) (string, error) {
+			if err := alarmOperation.SetAlarmActions(ctx, alarm.Name, enabled); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf(successMsg, alarm.Name), nil
+		},
+	}
+	newModel.CurrentView = constants.ViewExecutingAction
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleAlarmHistoryLoad loads the history of the selected alarm
+func HandleAlarmHistoryLoad(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedAlarm == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoAlarm)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingAlarmHistory
+	name := m.SelectedAlarm.Name
+
+	return WrapModel(newModel), func() tea.Msg {
+		alarmOperation, err := getAlarmOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		history, err := alarmOperation.GetAlarmHistory(context.Background(), name)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.AlarmHistoryMsg{History: history}
+	}
+}
+
+// HandleAlarmHistory shows the history of the selected alarm
+func HandleAlarmHistory(m *model.Model, msg model.AlarmHistoryMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.AlarmHistory = msg.History
+	newModel.CurrentView = constants.ViewAlarmHistory
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// LoadLinkedAlarms returns a command listing the alarms that share a prefix with the function or
+// pipeline shown in the current view. It returns nil if there is nothing to list, or if they were
+// listed already.
+func LoadLinkedAlarms(m *model.Model) tea.Cmd {
+	name := view.LinkedAlarmsName(m)
+	if name == "" {
+		return nil
+	}
+	if _, requested := m.LinkedAlarms[name]; requested {
+		return nil
+	}
+
+	if m.LinkedAlarms == nil {
+		m.LinkedAlarms = make(map[string][]cloud.Alarm)
+	}
+	m.LinkedAlarms[name] = nil
+
+	registry := m.Registry
+	providerName := m.ProviderState.ProviderName
+
+	return func() tea.Msg {
+		msg := model.LinkedAlarmsMsg{Name: name}
+
+		provider, err := registry.Get(providerName)
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+
+		alarmOperation, err := provider.GetAlarmOperation()
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+
+		msg.Alarms, msg.Err = alarmOperation.ListAlarms(context.Background(), name)
+		return msg
+	}
+}
+
+// HandleLinkedAlarms stores the alarms listed for a function or pipeline and refreshes its
+// details if they're still shown
+func HandleLinkedAlarms(m *model.Model, msg model.LinkedAlarmsMsg) *model.Model {
+	newModel := m.Clone()
+	if newModel.LinkedAlarms == nil {
+		newModel.LinkedAlarms = make(map[string][]cloud.Alarm)
+	}
+
+	if msg.Err != nil {
+		// Forget the failed request so it's retried the next time the details are shown
+		delete(newModel.LinkedAlarms, msg.Name)
+		newModel.Err = msg.Err
+		return newModel
+	}
+
+	newModel.LinkedAlarms[msg.Name] = msg.Alarms
+	if view.LinkedAlarmsName(newModel) == msg.Name {
+		refreshTable(newModel)
+	}
+	return newModel
+}
+
+// showAlarmDetails shows the details of an alarm, returning to backView when navigating back
+func showAlarmDetails(m *model.Model, alarm cloud.Alarm, backView constants.View) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.SelectedAlarm = &alarm
+	newModel.AlarmBackView = backView
+	newModel.AlarmHistory = nil
+	newModel.CurrentView = constants.ViewAlarmDetails
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// getAlarmOperation gets the CloudWatch alarm operation from the selected provider
+func getAlarmOperation(m *model.Model) (cloud.AlarmOperation, error) {
+	provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetAlarmOperation()
+}
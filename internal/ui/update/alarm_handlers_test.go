@@ -0,0 +1,181 @@
+package update
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// alarmTestOperation lists an alarm of a function and one of a pipeline, and records the prefixes
+// listed by and the actions changed
+type alarmTestOperation struct {
+	cloud.AlarmOperation
+	prefixes []string
+	muted    map[string]bool
+}
+
+func (o *alarmTestOperation) ListAlarms(ctx context.Context, prefix string) ([]cloud.Alarm, error) {
+	o.prefixes = append(o.prefixes, prefix)
+	alarms := []cloud.Alarm{
+		{Name: "checkout-errors", State: cloud.AlarmStateAlarm, StateReason: "Threshold crossed", ActionsEnabled: !o.muted["checkout-errors"]},
+		{Name: "deploy-failures", State: cloud.AlarmStateOK, ActionsEnabled: !o.muted["deploy-failures"]},
+	}
+	var matching []cloud.Alarm
+	for _, alarm := range alarms {
+		if strings.HasPrefix(alarm.Name, prefix) {
+			matching = append(matching, alarm)
+		}
+	}
+	return matching, nil
+}
+
+func (o *alarmTestOperation) SetAlarmActions(ctx context.Context, name string, enabled bool) error {
+	if o.muted == nil {
+		o.muted = make(map[string]bool)
+	}
+	o.muted[name] = !enabled
+	return nil
+}
+
+func (o *alarmTestOperation) GetAlarmHistory(ctx context.Context, name string) ([]cloud.AlarmHistoryItem, error) {
+	return []cloud.AlarmHistoryItem{
+		{Type: "StateUpdate", Summary: "Alarm updated from OK to ALARM"},
+		{Type: "ConfigurationUpdate", Summary: "Alarm created"},
+	}, nil
+}
+
+// newAlarmTestModel returns a model showing the alarm dashboard
+func newAlarmTestModel(t *testing.T, operation *alarmTestOperation) *model.Model {
+	t.Helper()
+	m := newTestModel(&testProvider{alarms: operation})
+	result, cmd := HandleAlarmsLoad(m)
+	return HandleAlarms(result.(ModelWrapper).Model, cmd().(model.AlarmsMsg))
+}
+
+// selectAlarmRow moves the cursor to the row starting with a value and selects it
+func selectAlarmRow(t *testing.T, m *model.Model, value string) (*model.Model, tea.Cmd) {
+	t.Helper()
+	for i, row := range m.Table.Rows() {
+		if row[0] == value {
+			m.Table.SetCursor(i)
+			result, cmd := HandleTableSelect(m)
+			return result.(ModelWrapper).Model, cmd
+		}
+	}
+	t.Fatalf("Expected a row %q, got %v", value, m.Table.Rows())
+	return m, nil
+}
+
+func TestAlarmMuteAndHistory(t *testing.T) {
+	operation := &alarmTestOperation{}
+	m := newAlarmTestModel(t, operation)
+	if m.CurrentView != constants.ViewAlarms || len(m.Table.Rows()) != 2 {
+		t.Fatalf("Expected both alarms, got view %v with %d rows", m.CurrentView, len(m.Table.Rows()))
+	}
+	if operation.prefixes[0] != "" {
+		t.Errorf("Expected every alarm to be listed, got prefix %q", operation.prefixes[0])
+	}
+
+	m.Table.SetCursor(0)
+	result, _ := HandleTableSelect(m)
+	details := result.(ModelWrapper).Model
+	if details.CurrentView != constants.ViewAlarmDetails || details.SelectedAlarm.Name != "checkout-errors" {
+		t.Fatalf("Expected the details of checkout-errors, got view %v", details.CurrentView)
+	}
+
+	// The history is read from the details, and going back returns to them
+	history, cmd := selectAlarmRow(t, details, constants.ActionAlarmHistory)
+	history = HandleAlarmHistory(history, cmd().(model.AlarmHistoryMsg))
+	if history.CurrentView != constants.ViewAlarmHistory || len(history.Table.Rows()) != 2 {
+		t.Fatalf("Expected two history items, got view %v with %d rows", history.CurrentView, len(history.Table.Rows()))
+	}
+	if back := NavigateBack(history); back.CurrentView != constants.ViewAlarmDetails || back.SelectedAlarm == nil {
+		t.Errorf("Expected to go back to the details, got view %v", back.CurrentView)
+	}
+
+	// Muting waits for confirmation
+	pending, _ := selectAlarmRow(t, details, constants.ActionMuteAlarm)
+	if pending.CurrentView != constants.ViewExecutingAction || pending.PendingAction == nil {
+		t.Fatalf("Expected muting to wait for confirmation, got view %v", pending.CurrentView)
+	}
+	if operation.muted["checkout-errors"] {
+		t.Fatalf("Expected nothing to change before confirming")
+	}
+	pending.Table.SetCursor(0)
+	result, cmd = HandleExecutionSelection(pending)
+	done := HandleActionResult(result.(ModelWrapper).Model, cmd().(model.ActionResultMsg))
+	if !operation.muted["checkout-errors"] || done.Success != "Disabled the actions of alarm checkout-errors" {
+		t.Errorf("Expected the alarm to be muted, got %q", done.Success)
+	}
+
+	// Muted alarms can be unmuted
+	m = newAlarmTestModel(t, operation)
+	if state := m.Table.Rows()[0][0]; state != "ALARM (muted)" {
+		t.Errorf("Expected the alarm to be marked as muted, got %q", state)
+	}
+	result, _ = HandleTableSelect(m)
+	pending, _ = selectAlarmRow(t, result.(ModelWrapper).Model, constants.ActionUnmuteAlarm)
+	if pending.PendingAction == nil || pending.PendingAction.Description != "Unmute Alarm checkout-errors" {
+		t.Errorf("Expected unmuting to wait for confirmation, got view %v", pending.CurrentView)
+	}
+}
+
+func TestLinkedAlarms(t *testing.T) {
+	testCases := []struct {
+		name  string
+		view  constants.View
+		setup func(m *model.Model)
+		alarm string
+	}{
+		{
+			name:  "Function details",
+			view:  constants.ViewFunctionDetails,
+			setup: func(m *model.Model) { m.SetSelectedFunction(&model.FunctionStatus{Name: "checkout"}) },
+			alarm: "checkout-errors",
+		},
+		{
+			name: "Pipeline stages",
+			view: constants.ViewPipelineStages,
+			setup: func(m *model.Model) {
+				m.SelectedPipeline = &model.PipelineStatus{Name: "deploy", Stages: []cloud.StageStatus{{Name: "Source"}}}
+			},
+			alarm: "deploy-failures",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			operation := &alarmTestOperation{}
+			m := newTestModel(&testProvider{alarms: operation})
+			m.CurrentView = tc.view
+			tc.setup(m)
+
+			cmd := LoadLinkedAlarms(m)
+			if cmd == nil {
+				t.Fatalf("Expected the linked alarms to be listed")
+			}
+			if LoadLinkedAlarms(m) != nil {
+				t.Errorf("Expected the alarms to be listed once")
+			}
+			m = HandleLinkedAlarms(m, cmd().(model.LinkedAlarmsMsg))
+			if operation.prefixes[0] != view.LinkedAlarmsName(m) {
+				t.Errorf("Expected the alarms to be listed by prefix, got %q", operation.prefixes[0])
+			}
+
+			// Selecting the alarm row opens it, and going back returns to the details it's linked into
+			details, _ := selectAlarmRow(t, m, constants.AlarmRowPrefix+tc.alarm)
+			if details.CurrentView != constants.ViewAlarmDetails || details.SelectedAlarm.Name != tc.alarm {
+				t.Fatalf("Expected the details of %s, got view %v", tc.alarm, details.CurrentView)
+			}
+			if back := NavigateBack(details); back.CurrentView != tc.view {
+				t.Errorf("Expected to go back to view %v, got %v", tc.view, back.CurrentView)
+			}
+		})
+	}
+}
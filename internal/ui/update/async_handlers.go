@@ -0,0 +1,153 @@
+package update
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleAsyncConfigLoad loads the asynchronous invocation config of the function in the details view
+func HandleAsyncConfigLoad(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.CurrentView != constants.ViewFunctionDetails {
+		return WrapModel(m), nil
+	}
+	if m.SelectedFunction == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoFunction)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingAsyncConfig
+	functionName := m.SelectedFunction.Name
+
+	return WrapModel(newModel), func() tea.Msg {
+		// Get the provider
+		provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the LambdaAsyncOperation from the provider
+		asyncOperation, err := provider.GetLambdaAsyncOperation()
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		config, err := asyncOperation.GetAsyncInvokeConfig(context.Background(), functionName)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.AsyncInvokeConfigMsg{Config: config}
+	}
+}
+
+// HandleAsyncInvokeConfig shows the retry settings and destinations of a function
+func HandleAsyncInvokeConfig(m *model.Model, msg model.AsyncInvokeConfigMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.AsyncConfig = msg.Config
+	newModel.FailedEvents = nil
+	newModel.FailedEventQueue = ""
+	newModel.CurrentView = constants.ViewAsyncConfig
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleAsyncConfigSelection peeks at the failed events of the selected queue
+func HandleAsyncConfigSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) < 2 || selected[0] != constants.ActionPeekFailedEvents {
+		return WrapModel(m), nil
+	}
+	queueArn := selected[1]
+
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgPeekingEvents
+
+	return WrapModel(newModel), func() tea.Msg {
+		// Get the provider
+		provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the LambdaAsyncOperation from the provider
+		asyncOperation, err := provider.GetLambdaAsyncOperation()
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		events, err := asyncOperation.PeekFailedEvents(context.Background(), queueArn, constants.MaxFailedEvents)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.FailedEventsMsg{QueueArn: queueArn, Events: events}
+	}
+}
+
+// HandleFailedEvents shows the failed events peeked from a queue
+func HandleFailedEvents(m *model.Model, msg model.FailedEventsMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.FailedEventQueue = msg.QueueArn
+	newModel.FailedEvents = msg.Events
+	newModel.CurrentView = constants.ViewFailedEvents
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleFailedEventSelection asks for confirmation before re-invoking the function with the
+// payload of the selected failed event
+func HandleFailedEventSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	index := m.Table.Cursor()
+	if m.AsyncConfig == nil || index < 0 || index >= len(m.FailedEvents) {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoEvent)}
+		}
+	}
+
+	// Get the provider
+	provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	// Get the LambdaAsyncOperation from the provider
+	asyncOperation, err := provider.GetLambdaAsyncOperation()
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	event := m.FailedEvents[index]
+	functionName := m.AsyncConfig.FunctionName
+
+	newModel := m.Clone()
+	newModel.PendingAction = &model.PendingAction{
+		Description: fmt.Sprintf("Re-invoke %s asynchronously with failed event %s", functionName, event.MessageID),
+		Details:     view.FailedEventSummary(event),
+		LoadingMsg:  constants.MsgRedrivingEvent,
+		BackView:    constants.ViewFailedEvents,
+		Run: func(ctx context.Context) (string, error) {
+			if err := asyncOperation.RedriveEvent(ctx, functionName, event); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf(constants.MsgRedriveSuccess, functionName, event.MessageID), nil
+		},
+	}
+	newModel.CurrentView = constants.ViewExecutingAction
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
@@ -0,0 +1,133 @@
+package update
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// asyncTestOperation returns a fixed config and failed events, and records the replayed event
+type asyncTestOperation struct {
+	cloud.LambdaAsyncOperation
+	config     *cloud.AsyncInvokeConfig
+	events     []cloud.FailedEvent
+	peekedFrom string
+	redriven   *cloud.FailedEvent
+}
+
+func (o *asyncTestOperation) GetAsyncInvokeConfig(ctx context.Context, functionName string) (*cloud.AsyncInvokeConfig, error) {
+	return o.config, nil
+}
+
+func (o *asyncTestOperation) PeekFailedEvents(ctx context.Context, queueArn string, limit int) ([]cloud.FailedEvent, error) {
+	o.peekedFrom = queueArn
+	return o.events, nil
+}
+
+func (o *asyncTestOperation) RedriveEvent(ctx context.Context, functionName string, event cloud.FailedEvent) error {
+	o.redriven = &event
+	return nil
+}
+
+func TestAsyncRedrive(t *testing.T) {
+	failureQueue := "arn:aws:sqs:us-east-1:123456789012:orders-failures"
+	operation := &asyncTestOperation{
+		config: &cloud.AsyncInvokeConfig{
+			FunctionName:     "orders",
+			MaxRetryAttempts: 1,
+			MaxEventAge:      3600,
+			OnSuccess:        "arn:aws:events:us-east-1:123456789012:event-bus/default",
+			OnFailure:        failureQueue,
+			DeadLetterQueue:  "arn:aws:sns:us-east-1:123456789012:orders-dlq",
+		},
+		events: []cloud.FailedEvent{
+			{MessageID: "m-1", QueueArn: failureQueue, Condition: "RetriesExhausted", ErrorMessage: "boom", Payload: `{"orderId": "1"}`},
+			{MessageID: "m-2", QueueArn: failureQueue, Condition: "EventAgeExceeded", Payload: "{\n  \"orderId\": \"2\"\n}"},
+		},
+	}
+	m := newTestModel(&testProvider{lambdaAsync: operation})
+	m.CurrentView = constants.ViewFunctionDetails
+	m.SetSelectedFunction(&cloud.FunctionStatus{Name: "orders"})
+
+	result, cmd := HandleAsyncConfigLoad(m)
+	if !result.(ModelWrapper).Model.IsLoading {
+		t.Errorf("Expected the model to be loading")
+	}
+	configMsg, ok := cmd().(model.AsyncInvokeConfigMsg)
+	if !ok {
+		t.Fatalf("Expected an async invoke config message")
+	}
+	m = HandleAsyncInvokeConfig(result.(ModelWrapper).Model, configMsg)
+	if m.CurrentView != constants.ViewAsyncConfig {
+		t.Fatalf("Expected the async config, got view %v", m.CurrentView)
+	}
+
+	// Only the SQS failure destination can be peeked, not the SNS dead-letter topic
+	rows := m.Table.Rows()
+	if len(rows) != 6 {
+		t.Fatalf("Expected five settings and one queue, got %v", rows)
+	}
+	if rows[1][1] != "1h0m0s" {
+		t.Errorf("Expected the max event age as a duration, got %q", rows[1][1])
+	}
+	if rows[5][0] != constants.ActionPeekFailedEvents || rows[5][1] != failureQueue {
+		t.Fatalf("Expected a row to peek at the failure queue, got %v", rows[5])
+	}
+
+	m.Table.SetCursor(5)
+	_, cmd = HandleAsyncConfigSelection(m)
+	eventsMsg, ok := cmd().(model.FailedEventsMsg)
+	if !ok {
+		t.Fatalf("Expected a failed events message")
+	}
+	if operation.peekedFrom != failureQueue {
+		t.Errorf("Expected the failure queue to be peeked, got %q", operation.peekedFrom)
+	}
+	m = HandleFailedEvents(m, eventsMsg)
+	if m.CurrentView != constants.ViewFailedEvents || len(m.Table.Rows()) != 2 {
+		t.Fatalf("Expected both failed events, got view %v with %v", m.CurrentView, m.Table.Rows())
+	}
+	if payload := m.Table.Rows()[1][3]; payload != `{"orderId":"2"}` {
+		t.Errorf("Expected the payload on a single line, got %q", payload)
+	}
+
+	// Replaying an event asks for confirmation first
+	m.Table.SetCursor(1)
+	result, _ = HandleFailedEventSelection(m)
+	m = result.(ModelWrapper).Model
+	if m.CurrentView != constants.ViewExecutingAction || m.PendingAction == nil {
+		t.Fatalf("Expected a pending action, got view %v", m.CurrentView)
+	}
+	if !strings.Contains(m.PendingAction.Description, "m-2") || m.PendingAction.BackView != constants.ViewFailedEvents {
+		t.Errorf("Expected the action to replay m-2, got %+v", m.PendingAction)
+	}
+	if operation.redriven != nil {
+		t.Fatalf("Expected nothing to be replayed before confirming")
+	}
+
+	message, err := m.PendingAction.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Expected the replay to succeed, got %v", err)
+	}
+	if operation.redriven == nil || operation.redriven.MessageID != "m-2" {
+		t.Errorf("Expected m-2 to be replayed, got %v", operation.redriven)
+	}
+	if !strings.Contains(message, "orders") {
+		t.Errorf("Expected the success message to name the function, got %q", message)
+	}
+
+	// Going back returns to the function one step at a time
+	m.CurrentView = constants.ViewFailedEvents
+	m = NavigateBack(m)
+	if m.CurrentView != constants.ViewAsyncConfig {
+		t.Errorf("Expected the async config, got %v", m.CurrentView)
+	}
+	m = NavigateBack(m)
+	if m.CurrentView != constants.ViewFunctionDetails {
+		t.Errorf("Expected the function details, got %v", m.CurrentView)
+	}
+}
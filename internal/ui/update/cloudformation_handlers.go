@@ -0,0 +1,404 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleStacksLoad lists the CloudFormation stacks of the account
+func HandleStacksLoad(m *model.Model) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingStacks
+
+	return WrapModel(newModel), func() tea.Msg {
+		stackOperation, err := getCloudFormationOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		stacks, err := stackOperation.ListStacks(context.Background())
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.StacksMsg{Stacks: stacks}
+	}
+}
+
+// HandleStacks shows the stacks with their status and drift
+func HandleStacks(m *model.Model, msg model.StacksMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.Stacks = msg.Stacks
+	newModel.SelectedStack = nil
+	newModel.CurrentView = constants.ViewStacks
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleStackSelection shows the outputs, parameters and actions of the selected stack
+func HandleStackSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	// Rows are in the order of the stacks
+	cursor := m.Table.Cursor()
+	if len(m.Table.Rows()) == 0 || cursor < 0 || cursor >= len(m.Stacks) {
+		return WrapModel(m), nil
+	}
+
+	stack := m.Stacks[cursor]
+	newModel := m.Clone()
+	newModel.SelectedStack = &stack
+	newModel.StackEvents = nil
+	newModel.StackDriftDetection = nil
+	newModel.StackDrifts = nil
+	newModel.ChangeSets = nil
+	newModel.CurrentView = constants.ViewStackDetails
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleStackDetailsSelection handles the selection of a row in the stack details; only the
+// action rows do anything
+func HandleStackDetailsSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 {
+		return WrapModel(m), nil
+	}
+
+	switch selected[0] {
+	case constants.ActionStackEvents:
+		return HandleStackEventsLoad(m)
+	case constants.ActionDetectDrift:
+		return HandleDetectDrift(m)
+	case constants.ActionChangeSets:
+		return HandleChangeSetsLoad(m)
+	default:
+		return WrapModel(m), nil
+	}
+}
+
+// HandleStackEventsLoad reads the latest events of the selected stack
+func HandleStackEventsLoad(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedStack == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoStack)}
+		}
+	}
+
+	stack := m.SelectedStack.Name
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingStackEvents
+
+	return WrapModel(newModel), func() tea.Msg {
+		stackOperation, err := getCloudFormationOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		events, err := stackOperation.GetStackEvents(context.Background(), stack)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.StackEventsMsg{Stack: stack, Events: events}
+	}
+}
+
+// HandleStackEvents shows the events of a stack as a timeline, scrolled to the first failure of
+// its latest operation
+func HandleStackEvents(m *model.Model, msg model.StackEventsMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.StackEvents = msg.Events
+
+	width := newModel.Width - constants.ViewportMarginX*2
+	content, failureLine := view.StackEventsContent(msg.Stack, msg.Events, width)
+	newModel.Viewport = viewport.New(width, constants.TableHeight)
+	newModel.Viewport.YPosition = constants.HeaderHeight // Position below the title
+	newModel.Viewport.SetContent(content)
+	if failureLine >= 0 {
+		newModel.Viewport.SetYOffset(failureLine)
+	}
+	newModel.CurrentView = constants.ViewStackEvents
+	return newModel
+}
+
+// HandleDetectDrift starts detecting the drift of the selected stack. The detection runs in the
+// background and is polled for results with PollStackDrift.
+func HandleDetectDrift(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedStack == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoStack)}
+		}
+	}
+
+	stack := m.SelectedStack.Name
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgStartingDrift
+	newModel.StackDriftID = ""
+
+	return WrapModel(newModel), func() tea.Msg {
+		stackOperation, err := getCloudFormationOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		detectionID, err := stackOperation.DetectStackDrift(context.Background(), stack)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.StackDriftStartedMsg{DetectionID: detectionID}
+	}
+}
+
+// HandleStackDriftStarted records the drift detection that's running, to poll it for results
+func HandleStackDriftStarted(m *model.Model, msg model.StackDriftStartedMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.StackDriftID = msg.DetectionID
+	newModel.LoadingMsg = constants.MsgDetectingDrift
+	return newModel
+}
+
+// PollStackDrift returns a command that checks a running drift detection after a moment, or nil
+// once the detection is no longer the one running
+func PollStackDrift(m *model.Model, detectionID string) tea.Cmd {
+	if !m.IsLoading || detectionID == "" || detectionID != m.StackDriftID || m.SelectedStack == nil {
+		return nil
+	}
+	check := checkStackDrift(m)
+	return tea.Tick(constants.DriftDetectionPollInterval, func(time.Time) tea.Msg {
+		return check()
+	})
+}
+
+// checkStackDrift returns a command that gets the state of the running drift detection, and the
+// drifted resources once it's done
+func checkStackDrift(m *model.Model) tea.Cmd {
+	detectionID, stack := m.StackDriftID, m.SelectedStack.Name
+	return func() tea.Msg {
+		stackOperation, err := getCloudFormationOperation(m)
+		if err != nil {
+			return model.StackDriftMsg{DetectionID: detectionID, Err: err}
+		}
+
+		detection, err := stackOperation.GetStackDriftDetection(context.Background(), detectionID)
+		if err != nil || !detection.Done() {
+			return model.StackDriftMsg{DetectionID: detectionID, Detection: detection, Err: err}
+		}
+
+		// A detection that failed for some resources still has results for the others
+		drifts, err := stackOperation.GetStackResourceDrifts(context.Background(), stack)
+		return model.StackDriftMsg{DetectionID: detectionID, Detection: detection, Drifts: drifts, Err: err}
+	}
+}
+
+// HandleStackDrift shows the resources that drifted once the running detection is done
+func HandleStackDrift(m *model.Model, msg model.StackDriftMsg) *model.Model {
+	// Ignore the results of a detection that's no longer running
+	if msg.DetectionID != m.StackDriftID {
+		return m
+	}
+
+	newModel := m.Clone()
+	if msg.Err != nil {
+		newModel.IsLoading = false
+		newModel.StackDriftID = ""
+		newModel.Err = msg.Err
+		return newModel
+	}
+	if !msg.Detection.Done() {
+		return newModel
+	}
+
+	newModel.IsLoading = false
+	newModel.StackDriftID = ""
+	newModel.StackDriftDetection = msg.Detection
+	newModel.StackDrifts = msg.Drifts
+
+	// Keep the stack's drift status in line with the detection
+	if newModel.SelectedStack != nil && msg.Detection.StackDriftStatus != "" {
+		stack := *newModel.SelectedStack
+		stack.DriftStatus = msg.Detection.StackDriftStatus
+		stack.DriftChecked = time.Now()
+		newModel.SelectedStack = &stack
+		newModel.Stacks = make([]cloud.Stack, len(m.Stacks))
+		copy(newModel.Stacks, m.Stacks)
+		for i := range newModel.Stacks {
+			if newModel.Stacks[i].ID == stack.ID {
+				newModel.Stacks[i] = stack
+			}
+		}
+	}
+
+	newModel.CurrentView = constants.ViewStackDrift
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleStackDriftSelection shows the property differences of the selected drifted resource
+func HandleStackDriftSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	// Rows are in the order of the drifts
+	cursor := m.Table.Cursor()
+	if len(m.Table.Rows()) == 0 || cursor < 0 || cursor >= len(m.StackDrifts) {
+		return WrapModel(m), nil
+	}
+
+	drift := m.StackDrifts[cursor]
+	newModel := m.Clone()
+	newModel.SelectedResourceDrift = &drift
+	newModel.CurrentView = constants.ViewResourceDrift
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleChangeSetsLoad lists the change sets of the selected stack
+func HandleChangeSetsLoad(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedStack == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoStack)}
+		}
+	}
+
+	stack := m.SelectedStack.Name
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingChangeSets
+
+	return WrapModel(newModel), func() tea.Msg {
+		stackOperation, err := getCloudFormationOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		changeSets, err := stackOperation.ListChangeSets(context.Background(), stack)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.ChangeSetsMsg{Stack: stack, ChangeSets: changeSets}
+	}
+}
+
+// HandleChangeSets shows the change sets of a stack
+func HandleChangeSets(m *model.Model, msg model.ChangeSetsMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.ChangeSets = msg.ChangeSets
+	newModel.SelectedChangeSet = nil
+	newModel.CurrentView = constants.ViewChangeSets
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleChangeSetSelection reads the resource changes of the selected change set
+func HandleChangeSetSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	// Rows are in the order of the change sets
+	cursor := m.Table.Cursor()
+	if len(m.Table.Rows()) == 0 || cursor < 0 || cursor >= len(m.ChangeSets) || m.SelectedStack == nil {
+		return WrapModel(m), nil
+	}
+
+	stack, name := m.SelectedStack.Name, m.ChangeSets[cursor].Name
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingChangeSet
+
+	return WrapModel(newModel), func() tea.Msg {
+		stackOperation, err := getCloudFormationOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		changeSet, err := stackOperation.GetChangeSet(context.Background(), stack, name)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.ChangeSetMsg{ChangeSet: changeSet}
+	}
+}
+
+// HandleChangeSet shows the resource changes of a change set, followed by the action executing it
+// when it can be
+func HandleChangeSet(m *model.Model, msg model.ChangeSetMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.SelectedChangeSet = msg.ChangeSet
+	newModel.CurrentView = constants.ViewChangeSetDetails
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleChangeSetDetailsSelection handles the selection of a row in the change set; only the
+// action row does anything
+func HandleChangeSetDetailsSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 || selected[0] != constants.ActionExecuteChangeSet {
+		return WrapModel(m), nil
+	}
+	return HandleExecuteChangeSet(m)
+}
+
+// HandleExecuteChangeSet asks for confirmation before updating the selected stack with the
+// selected change set
+func HandleExecuteChangeSet(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedStack == nil || m.SelectedChangeSet == nil || !m.SelectedChangeSet.Executable() {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoChangeSet)}
+		}
+	}
+
+	stackOperation, err := getCloudFormationOperation(m)
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	stack, changeSet := m.SelectedStack.Name, m.SelectedChangeSet.Name
+	details := []string{
+		fmt.Sprintf("Stack: %s", stack),
+		fmt.Sprintf("Change Set: %s", changeSet),
+		fmt.Sprintf("Changes: %s", view.ChangeSetSummary(m.SelectedChangeSet.Changes)),
+	}
+	if warning := view.ChangeSetReplacementWarning(m.SelectedChangeSet.Changes); warning != "" {
+		details = append(details, warning)
+	}
+
+	newModel := m.Clone()
+	newModel.PendingAction = &model.PendingAction{
+		Description: fmt.Sprintf("%s %s on %s", constants.ActionExecuteChangeSet, changeSet, stack),
+		Details:     details,
+		LoadingMsg:  constants.MsgExecutingChangeSet,
+		BackView:    constants.ViewChangeSetDetails,
+		Run: func(ctx context.Context) (string, error) {
+			if err := stackOperation.ExecuteChangeSet(ctx, stack, changeSet); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf(constants.MsgChangeSetSuccess, stack, changeSet), nil
+		},
+	}
+	newModel.CurrentView = constants.ViewExecutingAction
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// getCloudFormationOperation gets the CloudFormation operation from the selected provider
+func getCloudFormationOperation(m *model.Model) (cloud.CloudFormationOperation, error) {
+	provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetCloudFormationOperation()
+}
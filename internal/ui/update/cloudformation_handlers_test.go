@@ -0,0 +1,201 @@
+package update
+
+import (
+	"context"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// cloudFormationTestOperation lists a stack with a change set ready to execute and one that
+// failed, and records the change sets executed. Drift detections finish on their second check.
+type cloudFormationTestOperation struct {
+	cloud.CloudFormationOperation
+	checks   int
+	executed []string
+}
+
+func (o *cloudFormationTestOperation) ListStacks(ctx context.Context) ([]cloud.Stack, error) {
+	return []cloud.Stack{{
+		Name:        "api",
+		ID:          "arn:aws:cloudformation:us-east-1:123456789012:stack/api/1",
+		Status:      "UPDATE_COMPLETE",
+		DriftStatus: "NOT_CHECKED",
+		Outputs:     []cloud.StackOutput{{Key: "Url", Value: "https://api.example.com", ExportName: "api-url"}},
+	}}, nil
+}
+
+func (o *cloudFormationTestOperation) DetectStackDrift(ctx context.Context, stack string) (string, error) {
+	return "detection-1", nil
+}
+
+func (o *cloudFormationTestOperation) GetStackDriftDetection(ctx context.Context, id string) (*cloud.StackDriftDetection, error) {
+	o.checks++
+	if o.checks < 2 {
+		return &cloud.StackDriftDetection{ID: id, Status: cloud.DriftDetectionInProgress}, nil
+	}
+	return &cloud.StackDriftDetection{ID: id, Status: cloud.DriftDetectionComplete, StackDriftStatus: "DRIFTED", DriftedResources: 1}, nil
+}
+
+func (o *cloudFormationTestOperation) GetStackResourceDrifts(ctx context.Context, stack string) ([]cloud.StackResourceDrift, error) {
+	return []cloud.StackResourceDrift{{
+		LogicalID:    "Handler",
+		ResourceType: "AWS::Lambda::Function",
+		Status:       cloud.ResourceDriftModified,
+		Differences:  []cloud.PropertyDifference{{Path: "/Timeout", Type: "NOT_EQUAL", Expected: "30", Actual: "60"}},
+	}}, nil
+}
+
+func (o *cloudFormationTestOperation) ListChangeSets(ctx context.Context, stack string) ([]cloud.ChangeSet, error) {
+	return []cloud.ChangeSet{
+		{Name: "deploy-2", Status: "CREATE_COMPLETE", ExecutionStatus: cloud.ChangeSetExecutionAvailable},
+		{Name: "deploy-1", Status: "FAILED", ExecutionStatus: "UNAVAILABLE", StatusReason: "No updates are to be performed."},
+	}, nil
+}
+
+func (o *cloudFormationTestOperation) GetChangeSet(ctx context.Context, stack, name string) (*cloud.ChangeSet, error) {
+	if name != "deploy-2" {
+		return &cloud.ChangeSet{Name: name, Status: "FAILED", ExecutionStatus: "UNAVAILABLE"}, nil
+	}
+	return &cloud.ChangeSet{
+		Name:            name,
+		Status:          "CREATE_COMPLETE",
+		ExecutionStatus: cloud.ChangeSetExecutionAvailable,
+		Changes: []cloud.ResourceChange{
+			{Action: "Modify", LogicalID: "Handler", ResourceType: "AWS::Lambda::Function", Replacement: "False", Scope: []string{"Properties"}},
+			{Action: "Modify", LogicalID: "Table", ResourceType: "AWS::DynamoDB::Table", Replacement: "True", Scope: []string{"Properties"}},
+			{Action: "Add", LogicalID: "Queue", ResourceType: "AWS::SQS::Queue"},
+		},
+	}, nil
+}
+
+func (o *cloudFormationTestOperation) ExecuteChangeSet(ctx context.Context, stack, name string) error {
+	o.executed = append(o.executed, stack+"/"+name)
+	return nil
+}
+
+// newStackTestModel returns a model showing the details of the api stack
+func newStackTestModel(t *testing.T, operation *cloudFormationTestOperation) *model.Model {
+	t.Helper()
+	m := newTestModel(&testProvider{cloudFormation: operation})
+	result, cmd := HandleStacksLoad(m)
+	m = HandleStacks(result.(ModelWrapper).Model, cmd().(model.StacksMsg))
+
+	m, _ = selectStackRow(t, m, "api")
+	if m.CurrentView != constants.ViewStackDetails || m.SelectedStack == nil {
+		t.Fatalf("Expected the details of the api stack, got view %v", m.CurrentView)
+	}
+	return m
+}
+
+// selectStackRow moves the cursor to the row starting with a value and selects it
+func selectStackRow(t *testing.T, m *model.Model, value string) (*model.Model, tea.Cmd) {
+	t.Helper()
+	for i, row := range m.Table.Rows() {
+		if row[0] == value {
+			m.Table.SetCursor(i)
+			result, cmd := HandleTableSelect(m)
+			return result.(ModelWrapper).Model, cmd
+		}
+	}
+	t.Fatalf("Expected a row %q, got %v", value, m.Table.Rows())
+	return m, nil
+}
+
+func TestStackDriftDetection(t *testing.T) {
+	operation := &cloudFormationTestOperation{}
+	m := newStackTestModel(t, operation)
+
+	found := false
+	for _, row := range m.Table.Rows() {
+		if row[0] == constants.OutputRowPrefix+"Url" {
+			found = row[1] == "https://api.example.com (exported as api-url)"
+		}
+	}
+	if !found {
+		t.Errorf("Expected the exported output in the details, got %v", m.Table.Rows())
+	}
+
+	result, cmd := selectStackRow(t, m, constants.ActionDetectDrift)
+	m = HandleStackDriftStarted(result, cmd().(model.StackDriftStartedMsg))
+	if !m.IsLoading || m.StackDriftID != "detection-1" {
+		t.Fatalf("Expected the detection to be running, got %q", m.StackDriftID)
+	}
+
+	// The detection is polled until it's done
+	polls := 0
+	for cmd := PollStackDrift(m, m.StackDriftID); cmd != nil; cmd = PollStackDrift(m, m.StackDriftID) {
+		if polls++; polls > 5 {
+			t.Fatalf("Expected the detection to stop being polled")
+		}
+		m = HandleStackDrift(m, checkStackDrift(m)().(model.StackDriftMsg))
+	}
+	if m.CurrentView != constants.ViewStackDrift || len(m.Table.Rows()) != 1 || m.Table.Rows()[0][3] != "1" {
+		t.Fatalf("Expected the drifted resource with its difference, got view %v with %v", m.CurrentView, m.Table.Rows())
+	}
+	if m.SelectedStack.DriftStatus != "DRIFTED" || m.Stacks[0].DriftStatus != "DRIFTED" {
+		t.Errorf("Expected the stack to be shown as drifted, got %s", m.SelectedStack.DriftStatus)
+	}
+
+	details, _ := selectStackRow(t, m, "Handler")
+	if details.CurrentView != constants.ViewResourceDrift || details.Table.Rows()[0][2] != "30" || details.Table.Rows()[0][3] != "60" {
+		t.Errorf("Expected the expected and actual values of the drifted property, got %v", details.Table.Rows())
+	}
+
+	// Results of a detection that's no longer running are ignored
+	if ignored := HandleStackDrift(m, model.StackDriftMsg{DetectionID: "detection-0"}); ignored != m {
+		t.Errorf("Expected the results of another detection to be ignored")
+	}
+}
+
+func TestExecuteChangeSet(t *testing.T) {
+	operation := &cloudFormationTestOperation{}
+	m := newStackTestModel(t, operation)
+
+	result, cmd := selectStackRow(t, m, constants.ActionChangeSets)
+	m = HandleChangeSets(result, cmd().(model.ChangeSetsMsg))
+	if m.CurrentView != constants.ViewChangeSets || len(m.Table.Rows()) != 2 {
+		t.Fatalf("Expected both change sets, got view %v with %v", m.CurrentView, m.Table.Rows())
+	}
+
+	// Change sets that failed can be reviewed but not executed
+	result, cmd = selectStackRow(t, m, "deploy-1")
+	failed := HandleChangeSet(result, cmd().(model.ChangeSetMsg))
+	if rows := failed.Table.Rows(); len(rows) != 0 {
+		t.Errorf("Expected no changes and no execute action, got %v", rows)
+	}
+
+	result, cmd = selectStackRow(t, m, "deploy-2")
+	m = HandleChangeSet(result, cmd().(model.ChangeSetMsg))
+	rows := m.Table.Rows()
+	if len(rows) != 4 || rows[1][3] != "True" || rows[3][0] != constants.ActionExecuteChangeSet {
+		t.Fatalf("Expected the resource changes followed by the execute action, got %v", rows)
+	}
+
+	m, _ = selectStackRow(t, m, constants.ActionExecuteChangeSet)
+	if m.CurrentView != constants.ViewExecutingAction || m.PendingAction == nil {
+		t.Fatalf("Expected to confirm the execution, got view %v", m.CurrentView)
+	}
+	if want := "Changes: 3 (2 Modify, 1 Add)"; m.PendingAction.Details[2] != want {
+		t.Errorf("Expected %q, got %q", want, m.PendingAction.Details[2])
+	}
+	if len(m.PendingAction.Details) != 4 {
+		t.Errorf("Expected a warning about the replaced table, got %v", m.PendingAction.Details)
+	}
+	if len(operation.executed) != 0 {
+		t.Fatalf("Expected nothing to be executed before confirmation")
+	}
+
+	m.Table.SetCursor(0)
+	confirmed, cmd := HandleExecutionSelection(m)
+	m = HandleActionResult(confirmed.(ModelWrapper).Model, cmd().(model.ActionResultMsg))
+	if len(operation.executed) != 1 || operation.executed[0] != "api/deploy-2" {
+		t.Errorf("Expected the change set to be executed, got %v", operation.executed)
+	}
+	if m.Err != nil || m.Stacks != nil {
+		t.Errorf("Expected the stacks to be cleared after executing, got error %v", m.Err)
+	}
+}
@@ -0,0 +1,359 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleBuildProjectsLoad lists the CodeBuild projects of the account
+func HandleBuildProjectsLoad(m *model.Model) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingProjects
+
+	return WrapModel(newModel), func() tea.Msg {
+		buildOperation, err := getCodeBuildOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		projects, err := buildOperation.ListProjects(context.Background())
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.BuildProjectsMsg{Projects: projects}
+	}
+}
+
+// HandleBuildProjects shows the projects
+func HandleBuildProjects(m *model.Model, msg model.BuildProjectsMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.BuildProjects = msg.Projects
+	newModel.BuildProject = nil
+	newModel.Builds = nil
+	newModel.CurrentView = constants.ViewBuildProjects
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleBuildProjectSelection lists the latest builds of the selected project
+func HandleBuildProjectSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	// Rows are in the order of the projects
+	cursor := m.Table.Cursor()
+	if len(m.Table.Rows()) == 0 || cursor < 0 || cursor >= len(m.BuildProjects) {
+		return WrapModel(m), nil
+	}
+
+	project := m.BuildProjects[cursor]
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingBuilds
+
+	return WrapModel(newModel), func() tea.Msg {
+		buildOperation, err := getCodeBuildOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		builds, err := buildOperation.ListBuilds(context.Background(), project.Name)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.BuildsMsg{Project: project, Builds: builds}
+	}
+}
+
+// HandleBuilds shows the latest builds of a project below the row starting a new one
+func HandleBuilds(m *model.Model, msg model.BuildsMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.BuildProject = &msg.Project
+	newModel.Builds = msg.Builds
+	newModel.SelectedBuild = nil
+	newModel.CurrentView = constants.ViewBuilds
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleBuildSelection opens the start build form from its row, or the details of the selected build
+func HandleBuildSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 || m.BuildProject == nil {
+		return WrapModel(m), nil
+	}
+
+	if selected[0] == constants.ActionStartBuild {
+		newModel := m.Clone()
+		newModel.BuildStart = cloud.BuildStart{Project: m.BuildProject.Name}
+		newModel.BuildInputField = ""
+		newModel.CurrentView = constants.ViewStartBuild
+		view.UpdateTableForView(newModel)
+		return WrapModel(newModel), nil
+	}
+
+	// The builds follow the start build row
+	index := m.Table.Cursor() - 1
+	if index < 0 || index >= len(m.Builds) {
+		return WrapModel(m), nil
+	}
+
+	build := m.Builds[index]
+	newModel := m.Clone()
+	newModel.SelectedBuild = &build
+	newModel.CurrentView = constants.ViewBuildDetails
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleStartBuildSelection handles the selection of a row in the start build form
+func HandleStartBuildSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 {
+		return WrapModel(m), nil
+	}
+
+	newModel := m.Clone()
+	switch {
+	case selected[0] == constants.SettingSourceVersion:
+		newModel.TextInput.Placeholder = constants.MsgEnterSourceVersion
+		newModel.TextInput.SetValue(m.BuildStart.SourceVersion)
+	case selected[0] == constants.SettingAddVariable:
+		newModel.TextInput.Placeholder = constants.MsgEnterBuildVariable
+	case strings.HasPrefix(selected[0], constants.VariableRowPrefix):
+		newModel.TextInput.Placeholder = constants.MsgEnterVariableValue
+		newModel.TextInput.SetValue(selected[1])
+	case selected[0] == constants.ActionStartBuild:
+		return HandleStartBuild(m)
+	default:
+		return WrapModel(m), nil
+	}
+
+	newModel.BuildInputField = selected[0]
+	newModel.ManualInput = true
+	newModel.TextInput.Focus()
+	return WrapModel(newModel), nil
+}
+
+// HandleStartBuildInput applies the source version, variable or variable value entered in the
+// start build form
+func HandleStartBuildInput(m *model.Model, value string) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	value = strings.TrimSpace(value)
+
+	switch {
+	case m.BuildInputField == constants.SettingSourceVersion:
+		newModel.BuildStart.SourceVersion = value
+	case m.BuildInputField == constants.SettingAddVariable:
+		name, variableValue, found := strings.Cut(value, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return WrapModel(m), func() tea.Msg {
+				return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorBuildVariable)}
+			}
+		}
+		newModel.BuildStart.Variables = setBuildVariable(newModel.BuildStart.Variables, name, variableValue)
+	case strings.HasPrefix(m.BuildInputField, constants.VariableRowPrefix):
+		name := strings.TrimPrefix(m.BuildInputField, constants.VariableRowPrefix)
+		if value == "" {
+			newModel.BuildStart.Variables = removeBuildVariable(newModel.BuildStart.Variables, name)
+		} else {
+			newModel.BuildStart.Variables = setBuildVariable(newModel.BuildStart.Variables, name, value)
+		}
+	}
+
+	newModel.BuildInputField = ""
+	newModel.ManualInput = false
+	newModel.ResetTextInput()
+	refreshTable(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleStartBuild starts the build staged in the start build form
+func HandleStartBuild(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.BuildStart.Project == "" {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoProject)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgStartingBuild
+	start := newModel.BuildStart
+
+	return WrapModel(newModel), func() tea.Msg {
+		buildOperation, err := getCodeBuildOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		build, err := buildOperation.StartBuild(context.Background(), start)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.BuildStartedMsg{Build: *build}
+	}
+}
+
+// HandleBuildStarted adds the started build to the project's builds and follows its log. The
+// log is polled with PollBuildLogs.
+func HandleBuildStarted(m *model.Model, msg model.BuildStartedMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.Builds = append([]cloud.Build{msg.Build}, m.Builds...)
+	newModel.BuildStart = cloud.BuildStart{}
+	newModel.SelectedBuild = &msg.Build
+	startFollowingBuild(newModel)
+	return newModel
+}
+
+// HandleBuildDetailsSelection handles the selection of a row in the build details; only the
+// log action row does anything
+func HandleBuildDetailsSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 || selected[0] != constants.ActionBuildLogs {
+		return WrapModel(m), nil
+	}
+	if m.SelectedBuild == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoBuild)}
+		}
+	}
+
+	newModel := m.Clone()
+	startFollowingBuild(newModel)
+	return WrapModel(newModel), fetchBuildLogs(newModel)
+}
+
+// PollBuildLogs returns a command that checks a followed build for new log lines after a moment,
+// or nil once the build is no longer followed
+func PollBuildLogs(m *model.Model, buildID string) tea.Cmd {
+	if buildID == "" || buildID != m.BuildFollowID {
+		return nil
+	}
+	fetch := fetchBuildLogs(m)
+	return tea.Tick(constants.BuildLogsPollInterval, func(time.Time) tea.Msg {
+		return fetch()
+	})
+}
+
+// HandleBuildLogs shows the state of a followed build and appends the lines it logged, keeping
+// the log scrolled to the end if it was. Once the build is done and no lines are left to read,
+// it's no longer followed.
+func HandleBuildLogs(m *model.Model, msg model.BuildLogsMsg) *model.Model {
+	// Ignore the logs of a build that's no longer followed
+	if msg.BuildID != m.BuildFollowID {
+		return m
+	}
+
+	newModel := m.Clone()
+	if msg.Err != nil {
+		newModel.BuildFollowID = ""
+		newModel.Err = msg.Err
+		return newModel
+	}
+
+	newModel.SelectedBuild = msg.Build
+	newModel.Builds = make([]cloud.Build, len(m.Builds))
+	for i, build := range m.Builds {
+		if build.ID == msg.Build.ID {
+			build = *msg.Build
+		}
+		newModel.Builds[i] = build
+	}
+
+	atEnd := len(m.Table.Rows()) == 0 || m.Table.Cursor() >= len(m.Table.Rows())-1
+	if len(msg.Logs.Events) > 0 {
+		lines := make([]cloud.BuildLogEvent, 0, len(m.BuildLogs)+len(msg.Logs.Events))
+		lines = append(append(lines, m.BuildLogs...), msg.Logs.Events...)
+		newModel.BuildLogs = lines[max(0, len(lines)-constants.MaxBuildLogLines):]
+	}
+	newModel.BuildLogsToken = msg.Logs.NextToken
+	if msg.Build.Done() && len(msg.Logs.Events) == 0 {
+		newModel.BuildFollowID = ""
+	}
+
+	if newModel.CurrentView == constants.ViewBuildLogs {
+		refreshTable(newModel)
+		if atEnd {
+			newModel.Table.GotoBottom()
+		}
+	}
+	return newModel
+}
+
+// startFollowingBuild shows the log of the selected build from its start and follows it
+func startFollowingBuild(m *model.Model) {
+	m.BuildLogs = nil
+	m.BuildLogsToken = ""
+	m.BuildFollowID = m.SelectedBuild.ID
+	m.CurrentView = constants.ViewBuildLogs
+	view.UpdateTableForView(m)
+}
+
+// fetchBuildLogs returns a command reading the state of the followed build and the lines it
+// logged after the last token
+func fetchBuildLogs(m *model.Model) tea.Cmd {
+	buildID, token := m.BuildFollowID, m.BuildLogsToken
+
+	return func() tea.Msg {
+		msg := model.BuildLogsMsg{BuildID: buildID}
+
+		buildOperation, err := getCodeBuildOperation(m)
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+
+		msg.Build, msg.Err = buildOperation.GetBuild(context.Background(), buildID)
+		if msg.Err != nil {
+			return msg
+		}
+
+		msg.Logs, msg.Err = buildOperation.GetBuildLogs(context.Background(), *msg.Build, token)
+		return msg
+	}
+}
+
+// setBuildVariable returns the variables with a variable set, replacing one of the same name
+func setBuildVariable(variables []cloud.BuildEnvironmentVariable, name, value string) []cloud.BuildEnvironmentVariable {
+	for i, variable := range variables {
+		if variable.Name == name {
+			variables[i].Value = value
+			return variables
+		}
+	}
+	return append(variables, cloud.BuildEnvironmentVariable{Name: name, Value: value})
+}
+
+// removeBuildVariable returns the variables without the variable of a name
+func removeBuildVariable(variables []cloud.BuildEnvironmentVariable, name string) []cloud.BuildEnvironmentVariable {
+	kept := variables[:0]
+	for _, variable := range variables {
+		if variable.Name != name {
+			kept = append(kept, variable)
+		}
+	}
+	return kept
+}
+
+// getCodeBuildOperation gets the CodeBuild operation from the selected provider
+func getCodeBuildOperation(m *model.Model) (cloud.CodeBuildOperation, error) {
+	provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetCodeBuildOperation()
+}
@@ -0,0 +1,195 @@
+package update
+
+import (
+	"context"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// codeBuildTestOperation lists a project with a finished build and records the builds started.
+// Started builds log a line each time they're checked and finish after logging two lines.
+type codeBuildTestOperation struct {
+	cloud.CodeBuildOperation
+	started []cloud.BuildStart
+	checks  int
+}
+
+func (o *codeBuildTestOperation) ListProjects(ctx context.Context) ([]cloud.BuildProject, error) {
+	return []cloud.BuildProject{{Name: "api", SourceType: "GITHUB", SourceVersion: "main"}}, nil
+}
+
+func (o *codeBuildTestOperation) ListBuilds(ctx context.Context, project string) ([]cloud.Build, error) {
+	return []cloud.Build{{
+		ID:      project + ":1",
+		Number:  1,
+		Project: project,
+		Status:  cloud.BuildStatusFailed,
+		Phases: []cloud.BuildPhase{
+			{Type: "INSTALL", Status: cloud.BuildStatusSucceeded},
+			{Type: "BUILD", Status: cloud.BuildStatusFailed, Message: "COMMAND_EXECUTION_ERROR: exit status 2"},
+		},
+	}}, nil
+}
+
+func (o *codeBuildTestOperation) StartBuild(ctx context.Context, start cloud.BuildStart) (*cloud.Build, error) {
+	o.started = append(o.started, start)
+	return &cloud.Build{ID: start.Project + ":2", Number: 2, Project: start.Project, Status: cloud.BuildStatusInProgress}, nil
+}
+
+func (o *codeBuildTestOperation) GetBuild(ctx context.Context, id string) (*cloud.Build, error) {
+	o.checks++
+	build := &cloud.Build{ID: id, Number: 2, Project: "api", Status: cloud.BuildStatusInProgress, CurrentPhase: "BUILD"}
+	if o.checks >= 2 {
+		build.Status = cloud.BuildStatusSucceeded
+	}
+	return build, nil
+}
+
+func (o *codeBuildTestOperation) GetBuildLogs(ctx context.Context, build cloud.Build, token string) (*cloud.BuildLogs, error) {
+	lines := []string{"Running build", "Build complete"}
+	read := len(token)
+	if read >= len(lines) {
+		return &cloud.BuildLogs{NextToken: token}, nil
+	}
+	return &cloud.BuildLogs{
+		Events:    []cloud.BuildLogEvent{{Message: lines[read]}},
+		NextToken: token + ".",
+	}, nil
+}
+
+// newCodeBuildTestModel returns a model showing the builds of the api project
+func newCodeBuildTestModel(t *testing.T, operation *codeBuildTestOperation) *model.Model {
+	t.Helper()
+	m := newTestModel(&testProvider{codeBuild: operation})
+	result, cmd := HandleBuildProjectsLoad(m)
+	m = HandleBuildProjects(result.(ModelWrapper).Model, cmd().(model.BuildProjectsMsg))
+
+	result, cmd = HandleTableSelect(m)
+	m = HandleBuilds(result.(ModelWrapper).Model, cmd().(model.BuildsMsg))
+	if m.CurrentView != constants.ViewBuilds || len(m.Table.Rows()) != 2 {
+		t.Fatalf("Expected the start build row and one build, got view %v with %d rows", m.CurrentView, len(m.Table.Rows()))
+	}
+	return m
+}
+
+// selectBuildRow moves the cursor to the row starting with a value and selects it
+func selectBuildRow(t *testing.T, m *model.Model, value string) (*model.Model, tea.Cmd) {
+	t.Helper()
+	for i, row := range m.Table.Rows() {
+		if row[0] == value {
+			m.Table.SetCursor(i)
+			result, cmd := HandleTableSelect(m)
+			return result.(ModelWrapper).Model, cmd
+		}
+	}
+	t.Fatalf("Expected a row %q, got %v", value, m.Table.Rows())
+	return m, nil
+}
+
+// enterBuildSetting selects a row of the start build form and enters a value for it
+func enterBuildSetting(t *testing.T, m *model.Model, row, value string) *model.Model {
+	t.Helper()
+	m, _ = selectBuildRow(t, m, row)
+	if !m.ManualInput {
+		t.Fatalf("Expected to enter a value for %s", row)
+	}
+	m.TextInput.SetValue(value)
+	result, cmd := HandleTextInputSubmission(m)
+	if cmd != nil {
+		t.Fatalf("Expected %q to be accepted for %s, got %v", value, row, cmd())
+	}
+	return result.(ModelWrapper).Model
+}
+
+func TestBuildDetails(t *testing.T) {
+	m := newCodeBuildTestModel(t, &codeBuildTestOperation{})
+
+	details, _ := selectBuildRow(t, m, "#1")
+	if details.CurrentView != constants.ViewBuildDetails || details.SelectedBuild.ID != "api:1" {
+		t.Fatalf("Expected the details of build #1, got view %v", details.CurrentView)
+	}
+	found := false
+	for _, row := range details.Table.Rows() {
+		if row[0] == "Phase: BUILD" {
+			found = true
+			if row[1] != "FAILED, COMMAND_EXECUTION_ERROR: exit status 2" {
+				t.Errorf("Expected the status and message of the phase, got %q", row[1])
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Expected a row for the BUILD phase, got %v", details.Table.Rows())
+	}
+
+	if back := NavigateBack(details); back.CurrentView != constants.ViewBuilds || back.SelectedBuild != nil {
+		t.Errorf("Expected to go back to the builds, got view %v", back.CurrentView)
+	}
+}
+
+func TestStartBuildAndFollowLogs(t *testing.T) {
+	operation := &codeBuildTestOperation{}
+	m := newCodeBuildTestModel(t, operation)
+
+	form, _ := selectBuildRow(t, m, constants.ActionStartBuild)
+	if form.CurrentView != constants.ViewStartBuild || form.Table.Rows()[0][1] != "Project default (main)" {
+		t.Fatalf("Expected the start build form with the default source version, got %v", form.Table.Rows())
+	}
+
+	form = enterBuildSetting(t, form, constants.SettingSourceVersion, "feature/login")
+	form = enterBuildSetting(t, form, constants.SettingAddVariable, "STAGE=dev")
+	form = enterBuildSetting(t, form, constants.SettingAddVariable, "DEBUG=1")
+	form = enterBuildSetting(t, form, constants.VariableRowPrefix+"STAGE", "prod")
+	form = enterBuildSetting(t, form, constants.VariableRowPrefix+"DEBUG", "")
+
+	// Variables must name what they set
+	input, _ := selectBuildRow(t, form, constants.SettingAddVariable)
+	input.TextInput.SetValue("=dev")
+	if _, cmd := HandleTextInputSubmission(input); cmd == nil {
+		t.Errorf("Expected a variable without a name to be rejected")
+	}
+
+	result, cmd := selectBuildRow(t, form, constants.ActionStartBuild)
+	m = HandleBuildStarted(result, cmd().(model.BuildStartedMsg))
+	if len(operation.started) != 1 {
+		t.Fatalf("Expected a build to start, got %d", len(operation.started))
+	}
+	start := operation.started[0]
+	if start.Project != "api" || start.SourceVersion != "feature/login" ||
+		len(start.Variables) != 1 || start.Variables[0] != (cloud.BuildEnvironmentVariable{Name: "STAGE", Value: "prod"}) {
+		t.Errorf("Expected the overrides of the form, got %+v", start)
+	}
+	if m.CurrentView != constants.ViewBuildLogs || m.BuildFollowID != "api:2" || len(m.Builds) != 2 {
+		t.Fatalf("Expected to follow the log of the started build, got view %v", m.CurrentView)
+	}
+
+	// The log is polled until the build is done and no lines are left
+	polls := 0
+	for cmd := PollBuildLogs(m, m.BuildFollowID); cmd != nil; cmd = PollBuildLogs(m, m.BuildFollowID) {
+		if polls++; polls > 5 {
+			t.Fatalf("Expected the log to stop being followed")
+		}
+		m = HandleBuildLogs(m, fetchBuildLogs(m)().(model.BuildLogsMsg))
+	}
+	if len(m.BuildLogs) != 2 || m.BuildLogs[1].Message != "Build complete" {
+		t.Errorf("Expected both lines of the log, got %+v", m.BuildLogs)
+	}
+	if m.SelectedBuild.Status != cloud.BuildStatusSucceeded || m.Builds[0].Status != cloud.BuildStatusSucceeded {
+		t.Errorf("Expected the build to be shown as succeeded, got %s", m.SelectedBuild.Status)
+	}
+	if m.Table.Cursor() != 1 {
+		t.Errorf("Expected the log to stay scrolled to the end, got row %d", m.Table.Cursor())
+	}
+
+	// Results for a build that's no longer followed are ignored
+	back := NavigateBack(m)
+	if back.CurrentView != constants.ViewBuildDetails || back.BuildFollowID != "" {
+		t.Errorf("Expected to stop following the log, got view %v", back.CurrentView)
+	}
+	if ignored := HandleBuildLogs(back, model.BuildLogsMsg{BuildID: "api:2"}); ignored != back {
+		t.Errorf("Expected the results of an unfollowed build to be ignored")
+	}
+}
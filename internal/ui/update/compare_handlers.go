@@ -0,0 +1,151 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleCompareOptions shows the options for comparing the function in the details view across
+// accounts and regions, starting with the configured profile and region
+func HandleCompareOptions(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.CurrentView != constants.ViewFunctionDetails {
+		return WrapModel(m), nil
+	}
+	if m.SelectedFunction == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoFunction)}
+		}
+	}
+
+	newModel := m.Clone()
+	// Keep the targets of the previous comparison, so one function after another can be checked
+	if len(newModel.CompareOptions.Targets) == 0 {
+		newModel.CompareOptions = cloud.FunctionCompareOptions{
+			Targets: []cloud.CompareTarget{{Profile: m.AwsProfile, Region: m.AwsRegion}},
+		}
+	}
+	newModel.CompareField = ""
+	newModel.FunctionComparison = nil
+	newModel.CurrentView = constants.ViewCompareOptions
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleCompareOptionsSelection handles the selection of a row in the compare options form
+func HandleCompareOptionsSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 {
+		return WrapModel(m), nil
+	}
+
+	newModel := m.Clone()
+	switch selected[0] {
+	case constants.SettingRunCompare:
+		return HandleCompareRun(m)
+	case constants.SettingEnvValues:
+		newModel.CompareOptions.HashEnvValues = !m.CompareOptions.HashEnvValues
+		refreshTable(newModel)
+		return WrapModel(newModel), nil
+	case constants.SettingTargets:
+		// Start from the current targets, as more are usually added than replaced
+		targets := make([]string, len(m.CompareOptions.Targets))
+		for i, target := range m.CompareOptions.Targets {
+			targets[i] = target.String()
+		}
+		newModel.TextInput.Placeholder = constants.MsgEnterTargets
+		newModel.TextInput.SetValue(strings.Join(targets, ", "))
+	default:
+		return WrapModel(m), nil
+	}
+
+	newModel.CompareField = selected[0]
+	newModel.ManualInput = true
+	newModel.TextInput.Focus()
+	return WrapModel(newModel), nil
+}
+
+// HandleCompareInput applies the targets entered in the compare options form
+func HandleCompareInput(m *model.Model, value string) (tea.Model, tea.Cmd) {
+	var targets []cloud.CompareTarget
+	for _, entry := range strings.Split(value, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		target, err := cloud.ParseCompareTarget(entry)
+		if err != nil {
+			return WrapModel(m), func() tea.Msg {
+				return model.ErrMsg{Err: err}
+			}
+		}
+		targets = append(targets, target)
+	}
+
+	newModel := m.Clone()
+	// An empty list keeps the current targets
+	if len(targets) > 0 {
+		newModel.CompareOptions.Targets = targets
+	}
+	newModel.CompareField = ""
+	newModel.ManualInput = false
+	newModel.ResetTextInput()
+	refreshTable(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleCompareRun compares the selected function across the targets of the options
+func HandleCompareRun(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedFunction == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoFunction)}
+		}
+	}
+	options := m.CompareOptions
+	if err := options.Validate(); err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgComparingFunction
+	functionName := m.SelectedFunction.Name
+
+	return WrapModel(newModel), func() tea.Msg {
+		// Get the provider
+		provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the FunctionStatusOperation from the provider
+		functionOperation, err := provider.GetFunctionStatusOperation()
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		comparison, err := functionOperation.CompareFunction(context.Background(), functionName, options)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.FunctionComparisonMsg{Comparison: comparison}
+	}
+}
+
+// HandleFunctionComparison shows a function side by side across targets
+func HandleFunctionComparison(m *model.Model, msg model.FunctionComparisonMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.FunctionComparison = msg.Comparison
+	newModel.CurrentView = constants.ViewFunctionCompare
+	view.UpdateTableForView(newModel)
+	return newModel
+}
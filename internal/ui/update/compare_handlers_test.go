@@ -0,0 +1,168 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// compareTestOperation returns a fixed configuration for each target and records the options
+// it was asked to compare with
+type compareTestOperation struct {
+	cloud.FunctionStatusOperation
+	snapshots map[cloud.CompareTarget]cloud.FunctionSnapshot
+	options   cloud.FunctionCompareOptions
+}
+
+func (o *compareTestOperation) CompareFunction(ctx context.Context, functionName string, options cloud.FunctionCompareOptions) (*cloud.FunctionComparison, error) {
+	o.options = options
+	snapshots := make([]cloud.FunctionSnapshot, len(options.Targets))
+	for i, target := range options.Targets {
+		snapshots[i] = o.snapshots[target]
+		snapshots[i].Target = target
+	}
+	return cloud.NewFunctionComparison(functionName, snapshots), nil
+}
+
+func TestCompareFunction(t *testing.T) {
+	staging := cloud.CompareTarget{Profile: "staging", Region: "us-east-1"}
+	prod := cloud.CompareTarget{Profile: "prod", Region: "us-east-1"}
+	operation := &compareTestOperation{snapshots: map[cloud.CompareTarget]cloud.FunctionSnapshot{
+		staging: {
+			Status: &cloud.FunctionStatus{
+				Runtime:    "nodejs20.x",
+				Memory:     512,
+				Layers:     []string{"arn:aws:lambda:us-east-1:111111111111:layer:shared:4"},
+				CodeSha256: "abc=",
+			},
+			Environment: map[string]string{"TABLE_NAME": "", "FEATURE_FLAG": ""},
+			Aliases:     map[string]string{"live": "7"},
+		},
+		prod: {
+			Status: &cloud.FunctionStatus{
+				Runtime:    "nodejs20.x",
+				Memory:     1024,
+				Layers:     []string{"arn:aws:lambda:us-east-1:222222222222:layer:shared:4"},
+				CodeSha256: "def=",
+			},
+			Environment: map[string]string{"TABLE_NAME": ""},
+			Aliases:     map[string]string{"live": "5"},
+		},
+	}}
+	m := newTestModel(&testProvider{functionStatus: operation})
+	m.AwsProfile = "staging"
+	m.AwsRegion = "us-east-1"
+	m.CurrentView = constants.ViewFunctionDetails
+	m.SetSelectedFunction(&cloud.FunctionStatus{Name: "orders"})
+
+	result, _ := HandleCompareOptions(m)
+	m = result.(ModelWrapper).Model
+	if m.CurrentView != constants.ViewCompareOptions {
+		t.Fatalf("Expected the compare options, got view %v", m.CurrentView)
+	}
+	if len(m.CompareOptions.Targets) != 1 || m.CompareOptions.Targets[0] != staging {
+		t.Fatalf("Expected the configured profile and region as the first target, got %v", m.CompareOptions.Targets)
+	}
+
+	// A single target can't be compared
+	_, cmd := HandleCompareRun(m)
+	if msg, ok := cmd().(model.ErrMsg); !ok || !errors.Is(msg.Err, cloud.ErrCompareTargets) {
+		t.Errorf("Expected an error for too few targets, got %v", msg)
+	}
+
+	// Targets that aren't profile/region are refused
+	_, cmd = HandleCompareInput(m, "staging/us-east-1, prod")
+	if msg, ok := cmd().(model.ErrMsg); !ok || !errors.Is(msg.Err, cloud.ErrCompareTarget) {
+		t.Errorf("Expected an error for a malformed target, got %v", msg)
+	}
+
+	m.CompareField = constants.SettingTargets
+	result, _ = HandleCompareInput(m, "staging/us-east-1, prod/us-east-1")
+	m = result.(ModelWrapper).Model
+	if len(m.CompareOptions.Targets) != 2 || m.CompareOptions.Targets[1] != prod {
+		t.Fatalf("Expected staging and prod, got %v", m.CompareOptions.Targets)
+	}
+
+	// Hash the environment values
+	m.Table.SetCursor(1)
+	result, _ = HandleCompareOptionsSelection(m)
+	m = result.(ModelWrapper).Model
+	if !m.CompareOptions.HashEnvValues || m.Table.Rows()[1][1] != constants.EnvValuesHashed {
+		t.Errorf("Expected environment values to be hashed, got %v", m.Table.Rows()[1])
+	}
+
+	result, cmd = HandleCompareRun(m)
+	if !result.(ModelWrapper).Model.IsLoading {
+		t.Errorf("Expected the model to be loading")
+	}
+	msg, ok := cmd().(model.FunctionComparisonMsg)
+	if !ok {
+		t.Fatalf("Expected a comparison message")
+	}
+	if !operation.options.HashEnvValues {
+		t.Errorf("Expected the options to be passed to the operation")
+	}
+
+	m = HandleFunctionComparison(result.(ModelWrapper).Model, msg)
+	if m.CurrentView != constants.ViewFunctionCompare {
+		t.Fatalf("Expected the comparison, got view %v", m.CurrentView)
+	}
+	columns := m.Table.Columns()
+	if len(columns) != 3 || columns[1].Title != "staging/us-east-1" || columns[2].Title != "prod/us-east-1" {
+		t.Fatalf("Expected a column for each target, got %v", columns)
+	}
+
+	rows := make(map[string][]string)
+	for _, row := range m.Table.Rows() {
+		rows[row[0]] = row[1:]
+	}
+	expected := map[string][]string{
+		"  Runtime":           {"nodejs20.x", "nodejs20.x"},
+		"≠ Memory":            {"512 MB", "1024 MB"},
+		"  Layers":            {"shared:4", "shared:4"},
+		"≠ Code SHA256":       {"abc=", "def="},
+		"  Env: TABLE_NAME":   {"set", "set"},
+		"≠ Env: FEATURE_FLAG": {"set", cloud.CompareValueMissing},
+		"≠ Alias: live":       {"7", "5"},
+	}
+	for name, values := range expected {
+		got, ok := rows[name]
+		if !ok || got[0] != values[0] || got[1] != values[1] {
+			t.Errorf("Expected %q to be %v, got %v", name, values, got)
+		}
+	}
+	if differences := m.FunctionComparison.Differences(); differences != 4 {
+		t.Errorf("Expected 4 differences, got %d", differences)
+	}
+
+	// Going back keeps the targets for another run
+	m = NavigateBack(m)
+	if m.CurrentView != constants.ViewCompareOptions || len(m.CompareOptions.Targets) != 2 {
+		t.Errorf("Expected the options with both targets, got %v with %v", m.CurrentView, m.CompareOptions.Targets)
+	}
+	m = NavigateBack(m)
+	if m.CurrentView != constants.ViewFunctionDetails {
+		t.Errorf("Expected the function details, got %v", m.CurrentView)
+	}
+}
+
+func TestCompareUnavailableTarget(t *testing.T) {
+	comparison := cloud.NewFunctionComparison("orders", []cloud.FunctionSnapshot{
+		{Target: cloud.CompareTarget{Profile: "a", Region: "us-east-1"}, Status: &cloud.FunctionStatus{Memory: 128}},
+		{Target: cloud.CompareTarget{Profile: "b", Region: "us-east-1"}, Error: "function not found"},
+		{Target: cloud.CompareTarget{Profile: "c", Region: "us-east-1"}, Status: &cloud.FunctionStatus{Memory: 128}},
+	})
+
+	// A target the function couldn't be loaded from doesn't count as a difference
+	for _, field := range comparison.Fields {
+		if field.Name == "Memory" {
+			if field.Differs || field.Values[1] != cloud.CompareValueUnavailable {
+				t.Errorf("Expected memory to match, got %v", field)
+			}
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleEC2Filters shows the instance filter form, listing instances in every state by default
+func HandleEC2Filters(m *model.Model) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.EC2Filter = cloud.EC2InstanceFilter{}
+	newModel.EC2Instances = nil
+	newModel.EC2InstanceDetails = nil
+	newModel.CurrentView = constants.ViewEC2Filters
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleEC2FiltersSelection handles the selection of a row in the filter form
+func HandleEC2FiltersSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 {
+		return WrapModel(m), nil
+	}
+
+	newModel := m.Clone()
+	switch selected[0] {
+	case constants.SettingInstanceState:
+		newModel.EC2Filter.State = nextInstanceState(m.EC2Filter.State)
+		refreshTable(newModel)
+		return WrapModel(newModel), nil
+	case constants.SettingInstanceTag:
+		newModel.ManualInput = true
+		newModel.TextInput.Placeholder = constants.MsgEnterInstanceTag
+		newModel.TextInput.SetValue(m.EC2Filter.Tag())
+		newModel.TextInput.Focus()
+		return WrapModel(newModel), nil
+	case constants.SettingListInstances:
+		return HandleEC2InstancesLoad(m)
+	default:
+		return WrapModel(m), nil
+	}
+}
+
+// HandleEC2FilterInput applies the tag filter entered in the filter form
+func HandleEC2FilterInput(m *model.Model, value string) (tea.Model, tea.Cmd) {
+	key, tagValue, err := parseInstanceTag(value)
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.EC2Filter.TagKey = key
+	newModel.EC2Filter.TagValue = tagValue
+	newModel.ManualInput = false
+	newModel.ResetTextInput()
+	refreshTable(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleEC2InstancesLoad lists the instances matching the staged filter
+func HandleEC2InstancesLoad(m *model.Model) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingInstances
+	filter := m.EC2Filter
+
+	return WrapModel(newModel), func() tea.Msg {
+		instanceOperation, err := getEC2InstanceOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		instances, err := instanceOperation.ListInstances(context.Background(), filter)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.EC2InstancesMsg{Instances: instances}
+	}
+}
+
+// HandleEC2Instances shows the instances matching the filter
+func HandleEC2Instances(m *model.Model, msg model.EC2InstancesMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.EC2Instances = msg.Instances
+	newModel.EC2InstanceDetails = nil
+	newModel.CurrentView = constants.ViewEC2Instances
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleEC2InstanceSelection loads the details of the selected instance
+func HandleEC2InstanceSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	// Rows are in the order of the instances, which may share a name
+	cursor := m.Table.Cursor()
+	if len(m.Table.Rows()) == 0 || cursor < 0 || cursor >= len(m.EC2Instances) {
+		return WrapModel(m), nil
+	}
+	return loadEC2InstanceDetails(m, m.EC2Instances[cursor].ID)
+}
+
+// HandleEC2InstanceDetails shows the network, storage and status checks of an instance
+func HandleEC2InstanceDetails(m *model.Model, msg model.EC2InstanceDetailsMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.EC2InstanceDetails = msg.Details
+	newModel.CurrentView = constants.ViewEC2InstanceDetails
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleEC2InstanceDetailsSelection handles the selection of a row in the details view;
+// only the start, stop and reboot action rows do anything
+func HandleEC2InstanceDetailsSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 {
+		return WrapModel(m), nil
+	}
+
+	switch selected[0] {
+	case constants.ActionStartInstance, constants.ActionStopInstance, constants.ActionRebootInstance:
+		return HandleEC2InstanceAction(m, selected[0])
+	default:
+		return WrapModel(m), nil
+	}
+}
+
+// HandleEC2InstanceAction asks for confirmation before starting, stopping or rebooting the
+// instance shown in the details view
+func HandleEC2InstanceAction(m *model.Model, action string) (tea.Model, tea.Cmd) {
+	if m.EC2InstanceDetails == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoInstance)}
+		}
+	}
+
+	instanceOperation, err := getEC2InstanceOperation(m)
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	instance := m.EC2InstanceDetails.EC2Instance
+	label := instanceLabel(instance)
+	pending := &model.PendingAction{
+		Description: fmt.Sprintf("%s %s", action, label),
+		Details: []string{
+			fmt.Sprintf("Instance: %s", label),
+			fmt.Sprintf("Type: %s", instance.Type),
+			fmt.Sprintf("State: %s", instance.State),
+		},
+		BackView: constants.ViewEC2InstanceDetails,
+	}
+
+	switch action {
+	case constants.ActionStartInstance:
+		pending.LoadingMsg = constants.MsgStartingInstance
+		pending.Run = func(ctx context.Context) (string, error) {
+			state, err := instanceOperation.StartInstance(ctx, instance.ID)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf(constants.MsgInstanceStateSuccess, label, state), nil
+		}
+	case constants.ActionStopInstance:
+		pending.LoadingMsg = constants.MsgStoppingInstance
+		pending.Details = append(pending.Details, view.EC2StopWarnings(m.EC2InstanceDetails)...)
+		pending.Run = func(ctx context.Context) (string, error) {
+			state, err := instanceOperation.StopInstance(ctx, instance.ID)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf(constants.MsgInstanceStateSuccess, label, state), nil
+		}
+	case constants.ActionRebootInstance:
+		pending.LoadingMsg = constants.MsgRebootingInstance
+		pending.Run = func(ctx context.Context) (string, error) {
+			if err := instanceOperation.RebootInstance(ctx, instance.ID); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf(constants.MsgRebootSuccess, label), nil
+		}
+	default:
+		return WrapModel(m), nil
+	}
+
+	newModel := m.Clone()
+	newModel.PendingAction = pending
+	newModel.CurrentView = constants.ViewExecutingAction
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// loadEC2InstanceDetails loads the details of an instance
+func loadEC2InstanceDetails(m *model.Model, instanceID string) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingInstance
+
+	return WrapModel(newModel), func() tea.Msg {
+		instanceOperation, err := getEC2InstanceOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		details, err := instanceOperation.GetInstanceDetails(context.Background(), instanceID)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.EC2InstanceDetailsMsg{Details: details}
+	}
+}
+
+// nextInstanceState returns the state filter after the current one, cycling through every
+// state back to all of them
+func nextInstanceState(state string) string {
+	if state == "" {
+		return cloud.EC2InstanceStates[0]
+	}
+	for i, s := range cloud.EC2InstanceStates {
+		if s == state && i+1 < len(cloud.EC2InstanceStates) {
+			return cloud.EC2InstanceStates[i+1]
+		}
+	}
+	return ""
+}
+
+// parseInstanceTag parses a tag filter entered as key=value, or as a key alone to match any
+// value. An empty filter clears it.
+func parseInstanceTag(value string) (string, string, error) {
+	value = strings.TrimSpace(value)
+	key, tagValue, _ := strings.Cut(value, "=")
+	key, tagValue = strings.TrimSpace(key), strings.TrimSpace(tagValue)
+	if key == "" && value != "" {
+		return "", "", fmt.Errorf(constants.MsgErrorInvalidTag, value)
+	}
+	return key, tagValue, nil
+}
+
+// instanceLabel returns the name and ID of an instance, or its ID alone when it isn't named
+func instanceLabel(instance cloud.EC2Instance) string {
+	if instance.Name == "" {
+		return instance.ID
+	}
+	return fmt.Sprintf("%s (%s)", instance.Name, instance.ID)
+}
+
+// getEC2InstanceOperation gets the EC2 instance operation from the selected provider
+func getEC2InstanceOperation(m *model.Model) (cloud.EC2InstanceOperation, error) {
+	provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetEC2InstanceOperation()
+}
@@ -0,0 +1,222 @@
+package update
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ec2TestOperation lists a running and a stopped instance and records the state changes asked for
+type ec2TestOperation struct {
+	cloud.EC2InstanceOperation
+	filter  cloud.EC2InstanceFilter
+	stopped string
+	started string
+	reboots int
+}
+
+func (o *ec2TestOperation) ListInstances(ctx context.Context, filter cloud.EC2InstanceFilter) ([]cloud.EC2Instance, error) {
+	o.filter = filter
+	return []cloud.EC2Instance{
+		{ID: "i-web", Name: "web", State: cloud.EC2StateRunning, Type: "t3.small", PublicIP: "203.0.113.10"},
+		{ID: "i-batch", State: cloud.EC2StateStopped, Type: "c6i.large"},
+	}, nil
+}
+
+func (o *ec2TestOperation) GetInstanceDetails(ctx context.Context, instanceID string) (*cloud.EC2InstanceDetails, error) {
+	instances, _ := o.ListInstances(ctx, o.filter)
+	for _, instance := range instances {
+		if instance.ID == instanceID {
+			return &cloud.EC2InstanceDetails{
+				EC2Instance:    instance,
+				SecurityGroups: []cloud.EC2SecurityGroup{{ID: "sg-1", Name: "web"}},
+				Volumes:        []cloud.EC2Volume{{ID: "vol-1", Device: "/dev/xvda", Size: 8, Type: "gp3", State: "in-use"}},
+				SystemStatus:   "ok",
+				InstanceStatus: "impaired",
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (o *ec2TestOperation) StartInstance(ctx context.Context, instanceID string) (string, error) {
+	o.started = instanceID
+	return cloud.EC2StatePending, nil
+}
+
+func (o *ec2TestOperation) StopInstance(ctx context.Context, instanceID string) (string, error) {
+	o.stopped = instanceID
+	return cloud.EC2StateStopping, nil
+}
+
+func (o *ec2TestOperation) RebootInstance(ctx context.Context, instanceID string) error {
+	o.reboots++
+	return nil
+}
+
+// newEC2TestModel returns a model showing the instance filter form
+func newEC2TestModel(operation *ec2TestOperation) *model.Model {
+	m := newTestModel(&testProvider{ec2Instances: operation})
+	result, _ := HandleEC2Filters(m)
+	return result.(ModelWrapper).Model
+}
+
+// selectEC2Row moves the cursor to the row starting with a value and selects it
+func selectEC2Row(t *testing.T, m *model.Model, value string) (*model.Model, tea.Cmd) {
+	t.Helper()
+	for i, row := range m.Table.Rows() {
+		if row[0] == value {
+			m.Table.SetCursor(i)
+			result, cmd := HandleTableSelect(m)
+			return result.(ModelWrapper).Model, cmd
+		}
+	}
+	t.Fatalf("Expected a row %q, got %v", value, m.Table.Rows())
+	return m, nil
+}
+
+func TestEC2Filters(t *testing.T) {
+	operation := &ec2TestOperation{}
+	m := newEC2TestModel(operation)
+
+	// The state filter cycles through every state and back to all of them
+	m, _ = selectEC2Row(t, m, constants.SettingInstanceState)
+	if m.EC2Filter.State != cloud.EC2StateRunning {
+		t.Errorf("Expected running instances, got %q", m.EC2Filter.State)
+	}
+	for range cloud.EC2InstanceStates {
+		m, _ = selectEC2Row(t, m, constants.SettingInstanceState)
+	}
+	if m.EC2Filter.State != "" {
+		t.Errorf("Expected every state after a full cycle, got %q", m.EC2Filter.State)
+	}
+	m, _ = selectEC2Row(t, m, constants.SettingInstanceState)
+
+	m, _ = selectEC2Row(t, m, constants.SettingInstanceTag)
+	if !m.ManualInput {
+		t.Fatalf("Expected to be asked for a tag")
+	}
+	if _, cmd := HandleEC2FilterInput(m, "=prod"); cmd == nil {
+		t.Errorf("Expected an error for a tag value without a key")
+	}
+	result, _ := HandleEC2FilterInput(m, " env = prod ")
+	m = result.(ModelWrapper).Model
+	if m.EC2Filter.TagKey != "env" || m.EC2Filter.TagValue != "prod" || m.ManualInput {
+		t.Fatalf("Expected the env=prod tag, got %+v", m.EC2Filter)
+	}
+
+	m, list := selectEC2Row(t, m, constants.SettingListInstances)
+	m = HandleEC2Instances(m, list().(model.EC2InstancesMsg))
+	if operation.filter != m.EC2Filter {
+		t.Errorf("Expected the instances to be listed with %+v, got %+v", m.EC2Filter, operation.filter)
+	}
+	if m.CurrentView != constants.ViewEC2Instances || len(m.Table.Rows()) != 2 {
+		t.Fatalf("Expected both instances, got view %v with %d rows", m.CurrentView, len(m.Table.Rows()))
+	}
+
+	// Going back keeps the filter to list again with
+	m = NavigateBack(m)
+	if m.CurrentView != constants.ViewEC2Filters || m.EC2Filter.Tag() != "env=prod" {
+		t.Errorf("Expected the filter form with the tag, got view %v with %+v", m.CurrentView, m.EC2Filter)
+	}
+}
+
+func TestEC2InstanceActions(t *testing.T) {
+	operation := &ec2TestOperation{}
+	m := newEC2TestModel(operation)
+	m = HandleEC2Instances(m, model.EC2InstancesMsg{Instances: func() []cloud.EC2Instance {
+		instances, _ := operation.ListInstances(context.Background(), cloud.EC2InstanceFilter{})
+		return instances
+	}()})
+
+	testCases := []struct {
+		name     string
+		row      int
+		actions  []string
+		action   string
+		verify   func() bool
+		expected string
+	}{
+		{
+			name:     "Stop a running instance",
+			row:      0,
+			actions:  []string{constants.ActionStopInstance, constants.ActionRebootInstance},
+			action:   constants.ActionStopInstance,
+			verify:   func() bool { return operation.stopped == "i-web" },
+			expected: "Instance web (i-web) is now stopping",
+		},
+		{
+			name:     "Reboot a running instance",
+			row:      0,
+			actions:  []string{constants.ActionStopInstance, constants.ActionRebootInstance},
+			action:   constants.ActionRebootInstance,
+			verify:   func() bool { return operation.reboots == 1 },
+			expected: "Rebooted instance web (i-web)",
+		},
+		{
+			name:     "Start a stopped instance",
+			row:      1,
+			actions:  []string{constants.ActionStartInstance},
+			action:   constants.ActionStartInstance,
+			verify:   func() bool { return operation.started == "i-batch" },
+			expected: "Instance i-batch is now pending",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m.Table.SetCursor(tc.row)
+			result, cmd := HandleTableSelect(m)
+			details := HandleEC2InstanceDetails(result.(ModelWrapper).Model, cmd().(model.EC2InstanceDetailsMsg))
+			if details.CurrentView != constants.ViewEC2InstanceDetails {
+				t.Fatalf("Expected the instance details, got view %v", details.CurrentView)
+			}
+
+			var actions []string
+			for _, row := range details.Table.Rows() {
+				if strings.HasSuffix(row[0], " Instance") {
+					actions = append(actions, row[0])
+				}
+			}
+			if strings.Join(actions, ",") != strings.Join(tc.actions, ",") {
+				t.Errorf("Expected actions %v, got %v", tc.actions, actions)
+			}
+
+			// Actions wait for confirmation, and going back returns to the details
+			pending, _ := selectEC2Row(t, details, tc.action)
+			if pending.CurrentView != constants.ViewExecutingAction || pending.PendingAction == nil {
+				t.Fatalf("Expected the action to wait for confirmation, got view %v", pending.CurrentView)
+			}
+			if tc.verify() {
+				t.Fatalf("Expected nothing to change before confirming")
+			}
+			if back := NavigateBack(pending); back.CurrentView != constants.ViewEC2InstanceDetails {
+				t.Errorf("Expected to go back to the details, got view %v", back.CurrentView)
+			}
+
+			pending.Table.SetCursor(0)
+			result, cmd = HandleExecutionSelection(pending)
+			done := HandleActionResult(result.(ModelWrapper).Model, cmd().(model.ActionResultMsg))
+			if !tc.verify() || done.Success != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, done.Success)
+			}
+		})
+	}
+}
+
+func TestEC2StopWarnings(t *testing.T) {
+	details := &cloud.EC2InstanceDetails{EC2Instance: cloud.EC2Instance{ID: "i-web", PublicIP: "203.0.113.10"}}
+	if warnings := view.EC2StopWarnings(details); len(warnings) != 1 || !strings.Contains(warnings[0], "203.0.113.10") {
+		t.Errorf("Expected a warning about the public IP, got %v", warnings)
+	}
+	details.PublicIP = ""
+	if warnings := view.EC2StopWarnings(details); len(warnings) != 0 {
+		t.Errorf("Expected no warnings without a public IP, got %v", warnings)
+	}
+}
@@ -0,0 +1,295 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleECSClustersLoad lists the ECS clusters of the account
+func HandleECSClustersLoad(m *model.Model) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingClusters
+
+	return WrapModel(newModel), func() tea.Msg {
+		serviceOperation, err := getECSServiceOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		clusters, err := serviceOperation.ListClusters(context.Background())
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.ECSClustersMsg{Clusters: clusters}
+	}
+}
+
+// HandleECSClusters shows the clusters with their service and task counts
+func HandleECSClusters(m *model.Model, msg model.ECSClustersMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.ECSClusters = msg.Clusters
+	newModel.ECSCluster = ""
+	newModel.ECSServices = nil
+	newModel.CurrentView = constants.ViewECSClusters
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleECSClusterSelection lists the services of the selected cluster
+func HandleECSClusterSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	// Rows are in the order of the clusters
+	cursor := m.Table.Cursor()
+	if len(m.Table.Rows()) == 0 || cursor < 0 || cursor >= len(m.ECSClusters) {
+		return WrapModel(m), nil
+	}
+	return loadECSServices(m, m.ECSClusters[cursor].Name)
+}
+
+// HandleECSServices shows the services of a cluster with their task counts and rollout state
+func HandleECSServices(m *model.Model, msg model.ECSServicesMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.ECSCluster = msg.Cluster
+	newModel.ECSServices = msg.Services
+	newModel.SelectedECSService = nil
+	newModel.CurrentView = constants.ViewECSServices
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleECSServiceSelection shows the deployments, events and actions of the selected service
+func HandleECSServiceSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	// Rows are in the order of the services
+	cursor := m.Table.Cursor()
+	if len(m.Table.Rows()) == 0 || cursor < 0 || cursor >= len(m.ECSServices) {
+		return WrapModel(m), nil
+	}
+
+	service := m.ECSServices[cursor]
+	newModel := m.Clone()
+	newModel.SelectedECSService = &service
+	newModel.ECSTasks = nil
+	newModel.CurrentView = constants.ViewECSServiceDetails
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleECSServiceDetailsSelection handles the selection of a row in the service details; only
+// the action rows do anything
+func HandleECSServiceDetailsSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 {
+		return WrapModel(m), nil
+	}
+
+	switch selected[0] {
+	case constants.ActionViewTasks:
+		return HandleECSTasksLoad(m)
+	case constants.ActionForceDeployment:
+		return HandleECSForceDeployment(m)
+	case constants.ActionScaleService:
+		if m.SelectedECSService == nil {
+			return WrapModel(m), nil
+		}
+		newModel := m.Clone()
+		newModel.ManualInput = true
+		newModel.TextInput.Placeholder = constants.MsgEnterDesiredCount
+		newModel.TextInput.SetValue(strconv.Itoa(int(m.SelectedECSService.DesiredCount)))
+		newModel.TextInput.Focus()
+		return WrapModel(newModel), nil
+	default:
+		return WrapModel(m), nil
+	}
+}
+
+// HandleECSForceDeployment asks for confirmation before starting a deployment that replaces the
+// tasks of the selected service
+func HandleECSForceDeployment(m *model.Model) (tea.Model, tea.Cmd) {
+	service := m.SelectedECSService
+	if service == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoECSService)}
+		}
+	}
+
+	serviceOperation, err := getECSServiceOperation(m)
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	cluster, name := service.Cluster, service.Name
+	newModel := m.Clone()
+	newModel.PendingAction = &model.PendingAction{
+		Description: fmt.Sprintf("%s of %s", constants.ActionForceDeployment, name),
+		Details: []string{
+			fmt.Sprintf("Service: %s", name),
+			fmt.Sprintf("Cluster: %s", cluster),
+			fmt.Sprintf("Task Definition: %s", service.TaskDefinition),
+			fmt.Sprintf("Tasks: %d running of %d desired", service.RunningCount, service.DesiredCount),
+		},
+		LoadingMsg: constants.MsgForcingDeployment,
+		BackView:   constants.ViewECSServiceDetails,
+		Run: func(ctx context.Context) (string, error) {
+			if err := serviceOperation.ForceNewDeployment(ctx, cluster, name); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf(constants.MsgForceDeploySuccess, name), nil
+		},
+	}
+	newModel.CurrentView = constants.ViewExecutingAction
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleECSScaleInput asks for confirmation before changing the desired count of the selected
+// service to the count entered
+func HandleECSScaleInput(m *model.Model, value string) (tea.Model, tea.Cmd) {
+	service := m.SelectedECSService
+	if service == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoECSService)}
+		}
+	}
+
+	count, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+	if err != nil || count < 0 {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorDesiredCount)}
+		}
+	}
+
+	serviceOperation, err := getECSServiceOperation(m)
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	cluster, name, desired := service.Cluster, service.Name, int32(count)
+	details := []string{
+		fmt.Sprintf("Service: %s", name),
+		fmt.Sprintf("Cluster: %s", cluster),
+		fmt.Sprintf("Desired Count: %d → %d", service.DesiredCount, desired),
+	}
+	if desired == 0 {
+		details = append(details, view.ECSScaleToZeroWarning())
+	}
+
+	newModel := m.Clone()
+	newModel.ManualInput = false
+	newModel.ResetTextInput()
+	newModel.PendingAction = &model.PendingAction{
+		Description: fmt.Sprintf("Scale %s to %d tasks", name, desired),
+		Details:     details,
+		LoadingMsg:  constants.MsgScalingService,
+		BackView:    constants.ViewECSServiceDetails,
+		Run: func(ctx context.Context) (string, error) {
+			if err := serviceOperation.SetDesiredCount(ctx, cluster, name, desired); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf(constants.MsgScaleSuccess, name, desired), nil
+		},
+	}
+	newModel.CurrentView = constants.ViewExecutingAction
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleECSTasksLoad lists the running and recently stopped tasks of the selected service
+func HandleECSTasksLoad(m *model.Model) (tea.Model, tea.Cmd) {
+	service := m.SelectedECSService
+	if service == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoECSService)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingTasks
+	cluster, name := service.Cluster, service.Name
+
+	return WrapModel(newModel), func() tea.Msg {
+		serviceOperation, err := getECSServiceOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		tasks, err := serviceOperation.ListTasks(context.Background(), cluster, name)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.ECSTasksMsg{Tasks: tasks}
+	}
+}
+
+// HandleECSTasks shows the tasks of the selected service
+func HandleECSTasks(m *model.Model, msg model.ECSTasksMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.ECSTasks = msg.Tasks
+	newModel.SelectedECSTask = nil
+	newModel.CurrentView = constants.ViewECSTasks
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleECSTaskSelection shows the containers of the selected task
+func HandleECSTaskSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	// Rows are in the order of the tasks
+	cursor := m.Table.Cursor()
+	if len(m.Table.Rows()) == 0 || cursor < 0 || cursor >= len(m.ECSTasks) {
+		return WrapModel(m), nil
+	}
+
+	task := m.ECSTasks[cursor]
+	newModel := m.Clone()
+	newModel.SelectedECSTask = &task
+	newModel.CurrentView = constants.ViewECSTaskDetails
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// loadECSServices lists the services of a cluster
+func loadECSServices(m *model.Model, cluster string) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingECSServices
+
+	return WrapModel(newModel), func() tea.Msg {
+		serviceOperation, err := getECSServiceOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		services, err := serviceOperation.ListServices(context.Background(), cluster)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.ECSServicesMsg{Cluster: cluster, Services: services}
+	}
+}
+
+// getECSServiceOperation gets the ECS service operation from the selected provider
+func getECSServiceOperation(m *model.Model) (cloud.ECSServiceOperation, error) {
+	provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetECSServiceOperation()
+}
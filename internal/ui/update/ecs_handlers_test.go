@@ -0,0 +1,199 @@
+package update
+
+import (
+	"context"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ecsTestOperation lists a cluster with a service mid-rollout, and records the deployments forced
+// and the desired counts set
+type ecsTestOperation struct {
+	cloud.ECSServiceOperation
+	deployed []string
+	desired  map[string]int32
+}
+
+func (o *ecsTestOperation) ListClusters(ctx context.Context) ([]cloud.ECSCluster, error) {
+	return []cloud.ECSCluster{{Name: "prod", Status: "ACTIVE", ActiveServices: 1, RunningTasks: 2}}, nil
+}
+
+func (o *ecsTestOperation) ListServices(ctx context.Context, cluster string) ([]cloud.ECSService, error) {
+	return []cloud.ECSService{{
+		Name:           "web",
+		Cluster:        cluster,
+		TaskDefinition: "web:7",
+		DesiredCount:   2,
+		RunningCount:   2,
+		Deployments: []cloud.ECSDeployment{
+			{Status: cloud.ECSPrimaryDeployment, TaskDefinition: "web:7", RolloutState: cloud.ECSRolloutInProgress, DesiredCount: 2, RunningCount: 1},
+			{Status: "ACTIVE", TaskDefinition: "web:6", DesiredCount: 2, RunningCount: 1},
+		},
+		Events: []cloud.ECSServiceEvent{{Message: "(service web) has started 1 tasks"}},
+	}}, nil
+}
+
+func (o *ecsTestOperation) ListTasks(ctx context.Context, cluster, service string) ([]cloud.ECSTask, error) {
+	exitCode := int32(137)
+	return []cloud.ECSTask{
+		{ID: "1a2b", LastStatus: "RUNNING", Containers: []cloud.ECSContainer{{Name: "app", LastStatus: "RUNNING"}}},
+		{ID: "3c4d", LastStatus: "STOPPED", StoppedReason: "Essential container in task exited",
+			Containers: []cloud.ECSContainer{{Name: "app", LastStatus: "STOPPED", ExitCode: &exitCode}}},
+	}, nil
+}
+
+func (o *ecsTestOperation) ForceNewDeployment(ctx context.Context, cluster, service string) error {
+	o.deployed = append(o.deployed, cluster+"/"+service)
+	return nil
+}
+
+func (o *ecsTestOperation) SetDesiredCount(ctx context.Context, cluster, service string, count int32) error {
+	if o.desired == nil {
+		o.desired = make(map[string]int32)
+	}
+	o.desired[cluster+"/"+service] = count
+	return nil
+}
+
+// newECSTestModel returns a model showing the details of the web service
+func newECSTestModel(t *testing.T, operation *ecsTestOperation) *model.Model {
+	t.Helper()
+	m := newTestModel(&testProvider{ecsServices: operation})
+	result, cmd := HandleECSClustersLoad(m)
+	m = HandleECSClusters(result.(ModelWrapper).Model, cmd().(model.ECSClustersMsg))
+
+	result, cmd = HandleTableSelect(m)
+	m = HandleECSServices(result.(ModelWrapper).Model, cmd().(model.ECSServicesMsg))
+	if m.CurrentView != constants.ViewECSServices || m.ECSCluster != "prod" {
+		t.Fatalf("Expected the services of prod, got view %v", m.CurrentView)
+	}
+	if rollout := m.Table.Rows()[0][4]; rollout != cloud.ECSRolloutInProgress {
+		t.Errorf("Expected the rollout state of the primary deployment, got %q", rollout)
+	}
+
+	result, _ = HandleTableSelect(m)
+	m = result.(ModelWrapper).Model
+	if m.CurrentView != constants.ViewECSServiceDetails || m.SelectedECSService.Name != "web" {
+		t.Fatalf("Expected the details of web, got view %v", m.CurrentView)
+	}
+	return m
+}
+
+// selectECSRow moves the cursor to the row starting with a value and selects it
+func selectECSRow(t *testing.T, m *model.Model, value string) (*model.Model, tea.Cmd) {
+	t.Helper()
+	for i, row := range m.Table.Rows() {
+		if row[0] == value {
+			m.Table.SetCursor(i)
+			result, cmd := HandleTableSelect(m)
+			return result.(ModelWrapper).Model, cmd
+		}
+	}
+	t.Fatalf("Expected a row %q, got %v", value, m.Table.Rows())
+	return m, nil
+}
+
+// confirmECSAction confirms the pending action and returns the model showing its result
+func confirmECSAction(t *testing.T, m *model.Model) *model.Model {
+	t.Helper()
+	if m.CurrentView != constants.ViewExecutingAction || m.PendingAction == nil {
+		t.Fatalf("Expected the action to wait for confirmation, got view %v", m.CurrentView)
+	}
+	m.Table.SetCursor(0)
+	result, cmd := HandleExecutionSelection(m)
+	return HandleActionResult(result.(ModelWrapper).Model, cmd().(model.ActionResultMsg))
+}
+
+func TestECSTasks(t *testing.T) {
+	m := newECSTestModel(t, &ecsTestOperation{})
+
+	tasks, cmd := selectECSRow(t, m, constants.ActionViewTasks)
+	tasks = HandleECSTasks(tasks, cmd().(model.ECSTasksMsg))
+	if tasks.CurrentView != constants.ViewECSTasks || len(tasks.Table.Rows()) != 2 {
+		t.Fatalf("Expected both tasks, got view %v with %d rows", tasks.CurrentView, len(tasks.Table.Rows()))
+	}
+	if reason := tasks.Table.Rows()[1][5]; reason != "Essential container in task exited" {
+		t.Errorf("Expected the stopped reason of the stopped task, got %q", reason)
+	}
+
+	details, _ := selectECSRow(t, tasks, "3c4d")
+	if details.CurrentView != constants.ViewECSTaskDetails {
+		t.Fatalf("Expected the details of the stopped task, got view %v", details.CurrentView)
+	}
+	rows := details.Table.Rows()
+	if container := rows[len(rows)-1]; container[0] != "Container: app" || container[1] != "STOPPED, exit code 137" {
+		t.Errorf("Expected the status and exit code of the container, got %v", container)
+	}
+
+	// Going back returns through the tasks to the service details
+	back := NavigateBack(NavigateBack(details))
+	if back.CurrentView != constants.ViewECSServiceDetails || back.SelectedECSService == nil {
+		t.Errorf("Expected to go back to the service details, got view %v", back.CurrentView)
+	}
+}
+
+func TestECSForceDeployment(t *testing.T) {
+	operation := &ecsTestOperation{}
+	m := newECSTestModel(t, operation)
+
+	pending, _ := selectECSRow(t, m, constants.ActionForceDeployment)
+	if len(operation.deployed) != 0 {
+		t.Fatalf("Expected nothing to change before confirming")
+	}
+	done := confirmECSAction(t, pending)
+	if len(operation.deployed) != 1 || operation.deployed[0] != "prod/web" {
+		t.Errorf("Expected a deployment of prod/web, got %v", operation.deployed)
+	}
+	if done.Success != "Started a new deployment of service web" {
+		t.Errorf("Expected the deployment to succeed, got %q", done.Success)
+	}
+}
+
+func TestECSScaleService(t *testing.T) {
+	testCases := []struct {
+		name    string
+		value   string
+		want    int32
+		wantErr bool
+	}{
+		{name: "Scale up", value: "4", want: 4},
+		{name: "Scale to zero", value: " 0 ", want: 0},
+		{name: "Negative count", value: "-1", wantErr: true},
+		{name: "Not a number", value: "two", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			operation := &ecsTestOperation{}
+			m := newECSTestModel(t, operation)
+
+			input, _ := selectECSRow(t, m, constants.ActionScaleService)
+			if !input.ManualInput || input.TextInput.Value() != "2" {
+				t.Fatalf("Expected to edit the current desired count, got %q", input.TextInput.Value())
+			}
+
+			input.TextInput.SetValue(tc.value)
+			result, cmd := HandleTextInputSubmission(input)
+			pending := result.(ModelWrapper).Model
+			if tc.wantErr {
+				if msg, ok := cmd().(model.ErrMsg); !ok || msg.Err.Error() != constants.MsgErrorDesiredCount {
+					t.Errorf("Expected the count to be rejected, got %v", cmd())
+				}
+				return
+			}
+
+			done := confirmECSAction(t, pending)
+			got, ok := operation.desired["prod/web"]
+			if !ok || got != tc.want {
+				t.Errorf("Expected a desired count of %d, got %d", tc.want, got)
+			}
+			if done.Err != nil {
+				t.Errorf("Expected scaling to succeed, got %v", done.Err)
+			}
+		})
+	}
+}
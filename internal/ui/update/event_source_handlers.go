@@ -0,0 +1,176 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleEventSourceScope shows the choice between the mappings of all functions or of one function
+func HandleEventSourceScope(m *model.Model) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.EventSourceMappings = nil
+	newModel.SelectedEventSource = nil
+	newModel.EventSourceFunction = ""
+	newModel.CurrentView = constants.ViewEventSourceScope
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleEventSourceScopeSelection handles the selection of a scope
+func HandleEventSourceScopeSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 {
+		return WrapModel(m), nil
+	}
+
+	switch selected[0] {
+	case constants.ScopeAllFunctions:
+		return HandleEventSourceLoad(m)
+	case constants.ScopeSingleFunction:
+		// Pick the function from the function list first
+		newModel := m.Clone()
+		newModel.IsExecuteLambdaFlow = false
+		return HandleFunctionStatus(newModel)
+	default:
+		return WrapModel(m), nil
+	}
+}
+
+// HandleEventSourceLoad loads the event source mappings of the selected function, or of all
+// functions if none is selected
+func HandleEventSourceLoad(m *model.Model) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingEventSources
+	newModel.EventSourceFunction = ""
+	if m.SelectedFunction != nil {
+		newModel.EventSourceFunction = m.SelectedFunction.Name
+	}
+	functionName := newModel.EventSourceFunction
+
+	return WrapModel(newModel), func() tea.Msg {
+		// Get the provider
+		provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the LambdaEventSourceOperation from the provider
+		eventSourceOperation, err := provider.GetLambdaEventSourceOperation()
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// List the mappings in scope
+		ctx := context.Background()
+		mappings, err := eventSourceOperation.ListEventSourceMappings(ctx, functionName)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.EventSourceMappingsMsg{Mappings: mappings}
+	}
+}
+
+// HandleEventSourceMappings shows the loaded event source mappings
+func HandleEventSourceMappings(m *model.Model, msg model.EventSourceMappingsMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.EventSourceMappings = msg.Mappings
+	newModel.SelectedEventSource = nil
+	newModel.CurrentView = constants.ViewEventSourceMappings
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleEventSourceSelection shows the details of the selected event source mapping
+func HandleEventSourceSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	// Rows are in the order of the mappings, which may share a function and source name
+	cursor := m.Table.Cursor()
+	if len(m.Table.Rows()) == 0 || cursor < 0 || cursor >= len(m.EventSourceMappings) {
+		return WrapModel(m), nil
+	}
+
+	newModel := m.Clone()
+	mapping := m.EventSourceMappings[cursor]
+	newModel.SelectedEventSource = &mapping
+	newModel.CurrentView = constants.ViewEventSourceDetails
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleEventSourceDetailsSelection handles the selection of a row in the details view;
+// only the enable/disable action row does anything
+func HandleEventSourceDetailsSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 {
+		return WrapModel(m), nil
+	}
+
+	switch selected[0] {
+	case constants.ActionEnableMapping:
+		return HandleEventSourceToggle(m, true)
+	case constants.ActionDisableMapping:
+		return HandleEventSourceToggle(m, false)
+	default:
+		return WrapModel(m), nil
+	}
+}
+
+// HandleEventSourceToggle asks for confirmation before enabling or disabling the selected mapping
+func HandleEventSourceToggle(m *model.Model, enabled bool) (tea.Model, tea.Cmd) {
+	if m.SelectedEventSource == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoEventSource)}
+		}
+	}
+
+	// Get the provider
+	provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	// Get the LambdaEventSourceOperation from the provider
+	eventSourceOperation, err := provider.GetLambdaEventSourceOperation()
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	mapping := *m.SelectedEventSource
+	action := "Disable"
+	if enabled {
+		action = "Enable"
+	}
+
+	newModel := m.Clone()
+	newModel.PendingAction = &model.PendingAction{
+		Description: fmt.Sprintf("%s mapping from %s to %s", action, mapping.SourceType, mapping.FunctionName),
+		Details: []string{
+			fmt.Sprintf("Source: %s", mapping.EventSourceArn),
+			fmt.Sprintf("State: %s → %sd", mapping.State, action),
+		},
+		LoadingMsg: constants.MsgUpdatingEventSource,
+		BackView:   constants.ViewEventSourceDetails,
+		Run: func(ctx context.Context) (string, error) {
+			updated, err := eventSourceOperation.SetEventSourceMappingEnabled(ctx, mapping.UUID, enabled)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf(constants.MsgEventSourceSuccess, mapping.UUID, mapping.FunctionName, strings.ToLower(updated.State)), nil
+		},
+	}
+	newModel.CurrentView = constants.ViewExecutingAction
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
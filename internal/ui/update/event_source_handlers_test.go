@@ -0,0 +1,202 @@
+package update
+
+import (
+	"context"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+)
+
+// eventSourceTestOperation serves a fixed set of mappings and records state changes
+type eventSourceTestOperation struct {
+	mappings  []cloud.EventSourceMapping
+	listedFor []string
+	updated   map[string]bool
+}
+
+func (o *eventSourceTestOperation) Name() string        { return "Event Source Mappings" }
+func (o *eventSourceTestOperation) Description() string { return "Inspect Event Sources" }
+func (o *eventSourceTestOperation) IsUIVisible() bool   { return true }
+
+func (o *eventSourceTestOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func (o *eventSourceTestOperation) ListEventSourceMappings(ctx context.Context, functionName string) ([]cloud.EventSourceMapping, error) {
+	o.listedFor = append(o.listedFor, functionName)
+	return o.mappings, nil
+}
+
+func (o *eventSourceTestOperation) SetEventSourceMappingEnabled(ctx context.Context, uuid string, enabled bool) (*cloud.EventSourceMapping, error) {
+	if o.updated == nil {
+		o.updated = make(map[string]bool)
+	}
+	o.updated[uuid] = enabled
+
+	state := "Disabling"
+	if enabled {
+		state = "Enabling"
+	}
+	return &cloud.EventSourceMapping{UUID: uuid, State: state}, nil
+}
+
+// newEventSourceTestModel creates a model in the event source mapping flow
+func newEventSourceTestModel(operation *eventSourceTestOperation) *model.Model {
+	m := newTestModel(&testProvider{lambdaEventSources: operation})
+	m.SelectedOperation = &model.Operation{Name: "Event Source Mappings"}
+	return m
+}
+
+// testEventSourceMappings returns two mappings of the same function
+func testEventSourceMappings() []cloud.EventSourceMapping {
+	return []cloud.EventSourceMapping{
+		{
+			UUID:           "uuid-1",
+			FunctionName:   "orders",
+			EventSourceArn: "arn:aws:sqs:us-east-1:123456789012:orders-queue",
+			SourceType:     "SQS",
+			State:          "Enabled",
+			BatchSize:      10,
+		},
+		{
+			UUID:           "uuid-2",
+			FunctionName:   "orders",
+			EventSourceArn: "arn:aws:dynamodb:us-east-1:123456789012:table/Orders/stream/2024-01-01T00:00:00.000",
+			SourceType:     "DynamoDB",
+			State:          "Disabled",
+			BatchSize:      100,
+			FilterCriteria: []string{`{"eventName":["INSERT"]}`},
+		},
+	}
+}
+
+func TestHandleEventSourceLoad(t *testing.T) {
+	t.Run("All functions", func(t *testing.T) {
+		operation := &eventSourceTestOperation{mappings: testEventSourceMappings()}
+		m := newEventSourceTestModel(operation)
+
+		result, cmd := HandleEventSourceLoad(m)
+		if cmd == nil {
+			t.Fatalf("Expected a command loading the mappings")
+		}
+		if !result.(ModelWrapper).Model.IsLoading {
+			t.Errorf("Expected the model to be loading")
+		}
+
+		msg, ok := cmd().(model.EventSourceMappingsMsg)
+		if !ok {
+			t.Fatalf("Expected an EventSourceMappingsMsg")
+		}
+		if len(operation.listedFor) != 1 || operation.listedFor[0] != "" {
+			t.Errorf("Expected the mappings of all functions to be listed, got %v", operation.listedFor)
+		}
+
+		newModel := HandleEventSourceMappings(result.(ModelWrapper).Model, msg)
+		if newModel.CurrentView != constants.ViewEventSourceMappings {
+			t.Errorf("Expected view to be ViewEventSourceMappings, got %v", newModel.CurrentView)
+		}
+		if len(newModel.Table.Rows()) != 2 {
+			t.Errorf("Expected 2 rows, got %d", len(newModel.Table.Rows()))
+		}
+	})
+
+	t.Run("Single function", func(t *testing.T) {
+		operation := &eventSourceTestOperation{}
+		m := newEventSourceTestModel(operation)
+		m.SetSelectedFunction(&cloud.FunctionStatus{Name: "orders"})
+
+		result, cmd := HandleEventSourceLoad(m)
+		cmd()
+		if len(operation.listedFor) != 1 || operation.listedFor[0] != "orders" {
+			t.Errorf("Expected the mappings of orders to be listed, got %v", operation.listedFor)
+		}
+		if result.(ModelWrapper).Model.EventSourceFunction != "orders" {
+			t.Errorf("Expected the mappings to be scoped to orders")
+		}
+	})
+}
+
+func TestHandleEventSourceToggle(t *testing.T) {
+	operation := &eventSourceTestOperation{mappings: testEventSourceMappings()}
+	m := HandleEventSourceMappings(newEventSourceTestModel(operation), model.EventSourceMappingsMsg{Mappings: operation.mappings})
+
+	// Rows share the function name, so the mapping is picked by position
+	m.Table.SetCursor(1)
+	result, _ := HandleEventSourceSelection(m)
+	details := result.(ModelWrapper).Model
+	if details.SelectedEventSource == nil || details.SelectedEventSource.UUID != "uuid-2" {
+		t.Fatalf("Expected uuid-2 to be selected, got %+v", details.SelectedEventSource)
+	}
+
+	// The last row toggles the state of the mapping
+	rows := details.Table.Rows()
+	if action := rows[len(rows)-1][0]; action != constants.ActionEnableMapping {
+		t.Fatalf("Expected the last row to be %q, got %q", constants.ActionEnableMapping, action)
+	}
+	details.Table.SetCursor(len(rows) - 1)
+
+	result, cmd := HandleEventSourceDetailsSelection(details)
+	if cmd != nil {
+		t.Fatalf("Expected no command, got one returning %v", cmd())
+	}
+	confirm := result.(ModelWrapper).Model
+	if confirm.CurrentView != constants.ViewExecutingAction || confirm.PendingAction == nil {
+		t.Fatalf("Expected a pending action awaiting confirmation")
+	}
+	if confirm.PendingAction.BackView != constants.ViewEventSourceDetails {
+		t.Errorf("Expected to go back to the details view, got %v", confirm.PendingAction.BackView)
+	}
+
+	resultMsg := ExecutePendingAction(confirm)().(model.ActionResultMsg)
+	if resultMsg.Err != nil {
+		t.Fatalf("Expected no error, got %v", resultMsg.Err)
+	}
+	if enabled, ok := operation.updated["uuid-2"]; !ok || !enabled {
+		t.Errorf("Expected uuid-2 to be enabled, got %v", operation.updated)
+	}
+
+	expected := "Event source mapping uuid-2 for orders is now enabling"
+	if resultMsg.Message != expected {
+		t.Errorf("Expected message %q, got %q", expected, resultMsg.Message)
+	}
+}
+
+func TestEventSourceNavigateBack(t *testing.T) {
+	mappings := testEventSourceMappings()
+
+	t.Run("All functions", func(t *testing.T) {
+		m := newEventSourceTestModel(&eventSourceTestOperation{})
+		m.EventSourceMappings = mappings
+		m.CurrentView = constants.ViewEventSourceMappings
+		view.UpdateTableForView(m)
+
+		if back := NavigateBack(m); back.CurrentView != constants.ViewEventSourceScope {
+			t.Errorf("Expected view to be ViewEventSourceScope, got %v", back.CurrentView)
+		}
+	})
+
+	t.Run("Single function", func(t *testing.T) {
+		m := newEventSourceTestModel(&eventSourceTestOperation{})
+		m.EventSourceMappings = mappings
+		m.EventSourceFunction = "orders"
+		m.SetSelectedFunction(&cloud.FunctionStatus{Name: "orders"})
+		m.CurrentView = constants.ViewEventSourceMappings
+
+		back := NavigateBack(m)
+		if back.CurrentView != constants.ViewFunctionStatus {
+			t.Errorf("Expected view to be ViewFunctionStatus, got %v", back.CurrentView)
+		}
+		if back.SelectedFunction != nil || back.EventSourceFunction != "" {
+			t.Errorf("Expected the function scope to be cleared")
+		}
+
+		// The function list goes back to the choice of scope
+		back = NavigateBack(back)
+		if back.CurrentView != constants.ViewEventSourceScope {
+			t.Errorf("Expected view to be ViewEventSourceScope, got %v", back.CurrentView)
+		}
+	})
+}
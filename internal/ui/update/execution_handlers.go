@@ -1,6 +1,9 @@
 package update
 
 import (
+	"context"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
 	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
 	"github.com/HenryOwenz/cloudgate/internal/ui/model"
 	"github.com/HenryOwenz/cloudgate/internal/ui/view"
@@ -114,6 +117,13 @@ func HandleExecutionSelection(m *model.Model) (tea.Model, tea.Cmd) {
 		if selected[0] == "Execute" {
 			// Start loading and execute the action
 			newModel.IsLoading = true
+			if m.PendingAction != nil {
+				newModel.LoadingMsg = constants.MsgApplyingChanges
+				if m.PendingAction.LoadingMsg != "" {
+					newModel.LoadingMsg = m.PendingAction.LoadingMsg
+				}
+				return WrapModel(newModel), ExecutePendingAction(m)
+			}
 			if m.SelectedOperation != nil && m.SelectedOperation.Name == "Start Pipeline" {
 				return WrapModel(newModel), ExecutePipeline(m)
 			}
@@ -127,6 +137,13 @@ func HandleExecutionSelection(m *model.Model) (tea.Model, tea.Cmd) {
 			newModel.CommitID = ""
 			newModel.ManualCommitID = false
 			newModel.ResetTextInput()
+			newModel.PendingAction = nil
+			newModel.FunctionConfig = nil
+			newModel.FunctionConfigUpdate = cloud.FunctionConfigUpdate{}
+			newModel.DeployRequest = cloud.LambdaDeployRequest{}
+			newModel.EventSourceMappings = nil
+			newModel.SelectedEventSource = nil
+			newModel.EventSourceFunction = ""
 
 			// Reset pagination state
 			newModel.Pagination.Type = model.PaginationTypeNone
@@ -152,3 +169,157 @@ func HandleExecutionSelection(m *model.Model) (tea.Model, tea.Cmd) {
 	}
 	return WrapModel(m), nil
 }
+
+// actionProgressBuffer is how many progress updates can wait for the UI to read them
+const actionProgressBuffer = 16
+
+// ExecutePendingAction runs the pending action awaiting confirmation
+func ExecutePendingAction(m *model.Model) tea.Cmd {
+	action := m.PendingAction
+	if action.RunWithProgress != nil {
+		return func() tea.Msg {
+			return startActionProgress(action)
+		}
+	}
+	return func() tea.Msg {
+		message, err := action.Run(context.Background())
+		return model.ActionResultMsg{Message: message, Err: err}
+	}
+}
+
+// startActionProgress runs an action that reports its progress in the background and returns
+// the first message of its progress; the rest are read with WaitForActionProgress
+func startActionProgress(action *model.PendingAction) tea.Msg {
+	stream := make(chan model.ActionProgressMsg, actionProgressBuffer)
+
+	go func() {
+		defer close(stream)
+		lastPercent := -1
+		message, err := action.RunWithProgress(context.Background(), func(done, total int64) {
+			// Only report whole percents, and drop updates the UI hasn't caught up with as a
+			// later one supersedes them
+			percent := 100
+			if total > 0 {
+				percent = int(done * 100 / total)
+			}
+			if percent == lastPercent {
+				return
+			}
+			lastPercent = percent
+			select {
+			case stream <- model.ActionProgressMsg{Done: done, Total: total}:
+			default:
+			}
+		})
+		stream <- model.ActionProgressMsg{Finished: true, Message: message, Err: err}
+	}()
+
+	return readActionProgress(stream)
+}
+
+// WaitForActionProgress returns a command that reads the next progress message of an action,
+// or nil once the action has finished
+func WaitForActionProgress(msg model.ActionProgressMsg) tea.Cmd {
+	if msg.Finished || msg.Stream == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return readActionProgress(msg.Stream)
+	}
+}
+
+// readActionProgress blocks until the next progress message of an action arrives
+func readActionProgress(stream <-chan model.ActionProgressMsg) tea.Msg {
+	msg, ok := <-stream
+	if !ok {
+		return nil
+	}
+	msg.Stream = stream
+	return msg
+}
+
+// HandleActionProgress shows how far a running action has got, and its result once it's finished
+func HandleActionProgress(m *model.Model, msg model.ActionProgressMsg) *model.Model {
+	if msg.Finished {
+		return HandleActionResult(m, model.ActionResultMsg{Message: msg.Message, Err: msg.Err})
+	}
+
+	newModel := m.Clone()
+	newModel.ActionProgress = &model.ActionProgress{Done: msg.Done, Total: msg.Total}
+	return newModel
+}
+
+// HandleActionResult handles the result of a pending action
+func HandleActionResult(m *model.Model, msg model.ActionResultMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.ActionProgress = nil
+
+	// Stay on the confirmation view so the action can be retried or cancelled
+	if msg.Err != nil {
+		newModel.Err = msg.Err
+		return newModel
+	}
+
+	newModel.Success = msg.Message
+	newModel.PendingAction = nil
+	newModel.FunctionConfig = nil
+	newModel.FunctionConfigUpdate = cloud.FunctionConfigUpdate{}
+	newModel.DeployRequest = cloud.LambdaDeployRequest{}
+	newModel.EventSourceMappings = nil
+	newModel.SelectedEventSource = nil
+	newModel.EventSourceFunction = ""
+	newModel.SetSelectedFunction(nil)
+
+	// Reset pagination state
+	newModel.Pagination.Type = model.PaginationTypeNone
+	newModel.Pagination.CurrentPage = 1
+	newModel.Pagination.HasMorePages = false
+	newModel.Pagination.AllItems = make([]interface{}, 0)
+	newModel.Pagination.FilteredItems = make([]interface{}, 0)
+	newModel.Pagination.TotalItems = 0
+
+	// Reset search state
+	newModel.Search.IsActive = false
+	newModel.Search.Query = ""
+	newModel.Search.FilteredItems = make([]interface{}, 0)
+
+	// Navigate back to the operation selection view
+	newModel.CurrentView = constants.ViewSelectOperation
+
+	// Clear all lists to force a refresh next time
+	newModel.Pipelines = nil
+	newModel.Functions = nil
+	newModel.Approvals = nil
+	newModel.Alarms = nil
+	newModel.LinkedAlarms = nil
+	newModel.ECSClusters = nil
+	newModel.ECSServices = nil
+	newModel.SelectedECSService = nil
+	newModel.ECSTasks = nil
+	newModel.BuildProjects = nil
+	newModel.Builds = nil
+	newModel.Stacks = nil
+	newModel.SelectedStack = nil
+	newModel.ChangeSets = nil
+	newModel.SelectedChangeSet = nil
+	newModel.Parameters = nil
+	newModel.SelectedParameter = nil
+	newModel.ParameterValue = nil
+	newModel.ParameterHistory = nil
+	newModel.Secrets = nil
+	newModel.SelectedSecret = nil
+	newModel.SecretValue = nil
+	newModel.SecretKeys = nil
+	newModel.Queues = nil
+	newModel.SelectedQueue = nil
+	newModel.QueueMessages = nil
+	newModel.SelectedQueueMessage = nil
+	newModel.Topics = nil
+	newModel.SelectedTopic = nil
+	newModel.Subscriptions = nil
+	newModel.SelectedSubscription = nil
+
+	view.UpdateTableForView(newModel)
+	return newModel
+}
@@ -0,0 +1,119 @@
+package update
+
+import (
+	"context"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// LoadFunctionDescriptions marks the functions shown by the current view that weren't described
+// yet as requested, and returns a command describing them in a single batch. It returns nil if
+// there is nothing to describe.
+func LoadFunctionDescriptions(m *model.Model) tea.Cmd {
+	var functions []model.FunctionStatus
+	switch {
+	case m.CurrentView == constants.ViewFunctionStatus:
+		for _, function := range m.Functions {
+			if !m.DescribedFunctions[function.Name] {
+				functions = append(functions, function)
+			}
+		}
+	case m.CurrentView == constants.ViewFunctionDetails && m.SelectedFunction != nil:
+		if !m.DescribedFunctions[m.SelectedFunction.Name] {
+			functions = append(functions, *m.SelectedFunction)
+		}
+	}
+
+	if len(functions) == 0 {
+		return nil
+	}
+
+	if m.DescribedFunctions == nil {
+		m.DescribedFunctions = make(map[string]bool)
+	}
+	for _, function := range functions {
+		m.DescribedFunctions[function.Name] = true
+	}
+
+	registry := m.Registry
+	providerName := m.ProviderState.ProviderName
+
+	return func() tea.Msg {
+		described, err := describeFunctions(registry, providerName, functions)
+		if err != nil {
+			// Show the functions as listed, with why they couldn't be described
+			described = make([]model.FunctionStatus, len(functions))
+			for i, function := range functions {
+				function.Errors = []string{err.Error()}
+				described[i] = function
+			}
+		}
+		return model.FunctionDescriptionsMsg{Functions: described}
+	}
+}
+
+// describeFunctions describes functions with the function status operation of the provider
+func describeFunctions(registry *cloud.ProviderRegistry, providerName string, functions []model.FunctionStatus) ([]model.FunctionStatus, error) {
+	provider, err := registry.Get(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	functionOperation, err := provider.GetFunctionStatusOperation()
+	if err != nil {
+		return nil, err
+	}
+
+	return functionOperation.DescribeFunctions(context.Background(), functions)
+}
+
+// HandleFunctionDescriptions puts described functions in place of their listed versions, in the
+// current page, the items searched and paged through, and the selection
+func HandleFunctionDescriptions(m *model.Model, msg model.FunctionDescriptionsMsg) *model.Model {
+	described := make(map[string]model.FunctionStatus, len(msg.Functions))
+	for _, function := range msg.Functions {
+		described[function.Name] = function
+	}
+
+	newModel := m.Clone()
+	newModel.Functions = make([]model.FunctionStatus, len(m.Functions))
+	for i, function := range m.Functions {
+		if update, ok := described[function.Name]; ok {
+			function = update
+		}
+		newModel.Functions[i] = function
+	}
+	newModel.Pagination.AllItems = replaceDescribedFunctions(m.Pagination.AllItems, described)
+	newModel.Pagination.FilteredItems = replaceDescribedFunctions(m.Pagination.FilteredItems, described)
+	if m.SelectedFunction != nil {
+		if update, ok := described[m.SelectedFunction.Name]; ok {
+			newModel.SetSelectedFunction(&update)
+		}
+	}
+
+	if newModel.CurrentView == constants.ViewFunctionStatus || newModel.CurrentView == constants.ViewFunctionDetails {
+		refreshTable(newModel)
+	}
+	return newModel
+}
+
+// replaceDescribedFunctions returns a copy of paginated items with the described functions in
+// place of their listed versions
+func replaceDescribedFunctions(items []interface{}, described map[string]model.FunctionStatus) []interface{} {
+	if items == nil {
+		return nil
+	}
+	replaced := make([]interface{}, len(items))
+	for i, item := range items {
+		if function, ok := item.(model.FunctionStatus); ok {
+			if update, ok := described[function.Name]; ok {
+				item = update
+			}
+		}
+		replaced[i] = item
+	}
+	return replaced
+}
@@ -0,0 +1,61 @@
+package update
+
+import (
+	"context"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// describeTestOperation describes functions as failed to update and records those it describes
+type describeTestOperation struct {
+	cloud.FunctionStatusOperation
+	described []string
+}
+
+func (o *describeTestOperation) DescribeFunctions(ctx context.Context, functions []cloud.FunctionStatus) ([]cloud.FunctionStatus, error) {
+	described := make([]cloud.FunctionStatus, len(functions))
+	for i, function := range functions {
+		o.described = append(o.described, function.Name)
+		function.State = "Active"
+		function.LastUpdateStatus = "Failed"
+		function.Tags = map[string]string{"team": "payments"}
+		described[i] = function
+	}
+	return described, nil
+}
+
+func TestLoadFunctionDescriptions(t *testing.T) {
+	operation := &describeTestOperation{}
+	m := newTestModel(&testProvider{functionStatus: operation})
+	m.Width = 100
+	m.CurrentView = constants.ViewFunctionStatus
+	listed := []model.FunctionStatus{{Name: "orders"}, {Name: "billing"}, {Name: "refunds"}}
+	for _, function := range listed {
+		m.Pagination.AllItems = append(m.Pagination.AllItems, function)
+	}
+	// Only the first two functions are on the shown page
+	m.Functions = listed[:2]
+
+	cmd := LoadFunctionDescriptions(m)
+	if cmd == nil {
+		t.Fatalf("Expected the shown functions to be described")
+	}
+	m = HandleFunctionDescriptions(m, cmd().(model.FunctionDescriptionsMsg))
+	if len(operation.described) != 2 || operation.described[0] != "orders" || operation.described[1] != "billing" {
+		t.Errorf("Expected only the shown functions to be described, got %v", operation.described)
+	}
+	if m.Functions[0].LastUpdateStatus != "Failed" || m.Functions[1].Tags["team"] != "payments" {
+		t.Errorf("Expected the described functions to be shown, got %+v", m.Functions)
+	}
+	if m.Pagination.AllItems[1].(model.FunctionStatus).State != "Active" || m.Pagination.AllItems[2].(model.FunctionStatus).State != "" {
+		t.Errorf("Expected only the described functions to be searchable by state, got %+v", m.Pagination.AllItems)
+	}
+
+	// Functions aren't described twice
+	if cmd := LoadFunctionDescriptions(m); cmd != nil {
+		t.Errorf("Expected no more functions to describe")
+	}
+}
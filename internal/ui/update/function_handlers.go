@@ -78,9 +78,25 @@ func HandleFunctionSelection(m *model.Model) (tea.Model, tea.Cmd) {
 			return HandleLambdaExecuteSelection(newModel)
 		}
 
+		// In the configuration flow, load the full configuration of the function
+		if newModel.SelectedOperation != nil && newModel.SelectedOperation.Name == "Configure Function" {
+			return HandleLambdaConfigLoad(newModel)
+		}
+
+		// In the deployment flow, go straight to the deploy form
+		if newModel.SelectedOperation != nil && newModel.SelectedOperation.Name == "Deploy Code" {
+			return HandleLambdaDeploy(newModel)
+		}
+
+		// In the event source mapping flow, load the mappings of the function
+		if newModel.SelectedOperation != nil && newModel.SelectedOperation.Name == "Event Source Mappings" {
+			return HandleEventSourceLoad(newModel)
+		}
+
 		newModel.CurrentView = constants.ViewFunctionDetails
+		cmd := tea.Batch(LoadFunctionMetrics(newModel), LoadFunctionDescriptions(newModel), LoadLinkedAlarms(newModel))
 		view.UpdateTableForView(newModel)
-		return WrapModel(newModel), nil
+		return WrapModel(newModel), cmd
 	}
 	return WrapModel(m), nil
 }
@@ -115,6 +131,7 @@ func HandleLambdaExecuteSelection(m *model.Model) (tea.Model, tea.Cmd) {
 	newModel.CurrentView = constants.ViewLambdaExecute
 	newModel.SetLambdaPayload("{}")
 	newModel.SetLambdaResult(nil)
+	newModel.LambdaPayloadError = nil
 	newModel.IsLambdaInputMode = false // Start in command mode (not input mode)
 
 	return WrapModel(newModel), nil
@@ -137,9 +154,30 @@ func HandleLambdaExecute(m *model.Model) (tea.Model, tea.Cmd) {
 	// Set the payload in the model
 	newModel := m.Clone()
 	newModel.SetLambdaPayload(payload)
+
+	// Validate the payload before invoking, pointing the cursor at any error
+	newModel.LambdaPayloadError = view.ValidateJSON(payload)
+	if newModel.LambdaPayloadError != nil {
+		moveTextAreaCursor(&newModel.TextArea, newModel.LambdaPayloadError.Line, newModel.LambdaPayloadError.Column)
+		return WrapModel(newModel), nil
+	}
+
 	newModel.IsLoading = true
 	newModel.LoadingMsg = constants.MsgExecutingLambda
 
+	// Stop an earlier invocation, and number this one so chunks of an earlier stream are ignored
+	stopLambdaInvoke(newModel)
+	newModel.LambdaStreamID++
+	streamID := newModel.LambdaStreamID
+	invokeMode := m.LambdaInvokeMode
+	ctx, cancel := context.WithCancel(context.Background())
+	newModel.LambdaInvokeCancel = cancel
+
+	// Reuse the invoke mode looked up for the function by an earlier invocation
+	functionName := m.SelectedFunction.Name
+	modeKnown := m.ResponseStreamingFunction == functionName
+	knownStreaming := m.ResponseStreaming
+
 	return WrapModel(newModel), func() tea.Msg {
 		// Get the provider
 		provider, err := m.Registry.Get(m.ProviderState.ProviderName)
@@ -153,15 +191,39 @@ func HandleLambdaExecute(m *model.Model) (tea.Model, tea.Cmd) {
 			return model.LambdaExecuteResultMsg{Err: err}
 		}
 
+		// Stream the response if asked to, or if the function is configured to stream.
+		// Functions whose invoke mode can't be read are invoked with a buffered response,
+		// and looked up again next time.
+		streaming := invokeMode == constants.InvokeModeStreaming
+		var lookedUp *bool
+		if invokeMode == constants.InvokeModeAuto {
+			streaming = knownStreaming
+			if !modeKnown {
+				var err error
+				streaming, err = lambdaOperation.IsResponseStreaming(ctx, functionName)
+				if err == nil {
+					lookedUp = &streaming
+				}
+			}
+		}
+		if streaming {
+			msg := startLambdaStream(ctx, lambdaOperation, functionName, payload, streamID)
+			if first, ok := msg.(model.LambdaStreamMsg); ok {
+				first.ResponseStreaming = lookedUp
+				return first
+			}
+			return msg
+		}
+
 		// Execute the Lambda function
-		ctx := context.Background()
-		result, err := lambdaOperation.ExecuteFunction(ctx, m.SelectedFunction.Name, payload)
+		result, err := lambdaOperation.ExecuteFunction(ctx, functionName, payload)
 		if err != nil {
-			return model.LambdaExecuteResultMsg{Err: err}
+			return model.LambdaExecuteResultMsg{Err: err, ResponseStreaming: lookedUp}
 		}
 
 		return model.LambdaExecuteResultMsg{
-			Result: result,
+			Result:            result,
+			ResponseStreaming: lookedUp,
 		}
 	}
 }
@@ -0,0 +1,145 @@
+package update
+
+import (
+	"context"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// LoadFunctionMetrics marks the metrics needed by the current view as loading and returns
+// a command fetching them in a single batch. It returns nil if there is nothing to fetch.
+func LoadFunctionMetrics(m *model.Model) tea.Cmd {
+	var functionNames []string
+	switch {
+	case m.CurrentView == constants.ViewFunctionStatus && m.ShowFunctionMetrics:
+		for _, function := range m.Functions {
+			if _, requested := m.FunctionMetrics[function.Name]; !requested {
+				functionNames = append(functionNames, function.Name)
+			}
+		}
+	case m.CurrentView == constants.ViewFunctionDetails && m.SelectedFunction != nil:
+		if _, requested := m.FunctionMetrics[m.SelectedFunction.Name]; !requested {
+			functionNames = append(functionNames, m.SelectedFunction.Name)
+		}
+	}
+
+	if len(functionNames) == 0 {
+		return nil
+	}
+
+	if m.FunctionMetrics == nil {
+		m.FunctionMetrics = make(map[string]*cloud.FunctionMetrics)
+	}
+	for _, name := range functionNames {
+		m.FunctionMetrics[name] = nil
+	}
+
+	registry := m.Registry
+	providerName := m.ProviderState.ProviderName
+	window := m.FunctionMetricsWindow
+
+	return func() tea.Msg {
+		msg := model.FunctionMetricsMsg{
+			FunctionNames: functionNames,
+			Window:        window,
+		}
+
+		// Get the provider
+		provider, err := registry.Get(providerName)
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+
+		// Get the LambdaMetricsOperation from the provider
+		metricsOperation, err := provider.GetLambdaMetricsOperation()
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+
+		ctx := context.Background()
+		msg.Metrics, msg.Err = metricsOperation.GetFunctionMetrics(ctx, functionNames, window)
+		return msg
+	}
+}
+
+// HandleFunctionMetricsKey toggles the metric columns or switches the metrics window
+func HandleFunctionMetricsKey(m *model.Model, key string) (tea.Model, tea.Cmd) {
+	if m.CurrentView != constants.ViewFunctionStatus && m.CurrentView != constants.ViewFunctionDetails {
+		return WrapModel(m), nil
+	}
+
+	newModel := m.Clone()
+	switch key {
+	case constants.KeyToggleMetrics:
+		if m.CurrentView != constants.ViewFunctionStatus {
+			return WrapModel(m), nil
+		}
+		newModel.ShowFunctionMetrics = !m.ShowFunctionMetrics
+	case constants.KeyMetricsWindow:
+		if m.CurrentView == constants.ViewFunctionStatus && !m.ShowFunctionMetrics {
+			return WrapModel(m), nil
+		}
+		newModel.FunctionMetricsWindow = constants.MetricsWindowLong
+		if m.FunctionMetricsWindow == constants.MetricsWindowLong {
+			newModel.FunctionMetricsWindow = constants.MetricsWindowShort
+		}
+		// Metrics for the previous window no longer apply
+		newModel.FunctionMetrics = nil
+	default:
+		return WrapModel(m), nil
+	}
+
+	cmd := LoadFunctionMetrics(newModel)
+	refreshTable(newModel)
+	return WrapModel(newModel), cmd
+}
+
+// HandleFunctionMetrics stores loaded metrics and refreshes the current view
+func HandleFunctionMetrics(m *model.Model, msg model.FunctionMetricsMsg) *model.Model {
+	// Ignore metrics for a window that is no longer shown
+	if msg.Window != m.FunctionMetricsWindow {
+		return m
+	}
+
+	newModel := m.Clone()
+	if newModel.FunctionMetrics == nil {
+		newModel.FunctionMetrics = make(map[string]*cloud.FunctionMetrics)
+	}
+
+	if msg.Err != nil {
+		// Forget the failed requests and hide the columns so they aren't retried on every page
+		for _, name := range msg.FunctionNames {
+			delete(newModel.FunctionMetrics, name)
+		}
+		newModel.ShowFunctionMetrics = false
+		newModel.Err = msg.Err
+	} else {
+		for _, name := range msg.FunctionNames {
+			if metrics, ok := msg.Metrics[name]; ok {
+				newModel.FunctionMetrics[name] = &metrics
+			} else {
+				delete(newModel.FunctionMetrics, name)
+			}
+		}
+	}
+
+	if newModel.CurrentView == constants.ViewFunctionStatus || newModel.CurrentView == constants.ViewFunctionDetails {
+		refreshTable(newModel)
+	}
+	return newModel
+}
+
+// refreshTable rebuilds the table for the current view, keeping the selected row
+func refreshTable(m *model.Model) {
+	cursor := m.Table.Cursor()
+	view.UpdateTableForView(m)
+	if cursor < len(m.Table.Rows()) {
+		m.Table.SetCursor(cursor)
+	}
+}
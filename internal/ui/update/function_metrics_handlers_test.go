@@ -0,0 +1,123 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+)
+
+// metricsTestOperation records the batches of functions it is asked for
+type metricsTestOperation struct {
+	err      error
+	requests [][]string
+}
+
+func (o *metricsTestOperation) Name() string        { return "Function Metrics" }
+func (o *metricsTestOperation) Description() string { return "View Recent Lambda Function Metrics" }
+func (o *metricsTestOperation) IsUIVisible() bool   { return false }
+
+func (o *metricsTestOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func (o *metricsTestOperation) GetFunctionMetrics(ctx context.Context, functionNames []string, window time.Duration) (map[string]cloud.FunctionMetrics, error) {
+	o.requests = append(o.requests, functionNames)
+	if o.err != nil {
+		return nil, o.err
+	}
+
+	metrics := make(map[string]cloud.FunctionMetrics)
+	for _, name := range functionNames {
+		metrics[name] = cloud.FunctionMetrics{Window: window, Invocations: []float64{1, 2}}
+	}
+	return metrics, nil
+}
+
+// newMetricsTestModel creates a model showing a page of functions
+func newMetricsTestModel(operation *metricsTestOperation) *model.Model {
+	m := newTestModel(&testProvider{lambdaMetrics: operation})
+	m.CurrentView = constants.ViewFunctionStatus
+	m.Functions = []model.FunctionStatus{{Name: "function-a"}, {Name: "function-b"}}
+	view.UpdateTableForView(m)
+	return m
+}
+
+func TestFunctionMetricsToggle(t *testing.T) {
+	operation := &metricsTestOperation{}
+	m := newMetricsTestModel(operation)
+
+	// The window can't be switched while metrics are hidden
+	if _, cmd := HandleFunctionMetricsKey(m, constants.KeyMetricsWindow); cmd != nil {
+		t.Errorf("Expected no command while metrics are hidden")
+	}
+
+	result, cmd := HandleFunctionMetricsKey(m, constants.KeyToggleMetrics)
+	shown := result.(ModelWrapper).Model
+	if !shown.ShowFunctionMetrics {
+		t.Fatalf("Expected metrics to be shown")
+	}
+	if cmd == nil {
+		t.Fatalf("Expected a command loading the metrics")
+	}
+
+	// All visible functions are fetched in a single batch
+	msg, ok := cmd().(model.FunctionMetricsMsg)
+	if !ok {
+		t.Fatalf("Expected a FunctionMetricsMsg")
+	}
+	if len(operation.requests) != 1 || len(operation.requests[0]) != 2 {
+		t.Errorf("Expected one batch of 2 functions, got %v", operation.requests)
+	}
+
+	loaded := HandleFunctionMetrics(shown, msg)
+	if loaded.FunctionMetrics["function-a"] == nil || loaded.FunctionMetrics["function-b"] == nil {
+		t.Errorf("Expected metrics to be stored for both functions")
+	}
+
+	// Loaded metrics aren't fetched again
+	if cmd := LoadFunctionMetrics(loaded); cmd != nil {
+		t.Errorf("Expected no command when metrics are already loaded")
+	}
+
+	// Switching the window discards the loaded metrics and fetches the new window
+	result, cmd = HandleFunctionMetricsKey(loaded, constants.KeyMetricsWindow)
+	switched := result.(ModelWrapper).Model
+	if switched.FunctionMetricsWindow != constants.MetricsWindowLong {
+		t.Errorf("Expected window to be %v, got %v", constants.MetricsWindowLong, switched.FunctionMetricsWindow)
+	}
+	if cmd == nil {
+		t.Fatalf("Expected a command loading the metrics for the new window")
+	}
+
+	// Metrics for the previous window are ignored
+	if stale := HandleFunctionMetrics(switched, msg); stale.FunctionMetrics["function-a"] != nil {
+		t.Errorf("Expected metrics for the previous window to be ignored")
+	}
+}
+
+func TestFunctionMetricsError(t *testing.T) {
+	m := newMetricsTestModel(&metricsTestOperation{err: errors.New("access denied")})
+	m.ShowFunctionMetrics = true
+
+	cmd := LoadFunctionMetrics(m)
+	if cmd == nil {
+		t.Fatalf("Expected a command loading the metrics")
+	}
+
+	result := HandleFunctionMetrics(m, cmd().(model.FunctionMetricsMsg))
+	if result.Err == nil {
+		t.Errorf("Expected the error to be shown")
+	}
+	if result.ShowFunctionMetrics {
+		t.Errorf("Expected metrics to be hidden after an error")
+	}
+	if _, requested := result.FunctionMetrics["function-a"]; requested {
+		t.Errorf("Expected failed requests to be forgotten")
+	}
+}
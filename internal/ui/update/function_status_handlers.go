@@ -2,13 +2,13 @@ package update
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
 
 	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
 	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -82,6 +82,7 @@ func HandleFunctionStatusOperation(m *model.Model) (tea.Model, tea.Cmd) {
 func HandleLambdaExecuteResult(m *model.Model, result *model.LambdaExecuteResultMsg) *model.Model {
 	newModel := m.Clone()
 	newModel.IsLoading = false
+	rememberResponseStreaming(newModel, result.ResponseStreaming)
 
 	if result.Err != nil {
 		newModel.Err = result.Err
@@ -96,30 +97,11 @@ func HandleLambdaExecuteResult(m *model.Model, result *model.LambdaExecuteResult
 	// Store the result in the model
 	newModel.SetLambdaResult(result.Result)
 
-	// Format the payload for display
-	var formattedPayload string
-	if result.Result.Payload != "" {
-		// Try to pretty-print the JSON
-		var jsonObj interface{}
-		if err := json.Unmarshal([]byte(result.Result.Payload), &jsonObj); err == nil {
-			if prettyJSON, err := json.MarshalIndent(jsonObj, "", "  "); err == nil {
-				formattedPayload = string(prettyJSON)
-			} else {
-				formattedPayload = result.Result.Payload
-			}
-		} else {
-			formattedPayload = result.Result.Payload
-		}
-	} else {
-		formattedPayload = "(empty response)"
-	}
-
-	// Create the content for the viewport
-	content := fmt.Sprintf("Status Code: %d\nExecuted Version: %s\n\nResponse:\n%s\n\nLogs:\n%s",
-		result.Result.StatusCode,
-		result.Result.ExecutedVersion,
-		formattedPayload,
-		result.Result.LogResult)
+	// Show the whole response, fully expanded
+	newModel.LambdaResponseQuery = ""
+	newModel.LambdaResponseDepth = 0
+	newModel.ShowLambdaLogs = false
+	content := view.LambdaResponseContent(newModel)
 
 	// Initialize a new viewport (following the example pattern)
 	// We'll set initial dimensions, but these will be updated on WindowSizeMsg
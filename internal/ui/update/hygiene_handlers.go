@@ -0,0 +1,238 @@
+package update
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxUnusedDays is how far back CloudWatch keeps hourly Lambda metrics
+const maxUnusedDays = 455
+
+// HandleHygieneOptions shows the hygiene report options, starting with the configured region
+// and the default thresholds
+func HandleHygieneOptions(m *model.Model) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.HygieneOptions = cloud.HygieneReportOptions{
+		Regions:        []string{m.AwsRegion},
+		UnusedDays:     constants.DefaultUnusedDays,
+		MaxPackageSize: constants.DefaultMaxPackageSize * 1024 * 1024,
+	}
+	newModel.HygieneField = ""
+	newModel.HygieneReport = nil
+	newModel.CurrentView = constants.ViewHygieneOptions
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleHygieneOptionsSelection handles the selection of a row in the options form
+func HandleHygieneOptionsSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 {
+		return WrapModel(m), nil
+	}
+
+	newModel := m.Clone()
+	switch selected[0] {
+	case constants.SettingRunReport:
+		return HandleHygieneRun(m)
+	case constants.SettingRegions:
+		newModel.TextInput.Placeholder = constants.MsgEnterRegions
+	case constants.SettingUnusedDays:
+		newModel.TextInput.Placeholder = constants.MsgEnterUnusedDays
+	case constants.SettingMaxPackageSize:
+		newModel.TextInput.Placeholder = constants.MsgEnterMaxPackageSize
+	default:
+		return WrapModel(m), nil
+	}
+
+	newModel.HygieneField = selected[0]
+	newModel.ManualInput = true
+	newModel.TextInput.SetValue("")
+	newModel.TextInput.Focus()
+	return WrapModel(newModel), nil
+}
+
+// HandleHygieneInput applies the value entered for the setting being edited
+func HandleHygieneInput(m *model.Model, value string) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	value = strings.TrimSpace(value)
+	options := &newModel.HygieneOptions
+
+	switch m.HygieneField {
+	case constants.SettingRegions:
+		// An empty list keeps the current regions
+		var regions []string
+		for _, region := range strings.Split(value, ",") {
+			if region = strings.TrimSpace(region); region != "" {
+				regions = append(regions, region)
+			}
+		}
+		if len(regions) > 0 {
+			options.Regions = regions
+		}
+	case constants.SettingUnusedDays:
+		days, err := strconv.Atoi(value)
+		if err != nil {
+			return WrapModel(m), func() tea.Msg {
+				return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorInvalidNumber, value)}
+			}
+		}
+		if days < 1 || days > maxUnusedDays {
+			return WrapModel(m), func() tea.Msg {
+				return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorUnusedDays)}
+			}
+		}
+		options.UnusedDays = days
+	case constants.SettingMaxPackageSize:
+		size, err := strconv.Atoi(value)
+		if err != nil || size < 1 {
+			return WrapModel(m), func() tea.Msg {
+				return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorInvalidNumber, value)}
+			}
+		}
+		options.MaxPackageSize = int64(size) * 1024 * 1024
+	}
+
+	newModel.HygieneField = ""
+	newModel.ManualInput = false
+	newModel.ResetTextInput()
+	refreshTable(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleHygieneRun scans the functions of the selected regions
+func HandleHygieneRun(m *model.Model) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgGeneratingReport
+	options := m.HygieneOptions
+
+	return WrapModel(newModel), func() tea.Msg {
+		// Get the provider
+		provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the LambdaHygieneOperation from the provider
+		hygieneOperation, err := provider.GetLambdaHygieneOperation()
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		report, err := hygieneOperation.GenerateHygieneReport(context.Background(), options)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.HygieneReportMsg{Report: report}
+	}
+}
+
+// HandleHygieneReport shows the findings of a hygiene report
+func HandleHygieneReport(m *model.Model, msg model.HygieneReportMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.HygieneReport = msg.Report
+	newModel.CurrentView = constants.ViewHygieneReport
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleHygieneExportKey asks where to export the report, suggesting a CSV file named after its date
+func HandleHygieneExportKey(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.CurrentView != constants.ViewHygieneReport || m.HygieneReport == nil {
+		return WrapModel(m), nil
+	}
+
+	newModel := m.Clone()
+	newModel.ManualInput = true
+	newModel.TextInput.Placeholder = constants.MsgEnterExportPath
+	newModel.TextInput.SetValue(fmt.Sprintf("lambda-hygiene-%s.csv", m.HygieneReport.GeneratedAt.Format("2006-01-02")))
+	newModel.TextInput.Focus()
+	return WrapModel(newModel), nil
+}
+
+// HandleHygieneExport writes the report to a file, as CSV or JSON depending on its extension
+func HandleHygieneExport(m *model.Model, path string) (tea.Model, tea.Cmd) {
+	if m.HygieneReport == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoReport)}
+		}
+	}
+
+	path = strings.TrimSpace(path)
+	var write func(io.Writer, *cloud.HygieneReport) error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		write = writeHygieneCSV
+	case ".json":
+		write = writeHygieneJSON
+	default:
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorExportFormat)}
+		}
+	}
+
+	if err := writeHygieneFile(path, m.HygieneReport, write); err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.ManualInput = false
+	newModel.ResetTextInput()
+	newModel.Success = fmt.Sprintf(constants.MsgExportSuccess, len(m.HygieneReport.Findings), path)
+	return WrapModel(newModel), nil
+}
+
+// writeHygieneFile creates a file and writes the report to it
+func writeHygieneFile(path string, report *cloud.HygieneReport, write func(io.Writer, *cloud.HygieneReport) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := write(file, report); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// writeHygieneCSV writes a row for each finding, for spreadsheets and scripts
+func writeHygieneCSV(w io.Writer, report *cloud.HygieneReport) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"Severity", "Region", "Function", "Function ARN", "Runtime", "Check", "Detail"}); err != nil {
+		return err
+	}
+	for _, finding := range report.Findings {
+		record := []string{finding.Severity, finding.Region, finding.FunctionName, finding.FunctionArn, finding.Runtime, finding.Check, finding.Detail}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeHygieneJSON writes the whole report, including what couldn't be scanned
+func writeHygieneJSON(w io.Writer, report *cloud.HygieneReport) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
@@ -0,0 +1,182 @@
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// hygieneTestOperation records the options of each report and returns a fixed report
+type hygieneTestOperation struct {
+	options []cloud.HygieneReportOptions
+	report  *cloud.HygieneReport
+}
+
+func (o *hygieneTestOperation) Name() string        { return "Hygiene Report" }
+func (o *hygieneTestOperation) Description() string { return "Flag Deprecated Runtimes" }
+func (o *hygieneTestOperation) IsUIVisible() bool   { return true }
+
+func (o *hygieneTestOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func (o *hygieneTestOperation) GenerateHygieneReport(ctx context.Context, options cloud.HygieneReportOptions) (*cloud.HygieneReport, error) {
+	o.options = append(o.options, options)
+	return o.report, nil
+}
+
+// testHygieneReport returns a report with a finding that needs quoting in CSV
+func testHygieneReport() *cloud.HygieneReport {
+	return &cloud.HygieneReport{
+		GeneratedAt:      time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		Regions:          []string{"us-east-1", "eu-west-1"},
+		FunctionsScanned: 3,
+		Findings: []cloud.HygieneFinding{
+			{
+				Region:       "us-east-1",
+				FunctionName: "orders",
+				FunctionArn:  "arn:aws:lambda:us-east-1:123456789012:function:orders",
+				Runtime:      "python3.8",
+				Check:        cloud.HygieneCheckDeprecatedRuntime,
+				Severity:     cloud.SeverityHigh,
+				Detail:       "python3.8 was deprecated on 2024-10-14",
+			},
+			{
+				Region:       "eu-west-1",
+				FunctionName: "reports",
+				Check:        cloud.HygieneCheckOversizedPackage,
+				Severity:     cloud.SeverityLow,
+				Detail:       "Package is 60.0 MB, over the 50.0 MB limit",
+			},
+		},
+		Errors: []string{"eu-west-1: unused check skipped: access denied"},
+	}
+}
+
+func TestHygieneOptionsInput(t *testing.T) {
+	m := model.New()
+	m.AwsRegion = "us-east-1"
+	result, _ := HandleHygieneOptions(m)
+	m = result.(ModelWrapper).Model
+
+	if m.CurrentView != constants.ViewHygieneOptions || len(m.HygieneOptions.Regions) != 1 || m.HygieneOptions.Regions[0] != "us-east-1" {
+		t.Fatalf("Expected the options form for the configured region, got %+v", m.HygieneOptions)
+	}
+
+	m.HygieneField = constants.SettingRegions
+	result, _ = HandleHygieneInput(m, " us-east-1, eu-west-1 ,, ")
+	m = result.(ModelWrapper).Model
+	if got := strings.Join(m.HygieneOptions.Regions, ","); got != "us-east-1,eu-west-1" {
+		t.Errorf("Expected both regions, got %s", got)
+	}
+
+	m.HygieneField = constants.SettingMaxPackageSize
+	result, _ = HandleHygieneInput(m, "20")
+	m = result.(ModelWrapper).Model
+	if m.HygieneOptions.MaxPackageSize != 20*1024*1024 {
+		t.Errorf("Expected a 20 MB limit, got %d bytes", m.HygieneOptions.MaxPackageSize)
+	}
+
+	// Out of range values are rejected and leave the setting unchanged
+	m.HygieneField = constants.SettingUnusedDays
+	result, cmd := HandleHygieneInput(m, "900")
+	if cmd == nil {
+		t.Fatalf("Expected an error for 900 days")
+	}
+	if _, ok := cmd().(model.ErrMsg); !ok {
+		t.Errorf("Expected an error message")
+	}
+	if days := result.(ModelWrapper).Model.HygieneOptions.UnusedDays; days != constants.DefaultUnusedDays {
+		t.Errorf("Expected the default of %d days to be kept, got %d", constants.DefaultUnusedDays, days)
+	}
+}
+
+func TestHygieneRun(t *testing.T) {
+	operation := &hygieneTestOperation{report: testHygieneReport()}
+	m := newTestModel(&testProvider{lambdaHygiene: operation})
+	m.HygieneOptions = cloud.HygieneReportOptions{Regions: []string{"us-east-1", "eu-west-1"}, UnusedDays: 60}
+
+	result, cmd := HandleHygieneRun(m)
+	if !result.(ModelWrapper).Model.IsLoading {
+		t.Errorf("Expected the model to be loading")
+	}
+
+	msg, ok := cmd().(model.HygieneReportMsg)
+	if !ok {
+		t.Fatalf("Expected a hygiene report message")
+	}
+	if len(operation.options) != 1 || operation.options[0].UnusedDays != 60 {
+		t.Errorf("Expected the report to use the options, got %+v", operation.options)
+	}
+
+	m = HandleHygieneReport(result.(ModelWrapper).Model, msg)
+	if m.CurrentView != constants.ViewHygieneReport || len(m.Table.Rows()) != 2 {
+		t.Fatalf("Expected a row per finding, got %d rows", len(m.Table.Rows()))
+	}
+	if row := m.Table.Rows()[0]; row[0] != cloud.SeverityHigh || row[2] != "orders" {
+		t.Errorf("Expected the deprecated runtime first, got %v", row)
+	}
+}
+
+func TestHygieneExport(t *testing.T) {
+	m := model.New()
+	m.CurrentView = constants.ViewHygieneReport
+	m.HygieneReport = testHygieneReport()
+	dir := t.TempDir()
+
+	t.Run("CSV", func(t *testing.T) {
+		path := filepath.Join(dir, "report.csv")
+		result, cmd := HandleHygieneExport(m, path)
+		if cmd != nil {
+			t.Fatalf("Expected no error, got %v", cmd())
+		}
+		if success := result.(ModelWrapper).Model.Success; !strings.Contains(success, "2 findings") {
+			t.Errorf("Expected a success message, got %q", success)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Expected the file to be written: %v", err)
+		}
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		if len(lines) != 3 || !strings.HasPrefix(lines[0], "Severity,Region,Function") {
+			t.Fatalf("Expected a header and a line per finding, got:\n%s", data)
+		}
+		if !strings.Contains(lines[2], `"Package is 60.0 MB, over the 50.0 MB limit"`) {
+			t.Errorf("Expected the detail to be quoted, got %s", lines[2])
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		path := filepath.Join(dir, "report.JSON")
+		if _, cmd := HandleHygieneExport(m, path); cmd != nil {
+			t.Fatalf("Expected no error, got %v", cmd())
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Expected the file to be written: %v", err)
+		}
+		var report cloud.HygieneReport
+		if err := json.Unmarshal(data, &report); err != nil {
+			t.Fatalf("Expected valid JSON: %v", err)
+		}
+		if len(report.Findings) != 2 || len(report.Errors) != 1 || report.FunctionsScanned != 3 {
+			t.Errorf("Expected the whole report, got %+v", report)
+		}
+	})
+
+	t.Run("Unknown format", func(t *testing.T) {
+		if _, cmd := HandleHygieneExport(m, filepath.Join(dir, "report.txt")); cmd == nil {
+			t.Errorf("Expected an error for a .txt file")
+		}
+	})
+}
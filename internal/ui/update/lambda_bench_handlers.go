@@ -0,0 +1,155 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleLambdaBench shows the benchmark options for the payload in the editor. The payload is
+// validated first, as it is for a single invocation.
+func HandleLambdaBench(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedFunction == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoFunction)}
+		}
+	}
+
+	payload := m.TextArea.Value()
+	if payload == "" {
+		payload = "{}"
+	}
+
+	newModel := m.Clone()
+	newModel.SetLambdaPayload(payload)
+	newModel.LambdaPayloadError = view.ValidateJSON(payload)
+	if newModel.LambdaPayloadError != nil {
+		moveTextAreaCursor(&newModel.TextArea, newModel.LambdaPayloadError.Line, newModel.LambdaPayloadError.Column)
+		return WrapModel(newModel), nil
+	}
+
+	// Keep the options of the previous benchmark, so runs can be compared after changing the function
+	if newModel.BenchOptions.Invocations == 0 {
+		newModel.BenchOptions = cloud.LambdaBenchOptions{
+			Invocations: constants.DefaultBenchInvocations,
+			Concurrency: constants.DefaultBenchConcurrency,
+		}
+	}
+	newModel.BenchField = ""
+	newModel.BenchResult = nil
+	newModel.CurrentView = constants.ViewLambdaBench
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleLambdaBenchSelection handles the selection of a row in the benchmark options form
+func HandleLambdaBenchSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 {
+		return WrapModel(m), nil
+	}
+
+	newModel := m.Clone()
+	switch selected[0] {
+	case constants.SettingRunBenchmark:
+		return HandleLambdaBenchRun(m)
+	case constants.SettingInvocations:
+		newModel.TextInput.Placeholder = constants.MsgEnterInvocations
+	case constants.SettingConcurrency:
+		newModel.TextInput.Placeholder = constants.MsgEnterConcurrency
+	default:
+		return WrapModel(m), nil
+	}
+
+	newModel.BenchField = selected[0]
+	newModel.ManualInput = true
+	newModel.TextInput.SetValue("")
+	newModel.TextInput.Focus()
+	return WrapModel(newModel), nil
+}
+
+// HandleLambdaBenchInput applies the value entered for the setting being edited
+func HandleLambdaBenchInput(m *model.Model, value string) (tea.Model, tea.Cmd) {
+	value = strings.TrimSpace(value)
+	count, err := strconv.Atoi(value)
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorInvalidNumber, value)}
+		}
+	}
+
+	options := m.BenchOptions
+	switch m.BenchField {
+	case constants.SettingInvocations:
+		options.Invocations = count
+	case constants.SettingConcurrency:
+		options.Concurrency = count
+	}
+	if err := options.Validate(); err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.BenchOptions = options
+	newModel.BenchField = ""
+	newModel.ManualInput = false
+	newModel.ResetTextInput()
+	refreshTable(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleLambdaBenchRun invokes the selected function with the payload as many times as the options ask
+func HandleLambdaBenchRun(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedFunction == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoFunction)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgRunningBenchmark
+	functionName := m.SelectedFunction.Name
+	payload := m.LambdaPayload
+	options := m.BenchOptions
+
+	return WrapModel(newModel), func() tea.Msg {
+		// Get the provider
+		provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the LambdaExecuteOperation from the provider
+		lambdaOperation, err := provider.GetLambdaExecuteOperation()
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		result, err := cloud.RunLambdaBench(context.Background(), lambdaOperation, functionName, payload, options)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.LambdaBenchMsg{Result: result}
+	}
+}
+
+// HandleLambdaBenchResult shows the outcome of a benchmark
+func HandleLambdaBenchResult(m *model.Model, msg model.LambdaBenchMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.BenchResult = msg.Result
+	newModel.CurrentView = constants.ViewLambdaBenchResult
+	view.UpdateTableForView(newModel)
+	return newModel
+}
@@ -0,0 +1,171 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/charmbracelet/bubbles/textarea"
+)
+
+// benchTestOperation answers invocations in turn: the first two are cold starts, the fifth
+// fails in the function and the tenth fails to be made
+type benchTestOperation struct {
+	cloud.LambdaExecuteOperation
+	mu       sync.Mutex
+	invokers int
+	calls    int
+	payloads []string
+}
+
+func (o *benchTestOperation) Invoker(ctx context.Context, functionName string) (cloud.LambdaInvoker, error) {
+	o.invokers++
+	return func(ctx context.Context, payload string) (*cloud.LambdaExecuteResult, error) {
+		return o.ExecuteFunction(ctx, functionName, payload)
+	}, nil
+}
+
+func (o *benchTestOperation) ExecuteFunction(ctx context.Context, functionName string, payload string) (*cloud.LambdaExecuteResult, error) {
+	o.mu.Lock()
+	o.calls++
+	call := o.calls
+	o.payloads = append(o.payloads, payload)
+	o.mu.Unlock()
+
+	if call == 10 {
+		return nil, errors.New("throttled")
+	}
+
+	report := fmt.Sprintf("REPORT RequestId: %d Duration: 99.50 ms Billed Duration: %d ms Memory Size: 128 MB Max Memory Used: 64 MB", call, 100+call)
+	if call <= 2 {
+		report += " Init Duration: 250.12 ms"
+	}
+	result := &cloud.LambdaExecuteResult{StatusCode: 200, Payload: "{}", LogResult: "START RequestId: 1\n" + report}
+	if call == 5 {
+		result.FunctionError = "Unhandled"
+	}
+	return result, nil
+}
+
+func TestHandleLambdaBench(t *testing.T) {
+	m := model.New()
+	m.CurrentView = constants.ViewLambdaExecute
+	m.SetSelectedFunction(&cloud.FunctionStatus{Name: "test-function"})
+	m.TextArea = textarea.New()
+
+	// An invalid payload stays in the editor
+	m.TextArea.SetValue(`{"id": }`)
+	result, _ := HandleLambdaBench(m)
+	if invalid := result.(ModelWrapper).Model; invalid.CurrentView != constants.ViewLambdaExecute || invalid.LambdaPayloadError == nil {
+		t.Fatalf("Expected the payload error to be shown in the editor")
+	}
+
+	m.TextArea.SetValue(`{"id": 1}`)
+	result, _ = HandleLambdaBench(m)
+	m = result.(ModelWrapper).Model
+	if m.CurrentView != constants.ViewLambdaBench || m.LambdaPayload != `{"id": 1}` {
+		t.Fatalf("Expected the options form for the payload, got view %v", m.CurrentView)
+	}
+	if m.BenchOptions.Invocations != constants.DefaultBenchInvocations || m.BenchOptions.Concurrency != constants.DefaultBenchConcurrency {
+		t.Errorf("Expected the default options, got %+v", m.BenchOptions)
+	}
+
+	m.BenchField = constants.SettingConcurrency
+	result, _ = HandleLambdaBenchInput(m, " 10 ")
+	m = result.(ModelWrapper).Model
+	if m.BenchOptions.Concurrency != 10 || m.ManualInput {
+		t.Errorf("Expected a concurrency of 10, got %+v", m.BenchOptions)
+	}
+
+	// Out of range values are rejected and leave the setting unchanged
+	m.BenchField = constants.SettingInvocations
+	result, cmd := HandleLambdaBenchInput(m, "5000")
+	if cmd == nil {
+		t.Fatalf("Expected an error for 5000 invocations")
+	}
+	if msg, ok := cmd().(model.ErrMsg); !ok || !errors.Is(msg.Err, cloud.ErrBenchInvocations) {
+		t.Errorf("Expected an invocation count error, got %v", msg)
+	}
+	if invocations := result.(ModelWrapper).Model.BenchOptions.Invocations; invocations != constants.DefaultBenchInvocations {
+		t.Errorf("Expected the default invocations to be kept, got %d", invocations)
+	}
+}
+
+func TestHandleLambdaBenchRun(t *testing.T) {
+	operation := &benchTestOperation{}
+	m := newTestModel(&testProvider{lambdaExecute: operation})
+	m.SetSelectedFunction(&cloud.FunctionStatus{Name: "test-function"})
+	m.LambdaPayload = `{"id": 1}`
+	m.BenchOptions = cloud.LambdaBenchOptions{Invocations: 10, Concurrency: 3}
+
+	result, cmd := HandleLambdaBenchRun(m)
+	if !result.(ModelWrapper).Model.IsLoading {
+		t.Errorf("Expected the model to be loading")
+	}
+
+	msg, ok := cmd().(model.LambdaBenchMsg)
+	if !ok {
+		t.Fatalf("Expected a benchmark message")
+	}
+	if operation.calls != 10 || operation.payloads[0] != `{"id": 1}` {
+		t.Fatalf("Expected 10 invocations with the payload, got %d", operation.calls)
+	}
+	if operation.invokers != 1 {
+		t.Errorf("Expected the invocations to share one client, got %d", operation.invokers)
+	}
+
+	bench := msg.Result
+	if bench.Errors() != 2 || bench.ErrorRate() != 0.2 {
+		t.Errorf("Expected a function error and a failed request, got %d errors", bench.Errors())
+	}
+	if bench.ColdStarts() != 2 {
+		t.Errorf("Expected two cold starts, got %d", bench.ColdStarts())
+	}
+	// 101 to 109 ms were billed; the failed request isn't billed
+	if total := bench.TotalBilled(); total != 945 {
+		t.Errorf("Expected 945 ms billed, got %.0f", total)
+	}
+	histogram := bench.BilledHistogram()
+	counted := 0
+	for _, bucket := range histogram {
+		counted += bucket.Count
+	}
+	if len(histogram) == 0 || histogram[0].Low != 101 || counted != 9 {
+		t.Errorf("Expected the billed invocations to be bucketed from 101 ms, got %+v", histogram)
+	}
+
+	m = HandleLambdaBenchResult(result.(ModelWrapper).Model, msg)
+	if m.CurrentView != constants.ViewLambdaBenchResult || m.IsLoading {
+		t.Fatalf("Expected the benchmark results, got view %v", m.CurrentView)
+	}
+	rows := m.Table.Rows()
+	if len(rows) != 8+len(histogram) {
+		t.Fatalf("Expected the summary and a row per histogram bucket, got %d rows", len(rows))
+	}
+	if rows[1][1] != "2 (20.0%)" || rows[2][1] != "2" {
+		t.Errorf("Expected the error rate and cold starts, got %v and %v", rows[1], rows[2])
+	}
+	if last := rows[len(rows)-1]; !strings.HasPrefix(last[0], "Billed ") || !strings.Contains(last[1], "█") {
+		t.Errorf("Expected a histogram bar, got %v", last)
+	}
+}
+
+func TestHandleLambdaBenchRunFailed(t *testing.T) {
+	operation := &benchTestOperation{calls: 9}
+	m := newTestModel(&testProvider{lambdaExecute: operation})
+	m.SetSelectedFunction(&cloud.FunctionStatus{Name: "test-function"})
+	m.BenchOptions = cloud.LambdaBenchOptions{Invocations: 1, Concurrency: 1}
+
+	// A benchmark where no invocation could be made shows the error
+	_, cmd := HandleLambdaBenchRun(m)
+	msg, ok := cmd().(model.ErrMsg)
+	if !ok || !errors.Is(msg.Err, cloud.ErrBenchFailed) {
+		t.Errorf("Expected the benchmark to fail, got %v", msg)
+	}
+}
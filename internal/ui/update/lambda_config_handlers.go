@@ -0,0 +1,240 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleLambdaConfigLoad loads the full configuration of the selected function
+func HandleLambdaConfigLoad(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedFunction == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoFunction)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingConfig
+	functionName := m.SelectedFunction.Name
+
+	return WrapModel(newModel), func() tea.Msg {
+		// Get the provider
+		provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the LambdaConfigurationOperation from the provider
+		configOperation, err := provider.GetLambdaConfigurationOperation()
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Load the function configuration
+		ctx := context.Background()
+		function, err := configOperation.GetFunctionConfiguration(ctx, functionName)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.LambdaConfigurationMsg{Function: function}
+	}
+}
+
+// HandleLambdaConfiguration shows the configuration form for a loaded function
+func HandleLambdaConfiguration(m *model.Model, msg model.LambdaConfigurationMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.FunctionConfig = msg.Function
+	newModel.FunctionConfigUpdate = cloud.FunctionConfigUpdate{}
+	newModel.FunctionConfigField = ""
+	newModel.CurrentView = constants.ViewLambdaConfig
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleLambdaConfigSelection handles the selection of a row in the configuration form
+func HandleLambdaConfigSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 {
+		return WrapModel(m), nil
+	}
+
+	setting := selected[0]
+	if setting == constants.SettingReviewChanges {
+		return HandleLambdaConfigReview(m)
+	}
+
+	newModel := m.Clone()
+	newModel.FunctionConfigField = setting
+	newModel.ManualInput = true
+	newModel.TextInput.SetValue("")
+	newModel.TextInput.Focus()
+
+	switch {
+	case setting == constants.SettingMemory:
+		newModel.TextInput.Placeholder = constants.MsgEnterMemory
+	case setting == constants.SettingTimeout:
+		newModel.TextInput.Placeholder = constants.MsgEnterTimeout
+	case setting == constants.SettingEphemeralStorage:
+		newModel.TextInput.Placeholder = constants.MsgEnterEphemeral
+	case setting == constants.SettingReservedConcurrency:
+		newModel.TextInput.Placeholder = constants.MsgEnterReserved
+	case setting == constants.SettingAddProvisioned:
+		newModel.TextInput.Placeholder = constants.MsgEnterNewProvisioned
+	case strings.HasPrefix(setting, constants.SettingProvisionedPrefix):
+		newModel.TextInput.Placeholder = constants.MsgEnterProvisioned
+	default:
+		return WrapModel(m), nil
+	}
+
+	return WrapModel(newModel), nil
+}
+
+// HandleLambdaConfigInput stages the value entered for the setting being edited.
+// An empty value discards any change staged for that setting.
+func HandleLambdaConfigInput(m *model.Model, value string) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	value = strings.TrimSpace(value)
+	update := &newModel.FunctionConfigUpdate
+
+	var err error
+	switch setting := m.FunctionConfigField; {
+	case setting == constants.SettingMemory:
+		update.Memory, err = parseOptionalInt32(value)
+	case setting == constants.SettingTimeout:
+		update.Timeout, err = parseOptionalInt32(value)
+	case setting == constants.SettingEphemeralStorage:
+		update.EphemeralStorage, err = parseOptionalInt32(value)
+	case setting == constants.SettingReservedConcurrency:
+		update.RemoveReservedConcurrency = strings.EqualFold(value, "none")
+		update.ReservedConcurrency = nil
+		if !update.RemoveReservedConcurrency {
+			update.ReservedConcurrency, err = parseOptionalInt32(value)
+		}
+	case setting == constants.SettingAddProvisioned:
+		qualifier, count, found := strings.Cut(value, "=")
+		qualifier = strings.TrimSpace(qualifier)
+		if !found || qualifier == "" {
+			err = fmt.Errorf(constants.MsgErrorInvalidPair)
+			break
+		}
+		err = stageProvisioned(update, qualifier, strings.TrimSpace(count))
+	case strings.HasPrefix(setting, constants.SettingProvisionedPrefix):
+		err = stageProvisioned(update, strings.TrimPrefix(setting, constants.SettingProvisionedPrefix), value)
+	}
+
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	newModel.FunctionConfigField = ""
+	newModel.ManualInput = false
+	newModel.ResetTextInput()
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleLambdaConfigReview validates the staged changes and asks for confirmation
+func HandleLambdaConfigReview(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.FunctionConfig == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoFunction)}
+		}
+	}
+
+	changes := view.LambdaConfigChanges(*m.FunctionConfig, m.FunctionConfigUpdate)
+	if len(changes) == 0 {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoChanges)}
+		}
+	}
+
+	// Get the provider
+	provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	// Get the LambdaConfigurationOperation from the provider
+	configOperation, err := provider.GetLambdaConfigurationOperation()
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	// Validate the changes against the service limits before asking for confirmation
+	if err := configOperation.ValidateConfigUpdate(*m.FunctionConfig, m.FunctionConfigUpdate); err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	newModel := m.Clone()
+	functionName := m.FunctionConfig.Name
+
+	// Copy the staged changes so that later edits don't affect the pending action
+	update := m.Clone().FunctionConfigUpdate
+	newModel.PendingAction = &model.PendingAction{
+		Description: fmt.Sprintf("Apply %d change(s) to %s", len(changes), functionName),
+		Details:     changes,
+		BackView:    constants.ViewLambdaConfig,
+		Run: func(ctx context.Context) (string, error) {
+			if err := configOperation.UpdateFunctionConfiguration(ctx, functionName, update); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf(constants.MsgConfigUpdateSuccess, functionName), nil
+		},
+	}
+	newModel.CurrentView = constants.ViewExecutingAction
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// stageProvisioned stages the provisioned concurrency of an alias or version
+func stageProvisioned(update *cloud.FunctionConfigUpdate, qualifier, value string) error {
+	count, err := parseOptionalInt32(value)
+	if err != nil {
+		return err
+	}
+
+	if count == nil {
+		delete(update.ProvisionedConcurrency, qualifier)
+		return nil
+	}
+
+	if update.ProvisionedConcurrency == nil {
+		update.ProvisionedConcurrency = make(map[string]int32)
+	}
+	update.ProvisionedConcurrency[qualifier] = *count
+	return nil
+}
+
+// parseOptionalInt32 parses a number, returning nil for an empty value
+func parseOptionalInt32(value string) (*int32, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf(constants.MsgErrorInvalidNumber, value)
+	}
+
+	result := int32(parsed)
+	return &result, nil
+}
@@ -0,0 +1,257 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+)
+
+// configTestOperation records the updates it is asked to apply
+type configTestOperation struct {
+	validateErr error
+	applied     []cloud.FunctionConfigUpdate
+}
+
+func (o *configTestOperation) Name() string        { return "Configure Function" }
+func (o *configTestOperation) Description() string { return "Tune Memory, Timeout and Concurrency" }
+func (o *configTestOperation) IsUIVisible() bool   { return true }
+
+func (o *configTestOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func (o *configTestOperation) GetFunctionConfiguration(ctx context.Context, functionName string) (*cloud.FunctionStatus, error) {
+	return &cloud.FunctionStatus{Name: functionName}, nil
+}
+
+func (o *configTestOperation) ValidateConfigUpdate(current cloud.FunctionStatus, update cloud.FunctionConfigUpdate) error {
+	return o.validateErr
+}
+
+func (o *configTestOperation) UpdateFunctionConfiguration(ctx context.Context, functionName string, update cloud.FunctionConfigUpdate) error {
+	o.applied = append(o.applied, update)
+	return nil
+}
+
+// newConfigTestModel creates a model showing the configuration form for a function
+func newConfigTestModel(operation *configTestOperation) *model.Model {
+	reserved := int32(50)
+	m := newTestModel(&testProvider{lambdaConfig: operation})
+	m.CurrentView = constants.ViewLambdaConfig
+	m.FunctionConfig = &cloud.FunctionStatus{
+		Name:                "test-function",
+		Memory:              128,
+		Timeout:             3,
+		EphemeralStorage:    512,
+		ReservedConcurrency: &reserved,
+		ProvisionedConcurrency: []cloud.ProvisionedConcurrencyConfig{
+			{Qualifier: "live", Requested: 5, Status: "READY"},
+		},
+	}
+	view.UpdateTableForView(m)
+	return m
+}
+
+func TestHandleLambdaConfigInput(t *testing.T) {
+	testCases := []struct {
+		name      string
+		field     string
+		value     string
+		expectErr bool
+		check     func(t *testing.T, update cloud.FunctionConfigUpdate)
+	}{
+		{
+			name:  "Memory",
+			field: constants.SettingMemory,
+			value: "256",
+			check: func(t *testing.T, update cloud.FunctionConfigUpdate) {
+				if update.Memory == nil || *update.Memory != 256 {
+					t.Errorf("Expected memory to be staged as 256, got %v", update.Memory)
+				}
+			},
+		},
+		{
+			name:  "Remove reserved concurrency",
+			field: constants.SettingReservedConcurrency,
+			value: "none",
+			check: func(t *testing.T, update cloud.FunctionConfigUpdate) {
+				if !update.RemoveReservedConcurrency || update.ReservedConcurrency != nil {
+					t.Errorf("Expected reserved concurrency to be staged for removal")
+				}
+			},
+		},
+		{
+			name:  "Existing provisioned concurrency",
+			field: constants.SettingProvisionedPrefix + "live",
+			value: "0",
+			check: func(t *testing.T, update cloud.FunctionConfigUpdate) {
+				if count, ok := update.ProvisionedConcurrency["live"]; !ok || count != 0 {
+					t.Errorf("Expected provisioned concurrency for live to be staged as 0, got %v", update.ProvisionedConcurrency)
+				}
+			},
+		},
+		{
+			name:  "New provisioned concurrency",
+			field: constants.SettingAddProvisioned,
+			value: "beta = 2",
+			check: func(t *testing.T, update cloud.FunctionConfigUpdate) {
+				if count := update.ProvisionedConcurrency["beta"]; count != 2 {
+					t.Errorf("Expected provisioned concurrency for beta to be staged as 2, got %d", count)
+				}
+			},
+		},
+		{
+			name:      "Invalid number",
+			field:     constants.SettingTimeout,
+			value:     "ten",
+			expectErr: true,
+		},
+		{
+			name:      "Invalid provisioned pair",
+			field:     constants.SettingAddProvisioned,
+			value:     "beta",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newConfigTestModel(&configTestOperation{})
+			m.FunctionConfigField = tc.field
+			m.ManualInput = true
+
+			result, cmd := HandleLambdaConfigInput(m, tc.value)
+			wrapper := result.(ModelWrapper)
+
+			if tc.expectErr {
+				if cmd == nil {
+					t.Fatalf("Expected an error command, got nil")
+				}
+				if _, ok := cmd().(model.ErrMsg); !ok {
+					t.Errorf("Expected an ErrMsg")
+				}
+				return
+			}
+
+			if cmd != nil {
+				t.Fatalf("Expected no command, got one returning %v", cmd())
+			}
+			if wrapper.Model.ManualInput {
+				t.Errorf("Expected manual input to be closed")
+			}
+			tc.check(t, wrapper.Model.FunctionConfigUpdate)
+		})
+	}
+}
+
+func TestHandleLambdaConfigReview(t *testing.T) {
+	t.Run("No changes", func(t *testing.T) {
+		m := newConfigTestModel(&configTestOperation{})
+
+		_, cmd := HandleLambdaConfigReview(m)
+		if cmd == nil {
+			t.Fatalf("Expected an error command when there are no changes")
+		}
+	})
+
+	t.Run("Invalid changes", func(t *testing.T) {
+		operation := &configTestOperation{validateErr: errors.New("memory out of range")}
+		m := newConfigTestModel(operation)
+		memory := int32(64)
+		m.FunctionConfigUpdate.Memory = &memory
+
+		result, cmd := HandleLambdaConfigReview(m)
+		if cmd == nil {
+			t.Fatalf("Expected an error command for invalid changes")
+		}
+		if result.(ModelWrapper).Model.PendingAction != nil {
+			t.Errorf("Expected no pending action for invalid changes")
+		}
+	})
+
+	t.Run("Valid changes", func(t *testing.T) {
+		operation := &configTestOperation{}
+		m := newConfigTestModel(operation)
+		memory := int32(256)
+		m.FunctionConfigUpdate.Memory = &memory
+		m.FunctionConfigUpdate.ProvisionedConcurrency = map[string]int32{"live": 10}
+
+		result, cmd := HandleLambdaConfigReview(m)
+		if cmd != nil {
+			t.Fatalf("Expected no command, got one returning %v", cmd())
+		}
+
+		newModel := result.(ModelWrapper).Model
+		if newModel.CurrentView != constants.ViewExecutingAction {
+			t.Errorf("Expected view to be ViewExecutingAction, got %v", newModel.CurrentView)
+		}
+		if newModel.PendingAction == nil {
+			t.Fatalf("Expected a pending action")
+		}
+
+		expected := []string{
+			"Memory: 128 MB → 256 MB",
+			"Provisioned Concurrency (live): 5 → 10",
+		}
+		if strings.Join(newModel.PendingAction.Details, "\n") != strings.Join(expected, "\n") {
+			t.Errorf("Expected details %v, got %v", expected, newModel.PendingAction.Details)
+		}
+
+		// Confirm the pending action and check the staged changes are applied
+		msg := ExecutePendingAction(newModel)()
+		resultMsg, ok := msg.(model.ActionResultMsg)
+		if !ok {
+			t.Fatalf("Expected an ActionResultMsg, got %T", msg)
+		}
+		if resultMsg.Err != nil {
+			t.Fatalf("Expected no error, got %v", resultMsg.Err)
+		}
+		if len(operation.applied) != 1 || *operation.applied[0].Memory != 256 {
+			t.Errorf("Expected the staged update to be applied once, got %v", operation.applied)
+		}
+
+		final := HandleActionResult(newModel, resultMsg)
+		if final.CurrentView != constants.ViewSelectOperation {
+			t.Errorf("Expected view to be ViewSelectOperation, got %v", final.CurrentView)
+		}
+		if final.PendingAction != nil || final.FunctionConfig != nil {
+			t.Errorf("Expected configuration state to be cleared")
+		}
+	})
+}
+
+func TestLambdaConfigNavigateBack(t *testing.T) {
+	m := newConfigTestModel(&configTestOperation{})
+	memory := int32(256)
+	m.FunctionConfigUpdate.Memory = &memory
+
+	result, _ := HandleLambdaConfigReview(m)
+	confirmation := result.(ModelWrapper).Model
+
+	// Going back from the confirmation returns to the form with the changes still staged
+	form := NavigateBack(confirmation)
+	if form.CurrentView != constants.ViewLambdaConfig {
+		t.Errorf("Expected view to be ViewLambdaConfig, got %v", form.CurrentView)
+	}
+	if form.PendingAction != nil {
+		t.Errorf("Expected pending action to be cleared")
+	}
+	if form.FunctionConfigUpdate.Memory == nil {
+		t.Errorf("Expected staged changes to be preserved")
+	}
+
+	// Going back from the form discards the staged changes
+	functions := NavigateBack(form)
+	if functions.CurrentView != constants.ViewFunctionStatus {
+		t.Errorf("Expected view to be ViewFunctionStatus, got %v", functions.CurrentView)
+	}
+	if functions.FunctionConfig != nil || !functions.FunctionConfigUpdate.IsEmpty() {
+		t.Errorf("Expected configuration state to be cleared")
+	}
+}
@@ -0,0 +1,171 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleLambdaDeploy shows the deploy form for the selected function
+func HandleLambdaDeploy(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedFunction == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoFunction)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.DeployRequest = cloud.LambdaDeployRequest{}
+	newModel.DeployField = ""
+	newModel.CurrentView = constants.ViewLambdaDeploy
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleLambdaDeploySelection handles the selection of a row in the deploy form
+func HandleLambdaDeploySelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 {
+		return WrapModel(m), nil
+	}
+
+	newModel := m.Clone()
+	switch setting := selected[0]; setting {
+	case constants.SettingReviewDeploy:
+		return HandleLambdaDeployReview(m)
+	case constants.SettingPublishVersion:
+		// Toggle publishing; an alias can only be moved to a published version
+		newModel.DeployRequest.Publish = !m.DeployRequest.Publish
+		if !newModel.DeployRequest.Publish {
+			newModel.DeployRequest.Alias = ""
+		}
+		view.UpdateTableForView(newModel)
+		return WrapModel(newModel), nil
+	case constants.SettingZipFile:
+		newModel.TextInput.Placeholder = constants.MsgEnterZipFile
+	case constants.SettingS3Object:
+		newModel.TextInput.Placeholder = constants.MsgEnterS3Object
+	case constants.SettingImageURI:
+		newModel.TextInput.Placeholder = constants.MsgEnterImageURI
+	case constants.SettingAlias:
+		newModel.TextInput.Placeholder = constants.MsgEnterAlias
+	default:
+		return WrapModel(m), nil
+	}
+
+	newModel.DeployField = selected[0]
+	newModel.ManualInput = true
+	newModel.TextInput.SetValue("")
+	newModel.TextInput.Focus()
+	return WrapModel(newModel), nil
+}
+
+// HandleLambdaDeployInput stages the value entered for the setting being edited.
+// Choosing a source replaces any other source, and an empty value clears the setting.
+func HandleLambdaDeployInput(m *model.Model, value string) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	value = strings.TrimSpace(value)
+	request := &newModel.DeployRequest
+
+	switch m.DeployField {
+	case constants.SettingZipFile, constants.SettingS3Object, constants.SettingImageURI:
+		source := cloud.LambdaDeployRequest{Publish: request.Publish, Alias: request.Alias}
+		switch m.DeployField {
+		case constants.SettingZipFile:
+			source.ZipFile = value
+		case constants.SettingImageURI:
+			source.ImageURI = value
+		default:
+			if value != "" && !source.SetS3Object(value) {
+				return WrapModel(m), func() tea.Msg {
+					return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorInvalidS3, value)}
+				}
+			}
+		}
+		*request = source
+	case constants.SettingAlias:
+		// Moving an alias requires a published version
+		request.Alias = value
+		if value != "" {
+			request.Publish = true
+		}
+	}
+
+	newModel.DeployField = ""
+	newModel.ManualInput = false
+	newModel.ResetTextInput()
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleLambdaDeployReview validates the staged deployment and asks for confirmation
+func HandleLambdaDeployReview(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedFunction == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoFunction)}
+		}
+	}
+
+	request := m.DeployRequest
+	if request.ZipFile == "" && request.S3Bucket == "" && request.ImageURI == "" {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoSource)}
+		}
+	}
+
+	// Get the provider
+	provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	// Get the LambdaDeployOperation from the provider
+	deployOperation, err := provider.GetLambdaDeployOperation()
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	// Validate the request before asking for confirmation
+	if err := deployOperation.ValidateDeployRequest(request); err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	newModel := m.Clone()
+	functionName := m.SelectedFunction.Name
+	newModel.PendingAction = &model.PendingAction{
+		Description: fmt.Sprintf("Deploy new code to %s", functionName),
+		Details:     view.LambdaDeploySummary(request),
+		LoadingMsg:  constants.MsgDeployingCode,
+		BackView:    constants.ViewLambdaDeploy,
+		Run: func(ctx context.Context) (string, error) {
+			result, err := deployOperation.DeployFunctionCode(ctx, functionName, request)
+			if err != nil {
+				return "", err
+			}
+
+			message := fmt.Sprintf(constants.MsgDeploySuccess, functionName, result.CodeSha256)
+			if result.Version != "" {
+				message += fmt.Sprintf(", version: %s", result.Version)
+			}
+			if result.Alias != "" {
+				message += fmt.Sprintf(", alias: %s", result.Alias)
+			}
+			return message, nil
+		},
+	}
+	newModel.CurrentView = constants.ViewExecutingAction
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
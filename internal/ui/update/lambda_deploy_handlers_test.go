@@ -0,0 +1,164 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+)
+
+// deployTestOperation records the deployments it is asked to make
+type deployTestOperation struct {
+	validateErr error
+	deployed    []cloud.LambdaDeployRequest
+}
+
+func (o *deployTestOperation) Name() string        { return "Deploy Code" }
+func (o *deployTestOperation) Description() string { return "Update Function Code" }
+func (o *deployTestOperation) IsUIVisible() bool   { return true }
+
+func (o *deployTestOperation) Execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func (o *deployTestOperation) ValidateDeployRequest(request cloud.LambdaDeployRequest) error {
+	return o.validateErr
+}
+
+func (o *deployTestOperation) DeployFunctionCode(ctx context.Context, functionName string, request cloud.LambdaDeployRequest) (*cloud.LambdaDeployResult, error) {
+	o.deployed = append(o.deployed, request)
+	return &cloud.LambdaDeployResult{CodeSha256: "abc123", LastUpdateStatus: "Successful", Version: "7", Alias: request.Alias}, nil
+}
+
+// newDeployTestModel creates a model showing the deploy form for a function
+func newDeployTestModel(operation *deployTestOperation) *model.Model {
+	m := newTestModel(&testProvider{lambdaDeploy: operation})
+	m.SetSelectedFunction(&cloud.FunctionStatus{Name: "test-function", PackageType: "Zip"})
+	m.CurrentView = constants.ViewLambdaDeploy
+	view.UpdateTableForView(m)
+	return m
+}
+
+func TestHandleLambdaDeployInput(t *testing.T) {
+	t.Run("Source replaces previous source", func(t *testing.T) {
+		m := newDeployTestModel(&deployTestOperation{})
+		m.DeployRequest.ZipFile = "./build.zip"
+		m.DeployField = constants.SettingS3Object
+		m.ManualInput = true
+
+		result, cmd := HandleLambdaDeployInput(m, "s3://my-bucket/app.zip")
+		if cmd != nil {
+			t.Fatalf("Expected no command, got one returning %v", cmd())
+		}
+
+		request := result.(ModelWrapper).Model.DeployRequest
+		if request.ZipFile != "" || request.S3Bucket != "my-bucket" || request.S3Key != "app.zip" {
+			t.Errorf("Expected the S3 object to replace the zip file, got %+v", request)
+		}
+	})
+
+	t.Run("Invalid S3 object", func(t *testing.T) {
+		m := newDeployTestModel(&deployTestOperation{})
+		m.DeployField = constants.SettingS3Object
+		m.ManualInput = true
+
+		_, cmd := HandleLambdaDeployInput(m, "my-bucket")
+		if cmd == nil {
+			t.Fatalf("Expected an error command, got nil")
+		}
+		if _, ok := cmd().(model.ErrMsg); !ok {
+			t.Errorf("Expected an ErrMsg")
+		}
+	})
+
+	t.Run("Alias enables publishing", func(t *testing.T) {
+		m := newDeployTestModel(&deployTestOperation{})
+		m.DeployField = constants.SettingAlias
+		m.ManualInput = true
+
+		result, _ := HandleLambdaDeployInput(m, "live")
+		request := result.(ModelWrapper).Model.DeployRequest
+		if request.Alias != "live" || !request.Publish {
+			t.Errorf("Expected alias live with publishing enabled, got %+v", request)
+		}
+	})
+}
+
+func TestHandleLambdaDeployReview(t *testing.T) {
+	t.Run("No source", func(t *testing.T) {
+		m := newDeployTestModel(&deployTestOperation{})
+
+		_, cmd := HandleLambdaDeployReview(m)
+		if cmd == nil {
+			t.Fatalf("Expected an error command when no source is chosen")
+		}
+	})
+
+	t.Run("Invalid request", func(t *testing.T) {
+		m := newDeployTestModel(&deployTestOperation{validateErr: errors.New("zip file not found")})
+		m.DeployRequest.ZipFile = "./missing.zip"
+
+		result, cmd := HandleLambdaDeployReview(m)
+		if cmd == nil {
+			t.Fatalf("Expected an error command for an invalid request")
+		}
+		if result.(ModelWrapper).Model.PendingAction != nil {
+			t.Errorf("Expected no pending action for an invalid request")
+		}
+	})
+
+	t.Run("Valid request", func(t *testing.T) {
+		operation := &deployTestOperation{}
+		m := newDeployTestModel(operation)
+		m.DeployRequest = cloud.LambdaDeployRequest{ImageURI: "repo/app:1.2.3", Publish: true, Alias: "live"}
+
+		result, cmd := HandleLambdaDeployReview(m)
+		if cmd != nil {
+			t.Fatalf("Expected no command, got one returning %v", cmd())
+		}
+
+		newModel := result.(ModelWrapper).Model
+		if newModel.CurrentView != constants.ViewExecutingAction {
+			t.Errorf("Expected view to be ViewExecutingAction, got %v", newModel.CurrentView)
+		}
+		if newModel.PendingAction == nil {
+			t.Fatalf("Expected a pending action")
+		}
+
+		// Confirm the pending action and check the result is reported
+		msg := ExecutePendingAction(newModel)()
+		resultMsg, ok := msg.(model.ActionResultMsg)
+		if !ok {
+			t.Fatalf("Expected an ActionResultMsg, got %T", msg)
+		}
+		if resultMsg.Err != nil {
+			t.Fatalf("Expected no error, got %v", resultMsg.Err)
+		}
+		if len(operation.deployed) != 1 || operation.deployed[0].ImageURI != "repo/app:1.2.3" {
+			t.Errorf("Expected the staged deployment to be made once, got %v", operation.deployed)
+		}
+
+		expected := "Successfully deployed Lambda function: test-function, CodeSha256: abc123, version: 7, alias: live"
+		if resultMsg.Message != expected {
+			t.Errorf("Expected message %q, got %q", expected, resultMsg.Message)
+		}
+	})
+}
+
+func TestLambdaDeployNavigateBack(t *testing.T) {
+	m := newDeployTestModel(&deployTestOperation{})
+	m.DeployRequest.ZipFile = "./build.zip"
+
+	// Going back from the form discards the staged deployment
+	functions := NavigateBack(m)
+	if functions.CurrentView != constants.ViewFunctionStatus {
+		t.Errorf("Expected view to be ViewFunctionStatus, got %v", functions.CurrentView)
+	}
+	if functions.DeployRequest.ZipFile != "" || functions.SelectedFunction != nil {
+		t.Errorf("Expected deployment state to be cleared")
+	}
+}
@@ -0,0 +1,80 @@
+package update
+
+import (
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleLambdaResponseKey collapses or expands the response, switches between the response
+// and log panes, or starts entering a response query
+func HandleLambdaResponseKey(m *model.Model, key string) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	switch key {
+	case constants.KeyCollapse, constants.KeyExpand:
+		if m.ShowLambdaLogs {
+			return WrapModel(m), nil
+		}
+		newModel.LambdaResponseDepth = foldDepth(m.LambdaResponseDepth, view.LambdaResponseNesting(m), key == constants.KeyCollapse)
+		newModel.Viewport.SetContent(view.LambdaResponseContent(newModel))
+	case constants.KeyToggleLogs:
+		newModel.ShowLambdaLogs = !m.ShowLambdaLogs
+		newModel.Viewport.SetContent(view.LambdaResponseContent(newModel))
+		newModel.Viewport.GotoTop()
+	case constants.KeySearch:
+		newModel.ManualInput = true
+		newModel.TextInput.Placeholder = constants.MsgEnterResponseQuery
+		newModel.TextInput.SetValue(m.LambdaResponseQuery)
+		newModel.TextInput.Focus()
+	default:
+		return WrapModel(m), nil
+	}
+
+	return WrapModel(newModel), nil
+}
+
+// HandleLambdaResponseQuery applies a jq-style path to the response; an empty path shows the whole response
+func HandleLambdaResponseQuery(m *model.Model, query string) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.ManualInput = false
+	newModel.ResetTextInput()
+	newModel.LambdaResponseQuery = strings.TrimSpace(query)
+	newModel.LambdaResponseDepth = 0
+	newModel.ShowLambdaLogs = false
+	newModel.Viewport.SetContent(view.LambdaResponseContent(newModel))
+	newModel.Viewport.GotoTop()
+	return WrapModel(newModel), nil
+}
+
+// foldDepth returns the expanded depth after collapsing or expanding one level of a response
+// with the given nesting. A depth of 0 expands everything.
+func foldDepth(depth, nesting int, collapse bool) int {
+	if depth == 0 {
+		depth = nesting
+	}
+
+	if collapse {
+		// The top level always stays expanded
+		return max(depth-1, 1)
+	}
+	if depth+1 >= nesting {
+		return 0
+	}
+	return depth + 1
+}
+
+// moveTextAreaCursor moves the cursor of a text area to a 1-based line and column
+func moveTextAreaCursor(ta *textarea.Model, line, column int) {
+	// Soft-wrapped lines take several moves, but never more than there are characters
+	for moves := 0; ta.Line() > line-1 && moves <= ta.Length(); moves++ {
+		ta.CursorUp()
+	}
+	for moves := 0; ta.Line() < line-1 && moves <= ta.Length(); moves++ {
+		ta.CursorDown()
+	}
+	ta.SetCursor(column - 1)
+}
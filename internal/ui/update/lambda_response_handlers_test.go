@@ -0,0 +1,120 @@
+package update
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/viewport"
+)
+
+func TestHandleLambdaExecuteInvalidPayload(t *testing.T) {
+	m := model.New()
+	m.CurrentView = constants.ViewLambdaExecute
+	m.SetSelectedFunction(&cloud.FunctionStatus{Name: "test-function"})
+	m.TextArea = textarea.New()
+	m.TextArea.SetValue("{\n  \"key\": \"value\",\n}")
+
+	result, cmd := HandleLambdaExecute(m)
+	if cmd != nil {
+		t.Fatalf("Expected an invalid payload not to be invoked")
+	}
+
+	newModel := result.(ModelWrapper).Model
+	if newModel.IsLoading {
+		t.Errorf("Expected the model not to be loading")
+	}
+	if newModel.LambdaPayloadError == nil || newModel.LambdaPayloadError.Line != 3 || newModel.LambdaPayloadError.Column != 1 {
+		t.Fatalf("Expected an error at 3:1, got %+v", newModel.LambdaPayloadError)
+	}
+
+	// The cursor is moved to the error
+	if line := newModel.TextArea.Line(); line != 2 {
+		t.Errorf("Expected the cursor on line index 2, got %d", line)
+	}
+}
+
+func TestHandleLambdaResponseKey(t *testing.T) {
+	m := model.New()
+	m.CurrentView = constants.ViewLambdaResponse
+	m.Viewport = viewport.New(80, 20)
+	m.SetLambdaResult(&cloud.LambdaExecuteResult{
+		StatusCode: 200,
+		Payload:    `{"outer": {"inner": {"value": 1}}}`,
+		LogResult:  "START RequestId: 1\nEND RequestId: 1",
+	})
+
+	// Collapsing from fully expanded hides the deepest level first
+	result, _ := HandleLambdaResponseKey(m, constants.KeyCollapse)
+	collapsed := result.(ModelWrapper).Model
+	if collapsed.LambdaResponseDepth != 2 {
+		t.Errorf("Expected depth 2, got %d", collapsed.LambdaResponseDepth)
+	}
+	if !strings.Contains(collapsed.Viewport.View(), `"inner": {… 1 key}`) {
+		t.Errorf("Expected inner to be collapsed, got:\n%s", collapsed.Viewport.View())
+	}
+
+	// Expanding back to the full depth expands everything
+	result, _ = HandleLambdaResponseKey(collapsed, constants.KeyExpand)
+	if depth := result.(ModelWrapper).Model.LambdaResponseDepth; depth != 0 {
+		t.Errorf("Expected depth 0, got %d", depth)
+	}
+
+	result, _ = HandleLambdaResponseKey(m, constants.KeyToggleLogs)
+	if !result.(ModelWrapper).Model.ShowLambdaLogs {
+		t.Errorf("Expected the log pane to be shown")
+	}
+
+	result, _ = HandleLambdaResponseKey(m, constants.KeySearch)
+	if !result.(ModelWrapper).Model.ManualInput {
+		t.Errorf("Expected the query input to be shown")
+	}
+}
+
+func TestHandleLambdaResponseQuery(t *testing.T) {
+	m := model.New()
+	m.CurrentView = constants.ViewLambdaResponse
+	m.Viewport = viewport.New(80, 20)
+	m.ManualInput = true
+	m.ShowLambdaLogs = true
+	m.SetLambdaResult(&cloud.LambdaExecuteResult{StatusCode: 200, Payload: `{"items": [{"id": "a"}, {"id": "b"}]}`})
+
+	result, _ := HandleLambdaResponseQuery(m, " .items[].id ")
+	newModel := result.(ModelWrapper).Model
+	if newModel.ManualInput || newModel.ShowLambdaLogs {
+		t.Errorf("Expected the query input to close and the response to be shown")
+	}
+	if newModel.LambdaResponseQuery != ".items[].id" {
+		t.Errorf("Expected the query to be trimmed, got %q", newModel.LambdaResponseQuery)
+	}
+	if content := newModel.Viewport.View(); !strings.Contains(content, `"a"`) || !strings.Contains(content, `"b"`) {
+		t.Errorf("Expected both ids, got:\n%s", newModel.Viewport.View())
+	}
+}
+
+func TestFoldDepth(t *testing.T) {
+	testCases := []struct {
+		name     string
+		depth    int
+		nesting  int
+		collapse bool
+		expected int
+	}{
+		{name: "Collapse expanded", depth: 0, nesting: 3, collapse: true, expected: 2},
+		{name: "Collapse keeps top level", depth: 1, nesting: 3, collapse: true, expected: 1},
+		{name: "Expand", depth: 1, nesting: 3, collapse: false, expected: 2},
+		{name: "Expand to everything", depth: 2, nesting: 3, collapse: false, expected: 0},
+		{name: "Expand expanded", depth: 0, nesting: 3, collapse: false, expected: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := foldDepth(tc.depth, tc.nesting, tc.collapse); got != tc.expected {
+				t.Errorf("Expected depth %d, got %d", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package update
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// lambdaStreamBuffer is how many chunks can arrive before the UI reads them
+const lambdaStreamBuffer = 64
+
+// startLambdaStream invokes a function with a streamed response in the background and
+// returns the first message of the stream; the rest are read with WaitForLambdaStream
+func startLambdaStream(ctx context.Context, operation cloud.LambdaExecuteOperation, functionName, payload string, streamID int) tea.Msg {
+	stream := make(chan model.LambdaStreamMsg, lambdaStreamBuffer)
+
+	go func() {
+		defer close(stream)
+		result, err := operation.ExecuteFunctionStream(ctx, functionName, payload, func(chunk []byte) {
+			stream <- model.LambdaStreamMsg{StreamID: streamID, Chunk: string(chunk)}
+		})
+		stream <- model.LambdaStreamMsg{StreamID: streamID, Done: true, Result: result, Err: err}
+	}()
+
+	return readLambdaStream(stream)
+}
+
+// WaitForLambdaStream returns a command that reads the next message of a stream,
+// or nil once the stream is done
+func WaitForLambdaStream(msg model.LambdaStreamMsg) tea.Cmd {
+	if msg.Done || msg.Stream == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return readLambdaStream(msg.Stream)
+	}
+}
+
+// readLambdaStream blocks until the next message of a stream arrives
+func readLambdaStream(stream <-chan model.LambdaStreamMsg) tea.Msg {
+	msg, ok := <-stream
+	if !ok {
+		return nil
+	}
+	msg.Stream = stream
+	return msg
+}
+
+// HandleLambdaStream appends a chunk of a streamed response to the response view. The first
+// message switches to the response view; the last one shows the final status and tail logs.
+func HandleLambdaStream(m *model.Model, msg model.LambdaStreamMsg) *model.Model {
+	// Ignore what's left of a stream that was replaced by a newer invocation, or whose
+	// response view was left
+	if msg.StreamID != m.LambdaStreamID {
+		return m
+	}
+	if m.IsLambdaStreaming && m.CurrentView != constants.ViewLambdaResponse {
+		return m
+	}
+
+	newModel := m.Clone()
+	rememberResponseStreaming(newModel, msg.ResponseStreaming)
+	if msg.Done && msg.Err != nil && !m.IsLambdaStreaming {
+		// The invocation failed before anything was received
+		newModel.IsLoading = false
+		newModel.Err = msg.Err
+		return newModel
+	}
+
+	// The first message of a stream opens the response view
+	if !m.IsLambdaStreaming {
+		newModel.IsLoading = false
+		newModel.IsLambdaStreaming = true
+		newModel.SetLambdaResult(&cloud.LambdaExecuteResult{Streamed: true})
+		newModel.LambdaResponseQuery = ""
+		newModel.LambdaResponseDepth = 0
+		newModel.ShowLambdaLogs = false
+
+		newModel.Viewport = viewport.New(newModel.Width-constants.ViewportMarginX*2, constants.TableHeight)
+		newModel.Viewport.YPosition = constants.HeaderHeight
+		newModel.ViewportReady = false
+		newModel.CurrentView = constants.ViewLambdaResponse
+	}
+
+	result := *newModel.GetLambdaResult()
+	result.Payload += msg.Chunk
+
+	if msg.Done {
+		newModel.IsLambdaStreaming = false
+		if msg.Result != nil {
+			result = *msg.Result
+			result.Streamed = true
+		}
+		// Keep what was received when the stream breaks off, and show why it ended
+		if msg.Err != nil && result.ErrorDetails == "" {
+			result.ErrorDetails = msg.Err.Error()
+		}
+	}
+	newModel.SetLambdaResult(&result)
+
+	// Keep the newest output in view while the response arrives
+	newModel.Viewport.SetContent(view.LambdaResponseContent(newModel))
+	if newModel.IsLambdaStreaming {
+		newModel.Viewport.GotoBottom()
+	} else if msg.Err == nil && newModel.SelectedFunction != nil {
+		newModel.Success = fmt.Sprintf(constants.MsgLambdaExecuteSuccess, newModel.SelectedFunction.Name)
+	}
+
+	return newModel
+}
+
+// HandleLambdaResponseBack goes back from the response view to the execution view, stopping a
+// stream that is still arriving
+func HandleLambdaResponseBack(m *model.Model) *model.Model {
+	newModel := m.Clone()
+	stopLambdaInvoke(newModel)
+	newModel.CurrentView = constants.ViewLambdaExecute
+	return newModel
+}
+
+// stopLambdaInvoke cancels the latest invocation, so a stream stops arriving once its response
+// view is left
+func stopLambdaInvoke(m *model.Model) {
+	if m.LambdaInvokeCancel != nil {
+		m.LambdaInvokeCancel()
+		m.LambdaInvokeCancel = nil
+	}
+	if m.IsLambdaStreaming {
+		// Ignore what was already sent before the stream stopped
+		m.IsLambdaStreaming = false
+		m.LambdaStreamID++
+	}
+}
+
+// rememberResponseStreaming keeps the invoke mode looked up for the selected function, so the
+// next invocation in auto mode doesn't look it up again
+func rememberResponseStreaming(m *model.Model, streaming *bool) {
+	if streaming != nil && m.SelectedFunction != nil {
+		m.ResponseStreamingFunction = m.SelectedFunction.Name
+		m.ResponseStreaming = *streaming
+	}
+}
+
+// HandleLambdaInvokeMode cycles the invoke mode of the execution view between auto,
+// buffered and streaming
+func HandleLambdaInvokeMode(m *model.Model) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	switch m.LambdaInvokeMode {
+	case constants.InvokeModeAuto:
+		newModel.LambdaInvokeMode = constants.InvokeModeBuffered
+	case constants.InvokeModeBuffered:
+		newModel.LambdaInvokeMode = constants.InvokeModeStreaming
+	default:
+		newModel.LambdaInvokeMode = constants.InvokeModeAuto
+	}
+	return WrapModel(newModel), nil
+}
@@ -0,0 +1,157 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	"github.com/charmbracelet/bubbles/textarea"
+)
+
+// invokeModeTestOperation answers invocations with a buffered response and counts the lookups
+// of the invoke mode
+type invokeModeTestOperation struct {
+	cloud.LambdaExecuteOperation
+	lookups int
+}
+
+func (o *invokeModeTestOperation) IsResponseStreaming(ctx context.Context, functionName string) (bool, error) {
+	o.lookups++
+	return false, nil
+}
+
+func (o *invokeModeTestOperation) ExecuteFunction(ctx context.Context, functionName string, payload string) (*cloud.LambdaExecuteResult, error) {
+	return &cloud.LambdaExecuteResult{StatusCode: 200, Payload: "{}"}, nil
+}
+
+func TestHandleLambdaStream(t *testing.T) {
+	m := model.New()
+	m.Width = 100
+	m.CurrentView = constants.ViewLambdaExecute
+	m.SetSelectedFunction(&cloud.FunctionStatus{Name: "test-function"})
+	m.IsLoading = true
+	m.LambdaStreamID = 2
+
+	// The first chunk opens the response view
+	m = HandleLambdaStream(m, model.LambdaStreamMsg{StreamID: 2, Chunk: `{"items": [`})
+	if m.CurrentView != constants.ViewLambdaResponse || m.IsLoading || !m.IsLambdaStreaming {
+		t.Fatalf("Expected a streaming response view, got view %v, loading %v", m.CurrentView, m.IsLoading)
+	}
+
+	// Chunks of an earlier invocation are ignored
+	m = HandleLambdaStream(m, model.LambdaStreamMsg{StreamID: 1, Chunk: "stale"})
+	m = HandleLambdaStream(m, model.LambdaStreamMsg{StreamID: 2, Chunk: `1, 2]}`})
+	if payload := m.GetLambdaResult().Payload; payload != `{"items": [1, 2]}` {
+		t.Errorf("Expected the chunks to be appended, got %q", payload)
+	}
+	if content := view.LambdaResponseContent(m); !strings.Contains(content, "Stream: receiving…") {
+		t.Errorf("Expected the stream to be receiving, got:\n%s", content)
+	}
+
+	m = HandleLambdaStream(m, model.LambdaStreamMsg{StreamID: 2, Done: true, Result: &cloud.LambdaExecuteResult{
+		StatusCode: 200,
+		Payload:    `{"items": [1, 2]}`,
+		LogResult:  "START RequestId: 1\nEND RequestId: 1",
+	}})
+	if m.IsLambdaStreaming {
+		t.Errorf("Expected the stream to be done")
+	}
+	content := view.LambdaResponseContent(m)
+	if !strings.Contains(content, "Stream: complete") || !strings.HasSuffix(content, "Logs:\nSTART RequestId: 1\nEND RequestId: 1") {
+		t.Errorf("Expected the final status and tail logs, got:\n%s", content)
+	}
+}
+
+func TestHandleLambdaStreamError(t *testing.T) {
+	m := model.New()
+	m.CurrentView = constants.ViewLambdaExecute
+	m.IsLoading = true
+	m.LambdaStreamID = 1
+
+	// An invocation that fails before streaming shows the error
+	failed := HandleLambdaStream(m, model.LambdaStreamMsg{StreamID: 1, Done: true, Err: errors.New("access denied")})
+	if failed.Err == nil || failed.IsLoading {
+		t.Errorf("Expected the error to be shown")
+	}
+
+	// A stream that breaks off keeps what was received
+	m = HandleLambdaStream(m, model.LambdaStreamMsg{StreamID: 1, Chunk: "partial"})
+	m = HandleLambdaStream(m, model.LambdaStreamMsg{StreamID: 1, Done: true, Err: errors.New("connection reset")})
+	if m.Err != nil {
+		t.Errorf("Expected the response view to stay, got %v", m.Err)
+	}
+	content := view.LambdaResponseContent(m)
+	if !strings.Contains(content, "Stream: failed: connection reset") || !strings.HasSuffix(content, "partial") {
+		t.Errorf("Expected the partial response and the failure, got:\n%s", content)
+	}
+}
+
+func TestHandleLambdaInvokeMode(t *testing.T) {
+	m := model.New()
+	expected := []string{constants.InvokeModeBuffered, constants.InvokeModeStreaming, constants.InvokeModeAuto}
+	for _, mode := range expected {
+		result, _ := HandleLambdaInvokeMode(m)
+		m = result.(ModelWrapper).Model
+		if m.LambdaInvokeMode != mode {
+			t.Errorf("Expected invoke mode %s, got %s", mode, m.LambdaInvokeMode)
+		}
+	}
+}
+
+func TestHandleLambdaResponseBack(t *testing.T) {
+	m := model.New()
+	m.Width = 100
+	m.CurrentView = constants.ViewLambdaExecute
+	m.SetSelectedFunction(&cloud.FunctionStatus{Name: "test-function"})
+	m.LambdaStreamID = 1
+	ctx, cancel := context.WithCancel(context.Background())
+	m.LambdaInvokeCancel = cancel
+
+	m = HandleLambdaStream(m, model.LambdaStreamMsg{StreamID: 1, Chunk: "first"})
+	m = HandleLambdaResponseBack(m)
+	if m.CurrentView != constants.ViewLambdaExecute || m.IsLambdaStreaming {
+		t.Fatalf("Expected the execution view without a stream, got view %v", m.CurrentView)
+	}
+	if ctx.Err() == nil {
+		t.Errorf("Expected the stream to be cancelled")
+	}
+
+	// What the stream sent before it stopped doesn't reach the execution view
+	content := m.Viewport.View()
+	m = HandleLambdaStream(m, model.LambdaStreamMsg{StreamID: 1, Chunk: "second"})
+	m = HandleLambdaStream(m, model.LambdaStreamMsg{StreamID: 1, Done: true, Err: context.Canceled})
+	if m.Viewport.View() != content || m.Success != "" || m.Err != nil {
+		t.Errorf("Expected the stream to be ignored, got success %q and error %v", m.Success, m.Err)
+	}
+}
+
+func TestHandleLambdaExecuteInvokeModeLookup(t *testing.T) {
+	operation := &invokeModeTestOperation{}
+	m := newTestModel(&testProvider{lambdaExecute: operation})
+	m.CurrentView = constants.ViewLambdaExecute
+	m.SetSelectedFunction(&cloud.FunctionStatus{Name: "test-function"})
+	m.TextArea = textarea.New()
+
+	for i := 0; i < 2; i++ {
+		result, cmd := HandleLambdaExecute(m)
+		msg := cmd().(model.LambdaExecuteResultMsg)
+		m = HandleLambdaExecuteResult(result.(ModelWrapper).Model, &msg)
+		m.CurrentView = constants.ViewLambdaExecute
+	}
+	if operation.lookups != 1 {
+		t.Errorf("Expected the invoke mode to be looked up once, got %d lookups", operation.lookups)
+	}
+
+	// Another function is looked up again
+	m.SetSelectedFunction(&cloud.FunctionStatus{Name: "other-function"})
+	_, cmd := HandleLambdaExecute(m)
+	cmd()
+	if operation.lookups != 2 {
+		t.Errorf("Expected a lookup for another function, got %d lookups", operation.lookups)
+	}
+}
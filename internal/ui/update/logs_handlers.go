@@ -0,0 +1,483 @@
+package update
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleLogGroupsLoad lists the log groups to query, starting a new query over the last hour
+func HandleLogGroupsLoad(m *model.Model) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingLogGroups
+	newModel.SelectedLogGroups = nil
+	newModel.LogsTimeRange = constants.LogsTimeRanges[1]
+	newModel.LogsQueryID = ""
+	newModel.LogsQueryResults = nil
+	newModel.SavedQueries = nil
+	newModel.LogsSavedQuery = nil
+	newModel.IsLogsQueryInputMode = false
+	newModel.TextArea = newLogsQueryTextArea()
+
+	return WrapModel(newModel), func() tea.Msg {
+		insightsOperation, err := getLogsInsightsOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		logGroups, err := insightsOperation.ListLogGroups(context.Background())
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.LogGroupsMsg{LogGroups: logGroups}
+	}
+}
+
+// HandleLogGroups shows the log groups to pick the ones to query from
+func HandleLogGroups(m *model.Model, msg model.LogGroupsMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.LogGroups = msg.LogGroups
+	newModel.CurrentView = constants.ViewLogGroups
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleLogGroupSelection selects or deselects the log group under the cursor, or opens the
+// query editor from the row above them
+func HandleLogGroupSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	// The first row opens the editor, and the log groups follow in the order of m.LogGroups
+	cursor := m.Table.Cursor()
+	if cursor == 0 {
+		return HandleLogsQueryEditor(m)
+	}
+	if cursor < 0 || cursor > len(m.LogGroups) {
+		return WrapModel(m), nil
+	}
+
+	newModel := m.Clone()
+	newModel.SelectedLogGroups = toggleLogGroup(m.SelectedLogGroups, m.LogGroups[cursor-1].Name)
+	refreshTable(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleLogsQueryEditor opens the query editor for the selected log groups
+func HandleLogsQueryEditor(m *model.Model) (tea.Model, tea.Cmd) {
+	if err := validateLogGroups(m.SelectedLogGroups); err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.IsLogsQueryInputMode = false
+	newModel.CurrentView = constants.ViewLogsQuery
+	return WrapModel(newModel), nil
+}
+
+// HandleLogsQueryKey handles a key pressed in the query editor. In input mode keys edit the
+// query; in command mode they run it, change its time range, and load or save it.
+func HandleLogsQueryKey(m *model.Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == constants.KeyCtrlC {
+		return WrapModel(m), tea.Quit
+	}
+
+	// While a name is being entered to save the query as, keys go to the text input
+	if m.ManualInput {
+		switch key {
+		case constants.KeyEnter:
+			return HandleLogsQuerySave(m, m.TextInput.Value())
+		case constants.KeyEsc:
+			newModel := m.Clone()
+			newModel.ManualInput = false
+			newModel.ResetTextInput()
+			return WrapModel(newModel), nil
+		default:
+			newModel := m.Clone()
+			var cmd tea.Cmd
+			newModel.TextInput, cmd = newModel.TextInput.Update(msg)
+			return WrapModel(newModel), cmd
+		}
+	}
+
+	if m.IsLogsQueryInputMode {
+		newModel := m.Clone()
+		if key == constants.KeyEsc {
+			newModel.IsLogsQueryInputMode = false
+			return WrapModel(newModel), nil
+		}
+		var cmd tea.Cmd
+		newModel.TextArea, cmd = newModel.TextArea.Update(msg)
+		return WrapModel(newModel), cmd
+	}
+
+	switch key {
+	case constants.KeyQ:
+		return WrapModel(m), tea.Quit
+	case constants.KeyEsc, constants.KeyAltBack:
+		return WrapModel(NavigateBack(m)), nil
+	case "i":
+		newModel := m.Clone()
+		newModel.IsLogsQueryInputMode = true
+		return WrapModel(newModel), nil
+	case constants.KeyEnter:
+		return HandleLogsQueryRun(m)
+	case constants.KeyTimeRange:
+		newModel := m.Clone()
+		newModel.LogsTimeRange = nextLogsTimeRange(m.LogsTimeRange)
+		return WrapModel(newModel), nil
+	case constants.KeySavedQueries:
+		return HandleSavedQueriesLoad(m)
+	case constants.KeySaveQuery:
+		newModel := m.Clone()
+		newModel.ManualInput = true
+		newModel.TextInput.Placeholder = constants.MsgEnterQueryName
+		if m.LogsSavedQuery != nil {
+			newModel.TextInput.SetValue(m.LogsSavedQuery.Name)
+		}
+		newModel.TextInput.Focus()
+		return WrapModel(newModel), nil
+	default:
+		return WrapModel(m), nil
+	}
+}
+
+// HandleLogsQueryRun starts the query in the editor over the selected log groups and time range.
+// The query runs in the background and is polled for results with PollLogsQuery.
+func HandleLogsQueryRun(m *model.Model) (tea.Model, tea.Cmd) {
+	queryString := strings.TrimSpace(m.TextArea.Value())
+	if queryString == "" {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorEmptyQuery)}
+		}
+	}
+	if err := validateLogGroups(m.SelectedLogGroups); err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgStartingQuery
+	newModel.LogsQueryID = ""
+	newModel.LogsQueryResults = nil
+
+	end := time.Now()
+	query := cloud.LogsQuery{
+		LogGroups: m.SelectedLogGroups,
+		Query:     queryString,
+		Start:     end.Add(-m.LogsTimeRange),
+		End:       end,
+	}
+
+	return WrapModel(newModel), func() tea.Msg {
+		insightsOperation, err := getLogsInsightsOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		queryID, err := insightsOperation.StartQuery(context.Background(), query)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.LogsQueryStartedMsg{QueryID: queryID}
+	}
+}
+
+// HandleLogsQueryStarted records the query that's running, to poll it for results
+func HandleLogsQueryStarted(m *model.Model, msg model.LogsQueryStartedMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.LogsQueryID = msg.QueryID
+	newModel.LoadingMsg = constants.MsgRunningQuery
+	return newModel
+}
+
+// PollLogsQuery returns a command that gets the results of a running query after a moment,
+// or nil once the query is no longer the one running
+func PollLogsQuery(m *model.Model, queryID string) tea.Cmd {
+	if !m.IsLoading || queryID == "" || queryID != m.LogsQueryID {
+		return nil
+	}
+	return tea.Tick(constants.LogsQueryPollInterval, func(time.Time) tea.Msg {
+		insightsOperation, err := getLogsInsightsOperation(m)
+		if err != nil {
+			return model.LogsQueryResultsMsg{QueryID: queryID, Err: err}
+		}
+
+		results, err := insightsOperation.GetQueryResults(context.Background(), queryID)
+		return model.LogsQueryResultsMsg{QueryID: queryID, Results: results, Err: err}
+	})
+}
+
+// HandleLogsQueryResults shows how far a running query has got, and its records once it's complete
+func HandleLogsQueryResults(m *model.Model, msg model.LogsQueryResultsMsg) *model.Model {
+	// Ignore the results of a query that's no longer running
+	if msg.QueryID != m.LogsQueryID {
+		return m
+	}
+
+	newModel := m.Clone()
+	if msg.Err != nil {
+		newModel.IsLoading = false
+		newModel.LogsQueryID = ""
+		newModel.Err = msg.Err
+		return newModel
+	}
+
+	newModel.LogsQueryResults = msg.Results
+	if !msg.Results.Done() {
+		return newModel
+	}
+
+	newModel.IsLoading = false
+	newModel.LogsQueryID = ""
+	if msg.Results.Status != cloud.LogsQueryComplete {
+		newModel.Err = fmt.Errorf(constants.MsgErrorQueryStatus, msg.Results.Status)
+		return newModel
+	}
+
+	newModel.CurrentView = constants.ViewLogsResults
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleSavedQueriesLoad lists the queries saved over any of the selected log groups
+func HandleSavedQueriesLoad(m *model.Model) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingSavedQueries
+	logGroups := m.SelectedLogGroups
+
+	return WrapModel(newModel), func() tea.Msg {
+		insightsOperation, err := getLogsInsightsOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		queries, err := insightsOperation.ListSavedQueries(context.Background(), logGroups)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.SavedQueriesMsg{Queries: queries}
+	}
+}
+
+// HandleSavedQueries shows the queries saved over the selected log groups
+func HandleSavedQueries(m *model.Model, msg model.SavedQueriesMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.SavedQueries = msg.Queries
+	newModel.CurrentView = constants.ViewSavedQueries
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleSavedQuerySelection loads the selected saved query into the editor, keeping the
+// selected log groups
+func HandleSavedQuerySelection(m *model.Model) (tea.Model, tea.Cmd) {
+	cursor := m.Table.Cursor()
+	if len(m.Table.Rows()) == 0 || cursor < 0 || cursor >= len(m.SavedQueries) {
+		return WrapModel(m), nil
+	}
+
+	saved := m.SavedQueries[cursor]
+	newModel := m.Clone()
+	newModel.TextArea.SetValue(saved.Query)
+	newModel.LogsSavedQuery = &saved
+	newModel.IsLogsQueryInputMode = false
+	newModel.CurrentView = constants.ViewLogsQuery
+	return WrapModel(newModel), nil
+}
+
+// HandleLogsQuerySave saves the query in the editor over the selected log groups. Saving it
+// under the name of the saved query it was loaded from replaces that query.
+func HandleLogsQuerySave(m *model.Model, name string) (tea.Model, tea.Cmd) {
+	name = strings.TrimSpace(name)
+	queryString := strings.TrimSpace(m.TextArea.Value())
+	var err error
+	switch {
+	case name == "":
+		err = fmt.Errorf(constants.MsgErrorEmptyName)
+	case queryString == "":
+		err = fmt.Errorf(constants.MsgErrorEmptyQuery)
+	default:
+		err = validateLogGroups(m.SelectedLogGroups)
+	}
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	saved := cloud.SavedLogsQuery{
+		Name:      name,
+		LogGroups: m.SelectedLogGroups,
+		Query:     queryString,
+	}
+	if m.LogsSavedQuery != nil && m.LogsSavedQuery.Name == name {
+		saved.ID = m.LogsSavedQuery.ID
+	}
+
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgSavingQuery
+
+	return WrapModel(newModel), func() tea.Msg {
+		insightsOperation, err := getLogsInsightsOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		saved.ID, err = insightsOperation.SaveQuery(context.Background(), saved)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.LogsQuerySavedMsg{Query: saved}
+	}
+}
+
+// HandleLogsQuerySaved returns to the editor once the query is saved
+func HandleLogsQuerySaved(m *model.Model, msg model.LogsQuerySavedMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.ManualInput = false
+	newModel.ResetTextInput()
+	newModel.LogsSavedQuery = &msg.Query
+	newModel.Success = fmt.Sprintf(constants.MsgQuerySavedSuccess, msg.Query.Name)
+	return newModel
+}
+
+// HandleLogsResultsExportKey asks where to export the query results, suggesting a CSV file
+// named after the time they were exported
+func HandleLogsResultsExportKey(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.LogsQueryResults == nil {
+		return WrapModel(m), nil
+	}
+
+	newModel := m.Clone()
+	newModel.ManualInput = true
+	newModel.TextInput.Placeholder = constants.MsgEnterResultsPath
+	newModel.TextInput.SetValue(fmt.Sprintf("logs-insights-%s.csv", time.Now().Format("2006-01-02-150405")))
+	newModel.TextInput.Focus()
+	return WrapModel(newModel), nil
+}
+
+// HandleLogsResultsExport writes the query results to a CSV file, a column for each field
+func HandleLogsResultsExport(m *model.Model, path string) (tea.Model, tea.Cmd) {
+	if m.LogsQueryResults == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoResults)}
+		}
+	}
+
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorEmptyPath)}
+		}
+	}
+
+	if err := writeLogsResultsCSV(path, m.LogsQueryResults); err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.ManualInput = false
+	newModel.ResetTextInput()
+	newModel.Success = fmt.Sprintf(constants.MsgResultsExportSuccess, len(m.LogsQueryResults.Records), path)
+	return WrapModel(newModel), nil
+}
+
+// writeLogsResultsCSV creates a file with a header of the fields, followed by a row for each record
+func writeLogsResultsCSV(path string, results *cloud.LogsQueryResults) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(results.Fields); err != nil {
+		file.Close()
+		return err
+	}
+	if err := writer.WriteAll(results.Records); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// newLogsQueryTextArea returns the editor for a query, starting with the default query
+func newLogsQueryTextArea() textarea.Model {
+	ta := textarea.New()
+	ta.Placeholder = constants.MsgEnterLogsQuery
+	ta.ShowLineNumbers = true
+	ta.CharLimit = 0
+	ta.SetValue(constants.DefaultLogsQuery)
+	ta.Focus()
+	return ta
+}
+
+// toggleLogGroup returns the selected log groups with a log group added, or removed if it was
+// already selected. The selection is copied, as it's shared with earlier models.
+func toggleLogGroup(selected []string, logGroup string) []string {
+	toggled := make([]string, 0, len(selected)+1)
+	for _, name := range selected {
+		if name != logGroup {
+			toggled = append(toggled, name)
+		}
+	}
+	if len(toggled) == len(selected) {
+		toggled = append(toggled, logGroup)
+	}
+	return toggled
+}
+
+// validateLogGroups checks that a query runs over as many log groups as Logs Insights allows
+func validateLogGroups(logGroups []string) error {
+	if len(logGroups) == 0 {
+		return fmt.Errorf(constants.MsgErrorNoLogGroups)
+	}
+	if len(logGroups) > constants.MaxQueryLogGroups {
+		return fmt.Errorf(constants.MsgErrorManyLogGroups, constants.MaxQueryLogGroups, len(logGroups))
+	}
+	return nil
+}
+
+// nextLogsTimeRange returns the time range after the current one, cycling back to the shortest
+func nextLogsTimeRange(timeRange time.Duration) time.Duration {
+	for i, r := range constants.LogsTimeRanges {
+		if r == timeRange && i+1 < len(constants.LogsTimeRanges) {
+			return constants.LogsTimeRanges[i+1]
+		}
+	}
+	return constants.LogsTimeRanges[0]
+}
+
+// getLogsInsightsOperation gets the Logs Insights operation from the selected provider
+func getLogsInsightsOperation(m *model.Model) (cloud.LogsInsightsOperation, error) {
+	provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetLogsInsightsOperation()
+}
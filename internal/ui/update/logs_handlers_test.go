@@ -0,0 +1,275 @@
+package update
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// logsTestOperation lists two log groups, completes queries on the second poll and records
+// the queries it was asked to run and save
+type logsTestOperation struct {
+	cloud.LogsInsightsOperation
+	query cloud.LogsQuery
+	polls int
+	saved *cloud.SavedLogsQuery
+}
+
+func (o *logsTestOperation) ListLogGroups(ctx context.Context) ([]cloud.LogGroup, error) {
+	return []cloud.LogGroup{
+		{Name: "/aws/lambda/api", StoredBytes: 2048, RetentionDays: 14},
+		{Name: "/aws/lambda/worker"},
+	}, nil
+}
+
+func (o *logsTestOperation) StartQuery(ctx context.Context, query cloud.LogsQuery) (string, error) {
+	o.query = query
+	return "query-1", nil
+}
+
+func (o *logsTestOperation) GetQueryResults(ctx context.Context, queryID string) (*cloud.LogsQueryResults, error) {
+	o.polls++
+	if o.polls == 1 {
+		return &cloud.LogsQueryResults{Status: cloud.LogsQueryRunning, RecordsScanned: 500}, nil
+	}
+	return &cloud.LogsQueryResults{
+		Status:         cloud.LogsQueryComplete,
+		RecordsMatched: 2,
+		RecordsScanned: 1000,
+		Fields:         []string{"@timestamp", "@message"},
+		Records: [][]string{
+			{"2025-01-01 00:00:01.000", "ERROR timeout"},
+			{"2025-01-01 00:00:00.000", "ERROR \"quoted\", with a comma"},
+		},
+	}, nil
+}
+
+func (o *logsTestOperation) ListSavedQueries(ctx context.Context, logGroups []string) ([]cloud.SavedLogsQuery, error) {
+	return []cloud.SavedLogsQuery{
+		{ID: "definition-1", Name: "errors", LogGroups: []string{"/aws/lambda/api"}, Query: "filter @message like /ERROR/"},
+	}, nil
+}
+
+func (o *logsTestOperation) SaveQuery(ctx context.Context, query cloud.SavedLogsQuery) (string, error) {
+	o.saved = &query
+	if query.ID != "" {
+		return query.ID, nil
+	}
+	return "definition-2", nil
+}
+
+// newLogsTestModel returns a model listing the log groups
+func newLogsTestModel(operation *logsTestOperation) *model.Model {
+	m := newTestModel(&testProvider{logsInsights: operation})
+	m.Width = 100
+	result, cmd := HandleLogGroupsLoad(m)
+	return HandleLogGroups(result.(ModelWrapper).Model, cmd().(model.LogGroupsMsg))
+}
+
+// pressLogsQueryKey presses a key in the query editor
+func pressLogsQueryKey(m *model.Model, key tea.KeyMsg) (*model.Model, tea.Cmd) {
+	result, cmd := HandleLogsQueryKey(m, key)
+	return result.(ModelWrapper).Model, cmd
+}
+
+// runeKey returns the key press of a character
+func runeKey(key string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+func TestLogGroupSelection(t *testing.T) {
+	m := newLogsTestModel(&logsTestOperation{})
+	if m.CurrentView != constants.ViewLogGroups || len(m.Table.Rows()) != 3 {
+		t.Fatalf("Expected both log groups below the write query row, got view %v with %d rows", m.CurrentView, len(m.Table.Rows()))
+	}
+
+	// A query needs a log group
+	m.Table.SetCursor(0)
+	if _, cmd := HandleTableSelect(m); cmd == nil {
+		t.Errorf("Expected an error without a log group selected")
+	}
+
+	// Selecting a log group twice deselects it
+	for _, cursor := range []int{2, 1, 2} {
+		m.Table.SetCursor(cursor)
+		result, _ := HandleTableSelect(m)
+		m = result.(ModelWrapper).Model
+	}
+	if len(m.SelectedLogGroups) != 1 || m.SelectedLogGroups[0] != "/aws/lambda/api" {
+		t.Fatalf("Expected only the api log group, got %v", m.SelectedLogGroups)
+	}
+	if row := m.Table.Rows()[1]; !strings.HasPrefix(row[0], constants.LogGroupSelected) || m.Table.Cursor() != 2 {
+		t.Errorf("Expected the api log group marked with the cursor kept, got %q at %d", row[0], m.Table.Cursor())
+	}
+
+	m.Table.SetCursor(0)
+	result, _ := HandleTableSelect(m)
+	m = result.(ModelWrapper).Model
+	if m.CurrentView != constants.ViewLogsQuery || m.TextArea.Value() != constants.DefaultLogsQuery {
+		t.Fatalf("Expected the editor with the default query, got view %v", m.CurrentView)
+	}
+
+	// Going back keeps the selection to add log groups to
+	m, _ = pressLogsQueryKey(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentView != constants.ViewLogGroups || len(m.SelectedLogGroups) != 1 {
+		t.Errorf("Expected the log groups with the selection kept, got view %v with %v", m.CurrentView, m.SelectedLogGroups)
+	}
+}
+
+func TestLogsQueryRun(t *testing.T) {
+	operation := &logsTestOperation{}
+	m := newLogsTestModel(operation)
+	m.SelectedLogGroups = []string{"/aws/lambda/api"}
+	m.CurrentView = constants.ViewLogsQuery
+
+	// Keys edit the query only in input mode
+	m, _ = pressLogsQueryKey(m, runeKey(constants.KeyTimeRange))
+	if m.LogsTimeRange != 3*time.Hour {
+		t.Errorf("Expected the next time range, got %v", m.LogsTimeRange)
+	}
+	m, _ = pressLogsQueryKey(m, runeKey("i"))
+	m.TextArea.SetValue("")
+	m, _ = pressLogsQueryKey(m, runeKey("t"))
+	m, _ = pressLogsQueryKey(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.IsLogsQueryInputMode || m.TextArea.Value() != "t" {
+		t.Fatalf("Expected the key to be typed, got %q", m.TextArea.Value())
+	}
+	m.TextArea.SetValue("filter @message like /ERROR/")
+
+	m, start := pressLogsQueryKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.IsLoading {
+		t.Fatalf("Expected the query to start")
+	}
+	started := start().(model.LogsQueryStartedMsg)
+	if got := operation.query; got.Query != "filter @message like /ERROR/" || got.End.Sub(got.Start) != 3*time.Hour {
+		t.Errorf("Expected the query over the last 3h, got %+v", got)
+	}
+
+	// The query is polled until it completes, showing its progress meanwhile
+	m = HandleLogsQueryStarted(m, started)
+	poll := PollLogsQuery(m, started.QueryID)
+	if poll == nil || PollLogsQuery(m, "query-0") != nil {
+		t.Fatalf("Expected only the running query to be polled")
+	}
+	running := poll().(model.LogsQueryResultsMsg)
+	m = HandleLogsQueryResults(m, running)
+	if !m.IsLoading || m.LogsQueryResults.RecordsScanned != 500 || PollLogsQuery(m, started.QueryID) == nil {
+		t.Fatalf("Expected the query to keep running, got %+v", m.LogsQueryResults)
+	}
+
+	results, _ := operation.GetQueryResults(context.Background(), started.QueryID)
+	if stale := HandleLogsQueryResults(m, model.LogsQueryResultsMsg{QueryID: "query-0", Results: results}); stale.CurrentView != constants.ViewLogsQuery {
+		t.Errorf("Expected the results of another query to be ignored")
+	}
+	m = HandleLogsQueryResults(m, model.LogsQueryResultsMsg{QueryID: started.QueryID, Results: results})
+	if m.IsLoading || m.CurrentView != constants.ViewLogsResults || PollLogsQuery(m, started.QueryID) != nil {
+		t.Fatalf("Expected the results once the query completed, got view %v", m.CurrentView)
+	}
+
+	columns := m.Table.Columns()
+	if len(columns) != 2 || columns[0].Title != "@timestamp" || columns[1].Title != "@message" || len(m.Table.Rows()) != 2 {
+		t.Errorf("Expected a column for each field and a row for each record, got %v with %d rows", columns, len(m.Table.Rows()))
+	}
+}
+
+func TestLogsQueryFailure(t *testing.T) {
+	m := newLogsTestModel(&logsTestOperation{})
+	m.CurrentView = constants.ViewLogsQuery
+	m.IsLoading = true
+	m.LogsQueryID = "query-1"
+
+	m = HandleLogsQueryResults(m, model.LogsQueryResultsMsg{QueryID: "query-1", Results: &cloud.LogsQueryResults{Status: cloud.LogsQueryTimeout}})
+	if m.IsLoading || m.Err == nil || !strings.Contains(m.Err.Error(), cloud.LogsQueryTimeout) {
+		t.Errorf("Expected an error naming the status, got %v", m.Err)
+	}
+	if m.CurrentView != constants.ViewLogsQuery {
+		t.Errorf("Expected to stay in the editor, got view %v", m.CurrentView)
+	}
+}
+
+func TestLogsSavedQueries(t *testing.T) {
+	operation := &logsTestOperation{}
+	m := newLogsTestModel(operation)
+	m.SelectedLogGroups = []string{"/aws/lambda/api", "/aws/lambda/worker"}
+	m.CurrentView = constants.ViewLogsQuery
+
+	m, list := pressLogsQueryKey(m, runeKey(constants.KeySavedQueries))
+	m = HandleSavedQueries(m, list().(model.SavedQueriesMsg))
+	m.Table.SetCursor(0)
+	result, _ := HandleTableSelect(m)
+	m = result.(ModelWrapper).Model
+	if m.CurrentView != constants.ViewLogsQuery || m.TextArea.Value() != "filter @message like /ERROR/" {
+		t.Fatalf("Expected the saved query in the editor, got view %v with %q", m.CurrentView, m.TextArea.Value())
+	}
+
+	testCases := []struct {
+		name       string
+		input      string
+		expectedID string
+	}{
+		{name: "Same name replaces the saved query", input: "errors", expectedID: "definition-1"},
+		{name: "New name saves another query", input: "api errors", expectedID: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prompt, _ := pressLogsQueryKey(m, runeKey(constants.KeySaveQuery))
+			if !prompt.ManualInput || prompt.TextInput.Value() != "errors" {
+				t.Fatalf("Expected to be asked for a name, suggesting the loaded one")
+			}
+
+			result, cmd := HandleLogsQuerySave(prompt, tc.input)
+			saved := HandleLogsQuerySaved(result.(ModelWrapper).Model, cmd().(model.LogsQuerySavedMsg))
+			if operation.saved == nil || operation.saved.ID != tc.expectedID || len(operation.saved.LogGroups) != 2 {
+				t.Fatalf("Expected the query saved over both log groups with ID %q, got %+v", tc.expectedID, operation.saved)
+			}
+			if saved.ManualInput || saved.LogsSavedQuery == nil || saved.LogsSavedQuery.Name != tc.input || saved.LogsSavedQuery.ID == "" {
+				t.Errorf("Expected the editor to track the saved query, got %+v", saved.LogsSavedQuery)
+			}
+		})
+	}
+
+	if _, cmd := HandleLogsQuerySave(m, " "); cmd == nil {
+		t.Errorf("Expected an error for an empty name")
+	}
+}
+
+func TestLogsResultsExport(t *testing.T) {
+	operation := &logsTestOperation{polls: 1}
+	m := newLogsTestModel(operation)
+	results, _ := operation.GetQueryResults(context.Background(), "query-1")
+	m.LogsQueryResults = results
+	m.CurrentView = constants.ViewLogsResults
+
+	result, _ := HandleExportKey(m)
+	m = result.(ModelWrapper).Model
+	if !m.ManualInput || !strings.HasSuffix(m.TextInput.Value(), ".csv") {
+		t.Fatalf("Expected to be asked for a CSV file, got %q", m.TextInput.Value())
+	}
+
+	path := filepath.Join(t.TempDir(), "results.csv")
+	result, _ = HandleLogsResultsExport(m, path)
+	m = result.(ModelWrapper).Model
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "@timestamp,@message\n" +
+		"2025-01-01 00:00:01.000,ERROR timeout\n" +
+		"2025-01-01 00:00:00.000,\"ERROR \"\"quoted\"\", with a comma\"\n"
+	if string(data) != expected {
+		t.Errorf("Expected %q, got %q", expected, string(data))
+	}
+	if m.ManualInput || m.Success != "Exported 2 records to "+path {
+		t.Errorf("Expected the export to succeed, got %q", m.Success)
+	}
+}
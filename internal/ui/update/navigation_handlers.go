@@ -3,6 +3,7 @@ package update
 import (
 	"sort"
 
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
 	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
 	"github.com/HenryOwenz/cloudgate/internal/ui/model"
 	"github.com/HenryOwenz/cloudgate/internal/ui/view"
@@ -103,7 +104,11 @@ func NavigateBack(m *model.Model) *model.Model {
 		newModel.Summary = ""
 		newModel.ResetTextInput()
 	case constants.ViewExecutingAction:
-		if m.SelectedOperation != nil && m.SelectedOperation.Name == "Start Pipeline" {
+		if m.PendingAction != nil {
+			// Go back to the view that staged the action
+			newModel.CurrentView = m.PendingAction.BackView
+			newModel.PendingAction = nil
+		} else if m.SelectedOperation != nil && m.SelectedOperation.Name == "Start Pipeline" {
 			// For pipeline start flow, go back to pipeline status view
 			newModel.CurrentView = constants.ViewPipelineStatus
 
@@ -139,6 +144,10 @@ func NavigateBack(m *model.Model) *model.Model {
 		newModel.Provider = nil
 	case constants.ViewFunctionStatus:
 		newModel.CurrentView = constants.ViewSelectOperation
+		if m.SelectedOperation != nil && m.SelectedOperation.Name == "Event Source Mappings" {
+			// Go back to the choice of scope
+			newModel.CurrentView = constants.ViewEventSourceScope
+		}
 		newModel.Functions = nil
 		newModel.Provider = nil
 	case constants.ViewFunctionDetails:
@@ -156,9 +165,247 @@ func NavigateBack(m *model.Model) *model.Model {
 			newModel.CurrentView = constants.ViewFunctionDetails
 		}
 	case constants.ViewLambdaResponse:
-		// Always go back to the Lambda execute view
+		// Always go back to the Lambda execute view, stopping a stream that is still arriving
 		// The next back navigation will handle the flow correctly
+		stopLambdaInvoke(newModel)
 		newModel.CurrentView = constants.ViewLambdaExecute
+	case constants.ViewLambdaConfig:
+		// Discard staged changes and go back to the function list
+		newModel.CurrentView = constants.ViewFunctionStatus
+		newModel.FunctionConfig = nil
+		newModel.FunctionConfigUpdate = cloud.FunctionConfigUpdate{}
+		newModel.FunctionConfigField = ""
+		newModel.SetSelectedFunction(nil)
+	case constants.ViewLambdaDeploy:
+		// Discard the staged deployment and go back to the function list
+		newModel.CurrentView = constants.ViewFunctionStatus
+		newModel.DeployRequest = cloud.LambdaDeployRequest{}
+		newModel.DeployField = ""
+		newModel.SetSelectedFunction(nil)
+	case constants.ViewEventSourceScope:
+		newModel.CurrentView = constants.ViewSelectOperation
+	case constants.ViewEventSourceMappings:
+		// Go back to the function list if the mappings are scoped to a function
+		if m.EventSourceFunction != "" {
+			newModel.CurrentView = constants.ViewFunctionStatus
+			newModel.SetSelectedFunction(nil)
+		} else {
+			newModel.CurrentView = constants.ViewEventSourceScope
+		}
+		newModel.EventSourceMappings = nil
+		newModel.EventSourceFunction = ""
+	case constants.ViewEventSourceDetails:
+		newModel.CurrentView = constants.ViewEventSourceMappings
+		newModel.SelectedEventSource = nil
+	case constants.ViewHygieneOptions:
+		newModel.CurrentView = constants.ViewSelectOperation
+		newModel.HygieneField = ""
+	case constants.ViewHygieneReport:
+		// Keep the options so the report can be run again with different ones
+		newModel.CurrentView = constants.ViewHygieneOptions
+		newModel.HygieneReport = nil
+	case constants.ViewLambdaBench:
+		// Go back to the payload editor
+		newModel.CurrentView = constants.ViewLambdaExecute
+		newModel.BenchField = ""
+	case constants.ViewLambdaBenchResult:
+		// Keep the options so the benchmark can be run again with different ones
+		newModel.CurrentView = constants.ViewLambdaBench
+		newModel.BenchResult = nil
+	case constants.ViewPackageBrowser:
+		// Go up a directory, or back to the function once at the root of the package
+		if m.PackageDir != "" {
+			newModel.PackageDir = parentPackageDir(m.PackageDir)
+		} else {
+			newModel.CurrentView = constants.ViewFunctionDetails
+			newModel.FunctionPackage = nil
+		}
+	case constants.ViewPackageFile:
+		newModel.CurrentView = constants.ViewPackageBrowser
+		newModel.PackageFilePath = ""
+	case constants.ViewFunctionSecurity:
+		newModel.CurrentView = constants.ViewFunctionDetails
+		newModel.FunctionSecurity = nil
+	case constants.ViewCompareOptions:
+		newModel.CurrentView = constants.ViewFunctionDetails
+		newModel.CompareField = ""
+	case constants.ViewFunctionCompare:
+		// Keep the targets so the comparison can be run again with different ones
+		newModel.CurrentView = constants.ViewCompareOptions
+		newModel.FunctionComparison = nil
+	case constants.ViewAsyncConfig:
+		newModel.CurrentView = constants.ViewFunctionDetails
+		newModel.AsyncConfig = nil
+	case constants.ViewFailedEvents:
+		newModel.CurrentView = constants.ViewAsyncConfig
+		newModel.FailedEvents = nil
+		newModel.FailedEventQueue = ""
+	case constants.ViewS3Buckets:
+		newModel.CurrentView = constants.ViewSelectOperation
+		newModel.S3Buckets = nil
+	case constants.ViewS3Objects:
+		// Go up a prefix, or back to the buckets once at the root of the bucket
+		if len(m.S3Listings) > 1 {
+			newModel.S3Listings = newModel.S3Listings[:len(m.S3Listings)-1]
+		} else {
+			newModel.CurrentView = constants.ViewS3Buckets
+			newModel.SelectedBucket = nil
+			newModel.S3Listings = nil
+		}
+	case constants.ViewS3ObjectDetails:
+		newModel.CurrentView = constants.ViewS3Objects
+		newModel.S3ObjectDetails = nil
+	case constants.ViewS3Presign, constants.ViewS3Preview:
+		// Return to the object's details when it was opened from them, or to the browser
+		newModel.CurrentView = constants.ViewS3Objects
+		if m.S3ObjectDetails != nil {
+			newModel.CurrentView = constants.ViewS3ObjectDetails
+		}
+		newModel.S3PresignedURL = nil
+		newModel.S3Preview = nil
+		newModel.S3InputField = ""
+	case constants.ViewEC2Filters:
+		newModel.CurrentView = constants.ViewSelectOperation
+	case constants.ViewEC2Instances:
+		// Keep the filter so the instances can be listed again with a different one
+		newModel.CurrentView = constants.ViewEC2Filters
+		newModel.EC2Instances = nil
+	case constants.ViewEC2InstanceDetails:
+		newModel.CurrentView = constants.ViewEC2Instances
+		newModel.EC2InstanceDetails = nil
+	case constants.ViewLogGroups:
+		newModel.CurrentView = constants.ViewSelectOperation
+		newModel.LogGroups = nil
+		newModel.SelectedLogGroups = nil
+	case constants.ViewLogsQuery:
+		// Keep the query and the selection so other log groups can be added to it
+		newModel.CurrentView = constants.ViewLogGroups
+		newModel.IsLogsQueryInputMode = false
+	case constants.ViewSavedQueries:
+		newModel.CurrentView = constants.ViewLogsQuery
+		newModel.SavedQueries = nil
+	case constants.ViewLogsResults:
+		newModel.CurrentView = constants.ViewLogsQuery
+	case constants.ViewAlarms:
+		newModel.CurrentView = constants.ViewSelectOperation
+		newModel.Alarms = nil
+	case constants.ViewAlarmDetails:
+		// Go back to the dashboard, or the function or pipeline the alarm was cross-linked into
+		newModel.CurrentView = m.AlarmBackView
+		newModel.SelectedAlarm = nil
+	case constants.ViewAlarmHistory:
+		newModel.CurrentView = constants.ViewAlarmDetails
+		newModel.AlarmHistory = nil
+	case constants.ViewECSClusters:
+		newModel.CurrentView = constants.ViewSelectOperation
+		newModel.ECSClusters = nil
+	case constants.ViewECSServices:
+		newModel.CurrentView = constants.ViewECSClusters
+		newModel.ECSServices = nil
+	case constants.ViewECSServiceDetails:
+		newModel.CurrentView = constants.ViewECSServices
+		newModel.SelectedECSService = nil
+	case constants.ViewECSTasks:
+		newModel.CurrentView = constants.ViewECSServiceDetails
+		newModel.ECSTasks = nil
+	case constants.ViewECSTaskDetails:
+		newModel.CurrentView = constants.ViewECSTasks
+		newModel.SelectedECSTask = nil
+	case constants.ViewBuildProjects:
+		newModel.CurrentView = constants.ViewSelectOperation
+		newModel.BuildProjects = nil
+	case constants.ViewBuilds:
+		newModel.CurrentView = constants.ViewBuildProjects
+		newModel.Builds = nil
+	case constants.ViewStartBuild:
+		newModel.CurrentView = constants.ViewBuilds
+		newModel.BuildStart = cloud.BuildStart{}
+	case constants.ViewBuildDetails:
+		newModel.CurrentView = constants.ViewBuilds
+		newModel.SelectedBuild = nil
+	case constants.ViewBuildLogs:
+		// Stop following the log, which is read again from the start if it's shown again
+		newModel.CurrentView = constants.ViewBuildDetails
+		newModel.BuildFollowID = ""
+		newModel.BuildLogs = nil
+		newModel.BuildLogsToken = ""
+	case constants.ViewStacks:
+		newModel.CurrentView = constants.ViewSelectOperation
+		newModel.Stacks = nil
+	case constants.ViewStackDetails:
+		newModel.CurrentView = constants.ViewStacks
+		newModel.SelectedStack = nil
+	case constants.ViewStackEvents:
+		newModel.CurrentView = constants.ViewStackDetails
+		newModel.StackEvents = nil
+	case constants.ViewStackDrift:
+		newModel.CurrentView = constants.ViewStackDetails
+		newModel.StackDrifts = nil
+	case constants.ViewResourceDrift:
+		newModel.CurrentView = constants.ViewStackDrift
+		newModel.SelectedResourceDrift = nil
+	case constants.ViewChangeSets:
+		newModel.CurrentView = constants.ViewStackDetails
+		newModel.ChangeSets = nil
+	case constants.ViewChangeSetDetails:
+		newModel.CurrentView = constants.ViewChangeSets
+		newModel.SelectedChangeSet = nil
+	case constants.ViewParameters:
+		// Go up a path, or back to the operations once at the root
+		if m.ParameterPath != cloud.ParameterRootPath {
+			newModel.ParameterPath = parentParameterPath(m.ParameterPath)
+		} else {
+			newModel.CurrentView = constants.ViewSelectOperation
+			newModel.Parameters = nil
+		}
+	case constants.ViewParameterDetails:
+		newModel.CurrentView = constants.ViewParameters
+		newModel.SelectedParameter = nil
+		newModel.ParameterValue = nil
+	case constants.ViewParameterHistory:
+		newModel.CurrentView = constants.ViewParameterDetails
+		newModel.ParameterHistory = nil
+	case constants.ViewParameterEdit:
+		newModel.CurrentView = constants.ViewParameterDetails
+		newModel.IsParameterInputMode = false
+	case constants.ViewSecrets:
+		newModel.CurrentView = constants.ViewSelectOperation
+		newModel.Secrets = nil
+	case constants.ViewSecretDetails:
+		newModel.CurrentView = constants.ViewSecrets
+		newModel.SelectedSecret = nil
+		newModel.SecretValue = nil
+		newModel.SecretKeys = nil
+	case constants.ViewQueues:
+		newModel.CurrentView = constants.ViewSelectOperation
+		newModel.Queues = nil
+	case constants.ViewQueueDetails:
+		newModel.CurrentView = constants.ViewQueues
+		newModel.SelectedQueue = nil
+	case constants.ViewQueueMessages:
+		newModel.CurrentView = constants.ViewQueueDetails
+		newModel.QueueMessages = nil
+	case constants.ViewQueueMessage:
+		newModel.CurrentView = constants.ViewQueueMessages
+		newModel.SelectedQueueMessage = nil
+	case constants.ViewQueueSend:
+		newModel.CurrentView = constants.ViewQueueDetails
+		newModel.IsQueueInputMode = false
+	case constants.ViewTopics:
+		newModel.CurrentView = constants.ViewSelectOperation
+		newModel.Topics = nil
+	case constants.ViewTopicDetails:
+		newModel.CurrentView = constants.ViewTopics
+		newModel.SelectedTopic = nil
+	case constants.ViewSubscriptions:
+		newModel.CurrentView = constants.ViewTopicDetails
+		newModel.Subscriptions = nil
+	case constants.ViewSubscription:
+		newModel.CurrentView = constants.ViewSubscriptions
+		newModel.SelectedSubscription = nil
+	case constants.ViewTopicPublish:
+		newModel.CurrentView = constants.ViewTopicDetails
+		newModel.IsTopicInputMode = false
 	}
 
 	return newModel
@@ -195,13 +442,103 @@ func HandleTableSelect(m *model.Model) (tea.Model, tea.Cmd) {
 		return HandlePipelineSelection(m)
 	case constants.ViewFunctionStatus:
 		return HandleFunctionSelection(m)
+	case constants.ViewLambdaConfig:
+		return HandleLambdaConfigSelection(m)
+	case constants.ViewLambdaDeploy:
+		return HandleLambdaDeploySelection(m)
+	case constants.ViewEventSourceScope:
+		return HandleEventSourceScopeSelection(m)
+	case constants.ViewEventSourceMappings:
+		return HandleEventSourceSelection(m)
+	case constants.ViewEventSourceDetails:
+		return HandleEventSourceDetailsSelection(m)
+	case constants.ViewHygieneOptions:
+		return HandleHygieneOptionsSelection(m)
+	case constants.ViewLambdaBench:
+		return HandleLambdaBenchSelection(m)
+	case constants.ViewPackageBrowser:
+		return HandlePackageSelection(m)
+	case constants.ViewCompareOptions:
+		return HandleCompareOptionsSelection(m)
+	case constants.ViewAsyncConfig:
+		return HandleAsyncConfigSelection(m)
+	case constants.ViewFailedEvents:
+		return HandleFailedEventSelection(m)
+	case constants.ViewS3Buckets:
+		return HandleS3BucketSelection(m)
+	case constants.ViewS3Objects:
+		return HandleS3ObjectSelection(m)
+	case constants.ViewS3Presign:
+		return HandleS3PresignSelection(m)
+	case constants.ViewEC2Filters:
+		return HandleEC2FiltersSelection(m)
+	case constants.ViewEC2Instances:
+		return HandleEC2InstanceSelection(m)
+	case constants.ViewEC2InstanceDetails:
+		return HandleEC2InstanceDetailsSelection(m)
+	case constants.ViewLogGroups:
+		return HandleLogGroupSelection(m)
+	case constants.ViewSavedQueries:
+		return HandleSavedQuerySelection(m)
+	case constants.ViewAlarms:
+		return HandleAlarmSelection(m)
+	case constants.ViewAlarmDetails:
+		return HandleAlarmDetailsSelection(m)
+	case constants.ViewECSClusters:
+		return HandleECSClusterSelection(m)
+	case constants.ViewECSServices:
+		return HandleECSServiceSelection(m)
+	case constants.ViewECSServiceDetails:
+		return HandleECSServiceDetailsSelection(m)
+	case constants.ViewECSTasks:
+		return HandleECSTaskSelection(m)
+	case constants.ViewBuildProjects:
+		return HandleBuildProjectSelection(m)
+	case constants.ViewBuilds:
+		return HandleBuildSelection(m)
+	case constants.ViewStartBuild:
+		return HandleStartBuildSelection(m)
+	case constants.ViewBuildDetails:
+		return HandleBuildDetailsSelection(m)
+	case constants.ViewStacks:
+		return HandleStackSelection(m)
+	case constants.ViewStackDetails:
+		return HandleStackDetailsSelection(m)
+	case constants.ViewStackDrift:
+		return HandleStackDriftSelection(m)
+	case constants.ViewChangeSets:
+		return HandleChangeSetSelection(m)
+	case constants.ViewChangeSetDetails:
+		return HandleChangeSetDetailsSelection(m)
+	case constants.ViewParameters:
+		return HandleParameterBrowserSelection(m)
+	case constants.ViewParameterDetails:
+		return HandleParameterDetailsSelection(m)
+	case constants.ViewSecrets:
+		return HandleSecretSelection(m)
+	case constants.ViewSecretDetails:
+		return HandleSecretDetailsSelection(m)
+	case constants.ViewQueues:
+		return HandleQueueSelection(m)
+	case constants.ViewQueueDetails:
+		return HandleQueueDetailsSelection(m)
+	case constants.ViewQueueMessages:
+		return HandleQueueMessageSelection(m)
+	case constants.ViewTopics:
+		return HandleTopicSelection(m)
+	case constants.ViewTopicDetails:
+		return HandleTopicDetailsSelection(m)
+	case constants.ViewSubscriptions:
+		return HandleSubscriptionSelection(m)
+	case constants.ViewPipelineStages:
+		return HandleLinkedAlarmSelection(m)
 	case constants.ViewFunctionDetails:
 		// Only go to Lambda execution view if we're in the Lambda execution flow
 		if m.IsExecuteLambdaFlow {
 			return HandleLambdaExecuteSelection(m)
 		}
-		// Otherwise, do nothing
-		return WrapModel(m), nil
+		// Otherwise, open the alarm on the selected row, if any
+		return HandleLinkedAlarmSelection(m)
 	default:
 		return WrapModel(m), nil
 	}
@@ -527,6 +864,34 @@ func HandleTextInputSubmission(m *model.Model) (tea.Model, tea.Cmd) {
 			view.UpdateTableForView(newModel)
 			return WrapModel(newModel), nil
 		}
+	case constants.ViewLambdaConfig:
+		return HandleLambdaConfigInput(m, value)
+	case constants.ViewLambdaDeploy:
+		return HandleLambdaDeployInput(m, value)
+	case constants.ViewHygieneOptions:
+		return HandleHygieneInput(m, value)
+	case constants.ViewHygieneReport:
+		return HandleHygieneExport(m, value)
+	case constants.ViewLambdaBench:
+		return HandleLambdaBenchInput(m, value)
+	case constants.ViewPackageBrowser:
+		return HandlePackageExtract(m, value)
+	case constants.ViewCompareOptions:
+		return HandleCompareInput(m, value)
+	case constants.ViewS3Objects, constants.ViewS3ObjectDetails:
+		return HandleS3TransferInput(m, value)
+	case constants.ViewS3Presign:
+		return HandleS3PresignInput(m, value)
+	case constants.ViewEC2Filters:
+		return HandleEC2FilterInput(m, value)
+	case constants.ViewLogsResults:
+		return HandleLogsResultsExport(m, value)
+	case constants.ViewECSServiceDetails:
+		return HandleECSScaleInput(m, value)
+	case constants.ViewStartBuild:
+		return HandleStartBuildInput(m, value)
+	case constants.ViewQueueDetails:
+		return HandleQueuePurgeInput(m, value)
 	}
 
 	return WrapModel(newModel), nil
@@ -0,0 +1,166 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandlePackageDownload downloads the deployment package of the function shown in the details view
+func HandlePackageDownload(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.CurrentView != constants.ViewFunctionDetails {
+		return WrapModel(m), nil
+	}
+	if m.SelectedFunction == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoFunction)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgDownloadingPackage
+	functionName := m.SelectedFunction.Name
+
+	return WrapModel(newModel), func() tea.Msg {
+		// Get the provider
+		provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the LambdaPackageOperation from the provider
+		packageOperation, err := provider.GetLambdaPackageOperation()
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		pkg, err := packageOperation.DownloadFunctionPackage(context.Background(), functionName)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.FunctionPackageMsg{Package: pkg}
+	}
+}
+
+// HandleFunctionPackage shows the root directory of a downloaded package
+func HandleFunctionPackage(m *model.Model, msg model.FunctionPackageMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.FunctionPackage = msg.Package
+	newModel.PackageDir = ""
+	newModel.PackageFilePath = ""
+	newModel.CurrentView = constants.ViewPackageBrowser
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandlePackageSelection opens the selected directory in the package browser, or the selected file
+// in the file viewer
+func HandlePackageSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 || m.FunctionPackage == nil {
+		return WrapModel(m), nil
+	}
+
+	newModel := m.Clone()
+	name := selected[0]
+	switch {
+	case name == view.PackageParentDir:
+		newModel.PackageDir = parentPackageDir(m.PackageDir)
+	case strings.HasSuffix(name, "/"):
+		newModel.PackageDir = path.Join(m.PackageDir, strings.TrimSuffix(name, "/"))
+	default:
+		filePath := path.Join(m.PackageDir, name)
+		data, err := m.FunctionPackage.ReadFile(filePath)
+		if err != nil {
+			return WrapModel(m), func() tea.Msg {
+				return model.ErrMsg{Err: err}
+			}
+		}
+
+		newModel.PackageFilePath = filePath
+		newModel.Viewport = viewport.New(newModel.Width-constants.ViewportMarginX*2, constants.TableHeight)
+		newModel.Viewport.YPosition = constants.HeaderHeight // Position below the title
+		newModel.Viewport.SetContent(view.PackageFileContent(data))
+		newModel.CurrentView = constants.ViewPackageFile
+		return WrapModel(newModel), nil
+	}
+
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandlePackageExtractKey asks where to extract the package, suggesting a directory named after the function
+func HandlePackageExtractKey(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.CurrentView != constants.ViewPackageBrowser || m.FunctionPackage == nil {
+		return WrapModel(m), nil
+	}
+
+	newModel := m.Clone()
+	newModel.ManualInput = true
+	newModel.TextInput.Placeholder = constants.MsgEnterExtractPath
+	newModel.TextInput.SetValue(m.FunctionPackage.FunctionName + "-package")
+	newModel.TextInput.Focus()
+	return WrapModel(newModel), nil
+}
+
+// HandlePackageExtract extracts every file of the package below a directory
+func HandlePackageExtract(m *model.Model, dir string) (tea.Model, tea.Cmd) {
+	if m.FunctionPackage == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoPackage)}
+		}
+	}
+
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorEmptyPath)}
+		}
+	}
+
+	count, err := m.FunctionPackage.Extract(dir)
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.ManualInput = false
+	newModel.ResetTextInput()
+	newModel.Success = fmt.Sprintf(constants.MsgExtractSuccess, count, dir)
+	return WrapModel(newModel), nil
+}
+
+// HandleExportKey starts exporting what the current view shows: the hygiene report, the
+// deployment package being browsed, the selected S3 object, or the Logs Insights results
+func HandleExportKey(m *model.Model) (tea.Model, tea.Cmd) {
+	switch m.CurrentView {
+	case constants.ViewPackageBrowser:
+		return HandlePackageExtractKey(m)
+	case constants.ViewS3Objects, constants.ViewS3ObjectDetails:
+		return HandleS3DownloadKey(m)
+	case constants.ViewLogsResults:
+		return HandleLogsResultsExportKey(m)
+	}
+	return HandleHygieneExportKey(m)
+}
+
+// parentPackageDir returns the directory above a directory of a package, "" being the root
+func parentPackageDir(dir string) string {
+	parent := path.Dir(dir)
+	if parent == "." || parent == "/" {
+		return ""
+	}
+	return parent
+}
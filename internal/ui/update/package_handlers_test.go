@@ -0,0 +1,166 @@
+package update
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// packageTestOperation returns a package built from a fixed set of files
+type packageTestOperation struct {
+	cloud.LambdaPackageOperation
+	files map[string]string
+}
+
+func (o *packageTestOperation) DownloadFunctionPackage(ctx context.Context, functionName string) (*cloud.FunctionPackage, error) {
+	return cloud.NewFunctionPackage(functionName, "abc123", testArchive(o.files))
+}
+
+// testArchive builds a zip archive holding the given files
+func testArchive(files map[string]string) []byte {
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	for name, content := range files {
+		file, _ := writer.Create(name)
+		_, _ = file.Write([]byte(content))
+	}
+	_ = writer.Close()
+	return archive.Bytes()
+}
+
+func TestPackageBrowse(t *testing.T) {
+	operation := &packageTestOperation{files: map[string]string{
+		"index.js":                     "exports.handler = async () => 'ok';\n",
+		"lib/db.js":                    "module.exports = {};\n",
+		"lib/util/strings.js":          "// helpers\n",
+		"node_modules/uuid/index.js":   "module.exports = 'uuid';\n",
+		"node_modules/uuid/README.md":  "# uuid\n",
+		"node_modules/left-pad/pad.js": "module.exports = 'pad';\n",
+	}}
+	m := newTestModel(&testProvider{lambdaPackage: operation})
+	m.Width = 100
+	m.CurrentView = constants.ViewFunctionDetails
+	m.SetSelectedFunction(&cloud.FunctionStatus{Name: "orders"})
+
+	result, cmd := HandlePackageDownload(m)
+	if !result.(ModelWrapper).Model.IsLoading {
+		t.Errorf("Expected the model to be loading")
+	}
+	msg, ok := cmd().(model.FunctionPackageMsg)
+	if !ok {
+		t.Fatalf("Expected a package message")
+	}
+
+	m = HandleFunctionPackage(result.(ModelWrapper).Model, msg)
+	if m.CurrentView != constants.ViewPackageBrowser {
+		t.Fatalf("Expected the package browser, got view %v", m.CurrentView)
+	}
+	var names []string
+	for _, row := range m.Table.Rows() {
+		names = append(names, row[0])
+	}
+	if got := strings.Join(names, ","); got != "lib/,node_modules/,index.js" {
+		t.Fatalf("Expected directories before files, got %s", got)
+	}
+
+	// Open node_modules/, then uuid/
+	m.Table.SetCursor(1)
+	result, _ = HandlePackageSelection(m)
+	m = result.(ModelWrapper).Model
+	if m.PackageDir != "node_modules" || len(m.Table.Rows()) != 3 || m.Table.Rows()[0][0] != ".." {
+		t.Fatalf("Expected node_modules with a parent row, got %s with %v", m.PackageDir, m.Table.Rows())
+	}
+	m.Table.SetCursor(2)
+	result, _ = HandlePackageSelection(m)
+	m = result.(ModelWrapper).Model
+	if m.PackageDir != "node_modules/uuid" {
+		t.Fatalf("Expected node_modules/uuid, got %s", m.PackageDir)
+	}
+
+	// Open index.js in the file viewer
+	m.Table.SetCursor(2)
+	result, _ = HandlePackageSelection(m)
+	m = result.(ModelWrapper).Model
+	if m.CurrentView != constants.ViewPackageFile || m.PackageFilePath != "node_modules/uuid/index.js" {
+		t.Fatalf("Expected the file viewer for index.js, got %v, %s", m.CurrentView, m.PackageFilePath)
+	}
+	if content := m.Viewport.View(); !strings.Contains(content, "1 module.exports = 'uuid';") {
+		t.Errorf("Expected the file with line numbers, got:\n%s", content)
+	}
+
+	// Going back returns to the browser, then up a directory at a time, then to the function
+	m = NavigateBack(m)
+	if m.CurrentView != constants.ViewPackageBrowser || m.PackageDir != "node_modules/uuid" {
+		t.Errorf("Expected the browser at node_modules/uuid, got %v at %s", m.CurrentView, m.PackageDir)
+	}
+	m = NavigateBack(NavigateBack(m))
+	if m.PackageDir != "" || m.CurrentView != constants.ViewPackageBrowser {
+		t.Errorf("Expected the root of the package, got %s", m.PackageDir)
+	}
+	m = NavigateBack(m)
+	if m.CurrentView != constants.ViewFunctionDetails || m.FunctionPackage != nil {
+		t.Errorf("Expected the function details, got %v", m.CurrentView)
+	}
+}
+
+func TestPackageExtract(t *testing.T) {
+	pkg, err := cloud.NewFunctionPackage("orders", "abc123", testArchive(map[string]string{
+		"index.js":  "exports.handler = async () => 'ok';\n",
+		"lib/db.js": "module.exports = {};\n",
+	}))
+	if err != nil {
+		t.Fatalf("Expected a package, got %v", err)
+	}
+
+	m := model.New()
+	m.CurrentView = constants.ViewPackageBrowser
+	m.FunctionPackage = pkg
+
+	result, _ := HandleExportKey(m)
+	if value := result.(ModelWrapper).Model.TextInput.Value(); value != "orders-package" {
+		t.Errorf("Expected a directory named after the function, got %q", value)
+	}
+
+	dir := filepath.Join(t.TempDir(), "orders")
+	result, cmd := HandlePackageExtract(m, dir)
+	if cmd != nil {
+		t.Fatalf("Expected no error, got %v", cmd())
+	}
+	if success := result.(ModelWrapper).Model.Success; !strings.Contains(success, "Extracted 2 files") {
+		t.Errorf("Expected a success message, got %q", success)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "lib", "db.js"))
+	if err != nil || string(data) != "module.exports = {};\n" {
+		t.Errorf("Expected lib/db.js to be extracted, got %q, %v", data, err)
+	}
+
+	// Entries that would escape the directory are refused before anything is written
+	unsafe, err := cloud.NewFunctionPackage("orders", "abc123", testArchive(map[string]string{
+		"index.js":       "ok",
+		"../../evil.txt": "evil",
+	}))
+	if err != nil {
+		t.Fatalf("Expected a package, got %v", err)
+	}
+	m.FunctionPackage = unsafe
+	unsafeDir := filepath.Join(t.TempDir(), "unsafe")
+	_, cmd = HandlePackageExtract(m, unsafeDir)
+	if cmd == nil {
+		t.Fatalf("Expected an error for an unsafe path")
+	}
+	if msg, ok := cmd().(model.ErrMsg); !ok || !errors.Is(msg.Err, cloud.ErrUnsafePackagePath) {
+		t.Errorf("Expected an unsafe path error, got %v", msg)
+	}
+	if _, err := os.Stat(unsafeDir); !os.IsNotExist(err) {
+		t.Errorf("Expected nothing to be extracted")
+	}
+}
@@ -0,0 +1,326 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleParametersLoad lists the parameters of the account, without their values
+func HandleParametersLoad(m *model.Model) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingParameters
+
+	return WrapModel(newModel), func() tea.Msg {
+		parameterOperation, err := getParameterStoreOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		parameters, err := parameterOperation.ListParameters(context.Background())
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.ParametersMsg{Parameters: parameters}
+	}
+}
+
+// HandleParameters shows the root path of the parameter browser
+func HandleParameters(m *model.Model, msg model.ParametersMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.Parameters = msg.Parameters
+	newModel.ParameterPath = cloud.ParameterRootPath
+	newModel.SelectedParameter = nil
+	newModel.ParameterValue = nil
+	newModel.CurrentView = constants.ViewParameters
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleParameterBrowserSelection opens the selected path in the parameter browser, or reads the
+// value of the selected parameter
+func HandleParameterBrowserSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 {
+		return WrapModel(m), nil
+	}
+
+	name := selected[0]
+	switch {
+	case name == view.ParameterParentPath:
+		newModel := m.Clone()
+		newModel.ParameterPath = parentParameterPath(m.ParameterPath)
+		view.UpdateTableForView(newModel)
+		return WrapModel(newModel), nil
+	case strings.HasSuffix(name, "/"):
+		newModel := m.Clone()
+		newModel.ParameterPath = path.Join(m.ParameterPath, strings.TrimSuffix(name, "/"))
+		view.UpdateTableForView(newModel)
+		return WrapModel(newModel), nil
+	}
+
+	for _, entry := range cloud.ListParameterPath(m.Parameters, m.ParameterPath) {
+		if !entry.IsPath && entry.Name == name {
+			return HandleParameterValueLoad(m, *entry.Parameter, false)
+		}
+	}
+	return WrapModel(m), nil
+}
+
+// HandleParameterValueLoad reads the value of a parameter, decrypting a SecureString if asked to
+func HandleParameterValueLoad(m *model.Model, parameter cloud.Parameter, decrypt bool) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingParameter
+	if decrypt {
+		newModel.LoadingMsg = constants.MsgDecryptingParameter
+	}
+
+	return WrapModel(newModel), func() tea.Msg {
+		parameterOperation, err := getParameterStoreOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		value, err := parameterOperation.GetParameterValue(context.Background(), parameter.Name, decrypt)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.ParameterValueMsg{Parameter: parameter, Value: value}
+	}
+}
+
+// HandleParameterValue shows the details of a parameter with its value. The parameter is kept at
+// the version the value was read from, which is the version a new one is put over.
+func HandleParameterValue(m *model.Model, msg model.ParameterValueMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+
+	parameter := msg.Parameter
+	if msg.Value.Version != parameter.Version {
+		parameter.Version = msg.Value.Version
+		parameter.LastModified = msg.Value.LastModified
+		newModel.Parameters = make([]cloud.Parameter, len(m.Parameters))
+		copy(newModel.Parameters, m.Parameters)
+		for i := range newModel.Parameters {
+			if newModel.Parameters[i].Name == parameter.Name {
+				newModel.Parameters[i] = parameter
+			}
+		}
+	}
+	newModel.SelectedParameter = &parameter
+	newModel.ParameterValue = msg.Value
+	newModel.CurrentView = constants.ViewParameterDetails
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleParameterDetailsSelection handles the selection of a row in the parameter details; only
+// the action rows do anything
+func HandleParameterDetailsSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 || m.SelectedParameter == nil {
+		return WrapModel(m), nil
+	}
+
+	switch selected[0] {
+	case constants.ActionRevealValue:
+		return HandleParameterValueLoad(m, *m.SelectedParameter, true)
+	case constants.ActionParameterHistory:
+		return HandleParameterHistoryLoad(m)
+	case constants.ActionPutVersion:
+		return HandleParameterEdit(m)
+	default:
+		return WrapModel(m), nil
+	}
+}
+
+// HandleParameterHistoryLoad reads the versions of the selected parameter. The values of a
+// SecureString are only decrypted once its value was revealed.
+func HandleParameterHistoryLoad(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedParameter == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoParameter)}
+		}
+	}
+
+	name := m.SelectedParameter.Name
+	decrypt := m.ParameterValue != nil && m.ParameterValue.Decrypted
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingHistory
+
+	return WrapModel(newModel), func() tea.Msg {
+		parameterOperation, err := getParameterStoreOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		versions, err := parameterOperation.GetParameterHistory(context.Background(), name, decrypt)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.ParameterHistoryMsg{Versions: versions}
+	}
+}
+
+// HandleParameterHistory shows the versions of the selected parameter
+func HandleParameterHistory(m *model.Model, msg model.ParameterHistoryMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.ParameterHistory = msg.Versions
+	newModel.CurrentView = constants.ViewParameterHistory
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleParameterEdit opens the editor of a new version of the selected parameter, starting with
+// its current value. A SecureString has to be revealed first.
+func HandleParameterEdit(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedParameter == nil || m.ParameterValue == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoParameter)}
+		}
+	}
+	if !view.ParameterValueVisible(m.SelectedParameter, m.ParameterValue) {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorRevealFirst)}
+		}
+	}
+
+	ta := textarea.New()
+	ta.Placeholder = constants.MsgEnterParameterValue
+	ta.ShowLineNumbers = true
+	ta.CharLimit = 0
+	ta.SetValue(m.ParameterValue.Value)
+	ta.Focus()
+
+	newModel := m.Clone()
+	newModel.TextArea = ta
+	newModel.IsParameterInputMode = false
+	newModel.CurrentView = constants.ViewParameterEdit
+	return WrapModel(newModel), nil
+}
+
+// HandleParameterEditKey handles the keys of the value editor: in input mode they edit the value,
+// otherwise they review the changes or leave the editor
+func HandleParameterEditKey(m *model.Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == constants.KeyCtrlC {
+		return WrapModel(m), tea.Quit
+	}
+
+	if m.IsParameterInputMode {
+		newModel := m.Clone()
+		if key == constants.KeyEsc {
+			newModel.IsParameterInputMode = false
+			return WrapModel(newModel), nil
+		}
+		var cmd tea.Cmd
+		newModel.TextArea, cmd = newModel.TextArea.Update(msg)
+		return WrapModel(newModel), cmd
+	}
+
+	switch key {
+	case constants.KeyQ:
+		return WrapModel(m), tea.Quit
+	case constants.KeyEsc, constants.KeyAltBack:
+		return WrapModel(NavigateBack(m)), nil
+	case "i":
+		newModel := m.Clone()
+		newModel.IsParameterInputMode = true
+		return WrapModel(newModel), nil
+	case constants.KeyEnter:
+		return HandleParameterPut(m)
+	default:
+		return WrapModel(m), nil
+	}
+}
+
+// HandleParameterPut asks for confirmation before putting the edited value as a new version of
+// the selected parameter, showing what it changes
+func HandleParameterPut(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedParameter == nil || m.ParameterValue == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoParameter)}
+		}
+	}
+
+	value := m.TextArea.Value()
+	if value == "" {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorEmptyValue)}
+		}
+	}
+	if value == m.ParameterValue.Value {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorSameValue)}
+		}
+	}
+
+	parameterOperation, err := getParameterStoreOperation(m)
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	parameter := *m.SelectedParameter
+	details := []string{
+		fmt.Sprintf("Parameter: %s", parameter.Name),
+		fmt.Sprintf("Type: %s", parameter.Type),
+		fmt.Sprintf("Version: %d → %d", parameter.Version, parameter.Version+1),
+		"",
+	}
+	details = append(details, view.ParameterValueDiff(parameter.Type, m.ParameterValue.Value, value)...)
+
+	newModel := m.Clone()
+	newModel.IsParameterInputMode = false
+	newModel.PendingAction = &model.PendingAction{
+		Description: fmt.Sprintf("Put a new version of %s", parameter.Name),
+		Details:     details,
+		LoadingMsg:  constants.MsgPuttingParameter,
+		BackView:    constants.ViewParameterEdit,
+		Run: func(ctx context.Context) (string, error) {
+			version, err := parameterOperation.PutParameterVersion(ctx, parameter, value)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf(constants.MsgParameterPutSuccess, version, parameter.Name), nil
+		},
+	}
+	newModel.CurrentView = constants.ViewExecutingAction
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// parentParameterPath returns the path above a path of Parameter Store
+func parentParameterPath(parameterPath string) string {
+	parent := path.Dir(parameterPath)
+	if parent == "." {
+		return cloud.ParameterRootPath
+	}
+	return parent
+}
+
+// getParameterStoreOperation gets the Parameter Store operation from the selected provider
+func getParameterStoreOperation(m *model.Model) (cloud.ParameterStoreOperation, error) {
+	provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetParameterStoreOperation()
+}
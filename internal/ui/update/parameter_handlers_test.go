@@ -0,0 +1,201 @@
+package update
+
+import (
+	"context"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// parameterStoreTestOperation lists parameters in a hierarchy, with a password that was changed
+// since it was listed, and records the versions put
+type parameterStoreTestOperation struct {
+	cloud.ParameterStoreOperation
+	decrypted []string
+	put       []cloud.Parameter
+	values    []string
+}
+
+func (o *parameterStoreTestOperation) ListParameters(ctx context.Context) ([]cloud.Parameter, error) {
+	return []cloud.Parameter{
+		{Name: "/app/prod/db-url", Type: cloud.ParameterTypeString, Version: 1},
+		{Name: "/app/prod/password", Type: cloud.ParameterTypeSecureString, Version: 2, KeyID: "alias/aws/ssm"},
+		{Name: "/app/staging/db-url", Type: cloud.ParameterTypeString, Version: 4},
+		{Name: "/shared", Type: cloud.ParameterTypeStringList, Version: 1},
+		{Name: "legacy", Type: cloud.ParameterTypeString, Version: 1},
+	}, nil
+}
+
+func (o *parameterStoreTestOperation) GetParameterValue(ctx context.Context, name string, decrypt bool) (*cloud.ParameterValue, error) {
+	if name != "/app/prod/password" {
+		return &cloud.ParameterValue{Name: name, Type: cloud.ParameterTypeString, Version: 1, Value: "postgres://db"}, nil
+	}
+	value := &cloud.ParameterValue{Name: name, Type: cloud.ParameterTypeSecureString, Version: 3, Value: "AQICAHh..."}
+	if decrypt {
+		o.decrypted = append(o.decrypted, name)
+		value.Value, value.Decrypted = "hunter2", true
+	}
+	return value, nil
+}
+
+func (o *parameterStoreTestOperation) GetParameterHistory(ctx context.Context, name string, decrypt bool) ([]cloud.ParameterVersion, error) {
+	value := "AQICAHh..."
+	if decrypt {
+		value = "hunter2"
+	}
+	return []cloud.ParameterVersion{{Version: 3, Value: value, Labels: []string{"current"}}, {Version: 2, Value: value}}, nil
+}
+
+func (o *parameterStoreTestOperation) PutParameterVersion(ctx context.Context, parameter cloud.Parameter, value string) (int64, error) {
+	o.put = append(o.put, parameter)
+	o.values = append(o.values, value)
+	return parameter.Version + 1, nil
+}
+
+// newParameterTestModel returns a model showing the root of the parameter browser
+func newParameterTestModel(t *testing.T, operation *parameterStoreTestOperation) *model.Model {
+	t.Helper()
+	m := newTestModel(&testProvider{parameterStore: operation})
+	result, cmd := HandleParametersLoad(m)
+	return HandleParameters(result.(ModelWrapper).Model, cmd().(model.ParametersMsg))
+}
+
+// selectParameterRow moves the cursor to the row starting with a value and selects it
+func selectParameterRow(t *testing.T, m *model.Model, value string) (*model.Model, tea.Cmd) {
+	t.Helper()
+	for i, row := range m.Table.Rows() {
+		if row[0] == value {
+			m.Table.SetCursor(i)
+			result, cmd := HandleTableSelect(m)
+			return result.(ModelWrapper).Model, cmd
+		}
+	}
+	t.Fatalf("Expected a row %q, got %v", value, m.Table.Rows())
+	return m, nil
+}
+
+// rowNames returns the first column of the rows of the table
+func rowNames(m *model.Model) []string {
+	var names []string
+	for _, row := range m.Table.Rows() {
+		names = append(names, row[0])
+	}
+	return names
+}
+
+func TestParameterBrowser(t *testing.T) {
+	m := newParameterTestModel(t, &parameterStoreTestOperation{})
+
+	steps := []struct {
+		selected string
+		path     string
+		rows     []string
+	}{
+		{selected: "app/", path: "/app", rows: []string{"..", "prod/", "staging/"}},
+		{selected: "prod/", path: "/app/prod", rows: []string{"..", "db-url", "password"}},
+		{selected: "..", path: "/app", rows: []string{"..", "prod/", "staging/"}},
+		{selected: "..", path: "/", rows: []string{"app/", "shared", "legacy"}},
+	}
+	if got := rowNames(m); len(got) != 3 || m.Table.Rows()[0][4] != "3 parameters" {
+		t.Fatalf("Expected the app path with 3 parameters and the parameters outside it, got %v", m.Table.Rows())
+	}
+	for _, step := range steps {
+		m, _ = selectParameterRow(t, m, step.selected)
+		if got := rowNames(m); m.ParameterPath != step.path || len(got) != len(step.rows) {
+			t.Fatalf("Expected %v at %s after selecting %s, got %v at %s", step.rows, step.path, step.selected, got, m.ParameterPath)
+		}
+		for i, name := range step.rows {
+			if m.Table.Rows()[i][0] != name {
+				t.Errorf("Expected row %d at %s to be %q, got %q", i, step.path, name, m.Table.Rows()[i][0])
+			}
+		}
+	}
+
+	// Going back leaves a path before leaving the browser
+	m.ParameterPath = "/app/prod"
+	if back := NavigateBack(m); back.CurrentView != constants.ViewParameters || back.ParameterPath != "/app" {
+		t.Errorf("Expected to go up to /app, got view %v at %s", back.CurrentView, back.ParameterPath)
+	}
+	m.ParameterPath = "/"
+	if back := NavigateBack(m); back.CurrentView != constants.ViewSelectOperation || back.Parameters != nil {
+		t.Errorf("Expected to leave the browser from the root, got view %v", back.CurrentView)
+	}
+}
+
+func TestPutParameterVersion(t *testing.T) {
+	operation := &parameterStoreTestOperation{}
+	m := newParameterTestModel(t, operation)
+	m, _ = selectParameterRow(t, m, "app/")
+	m, _ = selectParameterRow(t, m, "prod/")
+
+	result, cmd := selectParameterRow(t, m, "password")
+	m = HandleParameterValue(result, cmd().(model.ParameterValueMsg))
+	if m.CurrentView != constants.ViewParameterDetails || m.Table.Rows()[2][1] != "(encrypted)" {
+		t.Fatalf("Expected the details with the value hidden, got view %v with %v", m.CurrentView, m.Table.Rows())
+	}
+	// The parameter is shown at the version its value was read from
+	if m.SelectedParameter.Version != 3 || m.Parameters[1].Version != 3 {
+		t.Errorf("Expected version 3 of the password, got %d", m.SelectedParameter.Version)
+	}
+
+	// A SecureString is revealed before a new version of it can be put
+	if _, cmd := selectParameterRow(t, m, constants.ActionPutVersion); cmd == nil {
+		t.Fatalf("Expected an error when editing an encrypted value")
+	} else if msg, ok := cmd().(model.ErrMsg); !ok || msg.Err.Error() != constants.MsgErrorRevealFirst {
+		t.Fatalf("Expected the value to have to be revealed first, got %v", msg)
+	}
+	result, cmd = selectParameterRow(t, m, constants.ActionRevealValue)
+	m = HandleParameterValue(result, cmd().(model.ParameterValueMsg))
+	if len(operation.decrypted) != 1 || m.Table.Rows()[2][1] != "hunter2" {
+		t.Fatalf("Expected the decrypted value, got %v", m.Table.Rows())
+	}
+	for _, name := range rowNames(m) {
+		if name == constants.ActionRevealValue {
+			t.Errorf("Expected no reveal action once the value is decrypted")
+		}
+	}
+
+	// The history is decrypted once the value is
+	result, cmd = selectParameterRow(t, m, constants.ActionParameterHistory)
+	history := HandleParameterHistory(result, cmd().(model.ParameterHistoryMsg))
+	if rows := history.Table.Rows(); len(rows) != 2 || rows[0][1] != "hunter2" || rows[0][4] != "current" {
+		t.Errorf("Expected the decrypted versions, newest first, got %v", rows)
+	}
+
+	m, _ = selectParameterRow(t, m, constants.ActionPutVersion)
+	if m.CurrentView != constants.ViewParameterEdit || m.TextArea.Value() != "hunter2" {
+		t.Fatalf("Expected the editor with the current value, got view %v with %q", m.CurrentView, m.TextArea.Value())
+	}
+
+	// An unchanged value isn't put
+	_, cmd = HandleParameterEditKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(model.ErrMsg); !ok || msg.Err.Error() != constants.MsgErrorSameValue {
+		t.Errorf("Expected an error for an unchanged value, got %v", msg)
+	}
+
+	m.TextArea.SetValue("correct horse")
+	edited, _ := HandleParameterEditKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = edited.(ModelWrapper).Model
+	if m.CurrentView != constants.ViewExecutingAction || m.PendingAction == nil {
+		t.Fatalf("Expected to confirm the new version, got view %v", m.CurrentView)
+	}
+	if want := "Version: 3 → 4"; m.PendingAction.Details[2] != want {
+		t.Errorf("Expected %q, got %q", want, m.PendingAction.Details[2])
+	}
+	if len(operation.put) != 0 {
+		t.Fatalf("Expected nothing to be put before confirmation")
+	}
+
+	m.Table.SetCursor(0)
+	confirmed, cmd := HandleExecutionSelection(m)
+	m = HandleActionResult(confirmed.(ModelWrapper).Model, cmd().(model.ActionResultMsg))
+	if len(operation.put) != 1 || operation.put[0].Version != 3 || operation.values[0] != "correct horse" {
+		t.Errorf("Expected the new value to be put over version 3, got %v", operation.put)
+	}
+	if m.Err != nil || m.Parameters != nil || m.ParameterValue != nil {
+		t.Errorf("Expected the parameters to be cleared after putting, got error %v", m.Err)
+	}
+}
@@ -51,8 +51,9 @@ func HandlePipelineSelection(m *model.Model) (tea.Model, tea.Cmd) {
 				newModel.Search.Query = ""
 				newModel.Search.FilteredItems = make([]interface{}, 0)
 
+				cmd := LoadLinkedAlarms(newModel)
 				view.UpdateTableForView(newModel)
-				return WrapModel(newModel), nil
+				return WrapModel(newModel), cmd
 			}
 		}
 	}
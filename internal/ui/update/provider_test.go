@@ -0,0 +1,134 @@
+package update
+
+import (
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// testProvider is the provider of the handler tests. It returns the operations it's given, and
+// panics on any other method of cloud.Provider.
+type testProvider struct {
+	cloud.Provider
+	lambdaConfig       cloud.LambdaConfigurationOperation
+	lambdaDeploy       cloud.LambdaDeployOperation
+	lambdaMetrics      cloud.LambdaMetricsOperation
+	lambdaEventSources cloud.LambdaEventSourceOperation
+	lambdaHygiene      cloud.LambdaHygieneOperation
+	lambdaExecute      cloud.LambdaExecuteOperation
+	lambdaPackage      cloud.LambdaPackageOperation
+	lambdaSecurity     cloud.LambdaSecurityOperation
+	functionStatus     cloud.FunctionStatusOperation
+	lambdaAsync        cloud.LambdaAsyncOperation
+	s3Browser          cloud.S3BrowserOperation
+	s3Transfer         cloud.S3TransferOperation
+	ec2Instances       cloud.EC2InstanceOperation
+	logsInsights       cloud.LogsInsightsOperation
+	alarms             cloud.AlarmOperation
+	ecsServices        cloud.ECSServiceOperation
+	codeBuild          cloud.CodeBuildOperation
+	cloudFormation     cloud.CloudFormationOperation
+	parameterStore     cloud.ParameterStoreOperation
+	secretsManager     cloud.SecretsManagerOperation
+	queues             cloud.QueueOperation
+	topics             cloud.TopicOperation
+}
+
+func (p *testProvider) Name() string {
+	return "AWS"
+}
+
+func (p *testProvider) GetLambdaConfigurationOperation() (cloud.LambdaConfigurationOperation, error) {
+	return p.lambdaConfig, nil
+}
+
+func (p *testProvider) GetLambdaDeployOperation() (cloud.LambdaDeployOperation, error) {
+	return p.lambdaDeploy, nil
+}
+
+func (p *testProvider) GetLambdaMetricsOperation() (cloud.LambdaMetricsOperation, error) {
+	return p.lambdaMetrics, nil
+}
+
+func (p *testProvider) GetLambdaEventSourceOperation() (cloud.LambdaEventSourceOperation, error) {
+	return p.lambdaEventSources, nil
+}
+
+func (p *testProvider) GetLambdaHygieneOperation() (cloud.LambdaHygieneOperation, error) {
+	return p.lambdaHygiene, nil
+}
+
+func (p *testProvider) GetLambdaExecuteOperation() (cloud.LambdaExecuteOperation, error) {
+	return p.lambdaExecute, nil
+}
+
+func (p *testProvider) GetLambdaPackageOperation() (cloud.LambdaPackageOperation, error) {
+	return p.lambdaPackage, nil
+}
+
+func (p *testProvider) GetLambdaSecurityOperation() (cloud.LambdaSecurityOperation, error) {
+	return p.lambdaSecurity, nil
+}
+
+func (p *testProvider) GetFunctionStatusOperation() (cloud.FunctionStatusOperation, error) {
+	return p.functionStatus, nil
+}
+
+func (p *testProvider) GetLambdaAsyncOperation() (cloud.LambdaAsyncOperation, error) {
+	return p.lambdaAsync, nil
+}
+
+func (p *testProvider) GetS3BrowserOperation() (cloud.S3BrowserOperation, error) {
+	return p.s3Browser, nil
+}
+
+func (p *testProvider) GetS3TransferOperation() (cloud.S3TransferOperation, error) {
+	return p.s3Transfer, nil
+}
+
+func (p *testProvider) GetEC2InstanceOperation() (cloud.EC2InstanceOperation, error) {
+	return p.ec2Instances, nil
+}
+
+func (p *testProvider) GetLogsInsightsOperation() (cloud.LogsInsightsOperation, error) {
+	return p.logsInsights, nil
+}
+
+func (p *testProvider) GetAlarmOperation() (cloud.AlarmOperation, error) {
+	return p.alarms, nil
+}
+
+func (p *testProvider) GetECSServiceOperation() (cloud.ECSServiceOperation, error) {
+	return p.ecsServices, nil
+}
+
+func (p *testProvider) GetCodeBuildOperation() (cloud.CodeBuildOperation, error) {
+	return p.codeBuild, nil
+}
+
+func (p *testProvider) GetCloudFormationOperation() (cloud.CloudFormationOperation, error) {
+	return p.cloudFormation, nil
+}
+
+func (p *testProvider) GetParameterStoreOperation() (cloud.ParameterStoreOperation, error) {
+	return p.parameterStore, nil
+}
+
+func (p *testProvider) GetSecretsManagerOperation() (cloud.SecretsManagerOperation, error) {
+	return p.secretsManager, nil
+}
+
+func (p *testProvider) GetQueueOperation() (cloud.QueueOperation, error) {
+	return p.queues, nil
+}
+
+func (p *testProvider) GetTopicOperation() (cloud.TopicOperation, error) {
+	return p.topics, nil
+}
+
+// newTestModel creates a model with the given provider selected
+func newTestModel(provider *testProvider) *model.Model {
+	m := model.New()
+	m.Registry = InitializeTestRegistry(provider)
+	m.ProviderState.ProviderName = "AWS"
+	return m
+}
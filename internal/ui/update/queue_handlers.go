@@ -0,0 +1,363 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleQueuesLoad lists the queues of the account with their message counts
+func HandleQueuesLoad(m *model.Model) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingQueues
+
+	return WrapModel(newModel), func() tea.Msg {
+		queueOperation, err := getQueueOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		queues, err := queueOperation.ListQueues(context.Background())
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.QueuesMsg{Queues: queues}
+	}
+}
+
+// HandleQueues shows the queues of the account
+func HandleQueues(m *model.Model, msg model.QueuesMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.Queues = msg.Queues
+	newModel.SelectedQueue = nil
+	newModel.QueueMessages = nil
+	newModel.SelectedQueueMessage = nil
+	newModel.CurrentView = constants.ViewQueues
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleQueueSelection shows the details and actions of the selected queue
+func HandleQueueSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 {
+		return WrapModel(m), nil
+	}
+
+	for _, queue := range m.Queues {
+		if queue.Name == selected[0] {
+			newModel := m.Clone()
+			newModel.SelectedQueue = &queue
+			newModel.CurrentView = constants.ViewQueueDetails
+			view.UpdateTableForView(newModel)
+			return WrapModel(newModel), nil
+		}
+	}
+	return WrapModel(m), nil
+}
+
+// HandleQueueDetailsSelection handles the selection of a row in the queue details; only the
+// action rows do anything
+func HandleQueueDetailsSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 {
+		return WrapModel(m), nil
+	}
+
+	switch selected[0] {
+	case constants.ActionPeekMessages:
+		return HandleQueueMessagesLoad(m)
+	case constants.ActionSendMessage:
+		return HandleQueueSend(m)
+	case constants.ActionPurgeQueue:
+		// The name of the queue has to be typed to purge it
+		newModel := m.Clone()
+		newModel.ManualInput = true
+		newModel.TextInput.SetValue("")
+		newModel.TextInput.Placeholder = constants.MsgEnterPurgeQueueName
+		newModel.TextInput.Focus()
+		return WrapModel(newModel), nil
+	case constants.ActionRedriveMessages:
+		return HandleQueueRedrive(m)
+	default:
+		return WrapModel(m), nil
+	}
+}
+
+// HandleQueueMessagesLoad peeks at the messages of the selected queue. The messages are visible
+// again as soon as they've been read.
+func HandleQueueMessagesLoad(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedQueue == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoQueue)}
+		}
+	}
+
+	queueURL := m.SelectedQueue.URL
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgPeekingMessages
+
+	return WrapModel(newModel), func() tea.Msg {
+		queueOperation, err := getQueueOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		messages, err := queueOperation.PeekMessages(context.Background(), queueURL, constants.MaxPeekedMessages)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.QueueMessagesMsg{Messages: messages}
+	}
+}
+
+// HandleQueueMessages shows the messages peeked from the selected queue
+func HandleQueueMessages(m *model.Model, msg model.QueueMessagesMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.QueueMessages = msg.Messages
+	newModel.SelectedQueueMessage = nil
+	newModel.CurrentView = constants.ViewQueueMessages
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleQueueMessageSelection shows the attributes and body of the selected message
+func HandleQueueMessageSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 {
+		return WrapModel(m), nil
+	}
+
+	for _, message := range m.QueueMessages {
+		if message.ID == selected[0] {
+			newModel := m.Clone()
+			newModel.SelectedQueueMessage = &message
+			newModel.Viewport = viewport.New(newModel.Width-constants.ViewportMarginX*2, constants.TableHeight)
+			newModel.Viewport.YPosition = constants.HeaderHeight // Position below the title
+			newModel.Viewport.SetContent(view.QueueMessageContent(&message))
+			newModel.CurrentView = constants.ViewQueueMessage
+			return WrapModel(newModel), nil
+		}
+	}
+	return WrapModel(m), nil
+}
+
+// HandleQueueSend opens the editor of a test message for the selected queue
+func HandleQueueSend(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedQueue == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoQueue)}
+		}
+	}
+
+	ta := textarea.New()
+	ta.Placeholder = constants.MsgEnterMessageBody
+	ta.ShowLineNumbers = true
+	ta.CharLimit = 0
+	ta.Focus()
+
+	newModel := m.Clone()
+	newModel.TextArea = ta
+	newModel.IsQueueInputMode = true
+	newModel.CurrentView = constants.ViewQueueSend
+	return WrapModel(newModel), nil
+}
+
+// HandleQueueSendKey handles the keys of the message editor: in input mode they edit the body,
+// otherwise they review the message or leave the editor
+func HandleQueueSendKey(m *model.Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == constants.KeyCtrlC {
+		return WrapModel(m), tea.Quit
+	}
+
+	if m.IsQueueInputMode {
+		newModel := m.Clone()
+		if key == constants.KeyEsc {
+			newModel.IsQueueInputMode = false
+			return WrapModel(newModel), nil
+		}
+		var cmd tea.Cmd
+		newModel.TextArea, cmd = newModel.TextArea.Update(msg)
+		return WrapModel(newModel), cmd
+	}
+
+	switch key {
+	case constants.KeyQ:
+		return WrapModel(m), tea.Quit
+	case constants.KeyEsc, constants.KeyAltBack:
+		return WrapModel(NavigateBack(m)), nil
+	case "i":
+		newModel := m.Clone()
+		newModel.IsQueueInputMode = true
+		return WrapModel(newModel), nil
+	case constants.KeyEnter:
+		return HandleQueueSendMessage(m)
+	default:
+		return WrapModel(m), nil
+	}
+}
+
+// HandleQueueSendMessage asks for confirmation before sending the edited message to the selected
+// queue
+func HandleQueueSendMessage(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedQueue == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoQueue)}
+		}
+	}
+
+	body := m.TextArea.Value()
+	if strings.TrimSpace(body) == "" {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorEmptyMessage)}
+		}
+	}
+
+	queueOperation, err := getQueueOperation(m)
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	queue := *m.SelectedQueue
+	newModel := m.Clone()
+	newModel.IsQueueInputMode = false
+	newModel.PendingAction = &model.PendingAction{
+		Description: fmt.Sprintf("Send a test message to %s", queue.Name),
+		Details:     view.QueueSendDetails(&queue, body),
+		LoadingMsg:  constants.MsgSendingMessage,
+		BackView:    constants.ViewQueueSend,
+		Run: func(ctx context.Context) (string, error) {
+			messageID, err := queueOperation.SendMessage(ctx, queue, body)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf(constants.MsgMessageSentSuccess, messageID, queue.Name), nil
+		},
+	}
+	newModel.CurrentView = constants.ViewExecutingAction
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleQueuePurgeInput purges the selected queue once its name has been typed. The typed name is
+// the confirmation, so the purge starts right away.
+func HandleQueuePurgeInput(m *model.Model, value string) (tea.Model, tea.Cmd) {
+	if m.SelectedQueue == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoQueue)}
+		}
+	}
+
+	queue := *m.SelectedQueue
+	if strings.TrimSpace(value) != queue.Name {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorPurgeName, queue.Name)}
+		}
+	}
+
+	queueOperation, err := getQueueOperation(m)
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.ManualInput = false
+	newModel.ResetTextInput()
+	newModel.PendingAction = &model.PendingAction{
+		Description: fmt.Sprintf("Purge %s", queue.Name),
+		Details: []string{
+			fmt.Sprintf("Queue: %s", queue.Name),
+			fmt.Sprintf("Messages: %d", queue.Visible+queue.InFlight+queue.Delayed),
+		},
+		LoadingMsg: constants.MsgPurgingQueue,
+		BackView:   constants.ViewQueueDetails,
+		Run: func(ctx context.Context) (string, error) {
+			if err := queueOperation.PurgeQueue(ctx, queue.URL); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf(constants.MsgPurgeSuccess, queue.Name), nil
+		},
+	}
+	newModel.CurrentView = constants.ViewExecutingAction
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgPurgingQueue
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), ExecutePendingAction(newModel)
+}
+
+// HandleQueueRedrive asks for confirmation before moving the messages of the selected dead-letter
+// queue back to their source queues, showing the progress of the move
+func HandleQueueRedrive(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedQueue == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoQueue)}
+		}
+	}
+	if !m.SelectedQueue.IsDeadLetterQueue() {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNotDLQ, m.SelectedQueue.Name)}
+		}
+	}
+
+	queueOperation, err := getQueueOperation(m)
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	queue := *m.SelectedQueue
+	details := []string{
+		fmt.Sprintf("Dead-Letter Queue: %s", queue.Name),
+		fmt.Sprintf("Messages: %d", queue.Visible),
+	}
+	for _, source := range queue.SourceQueues {
+		details = append(details, fmt.Sprintf("Source Queue: %s", source))
+	}
+
+	newModel := m.Clone()
+	newModel.PendingAction = &model.PendingAction{
+		Description: fmt.Sprintf("Redrive the messages of %s", queue.Name),
+		Details:     details,
+		LoadingMsg:  constants.MsgRedrivingMessages,
+		BackView:    constants.ViewQueueDetails,
+		RunWithProgress: func(ctx context.Context, progress func(done, total int64)) (string, error) {
+			moved, err := queueOperation.RedriveMessages(ctx, queue.ARN, progress)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf(constants.MsgQueueRedriveSuccess, moved, queue.Name), nil
+		},
+	}
+	newModel.CurrentView = constants.ViewExecutingAction
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// getQueueOperation gets the SQS operation from the selected provider
+func getQueueOperation(m *model.Model) (cloud.QueueOperation, error) {
+	provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetQueueOperation()
+}
@@ -0,0 +1,171 @@
+package update
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// queueTestOperation lists a queue with its dead-letter queue, and records the queues purged and
+// redriven
+type queueTestOperation struct {
+	cloud.QueueOperation
+	purged   []string
+	redriven []string
+}
+
+func (o *queueTestOperation) ListQueues(ctx context.Context) ([]cloud.Queue, error) {
+	return []cloud.Queue{
+		{
+			Name:             "orders",
+			URL:              "https://sqs.us-east-1.amazonaws.com/123456789012/orders",
+			ARN:              "arn:aws:sqs:us-east-1:123456789012:orders",
+			Visible:          12,
+			InFlight:         3,
+			OldestMessageAge: 90 * time.Second,
+			DeadLetterQueue:  "arn:aws:sqs:us-east-1:123456789012:orders-dlq",
+			MaxReceiveCount:  5,
+		},
+		{
+			Name:         "orders-dlq",
+			URL:          "https://sqs.us-east-1.amazonaws.com/123456789012/orders-dlq",
+			ARN:          "arn:aws:sqs:us-east-1:123456789012:orders-dlq",
+			Visible:      4,
+			SourceQueues: []string{"arn:aws:sqs:us-east-1:123456789012:orders"},
+		},
+	}, nil
+}
+
+func (o *queueTestOperation) PeekMessages(ctx context.Context, queueURL string, limit int) ([]cloud.QueueMessage, error) {
+	return []cloud.QueueMessage{
+		{ID: "m-1", Body: `{"order": 42}`, ReceiveCount: 1},
+		{ID: "m-2", Body: "plain text", ReceiveCount: 2},
+	}, nil
+}
+
+func (o *queueTestOperation) PurgeQueue(ctx context.Context, queueURL string) error {
+	o.purged = append(o.purged, queueURL)
+	return nil
+}
+
+func (o *queueTestOperation) RedriveMessages(ctx context.Context, queueARN string, progress func(moved, total int64)) (int64, error) {
+	o.redriven = append(o.redriven, queueARN)
+	progress(2, 4)
+	progress(4, 4)
+	return 4, nil
+}
+
+// newQueueTestModel returns a model showing the details of a queue
+func newQueueTestModel(t *testing.T, operation *queueTestOperation, name string) *model.Model {
+	t.Helper()
+	m := newTestModel(&testProvider{queues: operation})
+	result, cmd := HandleQueuesLoad(m)
+	m = HandleQueues(result.(ModelWrapper).Model, cmd().(model.QueuesMsg))
+	if rows := m.Table.Rows(); len(rows) != 2 || rows[0][5] != "→ orders-dlq" || rows[1][5] != "DLQ of orders" {
+		t.Fatalf("Expected the queues with their dead-letter queues, got %v", rows)
+	}
+	for i, row := range m.Table.Rows() {
+		if row[0] == name {
+			m.Table.SetCursor(i)
+			result, _ := HandleTableSelect(m)
+			return result.(ModelWrapper).Model
+		}
+	}
+	t.Fatalf("Expected a queue %q, got %v", name, m.Table.Rows())
+	return m
+}
+
+// selectQueueAction selects an action of the queue details
+func selectQueueAction(t *testing.T, m *model.Model, action string) (*model.Model, tea.Cmd) {
+	t.Helper()
+	for i, row := range m.Table.Rows() {
+		if row[0] == action {
+			m.Table.SetCursor(i)
+			result, cmd := HandleTableSelect(m)
+			return result.(ModelWrapper).Model, cmd
+		}
+	}
+	t.Fatalf("Expected an action %q, got %v", action, m.Table.Rows())
+	return m, nil
+}
+
+func TestQueuePeekAndPurge(t *testing.T) {
+	operation := &queueTestOperation{}
+	m := newQueueTestModel(t, operation, "orders")
+	for _, row := range m.Table.Rows() {
+		if row[0] == constants.ActionRedriveMessages {
+			t.Errorf("Expected no redrive action for a queue that isn't a dead-letter queue")
+		}
+	}
+
+	peeked, cmd := selectQueueAction(t, m, constants.ActionPeekMessages)
+	peeked = HandleQueueMessages(peeked, cmd().(model.QueueMessagesMsg))
+	if rows := peeked.Table.Rows(); peeked.CurrentView != constants.ViewQueueMessages || len(rows) != 2 || rows[0][4] != `{"order":42}` {
+		t.Fatalf("Expected the peeked messages, got %v", rows)
+	}
+	peeked.Table.SetCursor(1)
+	result, _ := HandleTableSelect(peeked)
+	if message := result.(ModelWrapper).Model; message.CurrentView != constants.ViewQueueMessage || message.SelectedQueueMessage.ID != "m-2" {
+		t.Errorf("Expected the selected message to be shown, got view %v", message.CurrentView)
+	}
+
+	// Only the exact name of the queue purges it
+	m, _ = selectQueueAction(t, m, constants.ActionPurgeQueue)
+	if !m.ManualInput {
+		t.Fatalf("Expected the name of the queue to be asked for")
+	}
+	m.TextInput.SetValue("orders-dlq")
+	result, cmd = HandleTextInputSubmission(m)
+	if _, ok := cmd().(model.ErrMsg); !ok || len(operation.purged) != 0 {
+		t.Fatalf("Expected another name to be rejected, purged %v", operation.purged)
+	}
+	m = result.(ModelWrapper).Model
+	m.TextInput.SetValue(" orders ")
+	result, cmd = HandleTextInputSubmission(m)
+	m = result.(ModelWrapper).Model
+	if m.CurrentView != constants.ViewExecutingAction || !m.IsLoading || m.ManualInput {
+		t.Fatalf("Expected the purge to start right away, got view %v", m.CurrentView)
+	}
+	m = HandleActionResult(m, cmd().(model.ActionResultMsg))
+	if len(operation.purged) != 1 || operation.purged[0] != "https://sqs.us-east-1.amazonaws.com/123456789012/orders" {
+		t.Errorf("Expected the queue to be purged, got %v", operation.purged)
+	}
+	if m.Err != nil || m.Queues != nil || m.SelectedQueue != nil {
+		t.Errorf("Expected the queues to be cleared after purging, got error %v", m.Err)
+	}
+}
+
+func TestRedriveQueue(t *testing.T) {
+	operation := &queueTestOperation{}
+	m := newQueueTestModel(t, operation, "orders-dlq")
+	m, _ = selectQueueAction(t, m, constants.ActionRedriveMessages)
+	if m.CurrentView != constants.ViewExecutingAction || m.PendingAction == nil || m.PendingAction.RunWithProgress == nil {
+		t.Fatalf("Expected to confirm the redrive, got view %v", m.CurrentView)
+	}
+	if len(operation.redriven) != 0 {
+		t.Fatalf("Expected nothing to be redriven before confirmation")
+	}
+
+	m.Table.SetCursor(0)
+	confirmed, cmd := HandleExecutionSelection(m)
+	m = confirmed.(ModelWrapper).Model
+	msg := cmd().(model.ActionProgressMsg)
+	for !msg.Finished {
+		if m = HandleActionProgress(m, msg); m.ActionProgress == nil || m.ActionProgress.Total != 4 {
+			t.Fatalf("Expected the progress of the redrive, got %+v", m.ActionProgress)
+		}
+		msg = WaitForActionProgress(msg)().(model.ActionProgressMsg)
+	}
+	m = HandleActionProgress(m, msg)
+	if len(operation.redriven) != 1 || operation.redriven[0] != "arn:aws:sqs:us-east-1:123456789012:orders-dlq" {
+		t.Errorf("Expected the dead-letter queue to be redriven, got %v", operation.redriven)
+	}
+	if m.Err != nil || m.ActionProgress != nil || m.SelectedQueue != nil {
+		t.Errorf("Expected the redrive to finish, got error %v", m.Err)
+	}
+}
@@ -0,0 +1,204 @@
+package update
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleS3Buckets loads the buckets of the account for the bucket browser
+func HandleS3Buckets(m *model.Model) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingBuckets
+
+	return WrapModel(newModel), func() tea.Msg {
+		browserOperation, err := getS3BrowserOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		buckets, err := browserOperation.ListBuckets(context.Background())
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.S3BucketsMsg{Buckets: buckets}
+	}
+}
+
+// HandleS3BucketsResult shows the buckets of the account
+func HandleS3BucketsResult(m *model.Model, msg model.S3BucketsMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.S3Buckets = msg.Buckets
+	newModel.SelectedBucket = nil
+	newModel.S3Listings = nil
+	newModel.CurrentView = constants.ViewS3Buckets
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleS3BucketSelection lists the root of the selected bucket
+func HandleS3BucketSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 {
+		return WrapModel(m), nil
+	}
+
+	for _, bucket := range m.S3Buckets {
+		if bucket.Name == selected[0] {
+			newModel := m.Clone()
+			newModel.SelectedBucket = &bucket
+			newModel.S3Listings = nil
+			return listS3Objects(newModel, "", []string{""}, true)
+		}
+	}
+	return WrapModel(m), nil
+}
+
+// HandleS3ObjectSelection opens the selected prefix like a directory, goes up from the parent
+// row, or shows the details of the selected object
+func HandleS3ObjectSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 || len(m.S3Listings) == 0 {
+		return WrapModel(m), nil
+	}
+
+	if selected[0] == view.S3ParentPrefix {
+		newModel := NavigateBack(m)
+		view.UpdateTableForView(newModel)
+		return WrapModel(newModel), nil
+	}
+
+	listing := m.S3Listings[len(m.S3Listings)-1]
+	key := listing.Page.Prefix + selected[0]
+	for _, object := range listing.Page.Objects {
+		if object.Key != key {
+			continue
+		}
+		if object.IsPrefix {
+			return listS3Objects(m, object.Key, []string{""}, true)
+		}
+		return loadS3ObjectDetails(m, object.Key)
+	}
+	return WrapModel(m), nil
+}
+
+// HandleS3PageKey lists the next or previous page of the prefix being browsed. Pages are listed
+// when they're asked for, continuing from the token S3 returned with the page before.
+func HandleS3PageKey(m *model.Model, key string) (tea.Model, tea.Cmd) {
+	if m.CurrentView != constants.ViewS3Objects || len(m.S3Listings) == 0 || m.IsLoading {
+		return WrapModel(m), nil
+	}
+
+	listing := m.S3Listings[len(m.S3Listings)-1]
+	tokens := listing.PageTokens
+	switch key {
+	case constants.KeyNextPage:
+		if listing.Page.NextToken == "" {
+			return WrapModel(m), nil
+		}
+		next := make([]string, len(tokens), len(tokens)+1)
+		copy(next, tokens)
+		return listS3Objects(m, listing.Page.Prefix, append(next, listing.Page.NextToken), false)
+	case constants.KeyPreviousPage:
+		if len(tokens) <= 1 {
+			return WrapModel(m), nil
+		}
+		return listS3Objects(m, listing.Page.Prefix, tokens[:len(tokens)-1], false)
+	}
+	return WrapModel(m), nil
+}
+
+// HandleS3ObjectsPage shows a page of a bucket listing, either below the prefix being browsed or
+// in place of the current page
+func HandleS3ObjectsPage(m *model.Model, msg model.S3ObjectsPageMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+
+	listing := model.S3Listing{Page: msg.Page, PageTokens: msg.PageTokens}
+	if msg.NewPrefix || len(newModel.S3Listings) == 0 {
+		newModel.S3Listings = append(newModel.S3Listings, listing)
+	} else {
+		newModel.S3Listings[len(newModel.S3Listings)-1] = listing
+	}
+
+	newModel.CurrentView = constants.ViewS3Objects
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleS3ObjectDetails shows the properties, metadata and tags of an object
+func HandleS3ObjectDetails(m *model.Model, msg model.S3ObjectDetailsMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.S3ObjectDetails = msg.Details
+	newModel.CurrentView = constants.ViewS3ObjectDetails
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// listS3Objects lists the page of a prefix reached with the last of the tokens
+func listS3Objects(m *model.Model, prefix string, tokens []string, newPrefix bool) (tea.Model, tea.Cmd) {
+	if m.SelectedBucket == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoBucket)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgListingObjects
+	bucket := *m.SelectedBucket
+
+	return WrapModel(newModel), func() tea.Msg {
+		browserOperation, err := getS3BrowserOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		page, err := browserOperation.ListObjects(context.Background(), bucket, prefix, tokens[len(tokens)-1], constants.S3ObjectsPageSize)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.S3ObjectsPageMsg{Page: page, PageTokens: tokens, NewPrefix: newPrefix}
+	}
+}
+
+// loadS3ObjectDetails loads the details of an object in the bucket being browsed
+func loadS3ObjectDetails(m *model.Model, key string) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingObject
+	bucket := *m.SelectedBucket
+
+	return WrapModel(newModel), func() tea.Msg {
+		browserOperation, err := getS3BrowserOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		details, err := browserOperation.GetObjectDetails(context.Background(), bucket, key)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.S3ObjectDetailsMsg{Details: details}
+	}
+}
+
+// getS3BrowserOperation gets the S3 browser operation from the selected provider
+func getS3BrowserOperation(m *model.Model) (cloud.S3BrowserOperation, error) {
+	provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetS3BrowserOperation()
+}
@@ -0,0 +1,145 @@
+package update
+
+import (
+	"context"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// s3TestOperation serves fixed pages keyed by prefix and continuation token, and records the
+// tokens it was asked for
+type s3TestOperation struct {
+	cloud.S3BrowserOperation
+	pages  map[string]*cloud.S3ObjectPage
+	tokens []string
+}
+
+func (o *s3TestOperation) ListBuckets(ctx context.Context) ([]cloud.S3Bucket, error) {
+	return []cloud.S3Bucket{{Name: "assets", Region: "eu-west-1"}, {Name: "logs", Region: "us-east-1"}}, nil
+}
+
+func (o *s3TestOperation) ListObjects(ctx context.Context, bucket cloud.S3Bucket, prefix, token string, limit int) (*cloud.S3ObjectPage, error) {
+	o.tokens = append(o.tokens, token)
+	return o.pages[prefix+"|"+token], nil
+}
+
+func (o *s3TestOperation) GetObjectDetails(ctx context.Context, bucket cloud.S3Bucket, key string) (*cloud.S3ObjectDetails, error) {
+	return &cloud.S3ObjectDetails{
+		Bucket:       bucket.Name,
+		Key:          key,
+		StorageClass: "STANDARD",
+		Metadata:     map[string]string{"owner": "web"},
+		Tags:         map[string]string{"env": "prod"},
+	}, nil
+}
+
+// applyS3Cmd runs the command of a handler and applies the message it returns
+func applyS3Cmd(result tea.Model, cmd tea.Cmd) *model.Model {
+	m := result.(ModelWrapper).Model
+	switch msg := cmd().(type) {
+	case model.S3BucketsMsg:
+		return HandleS3BucketsResult(m, msg)
+	case model.S3ObjectsPageMsg:
+		return HandleS3ObjectsPage(m, msg)
+	case model.S3ObjectDetailsMsg:
+		return HandleS3ObjectDetails(m, msg)
+	default:
+		return m
+	}
+}
+
+func TestS3Browser(t *testing.T) {
+	operation := &s3TestOperation{pages: map[string]*cloud.S3ObjectPage{
+		"|": {Objects: []cloud.S3Object{
+			{Key: "images/", IsPrefix: true},
+			{Key: "index.html", Size: 2048, StorageClass: "STANDARD"},
+		}},
+		"images/|": {Prefix: "images/", NextToken: "page-2", Objects: []cloud.S3Object{
+			{Key: "images/a.png", Size: 10, StorageClass: "STANDARD"},
+		}},
+		"images/|page-2": {Prefix: "images/", Objects: []cloud.S3Object{
+			{Key: "images/b.png", Size: 20, StorageClass: "GLACIER"},
+		}},
+	}}
+	m := newTestModel(&testProvider{s3Browser: operation})
+
+	m = applyS3Cmd(HandleS3Buckets(m))
+	if m.CurrentView != constants.ViewS3Buckets || len(m.Table.Rows()) != 2 {
+		t.Fatalf("Expected both buckets, got view %v with %v", m.CurrentView, m.Table.Rows())
+	}
+
+	m.Table.SetCursor(0)
+	m = applyS3Cmd(HandleS3BucketSelection(m))
+	if m.CurrentView != constants.ViewS3Objects || m.SelectedBucket == nil || m.SelectedBucket.Region != "eu-west-1" {
+		t.Fatalf("Expected the root of the assets bucket, got view %v with %v", m.CurrentView, m.SelectedBucket)
+	}
+	rows := m.Table.Rows()
+	if len(rows) != 2 || rows[0][0] != "images/" || rows[1][0] != "index.html" || rows[1][1] != "2.00 KB" {
+		t.Fatalf("Expected a prefix and an object at the root, got %v", rows)
+	}
+
+	// Drill into the prefix, which has a parent row to go back up
+	m.Table.SetCursor(0)
+	m = applyS3Cmd(HandleS3ObjectSelection(m))
+	rows = m.Table.Rows()
+	if len(m.S3Listings) != 2 || rows[0][0] != view.S3ParentPrefix || rows[1][0] != "a.png" {
+		t.Fatalf("Expected the images prefix, got %v", rows)
+	}
+
+	// Pages are listed with the token of the page before
+	m = applyS3Cmd(HandleS3PageKey(m, constants.KeyNextPage))
+	listing := m.S3Listings[len(m.S3Listings)-1]
+	if listing.PageNumber() != 2 || m.Table.Rows()[1][0] != "b.png" {
+		t.Fatalf("Expected the second page, got page %d with %v", listing.PageNumber(), m.Table.Rows())
+	}
+	if last := operation.tokens[len(operation.tokens)-1]; last != "page-2" {
+		t.Errorf("Expected the continuation token to be passed, got %q", last)
+	}
+	if _, cmd := HandleS3PageKey(m, constants.KeyNextPage); cmd != nil {
+		t.Errorf("Expected no page after the last one")
+	}
+	m = applyS3Cmd(HandleS3PageKey(m, constants.KeyPreviousPage))
+	if listing := m.S3Listings[len(m.S3Listings)-1]; listing.PageNumber() != 1 || m.Table.Rows()[1][0] != "a.png" {
+		t.Fatalf("Expected the first page again, got page %d with %v", listing.PageNumber(), m.Table.Rows())
+	}
+
+	// Object details show the metadata and tags
+	m.Table.SetCursor(1)
+	m = applyS3Cmd(HandleS3ObjectSelection(m))
+	if m.CurrentView != constants.ViewS3ObjectDetails || m.S3ObjectDetails.Key != "images/a.png" {
+		t.Fatalf("Expected the details of images/a.png, got view %v", m.CurrentView)
+	}
+	found := map[string]string{}
+	for _, row := range m.Table.Rows() {
+		found[row[0]] = row[1]
+	}
+	if found["Metadata: owner"] != "web" || found["Tag: env"] != "prod" {
+		t.Errorf("Expected the metadata and tags, got %v", m.Table.Rows())
+	}
+
+	// Going back returns through the prefixes without listing them again
+	listed := len(operation.tokens)
+	m = NavigateBack(m)
+	view.UpdateTableForView(m)
+	if m.CurrentView != constants.ViewS3Objects || len(m.S3Listings) != 2 {
+		t.Fatalf("Expected the images prefix, got view %v", m.CurrentView)
+	}
+	m.Table.SetCursor(0)
+	result, cmd := HandleS3ObjectSelection(m)
+	m = result.(ModelWrapper).Model
+	if cmd != nil || len(m.S3Listings) != 1 || m.Table.Rows()[0][0] != "images/" {
+		t.Fatalf("Expected the parent row to go up to the root, got %v", m.Table.Rows())
+	}
+	m = NavigateBack(m)
+	if m.CurrentView != constants.ViewS3Buckets || m.SelectedBucket != nil {
+		t.Errorf("Expected the buckets, got view %v", m.CurrentView)
+	}
+	if len(operation.tokens) != listed {
+		t.Errorf("Expected going back not to list objects again")
+	}
+}
@@ -0,0 +1,360 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleS3DownloadKey asks where to download the selected object, suggesting its name
+func HandleS3DownloadKey(m *model.Model) (tea.Model, tea.Cmd) {
+	if !isS3ObjectView(m.CurrentView) {
+		return WrapModel(m), nil
+	}
+
+	object := selectedS3Object(m)
+	if object == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoObject)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.S3InputField = constants.S3InputDownload
+	newModel.ManualInput = true
+	newModel.TextInput.Placeholder = constants.MsgEnterDownloadPath
+	newModel.TextInput.SetValue(path.Base(object.Key))
+	newModel.TextInput.Focus()
+	return WrapModel(newModel), nil
+}
+
+// HandleS3UploadKey asks for a local file or directory to upload below the prefix being browsed
+func HandleS3UploadKey(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.CurrentView != constants.ViewS3Objects || len(m.S3Listings) == 0 {
+		return WrapModel(m), nil
+	}
+
+	newModel := m.Clone()
+	newModel.S3InputField = constants.S3InputUpload
+	newModel.ManualInput = true
+	newModel.TextInput.Placeholder = constants.MsgEnterUploadPath
+	newModel.TextInput.SetValue("")
+	newModel.TextInput.Focus()
+	return WrapModel(newModel), nil
+}
+
+// HandleS3TransferInput stages the download or upload for the path entered in the browser and
+// asks for confirmation, as both write over whatever is already at the destination
+func HandleS3TransferInput(m *model.Model, value string) (tea.Model, tea.Cmd) {
+	if m.SelectedBucket == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoBucket)}
+		}
+	}
+
+	localPath := strings.TrimSpace(value)
+	if localPath == "" {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorEmptyPath)}
+		}
+	}
+
+	transferOperation, err := getS3TransferOperation(m)
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	bucket := *m.SelectedBucket
+	var action *model.PendingAction
+	switch m.S3InputField {
+	case constants.S3InputDownload:
+		object := selectedS3Object(m)
+		if object == nil {
+			return WrapModel(m), func() tea.Msg {
+				return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoObject)}
+			}
+		}
+		key := object.Key
+		target := cloud.S3DownloadPath(localPath, key)
+		action = &model.PendingAction{
+			Description: fmt.Sprintf("Download %s", path.Base(key)),
+			Details:     view.S3DownloadSummary(bucket, *object, target),
+			LoadingMsg:  constants.MsgDownloadingObject,
+			BackView:    m.CurrentView,
+			RunWithProgress: func(ctx context.Context, progress func(done, total int64)) (string, error) {
+				if err := transferOperation.DownloadObject(ctx, bucket, key, target, progress); err != nil {
+					return "", err
+				}
+				return fmt.Sprintf(constants.MsgDownloadSuccess, bucket.Name, key, target), nil
+			},
+		}
+	case constants.S3InputUpload:
+		prefix := m.S3Listings[len(m.S3Listings)-1].Page.Prefix
+		upload, err := cloud.NewS3Upload(localPath, prefix)
+		if err != nil {
+			return WrapModel(m), func() tea.Msg {
+				return model.ErrMsg{Err: err}
+			}
+		}
+		action = &model.PendingAction{
+			Description: fmt.Sprintf("Upload %d files to s3://%s/%s", len(upload.Files), bucket.Name, prefix),
+			Details:     view.S3UploadSummary(bucket, upload),
+			LoadingMsg:  constants.MsgUploadingFiles,
+			BackView:    constants.ViewS3Objects,
+			RunWithProgress: func(ctx context.Context, progress func(done, total int64)) (string, error) {
+				if err := transferOperation.UploadFiles(ctx, bucket, upload, progress); err != nil {
+					return "", err
+				}
+				return fmt.Sprintf(constants.MsgUploadSuccess, len(upload.Files), bucket.Name, prefix), nil
+			},
+		}
+	default:
+		return WrapModel(m), nil
+	}
+
+	newModel := m.Clone()
+	newModel.S3InputField = ""
+	newModel.ManualInput = false
+	newModel.ResetTextInput()
+	newModel.PendingAction = action
+	newModel.CurrentView = constants.ViewExecutingAction
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleS3PresignKey opens the presign form for the selected object. Without an object selected,
+// the form starts from the prefix being browsed so a key can be added for an upload URL.
+func HandleS3PresignKey(m *model.Model) (tea.Model, tea.Cmd) {
+	if !isS3ObjectView(m.CurrentView) || m.SelectedBucket == nil {
+		return WrapModel(m), nil
+	}
+
+	expiry, err := cloud.ParseS3PresignExpiry(constants.DefaultPresignExpiry)
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	request := cloud.S3PresignRequest{Method: cloud.S3PresignGet, Expiry: expiry}
+	if object := selectedS3Object(m); object != nil {
+		request.Key = object.Key
+	} else if len(m.S3Listings) > 0 {
+		request.Key = m.S3Listings[len(m.S3Listings)-1].Page.Prefix
+		request.Method = cloud.S3PresignPut
+	}
+
+	newModel := m.Clone()
+	newModel.S3Presign = request
+	newModel.S3PresignedURL = nil
+	newModel.S3InputField = ""
+	newModel.CurrentView = constants.ViewS3Presign
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleS3PresignSelection handles the selection of a row in the presign form
+func HandleS3PresignSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 {
+		return WrapModel(m), nil
+	}
+
+	newModel := m.Clone()
+	switch selected[0] {
+	case constants.SettingPresignMethod:
+		newModel.S3Presign.Method = cloud.S3PresignPut
+		if m.S3Presign.Method == cloud.S3PresignPut {
+			newModel.S3Presign.Method = cloud.S3PresignGet
+		}
+		newModel.S3PresignedURL = nil
+		view.UpdateTableForView(newModel)
+		return WrapModel(newModel), nil
+	case constants.SettingPresignKey:
+		newModel.TextInput.Placeholder = constants.MsgEnterPresignKey
+		newModel.TextInput.SetValue(m.S3Presign.Key)
+	case constants.SettingPresignExpiry:
+		newModel.TextInput.Placeholder = constants.MsgEnterPresignExpiry
+		newModel.TextInput.SetValue(m.S3Presign.Expiry.String())
+	case constants.SettingPresignURL:
+		return presignS3Object(m)
+	default:
+		return WrapModel(m), nil
+	}
+
+	newModel.S3InputField = selected[0]
+	newModel.ManualInput = true
+	newModel.TextInput.Focus()
+	return WrapModel(newModel), nil
+}
+
+// HandleS3PresignInput stages the key or expiry entered in the presign form
+func HandleS3PresignInput(m *model.Model, value string) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	value = strings.TrimSpace(value)
+
+	switch m.S3InputField {
+	case constants.SettingPresignKey:
+		if value == "" {
+			return WrapModel(m), func() tea.Msg {
+				return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorEmptyKey)}
+			}
+		}
+		newModel.S3Presign.Key = value
+	case constants.SettingPresignExpiry:
+		expiry, err := cloud.ParseS3PresignExpiry(value)
+		if err != nil {
+			return WrapModel(m), func() tea.Msg {
+				return model.ErrMsg{Err: err}
+			}
+		}
+		newModel.S3Presign.Expiry = expiry
+	}
+
+	newModel.S3InputField = ""
+	newModel.S3PresignedURL = nil
+	newModel.ManualInput = false
+	newModel.ResetTextInput()
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// HandleS3PresignedURL shows a generated URL below the presign form
+func HandleS3PresignedURL(m *model.Model, msg model.S3PresignedURLMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.S3PresignedURL = msg.URL
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleS3PreviewKey reads the start of the selected object to show it inline
+func HandleS3PreviewKey(m *model.Model) (tea.Model, tea.Cmd) {
+	if !isS3ObjectView(m.CurrentView) {
+		return WrapModel(m), nil
+	}
+
+	object := selectedS3Object(m)
+	if object == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoObject)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingPreview
+	bucket := *m.SelectedBucket
+	key := object.Key
+
+	return WrapModel(newModel), func() tea.Msg {
+		transferOperation, err := getS3TransferOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		preview, err := transferOperation.PreviewObject(context.Background(), bucket, key, constants.S3PreviewMaxSize)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.S3ObjectPreviewMsg{Preview: preview}
+	}
+}
+
+// HandleS3ObjectPreview shows the start of an object in the preview viewer
+func HandleS3ObjectPreview(m *model.Model, msg model.S3ObjectPreviewMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.S3Preview = msg.Preview
+	newModel.Viewport = viewport.New(newModel.Width-constants.ViewportMarginX*2, constants.TableHeight)
+	newModel.Viewport.YPosition = constants.HeaderHeight // Position below the title
+	newModel.Viewport.SetContent(view.S3PreviewContent(msg.Preview))
+	newModel.CurrentView = constants.ViewS3Preview
+	return newModel
+}
+
+// presignS3Object generates a URL for the request staged in the presign form
+func presignS3Object(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedBucket == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoBucket)}
+		}
+	}
+	if m.S3Presign.Key == "" || strings.HasSuffix(m.S3Presign.Key, "/") {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorEmptyKey)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgPresigningURL
+	bucket := *m.SelectedBucket
+	request := m.S3Presign
+
+	return WrapModel(newModel), func() tea.Msg {
+		transferOperation, err := getS3TransferOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		url, err := transferOperation.PresignObject(context.Background(), bucket, request)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.S3PresignedURLMsg{URL: url}
+	}
+}
+
+// selectedS3Object returns the object shown in the details view, or the object selected in the
+// browser; prefixes and the parent row aren't objects
+func selectedS3Object(m *model.Model) *cloud.S3Object {
+	if m.CurrentView == constants.ViewS3ObjectDetails && m.S3ObjectDetails != nil {
+		details := m.S3ObjectDetails
+		return &cloud.S3Object{
+			Key:          details.Key,
+			Size:         details.Size,
+			StorageClass: details.StorageClass,
+			LastModified: details.LastModified,
+		}
+	}
+
+	selected := m.Table.SelectedRow()
+	if m.CurrentView != constants.ViewS3Objects || len(selected) == 0 || len(m.S3Listings) == 0 {
+		return nil
+	}
+	page := m.S3Listings[len(m.S3Listings)-1].Page
+	for _, object := range page.Objects {
+		if !object.IsPrefix && object.Key == page.Prefix+selected[0] {
+			return &object
+		}
+	}
+	return nil
+}
+
+// isS3ObjectView returns whether objects can be transferred from a view: the browser, or the
+// details of an object
+func isS3ObjectView(v constants.View) bool {
+	return v == constants.ViewS3Objects || v == constants.ViewS3ObjectDetails
+}
+
+// getS3TransferOperation gets the S3 transfer operation from the selected provider
+func getS3TransferOperation(m *model.Model) (cloud.S3TransferOperation, error) {
+	provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetS3TransferOperation()
+}
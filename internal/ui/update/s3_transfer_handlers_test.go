@@ -0,0 +1,234 @@
+package update
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+)
+
+// s3TransferTestOperation records the transfers it was asked for and reports them done in halves
+type s3TransferTestOperation struct {
+	cloud.S3TransferOperation
+	downloaded string
+	uploaded   *cloud.S3Upload
+	presigned  *cloud.S3PresignRequest
+}
+
+func (o *s3TransferTestOperation) DownloadObject(ctx context.Context, bucket cloud.S3Bucket, key, localPath string, progress cloud.S3TransferProgress) error {
+	o.downloaded = localPath
+	progress(512, 1024)
+	progress(1024, 1024)
+	return nil
+}
+
+func (o *s3TransferTestOperation) UploadFiles(ctx context.Context, bucket cloud.S3Bucket, upload *cloud.S3Upload, progress cloud.S3TransferProgress) error {
+	o.uploaded = upload
+	progress(upload.Size/2, upload.Size)
+	progress(upload.Size, upload.Size)
+	return nil
+}
+
+func (o *s3TransferTestOperation) PresignObject(ctx context.Context, bucket cloud.S3Bucket, request cloud.S3PresignRequest) (*cloud.S3PresignedURL, error) {
+	o.presigned = &request
+	return &cloud.S3PresignedURL{Method: request.Method, URL: "https://assets.s3.amazonaws.com/" + request.Key + "?X-Amz-Signature=test"}, nil
+}
+
+func (o *s3TransferTestOperation) PreviewObject(ctx context.Context, bucket cloud.S3Bucket, key string, limit int64) (*cloud.S3ObjectPreview, error) {
+	return &cloud.S3ObjectPreview{Key: key, ContentType: "application/json", Size: 13, Data: []byte(`{"ok": true}` + "\n")}, nil
+}
+
+// newS3TransferTestModel returns a model browsing a prefix with an object in it
+func newS3TransferTestModel(operation *s3TransferTestOperation) *model.Model {
+	m := newTestModel(&testProvider{s3Transfer: operation})
+	m.SelectedBucket = &cloud.S3Bucket{Name: "assets", Region: "eu-west-1"}
+	m.S3Listings = []model.S3Listing{{PageTokens: []string{""}, Page: &cloud.S3ObjectPage{
+		Prefix: "images/",
+		Objects: []cloud.S3Object{
+			{Key: "images/icons/", IsPrefix: true},
+			{Key: "images/a.png", Size: 1024, StorageClass: "STANDARD"},
+		},
+	}}}
+	m.Width = 100
+	m.CurrentView = constants.ViewS3Objects
+	view.UpdateTableForView(m)
+	return m
+}
+
+// runTransfer confirms the pending action and reads its progress until it finishes, returning
+// the model once the result is shown and the progress messages seen on the way
+func runTransfer(t *testing.T, m *model.Model) (*model.Model, []model.ActionProgressMsg) {
+	t.Helper()
+	m.Table.SetCursor(0)
+	result, cmd := HandleExecutionSelection(m)
+	m = result.(ModelWrapper).Model
+
+	var progress []model.ActionProgressMsg
+	msg, ok := cmd().(model.ActionProgressMsg)
+	for ok {
+		m = HandleActionProgress(m, msg)
+		if msg.Finished {
+			return m, progress
+		}
+		progress = append(progress, msg)
+		if m.ActionProgress == nil || m.ActionProgress.Done != msg.Done {
+			t.Fatalf("Expected the progress to be shown, got %+v", m.ActionProgress)
+		}
+		msg, ok = WaitForActionProgress(msg)().(model.ActionProgressMsg)
+	}
+	t.Fatalf("Expected the transfer to report its progress")
+	return m, progress
+}
+
+func TestS3Upload(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "site")
+	if err := os.MkdirAll(filepath.Join(dir, "css"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(dir, "index.html"), []byte("<html></html>"), 0o644)
+	os.WriteFile(filepath.Join(dir, "css", "site.css"), []byte("body {}"), 0o644)
+
+	operation := &s3TransferTestOperation{}
+	m := newS3TransferTestModel(operation)
+
+	result, _ := HandleS3UploadKey(m)
+	m = result.(ModelWrapper).Model
+	if !m.ManualInput || m.S3InputField != constants.S3InputUpload {
+		t.Fatalf("Expected to be asked for a path to upload")
+	}
+
+	// Uploading asks for confirmation first, listing where the files go
+	result, _ = HandleS3TransferInput(m, dir)
+	m = result.(ModelWrapper).Model
+	if m.CurrentView != constants.ViewExecutingAction || m.PendingAction == nil {
+		t.Fatalf("Expected a pending action, got view %v", m.CurrentView)
+	}
+	details := strings.Join(m.PendingAction.Details, "\n")
+	if !strings.Contains(details, "images/site/css/site.css") || !strings.Contains(details, "images/site/index.html") {
+		t.Errorf("Expected the keys of both files below the prefix, got %q", details)
+	}
+	if operation.uploaded != nil {
+		t.Fatalf("Expected nothing to be uploaded before confirming")
+	}
+
+	m, progress := runTransfer(t, m)
+	if operation.uploaded == nil || len(operation.uploaded.Files) != 2 {
+		t.Fatalf("Expected both files to be uploaded, got %+v", operation.uploaded)
+	}
+	if len(progress) == 0 || progress[len(progress)-1].Total != operation.uploaded.Size {
+		t.Errorf("Expected progress across all files, got %+v", progress)
+	}
+	if m.IsLoading || m.ActionProgress != nil || !strings.Contains(m.Success, "Uploaded 2 files to s3://assets/images/") {
+		t.Errorf("Expected the upload to succeed, got %q", m.Success)
+	}
+}
+
+func TestS3Download(t *testing.T) {
+	operation := &s3TransferTestOperation{}
+	m := newS3TransferTestModel(operation)
+
+	// Prefixes can't be downloaded
+	m.Table.SetCursor(1)
+	if _, cmd := HandleS3DownloadKey(m); cmd == nil {
+		t.Errorf("Expected an error for a prefix")
+	}
+
+	m.Table.SetCursor(2)
+	result, _ := HandleS3DownloadKey(m)
+	m = result.(ModelWrapper).Model
+	if m.TextInput.Value() != "a.png" {
+		t.Errorf("Expected the object's name to be suggested, got %q", m.TextInput.Value())
+	}
+
+	// An existing directory gets the object under its name
+	dir := t.TempDir()
+	result, _ = HandleS3TransferInput(m, dir)
+	m = result.(ModelWrapper).Model
+	if m.PendingAction == nil || m.PendingAction.BackView != constants.ViewS3Objects {
+		t.Fatalf("Expected a download returning to the browser, got %+v", m.PendingAction)
+	}
+
+	m, _ = runTransfer(t, m)
+	if want := filepath.Join(dir, "a.png"); operation.downloaded != want {
+		t.Errorf("Expected the object to be downloaded to %s, got %s", want, operation.downloaded)
+	}
+	if !strings.Contains(m.Success, "s3://assets/images/a.png") {
+		t.Errorf("Expected the success message to name the object, got %q", m.Success)
+	}
+}
+
+func TestS3Presign(t *testing.T) {
+	operation := &s3TransferTestOperation{}
+	m := newS3TransferTestModel(operation)
+
+	m.Table.SetCursor(2)
+	result, _ := HandleS3PresignKey(m)
+	m = result.(ModelWrapper).Model
+	if m.CurrentView != constants.ViewS3Presign || m.S3Presign.Key != "images/a.png" || m.S3Presign.Method != cloud.S3PresignGet {
+		t.Fatalf("Expected a GET URL for the object, got view %v with %+v", m.CurrentView, m.S3Presign)
+	}
+
+	// The expiry is limited to what Signature Version 4 allows
+	m.Table.SetCursor(2)
+	result, _ = HandleS3PresignSelection(m)
+	m = result.(ModelWrapper).Model
+	if m.S3InputField != constants.SettingPresignExpiry {
+		t.Fatalf("Expected to edit the expiry, got %q", m.S3InputField)
+	}
+	if _, cmd := HandleS3PresignInput(m, "30d"); cmd == nil {
+		t.Errorf("Expected an error for an unparseable expiry")
+	}
+	if _, cmd := HandleS3PresignInput(m, "200h"); cmd == nil {
+		t.Errorf("Expected an error for an expiry over 7 days")
+	}
+	result, _ = HandleS3PresignInput(m, "2h")
+	m = result.(ModelWrapper).Model
+
+	// Switch to an upload URL and generate it
+	m.Table.SetCursor(0)
+	result, _ = HandleS3PresignSelection(m)
+	m = result.(ModelWrapper).Model
+	m.Table.SetCursor(3)
+	result, cmd := HandleS3PresignSelection(m)
+	m = HandleS3PresignedURL(result.(ModelWrapper).Model, cmd().(model.S3PresignedURLMsg))
+	if operation.presigned == nil || operation.presigned.Method != cloud.S3PresignPut || operation.presigned.Expiry != 2*time.Hour {
+		t.Fatalf("Expected a PUT URL valid for 2h, got %+v", operation.presigned)
+	}
+	if m.S3PresignedURL == nil || !strings.Contains(m.S3PresignedURL.URL, "images/a.png") {
+		t.Errorf("Expected the URL to be shown, got %+v", m.S3PresignedURL)
+	}
+
+	m = NavigateBack(m)
+	if m.CurrentView != constants.ViewS3Objects || m.S3PresignedURL != nil {
+		t.Errorf("Expected the browser, got view %v", m.CurrentView)
+	}
+}
+
+func TestS3Preview(t *testing.T) {
+	operation := &s3TransferTestOperation{}
+	m := newS3TransferTestModel(operation)
+	m.S3ObjectDetails = &cloud.S3ObjectDetails{Key: "images/a.png", Size: 1024}
+	m.CurrentView = constants.ViewS3ObjectDetails
+
+	result, cmd := HandleS3PreviewKey(m)
+	m = HandleS3ObjectPreview(result.(ModelWrapper).Model, cmd().(model.S3ObjectPreviewMsg))
+	if m.CurrentView != constants.ViewS3Preview || m.S3Preview == nil {
+		t.Fatalf("Expected the preview, got view %v", m.CurrentView)
+	}
+	if content := m.Viewport.View(); !strings.Contains(content, `"ok"`) {
+		t.Errorf("Expected the JSON to be shown, got %q", content)
+	}
+
+	// Going back returns to the details the preview was opened from
+	m = NavigateBack(m)
+	if m.CurrentView != constants.ViewS3ObjectDetails || m.S3Preview != nil {
+		t.Errorf("Expected the object details, got view %v", m.CurrentView)
+	}
+}
@@ -80,6 +80,7 @@ func filterItemsByQuery(items []interface{}, query string) []interface{} {
 			if v.Runtime != "" {
 				searchText += " " + strings.ToLower(v.Runtime)
 			}
+			searchText += " " + strings.ToLower(functionSearchText(v))
 		case model.PipelineStatus:
 			searchText = strings.ToLower(v.Name)
 			// Include stages for more comprehensive search
@@ -183,3 +184,27 @@ func updateApprovalsTable(m *model.Model, items []interface{}, startIdx, endIdx
 func IsPrintableChar(r rune) bool {
 	return r >= 32 && r < 127
 }
+
+// functionSearchText returns the state, dependencies and tags of a function, so that
+// functions can be found by e.g. Failed, a VPC or subnet ID, a layer or a tag key or value
+func functionSearchText(function model.FunctionStatus) string {
+	fields := []string{
+		function.State,
+		function.LastUpdateStatus,
+		function.DeadLetterTarget,
+		function.TracingMode,
+		function.SnapStart,
+		function.KMSKeyArn,
+		function.RuntimeVersion,
+	}
+	fields = append(fields, function.Layers...)
+	if function.VpcConfig != nil {
+		fields = append(fields, function.VpcConfig.VpcID)
+		fields = append(fields, function.VpcConfig.SubnetIDs...)
+		fields = append(fields, function.VpcConfig.SecurityGroupIDs...)
+	}
+	for key, value := range function.Tags {
+		fields = append(fields, key+"="+value)
+	}
+	return strings.Join(fields, " ")
+}
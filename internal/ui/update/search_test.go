@@ -445,3 +445,26 @@ func createTestItems(count int) []interface{} {
 	}
 	return items
 }
+
+func TestFilterFunctionsByInventory(t *testing.T) {
+	items := []interface{}{
+		model.FunctionStatus{Name: "healthy", State: "Active", LastUpdateStatus: "Successful"},
+		model.FunctionStatus{
+			Name:             "broken",
+			State:            "Failed",
+			Layers:           []string{"arn:aws:lambda:us-east-1:123456789012:layer:shared-utils:3"},
+			VpcConfig:        &cloud.FunctionVpcConfig{VpcID: "vpc-0abc", SubnetIDs: []string{"subnet-1234"}},
+			DeadLetterTarget: "arn:aws:sqs:us-east-1:123456789012:orders-dlq",
+			Tags:             map[string]string{"team": "payments"},
+		},
+	}
+
+	for _, query := range []string{"failed", "shared-utils", "vpc-0abc", "subnet-1234", "orders-dlq", "team=payments", "Payments"} {
+		t.Run(query, func(t *testing.T) {
+			filtered := filterItemsByQuery(items, query)
+			if len(filtered) != 1 || filtered[0].(model.FunctionStatus).Name != "broken" {
+				t.Errorf("Expected only the broken function, got %v", filtered)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleSecretsLoad lists the secrets of the account with their rotation status, without their
+// values
+func HandleSecretsLoad(m *model.Model) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingSecrets
+
+	return WrapModel(newModel), func() tea.Msg {
+		secretOperation, err := getSecretsManagerOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		secrets, err := secretOperation.ListSecrets(context.Background())
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.SecretsMsg{Secrets: secrets}
+	}
+}
+
+// HandleSecrets shows the secrets of the account
+func HandleSecrets(m *model.Model, msg model.SecretsMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.Secrets = msg.Secrets
+	newModel.SelectedSecret = nil
+	newModel.SecretValue = nil
+	newModel.SecretKeys = nil
+	newModel.CurrentView = constants.ViewSecrets
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleSecretSelection shows the details of the selected secret, with its value hidden
+func HandleSecretSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 {
+		return WrapModel(m), nil
+	}
+
+	for _, secret := range m.Secrets {
+		if secret.Name == selected[0] {
+			newModel := m.Clone()
+			newModel.SelectedSecret = &secret
+			newModel.SecretValue = nil
+			newModel.SecretKeys = nil
+			newModel.CurrentView = constants.ViewSecretDetails
+			view.UpdateTableForView(newModel)
+			return WrapModel(newModel), nil
+		}
+	}
+	return WrapModel(m), nil
+}
+
+// HandleSecretDetailsSelection handles the selection of a row in the secret details; only the
+// rotate action does anything
+func HandleSecretDetailsSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 || selected[0] != constants.ActionRotateSecret {
+		return WrapModel(m), nil
+	}
+	return HandleSecretRotate(m)
+}
+
+// HandleSecretRevealKey reveals the value of the selected secret, or hides it again if it's
+// revealed
+func HandleSecretRevealKey(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedSecret == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoSecret)}
+		}
+	}
+	if m.SecretValue != nil {
+		return WrapModel(HideSecretValue(m)), nil
+	}
+
+	secretID := m.SelectedSecret.ARN
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgRevealingSecret
+
+	return WrapModel(newModel), func() tea.Msg {
+		secretOperation, err := getSecretsManagerOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		value, err := secretOperation.GetSecretValue(context.Background(), secretID)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.SecretValueMsg{SecretID: secretID, Value: value}
+	}
+}
+
+// HandleSecretValue shows the revealed value of the selected secret until HideSecretAfterTimeout
+// hides it again. A value read for a secret that's no longer shown is dropped.
+func HandleSecretValue(m *model.Model, msg model.SecretValueMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	if m.CurrentView != constants.ViewSecretDetails || m.SelectedSecret == nil || m.SelectedSecret.ARN != msg.SecretID {
+		return newModel
+	}
+
+	newModel.SecretValue = msg.Value
+	newModel.SecretKeys = nil
+	for _, field := range view.SecretFields(msg.Value.Value) {
+		newModel.SecretKeys = append(newModel.SecretKeys, field.Key)
+	}
+	newModel.SecretRevealID++
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HideSecretAfterTimeout returns a command that hides the revealed secret value once it has been
+// shown for constants.SecretRevealTimeout
+func HideSecretAfterTimeout(m *model.Model) tea.Cmd {
+	if m.SecretValue == nil {
+		return nil
+	}
+	revealID := m.SecretRevealID
+	return tea.Tick(constants.SecretRevealTimeout, func(time.Time) tea.Msg {
+		return model.SecretHideMsg{RevealID: revealID}
+	})
+}
+
+// HandleSecretHide hides the secret value once its timeout is up, unless it was hidden and
+// revealed again since
+func HandleSecretHide(m *model.Model, msg model.SecretHideMsg) *model.Model {
+	if msg.RevealID != m.SecretRevealID {
+		return m
+	}
+	return HideSecretValue(m)
+}
+
+// HideSecretValue forgets the revealed secret value, keeping the keys of a JSON secret
+func HideSecretValue(m *model.Model) *model.Model {
+	if m.SecretValue == nil {
+		return m
+	}
+	newModel := m.Clone()
+	newModel.SecretValue = nil
+	if newModel.CurrentView == constants.ViewSecretDetails {
+		view.UpdateTableForView(newModel)
+	}
+	return newModel
+}
+
+// HandleSecretRotate asks for confirmation before rotating the selected secret right away. The
+// value is hidden first, since it changes with the rotation.
+func HandleSecretRotate(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedSecret == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoSecret)}
+		}
+	}
+	if !m.SelectedSecret.RotationEnabled {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoRotation)}
+		}
+	}
+
+	secretOperation, err := getSecretsManagerOperation(m)
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	secret := *m.SelectedSecret
+	details := []string{
+		fmt.Sprintf("Secret: %s", secret.Name),
+		fmt.Sprintf("Rotation: %s", view.SecretRotation(&secret)),
+	}
+	if secret.RotationLambdaARN != "" {
+		details = append(details, fmt.Sprintf("Rotation Function: %s", secret.RotationLambdaARN))
+	}
+
+	newModel := HideSecretValue(m).Clone()
+	newModel.PendingAction = &model.PendingAction{
+		Description: fmt.Sprintf("Rotate %s now", secret.Name),
+		Details:     details,
+		LoadingMsg:  constants.MsgRotatingSecret,
+		BackView:    constants.ViewSecretDetails,
+		Run: func(ctx context.Context) (string, error) {
+			if err := secretOperation.RotateSecret(ctx, secret.ARN); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf(constants.MsgRotateSecretSuccess, secret.Name), nil
+		},
+	}
+	newModel.CurrentView = constants.ViewExecutingAction
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// getSecretsManagerOperation gets the Secrets Manager operation from the selected provider
+func getSecretsManagerOperation(m *model.Model) (cloud.SecretsManagerOperation, error) {
+	provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetSecretsManagerOperation()
+}
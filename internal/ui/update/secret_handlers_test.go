@@ -0,0 +1,168 @@
+package update
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// secretsManagerTestOperation lists a rotated JSON secret and a plain one, and records the secrets
+// read and rotated
+type secretsManagerTestOperation struct {
+	cloud.SecretsManagerOperation
+	read    []string
+	rotated []string
+}
+
+func (o *secretsManagerTestOperation) ListSecrets(ctx context.Context) ([]cloud.Secret, error) {
+	return []cloud.Secret{
+		{
+			Name:              "prod/db",
+			ARN:               "arn:aws:secretsmanager:us-east-1:123456789012:secret:prod/db-AbCdEf",
+			RotationEnabled:   true,
+			RotationAfterDays: 30,
+			RotationLambdaARN: "arn:aws:lambda:us-east-1:123456789012:function:rotate-db",
+			NextRotation:      time.Now().Add(-time.Hour),
+		},
+		{
+			Name: "prod/token",
+			ARN:  "arn:aws:secretsmanager:us-east-1:123456789012:secret:prod/token-GhIjKl",
+		},
+	}, nil
+}
+
+func (o *secretsManagerTestOperation) GetSecretValue(ctx context.Context, secretID string) (*cloud.SecretValue, error) {
+	o.read = append(o.read, secretID)
+	return &cloud.SecretValue{
+		VersionID: "v1",
+		Stages:    []string{"AWSCURRENT"},
+		Value:     `{"username":"app","password":"hunter2"}`,
+	}, nil
+}
+
+func (o *secretsManagerTestOperation) RotateSecret(ctx context.Context, secretID string) error {
+	o.rotated = append(o.rotated, secretID)
+	return nil
+}
+
+// newSecretTestModel returns a model showing the details of a secret
+func newSecretTestModel(t *testing.T, operation *secretsManagerTestOperation, name string) *model.Model {
+	t.Helper()
+	m := newTestModel(&testProvider{secretsManager: operation})
+	result, cmd := HandleSecretsLoad(m)
+	m = HandleSecrets(result.(ModelWrapper).Model, cmd().(model.SecretsMsg))
+	if rows := m.Table.Rows(); len(rows) != 2 || rows[0][1] != "Every 30 days" || rows[1][1] != "Disabled" {
+		t.Fatalf("Expected the secrets with their rotation, got %v", rows)
+	}
+	for i, row := range m.Table.Rows() {
+		if row[0] == name {
+			m.Table.SetCursor(i)
+			result, _ := HandleTableSelect(m)
+			return result.(ModelWrapper).Model
+		}
+	}
+	t.Fatalf("Expected a secret %q, got %v", name, m.Table.Rows())
+	return m
+}
+
+// secretRows returns the rows of the secret details by property
+func secretRows(m *model.Model) map[string]string {
+	rows := make(map[string]string)
+	for _, row := range m.Table.Rows() {
+		rows[row[0]] = row[1]
+	}
+	return rows
+}
+
+func TestSecretReveal(t *testing.T) {
+	operation := &secretsManagerTestOperation{}
+	m := newSecretTestModel(t, operation, "prod/db")
+	if m.CurrentView != constants.ViewSecretDetails || len(operation.read) != 0 {
+		t.Fatalf("Expected the details without reading the value, got view %v", m.CurrentView)
+	}
+	if rows := secretRows(m); rows["Value"] != "••••••••" {
+		t.Errorf("Expected the value to be masked, got %v", rows)
+	}
+
+	result, cmd := HandleSecretRevealKey(m)
+	m = HandleSecretValue(result.(ModelWrapper).Model, cmd().(model.SecretValueMsg))
+	rows := secretRows(m)
+	if rows["Key: username"] != "app" || rows["Key: password"] != "hunter2" || rows["Version"] != "v1 (AWSCURRENT)" {
+		t.Fatalf("Expected a row for each key of the revealed value, got %v", rows)
+	}
+	if HideSecretAfterTimeout(m) == nil {
+		t.Errorf("Expected the revealed value to be hidden after a timeout")
+	}
+
+	// The timeout of an earlier reveal leaves the value shown
+	if hidden := HandleSecretHide(m, model.SecretHideMsg{RevealID: m.SecretRevealID - 1}); hidden.SecretValue == nil {
+		t.Errorf("Expected a stale timeout to be ignored")
+	}
+	m = HandleSecretHide(m, model.SecretHideMsg{RevealID: m.SecretRevealID})
+	rows = secretRows(m)
+	if m.SecretValue != nil || rows["Key: password"] != "••••••••" || rows["Key: username"] != "••••••••" {
+		t.Errorf("Expected the keys to stay listed with masked values, got %v", rows)
+	}
+	if _, ok := rows["Version"]; ok {
+		t.Errorf("Expected no version once the value is hidden")
+	}
+
+	// The reveal key hides a revealed value again, and a value read after leaving the secret is dropped
+	result, cmd = HandleSecretRevealKey(m)
+	msg := cmd().(model.SecretValueMsg)
+	m = HandleSecretValue(result.(ModelWrapper).Model, msg)
+	result, _ = HandleSecretRevealKey(m)
+	if m = result.(ModelWrapper).Model; m.SecretValue != nil {
+		t.Errorf("Expected the reveal key to hide the value")
+	}
+	back := NavigateBack(m)
+	if back = HandleSecretValue(back, msg); back.CurrentView != constants.ViewSecrets || back.SecretValue != nil {
+		t.Errorf("Expected a value read after leaving the secret to be dropped")
+	}
+}
+
+func TestRotateSecret(t *testing.T) {
+	operation := &secretsManagerTestOperation{}
+
+	// Only secrets with rotation enabled can be rotated
+	m := newSecretTestModel(t, operation, "prod/token")
+	for _, row := range m.Table.Rows() {
+		if row[0] == constants.ActionRotateSecret {
+			t.Errorf("Expected no rotate action for a secret without rotation")
+		}
+	}
+
+	m = newSecretTestModel(t, operation, "prod/db")
+	result, cmd := HandleSecretRevealKey(m)
+	m = HandleSecretValue(result.(ModelWrapper).Model, cmd().(model.SecretValueMsg))
+	for i, row := range m.Table.Rows() {
+		if row[0] == constants.ActionRotateSecret {
+			m.Table.SetCursor(i)
+		}
+	}
+	result, _ = HandleTableSelect(m)
+	m = result.(ModelWrapper).Model
+	if m.CurrentView != constants.ViewExecutingAction || m.PendingAction == nil {
+		t.Fatalf("Expected to confirm the rotation, got view %v", m.CurrentView)
+	}
+	if m.SecretValue != nil {
+		t.Errorf("Expected the value to be hidden before rotating")
+	}
+	if len(operation.rotated) != 0 {
+		t.Fatalf("Expected nothing to be rotated before confirmation")
+	}
+
+	m.Table.SetCursor(0)
+	confirmed, cmd := HandleExecutionSelection(m)
+	m = HandleActionResult(confirmed.(ModelWrapper).Model, cmd().(model.ActionResultMsg))
+	if len(operation.rotated) != 1 || operation.rotated[0] != "arn:aws:secretsmanager:us-east-1:123456789012:secret:prod/db-AbCdEf" {
+		t.Errorf("Expected the secret to be rotated, got %v", operation.rotated)
+	}
+	if m.Err != nil || m.Secrets != nil || m.SelectedSecret != nil {
+		t.Errorf("Expected the secrets to be cleared after rotating, got error %v", m.Err)
+	}
+}
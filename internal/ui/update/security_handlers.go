@@ -0,0 +1,60 @@
+package update
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleSecurityLoad loads the function URL, resource-based policy and execution role policies of
+// the function shown in the details view
+func HandleSecurityLoad(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.CurrentView != constants.ViewFunctionDetails {
+		return WrapModel(m), nil
+	}
+	if m.SelectedFunction == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoFunction)}
+		}
+	}
+
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingSecurity
+	functionName := m.SelectedFunction.Name
+
+	return WrapModel(newModel), func() tea.Msg {
+		// Get the provider
+		provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		// Get the LambdaSecurityOperation from the provider
+		securityOperation, err := provider.GetLambdaSecurityOperation()
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		security, err := securityOperation.GetFunctionSecurity(context.Background(), functionName)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.FunctionSecurityMsg{Security: security}
+	}
+}
+
+// HandleFunctionSecurity shows the security review of a function
+func HandleFunctionSecurity(m *model.Model, msg model.FunctionSecurityMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.FunctionSecurity = msg.Security
+	newModel.CurrentView = constants.ViewFunctionSecurity
+	view.UpdateTableForView(newModel)
+	return newModel
+}
@@ -0,0 +1,112 @@
+package update
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// securityTestOperation returns a fixed security review and records the function it was asked for
+type securityTestOperation struct {
+	cloud.LambdaSecurityOperation
+	security     *cloud.FunctionSecurity
+	functionName string
+}
+
+func (o *securityTestOperation) GetFunctionSecurity(ctx context.Context, functionName string) (*cloud.FunctionSecurity, error) {
+	o.functionName = functionName
+	return o.security, nil
+}
+
+func TestFunctionSecurity(t *testing.T) {
+	operation := &securityTestOperation{security: &cloud.FunctionSecurity{
+		FunctionName: "orders",
+		Role:         "arn:aws:iam::123456789012:role/orders-role",
+		FunctionURL: &cloud.FunctionURLConfig{
+			URL:      "https://abc.lambda-url.us-east-1.on.aws/",
+			AuthType: "NONE",
+			Cors:     &cloud.FunctionURLCors{AllowOrigins: []string{"*"}},
+		},
+		PolicyStatements: []cloud.PolicyStatement{
+			{
+				Sid:        "s3-invoke",
+				Effect:     "Allow",
+				Principals: []string{"Service: s3.amazonaws.com"},
+				Actions:    []string{"lambda:InvokeFunction"},
+				Conditions: []string{"ArnLike AWS:SourceArn arn:aws:s3:::orders-uploads"},
+			},
+		},
+		RolePolicies: []cloud.RolePolicy{
+			{Name: "AdministratorAccess", Arn: "arn:aws:iam::aws:policy/AdministratorAccess"},
+			{Name: "orders-table"},
+		},
+		Findings: []cloud.SecurityFinding{
+			{Severity: cloud.SeverityHigh, Subject: "Function URL", Detail: "Auth type is NONE"},
+			{Severity: cloud.SeverityHigh, Subject: "Execution role", Detail: "AdministratorAccess"},
+			{Severity: cloud.SeverityMedium, Subject: "CORS", Detail: "Any origin may call the URL"},
+		},
+		Errors: []string{"failed to get resource-based policy: access denied"},
+	}}
+	m := newTestModel(&testProvider{lambdaSecurity: operation})
+	m.CurrentView = constants.ViewFunctionDetails
+	m.SetSelectedFunction(&cloud.FunctionStatus{Name: "orders"})
+
+	result, cmd := HandleSecurityLoad(m)
+	if !result.(ModelWrapper).Model.IsLoading {
+		t.Errorf("Expected the model to be loading")
+	}
+	msg, ok := cmd().(model.FunctionSecurityMsg)
+	if !ok {
+		t.Fatalf("Expected a security message")
+	}
+	if operation.functionName != "orders" {
+		t.Errorf("Expected the selected function to be reviewed, got %q", operation.functionName)
+	}
+
+	m = HandleFunctionSecurity(result.(ModelWrapper).Model, msg)
+	if m.CurrentView != constants.ViewFunctionSecurity || m.IsLoading {
+		t.Fatalf("Expected the security view, got view %v", m.CurrentView)
+	}
+
+	rows := m.Table.Rows()
+	if len(rows) < 3 || rows[0][0] != "! High" || rows[2][0] != "! Medium" {
+		t.Fatalf("Expected the findings first, got %v", rows)
+	}
+	var lines []string
+	for _, row := range rows {
+		lines = append(lines, strings.Join(row, "|"))
+	}
+	table := strings.Join(lines, "\n")
+	for _, expected := range []string{
+		"URL|Auth type|NONE",
+		"CORS|Allow origins|*",
+		"Policy|s3-invoke|Allow",
+		"|  Principals|Service: s3.amazonaws.com",
+		"|  Condition|ArnLike AWS:SourceArn arn:aws:s3:::orders-uploads",
+		"Role|AdministratorAccess|arn:aws:iam::aws:policy/AdministratorAccess",
+		"Role|orders-table|Inline policy",
+	} {
+		if !strings.Contains(table, expected) {
+			t.Errorf("Expected a row %q, got:\n%s", expected, table)
+		}
+	}
+
+	m = NavigateBack(m)
+	if m.CurrentView != constants.ViewFunctionDetails || m.FunctionSecurity != nil {
+		t.Errorf("Expected the function details, got %v", m.CurrentView)
+	}
+}
+
+func TestFunctionSecurityOnlyFromDetails(t *testing.T) {
+	m := model.New()
+	m.CurrentView = constants.ViewFunctionStatus
+
+	result, cmd := HandleSecurityLoad(m)
+	if cmd != nil || result.(ModelWrapper).Model.IsLoading {
+		t.Errorf("Expected the key to be ignored outside the function details")
+	}
+}
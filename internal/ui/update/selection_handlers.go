@@ -181,6 +181,55 @@ func SelectOperation(m *model.Model) (tea.Model, tea.Cmd) {
 				// Lambda execution flow
 				newModel.IsExecuteLambdaFlow = true
 				return HandleFunctionStatus(newModel)
+			case "Configure Function":
+				// Lambda configuration flow
+				newModel.IsExecuteLambdaFlow = false
+				return HandleFunctionStatus(newModel)
+			case "Deploy Code":
+				// Lambda deployment flow
+				newModel.IsExecuteLambdaFlow = false
+				return HandleFunctionStatus(newModel)
+			case "Event Source Mappings":
+				// Event source mapping flow, scoped to one function or all of them
+				newModel.IsExecuteLambdaFlow = false
+				return HandleEventSourceScope(newModel)
+			case "Hygiene Report":
+				// Hygiene report flow, scanning every function in the chosen regions
+				newModel.IsExecuteLambdaFlow = false
+				return HandleHygieneOptions(newModel)
+			case "Browse Buckets":
+				// S3 browser flow, from the buckets down through their prefixes
+				return HandleS3Buckets(newModel)
+			case "Manage Instances":
+				// EC2 instance flow, from the filters to an instance's details
+				return HandleEC2Filters(newModel)
+			case "Query Logs":
+				// Logs Insights flow, from the log groups to the query editor and its results
+				return HandleLogGroupsLoad(newModel)
+			case "View Alarms":
+				// Alarm dashboard flow, from the alarms grouped by state to an alarm's history
+				return HandleAlarmsLoad(newModel)
+			case "Manage Services":
+				// ECS flow, from the clusters to a service's tasks and their containers
+				return HandleECSClustersLoad(newModel)
+			case "Manage Builds":
+				// CodeBuild flow, from the projects to a build's phases and its live log
+				return HandleBuildProjectsLoad(newModel)
+			case "Manage Stacks":
+				// CloudFormation flow, from the stacks to their events, drift and change sets
+				return HandleStacksLoad(newModel)
+			case "Manage Parameters":
+				// Parameter Store flow, browsing the parameters by path
+				return HandleParametersLoad(newModel)
+			case "Manage Secrets":
+				// Secrets Manager flow, from the secrets' rotation status to a secret's value
+				return HandleSecretsLoad(newModel)
+			case "Manage Queues":
+				// SQS flow, from the queues' depth to peeking, sending, purging and redriving messages
+				return HandleQueuesLoad(newModel)
+			case "Manage Topics":
+				// SNS flow, from the topics to their subscriptions and publishing test messages
+				return HandleTopicsLoad(newModel)
 			default:
 				return WrapModel(newModel), nil
 			}
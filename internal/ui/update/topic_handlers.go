@@ -0,0 +1,313 @@
+package update
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	"github.com/HenryOwenz/cloudgate/internal/ui/view"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// HandleTopicsLoad lists the topics of the account with their subscription counts
+func HandleTopicsLoad(m *model.Model) (tea.Model, tea.Cmd) {
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingTopics
+
+	return WrapModel(newModel), func() tea.Msg {
+		topicOperation, err := getTopicOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		topics, err := topicOperation.ListTopics(context.Background())
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.TopicsMsg{Topics: topics}
+	}
+}
+
+// HandleTopics shows the topics of the account
+func HandleTopics(m *model.Model, msg model.TopicsMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.Topics = msg.Topics
+	newModel.SelectedTopic = nil
+	newModel.Subscriptions = nil
+	newModel.SelectedSubscription = nil
+	newModel.CurrentView = constants.ViewTopics
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleTopicSelection shows the details and actions of the selected topic
+func HandleTopicSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 {
+		return WrapModel(m), nil
+	}
+
+	for _, topic := range m.Topics {
+		if topic.Name == selected[0] {
+			newModel := m.Clone()
+			newModel.SelectedTopic = &topic
+			newModel.CurrentView = constants.ViewTopicDetails
+			view.UpdateTableForView(newModel)
+			return WrapModel(newModel), nil
+		}
+	}
+	return WrapModel(m), nil
+}
+
+// HandleTopicDetailsSelection handles the selection of a row in the topic details; only the
+// action rows do anything
+func HandleTopicDetailsSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	selected := m.Table.SelectedRow()
+	if len(selected) == 0 {
+		return WrapModel(m), nil
+	}
+
+	switch selected[0] {
+	case constants.ActionViewSubscriptions:
+		return HandleSubscriptionsLoad(m)
+	case constants.ActionPublishMessage:
+		return HandleTopicPublish(m)
+	default:
+		return WrapModel(m), nil
+	}
+}
+
+// HandleSubscriptionsLoad lists the subscriptions to the selected topic
+func HandleSubscriptionsLoad(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedTopic == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoTopic)}
+		}
+	}
+
+	topicARN := m.SelectedTopic.ARN
+	newModel := m.Clone()
+	newModel.IsLoading = true
+	newModel.LoadingMsg = constants.MsgLoadingSubscribers
+
+	return WrapModel(newModel), func() tea.Msg {
+		topicOperation, err := getTopicOperation(m)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		subscriptions, err := topicOperation.ListSubscriptions(context.Background(), topicARN)
+		if err != nil {
+			return model.ErrMsg{Err: err}
+		}
+
+		return model.SubscriptionsMsg{Subscriptions: subscriptions}
+	}
+}
+
+// HandleSubscriptions shows the subscriptions to the selected topic
+func HandleSubscriptions(m *model.Model, msg model.SubscriptionsMsg) *model.Model {
+	newModel := m.Clone()
+	newModel.IsLoading = false
+	newModel.Subscriptions = msg.Subscriptions
+	newModel.SelectedSubscription = nil
+	newModel.CurrentView = constants.ViewSubscriptions
+	view.UpdateTableForView(newModel)
+	return newModel
+}
+
+// HandleSubscriptionSelection shows the settings and filter policy of the selected subscription.
+// Subscriptions pending confirmation have no ARN, so they're found by their position.
+func HandleSubscriptionSelection(m *model.Model) (tea.Model, tea.Cmd) {
+	cursor := m.Table.Cursor()
+	if cursor < 0 || cursor >= len(m.Subscriptions) {
+		return WrapModel(m), nil
+	}
+
+	subscription := m.Subscriptions[cursor]
+	newModel := m.Clone()
+	newModel.SelectedSubscription = &subscription
+	newModel.Viewport = viewport.New(newModel.Width-constants.ViewportMarginX*2, constants.TableHeight)
+	newModel.Viewport.YPosition = constants.HeaderHeight // Position below the title
+	newModel.Viewport.SetContent(view.SubscriptionContent(&subscription))
+	newModel.CurrentView = constants.ViewSubscription
+	return WrapModel(newModel), nil
+}
+
+// HandleTopicPublish opens the editor of a test message for the selected topic, starting with
+// constants.DefaultTopicMessage
+func HandleTopicPublish(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedTopic == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoTopic)}
+		}
+	}
+
+	ta := textarea.New()
+	ta.ShowLineNumbers = true
+	ta.CharLimit = 0
+	ta.SetValue(constants.DefaultTopicMessage)
+	ta.Focus()
+
+	newModel := m.Clone()
+	newModel.TextArea = ta
+	newModel.IsTopicInputMode = true
+	newModel.CurrentView = constants.ViewTopicPublish
+	return WrapModel(newModel), nil
+}
+
+// HandleTopicPublishKey handles the keys of the message editor: in input mode they edit the
+// message, otherwise they review it or leave the editor
+func HandleTopicPublishKey(m *model.Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == constants.KeyCtrlC {
+		return WrapModel(m), tea.Quit
+	}
+
+	if m.IsTopicInputMode {
+		newModel := m.Clone()
+		if key == constants.KeyEsc {
+			newModel.IsTopicInputMode = false
+			return WrapModel(newModel), nil
+		}
+		var cmd tea.Cmd
+		newModel.TextArea, cmd = newModel.TextArea.Update(msg)
+		return WrapModel(newModel), cmd
+	}
+
+	switch key {
+	case constants.KeyQ:
+		return WrapModel(m), tea.Quit
+	case constants.KeyEsc, constants.KeyAltBack:
+		return WrapModel(NavigateBack(m)), nil
+	case "i":
+		newModel := m.Clone()
+		newModel.IsTopicInputMode = true
+		return WrapModel(newModel), nil
+	case constants.KeyEnter:
+		return HandleTopicPublishMessage(m)
+	default:
+		return WrapModel(m), nil
+	}
+}
+
+// HandleTopicPublishMessage asks for confirmation before publishing the edited message to the
+// selected topic
+func HandleTopicPublishMessage(m *model.Model) (tea.Model, tea.Cmd) {
+	if m.SelectedTopic == nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: fmt.Errorf(constants.MsgErrorNoTopic)}
+		}
+	}
+
+	message, err := parseTopicMessage(m.TextArea.Value())
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	topicOperation, err := getTopicOperation(m)
+	if err != nil {
+		return WrapModel(m), func() tea.Msg {
+			return model.ErrMsg{Err: err}
+		}
+	}
+
+	topic := *m.SelectedTopic
+	newModel := m.Clone()
+	newModel.IsTopicInputMode = false
+	newModel.PendingAction = &model.PendingAction{
+		Description: fmt.Sprintf("Publish a test message to %s", topic.Name),
+		Details:     view.TopicPublishDetails(&topic, message),
+		LoadingMsg:  constants.MsgPublishingMessage,
+		BackView:    constants.ViewTopicPublish,
+		Run: func(ctx context.Context) (string, error) {
+			messageID, err := topicOperation.PublishMessage(ctx, topic, message)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf(constants.MsgPublishSuccess, messageID, topic.Name), nil
+		},
+	}
+	newModel.CurrentView = constants.ViewExecutingAction
+	view.UpdateTableForView(newModel)
+	return WrapModel(newModel), nil
+}
+
+// parseTopicMessage reads a test message from the payload of the editor, in the form of
+// constants.DefaultTopicMessage. A message that isn't a string is published as compact JSON. The
+// type of an attribute follows its JSON value: a string is a String, a number a Number and an
+// array a String.Array.
+func parseTopicMessage(payload string) (cloud.TopicMessage, error) {
+	var fields struct {
+		Subject    string                     `json:"subject"`
+		Message    json.RawMessage            `json:"message"`
+		Attributes map[string]json.RawMessage `json:"attributes"`
+	}
+	decoder := json.NewDecoder(strings.NewReader(payload))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&fields); err != nil {
+		return cloud.TopicMessage{}, fmt.Errorf(constants.MsgErrorTopicMessage, err)
+	}
+
+	message := cloud.TopicMessage{Subject: fields.Subject}
+	if err := json.Unmarshal(fields.Message, &message.Body); err != nil {
+		var compact bytes.Buffer
+		if json.Compact(&compact, fields.Message) == nil {
+			message.Body = compact.String()
+		}
+	}
+	if strings.TrimSpace(message.Body) == "" {
+		return cloud.TopicMessage{}, fmt.Errorf(constants.MsgErrorEmptyMessage)
+	}
+
+	if len(fields.Attributes) > 0 {
+		message.Attributes = make(map[string]cloud.TopicMessageAttribute, len(fields.Attributes))
+	}
+	for name, raw := range fields.Attributes {
+		var value any
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); err != nil {
+			return cloud.TopicMessage{}, fmt.Errorf(constants.MsgErrorTopicMessage, err)
+		}
+
+		var attribute cloud.TopicMessageAttribute
+		switch value := value.(type) {
+		case string:
+			attribute = cloud.TopicMessageAttribute{DataType: "String", Value: value}
+		case json.Number:
+			attribute = cloud.TopicMessageAttribute{DataType: "Number", Value: value.String()}
+		case []any:
+			var compact bytes.Buffer
+			if err := json.Compact(&compact, raw); err != nil {
+				return cloud.TopicMessage{}, fmt.Errorf(constants.MsgErrorTopicMessage, err)
+			}
+			attribute = cloud.TopicMessageAttribute{DataType: "String.Array", Value: compact.String()}
+		default:
+			return cloud.TopicMessage{}, fmt.Errorf(constants.MsgErrorAttributeType, name)
+		}
+		message.Attributes[name] = attribute
+	}
+	return message, nil
+}
+
+// getTopicOperation gets the SNS operation from the selected provider
+func getTopicOperation(m *model.Model) (cloud.TopicOperation, error) {
+	provider, err := m.Registry.Get(m.ProviderState.ProviderName)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetTopicOperation()
+}
@@ -0,0 +1,169 @@
+package update
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// topicTestOperation lists a topic with a filtered and a pending subscription, and records the
+// messages published
+type topicTestOperation struct {
+	cloud.TopicOperation
+	published []cloud.TopicMessage
+}
+
+func (o *topicTestOperation) ListTopics(ctx context.Context) ([]cloud.Topic, error) {
+	return []cloud.Topic{
+		{
+			Name:      "orders",
+			ARN:       "arn:aws:sns:us-east-1:123456789012:orders",
+			Confirmed: 1,
+			Pending:   1,
+		},
+	}, nil
+}
+
+func (o *topicTestOperation) ListSubscriptions(ctx context.Context, topicARN string) ([]cloud.Subscription, error) {
+	return []cloud.Subscription{
+		{
+			ARN:               topicARN + ":4f2c",
+			Protocol:          "sqs",
+			Endpoint:          "arn:aws:sqs:us-east-1:123456789012:billing",
+			FilterPolicy:      `{"eventType": ["order.created"]}`,
+			FilterPolicyScope: "MessageAttributes",
+		},
+		{
+			Protocol:            "email",
+			Endpoint:            "oncall@example.com",
+			PendingConfirmation: true,
+		},
+	}, nil
+}
+
+func (o *topicTestOperation) PublishMessage(ctx context.Context, topic cloud.Topic, message cloud.TopicMessage) (string, error) {
+	o.published = append(o.published, message)
+	return "message-1", nil
+}
+
+func TestPublishTopicMessage(t *testing.T) {
+	operation := &topicTestOperation{}
+	m := newTestModel(&testProvider{topics: operation})
+	result, cmd := HandleTopicsLoad(m)
+	m = HandleTopics(result.(ModelWrapper).Model, cmd().(model.TopicsMsg))
+	m.Table.SetCursor(0)
+	result, _ = HandleTableSelect(m)
+	m = result.(ModelWrapper).Model
+	if m.CurrentView != constants.ViewTopicDetails || m.SelectedTopic == nil {
+		t.Fatalf("Expected the details of the topic, got view %v", m.CurrentView)
+	}
+
+	// Subscriptions pending confirmation are selected by their position, as they have no ARN
+	subscriptions, cmd := HandleSubscriptionsLoad(m)
+	listed := HandleSubscriptions(subscriptions.(ModelWrapper).Model, cmd().(model.SubscriptionsMsg))
+	rows := listed.Table.Rows()
+	if len(rows) != 2 || rows[0][3] != `{"eventType":["order.created"]}` || rows[1][2] != "Pending Confirmation" {
+		t.Fatalf("Expected the subscriptions with their status and filter policy, got %v", rows)
+	}
+	listed.Table.SetCursor(1)
+	result, _ = HandleTableSelect(listed)
+	if selected := result.(ModelWrapper).Model.SelectedSubscription; selected == nil || selected.Endpoint != "oncall@example.com" {
+		t.Errorf("Expected the pending subscription to be shown, got %+v", selected)
+	}
+
+	for i, row := range m.Table.Rows() {
+		if row[0] == constants.ActionPublishMessage {
+			m.Table.SetCursor(i)
+		}
+	}
+	result, _ = HandleTableSelect(m)
+	m = result.(ModelWrapper).Model
+	if m.CurrentView != constants.ViewTopicPublish || m.TextArea.Value() != constants.DefaultTopicMessage {
+		t.Fatalf("Expected the editor to start with the default message, got view %v", m.CurrentView)
+	}
+
+	m.TextArea.SetValue(`{"message": {"id": 42}, "attributes": {"eventType": "order.created"}}`)
+	m.IsTopicInputMode = false
+	result, _ = HandleTopicPublishKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(ModelWrapper).Model
+	if m.CurrentView != constants.ViewExecutingAction || m.PendingAction == nil || len(operation.published) != 0 {
+		t.Fatalf("Expected to confirm the message before publishing it, got view %v", m.CurrentView)
+	}
+
+	m.Table.SetCursor(0)
+	confirmed, cmd := HandleExecutionSelection(m)
+	m = HandleActionResult(confirmed.(ModelWrapper).Model, cmd().(model.ActionResultMsg))
+	if len(operation.published) != 1 || operation.published[0].Body != `{"id":42}` {
+		t.Fatalf("Expected the message to be published, got %+v", operation.published)
+	}
+	if m.Err != nil || m.Topics != nil || m.SelectedTopic != nil {
+		t.Errorf("Expected the topics to be cleared after publishing, got error %v", m.Err)
+	}
+}
+
+func TestParseTopicMessage(t *testing.T) {
+	testCases := []struct {
+		name    string
+		payload string
+		want    cloud.TopicMessage
+		wantErr bool
+	}{
+		{
+			name:    "String message with a subject",
+			payload: `{"subject": "Test", "message": "hello"}`,
+			want:    cloud.TopicMessage{Subject: "Test", Body: "hello"},
+		},
+		{
+			name: "JSON message with attributes of each type",
+			payload: `{
+  "message": {"id": 42},
+  "attributes": {"eventType": "order.created", "amount": 12.5, "regions": ["eu", "us"]}
+}`,
+			want: cloud.TopicMessage{
+				Body: `{"id":42}`,
+				Attributes: map[string]cloud.TopicMessageAttribute{
+					"eventType": {DataType: "String", Value: "order.created"},
+					"amount":    {DataType: "Number", Value: "12.5"},
+					"regions":   {DataType: "String.Array", Value: `["eu","us"]`},
+				},
+			},
+		},
+		{
+			name:    "Missing message",
+			payload: `{"subject": "Test"}`,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown field",
+			payload: `{"mesage": "hello"}`,
+			wantErr: true,
+		},
+		{
+			name:    "Boolean attribute",
+			payload: `{"message": "hello", "attributes": {"urgent": true}}`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid JSON",
+			payload: `{"message": "hello"`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseTopicMessage(tc.payload)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Expected error %v, got %v", tc.wantErr, err)
+			}
+			if !tc.wantErr && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// getAlarmsColumns returns the columns of the alarm dashboard
+func getAlarmsColumns() []table.Column {
+	return []table.Column{
+		{Title: "State", Width: constants.TableNarrowWidth + 4},
+		{Title: "Alarm", Width: constants.TableWideWidth},
+		{Title: "Reason", Width: constants.TableDescWidth},
+		{Title: "Last Change", Width: constants.TableNarrowWidth},
+		{Title: "Resource", Width: constants.TableWideWidth},
+	}
+}
+
+// getAlarmsRows returns a row for each alarm, in the order of m.Alarms
+func getAlarmsRows(m *model.Model) []table.Row {
+	rows := make([]table.Row, 0, len(m.Alarms))
+	for _, alarm := range m.Alarms {
+		rows = append(rows, table.Row{
+			alarmStateLabel(alarm),
+			alarm.Name,
+			alarm.StateReason,
+			formatTimestamp(alarm.StateChanged),
+			alarm.Resource,
+		})
+	}
+	return rows
+}
+
+// getAlarmDetailsRows returns the properties of the selected alarm, ending with its actions
+func getAlarmDetailsRows(m *model.Model) []table.Row {
+	alarm := m.SelectedAlarm
+	if alarm == nil {
+		return []table.Row{}
+	}
+
+	resource := "Metric"
+	if alarm.Type == cloud.AlarmTypeComposite {
+		resource = "Rule"
+	}
+
+	action := constants.ActionMuteAlarm
+	if !alarm.ActionsEnabled {
+		action = constants.ActionUnmuteAlarm
+	}
+
+	return []table.Row{
+		{"Name", alarm.Name},
+		{"Type", alarm.Type},
+		{"State", alarm.State},
+		{"Reason", valueOrNone(alarm.StateReason)},
+		{"Last Change", formatTimestamp(alarm.StateChanged)},
+		{"Actions", AlarmActionsLabel(alarm.ActionsEnabled)},
+		{resource, valueOrNone(alarm.Resource)},
+		{"Description", valueOrNone(alarm.Description)},
+		{"ARN", alarm.ARN},
+		{action, ""},
+		{constants.ActionAlarmHistory, ""},
+	}
+}
+
+// getAlarmHistoryColumns returns the columns of the alarm history
+func getAlarmHistoryColumns() []table.Column {
+	return []table.Column{
+		{Title: "Time", Width: constants.TableNarrowWidth},
+		{Title: "Type", Width: constants.TableNarrowWidth},
+		{Title: "Summary", Width: constants.TableDescWidth + 10},
+	}
+}
+
+// getAlarmHistoryRows returns a row for each history item, newest first
+func getAlarmHistoryRows(m *model.Model) []table.Row {
+	rows := make([]table.Row, 0, len(m.AlarmHistory))
+	for _, item := range m.AlarmHistory {
+		rows = append(rows, table.Row{formatTimestamp(item.Timestamp), item.Type, item.Summary})
+	}
+	return rows
+}
+
+// getLinkedAlarmDetailRows returns a property row for each alarm cross-linked into the function
+// details view
+func getLinkedAlarmDetailRows(m *model.Model) []table.Row {
+	var rows []table.Row
+	for _, alarm := range m.LinkedAlarms[LinkedAlarmsName(m)] {
+		rows = append(rows, table.Row{
+			constants.AlarmRowPrefix + alarm.Name,
+			fmt.Sprintf("%s since %s", alarmStateLabel(alarm), formatTimestamp(alarm.StateChanged)),
+		})
+	}
+	return rows
+}
+
+// getLinkedAlarmStageRows returns a row for each alarm cross-linked into the pipeline stages
+// view, laid out like the stages above them
+func getLinkedAlarmStageRows(m *model.Model) []table.Row {
+	var rows []table.Row
+	for _, alarm := range m.LinkedAlarms[LinkedAlarmsName(m)] {
+		rows = append(rows, table.Row{
+			constants.AlarmRowPrefix + alarm.Name,
+			alarmStateLabel(alarm),
+			formatTimestamp(alarm.StateChanged),
+		})
+	}
+	return rows
+}
+
+// LinkedAlarmsName returns the name of the function or pipeline whose details are shown, which
+// the alarms cross-linked into them start with, or "" for other views
+func LinkedAlarmsName(m *model.Model) string {
+	switch {
+	case m.CurrentView == constants.ViewFunctionDetails && m.SelectedFunction != nil:
+		return m.SelectedFunction.Name
+	case m.CurrentView == constants.ViewPipelineStages && m.SelectedPipeline != nil:
+		return m.SelectedPipeline.Name
+	default:
+		return ""
+	}
+}
+
+// AlarmActionsLabel returns whether the actions of an alarm are enabled, or muted
+func AlarmActionsLabel(enabled bool) string {
+	if enabled {
+		return "Enabled"
+	}
+	return "Disabled (muted)"
+}
+
+// alarmStateLabel returns the state of an alarm, marking alarms whose actions are disabled
+func alarmStateLabel(alarm cloud.Alarm) string {
+	if alarm.ActionsEnabled {
+		return alarm.State
+	}
+	return alarm.State + " (muted)"
+}
+
+// getAlarmsContextText returns the context text for the alarm views
+func getAlarmsContextText(m *model.Model) string {
+	context := fmt.Sprintf("Profile: %s\nRegion: %s", m.AwsProfile, m.AwsRegion)
+
+	switch m.CurrentView {
+	case constants.ViewAlarms:
+		counts := make(map[string]int, len(cloud.AlarmStates))
+		muted := 0
+		for _, alarm := range m.Alarms {
+			counts[alarm.State]++
+			if !alarm.ActionsEnabled {
+				muted++
+			}
+		}
+		context += fmt.Sprintf("\nIn Alarm: %d\nInsufficient Data: %d\nOK: %d\nMuted: %d",
+			counts[cloud.AlarmStateAlarm], counts[cloud.AlarmStateInsufficientData], counts[cloud.AlarmStateOK], muted)
+	case constants.ViewAlarmDetails, constants.ViewAlarmHistory:
+		alarm := m.SelectedAlarm
+		if alarm == nil {
+			break
+		}
+		context += fmt.Sprintf("\nAlarm: %s\nState: %s", alarm.Name, alarm.State)
+		if m.CurrentView == constants.ViewAlarmHistory {
+			context += fmt.Sprintf("\nHistory Items: %d", len(m.AlarmHistory))
+		}
+		if !alarm.ActionsEnabled {
+			context += "\n" + logWarningStyle.Render("Actions are disabled")
+		}
+	}
+
+	return context
+}
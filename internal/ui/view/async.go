@@ -0,0 +1,118 @@
+package view
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// payloadPreviewLength is how much of a failed event's payload is shown before it's cut off
+const payloadPreviewLength = 120
+
+// getAsyncConfigRows returns the retry settings and destinations of a function, followed by a row
+// to peek at each queue failed events end up in
+func getAsyncConfigRows(m *model.Model) []table.Row {
+	config := m.AsyncConfig
+	if config == nil {
+		return []table.Row{}
+	}
+
+	rows := []table.Row{
+		{constants.SettingMaxRetryAttempts, fmt.Sprintf("%d", config.MaxRetryAttempts)},
+		{constants.SettingMaxEventAge, (time.Duration(config.MaxEventAge) * time.Second).String()},
+		{constants.SettingOnSuccess, valueOrNone(config.OnSuccess)},
+		{constants.SettingOnFailure, valueOrNone(config.OnFailure)},
+		{constants.SettingDeadLetterQueue, valueOrNone(config.DeadLetterQueue)},
+	}
+	for _, queue := range config.FailedEventQueues() {
+		rows = append(rows, table.Row{constants.ActionPeekFailedEvents, queue})
+	}
+	return rows
+}
+
+// getFailedEventsColumns returns the columns of the failed events view
+func getFailedEventsColumns() []table.Column {
+	return []table.Column{
+		{Title: "Sent", Width: constants.TableNarrowWidth},
+		{Title: "Condition", Width: constants.TableNarrowWidth},
+		{Title: "Error", Width: constants.TableWideWidth},
+		{Title: "Payload", Width: constants.TableDescWidth},
+	}
+}
+
+// getFailedEventsRows returns a row for each failed event peeked from a queue
+func getFailedEventsRows(m *model.Model) []table.Row {
+	rows := make([]table.Row, 0, len(m.FailedEvents))
+	for _, event := range m.FailedEvents {
+		rows = append(rows, table.Row{
+			formatTimestamp(event.SentAt),
+			valueOrNone(event.Condition),
+			valueOrNone(event.ErrorMessage),
+			payloadPreview(event.Payload),
+		})
+	}
+	return rows
+}
+
+// FailedEventSummary returns the lines describing a failed event before it's replayed
+func FailedEventSummary(event cloud.FailedEvent) []string {
+	return []string{
+		fmt.Sprintf("Queue: %s", event.QueueArn),
+		fmt.Sprintf("Request ID: %s", valueOrNone(event.RequestID)),
+		fmt.Sprintf("Error: %s", valueOrNone(event.ErrorMessage)),
+		fmt.Sprintf("Payload: %s", payloadPreview(event.Payload)),
+		"The message is left in the queue",
+	}
+}
+
+// getAsyncContextText returns the context text for the asynchronous invocation views
+func getAsyncContextText(m *model.Model) string {
+	context := fmt.Sprintf("Profile: %s\nRegion: %s", m.AwsProfile, m.AwsRegion)
+	if m.AsyncConfig == nil {
+		return context
+	}
+	context += "\nFunction: " + m.AsyncConfig.FunctionName
+
+	if m.CurrentView == constants.ViewFailedEvents {
+		return context + fmt.Sprintf("\nQueue: %s\nEvents: %d (at most %d are read)",
+			m.FailedEventQueue, len(m.FailedEvents), constants.MaxFailedEvents)
+	}
+
+	if m.AsyncConfig.IsDefault {
+		context += "\nNo event invoke config, the Lambda defaults apply"
+	}
+	if len(m.AsyncConfig.FailedEventQueues()) == 0 {
+		context += "\n" + logWarningStyle.Render("Failed events don't go to an SQS queue")
+	}
+	return context
+}
+
+// valueOrNone returns a value, or "none" when it's empty
+func valueOrNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
+
+// payloadPreview returns a payload on a single line, cut off when it's long
+func payloadPreview(payload string) string {
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, []byte(payload)); err == nil {
+		payload = compacted.String()
+	} else {
+		payload = strings.Join(strings.Fields(payload), " ")
+	}
+	if runes := []rune(payload); len(runes) > payloadPreviewLength {
+		return string(runes[:payloadPreviewLength]) + "…"
+	}
+	return payload
+}
@@ -0,0 +1,412 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// stackResourceType is the resource type of the events a stack reports about itself
+const stackResourceType = "AWS::CloudFormation::Stack"
+
+// stackCompleteStyle colors the statuses of operations that completed
+var stackCompleteStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(constants.ColorSuccess))
+
+// getStacksColumns returns the columns of the stack list
+func getStacksColumns() []table.Column {
+	return []table.Column{
+		{Title: "Stack", Width: constants.TableWideWidth},
+		{Title: "Status", Width: constants.TableNarrowWidth + 8},
+		{Title: "Drift", Width: constants.TableCompactWidth},
+		{Title: "Last Updated", Width: constants.TableNarrowWidth},
+		{Title: "Description", Width: constants.TableDescWidth},
+	}
+}
+
+// getStacksRows returns a row for each stack, in the order of m.Stacks
+func getStacksRows(m *model.Model) []table.Row {
+	rows := make([]table.Row, 0, len(m.Stacks))
+	for _, stack := range m.Stacks {
+		rows = append(rows, table.Row{
+			stack.Name,
+			stack.Status,
+			stack.DriftStatus,
+			formatTimestamp(stackLastChanged(stack)),
+			stack.Description,
+		})
+	}
+	return rows
+}
+
+// getStackDetailsRows returns the properties of the selected stack, its outputs and parameters,
+// ending with its actions
+func getStackDetailsRows(m *model.Model) []table.Row {
+	stack := m.SelectedStack
+	if stack == nil {
+		return []table.Row{}
+	}
+
+	drift := stack.DriftStatus
+	if !stack.DriftChecked.IsZero() {
+		drift += ", checked " + formatTimestamp(stack.DriftChecked)
+	}
+	rows := []table.Row{
+		{"Stack", stack.Name},
+		{"Status", stack.Status},
+		{"Status Reason", valueOrNone(stack.StatusReason)},
+		{"Description", valueOrNone(stack.Description)},
+		{"Created", formatTimestamp(stack.CreationTime)},
+		{"Last Updated", formatTimestamp(stack.LastUpdated)},
+		{"Drift", drift},
+		{"Stack ID", stack.ID},
+	}
+	for _, output := range stack.Outputs {
+		value := output.Value
+		if output.ExportName != "" {
+			value += fmt.Sprintf(" (exported as %s)", output.ExportName)
+		}
+		rows = append(rows, table.Row{constants.OutputRowPrefix + output.Key, value})
+	}
+	for _, parameter := range stack.Parameters {
+		value := parameter.Value
+		if parameter.ResolvedValue != "" {
+			value += " → " + parameter.ResolvedValue
+		}
+		rows = append(rows, table.Row{constants.ParameterRowPrefix + parameter.Key, value})
+	}
+	return append(rows,
+		table.Row{constants.ActionStackEvents, ""},
+		table.Row{constants.ActionDetectDrift, ""},
+		table.Row{constants.ActionChangeSets, ""},
+	)
+}
+
+// StackEventsContent returns the events of a stack as shown in the timeline: a line for each
+// event colored by its status, followed by its reason wrapped to the width. The first failure of
+// the stack's latest operation is marked, and the line it's on returned, or -1 without one.
+func StackEventsContent(stack string, events []cloud.StackEvent, width int) (string, int) {
+	if len(events) == 0 {
+		return "(no events)", -1
+	}
+
+	statusWidth := 0
+	for _, event := range events {
+		statusWidth = max(statusWidth, len(event.Status))
+	}
+
+	failure := firstStackFailure(stack, events)
+	failureLine := -1
+	var lines []string
+	for i, event := range events {
+		style := stackStatusStyle(event.Status)
+		marker := "  "
+		if i == failure {
+			style = errorMarkStyle
+			marker = "▶ "
+			failureLine = len(lines)
+		}
+
+		lines = append(lines, marker+style.Render(fmt.Sprintf("%s  %-*s  %s (%s)",
+			formatTimestamp(event.Timestamp), statusWidth, event.Status, event.LogicalID, event.ResourceType)))
+		if event.Reason == "" {
+			continue
+		}
+		reason := lipgloss.NewStyle().Width(max(constants.TableDescWidth, width-6)).Render(event.Reason)
+		for _, line := range strings.Split(reason, "\n") {
+			lines = append(lines, "    "+style.Render(strings.TrimRight(line, " ")))
+		}
+	}
+	return strings.Join(lines, "\n"), failureLine
+}
+
+// renderStackEvents renders the event timeline of the selected stack
+func renderStackEvents(m *model.Model) string {
+	if m.SelectedStack == nil {
+		return ""
+	}
+	return renderFileViewer(m, m.SelectedStack.Name+" events")
+}
+
+// getStackDriftColumns returns the columns of the drifted resources
+func getStackDriftColumns() []table.Column {
+	return []table.Column{
+		{Title: "Resource", Width: constants.TableWideWidth},
+		{Title: "Type", Width: constants.TableWideWidth},
+		{Title: "Drift", Width: constants.TableCompactWidth},
+		{Title: "Differences", Width: constants.TableCompactWidth},
+	}
+}
+
+// getStackDriftRows returns a row for each drifted resource, in the order of m.StackDrifts
+func getStackDriftRows(m *model.Model) []table.Row {
+	rows := make([]table.Row, 0, len(m.StackDrifts))
+	for _, drift := range m.StackDrifts {
+		differences := "-"
+		if len(drift.Differences) > 0 {
+			differences = fmt.Sprint(len(drift.Differences))
+		}
+		rows = append(rows, table.Row{drift.LogicalID, drift.ResourceType, drift.Status, differences})
+	}
+	return rows
+}
+
+// getResourceDriftColumns returns the columns of the property differences of a drifted resource
+func getResourceDriftColumns() []table.Column {
+	return []table.Column{
+		{Title: "Property", Width: constants.TableWideWidth},
+		{Title: "Difference", Width: constants.TableCompactWidth},
+		{Title: "Expected", Width: constants.TableDescWidth},
+		{Title: "Actual", Width: constants.TableDescWidth},
+	}
+}
+
+// getResourceDriftRows returns a row for each property of the selected resource that drifted
+func getResourceDriftRows(m *model.Model) []table.Row {
+	drift := m.SelectedResourceDrift
+	if drift == nil {
+		return []table.Row{}
+	}
+
+	rows := make([]table.Row, 0, len(drift.Differences))
+	for _, difference := range drift.Differences {
+		rows = append(rows, table.Row{
+			difference.Path,
+			difference.Type,
+			valueOrNone(difference.Expected),
+			valueOrNone(difference.Actual),
+		})
+	}
+	return rows
+}
+
+// getChangeSetsColumns returns the columns of the change set list
+func getChangeSetsColumns() []table.Column {
+	return []table.Column{
+		{Title: "Change Set", Width: constants.TableWideWidth},
+		{Title: "Status", Width: constants.TableNarrowWidth},
+		{Title: "Execution", Width: constants.TableNarrowWidth},
+		{Title: "Created", Width: constants.TableNarrowWidth},
+		{Title: "Description", Width: constants.TableDescWidth},
+	}
+}
+
+// getChangeSetsRows returns a row for each change set, in the order of m.ChangeSets
+func getChangeSetsRows(m *model.Model) []table.Row {
+	rows := make([]table.Row, 0, len(m.ChangeSets))
+	for _, changeSet := range m.ChangeSets {
+		rows = append(rows, table.Row{
+			changeSet.Name,
+			changeSet.Status,
+			changeSet.ExecutionStatus,
+			formatTimestamp(changeSet.CreationTime),
+			changeSet.Description,
+		})
+	}
+	return rows
+}
+
+// getChangeSetDetailsColumns returns the columns of the resource changes of a change set
+func getChangeSetDetailsColumns() []table.Column {
+	return []table.Column{
+		{Title: "Action", Width: constants.TableNarrowWidth},
+		{Title: "Resource", Width: constants.TableWideWidth},
+		{Title: "Type", Width: constants.TableWideWidth},
+		{Title: "Replacement", Width: constants.TableCompactWidth},
+		{Title: "Scope", Width: constants.TableNarrowWidth},
+	}
+}
+
+// getChangeSetDetailsRows returns a row for each resource change of the selected change set,
+// followed by the action executing it when it can be
+func getChangeSetDetailsRows(m *model.Model) []table.Row {
+	changeSet := m.SelectedChangeSet
+	if changeSet == nil {
+		return []table.Row{}
+	}
+
+	rows := make([]table.Row, 0, len(changeSet.Changes)+1)
+	for _, change := range changeSet.Changes {
+		replacement := change.Replacement
+		if replacement == "" {
+			replacement = "-"
+		}
+		rows = append(rows, table.Row{
+			change.Action,
+			change.LogicalID,
+			change.ResourceType,
+			replacement,
+			valueOrNone(strings.Join(change.Scope, ", ")),
+		})
+	}
+	if changeSet.Executable() {
+		rows = append(rows, table.Row{constants.ActionExecuteChangeSet, "", "", "", ""})
+	}
+	return rows
+}
+
+// ChangeSetSummary returns how many resources a change set changes, by action, e.g. 3 (1 Add,
+// 2 Modify)
+func ChangeSetSummary(changes []cloud.ResourceChange) string {
+	if len(changes) == 0 {
+		return "0"
+	}
+
+	// Actions are counted in the order they first appear
+	var actions []string
+	counts := make(map[string]int)
+	for _, change := range changes {
+		if counts[change.Action] == 0 {
+			actions = append(actions, change.Action)
+		}
+		counts[change.Action]++
+	}
+
+	parts := make([]string, 0, len(actions))
+	for _, action := range actions {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[action], action))
+	}
+	return fmt.Sprintf("%d (%s)", len(changes), strings.Join(parts, ", "))
+}
+
+// ChangeSetReplacementWarning returns a warning naming the resources a change set replaces, or
+// may replace, or an empty string when it replaces none
+func ChangeSetReplacementWarning(changes []cloud.ResourceChange) string {
+	var replaced []string
+	for _, change := range changes {
+		switch change.Replacement {
+		case "True":
+			replaced = append(replaced, change.LogicalID)
+		case "Conditional":
+			replaced = append(replaced, change.LogicalID+" (conditional)")
+		}
+	}
+	if len(replaced) == 0 {
+		return ""
+	}
+	return logWarningStyle.Render("Replaces " + strings.Join(replaced, ", "))
+}
+
+// getCloudFormationContextText returns the context text for the CloudFormation views
+func getCloudFormationContextText(m *model.Model) string {
+	context := fmt.Sprintf("Profile: %s\nRegion: %s", m.AwsProfile, m.AwsRegion)
+	if m.CurrentView == constants.ViewStacks {
+		return context + fmt.Sprintf("\nStacks: %d", len(m.Stacks))
+	}
+	stack := m.SelectedStack
+	if stack == nil {
+		return context
+	}
+	context += fmt.Sprintf("\nStack: %s\nStatus: %s", stack.Name, stack.Status)
+
+	switch m.CurrentView {
+	case constants.ViewStackDetails:
+		if stackStatusFailed(stack.Status) && stack.StatusReason != "" {
+			context += "\n" + logWarningStyle.Render(stack.StatusReason)
+		}
+	case constants.ViewStackEvents:
+		context += fmt.Sprintf("\nEvents: %d", len(m.StackEvents))
+		if failure := firstStackFailure(stack.Name, m.StackEvents); failure >= 0 {
+			event := m.StackEvents[failure]
+			context += "\n" + logWarningStyle.Render(fmt.Sprintf("First failure: %s (%s): %s",
+				event.LogicalID, event.Status, valueOrNone(event.Reason)))
+		}
+	case constants.ViewStackDrift, constants.ViewResourceDrift:
+		detection := m.StackDriftDetection
+		if detection == nil {
+			break
+		}
+		context += fmt.Sprintf("\nDrift: %s\nDrifted Resources: %d", valueOrNone(detection.StackDriftStatus), len(m.StackDrifts))
+		if detection.Status == cloud.DriftDetectionFailed {
+			context += "\n" + logWarningStyle.Render("Detection failed for some resources: "+valueOrNone(detection.Reason))
+		}
+		if m.CurrentView == constants.ViewResourceDrift && m.SelectedResourceDrift != nil {
+			context += fmt.Sprintf("\nResource: %s (%s)", m.SelectedResourceDrift.LogicalID, m.SelectedResourceDrift.PhysicalID)
+		}
+	case constants.ViewChangeSets:
+		context += fmt.Sprintf("\nChange Sets: %d", len(m.ChangeSets))
+	case constants.ViewChangeSetDetails:
+		changeSet := m.SelectedChangeSet
+		if changeSet == nil {
+			break
+		}
+		context += fmt.Sprintf("\nChange Set: %s\nChanges: %s", changeSet.Name, ChangeSetSummary(changeSet.Changes))
+		if !changeSet.Executable() {
+			context += "\n" + logWarningStyle.Render(fmt.Sprintf("Can't be executed (%s): %s",
+				changeSet.ExecutionStatus, valueOrNone(changeSet.StatusReason)))
+		} else if warning := ChangeSetReplacementWarning(changeSet.Changes); warning != "" {
+			context += "\n" + warning
+		}
+	}
+
+	return context
+}
+
+// firstStackFailure returns the index of the event that first failed in the stack's latest
+// operation, passing over resources whose changes were only cancelled because of it, or -1
+// when nothing failed. Events are newest first.
+func firstStackFailure(stack string, events []cloud.StackEvent) int {
+	start := len(events) - 1
+	for i, event := range events {
+		if event.LogicalID == stack && event.ResourceType == stackResourceType && stackOperationStarted(event.Status) {
+			start = i
+			break
+		}
+	}
+
+	failure := -1
+	for i := start; i >= 0; i-- {
+		if !events[i].Failed() {
+			continue
+		}
+		if !strings.HasSuffix(strings.ToLower(events[i].Reason), "cancelled") {
+			return i
+		}
+		if failure < 0 {
+			failure = i
+		}
+	}
+	return failure
+}
+
+// stackOperationStarted returns whether a status of the stack itself starts an operation on it
+func stackOperationStarted(status string) bool {
+	switch status {
+	case "CREATE_IN_PROGRESS", "UPDATE_IN_PROGRESS", "DELETE_IN_PROGRESS", "IMPORT_IN_PROGRESS":
+		return true
+	}
+	return false
+}
+
+// stackStatusFailed returns whether a stack or resource status reports a failure or rollback
+func stackStatusFailed(status string) bool {
+	return strings.HasSuffix(status, "_FAILED") || strings.Contains(status, "ROLLBACK")
+}
+
+// stackStatusStyle returns the style of a stack or resource status in the event timeline
+func stackStatusStyle(status string) lipgloss.Style {
+	switch {
+	case stackStatusFailed(status):
+		return logErrorStyle
+	case strings.HasSuffix(status, "_IN_PROGRESS"):
+		return logWarningStyle
+	case strings.HasSuffix(status, "_COMPLETE"):
+		return stackCompleteStyle
+	default:
+		return logRequestStyle
+	}
+}
+
+// stackLastChanged returns when a stack was last updated, or created if it never was
+func stackLastChanged(stack cloud.Stack) time.Time {
+	if stack.LastUpdated.IsZero() {
+		return stack.CreationTime
+	}
+	return stack.LastUpdated
+}
@@ -0,0 +1,93 @@
+package view
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+)
+
+// stackEvent returns an event of the api stack, with its own type for events of the stack itself
+func stackEvent(logicalID, status, reason string) cloud.StackEvent {
+	resourceType := "AWS::Lambda::Function"
+	if logicalID == "api" {
+		resourceType = stackResourceType
+	}
+	return cloud.StackEvent{LogicalID: logicalID, ResourceType: resourceType, Status: status, Reason: reason}
+}
+
+func TestFirstStackFailure(t *testing.T) {
+	testCases := []struct {
+		name   string
+		events []cloud.StackEvent // Newest first
+		want   int
+	}{
+		{
+			name: "Cancelled changes are passed over",
+			events: []cloud.StackEvent{
+				stackEvent("api", "UPDATE_ROLLBACK_IN_PROGRESS", "The following resource(s) failed to update: [Handler]"),
+				stackEvent("Queue", "UPDATE_FAILED", "Resource update cancelled"),
+				stackEvent("Handler", "UPDATE_FAILED", "Role is not authorized"),
+				stackEvent("Handler", "UPDATE_IN_PROGRESS", ""),
+				stackEvent("api", "UPDATE_IN_PROGRESS", "User Initiated"),
+			},
+			want: 2,
+		},
+		{
+			name: "Failures of earlier operations are ignored",
+			events: []cloud.StackEvent{
+				stackEvent("api", "UPDATE_COMPLETE", ""),
+				stackEvent("Handler", "UPDATE_COMPLETE", ""),
+				stackEvent("api", "UPDATE_IN_PROGRESS", "User Initiated"),
+				stackEvent("Handler", "CREATE_FAILED", "Bucket already exists"),
+				stackEvent("api", "CREATE_IN_PROGRESS", "User Initiated"),
+			},
+			want: -1,
+		},
+		{
+			name: "Only cancelled changes",
+			events: []cloud.StackEvent{
+				stackEvent("Queue", "UPDATE_FAILED", "Resource update cancelled"),
+				stackEvent("Handler", "UPDATE_FAILED", "Resource update cancelled"),
+			},
+			want: 1,
+		},
+		{
+			name:   "No events",
+			events: nil,
+			want:   -1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := firstStackFailure("api", tc.events); got != tc.want {
+				t.Errorf("Expected event %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestStackEventsContent(t *testing.T) {
+	events := []cloud.StackEvent{
+		stackEvent("api", "UPDATE_ROLLBACK_IN_PROGRESS", "The following resource(s) failed to update: [Handler]"),
+		stackEvent("Handler", "UPDATE_FAILED", "Role is not authorized"),
+		stackEvent("api", "UPDATE_IN_PROGRESS", ""),
+	}
+
+	content, failureLine := StackEventsContent("api", events, 120)
+	lines := strings.Split(content, "\n")
+	if len(lines) != 5 {
+		t.Fatalf("Expected a line for each event and each reason, got %q", lines)
+	}
+	if failureLine != 2 || !strings.HasPrefix(lines[failureLine], "▶ ") || !strings.Contains(lines[failureLine], "Handler") {
+		t.Errorf("Expected the failure of Handler to be marked on line 2, got line %d: %q", failureLine, lines)
+	}
+	if strings.TrimSpace(lines[3]) != "Role is not authorized" {
+		t.Errorf("Expected the reason below the failure, got %q", lines[3])
+	}
+
+	if content, failureLine := StackEventsContent("api", nil, 120); content != "(no events)" || failureLine != -1 {
+		t.Errorf("Expected a note for no events, got %q at %d", content, failureLine)
+	}
+}
@@ -0,0 +1,217 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// getBuildProjectsColumns returns the columns of the project list
+func getBuildProjectsColumns() []table.Column {
+	return []table.Column{
+		{Title: "Project", Width: constants.TableWideWidth},
+		{Title: "Source", Width: constants.TableCompactWidth + 4},
+		{Title: "Description", Width: constants.TableDescWidth},
+		{Title: "Last Modified", Width: constants.TableNarrowWidth},
+	}
+}
+
+// getBuildProjectsRows returns a row for each project, in the order of m.BuildProjects
+func getBuildProjectsRows(m *model.Model) []table.Row {
+	rows := make([]table.Row, 0, len(m.BuildProjects))
+	for _, project := range m.BuildProjects {
+		rows = append(rows, table.Row{
+			project.Name,
+			project.SourceType,
+			project.Description,
+			formatTimestamp(project.LastModified),
+		})
+	}
+	return rows
+}
+
+// getBuildsColumns returns the columns of the build list
+func getBuildsColumns() []table.Column {
+	return []table.Column{
+		{Title: "Build", Width: constants.TableCompactWidth},
+		{Title: "Status", Width: constants.TableCompactWidth + 4},
+		{Title: "Source Version", Width: constants.TableNarrowWidth},
+		{Title: "Duration", Width: constants.TableCompactWidth},
+		{Title: "Initiator", Width: constants.TableWideWidth},
+		{Title: "Started", Width: constants.TableNarrowWidth},
+	}
+}
+
+// getBuildsRows returns the row starting a new build followed by a row for each build, in the
+// order of m.Builds
+func getBuildsRows(m *model.Model) []table.Row {
+	rows := make([]table.Row, 0, len(m.Builds)+1)
+	rows = append(rows, table.Row{constants.ActionStartBuild, "", "", "", "", ""})
+	for _, build := range m.Builds {
+		rows = append(rows, table.Row{
+			fmt.Sprintf("#%d", build.Number),
+			buildStatusLabel(build),
+			buildSourceVersion(build),
+			formatBuildDuration(build.Duration()),
+			build.Initiator,
+			formatTimestamp(build.StartTime),
+		})
+	}
+	return rows
+}
+
+// getStartBuildRows returns the rows of the start build form: the source version, the
+// environment variables overridden and the action starting the build
+func getStartBuildRows(m *model.Model) []table.Row {
+	sourceVersion := m.BuildStart.SourceVersion
+	if sourceVersion == "" {
+		sourceVersion = "Project default"
+		if m.BuildProject != nil && m.BuildProject.SourceVersion != "" {
+			sourceVersion = fmt.Sprintf("Project default (%s)", m.BuildProject.SourceVersion)
+		}
+	}
+
+	rows := []table.Row{{constants.SettingSourceVersion, sourceVersion}}
+	for _, variable := range m.BuildStart.Variables {
+		rows = append(rows, table.Row{constants.VariableRowPrefix + variable.Name, variable.Value})
+	}
+	return append(rows,
+		table.Row{constants.SettingAddVariable, ""},
+		table.Row{constants.ActionStartBuild, ""},
+	)
+}
+
+// getBuildDetailsRows returns the properties of the selected build and its phases, ending with
+// the action showing its log
+func getBuildDetailsRows(m *model.Model) []table.Row {
+	build := m.SelectedBuild
+	if build == nil {
+		return []table.Row{}
+	}
+
+	rows := []table.Row{
+		{"Build", fmt.Sprintf("%s #%d", build.Project, build.Number)},
+		{"Status", buildStatusLabel(*build)},
+		{"Source Version", buildSourceVersion(*build)},
+		{"Resolved Commit", valueOrNone(build.ResolvedSourceVersion)},
+		{"Initiator", valueOrNone(build.Initiator)},
+		{"Started", formatTimestamp(build.StartTime)},
+		{"Ended", formatTimestamp(build.EndTime)},
+		{"Duration", formatBuildDuration(build.Duration())},
+		{"Build ID", build.ID},
+	}
+	for _, phase := range build.Phases {
+		rows = append(rows, table.Row{"Phase: " + phase.Type, phaseSummary(phase)})
+	}
+	return append(rows, table.Row{constants.ActionBuildLogs, ""})
+}
+
+// getBuildLogsColumns returns the columns of the build log, the message filling the window
+func getBuildLogsColumns(m *model.Model) []table.Column {
+	timeWidth := len(time.DateTime)
+	return []table.Column{
+		{Title: "Time", Width: timeWidth},
+		{Title: "Message", Width: max(constants.TableDescWidth, m.Width-constants.ViewportMarginX*2-(timeWidth+2)-2)},
+	}
+}
+
+// getBuildLogsRows returns a row for each line of the build log read so far
+func getBuildLogsRows(m *model.Model) []table.Row {
+	rows := make([]table.Row, 0, len(m.BuildLogs))
+	for _, event := range m.BuildLogs {
+		rows = append(rows, table.Row{formatTimestamp(event.Timestamp), strings.TrimRight(event.Message, "\r\n")})
+	}
+	return rows
+}
+
+// buildStatusLabel returns the status of a build, with the phase running builds are in
+func buildStatusLabel(build cloud.Build) string {
+	if build.Status == cloud.BuildStatusInProgress && build.CurrentPhase != "" {
+		return fmt.Sprintf("%s (%s)", build.Status, build.CurrentPhase)
+	}
+	return build.Status
+}
+
+// buildSourceVersion returns the source version a build was asked for, or the commit it resolved
+// to when it built the project's default
+func buildSourceVersion(build cloud.Build) string {
+	if build.SourceVersion != "" {
+		return build.SourceVersion
+	}
+	return valueOrNone(build.ResolvedSourceVersion)
+}
+
+// phaseSummary returns the status, duration and failure message of a build phase on a single line
+func phaseSummary(phase cloud.BuildPhase) string {
+	status := phase.Status
+	if status == "" {
+		status = cloud.BuildStatusInProgress
+	}
+	parts := []string{status}
+	if phase.Duration > 0 {
+		parts = append(parts, formatBuildDuration(phase.Duration))
+	}
+	if phase.Message != "" {
+		parts = append(parts, phase.Message)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatBuildDuration formats how long a build or phase ran to the second, e.g. 4m05s
+func formatBuildDuration(duration time.Duration) string {
+	duration = duration.Round(time.Second)
+	switch {
+	case duration <= 0:
+		return "-"
+	case duration < time.Minute:
+		return fmt.Sprintf("%ds", duration/time.Second)
+	case duration < time.Hour:
+		return fmt.Sprintf("%dm%02ds", duration/time.Minute, duration%time.Minute/time.Second)
+	default:
+		return fmt.Sprintf("%dh%02dm", duration/time.Hour, duration%time.Hour/time.Minute)
+	}
+}
+
+// getCodeBuildContextText returns the context text for the CodeBuild views
+func getCodeBuildContextText(m *model.Model) string {
+	context := fmt.Sprintf("Profile: %s\nRegion: %s", m.AwsProfile, m.AwsRegion)
+
+	switch m.CurrentView {
+	case constants.ViewBuildProjects:
+		context += fmt.Sprintf("\nProjects: %d", len(m.BuildProjects))
+	case constants.ViewBuilds, constants.ViewStartBuild:
+		if m.BuildProject == nil {
+			break
+		}
+		context += fmt.Sprintf("\nProject: %s", m.BuildProject.Name)
+		if m.BuildProject.SourceLocation != "" {
+			context += fmt.Sprintf("\nSource: %s", m.BuildProject.SourceLocation)
+		}
+		if m.CurrentView == constants.ViewBuilds {
+			context += fmt.Sprintf("\nRecent Builds: %d", len(m.Builds))
+		} else if m.ManualInput {
+			context += fmt.Sprintf("\n\nEditing: %s", m.BuildInputField)
+		}
+	case constants.ViewBuildDetails, constants.ViewBuildLogs:
+		build := m.SelectedBuild
+		if build == nil {
+			break
+		}
+		context += fmt.Sprintf("\nBuild: %s #%d\nStatus: %s\nDuration: %s",
+			build.Project, build.Number, buildStatusLabel(*build), formatBuildDuration(build.Duration()))
+		if m.CurrentView == constants.ViewBuildLogs {
+			context += fmt.Sprintf("\nLines: %d", len(m.BuildLogs))
+			if m.BuildFollowID != "" {
+				context += "\nFollowing the log as it's written"
+			}
+		}
+	}
+
+	return context
+}
@@ -0,0 +1,91 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// compareDiffMarker marks the settings the targets disagree on
+const compareDiffMarker = "≠ "
+
+// getCompareOptionsRows returns the rows of the compare options form
+func getCompareOptionsRows(m *model.Model) []table.Row {
+	targets := make([]string, len(m.CompareOptions.Targets))
+	for i, target := range m.CompareOptions.Targets {
+		targets[i] = target.String()
+	}
+
+	envValues := constants.EnvValuesKeysOnly
+	if m.CompareOptions.HashEnvValues {
+		envValues = constants.EnvValuesHashed
+	}
+
+	return []table.Row{
+		{constants.SettingTargets, strings.Join(targets, ", ")},
+		{constants.SettingEnvValues, envValues},
+		{constants.SettingRunCompare, ""},
+	}
+}
+
+// getFunctionCompareColumns returns a column for the setting and one for each target, narrower
+// when there are more targets so the comparison still fits
+func getFunctionCompareColumns(m *model.Model) []table.Column {
+	if m.FunctionComparison == nil {
+		return []table.Column{}
+	}
+
+	width := constants.TableDefaultWidth
+	if len(m.FunctionComparison.Snapshots) > 2 {
+		width = constants.TableNarrowWidth
+	}
+	columns := []table.Column{{Title: "Setting", Width: constants.TableDefaultWidth}}
+	for _, snapshot := range m.FunctionComparison.Snapshots {
+		columns = append(columns, table.Column{Title: snapshot.Target.String(), Width: width})
+	}
+	return columns
+}
+
+// getFunctionCompareRows returns a row for each compared setting, with the settings the
+// targets disagree on marked
+func getFunctionCompareRows(m *model.Model) []table.Row {
+	if m.FunctionComparison == nil {
+		return []table.Row{}
+	}
+
+	rows := make([]table.Row, 0, len(m.FunctionComparison.Fields))
+	for _, field := range m.FunctionComparison.Fields {
+		name := "  " + field.Name
+		if field.Differs {
+			name = compareDiffMarker + field.Name
+		}
+		rows = append(rows, append(table.Row{name}, field.Values...))
+	}
+	return rows
+}
+
+// getCompareContextText returns the context text for the compare views
+func getCompareContextText(m *model.Model) string {
+	context := fmt.Sprintf("Profile: %s\nRegion: %s", m.AwsProfile, m.AwsRegion)
+	if m.SelectedFunction != nil {
+		context += "\nFunction: " + m.SelectedFunction.Name
+	}
+
+	comparison := m.FunctionComparison
+	if m.CurrentView != constants.ViewFunctionCompare || comparison == nil {
+		return context
+	}
+
+	context += fmt.Sprintf("\nTargets: %d\nDifferences: %d of %d settings",
+		len(comparison.Snapshots), comparison.Differences(), len(comparison.Fields))
+	for _, snapshot := range comparison.Snapshots {
+		if snapshot.Error != "" {
+			context += "\n" + logWarningStyle.Render(snapshot.Target.String()+": "+snapshot.Error)
+		}
+	}
+	return context
+}
@@ -0,0 +1,182 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// statusImpaired is the status check result of an instance failing its checks
+const statusImpaired = "impaired"
+
+// getEC2FilterRows returns the rows of the instance filter form
+func getEC2FilterRows(m *model.Model) []table.Row {
+	state := m.EC2Filter.State
+	if state == "" {
+		state = constants.FilterAllStates
+	}
+	return []table.Row{
+		{constants.SettingInstanceState, state},
+		{constants.SettingInstanceTag, valueOrNone(m.EC2Filter.Tag())},
+		{constants.SettingListInstances, ""},
+	}
+}
+
+// getEC2InstancesColumns returns the columns of the instance list
+func getEC2InstancesColumns() []table.Column {
+	return []table.Column{
+		{Title: "Name", Width: constants.TableDefaultWidth},
+		{Title: "Instance ID", Width: constants.TableNarrowWidth},
+		{Title: "State", Width: constants.TableCompactWidth},
+		{Title: "Type", Width: constants.TableCompactWidth},
+		{Title: "AZ", Width: constants.TableCompactWidth},
+		{Title: "Private IP", Width: constants.TableCompactWidth + 4},
+		{Title: "Public IP", Width: constants.TableCompactWidth + 4},
+		{Title: "Launched", Width: constants.TableNarrowWidth},
+	}
+}
+
+// getEC2InstancesRows returns a row for each instance, in the order of m.EC2Instances
+func getEC2InstancesRows(m *model.Model) []table.Row {
+	rows := make([]table.Row, 0, len(m.EC2Instances))
+	for _, instance := range m.EC2Instances {
+		rows = append(rows, table.Row{
+			valueOrNone(instance.Name),
+			instance.ID,
+			instance.State,
+			instance.Type,
+			instance.AvailabilityZone,
+			valueOrNone(instance.PrivateIP),
+			valueOrNone(instance.PublicIP),
+			formatTimestamp(instance.LaunchTime),
+		})
+	}
+	return rows
+}
+
+// getEC2InstanceDetailsRows returns the properties of an instance, its security groups, volumes
+// and tags, ending with the actions its state allows
+func getEC2InstanceDetailsRows(m *model.Model) []table.Row {
+	details := m.EC2InstanceDetails
+	if details == nil {
+		return []table.Row{}
+	}
+
+	state := details.State
+	if details.StateReason != "" {
+		state = fmt.Sprintf("%s (%s)", details.State, details.StateReason)
+	}
+
+	rows := []table.Row{
+		{"Instance ID", details.ID},
+		{"Name", valueOrNone(details.Name)},
+		{"State", state},
+		{"Type", details.Type},
+		{"Availability Zone", details.AvailabilityZone},
+		{"Private IP", valueOrNone(details.PrivateIP)},
+		{"Public IP", valueOrNone(details.PublicIP)},
+		{"Launched", formatTimestamp(details.LaunchTime)},
+		{"Image", details.ImageID},
+		{"Platform", valueOrNone(details.Platform)},
+		{"Architecture", valueOrNone(details.Architecture)},
+		{"Key Pair", valueOrNone(details.KeyName)},
+		{"VPC", valueOrNone(details.VpcID)},
+		{"Subnet", valueOrNone(details.SubnetID)},
+		{"Instance Profile", valueOrNone(details.InstanceProfile)},
+		{"System Status", details.SystemStatus},
+		{"Instance Status", details.InstanceStatus},
+	}
+
+	if len(details.SecurityGroups) == 0 {
+		rows = append(rows, table.Row{"Security Groups", "none"})
+	}
+	for _, group := range details.SecurityGroups {
+		rows = append(rows, table.Row{"Security Group: " + group.ID, group.Name})
+	}
+
+	if len(details.Volumes) == 0 {
+		rows = append(rows, table.Row{"Volumes", "none"})
+	}
+	for _, volume := range details.Volumes {
+		rows = append(rows, table.Row{"Volume: " + volume.Device, volumeSummary(volume)})
+	}
+
+	for _, key := range sortedKeys(details.Tags) {
+		rows = append(rows, table.Row{"Tag: " + key, details.Tags[key]})
+	}
+
+	for _, action := range ec2InstanceActions(details.State) {
+		rows = append(rows, table.Row{action, ""})
+	}
+	return rows
+}
+
+// EC2StopWarnings returns the warnings shown before stopping an instance
+func EC2StopWarnings(details *cloud.EC2InstanceDetails) []string {
+	var warnings []string
+	if details.PublicIP != "" {
+		warnings = append(warnings, logWarningStyle.Render(
+			fmt.Sprintf("The public IP %s is released on stop unless it's an Elastic IP", details.PublicIP)))
+	}
+	return warnings
+}
+
+// ec2InstanceActions returns the actions an instance in a state can take: running instances can
+// be stopped or rebooted and stopped instances started, while others are between states
+func ec2InstanceActions(state string) []string {
+	switch state {
+	case cloud.EC2StateRunning:
+		return []string{constants.ActionStopInstance, constants.ActionRebootInstance}
+	case cloud.EC2StateStopped:
+		return []string{constants.ActionStartInstance}
+	default:
+		return nil
+	}
+}
+
+// volumeSummary returns the ID, size, type and state of a volume on a single line
+func volumeSummary(volume cloud.EC2Volume) string {
+	parts := []string{volume.ID, fmt.Sprintf("%d GiB", volume.Size), volume.Type, volume.State}
+	if volume.Encrypted {
+		parts = append(parts, "encrypted")
+	}
+	if volume.DeleteOnTermination {
+		parts = append(parts, "deleted on termination")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// getEC2ContextText returns the context text for the EC2 instance views
+func getEC2ContextText(m *model.Model) string {
+	context := fmt.Sprintf("Profile: %s\nRegion: %s", m.AwsProfile, m.AwsRegion)
+
+	switch m.CurrentView {
+	case constants.ViewEC2Filters:
+		if m.ManualInput {
+			context += fmt.Sprintf("\n\nEditing: %s", constants.SettingInstanceTag)
+		}
+	case constants.ViewEC2Instances:
+		filter := m.EC2Filter
+		state := filter.State
+		if state == "" {
+			state = constants.FilterAllStates
+		}
+		context += fmt.Sprintf("\nState: %s\nTag: %s\nInstances: %d", state, valueOrNone(filter.Tag()), len(m.EC2Instances))
+	case constants.ViewEC2InstanceDetails:
+		details := m.EC2InstanceDetails
+		if details == nil {
+			break
+		}
+		context += fmt.Sprintf("\nInstance: %s\nState: %s", details.ID, details.State)
+		if details.SystemStatus == statusImpaired || details.InstanceStatus == statusImpaired {
+			context += "\n" + logWarningStyle.Render("Status checks are failing")
+		}
+	}
+
+	return context
+}
@@ -0,0 +1,257 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// getECSClustersColumns returns the columns of the cluster list
+func getECSClustersColumns() []table.Column {
+	return []table.Column{
+		{Title: "Cluster", Width: constants.TableWideWidth},
+		{Title: "Status", Width: constants.TableCompactWidth},
+		{Title: "Services", Width: constants.TableCompactWidth},
+		{Title: "Running", Width: constants.TableCompactWidth},
+		{Title: "Pending", Width: constants.TableCompactWidth},
+	}
+}
+
+// getECSClustersRows returns a row for each cluster, in the order of m.ECSClusters
+func getECSClustersRows(m *model.Model) []table.Row {
+	rows := make([]table.Row, 0, len(m.ECSClusters))
+	for _, cluster := range m.ECSClusters {
+		rows = append(rows, table.Row{
+			cluster.Name,
+			cluster.Status,
+			fmt.Sprintf("%d", cluster.ActiveServices),
+			fmt.Sprintf("%d", cluster.RunningTasks),
+			fmt.Sprintf("%d", cluster.PendingTasks),
+		})
+	}
+	return rows
+}
+
+// getECSServicesColumns returns the columns of the service list
+func getECSServicesColumns() []table.Column {
+	return []table.Column{
+		{Title: "Service", Width: constants.TableWideWidth},
+		{Title: "Desired", Width: constants.TableCompactWidth},
+		{Title: "Running", Width: constants.TableCompactWidth},
+		{Title: "Pending", Width: constants.TableCompactWidth},
+		{Title: "Rollout", Width: constants.TableNarrowWidth},
+		{Title: "Task Definition", Width: constants.TableWideWidth},
+	}
+}
+
+// getECSServicesRows returns a row for each service, in the order of m.ECSServices
+func getECSServicesRows(m *model.Model) []table.Row {
+	rows := make([]table.Row, 0, len(m.ECSServices))
+	for _, service := range m.ECSServices {
+		rows = append(rows, table.Row{
+			service.Name,
+			fmt.Sprintf("%d", service.DesiredCount),
+			fmt.Sprintf("%d", service.RunningCount),
+			fmt.Sprintf("%d", service.PendingCount),
+			rolloutLabel(service.Rollout()),
+			service.TaskDefinition,
+		})
+	}
+	return rows
+}
+
+// getECSServiceDetailsRows returns the properties of the selected service, its deployments and
+// latest events, ending with its actions
+func getECSServiceDetailsRows(m *model.Model) []table.Row {
+	service := m.SelectedECSService
+	if service == nil {
+		return []table.Row{}
+	}
+
+	rows := []table.Row{
+		{"Name", service.Name},
+		{"Cluster", service.Cluster},
+		{"Status", service.Status},
+		{"Launch Type", valueOrNone(service.LaunchType)},
+		{"Task Definition", service.TaskDefinition},
+		{"Tasks", fmt.Sprintf("%d desired, %d running, %d pending", service.DesiredCount, service.RunningCount, service.PendingCount)},
+		{"Rollout", rolloutLabel(service.Rollout())},
+	}
+	if rollout := service.Rollout(); rollout != nil && rollout.RolloutStateReason != "" {
+		rows = append(rows, table.Row{"Rollout Reason", rollout.RolloutStateReason})
+	}
+
+	for _, deployment := range service.Deployments {
+		rows = append(rows, table.Row{"Deployment: " + deployment.Status, deploymentSummary(deployment)})
+	}
+
+	if len(service.Events) == 0 {
+		rows = append(rows, table.Row{"Events", "none"})
+	}
+	for _, event := range service.Events {
+		rows = append(rows, table.Row{"Event: " + formatTimestamp(event.CreatedAt), event.Message})
+	}
+
+	return append(rows,
+		table.Row{constants.ActionViewTasks, ""},
+		table.Row{constants.ActionForceDeployment, ""},
+		table.Row{constants.ActionScaleService, ""},
+	)
+}
+
+// getECSTasksColumns returns the columns of the task list
+func getECSTasksColumns() []table.Column {
+	return []table.Column{
+		{Title: "Task", Width: constants.TableWideWidth},
+		{Title: "Status", Width: constants.TableCompactWidth},
+		{Title: "Health", Width: constants.TableCompactWidth},
+		{Title: "Containers", Width: constants.TableNarrowWidth},
+		{Title: "Started", Width: constants.TableNarrowWidth},
+		{Title: "Stopped Reason", Width: constants.TableDescWidth},
+	}
+}
+
+// getECSTasksRows returns a row for each task, in the order of m.ECSTasks
+func getECSTasksRows(m *model.Model) []table.Row {
+	rows := make([]table.Row, 0, len(m.ECSTasks))
+	for _, task := range m.ECSTasks {
+		rows = append(rows, table.Row{
+			task.ID,
+			task.LastStatus,
+			task.HealthStatus,
+			containersSummary(task.Containers),
+			formatTimestamp(task.StartedAt),
+			task.StoppedReason,
+		})
+	}
+	return rows
+}
+
+// getECSTaskDetailsRows returns the properties of the selected task followed by its containers
+func getECSTaskDetailsRows(m *model.Model) []table.Row {
+	task := m.SelectedECSTask
+	if task == nil {
+		return []table.Row{}
+	}
+
+	rows := []table.Row{
+		{"Task ID", task.ID},
+		{"Task Definition", task.TaskDefinition},
+		{"Status", fmt.Sprintf("%s (desired %s)", task.LastStatus, task.DesiredStatus)},
+		{"Health", valueOrNone(task.HealthStatus)},
+		{"Started", formatTimestamp(task.StartedAt)},
+		{"Stopped", formatTimestamp(task.StoppedAt)},
+		{"Stop Code", valueOrNone(task.StopCode)},
+		{"Stopped Reason", valueOrNone(task.StoppedReason)},
+		{"ARN", task.ARN},
+	}
+	for _, container := range task.Containers {
+		rows = append(rows, table.Row{"Container: " + container.Name, containerSummary(container)})
+	}
+	return rows
+}
+
+// ECSScaleToZeroWarning returns the warning shown before scaling a service to no tasks
+func ECSScaleToZeroWarning() string {
+	return logWarningStyle.Render("Every task of the service will be stopped")
+}
+
+// rolloutLabel returns the rollout state of a deployment, or "-" for a service without one
+func rolloutLabel(deployment *cloud.ECSDeployment) string {
+	if deployment == nil || deployment.RolloutState == "" {
+		return "-"
+	}
+	if deployment.FailedTasks > 0 {
+		return fmt.Sprintf("%s (%d failed)", deployment.RolloutState, deployment.FailedTasks)
+	}
+	return deployment.RolloutState
+}
+
+// deploymentSummary returns the task definition, task counts and rollout state of a deployment on
+// a single line
+func deploymentSummary(deployment cloud.ECSDeployment) string {
+	parts := []string{
+		deployment.TaskDefinition,
+		fmt.Sprintf("%d/%d running", deployment.RunningCount, deployment.DesiredCount),
+	}
+	if deployment.PendingCount > 0 {
+		parts = append(parts, fmt.Sprintf("%d pending", deployment.PendingCount))
+	}
+	if deployment.FailedTasks > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", deployment.FailedTasks))
+	}
+	if deployment.RolloutState != "" {
+		parts = append(parts, deployment.RolloutState)
+	}
+	parts = append(parts, "created "+formatTimestamp(deployment.CreatedAt))
+	return strings.Join(parts, ", ")
+}
+
+// containersSummary returns how many containers of a task are running, such as "2/3 running"
+func containersSummary(containers []cloud.ECSContainer) string {
+	running := 0
+	for _, container := range containers {
+		if container.LastStatus == "RUNNING" {
+			running++
+		}
+	}
+	return fmt.Sprintf("%d/%d running", running, len(containers))
+}
+
+// containerSummary returns the status, health, exit code and image of a container on a single line
+func containerSummary(container cloud.ECSContainer) string {
+	parts := []string{container.LastStatus}
+	if container.HealthStatus != "" && container.HealthStatus != "UNKNOWN" {
+		parts = append(parts, container.HealthStatus)
+	}
+	if container.ExitCode != nil {
+		parts = append(parts, fmt.Sprintf("exit code %d", *container.ExitCode))
+	}
+	if container.Reason != "" {
+		parts = append(parts, container.Reason)
+	}
+	if container.Image != "" {
+		parts = append(parts, container.Image)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// getECSContextText returns the context text for the ECS views
+func getECSContextText(m *model.Model) string {
+	context := fmt.Sprintf("Profile: %s\nRegion: %s", m.AwsProfile, m.AwsRegion)
+
+	switch m.CurrentView {
+	case constants.ViewECSClusters:
+		context += fmt.Sprintf("\nClusters: %d", len(m.ECSClusters))
+	case constants.ViewECSServices:
+		context += fmt.Sprintf("\nCluster: %s\nServices: %d", m.ECSCluster, len(m.ECSServices))
+	case constants.ViewECSServiceDetails, constants.ViewECSTasks:
+		service := m.SelectedECSService
+		if service == nil {
+			break
+		}
+		context += fmt.Sprintf("\nCluster: %s\nService: %s", service.Cluster, service.Name)
+		if m.CurrentView == constants.ViewECSTasks {
+			context += fmt.Sprintf("\nTasks: %d", len(m.ECSTasks))
+		}
+		if m.ManualInput {
+			context += fmt.Sprintf("\n\nEditing: %s", constants.ActionScaleService)
+		}
+		if rollout := service.Rollout(); rollout != nil && rollout.RolloutState == cloud.ECSRolloutFailed {
+			context += "\n" + logWarningStyle.Render("The latest deployment failed")
+		}
+	case constants.ViewECSTaskDetails:
+		task := m.SelectedECSTask
+		if task == nil {
+			break
+		}
+		context += fmt.Sprintf("\nCluster: %s\nTask: %s", m.ECSCluster, task.ID)
+	}
+
+	return context
+}
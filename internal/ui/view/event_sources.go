@@ -0,0 +1,138 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// getEventSourceScopeRows returns the rows of the event source scope view
+func getEventSourceScopeRows() []table.Row {
+	return []table.Row{
+		{constants.ScopeAllFunctions, "Mappings of every function in the region"},
+		{constants.ScopeSingleFunction, "Mappings of one function"},
+	}
+}
+
+// getEventSourceMappingColumns returns the columns of the event source mapping list
+func getEventSourceMappingColumns() []table.Column {
+	return []table.Column{
+		{Title: "Function", Width: constants.TableDefaultWidth},
+		{Title: "Source", Width: constants.TableDefaultWidth},
+		{Title: "State", Width: constants.TableCompactWidth},
+		{Title: "Batch", Width: constants.TableCompactWidth},
+		{Title: "Last Result", Width: constants.TableNarrowWidth},
+	}
+}
+
+// getEventSourceMappingRows returns a row for each event source mapping, in the order of m.EventSourceMappings
+func getEventSourceMappingRows(m *model.Model) []table.Row {
+	rows := make([]table.Row, 0, len(m.EventSourceMappings))
+	for _, mapping := range m.EventSourceMappings {
+		rows = append(rows, table.Row{
+			mapping.FunctionName,
+			fmt.Sprintf("%s: %s", mapping.SourceType, eventSourceName(mapping.EventSourceArn)),
+			mapping.State,
+			fmt.Sprintf("%d", mapping.BatchSize),
+			mapping.LastProcessingResult,
+		})
+	}
+	return rows
+}
+
+// getEventSourceDetailRows returns the rows of the event source mapping details view,
+// ending with the action that enables or disables the mapping
+func getEventSourceDetailRows(m *model.Model) []table.Row {
+	mapping := m.SelectedEventSource
+	if mapping == nil {
+		return []table.Row{}
+	}
+
+	state := mapping.State
+	if mapping.StateReason != "" {
+		state = fmt.Sprintf("%s (%s)", mapping.State, mapping.StateReason)
+	}
+
+	rows := []table.Row{
+		{"UUID", mapping.UUID},
+		{"Function", mapping.FunctionName},
+		{"Source Type", mapping.SourceType},
+		{"Source", mapping.EventSourceArn},
+		{"State", state},
+		{"Batch Size", fmt.Sprintf("%d", mapping.BatchSize)},
+		{"Batching Window", fmt.Sprintf("%d seconds", mapping.BatchingWindow)},
+	}
+
+	if mapping.StartingPosition != "" {
+		rows = append(rows, table.Row{"Starting Position", mapping.StartingPosition})
+	}
+
+	rows = append(rows,
+		table.Row{"Last Result", mapping.LastProcessingResult},
+		table.Row{"Last Modified", mapping.LastModified},
+	)
+
+	if len(mapping.FilterCriteria) == 0 {
+		rows = append(rows, table.Row{"Filters", "None"})
+	}
+	for i, filter := range mapping.FilterCriteria {
+		rows = append(rows, table.Row{fmt.Sprintf("Filter %d", i+1), filter})
+	}
+
+	return append(rows, table.Row{eventSourceAction(mapping), ""})
+}
+
+// eventSourceAction returns the action that toggles a mapping's state
+func eventSourceAction(mapping *cloud.EventSourceMapping) string {
+	if mapping.IsEnabled() {
+		return constants.ActionDisableMapping
+	}
+	return constants.ActionEnableMapping
+}
+
+// eventSourceName returns the short name of an event source, e.g. the queue, stream or table name.
+// Sources that aren't ARNs, such as Kafka bootstrap servers, are returned unchanged.
+func eventSourceName(source string) string {
+	// Format: arn:aws:service:region:account:resource
+	parts := strings.SplitN(source, ":", 6)
+	if len(parts) < 6 {
+		return source
+	}
+
+	// Resources such as table/Orders/stream/... or broker:name:id carry the name second
+	resource := parts[5]
+	if i := strings.IndexAny(resource, "/:"); i >= 0 {
+		resource = resource[i+1:]
+		if j := strings.IndexAny(resource, "/:"); j >= 0 {
+			resource = resource[:j]
+		}
+	}
+	return resource
+}
+
+// getEventSourceContextText returns the context text for the event source mapping views
+func getEventSourceContextText(m *model.Model) string {
+	context := fmt.Sprintf("Profile: %s\nRegion: %s", m.AwsProfile, m.AwsRegion)
+
+	switch m.CurrentView {
+	case constants.ViewEventSourceMappings:
+		scope := m.EventSourceFunction
+		if scope == "" {
+			scope = constants.ScopeAllFunctions
+		}
+		context += fmt.Sprintf("\nScope: %s\nMappings: %d", scope, len(m.EventSourceMappings))
+	case constants.ViewEventSourceDetails:
+		if m.SelectedEventSource != nil {
+			context += fmt.Sprintf("\nFunction: %s\nSource: %s",
+				m.SelectedEventSource.FunctionName,
+				eventSourceName(m.SelectedEventSource.EventSourceArn))
+		}
+	}
+
+	return context
+}
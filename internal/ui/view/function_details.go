@@ -0,0 +1,89 @@
+package view
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// functionStateLabel summarizes whether a function is ready to be invoked, so that functions
+// that failed to create or update stand out in the function list
+func functionStateLabel(function model.FunctionStatus) string {
+	switch {
+	case function.State == "":
+		// The state couldn't be read
+		return "-"
+	case function.State != "Active":
+		return function.State
+	case function.LastUpdateStatus == "Failed":
+		return "Update Failed"
+	case function.LastUpdateStatus == "InProgress":
+		return "Updating"
+	default:
+		return function.State
+	}
+}
+
+// getFunctionInventoryRows returns the state, dependency and tag rows of the function details view.
+// Settings a function doesn't use are left out.
+func getFunctionInventoryRows(function *model.FunctionStatus) []table.Row {
+	var rows []table.Row
+	if function.State != "" {
+		rows = append(rows, table.Row{"State", withReason(function.State, function.StateReason)})
+	}
+	if function.LastUpdateStatus != "" {
+		rows = append(rows, table.Row{"Last Update Status", withReason(function.LastUpdateStatus, function.LastUpdateStatusReason)})
+	}
+	if function.RuntimeVersion != "" {
+		rows = append(rows, table.Row{"Runtime Version", function.RuntimeVersion})
+	}
+
+	for _, layer := range function.Layers {
+		rows = append(rows, table.Row{"Layer", layer})
+	}
+
+	if vpc := function.VpcConfig; vpc != nil {
+		rows = append(rows,
+			table.Row{"VPC", vpc.VpcID},
+			table.Row{"Subnets", strings.Join(vpc.SubnetIDs, ", ")},
+			table.Row{"Security Groups", strings.Join(vpc.SecurityGroupIDs, ", ")},
+		)
+	}
+
+	if function.DeadLetterTarget != "" {
+		rows = append(rows, table.Row{"Dead-Letter Queue", function.DeadLetterTarget})
+	}
+	if function.TracingMode != "" {
+		rows = append(rows, table.Row{"Tracing", function.TracingMode})
+	}
+	if function.SnapStart != "" && function.SnapStart != "None" {
+		rows = append(rows, table.Row{"SnapStart", fmt.Sprintf("%s (%s)", function.SnapStart, function.SnapStartStatus)})
+	}
+	if function.KMSKeyArn != "" {
+		rows = append(rows, table.Row{"KMS Key", function.KMSKeyArn})
+	}
+
+	// Tags are sorted so the rows don't move between refreshes
+	keys := make([]string, 0, len(function.Tags))
+	for key := range function.Tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		rows = append(rows, table.Row{"Tag: " + key, function.Tags[key]})
+	}
+
+	return rows
+}
+
+// withReason appends the reason for a status, if there is one
+func withReason(status, reason string) string {
+	if reason == "" {
+		return status
+	}
+	return fmt.Sprintf("%s: %s", status, reason)
+}
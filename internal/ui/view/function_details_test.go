@@ -0,0 +1,107 @@
+package view
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+func TestFunctionStateLabel(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function model.FunctionStatus
+		expected string
+	}{
+		{name: "Unknown", function: model.FunctionStatus{}, expected: "-"},
+		{name: "Active", function: model.FunctionStatus{State: "Active", LastUpdateStatus: "Successful"}, expected: "Active"},
+		{name: "Failed", function: model.FunctionStatus{State: "Failed"}, expected: "Failed"},
+		{name: "Update failed", function: model.FunctionStatus{State: "Active", LastUpdateStatus: "Failed"}, expected: "Update Failed"},
+		{name: "Updating", function: model.FunctionStatus{State: "Active", LastUpdateStatus: "InProgress"}, expected: "Updating"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := functionStateLabel(tc.function); got != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestFunctionDetailsInventoryRows(t *testing.T) {
+	m := model.New()
+	m.CurrentView = constants.ViewFunctionDetails
+	m.SetSelectedFunction(&cloud.FunctionStatus{
+		Name:             "orders",
+		State:            "Failed",
+		StateReason:      "The subnet has no free IP addresses",
+		LastUpdateStatus: "Failed",
+		Layers:           []string{"arn:layer:a:1", "arn:layer:b:2"},
+		VpcConfig:        &cloud.FunctionVpcConfig{VpcID: "vpc-1", SubnetIDs: []string{"subnet-1", "subnet-2"}, SecurityGroupIDs: []string{"sg-1"}},
+		DeadLetterTarget: "arn:aws:sqs:us-east-1:123456789012:orders-dlq",
+		TracingMode:      "Active",
+		SnapStart:        "None",
+		Tags:             map[string]string{"team": "payments", "env": "prod"},
+	})
+
+	values := map[string][]string{}
+	for _, row := range getRowsForView(m) {
+		values[row[0]] = append(values[row[0]], row[1])
+	}
+
+	expected := map[string]string{
+		"State":              "Failed: The subnet has no free IP addresses",
+		"Last Update Status": "Failed",
+		"Subnets":            "subnet-1, subnet-2",
+		"Dead-Letter Queue":  "arn:aws:sqs:us-east-1:123456789012:orders-dlq",
+		"Tracing":            "Active",
+		"Tag: team":          "payments",
+	}
+	for property, value := range expected {
+		if len(values[property]) != 1 || values[property][0] != value {
+			t.Errorf("Expected %s to be %q, got %v", property, value, values[property])
+		}
+	}
+	if len(values["Layer"]) != 2 {
+		t.Errorf("Expected a row per layer, got %v", values["Layer"])
+	}
+	if _, ok := values["SnapStart"]; ok {
+		t.Errorf("Expected SnapStart to be hidden when it isn't used")
+	}
+}
+
+func TestFunctionStatusContextUndescribed(t *testing.T) {
+	m := model.New()
+	m.CurrentView = constants.ViewFunctionStatus
+	m.SelectedService = &model.Service{Name: "Lambda"}
+	m.SelectedCategory = &model.Category{Name: "Functions"}
+	m.Functions = []model.FunctionStatus{
+		{Name: "orders"},
+		{Name: "billing", Errors: []string{"failed to list function tags: access denied"}},
+	}
+
+	if context := getContextText(m); !strings.Contains(context, "Not fully described: billing") {
+		t.Errorf("Expected the functions that couldn't be described, got %q", context)
+	}
+
+	// Only the first few of many functions are named
+	throttled := []string{"throttled"}
+	many := append([]model.FunctionStatus{}, m.Functions...)
+	for _, name := range []string{"c", "d", "e"} {
+		many = append(many, model.FunctionStatus{Name: name, Errors: throttled})
+	}
+	m.Functions = many
+	if context := getContextText(m); !strings.Contains(context, "Not fully described: billing, c, d and 1 more") {
+		t.Errorf("Expected a count of the functions left out, got %q", context)
+	}
+	m.Functions = many[:2]
+
+	m.CurrentView = constants.ViewFunctionDetails
+	m.SetSelectedFunction(&m.Functions[1])
+	if context := getContextText(m); !strings.Contains(context, "access denied") {
+		t.Errorf("Expected the error of the selected function, got %q", context)
+	}
+}
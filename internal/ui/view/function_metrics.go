@@ -0,0 +1,127 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// sparkBlocks are the characters used to draw sparklines, from lowest to highest
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// getFunctionMetricsColumns returns the columns of the function list when metrics are shown
+func getFunctionMetricsColumns() []table.Column {
+	return []table.Column{
+		{Title: "Function", Width: constants.TableDefaultWidth},
+		{Title: "Invocations", Width: constants.TableMetricWidth},
+		{Title: "Errors", Width: constants.TableMetricWidth},
+		{Title: "Throttles", Width: constants.TableCompactWidth},
+		{Title: "p50/p99", Width: constants.TableCompactWidth},
+		{Title: "Concurrency", Width: constants.TableCompactWidth},
+	}
+}
+
+// getFunctionMetricsRow returns the row of the function list for a function when metrics are shown
+func getFunctionMetricsRow(m *model.Model, function model.FunctionStatus) table.Row {
+	metrics := m.FunctionMetrics[function.Name]
+	if metrics == nil {
+		return table.Row{function.Name, constants.MetricsLoading, constants.MetricsLoading, constants.MetricsLoading, constants.MetricsLoading, constants.MetricsLoading}
+	}
+
+	return table.Row{
+		function.Name,
+		fmt.Sprintf("%s %s", sparkline(metrics.Invocations), formatCount(sum(metrics.Invocations))),
+		fmt.Sprintf("%s %s", sparkline(metrics.Errors), formatCount(sum(metrics.Errors))),
+		formatCount(sum(metrics.Throttles)),
+		fmt.Sprintf("%s/%s", formatMillis(maximum(metrics.DurationP50)), formatMillis(maximum(metrics.DurationP99))),
+		formatCount(maximum(metrics.ConcurrentExecutions)),
+	}
+}
+
+// getFunctionMetricsDetailRows returns the metric rows shown in the function details view
+func getFunctionMetricsDetailRows(m *model.Model, functionName string) []table.Row {
+	header := table.Row{fmt.Sprintf("Metrics (last %s)", formatWindow(m.FunctionMetricsWindow)), ""}
+
+	metrics, requested := m.FunctionMetrics[functionName]
+	if !requested {
+		return nil
+	}
+	if metrics == nil {
+		return []table.Row{{header[0], constants.MetricsLoading}}
+	}
+
+	return []table.Row{
+		header,
+		{"Invocations", fmt.Sprintf("%s %s", sparkline(metrics.Invocations), formatCount(sum(metrics.Invocations)))},
+		{"Errors", fmt.Sprintf("%s %s", sparkline(metrics.Errors), formatCount(sum(metrics.Errors)))},
+		{"Throttles", fmt.Sprintf("%s %s", sparkline(metrics.Throttles), formatCount(sum(metrics.Throttles)))},
+		{"Duration p50", fmt.Sprintf("%s %s", sparkline(metrics.DurationP50), formatMillis(maximum(metrics.DurationP50)))},
+		{"Duration p99", fmt.Sprintf("%s %s", sparkline(metrics.DurationP99), formatMillis(maximum(metrics.DurationP99)))},
+		{"Concurrent Executions", fmt.Sprintf("%s %s", sparkline(metrics.ConcurrentExecutions), formatCount(maximum(metrics.ConcurrentExecutions)))},
+	}
+}
+
+// sparkline renders a series of values as a unicode sparkline scaled to its maximum
+func sparkline(values []float64) string {
+	peak := maximum(values)
+
+	var builder strings.Builder
+	for _, value := range values {
+		level := 0
+		if peak > 0 && value > 0 {
+			level = int(value / peak * float64(len(sparkBlocks)-1))
+		}
+		builder.WriteRune(sparkBlocks[level])
+	}
+	return builder.String()
+}
+
+// sum returns the sum of a series
+func sum(values []float64) float64 {
+	var total float64
+	for _, value := range values {
+		total += value
+	}
+	return total
+}
+
+// maximum returns the largest value of a series, or 0 for an empty series
+func maximum(values []float64) float64 {
+	var peak float64
+	for _, value := range values {
+		if value > peak {
+			peak = value
+		}
+	}
+	return peak
+}
+
+// formatCount formats a count compactly, e.g. 1.2k
+func formatCount(value float64) string {
+	switch {
+	case value >= 1_000_000:
+		return fmt.Sprintf("%.1fM", value/1_000_000)
+	case value >= 1_000:
+		return fmt.Sprintf("%.1fk", value/1_000)
+	default:
+		return fmt.Sprintf("%.0f", value)
+	}
+}
+
+// formatMillis formats a duration in milliseconds compactly, e.g. 45ms or 1.2s
+func formatMillis(value float64) string {
+	if value >= 1000 {
+		return fmt.Sprintf("%.1fs", value/1000)
+	}
+	return fmt.Sprintf("%.0fms", value)
+}
+
+// formatWindow formats a metrics window, e.g. 1h or 24h
+func formatWindow(window time.Duration) string {
+	return fmt.Sprintf("%.0fh", window.Hours())
+}
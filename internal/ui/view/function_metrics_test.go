@@ -0,0 +1,69 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+func TestSparkline(t *testing.T) {
+	testCases := []struct {
+		name     string
+		values   []float64
+		expected string
+	}{
+		{name: "Empty", values: nil, expected: ""},
+		{name: "No data", values: []float64{0, 0, 0}, expected: "▁▁▁"},
+		{name: "Rising", values: []float64{0, 1, 2, 3, 4, 5, 6, 7}, expected: "▁▂▃▄▅▆▇█"},
+		{name: "Spike", values: []float64{1, 100, 1}, expected: "▁█▁"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := sparkline(tc.values); got != tc.expected {
+				t.Errorf("Expected sparkline %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestFunctionMetricsRows(t *testing.T) {
+	m := model.New()
+	m.CurrentView = constants.ViewFunctionStatus
+	m.ShowFunctionMetrics = true
+	m.Functions = []model.FunctionStatus{{Name: "loaded"}, {Name: "loading"}}
+	m.FunctionMetrics = map[string]*cloud.FunctionMetrics{
+		"loaded": {
+			Invocations:          []float64{500, 1000},
+			Errors:               []float64{0, 3},
+			Throttles:            []float64{0, 0},
+			DurationP50:          []float64{40, 45},
+			DurationP99:          []float64{900, 1500},
+			ConcurrentExecutions: []float64{2, 7},
+		},
+		"loading": nil,
+	}
+
+	if columns := getColumnsForView(m); len(columns) != 6 {
+		t.Fatalf("Expected 6 metric columns, got %d", len(columns))
+	}
+
+	rows := getRowsForView(m)
+	expected := []string{"loaded", "▄█ 1.5k", "▁█ 3", "0", "45ms/1.5s", "7"}
+	for i, value := range expected {
+		if rows[0][i] != value {
+			t.Errorf("Expected column %d to be %q, got %q", i, value, rows[0][i])
+		}
+	}
+	if rows[1][1] != constants.MetricsLoading {
+		t.Errorf("Expected metrics still loading to be shown as %q, got %q", constants.MetricsLoading, rows[1][1])
+	}
+
+	// Hiding the metrics restores the regular columns
+	m.ShowFunctionMetrics = false
+	if columns := getColumnsForView(m); columns[1].Title != "Runtime" {
+		t.Errorf("Expected the regular columns when metrics are hidden, got %v", columns)
+	}
+}
@@ -0,0 +1,69 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// getHygieneOptionsRows returns the rows of the hygiene report options form
+func getHygieneOptionsRows(m *model.Model) []table.Row {
+	options := m.HygieneOptions
+	return []table.Row{
+		{constants.SettingRegions, strings.Join(options.Regions, ", ")},
+		{constants.SettingUnusedDays, fmt.Sprintf("%d days", options.UnusedDays)},
+		{constants.SettingMaxPackageSize, fmt.Sprintf("%d MB", options.MaxPackageSize/(1024*1024))},
+		{constants.SettingRunReport, ""},
+	}
+}
+
+// getHygieneReportColumns returns the columns of the hygiene report
+func getHygieneReportColumns() []table.Column {
+	return []table.Column{
+		{Title: "Severity", Width: constants.TableCompactWidth},
+		{Title: "Region", Width: constants.TableCompactWidth},
+		{Title: "Function", Width: constants.TableDefaultWidth},
+		{Title: "Check", Width: constants.TableDefaultWidth},
+		{Title: "Detail", Width: constants.TableWideWidth},
+	}
+}
+
+// getHygieneReportRows returns a row for each finding of the hygiene report
+func getHygieneReportRows(m *model.Model) []table.Row {
+	if m.HygieneReport == nil {
+		return []table.Row{}
+	}
+
+	rows := make([]table.Row, 0, len(m.HygieneReport.Findings))
+	for _, finding := range m.HygieneReport.Findings {
+		rows = append(rows, table.Row{
+			finding.Severity,
+			finding.Region,
+			finding.FunctionName,
+			finding.Check,
+			finding.Detail,
+		})
+	}
+	return rows
+}
+
+// getHygieneContextText returns the context text for the hygiene report views
+func getHygieneContextText(m *model.Model) string {
+	context := fmt.Sprintf("Profile: %s\nRegion: %s", m.AwsProfile, m.AwsRegion)
+
+	report := m.HygieneReport
+	if m.CurrentView != constants.ViewHygieneReport || report == nil {
+		return context
+	}
+
+	context += fmt.Sprintf("\nScanned: %d functions in %s\nFindings: %d",
+		report.FunctionsScanned, strings.Join(report.Regions, ", "), len(report.Findings))
+	for _, err := range report.Errors {
+		context += "\n" + logWarningStyle.Render("Skipped "+err)
+	}
+	return context
+}
@@ -0,0 +1,383 @@
+package view
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// Styles used to colorize JSON documents
+var (
+	jsonKeyStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color(constants.ColorInfo))
+	jsonStringStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(constants.ColorSuccess))
+	jsonNumberStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(constants.ColorHighlight))
+	jsonBoolStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color(constants.ColorPrimary))
+	jsonNullStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color(constants.ColorSubtle))
+	jsonFoldStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color(constants.ColorSubtle)).Italic(true)
+)
+
+// jsonKind is the type of a JSON value
+type jsonKind int
+
+const (
+	jsonObject jsonKind = iota
+	jsonArray
+	jsonString
+	jsonNumber
+	jsonBool
+	jsonNull
+)
+
+// jsonNode is a parsed JSON value that, unlike a decoded map, keeps the order of object keys
+type jsonNode struct {
+	kind     jsonKind
+	key      string      // Key of the member when the parent is an object
+	literal  string      // Encoded value of a scalar
+	children []*jsonNode // Members of an object or elements of an array
+}
+
+// jsonNullNode is the result of looking up a missing key or index, as in jq. It's shared, so
+// it must never be changed; parsed nulls get their own node to hold their key.
+var jsonNullNode = &jsonNode{kind: jsonNull, literal: "null"}
+
+// ValidateJSON checks that a payload is valid JSON and returns the position of the first error, or nil
+func ValidateJSON(payload string) *model.JSONSyntaxError {
+	var value interface{}
+	err := json.Unmarshal([]byte(payload), &value)
+	if err == nil {
+		return nil
+	}
+
+	// The offset counts the offending byte; input that ends early is reported at its last byte
+	offset := len(payload)
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		offset = int(syntaxErr.Offset)
+	}
+
+	line, column := jsonPosition(payload, offset-1)
+	return &model.JSONSyntaxError{
+		Line:    line,
+		Column:  column,
+		Message: strings.TrimPrefix(err.Error(), "json: "),
+	}
+}
+
+// jsonPosition returns the 1-based line and rune column of a byte offset
+func jsonPosition(data string, offset int) (int, int) {
+	offset = min(max(offset, 0), len(data))
+	before := data[:offset]
+	lineStart := strings.LastIndexByte(before, '\n') + 1
+	return strings.Count(before, "\n") + 1, utf8.RuneCountInString(before[lineStart:]) + 1
+}
+
+// parseJSON parses a JSON document, keeping the order of object keys
+func parseJSON(data string) (*jsonNode, error) {
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+
+	node, err := decodeJSONValue(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reject anything after the top-level value
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after top-level value")
+	}
+	return node, nil
+}
+
+// decodeJSONValue decodes the next value from a decoder
+func decodeJSONValue(decoder *json.Decoder) (*jsonNode, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch value := token.(type) {
+	case json.Delim:
+		node := &jsonNode{kind: jsonArray}
+		if value == '{' {
+			node.kind = jsonObject
+		}
+
+		for decoder.More() {
+			var key string
+			if node.kind == jsonObject {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, _ = keyToken.(string)
+			}
+
+			child, err := decodeJSONValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			child.key = key
+			node.children = append(node.children, child)
+		}
+
+		// Consume the closing delimiter
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return node, nil
+	case string:
+		return &jsonNode{kind: jsonString, literal: quoteJSON(value)}, nil
+	case json.Number:
+		return &jsonNode{kind: jsonNumber, literal: value.String()}, nil
+	case bool:
+		return &jsonNode{kind: jsonBool, literal: strconv.FormatBool(value)}, nil
+	default:
+		return &jsonNode{kind: jsonNull, literal: "null"}, nil
+	}
+}
+
+// quoteJSON encodes a string as JSON without escaping HTML characters
+func quoteJSON(s string) string {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(s)
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+// kindName returns the jq name of a value's type, used in query errors
+func (n *jsonNode) kindName() string {
+	switch n.kind {
+	case jsonObject:
+		return "object"
+	case jsonArray:
+		return "array"
+	case jsonString:
+		return "string"
+	case jsonNumber:
+		return "number"
+	case jsonBool:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
+
+// nesting returns how many levels of non-empty objects and arrays a value contains
+func (n *jsonNode) nesting() int {
+	if len(n.children) == 0 {
+		return 0
+	}
+
+	deepest := 0
+	for _, child := range n.children {
+		deepest = max(deepest, child.nesting())
+	}
+	return deepest + 1
+}
+
+// renderJSON renders a value as indented, colorized lines. Objects and arrays nested at
+// maxDepth or deeper are collapsed to a one-line summary; a maxDepth of 0 expands everything.
+func renderJSON(node *jsonNode, maxDepth int) string {
+	return strings.Join(appendJSONLines(nil, node, "", "", "", 0, maxDepth), "\n")
+}
+
+// appendJSONLines appends the lines of a value, preceded by its key and followed by its separator
+func appendJSONLines(lines []string, node *jsonNode, indent, prefix, suffix string, depth, maxDepth int) []string {
+	open, close := "{", "}"
+	switch node.kind {
+	case jsonArray:
+		open, close = "[", "]"
+	case jsonObject:
+	default:
+		return append(lines, indent+prefix+styleJSONScalar(node)+suffix)
+	}
+
+	if len(node.children) == 0 {
+		return append(lines, indent+prefix+open+close+suffix)
+	}
+	if maxDepth > 0 && depth >= maxDepth {
+		return append(lines, indent+prefix+open+jsonFoldStyle.Render("… "+describeJSONSize(node))+close+suffix)
+	}
+
+	lines = append(lines, indent+prefix+open)
+	for i, child := range node.children {
+		childPrefix := ""
+		if node.kind == jsonObject {
+			childPrefix = jsonKeyStyle.Render(quoteJSON(child.key)) + ": "
+		}
+		childSuffix := ","
+		if i == len(node.children)-1 {
+			childSuffix = ""
+		}
+		lines = appendJSONLines(lines, child, indent+"  ", childPrefix, childSuffix, depth+1, maxDepth)
+	}
+	return append(lines, indent+close+suffix)
+}
+
+// styleJSONScalar colorizes a scalar value by type
+func styleJSONScalar(node *jsonNode) string {
+	switch node.kind {
+	case jsonString:
+		return jsonStringStyle.Render(node.literal)
+	case jsonNumber:
+		return jsonNumberStyle.Render(node.literal)
+	case jsonBool:
+		return jsonBoolStyle.Render(node.literal)
+	default:
+		return jsonNullStyle.Render(node.literal)
+	}
+}
+
+// describeJSONSize summarizes a collapsed object or array, e.g. 3 keys or 1 item
+func describeJSONSize(node *jsonNode) string {
+	unit := "item"
+	if node.kind == jsonObject {
+		unit = "key"
+	}
+	if len(node.children) != 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s", len(node.children), unit)
+}
+
+// jsonPathStepKind is the kind of a step in a jq-style path
+type jsonPathStepKind int
+
+const (
+	jsonPathKey jsonPathStepKind = iota
+	jsonPathIndex
+	jsonPathIterate
+)
+
+// jsonPathStep is one step of a jq-style path: .key, ["key"], [n] or []
+type jsonPathStep struct {
+	kind  jsonPathStepKind
+	key   string
+	index int
+}
+
+// parseJSONPath parses a jq-style path such as .Records[0].body, .items[].id or .["odd key"]
+func parseJSONPath(path string) ([]jsonPathStep, error) {
+	if !strings.HasPrefix(path, ".") {
+		return nil, fmt.Errorf("paths start with .")
+	}
+
+	var steps []jsonPathStep
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '.':
+			i++
+			start := i
+			for i < len(path) && isJSONPathKeyChar(path[i]) {
+				i++
+			}
+			if i > start {
+				steps = append(steps, jsonPathStep{kind: jsonPathKey, key: path[start:i]})
+			} else if i < len(path) && path[i] != '[' {
+				return nil, fmt.Errorf("unexpected %q at position %d", path[i], i+1)
+			}
+		case '[':
+			inner := path[i+1:]
+			if quoted, err := strconv.QuotedPrefix(inner); err == nil {
+				// Quoted keys may contain any character, including ]
+				if !strings.HasPrefix(inner[len(quoted):], "]") {
+					return nil, fmt.Errorf("missing ] at position %d", i+len(quoted)+2)
+				}
+				key, _ := strconv.Unquote(quoted)
+				steps = append(steps, jsonPathStep{kind: jsonPathKey, key: key})
+				i += len(quoted) + 2
+				continue
+			}
+
+			end := strings.IndexByte(inner, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("missing ] at position %d", len(path)+1)
+			}
+			if end == 0 {
+				steps = append(steps, jsonPathStep{kind: jsonPathIterate})
+			} else {
+				index, err := strconv.Atoi(strings.TrimSpace(inner[:end]))
+				if err != nil {
+					return nil, fmt.Errorf("invalid index %q", inner[:end])
+				}
+				steps = append(steps, jsonPathStep{kind: jsonPathIndex, index: index})
+			}
+			i += end + 2
+		default:
+			return nil, fmt.Errorf("unexpected %q at position %d", path[i], i+1)
+		}
+	}
+	return steps, nil
+}
+
+// isJSONPathKeyChar reports whether a character can appear in an unquoted key
+func isJSONPathKeyChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// queryJSON applies a path to a value. Like jq, missing keys and indexes yield null and
+// [] yields every element, so a path can produce several results.
+func queryJSON(root *jsonNode, steps []jsonPathStep) ([]*jsonNode, error) {
+	current := []*jsonNode{root}
+	for _, step := range steps {
+		var next []*jsonNode
+		for _, node := range current {
+			switch step.kind {
+			case jsonPathKey:
+				if node.kind == jsonNull {
+					next = append(next, jsonNullNode)
+					continue
+				}
+				if node.kind != jsonObject {
+					return nil, fmt.Errorf("cannot index %s with %q", node.kindName(), step.key)
+				}
+
+				// The last duplicate key wins, as when decoding
+				found := jsonNullNode
+				for _, child := range node.children {
+					if child.key == step.key {
+						found = child
+					}
+				}
+				next = append(next, found)
+			case jsonPathIndex:
+				if node.kind == jsonNull {
+					next = append(next, jsonNullNode)
+					continue
+				}
+				if node.kind != jsonArray {
+					return nil, fmt.Errorf("cannot index %s with number", node.kindName())
+				}
+
+				// Negative indexes count from the end
+				index := step.index
+				if index < 0 {
+					index += len(node.children)
+				}
+				if index < 0 || index >= len(node.children) {
+					next = append(next, jsonNullNode)
+				} else {
+					next = append(next, node.children[index])
+				}
+			case jsonPathIterate:
+				if node.kind != jsonObject && node.kind != jsonArray {
+					return nil, fmt.Errorf("cannot iterate over %s", node.kindName())
+				}
+				next = append(next, node.children...)
+			}
+		}
+		current = next
+	}
+	return current, nil
+}
@@ -0,0 +1,187 @@
+package view
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+func TestValidateJSON(t *testing.T) {
+	testCases := []struct {
+		name    string
+		payload string
+		line    int
+		column  int
+	}{
+		{name: "Valid", payload: `{"key": [1, 2, 3]}`},
+		{name: "Missing value", payload: `{"key": }`, line: 1, column: 9},
+		{name: "Trailing comma", payload: "{\n  \"key\": 1,\n}", line: 3, column: 1},
+		{name: "Unterminated", payload: "[1,\n 2", line: 2, column: 2},
+		{name: "Trailing data", payload: `{} x`, line: 1, column: 4},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateJSON(tc.payload)
+			if tc.line == 0 {
+				if err != nil {
+					t.Fatalf("Expected no error, got %+v", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("Expected an error at %d:%d, got nil", tc.line, tc.column)
+			}
+			if err.Line != tc.line || err.Column != tc.column {
+				t.Errorf("Expected an error at %d:%d, got %d:%d (%s)", tc.line, tc.column, err.Line, err.Column, err.Message)
+			}
+		})
+	}
+}
+
+func TestRenderJSON(t *testing.T) {
+	root, err := parseJSON(`{"zeta": 1, "alpha": {"items": [true, null], "empty": {}}, "html": "<b>"}`)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	t.Run("Keeps key order", func(t *testing.T) {
+		expected := strings.Join([]string{
+			`{`,
+			`  "zeta": 1,`,
+			`  "alpha": {`,
+			`    "items": [`,
+			`      true,`,
+			`      null`,
+			`    ],`,
+			`    "empty": {}`,
+			`  },`,
+			`  "html": "<b>"`,
+			`}`,
+		}, "\n")
+		if got := renderJSON(root, 0); got != expected {
+			t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+		}
+	})
+
+	t.Run("Collapses nested values", func(t *testing.T) {
+		expected := strings.Join([]string{
+			`{`,
+			`  "zeta": 1,`,
+			`  "alpha": {… 2 keys},`,
+			`  "html": "<b>"`,
+			`}`,
+		}, "\n")
+		if got := renderJSON(root, 1); got != expected {
+			t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+		}
+	})
+
+	if nesting := root.nesting(); nesting != 3 {
+		t.Errorf("Expected nesting 3, got %d", nesting)
+	}
+
+	t.Run("Keeps the key of each null", func(t *testing.T) {
+		nulls, err := parseJSON(`{"a": null, "b": null, "c": 1}`)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := strings.Join([]string{
+			`{`,
+			`  "a": null,`,
+			`  "b": null,`,
+			`  "c": 1`,
+			`}`,
+		}, "\n")
+		if got := renderJSON(nulls, 0); got != expected {
+			t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+		}
+		if jsonNullNode.key != "" {
+			t.Errorf("Expected the shared null to keep no key, got %q", jsonNullNode.key)
+		}
+	})
+}
+
+func TestQueryJSON(t *testing.T) {
+	root, err := parseJSON(`{"Records": [{"body": "a"}, {"body": "b"}], "odd key": 42}`)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	testCases := []struct {
+		name      string
+		path      string
+		expected  []string
+		expectErr bool
+	}{
+		{name: "Identity", path: ".", expected: []string{renderJSON(root, 0)}},
+		{name: "Index", path: ".Records[1].body", expected: []string{`"b"`}},
+		{name: "Negative index", path: ".Records[-1].body", expected: []string{`"b"`}},
+		{name: "Iterate", path: ".Records[].body", expected: []string{`"a"`, `"b"`}},
+		{name: "Quoted key", path: `.["odd key"]`, expected: []string{`42`}},
+		{name: "Missing key", path: ".missing.deeper", expected: []string{`null`}},
+		{name: "Out of range", path: ".Records[5]", expected: []string{`null`}},
+		{name: "Index a string", path: ".Records[0].body.length", expectErr: true},
+		{name: "No leading dot", path: "Records", expectErr: true},
+		{name: "Unclosed bracket", path: ".Records[0", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			steps, err := parseJSONPath(tc.path)
+			var nodes []*jsonNode
+			if err == nil {
+				nodes, err = queryJSON(root, steps)
+			}
+
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("Expected an error for %s", tc.path)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if len(nodes) != len(tc.expected) {
+				t.Fatalf("Expected %d results, got %d", len(tc.expected), len(nodes))
+			}
+			for i, node := range nodes {
+				if got := renderJSON(node, 0); got != tc.expected[i] {
+					t.Errorf("Expected result %d to be %s, got %s", i, tc.expected[i], got)
+				}
+			}
+		})
+	}
+}
+
+func TestLambdaResponseContent(t *testing.T) {
+	m := model.New()
+	m.SetLambdaResult(&cloud.LambdaExecuteResult{
+		StatusCode: 200,
+		Payload:    `{"statusCode": 200, "body": "ok"}`,
+		LogResult:  "START RequestId: 1\nhello\nEND RequestId: 1\n",
+	})
+
+	m.LambdaResponseQuery = ".body"
+	if content := LambdaResponseContent(m); !strings.HasSuffix(content, "Response (.body):\n\"ok\"") {
+		t.Errorf("Expected the queried body, got:\n%s", content)
+	}
+
+	m.ShowLambdaLogs = true
+	if content := LambdaResponseContent(m); !strings.HasSuffix(content, "Logs:\nSTART RequestId: 1\nhello\nEND RequestId: 1") {
+		t.Errorf("Expected the log pane, got:\n%s", content)
+	}
+
+	// Responses that aren't JSON are shown as they are
+	m.ShowLambdaLogs = false
+	m.LambdaResponseQuery = ""
+	m.SetLambdaResult(&cloud.LambdaExecuteResult{StatusCode: 200, Payload: "plain text"})
+	if content := LambdaResponseContent(m); !strings.HasSuffix(content, "Response:\nplain text") {
+		t.Errorf("Expected the raw payload, got:\n%s", content)
+	}
+}
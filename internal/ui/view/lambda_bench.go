@@ -0,0 +1,75 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// benchBarWidth is the width of the longest bar of the billed-duration histogram, leaving
+// room in the value column for the count and total
+const benchBarWidth = 20
+
+// getLambdaBenchOptionsRows returns the rows of the benchmark options form
+func getLambdaBenchOptionsRows(m *model.Model) []table.Row {
+	return []table.Row{
+		{constants.SettingInvocations, fmt.Sprintf("%d", m.BenchOptions.Invocations)},
+		{constants.SettingConcurrency, fmt.Sprintf("%d at a time", m.BenchOptions.Concurrency)},
+		{constants.SettingRunBenchmark, ""},
+	}
+}
+
+// getLambdaBenchResultRows returns the summary of a benchmark followed by a row for each
+// bucket of its billed-duration histogram
+func getLambdaBenchResultRows(m *model.Model) []table.Row {
+	result := m.BenchResult
+	if result == nil {
+		return []table.Row{}
+	}
+
+	rows := []table.Row{
+		{"Invocations", fmt.Sprintf("%d in %s", len(result.Invocations), result.Elapsed.Round(time.Millisecond))},
+		{"Errors", fmt.Sprintf("%d (%.1f%%)", result.Errors(), result.ErrorRate()*100)},
+		{"Cold Starts", fmt.Sprintf("%d", result.ColdStarts())},
+		{"Latency p50", result.LatencyPercentile(50).Round(time.Millisecond).String()},
+		{"Latency p90", result.LatencyPercentile(90).Round(time.Millisecond).String()},
+		{"Latency p99", result.LatencyPercentile(99).Round(time.Millisecond).String()},
+		{"Latency Max", result.LatencyPercentile(100).Round(time.Millisecond).String()},
+		{"Billed Duration", fmt.Sprintf("%.0f ms total", result.TotalBilled())},
+	}
+
+	buckets := result.BilledHistogram()
+	most := 0
+	for _, bucket := range buckets {
+		most = max(most, bucket.Count)
+	}
+	for _, bucket := range buckets {
+		bar := strings.Repeat("█", bucket.Count*benchBarWidth/most)
+		rows = append(rows, table.Row{
+			fmt.Sprintf("Billed %.0f-%.0f ms", bucket.Low, bucket.High),
+			fmt.Sprintf("%s %d (%.0f ms)", bar, bucket.Count, bucket.TotalBilled),
+		})
+	}
+	return rows
+}
+
+// getLambdaBenchContextText returns the context text for the benchmark views
+func getLambdaBenchContextText(m *model.Model) string {
+	if m.SelectedFunction == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"Profile: %s\nRegion: %s\nService: Lambda\nFunction: %s\nMemory: %d MB\nPayload: %d bytes",
+		m.AwsProfile,
+		m.AwsRegion,
+		m.SelectedFunction.Name,
+		m.SelectedFunction.Memory,
+		len(m.LambdaPayload),
+	)
+}
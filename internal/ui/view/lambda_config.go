@@ -0,0 +1,165 @@
+package view
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// getLambdaConfigRows returns the rows of the Lambda configuration form
+func getLambdaConfigRows(m *model.Model) []table.Row {
+	if m.FunctionConfig == nil {
+		return []table.Row{}
+	}
+	current := m.FunctionConfig
+	update := m.FunctionConfigUpdate
+
+	rows := []table.Row{
+		{constants.SettingMemory, formatMB(current.Memory), formatNewValue(update.Memory, formatMB)},
+		{constants.SettingTimeout, formatSeconds(current.Timeout), formatNewValue(update.Timeout, formatSeconds)},
+		{constants.SettingEphemeralStorage, formatMB(current.EphemeralStorage), formatNewValue(update.EphemeralStorage, formatMB)},
+		{constants.SettingReservedConcurrency, formatReserved(current.ReservedConcurrency), formatNewReserved(update)},
+	}
+
+	// Existing provisioned concurrency configs, followed by newly staged ones
+	existing := make(map[string]bool)
+	for _, config := range current.ProvisionedConcurrency {
+		existing[config.Qualifier] = true
+		rows = append(rows, table.Row{
+			constants.SettingProvisionedPrefix + config.Qualifier,
+			fmt.Sprintf("%d (%s)", config.Requested, config.Status),
+			formatNewProvisioned(update, config.Qualifier),
+		})
+	}
+	for _, qualifier := range sortedQualifiers(update.ProvisionedConcurrency) {
+		if existing[qualifier] {
+			continue
+		}
+		rows = append(rows, table.Row{
+			constants.SettingProvisionedPrefix + qualifier,
+			"None",
+			formatNewProvisioned(update, qualifier),
+		})
+	}
+
+	changes := len(LambdaConfigChanges(*current, update))
+	rows = append(rows,
+		table.Row{constants.SettingAddProvisioned, "", ""},
+		table.Row{constants.SettingReviewChanges, fmt.Sprintf("%d pending", changes), ""},
+	)
+
+	return rows
+}
+
+// LambdaConfigChanges returns a before/after line for each staged configuration change
+func LambdaConfigChanges(current cloud.FunctionStatus, update cloud.FunctionConfigUpdate) []string {
+	var changes []string
+
+	if update.Memory != nil {
+		changes = append(changes, fmt.Sprintf("%s: %s → %s", constants.SettingMemory, formatMB(current.Memory), formatMB(*update.Memory)))
+	}
+	if update.Timeout != nil {
+		changes = append(changes, fmt.Sprintf("%s: %s → %s", constants.SettingTimeout, formatSeconds(current.Timeout), formatSeconds(*update.Timeout)))
+	}
+	if update.EphemeralStorage != nil {
+		changes = append(changes, fmt.Sprintf("%s: %s → %s", constants.SettingEphemeralStorage, formatMB(current.EphemeralStorage), formatMB(*update.EphemeralStorage)))
+	}
+	if update.ReservedConcurrency != nil || update.RemoveReservedConcurrency {
+		changes = append(changes, fmt.Sprintf("%s: %s → %s", constants.SettingReservedConcurrency, formatReserved(current.ReservedConcurrency), formatNewReserved(update)))
+	}
+
+	for _, qualifier := range sortedQualifiers(update.ProvisionedConcurrency) {
+		before := "None"
+		for _, config := range current.ProvisionedConcurrency {
+			if config.Qualifier == qualifier {
+				before = fmt.Sprintf("%d", config.Requested)
+				break
+			}
+		}
+		changes = append(changes, fmt.Sprintf("Provisioned Concurrency (%s): %s → %s", qualifier, before, formatNewProvisioned(update, qualifier)))
+	}
+
+	return changes
+}
+
+// formatMB formats a size in megabytes
+func formatMB(value int32) string {
+	return fmt.Sprintf("%d MB", value)
+}
+
+// formatSeconds formats a duration in seconds
+func formatSeconds(value int32) string {
+	return fmt.Sprintf("%d seconds", value)
+}
+
+// formatNewValue formats a staged value, or returns an empty string if nothing is staged
+func formatNewValue(value *int32, format func(int32) string) string {
+	if value == nil {
+		return ""
+	}
+	return format(*value)
+}
+
+// formatReserved formats a reserved concurrency setting
+func formatReserved(reserved *int32) string {
+	if reserved == nil {
+		return "Unreserved"
+	}
+	return fmt.Sprintf("%d", *reserved)
+}
+
+// formatNewReserved formats the staged reserved concurrency
+func formatNewReserved(update cloud.FunctionConfigUpdate) string {
+	if update.RemoveReservedConcurrency {
+		return "Unreserved"
+	}
+	if update.ReservedConcurrency == nil {
+		return ""
+	}
+	return formatReserved(update.ReservedConcurrency)
+}
+
+// formatNewProvisioned formats the staged provisioned concurrency of an alias or version
+func formatNewProvisioned(update cloud.FunctionConfigUpdate, qualifier string) string {
+	count, ok := update.ProvisionedConcurrency[qualifier]
+	if !ok {
+		return ""
+	}
+	if count == 0 {
+		return "None"
+	}
+	return fmt.Sprintf("%d", count)
+}
+
+// sortedQualifiers returns the qualifiers of a provisioned concurrency map in order
+func sortedQualifiers(provisioned map[string]int32) []string {
+	qualifiers := make([]string, 0, len(provisioned))
+	for qualifier := range provisioned {
+		qualifiers = append(qualifiers, qualifier)
+	}
+	sort.Strings(qualifiers)
+	return qualifiers
+}
+
+// getLambdaConfigContextText returns the context text for the Lambda configuration view
+func getLambdaConfigContextText(m *model.Model) string {
+	if m.FunctionConfig == nil {
+		return ""
+	}
+
+	context := fmt.Sprintf("Profile: %s\nRegion: %s\nFunction: %s",
+		m.AwsProfile,
+		m.AwsRegion,
+		m.FunctionConfig.Name)
+
+	if m.ManualInput && m.FunctionConfigField != "" {
+		context += fmt.Sprintf("\n\nEditing: %s", m.FunctionConfigField)
+	}
+
+	return context
+}
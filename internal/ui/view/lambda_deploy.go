@@ -0,0 +1,69 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// getLambdaDeployRows returns the rows of the Lambda deploy form
+func getLambdaDeployRows(m *model.Model) []table.Row {
+	request := m.DeployRequest
+
+	var s3Object string
+	if request.S3Bucket != "" {
+		s3Object = request.S3Bucket + "/" + request.S3Key
+	}
+
+	return []table.Row{
+		{constants.SettingZipFile, request.ZipFile},
+		{constants.SettingS3Object, s3Object},
+		{constants.SettingImageURI, request.ImageURI},
+		{constants.SettingPublishVersion, formatYesNo(request.Publish)},
+		{constants.SettingAlias, request.Alias},
+		{constants.SettingReviewDeploy, ""},
+	}
+}
+
+// LambdaDeploySummary returns the lines describing a staged deployment
+func LambdaDeploySummary(request cloud.LambdaDeployRequest) []string {
+	summary := []string{
+		fmt.Sprintf("Source: %s", request.Source()),
+		fmt.Sprintf("%s: %s", constants.SettingPublishVersion, formatYesNo(request.Publish)),
+	}
+	if request.Alias != "" {
+		summary = append(summary, fmt.Sprintf("%s: %s → new version", constants.SettingAlias, request.Alias))
+	}
+	return summary
+}
+
+// formatYesNo formats a boolean setting
+func formatYesNo(value bool) string {
+	if value {
+		return "Yes"
+	}
+	return "No"
+}
+
+// getLambdaDeployContextText returns the context text for the Lambda deploy view
+func getLambdaDeployContextText(m *model.Model) string {
+	if m.SelectedFunction == nil {
+		return ""
+	}
+
+	context := fmt.Sprintf("Profile: %s\nRegion: %s\nFunction: %s\nPackage Type: %s",
+		m.AwsProfile,
+		m.AwsRegion,
+		m.SelectedFunction.Name,
+		m.SelectedFunction.PackageType)
+
+	if m.ManualInput && m.DeployField != "" {
+		context += fmt.Sprintf("\n\nEditing: %s", m.DeployField)
+	}
+
+	return context
+}
@@ -0,0 +1,191 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// Styles used to colorize Lambda logs and payload errors
+var (
+	logErrorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color(constants.ColorError))
+	logWarningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(constants.ColorWarning))
+	logReportStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color(constants.ColorInfo))
+	logRequestStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(constants.ColorSubtle))
+	errorMarkStyle  = lipgloss.NewStyle().
+			Foreground(lipgloss.Color(constants.ColorText)).
+			Background(lipgloss.Color(constants.ColorError))
+)
+
+// LambdaResponseContent returns the content of the Lambda response viewport: the colorized
+// response, narrowed by the response query and folded to the response depth, or the log pane
+func LambdaResponseContent(m *model.Model) string {
+	result := m.GetLambdaResult()
+	if result == nil {
+		return ""
+	}
+
+	content := fmt.Sprintf("Status Code: %d\nExecuted Version: %s\n", result.StatusCode, result.ExecutedVersion)
+	if result.Streamed {
+		content += "Stream: " + describeLambdaStream(m) + "\n"
+	}
+	content += "\n"
+	if m.ShowLambdaLogs {
+		return content + "Logs:\n" + colorizeLogs(result.LogResult)
+	}
+
+	if m.LambdaResponseQuery != "" {
+		content += fmt.Sprintf("Response (%s):\n", m.LambdaResponseQuery)
+	} else {
+		content += "Response:\n"
+	}
+
+	// Partial streams are shown as received, since they are rarely valid JSON yet
+	if m.IsLambdaStreaming {
+		return content + result.Payload
+	}
+	content += renderLambdaPayload(result.Payload, m.LambdaResponseQuery, m.LambdaResponseDepth)
+
+	// Streamed responses end with the tail logs, which arrive with the last event
+	if result.Streamed && result.LogResult != "" {
+		content += "\n\nLogs:\n" + colorizeLogs(result.LogResult)
+	}
+	return content
+}
+
+// describeLambdaStream returns the state of a streamed response
+func describeLambdaStream(m *model.Model) string {
+	result := m.GetLambdaResult()
+	switch {
+	case m.IsLambdaStreaming:
+		return logWarningStyle.Render("receiving…")
+	case result.ErrorCode != "":
+		return logErrorStyle.Render(fmt.Sprintf("failed (%s): %s", result.ErrorCode, result.ErrorDetails))
+	case result.ErrorDetails != "":
+		return logErrorStyle.Render("failed: " + result.ErrorDetails)
+	default:
+		return logReportStyle.Render("complete")
+	}
+}
+
+// LambdaResponseNesting returns how many levels of the shown response can be collapsed
+func LambdaResponseNesting(m *model.Model) int {
+	result := m.GetLambdaResult()
+	if result == nil {
+		return 0
+	}
+
+	nodes, err := lambdaResponseNodes(result.Payload, m.LambdaResponseQuery)
+	if err != nil {
+		return 0
+	}
+
+	nesting := 0
+	for _, node := range nodes {
+		nesting = max(nesting, node.nesting())
+	}
+	return nesting
+}
+
+// lambdaResponseNodes parses a response payload and applies a query to it
+func lambdaResponseNodes(payload, query string) ([]*jsonNode, error) {
+	root, err := parseJSON(payload)
+	if err != nil {
+		return nil, err
+	}
+	if query == "" {
+		return []*jsonNode{root}, nil
+	}
+
+	steps, err := parseJSONPath(query)
+	if err != nil {
+		return nil, err
+	}
+	return queryJSON(root, steps)
+}
+
+// renderLambdaPayload renders a response payload, falling back to the raw payload if it isn't JSON
+func renderLambdaPayload(payload, query string, depth int) string {
+	if payload == "" {
+		return "(empty response)"
+	}
+
+	if _, err := parseJSON(payload); err != nil {
+		if query != "" {
+			return payload + "\n\n" + logWarningStyle.Render("(response is not JSON, query ignored)")
+		}
+		return payload
+	}
+
+	nodes, err := lambdaResponseNodes(payload, query)
+	if err != nil {
+		return logErrorStyle.Render(fmt.Sprintf(constants.MsgErrorInvalidQuery, query, err))
+	}
+	if len(nodes) == 0 {
+		return "(no results)"
+	}
+
+	rendered := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		rendered = append(rendered, renderJSON(node, depth))
+	}
+	return strings.Join(rendered, "\n")
+}
+
+// colorizeLogs colorizes the tail of a function's log by line: errors, warnings,
+// invocation reports and request markers each get their own color
+func colorizeLogs(logs string) string {
+	logs = strings.TrimRight(logs, "\n")
+	if logs == "" {
+		return "(no logs)"
+	}
+
+	lines := strings.Split(logs, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "START RequestId:"), strings.HasPrefix(line, "END RequestId:"):
+			lines[i] = logRequestStyle.Render(line)
+		case strings.HasPrefix(line, "REPORT RequestId:"):
+			lines[i] = logReportStyle.Render(line)
+		case strings.Contains(line, "ERROR"), strings.Contains(line, "Task timed out"),
+			strings.Contains(line, "Traceback"), strings.Contains(line, "Exception"):
+			lines[i] = logErrorStyle.Render(line)
+		case strings.Contains(line, "WARN"):
+			lines[i] = logWarningStyle.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderPayloadError renders the payload validation error below the editor, with the
+// offending character of its line highlighted
+func renderPayloadError(m *model.Model) string {
+	payloadErr := m.LambdaPayloadError
+	if payloadErr == nil {
+		return ""
+	}
+
+	message := logErrorStyle.Render(fmt.Sprintf(constants.MsgErrorJSONSyntax, payloadErr.Line, payloadErr.Column, payloadErr.Message))
+
+	lines := strings.Split(m.TextArea.Value(), "\n")
+	if payloadErr.Line < 1 || payloadErr.Line > len(lines) {
+		return message
+	}
+
+	// Mark the offending character, or the end of the line if the input ended early
+	runes := []rune(lines[payloadErr.Line-1])
+	column := min(max(payloadErr.Column-1, 0), len(runes))
+	marked := " "
+	rest := ""
+	if column < len(runes) {
+		marked = string(runes[column])
+		rest = string(runes[column+1:])
+	}
+
+	snippet := fmt.Sprintf("%4d │ %s%s%s", payloadErr.Line, string(runes[:column]), errorMarkStyle.Render(marked), rest)
+	return message + "\n" + snippet
+}
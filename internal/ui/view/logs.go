@@ -0,0 +1,199 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// getLogGroupsColumns returns the columns of the log group list
+func getLogGroupsColumns() []table.Column {
+	return []table.Column{
+		{Title: "Log Group", Width: constants.TableDescWidth + 10},
+		{Title: "Stored", Width: constants.TableCompactWidth},
+		{Title: "Retention", Width: constants.TableCompactWidth},
+	}
+}
+
+// getLogGroupsRows returns a row to write the query, followed by a row for each log group in the
+// order of m.LogGroups with the selected ones marked
+func getLogGroupsRows(m *model.Model) []table.Row {
+	selected := make(map[string]bool, len(m.SelectedLogGroups))
+	for _, name := range m.SelectedLogGroups {
+		selected[name] = true
+	}
+
+	rows := make([]table.Row, 0, len(m.LogGroups)+1)
+	rows = append(rows, table.Row{constants.SettingWriteQuery, "", ""})
+	for _, group := range m.LogGroups {
+		marker := "  "
+		if selected[group.Name] {
+			marker = constants.LogGroupSelected
+		}
+		retention := "never expires"
+		if group.RetentionDays > 0 {
+			retention = fmt.Sprintf("%d days", group.RetentionDays)
+		}
+		rows = append(rows, table.Row{marker + group.Name, formatBytes(group.StoredBytes), retention})
+	}
+	return rows
+}
+
+// getSavedQueriesColumns returns the columns of the saved query list
+func getSavedQueriesColumns() []table.Column {
+	return []table.Column{
+		{Title: "Name", Width: constants.TableDefaultWidth},
+		{Title: "Log Groups", Width: constants.TableDefaultWidth},
+		{Title: "Query", Width: constants.TableDescWidth},
+	}
+}
+
+// getSavedQueriesRows returns a row for each saved query, with the query on a single line
+func getSavedQueriesRows(m *model.Model) []table.Row {
+	rows := make([]table.Row, 0, len(m.SavedQueries))
+	for _, saved := range m.SavedQueries {
+		rows = append(rows, table.Row{
+			saved.Name,
+			strings.Join(saved.LogGroups, ", "),
+			strings.Join(strings.Fields(saved.Query), " "),
+		})
+	}
+	return rows
+}
+
+// getLogsResultsColumns returns a column for each field of the query results, each as wide as
+// its longest value up to a limit, with the last field taking what's left of the window
+func getLogsResultsColumns(m *model.Model) []table.Column {
+	results := m.LogsQueryResults
+	if results == nil || len(results.Fields) == 0 {
+		return []table.Column{{Title: "Result", Width: constants.TableDescWidth}}
+	}
+
+	columns := make([]table.Column, 0, len(results.Fields))
+	used := 0
+	for i, field := range results.Fields {
+		width := utf8.RuneCountInString(field)
+		for _, record := range results.Records {
+			width = max(width, utf8.RuneCountInString(record[i]))
+		}
+		width = min(width, constants.TableWideWidth)
+		columns = append(columns, table.Column{Title: field, Width: width})
+		used += width + 2
+	}
+
+	last := &columns[len(columns)-1]
+	last.Width = max(max(last.Width, constants.TableDescWidth), m.Width-constants.ViewportMarginX*2-used+last.Width)
+	return columns
+}
+
+// getLogsResultsRows returns a row for each record, with line breaks in values flattened
+func getLogsResultsRows(m *model.Model) []table.Row {
+	results := m.LogsQueryResults
+	if results == nil {
+		return []table.Row{}
+	}
+	if len(results.Fields) == 0 {
+		return []table.Row{{"No records matched"}}
+	}
+
+	rows := make([]table.Row, 0, len(results.Records))
+	for _, record := range results.Records {
+		row := make(table.Row, len(record))
+		for i, value := range record {
+			row[i] = strings.Join(strings.Fields(value), " ")
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// renderLogsQuery renders the query editor, with the mode and time range in its footer and the
+// name to save the query as below it while it's being entered
+func renderLogsQuery(m *model.Model) string {
+	m.TextArea.SetWidth(m.Width - constants.ViewportMarginX*2)
+	m.TextArea.SetHeight(constants.TableHeight)
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(constants.ColorTitle)).
+		Render(constants.TitleLogsQuery)
+	line := strings.Repeat("─", max(0, m.Width-constants.ViewportMarginX*2-lipgloss.Width(title)))
+	header := lipgloss.JoinHorizontal(lipgloss.Center, title, line)
+
+	footerText := "COMMAND MODE"
+	if m.IsLogsQueryInputMode {
+		footerText = "INPUT MODE"
+	}
+	footerText += " • RANGE: last " + formatTimeRange(m.LogsTimeRange)
+	footer := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(constants.ColorPrimary)).
+		Render(footerText)
+	footerLine := strings.Repeat("─", max(0, m.Width-constants.ViewportMarginX*2-lipgloss.Width(footerText)))
+	footer = lipgloss.JoinHorizontal(lipgloss.Center, footerLine, footer)
+
+	if m.ManualInput {
+		return fmt.Sprintf("%s\n%s\n%s\n%s", header, m.TextArea.View(), footer, m.TextInput.View())
+	}
+	return fmt.Sprintf("%s\n%s\n%s", header, m.TextArea.View(), footer)
+}
+
+// renderLogsQueryProgress renders how many records a running query has matched and scanned
+func renderLogsQueryProgress(results *cloud.LogsQueryResults) string {
+	if results == nil {
+		return constants.MsgRunningQuery
+	}
+	return constants.MsgRunningQuery + " " + formatQueryStatistics(results)
+}
+
+// formatQueryStatistics returns how many records a query matched and scanned
+func formatQueryStatistics(results *cloud.LogsQueryResults) string {
+	return fmt.Sprintf(constants.MsgQueryProgress,
+		formatCount(results.RecordsMatched), formatCount(results.RecordsScanned), formatBytes(int64(results.BytesScanned)))
+}
+
+// formatTimeRange returns a time range in the largest unit it's a whole number of
+func formatTimeRange(timeRange time.Duration) string {
+	switch {
+	case timeRange >= 24*time.Hour && timeRange%(24*time.Hour) == 0:
+		return fmt.Sprintf("%dd", timeRange/(24*time.Hour))
+	case timeRange >= time.Hour && timeRange%time.Hour == 0:
+		return fmt.Sprintf("%dh", timeRange/time.Hour)
+	default:
+		return fmt.Sprintf("%dm", timeRange/time.Minute)
+	}
+}
+
+// getLogsContextText returns the context text for the Logs Insights views
+func getLogsContextText(m *model.Model) string {
+	context := fmt.Sprintf("Profile: %s\nRegion: %s", m.AwsProfile, m.AwsRegion)
+
+	switch m.CurrentView {
+	case constants.ViewLogGroups:
+		context += fmt.Sprintf("\nLog Groups: %d\nSelected: %d of up to %d", len(m.LogGroups), len(m.SelectedLogGroups), constants.MaxQueryLogGroups)
+	case constants.ViewLogsQuery, constants.ViewSavedQueries:
+		context += fmt.Sprintf("\nLog Groups: %s", strings.Join(m.SelectedLogGroups, ", "))
+		if m.LogsSavedQuery != nil {
+			context += fmt.Sprintf("\nSaved Query: %s", m.LogsSavedQuery.Name)
+		}
+		if m.ManualInput {
+			context += "\n\nEditing: Query Name"
+		}
+	case constants.ViewLogsResults:
+		results := m.LogsQueryResults
+		if results == nil {
+			break
+		}
+		context += fmt.Sprintf("\nLog Groups: %s\nRange: last %s\nRecords: %s",
+			strings.Join(m.SelectedLogGroups, ", "), formatTimeRange(m.LogsTimeRange), formatQueryStatistics(results))
+	}
+
+	return context
+}
@@ -0,0 +1,57 @@
+package view
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+func TestLogsResultsColumns(t *testing.T) {
+	m := model.New()
+	m.Width = 160
+	m.LogsQueryResults = &cloud.LogsQueryResults{
+		Fields: []string{"@timestamp", "level", "@message"},
+		Records: [][]string{
+			{"2025-01-01 00:00:00.000", "ERROR", strings.Repeat("x", 200)},
+			{"2025-01-01 00:00:01.000", "", "line one\nline two"},
+		},
+	}
+
+	columns := getLogsResultsColumns(m)
+	if len(columns) != 3 || columns[0].Width != 23 || columns[1].Width != 5 {
+		t.Fatalf("Expected columns as wide as their values, got %+v", columns)
+	}
+	if want := m.Width - constants.ViewportMarginX*2 - (23 + 2) - (5 + 2) - 2; columns[2].Width != want {
+		t.Errorf("Expected the last column to fill the window (%d), got %d", want, columns[2].Width)
+	}
+
+	rows := getLogsResultsRows(m)
+	if rows[1][2] != "line one line two" {
+		t.Errorf("Expected line breaks to be flattened, got %q", rows[1][2])
+	}
+
+	// Queries that matched nothing still show a row saying so
+	m.LogsQueryResults = &cloud.LogsQueryResults{Status: cloud.LogsQueryComplete}
+	if len(getLogsResultsColumns(m)) != 1 || len(getLogsResultsRows(m)) != 1 {
+		t.Errorf("Expected a single row for no records")
+	}
+}
+
+func TestFormatTimeRange(t *testing.T) {
+	testCases := map[time.Duration]string{
+		15 * time.Minute:   "15m",
+		90 * time.Minute:   "90m",
+		3 * time.Hour:      "3h",
+		36 * time.Hour:     "36h",
+		7 * 24 * time.Hour: "7d",
+	}
+	for timeRange, expected := range testCases {
+		if got := formatTimeRange(timeRange); got != expected {
+			t.Errorf("Expected %v to be %s, got %s", timeRange, expected, got)
+		}
+	}
+}
@@ -121,6 +121,86 @@ func (p *MockProvider) GetLambdaExecuteOperation() (cloud.LambdaExecuteOperation
 	return nil, nil
 }
 
+func (p *MockProvider) GetLambdaConfigurationOperation() (cloud.LambdaConfigurationOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetLambdaDeployOperation() (cloud.LambdaDeployOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetLambdaMetricsOperation() (cloud.LambdaMetricsOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetLambdaEventSourceOperation() (cloud.LambdaEventSourceOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetLambdaHygieneOperation() (cloud.LambdaHygieneOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetLambdaPackageOperation() (cloud.LambdaPackageOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetLambdaSecurityOperation() (cloud.LambdaSecurityOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetLambdaAsyncOperation() (cloud.LambdaAsyncOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetS3BrowserOperation() (cloud.S3BrowserOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetS3TransferOperation() (cloud.S3TransferOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetEC2InstanceOperation() (cloud.EC2InstanceOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetLogsInsightsOperation() (cloud.LogsInsightsOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetAlarmOperation() (cloud.AlarmOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetECSServiceOperation() (cloud.ECSServiceOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetCodeBuildOperation() (cloud.CodeBuildOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetCloudFormationOperation() (cloud.CloudFormationOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetParameterStoreOperation() (cloud.ParameterStoreOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetSecretsManagerOperation() (cloud.SecretsManagerOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetQueueOperation() (cloud.QueueOperation, error) {
+	return nil, nil
+}
+
+func (p *MockProvider) GetTopicOperation() (cloud.TopicOperation, error) {
+	return nil, nil
+}
+
 func (p *MockProvider) GetAuthenticationMethods() []string {
 	return []string{}
 }
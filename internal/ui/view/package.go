@@ -0,0 +1,131 @@
+package view
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// maxPackageFileView is the most of a file shown in the package file viewer
+const maxPackageFileView = 1024 * 1024
+
+// PackageParentDir is the row that leads to the parent directory in the package browser
+const PackageParentDir = ".."
+
+// getPackageBrowserColumns returns the columns of the package browser
+func getPackageBrowserColumns() []table.Column {
+	return []table.Column{
+		{Title: "Name", Width: constants.TableDescWidth},
+		{Title: "Size", Width: constants.TableCompactWidth},
+	}
+}
+
+// getPackageBrowserRows returns a row for each directory and file in the directory being browsed,
+// with directories marked by a trailing slash
+func getPackageBrowserRows(m *model.Model) []table.Row {
+	if m.FunctionPackage == nil {
+		return []table.Row{}
+	}
+
+	var rows []table.Row
+	if m.PackageDir != "" {
+		rows = append(rows, table.Row{PackageParentDir, ""})
+	}
+	for _, entry := range m.FunctionPackage.List(m.PackageDir) {
+		name := entry.Name
+		if entry.IsDir {
+			name += "/"
+		}
+		rows = append(rows, table.Row{name, formatBytes(int64(entry.Size))})
+	}
+	return rows
+}
+
+// getPackageContextText returns the context text for the package browser and file viewer
+func getPackageContextText(m *model.Model) string {
+	pkg := m.FunctionPackage
+	if pkg == nil {
+		return ""
+	}
+
+	context := fmt.Sprintf("Profile: %s\nRegion: %s\nFunction: %s\nCodeSha256: %s\nPackage: %s, %d files",
+		m.AwsProfile, m.AwsRegion, pkg.FunctionName, pkg.CodeSha256, formatBytes(pkg.Size), len(pkg.Files))
+	if m.CurrentView == constants.ViewPackageFile {
+		return context + "\nFile: " + m.PackageFilePath
+	}
+	return context + "\nDirectory: /" + m.PackageDir
+}
+
+// PackageFileContent returns a file of a deployment package as shown in the file viewer: text
+// with line numbers, cut off after the first megabyte, or a note for binary files
+func PackageFileContent(data []byte) string {
+	size := int64(len(data))
+	truncated := size > maxPackageFileView
+	if truncated {
+		// Cut at the start of a character so the text stays valid UTF-8
+		cut := maxPackageFileView
+		for cut > 0 && !utf8.RuneStart(data[cut]) {
+			cut--
+		}
+		data = data[:cut]
+	}
+
+	if bytes.IndexByte(data, 0) >= 0 || !utf8.Valid(data) {
+		return fmt.Sprintf("(binary file, %s)", formatBytes(size))
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	width := len(fmt.Sprint(len(lines)))
+	for i, line := range lines {
+		lines[i] = logRequestStyle.Render(fmt.Sprintf("%*d ", width, i+1)) + line
+	}
+	if truncated {
+		lines = append(lines, logWarningStyle.Render("(file truncated at 1 MB)"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderPackageFile renders the file viewer for the package file being read
+func renderPackageFile(m *model.Model) string {
+	return renderFileViewer(m, m.PackageFilePath)
+}
+
+// renderFileViewer renders a read-only file viewer: a title with the file's name, the viewport
+// and a footer with the scroll position
+func renderFileViewer(m *model.Model, name string) string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(constants.ColorTitle)).
+		Render(name)
+	line := strings.Repeat("─", max(0, m.Viewport.Width-lipgloss.Width(title)))
+	header := lipgloss.JoinHorizontal(lipgloss.Center, title, line)
+
+	footerText := fmt.Sprintf("READ-ONLY %3.f%%", m.Viewport.ScrollPercent()*100)
+	footer := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(constants.ColorPrimary)).
+		Render(footerText)
+	footerLine := strings.Repeat("─", max(0, m.Viewport.Width-lipgloss.Width(footerText)))
+	footer = lipgloss.JoinHorizontal(lipgloss.Center, footerLine, footer)
+
+	m.Viewport.Height = constants.TableHeight
+	return fmt.Sprintf("%s\n%s\n%s", header, m.Viewport.View(), footer)
+}
+
+// formatBytes formats a size in bytes, KB or MB
+func formatBytes(size int64) string {
+	switch {
+	case size < 1024:
+		return fmt.Sprintf("%d bytes", size)
+	case size < 1024*1024:
+		return fmt.Sprintf("%.2f KB", float64(size)/1024)
+	default:
+		return fmt.Sprintf("%.2f MB", float64(size)/(1024*1024))
+	}
+}
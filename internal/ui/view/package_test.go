@@ -0,0 +1,36 @@
+package view
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPackageFileContent(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     []byte
+		expected string
+	}{
+		{name: "Text", data: []byte("a\nb\n"), expected: "1 a\n2 b"},
+		{name: "Empty", data: []byte{}, expected: "1 "},
+		{name: "Binary", data: []byte{0x50, 0x4b, 0x03, 0x04, 0x00}, expected: "(binary file, 5 bytes)"},
+		{name: "Invalid UTF-8", data: []byte{0xff, 0xfe, 'a'}, expected: "(binary file, 3 bytes)"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := PackageFileContent(tc.data); got != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestPackageFileContentTruncated(t *testing.T) {
+	// A multi-byte character straddling the cut isn't mistaken for binary
+	data := []byte(strings.Repeat("a", maxPackageFileView-1) + "é and more")
+	content := PackageFileContent(data)
+	if !strings.HasSuffix(content, "(file truncated at 1 MB)") {
+		t.Errorf("Expected the file to be truncated, got the tail %q", content[len(content)-40:])
+	}
+}
@@ -0,0 +1,240 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// ParameterParentPath is the row that leads to the parent path in the parameter browser
+const ParameterParentPath = ".."
+
+// maxParameterDiffLines is the most lines of a diff shown before putting a new version
+const maxParameterDiffLines = 20
+
+// hiddenParameterValue is shown in place of a SecureString value that isn't decrypted
+const hiddenParameterValue = "(encrypted)"
+
+// Styles of the lines a new version of a parameter adds and removes
+var (
+	diffAddedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color(constants.ColorSuccess))
+	diffRemovedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(constants.ColorError))
+)
+
+// getParametersColumns returns the columns of the parameter browser
+func getParametersColumns() []table.Column {
+	return []table.Column{
+		{Title: "Name", Width: constants.TableWideWidth},
+		{Title: "Type", Width: constants.TableCompactWidth},
+		{Title: "Version", Width: constants.TableCompactWidth},
+		{Title: "Last Modified", Width: constants.TableNarrowWidth},
+		{Title: "Description", Width: constants.TableDescWidth},
+	}
+}
+
+// getParametersRows returns a row for each path and parameter directly below the path being
+// browsed, with paths marked by a trailing slash
+func getParametersRows(m *model.Model) []table.Row {
+	var rows []table.Row
+	if m.ParameterPath != cloud.ParameterRootPath {
+		rows = append(rows, table.Row{ParameterParentPath, "", "", "", ""})
+	}
+	for _, entry := range cloud.ListParameterPath(m.Parameters, m.ParameterPath) {
+		if entry.IsPath {
+			rows = append(rows, table.Row{entry.Name + "/", "", "", "", fmt.Sprintf("%d parameters", entry.Count)})
+			continue
+		}
+		parameter := entry.Parameter
+		rows = append(rows, table.Row{
+			entry.Name,
+			parameter.Type,
+			fmt.Sprintf("%d", parameter.Version),
+			formatTimestamp(parameter.LastModified),
+			parameter.Description,
+		})
+	}
+	return rows
+}
+
+// getParameterDetailsRows returns the properties and value of the selected parameter, ending with
+// its actions. A SecureString can be revealed until it's decrypted.
+func getParameterDetailsRows(m *model.Model) []table.Row {
+	parameter := m.SelectedParameter
+	if parameter == nil {
+		return []table.Row{}
+	}
+
+	value := hiddenParameterValue
+	if m.ParameterValue != nil && ParameterValueVisible(parameter, m.ParameterValue) {
+		value = strings.Join(strings.Fields(m.ParameterValue.Value), " ")
+	}
+	rows := []table.Row{
+		{"Name", parameter.Name},
+		{"Type", parameter.Type},
+		{"Value", value},
+		{"Version", fmt.Sprintf("%d", parameter.Version)},
+		{"Last Modified", formatTimestamp(parameter.LastModified)},
+		{"Modified By", valueOrNone(parameter.LastModifiedUser)},
+		{"Description", valueOrNone(parameter.Description)},
+		{"Tier", parameter.Tier},
+		{"Data Type", valueOrNone(parameter.DataType)},
+	}
+	if parameter.Secure() {
+		rows = append(rows, table.Row{"KMS Key", valueOrNone(parameter.KeyID)})
+		if m.ParameterValue == nil || !m.ParameterValue.Decrypted {
+			rows = append(rows, table.Row{constants.ActionRevealValue, ""})
+		}
+	}
+	return append(rows,
+		table.Row{constants.ActionParameterHistory, ""},
+		table.Row{constants.ActionPutVersion, ""},
+	)
+}
+
+// getParameterHistoryColumns returns the columns of the versions of a parameter
+func getParameterHistoryColumns() []table.Column {
+	return []table.Column{
+		{Title: "Version", Width: constants.TableCompactWidth},
+		{Title: "Value", Width: constants.TableDescWidth},
+		{Title: "Last Modified", Width: constants.TableNarrowWidth},
+		{Title: "Modified By", Width: constants.TableWideWidth},
+		{Title: "Labels", Width: constants.TableNarrowWidth},
+	}
+}
+
+// getParameterHistoryRows returns a row for each version of the selected parameter, newest first
+func getParameterHistoryRows(m *model.Model) []table.Row {
+	rows := make([]table.Row, 0, len(m.ParameterHistory))
+	decrypted := m.ParameterValue != nil && m.ParameterValue.Decrypted
+	for _, version := range m.ParameterHistory {
+		value := hiddenParameterValue
+		if m.SelectedParameter == nil || !m.SelectedParameter.Secure() || decrypted {
+			value = strings.Join(strings.Fields(version.Value), " ")
+		}
+		rows = append(rows, table.Row{
+			fmt.Sprintf("%d", version.Version),
+			value,
+			formatTimestamp(version.LastModified),
+			valueOrNone(version.LastModifiedUser),
+			valueOrNone(strings.Join(version.Labels, ", ")),
+		})
+	}
+	return rows
+}
+
+// ParameterValueVisible returns whether the value of a parameter can be shown and edited, which
+// a SecureString only can once it's decrypted
+func ParameterValueVisible(parameter *cloud.Parameter, value *cloud.ParameterValue) bool {
+	return !parameter.Secure() || value.Decrypted
+}
+
+// ParameterValueDiff returns the lines a new value of a parameter removes and adds, with a line
+// of unchanged context around them. The items of a StringList are compared instead of its lines.
+func ParameterValueDiff(parameterType, current, next string) []string {
+	separator := "\n"
+	if parameterType == cloud.ParameterTypeStringList {
+		separator = ","
+	}
+	before, after := strings.Split(current, separator), strings.Split(next, separator)
+
+	// Find the longest common subsequence of the lines, from the end
+	common := make([][]int, len(before)+1)
+	for i := range common {
+		common[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	type diffLine struct {
+		op   byte // '-', '+' or ' '
+		text string
+	}
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(before) || j < len(after) {
+		switch {
+		case i < len(before) && j < len(after) && before[i] == after[j]:
+			lines = append(lines, diffLine{' ', before[i]})
+			i, j = i+1, j+1
+		case i < len(before) && (j == len(after) || common[i+1][j] >= common[i][j+1]):
+			lines = append(lines, diffLine{'-', before[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', after[j]})
+			j++
+		}
+	}
+
+	// Unchanged lines away from the changes are collapsed
+	changed := func(k int) bool { return k >= 0 && k < len(lines) && lines[k].op != ' ' }
+	var diff []string
+	collapsed := false
+	for k, line := range lines {
+		switch {
+		case line.op == '-':
+			diff = append(diff, diffRemovedStyle.Render("- "+line.text))
+		case line.op == '+':
+			diff = append(diff, diffAddedStyle.Render("+ "+line.text))
+		case changed(k-1) || changed(k+1):
+			diff = append(diff, "  "+line.text)
+		default:
+			if !collapsed {
+				diff = append(diff, "  …")
+			}
+			collapsed = true
+			continue
+		}
+		collapsed = false
+	}
+
+	if len(diff) > maxParameterDiffLines {
+		more := len(diff) - maxParameterDiffLines
+		diff = append(diff[:maxParameterDiffLines], fmt.Sprintf("  … %d more lines", more))
+	}
+	return diff
+}
+
+// renderParameterEditor renders the editor of a new version of a parameter's value
+func renderParameterEditor(m *model.Model) string {
+	return renderTextEditor(m, constants.TitleParameterEdit, m.IsParameterInputMode)
+}
+
+// getParametersContextText returns the context text for the Parameter Store views
+func getParametersContextText(m *model.Model) string {
+	context := fmt.Sprintf("Profile: %s\nRegion: %s", m.AwsProfile, m.AwsRegion)
+	if m.CurrentView == constants.ViewParameters {
+		return context + fmt.Sprintf("\nPath: %s\nParameters: %d", m.ParameterPath, len(m.Parameters))
+	}
+	parameter := m.SelectedParameter
+	if parameter == nil {
+		return context
+	}
+	context += fmt.Sprintf("\nParameter: %s\nType: %s\nVersion: %d", parameter.Name, parameter.Type, parameter.Version)
+
+	switch m.CurrentView {
+	case constants.ViewParameterDetails:
+		if parameter.Secure() && (m.ParameterValue == nil || !m.ParameterValue.Decrypted) {
+			context += "\n" + logRequestStyle.Render("The value is encrypted until it's revealed")
+		}
+	case constants.ViewParameterHistory:
+		context += fmt.Sprintf("\nVersions: %d", len(m.ParameterHistory))
+	case constants.ViewParameterEdit:
+		if parameter.Type == cloud.ParameterTypeStringList {
+			context += "\n" + logRequestStyle.Render("Separate the items of the list with commas")
+		}
+	}
+	return context
+}
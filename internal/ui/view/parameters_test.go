@@ -0,0 +1,49 @@
+package view
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+)
+
+func TestParameterValueDiff(t *testing.T) {
+	testCases := []struct {
+		name          string
+		parameterType string
+		current       string
+		next          string
+		want          []string
+	}{
+		{
+			name:          "Single value",
+			parameterType: cloud.ParameterTypeString,
+			current:       "30",
+			next:          "60",
+			want:          []string{"- 30", "+ 60"},
+		},
+		{
+			name:          "Unchanged lines away from the change are collapsed",
+			parameterType: cloud.ParameterTypeString,
+			current:       "host=db\nport=5432\nuser=app\npool=10\ntimeout=30",
+			next:          "host=db\nport=5432\nuser=app\npool=20\ntimeout=30",
+			want:          []string{"  …", "  user=app", "- pool=10", "+ pool=20", "  timeout=30"},
+		},
+		{
+			name:          "Items of a list",
+			parameterType: cloud.ParameterTypeStringList,
+			current:       "a,b,c",
+			next:          "a,c,d",
+			want:          []string{"  a", "- b", "  c", "+ d"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ParameterValueDiff(tc.parameterType, tc.current, tc.next)
+			if strings.Join(got, "\n") != strings.Join(tc.want, "\n") {
+				t.Errorf("Expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package view
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// getQueuesColumns returns the columns of the queues list
+func getQueuesColumns() []table.Column {
+	return []table.Column{
+		{Title: "Queue", Width: constants.TableWideWidth},
+		{Title: "Visible", Width: constants.TableCompactWidth},
+		{Title: "In Flight", Width: constants.TableCompactWidth},
+		{Title: "Delayed", Width: constants.TableCompactWidth},
+		{Title: "Oldest", Width: constants.TableCompactWidth},
+		{Title: "Dead-Letter", Width: constants.TableDefaultWidth},
+	}
+}
+
+// getQueuesRows returns a row for each queue with its message counts and dead-letter relationship
+func getQueuesRows(m *model.Model) []table.Row {
+	rows := make([]table.Row, 0, len(m.Queues))
+	for i := range m.Queues {
+		queue := &m.Queues[i]
+		rows = append(rows, table.Row{
+			queue.Name,
+			fmt.Sprintf("%d", queue.Visible),
+			fmt.Sprintf("%d", queue.InFlight),
+			fmt.Sprintf("%d", queue.Delayed),
+			queueMessageAge(queue),
+			queueDeadLetter(queue),
+		})
+	}
+	return rows
+}
+
+// getQueueDetailsRows returns the settings and message counts of the selected queue, the queues it
+// shares failed messages with, and its actions. Only a dead-letter queue can be redriven.
+func getQueueDetailsRows(m *model.Model) []table.Row {
+	queue := m.SelectedQueue
+	if queue == nil {
+		return []table.Row{}
+	}
+
+	queueType := "Standard"
+	if queue.FIFO {
+		queueType = "FIFO"
+	}
+	rows := []table.Row{
+		{"Name", queue.Name},
+		{"Type", queueType},
+		{"Visible Messages", fmt.Sprintf("%d", queue.Visible)},
+		{"In Flight", fmt.Sprintf("%d", queue.InFlight)},
+		{"Delayed", fmt.Sprintf("%d", queue.Delayed)},
+		{"Oldest Message", queueMessageAge(queue)},
+		{"Visibility Timeout", formatBuildDuration(queue.VisibilityTimeout)},
+		{"Retention", formatTimeRange(queue.RetentionPeriod)},
+		{"Delivery Delay", formatBuildDuration(queue.Delay)},
+		{"Created", formatTimestamp(queue.Created)},
+		{"Last Modified", formatTimestamp(queue.LastModified)},
+		{"URL", queue.URL},
+	}
+	if queue.DeadLetterQueue != "" {
+		rows = append(rows, table.Row{
+			"Dead-Letter Queue",
+			fmt.Sprintf("%s after %d receives", queueName(queue.DeadLetterQueue), queue.MaxReceiveCount),
+		})
+	}
+	for _, source := range queue.SourceQueues {
+		rows = append(rows, table.Row{constants.SourceQueueRowPrefix + queueName(source), source})
+	}
+
+	rows = append(rows,
+		table.Row{constants.ActionPeekMessages, fmt.Sprintf("Show up to %d messages without deleting them", constants.MaxPeekedMessages)},
+		table.Row{constants.ActionSendMessage, "Send a message to the queue"},
+		table.Row{constants.ActionPurgeQueue, "Delete all the messages of the queue"},
+	)
+	if queue.IsDeadLetterQueue() {
+		rows = append(rows, table.Row{constants.ActionRedriveMessages, "Move the messages back to their source queues"})
+	}
+	return rows
+}
+
+// getQueueMessagesColumns returns the columns of the messages peeked from a queue
+func getQueueMessagesColumns() []table.Column {
+	return []table.Column{
+		{Title: "Message ID", Width: constants.TableDefaultWidth},
+		{Title: "Sent", Width: constants.TableNarrowWidth},
+		{Title: "Receives", Width: constants.TableCompactWidth},
+		{Title: "Group", Width: constants.TableCompactWidth},
+		{Title: "Body", Width: constants.TableDescWidth},
+	}
+}
+
+// getQueueMessagesRows returns a row for each message peeked from the selected queue
+func getQueueMessagesRows(m *model.Model) []table.Row {
+	rows := make([]table.Row, 0, len(m.QueueMessages))
+	for _, message := range m.QueueMessages {
+		rows = append(rows, table.Row{
+			message.ID,
+			formatTimestamp(message.SentAt),
+			fmt.Sprintf("%d", message.ReceiveCount),
+			valueOrNone(message.GroupID),
+			payloadPreview(message.Body),
+		})
+	}
+	return rows
+}
+
+// QueueMessageContent returns the content of the message viewer: the string attributes of a
+// message followed by its body, formatted when it's JSON
+func QueueMessageContent(message *cloud.QueueMessage) string {
+	if message == nil {
+		return ""
+	}
+
+	var lines []string
+	names := make([]string, 0, len(message.Attributes))
+	for name := range message.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		lines = append(lines, logRequestStyle.Render(fmt.Sprintf("%s: %s", name, message.Attributes[name])))
+	}
+	if len(lines) > 0 {
+		lines = append(lines, "")
+	}
+
+	body := message.Body
+	if node, err := parseJSON(body); err == nil {
+		body = renderJSON(node, 0)
+	}
+	return strings.Join(append(lines, body), "\n")
+}
+
+// QueueSendDetails returns the details of a test message shown before it's sent
+func QueueSendDetails(queue *cloud.Queue, body string) []string {
+	details := []string{fmt.Sprintf("Queue: %s", queue.Name)}
+	if queue.FIFO {
+		details = append(details, fmt.Sprintf("Message Group: %s", cloud.TestMessageGroup))
+	}
+	return append(details, fmt.Sprintf("Body: %s", payloadPreview(body)))
+}
+
+// renderQueueMessage renders the viewer of a peeked message
+func renderQueueMessage(m *model.Model) string {
+	if m.SelectedQueueMessage == nil {
+		return ""
+	}
+	return renderFileViewer(m, m.SelectedQueueMessage.ID)
+}
+
+// renderQueueSend renders the editor of a test message
+func renderQueueSend(m *model.Model) string {
+	return renderTextEditor(m, constants.TitleQueueSend, m.IsQueueInputMode)
+}
+
+// queueDeadLetter returns the dead-letter queue of a queue, or how many queues it's the
+// dead-letter queue of
+func queueDeadLetter(queue *cloud.Queue) string {
+	switch {
+	case len(queue.SourceQueues) == 1:
+		return "DLQ of " + queueName(queue.SourceQueues[0])
+	case queue.IsDeadLetterQueue():
+		return fmt.Sprintf("DLQ of %d queues", len(queue.SourceQueues))
+	case queue.DeadLetterQueue != "":
+		return "→ " + queueName(queue.DeadLetterQueue)
+	default:
+		return "-"
+	}
+}
+
+// queueName returns the name of a queue from its ARN
+func queueName(arn string) string {
+	return arn[strings.LastIndex(arn, ":")+1:]
+}
+
+// queueMessageAge returns the age of the oldest message of a queue, or nothing when CloudWatch
+// couldn't be read for it
+func queueMessageAge(queue *cloud.Queue) string {
+	if len(queue.Errors) > 0 {
+		return ""
+	}
+	return formatMessageAge(queue.OldestMessageAge)
+}
+
+// formatMessageAge formats the age of a message, e.g. 45s, 12m30s or 2d04h
+func formatMessageAge(age time.Duration) string {
+	if age >= 24*time.Hour {
+		return fmt.Sprintf("%dd%02dh", age/(24*time.Hour), age%(24*time.Hour)/time.Hour)
+	}
+	return formatBuildDuration(age)
+}
+
+// getQueuesContextText returns the context text for the SQS views
+func getQueuesContextText(m *model.Model) string {
+	context := fmt.Sprintf("Profile: %s\nRegion: %s", m.AwsProfile, m.AwsRegion)
+	if m.CurrentView == constants.ViewQueues {
+		var visible int64
+		var unknownAge []string
+		for _, queue := range m.Queues {
+			visible += queue.Visible
+			if len(queue.Errors) > 0 {
+				unknownAge = append(unknownAge, queue.Name)
+			}
+		}
+		context += fmt.Sprintf("\nQueues: %d\nVisible Messages: %d", len(m.Queues), visible)
+		if len(unknownAge) > 0 {
+			context += "\n" + logWarningStyle.Render(fmt.Sprintf("Oldest message age unavailable: %s", strings.Join(unknownAge, ", ")))
+		}
+		return context
+	}
+
+	queue := m.SelectedQueue
+	if queue == nil {
+		return context
+	}
+	context += fmt.Sprintf("\nQueue: %s", queue.Name)
+
+	switch m.CurrentView {
+	case constants.ViewQueueDetails:
+		for _, err := range queue.Errors {
+			context += "\n" + logWarningStyle.Render(err)
+		}
+		if m.ManualInput {
+			context += "\n\n" + logWarningStyle.Render(fmt.Sprintf("Purging deletes all %d messages of the queue and can't be undone",
+				queue.Visible+queue.InFlight+queue.Delayed))
+		} else if queue.DeadLetterQueue != "" {
+			context += "\n" + logRequestStyle.Render(fmt.Sprintf("Peeking counts as a receive; messages move to %s after %d",
+				queueName(queue.DeadLetterQueue), queue.MaxReceiveCount))
+		}
+	case constants.ViewQueueMessages:
+		context += fmt.Sprintf("\nPeeked Messages: %d", len(m.QueueMessages))
+	case constants.ViewQueueMessage:
+		if message := m.SelectedQueueMessage; message != nil {
+			context += fmt.Sprintf("\nMessage: %s\nSent: %s\nReceives: %d", message.ID, formatTimestamp(message.SentAt), message.ReceiveCount)
+		}
+	case constants.ViewQueueSend:
+		if queue.FIFO {
+			context += "\n" + logRequestStyle.Render(fmt.Sprintf("Sent to the %s message group", cloud.TestMessageGroup))
+		}
+	}
+	return context
+}
@@ -0,0 +1,91 @@
+package view
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+func TestQueueDeadLetter(t *testing.T) {
+	testCases := []struct {
+		name  string
+		queue cloud.Queue
+		want  string
+	}{
+		{
+			name:  "Queue with a dead-letter queue",
+			queue: cloud.Queue{DeadLetterQueue: "arn:aws:sqs:us-east-1:123456789012:orders-dlq"},
+			want:  "→ orders-dlq",
+		},
+		{
+			name:  "Dead-letter queue of one queue",
+			queue: cloud.Queue{SourceQueues: []string{"arn:aws:sqs:us-east-1:123456789012:orders"}},
+			want:  "DLQ of orders",
+		},
+		{
+			name: "Dead-letter queue of several queues",
+			queue: cloud.Queue{SourceQueues: []string{
+				"arn:aws:sqs:us-east-1:123456789012:orders",
+				"arn:aws:sqs:us-east-1:123456789012:refunds",
+			}},
+			want: "DLQ of 2 queues",
+		},
+		{
+			name: "Queue without a dead-letter queue",
+			want: "-",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := queueDeadLetter(&tc.queue); got != tc.want {
+				t.Errorf("Expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatMessageAge(t *testing.T) {
+	testCases := []struct {
+		age  time.Duration
+		want string
+	}{
+		{age: 0, want: formatBuildDuration(0)},
+		{age: 12*time.Minute + 30*time.Second, want: formatBuildDuration(12*time.Minute + 30*time.Second)},
+		{age: 52 * time.Hour, want: "2d04h"},
+		{age: 14 * 24 * time.Hour, want: "14d00h"},
+	}
+
+	for _, tc := range testCases {
+		if got := formatMessageAge(tc.age); got != tc.want {
+			t.Errorf("Expected %q for %s, got %q", tc.want, tc.age, got)
+		}
+	}
+}
+
+func TestQueuesWithoutMessageAge(t *testing.T) {
+	m := model.New()
+	m.CurrentView = constants.ViewQueues
+	m.Queues = []cloud.Queue{
+		{Name: "orders", OldestMessageAge: 90 * time.Second},
+		{Name: "refunds", Errors: []string{"failed to get queue metrics: access denied"}},
+	}
+
+	rows := getQueuesRows(m)
+	if rows[0][4] != "1m30s" || rows[1][4] != "" {
+		t.Errorf("Expected an age only for the queue with metrics, got %q and %q", rows[0][4], rows[1][4])
+	}
+	if context := getQueuesContextText(m); !strings.Contains(context, "Oldest message age unavailable: refunds") {
+		t.Errorf("Expected the queues without an age, got %q", context)
+	}
+
+	m.CurrentView = constants.ViewQueueDetails
+	m.SelectedQueue = &m.Queues[1]
+	if context := getQueuesContextText(m); !strings.Contains(context, "access denied") {
+		t.Errorf("Expected the error of the selected queue, got %q", context)
+	}
+}
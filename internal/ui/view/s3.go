@@ -0,0 +1,151 @@
+package view
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// S3ParentPrefix is the row that leads to the parent prefix in the S3 browser
+const S3ParentPrefix = ".."
+
+// getS3BucketsColumns returns the columns of the bucket list
+func getS3BucketsColumns() []table.Column {
+	return []table.Column{
+		{Title: "Bucket", Width: constants.TableDescWidth},
+		{Title: "Region", Width: constants.TableNarrowWidth},
+		{Title: "Created", Width: constants.TableNarrowWidth},
+	}
+}
+
+// getS3BucketsRows returns a row for each bucket of the account
+func getS3BucketsRows(m *model.Model) []table.Row {
+	rows := make([]table.Row, 0, len(m.S3Buckets))
+	for _, bucket := range m.S3Buckets {
+		rows = append(rows, table.Row{bucket.Name, valueOrNone(bucket.Region), formatTimestamp(bucket.CreationDate)})
+	}
+	return rows
+}
+
+// getS3ObjectsColumns returns the columns of the object browser
+func getS3ObjectsColumns() []table.Column {
+	return []table.Column{
+		{Title: "Name", Width: constants.TableDescWidth},
+		{Title: "Size", Width: constants.TableCompactWidth},
+		{Title: "Storage Class", Width: constants.TableNarrowWidth},
+		{Title: "Last Modified", Width: constants.TableNarrowWidth},
+	}
+}
+
+// getS3ObjectsRows returns a row for each prefix and object on the page being browsed, with
+// prefixes marked by a trailing slash like directories
+func getS3ObjectsRows(m *model.Model) []table.Row {
+	if len(m.S3Listings) == 0 {
+		return []table.Row{}
+	}
+	page := m.S3Listings[len(m.S3Listings)-1].Page
+
+	var rows []table.Row
+	if page.Prefix != "" {
+		rows = append(rows, table.Row{S3ParentPrefix, "", "", ""})
+	}
+	for _, object := range page.Objects {
+		if object.IsPrefix {
+			rows = append(rows, table.Row{object.Name(page.Prefix), "", "", ""})
+			continue
+		}
+		rows = append(rows, table.Row{
+			object.Name(page.Prefix),
+			formatBytes(object.Size),
+			object.StorageClass,
+			formatTimestamp(object.LastModified),
+		})
+	}
+	return rows
+}
+
+// getS3ObjectDetailsRows returns the properties of an object, followed by its user metadata and tags
+func getS3ObjectDetailsRows(m *model.Model) []table.Row {
+	details := m.S3ObjectDetails
+	if details == nil {
+		return []table.Row{}
+	}
+
+	rows := []table.Row{
+		{"Key", details.Key},
+		{"Size", fmt.Sprintf("%s (%d bytes)", formatBytes(details.Size), details.Size)},
+		{"Content Type", valueOrNone(details.ContentType)},
+		{"Storage Class", details.StorageClass},
+		{"Last Modified", formatTimestamp(details.LastModified)},
+		{"ETag", details.ETag},
+		{"Version", valueOrNone(details.VersionID)},
+		{"Encryption", valueOrNone(details.ServerSideEncryption)},
+	}
+	for _, key := range sortedKeys(details.Metadata) {
+		rows = append(rows, table.Row{"Metadata: " + key, details.Metadata[key]})
+	}
+	for _, key := range sortedKeys(details.Tags) {
+		rows = append(rows, table.Row{"Tag: " + key, details.Tags[key]})
+	}
+	return rows
+}
+
+// getS3ContextText returns the context text for the S3 browser views
+func getS3ContextText(m *model.Model) string {
+	context := fmt.Sprintf("Profile: %s\nRegion: %s", m.AwsProfile, m.AwsRegion)
+	if m.CurrentView == constants.ViewS3Buckets {
+		return context + fmt.Sprintf("\nBuckets: %d", len(m.S3Buckets))
+	}
+	if m.SelectedBucket == nil {
+		return context
+	}
+	context += fmt.Sprintf("\nBucket: %s (%s)", m.SelectedBucket.Name, valueOrNone(m.SelectedBucket.Region))
+
+	switch m.CurrentView {
+	case constants.ViewS3ObjectDetails:
+		if m.S3ObjectDetails != nil && m.S3ObjectDetails.TagsError != "" {
+			context += "\n" + logWarningStyle.Render("Tags unavailable: "+m.S3ObjectDetails.TagsError)
+		}
+		return context
+	case constants.ViewS3Presign:
+		if m.ManualInput && m.S3InputField != "" {
+			context += fmt.Sprintf("\n\nEditing: %s", m.S3InputField)
+		}
+		return context
+	case constants.ViewS3Preview:
+		return context + getS3PreviewContextText(m)
+	}
+
+	if len(m.S3Listings) == 0 {
+		return context
+	}
+	listing := m.S3Listings[len(m.S3Listings)-1]
+	context += fmt.Sprintf("\nPrefix: /%s\nPage: %d", listing.Page.Prefix, listing.PageNumber())
+	if listing.Page.NextToken != "" {
+		context += fmt.Sprintf(" (more with %s)", constants.KeyNextPage)
+	}
+	return context
+}
+
+// formatTimestamp formats a point in time in the local time zone, or "-" when it's unknown
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,148 @@
+package view
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// maxUploadSummaryFiles is the most files of an upload listed before confirming it
+const maxUploadSummaryFiles = 5
+
+// getS3PresignRows returns the rows of the presign form
+func getS3PresignRows(m *model.Model) []table.Row {
+	request := m.S3Presign
+	return []table.Row{
+		{constants.SettingPresignMethod, request.Method},
+		{constants.SettingPresignKey, request.Key},
+		{constants.SettingPresignExpiry, request.Expiry.String()},
+		{constants.SettingPresignURL, ""},
+	}
+}
+
+// renderS3Presign renders the presign form, followed by the URL input or the last URL generated.
+// The URL is printed whole so it can be copied from the terminal.
+func renderS3Presign(m *model.Model) string {
+	if m.ManualInput {
+		return fmt.Sprintf("%s\n%s", renderTable(m), m.TextInput.View())
+	}
+	if m.S3PresignedURL == nil {
+		return renderTable(m)
+	}
+
+	url := m.S3PresignedURL
+	header := logRequestStyle.Render(fmt.Sprintf("%s URL, valid until %s:", url.Method, formatTimestamp(url.Expires)))
+	return fmt.Sprintf("%s\n%s\n%s", renderTable(m), header, url.URL)
+}
+
+// S3DownloadSummary returns the lines describing a download awaiting confirmation
+func S3DownloadSummary(bucket cloud.S3Bucket, object cloud.S3Object, target string) []string {
+	summary := []string{
+		fmt.Sprintf("Object: s3://%s/%s", bucket.Name, object.Key),
+		fmt.Sprintf("Size: %s", formatBytes(object.Size)),
+		fmt.Sprintf("Storage Class: %s", object.StorageClass),
+		fmt.Sprintf("Local File: %s", target),
+	}
+	if _, err := os.Stat(target); err == nil {
+		summary = append(summary, logWarningStyle.Render("The local file exists and will be overwritten"))
+	}
+	if object.StorageClass == "GLACIER" || object.StorageClass == "DEEP_ARCHIVE" {
+		summary = append(summary, logWarningStyle.Render(fmt.Sprintf("Objects in %s must be restored before they can be downloaded", object.StorageClass)))
+	}
+	return summary
+}
+
+// S3UploadSummary returns the lines describing an upload awaiting confirmation, listing the
+// first few keys the files are uploaded to
+func S3UploadSummary(bucket cloud.S3Bucket, upload *cloud.S3Upload) []string {
+	summary := []string{
+		fmt.Sprintf("From: %s", upload.LocalPath),
+		fmt.Sprintf("To: s3://%s/%s", bucket.Name, upload.Prefix),
+		fmt.Sprintf("Files: %d, %s", len(upload.Files), formatBytes(upload.Size)),
+	}
+	for i, file := range upload.Files {
+		if i == maxUploadSummaryFiles {
+			summary = append(summary, fmt.Sprintf("  … and %d more", len(upload.Files)-i))
+			break
+		}
+		summary = append(summary, "  "+file.Key)
+	}
+	return summary
+}
+
+// S3PreviewContent returns the start of an object as shown in the preview: JSON indented and
+// colorized, other text with line numbers, or a note for binary and empty objects
+func S3PreviewContent(preview *cloud.S3ObjectPreview) string {
+	if preview == nil {
+		return ""
+	}
+	if preview.Size == 0 {
+		return "(empty object)"
+	}
+
+	data := preview.Data
+	if preview.Truncated {
+		data = trimPartialRune(data)
+	}
+	if bytes.IndexByte(data, 0) >= 0 || !utf8.Valid(data) {
+		return fmt.Sprintf("(binary object, %s)", formatBytes(preview.Size))
+	}
+
+	if !preview.Truncated && isJSONObject(preview) {
+		if node, err := parseJSON(string(data)); err == nil {
+			return renderJSON(node, 0)
+		}
+	}
+
+	content := PackageFileContent(data)
+	if preview.Truncated {
+		content += "\n" + logWarningStyle.Render(fmt.Sprintf("(preview truncated at %s of %s)",
+			formatBytes(int64(len(data))), formatBytes(preview.Size)))
+	}
+	return content
+}
+
+// renderS3Preview renders the preview viewer for the object being previewed
+func renderS3Preview(m *model.Model) string {
+	if m.S3Preview == nil {
+		return ""
+	}
+	return renderFileViewer(m, m.S3Preview.Key)
+}
+
+// getS3PreviewContextText returns the lines the preview adds to the S3 context
+func getS3PreviewContextText(m *model.Model) string {
+	preview := m.S3Preview
+	if preview == nil {
+		return ""
+	}
+	return fmt.Sprintf("\nContent Type: %s\nSize: %s", valueOrNone(preview.ContentType), formatBytes(preview.Size))
+}
+
+// isJSONObject returns whether an object holds JSON, going by its content type or extension
+func isJSONObject(preview *cloud.S3ObjectPreview) bool {
+	return strings.Contains(preview.ContentType, "json") || strings.HasSuffix(strings.ToLower(preview.Key), ".json")
+}
+
+// trimPartialRune cuts off a character left incomplete at the end of the data, as a preview can
+// end partway through one
+func trimPartialRune(data []byte) []byte {
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if !utf8.RuneStart(data[i]) {
+			continue
+		}
+		if !utf8.FullRune(data[i:]) {
+			return data[:i]
+		}
+		break
+	}
+	return data
+}
@@ -0,0 +1,42 @@
+package view
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+func TestS3PreviewContent(t *testing.T) {
+	testCases := []struct {
+		name     string
+		preview  *cloud.S3ObjectPreview
+		expected string
+	}{
+		{name: "Empty", preview: &cloud.S3ObjectPreview{Key: "empty.txt"}, expected: "(empty object)"},
+		{name: "Text", preview: &cloud.S3ObjectPreview{Key: "a.txt", Size: 4, Data: []byte("a\nb\n")}, expected: "1 a\n2 b"},
+		{name: "Binary", preview: &cloud.S3ObjectPreview{Key: "a.png", Size: 2048, Data: []byte{0x89, 'P', 'N', 'G', 0x00}, Truncated: true}, expected: "(binary object, 2.00 KB)"},
+		{name: "JSON", preview: &cloud.S3ObjectPreview{Key: "a.json", Size: 9, Data: []byte(`{"a": 1}` + "\n")}, expected: `"a": 1`},
+		{name: "Truncated JSON", preview: &cloud.S3ObjectPreview{Key: "a.json", Size: 100, Data: []byte(`{"a": `), Truncated: true}, expected: "(preview truncated at 6 bytes of 100 bytes)"},
+		{name: "Partial character", preview: &cloud.S3ObjectPreview{Key: "a.txt", Size: 100, Data: []byte("caf\xc3"), Truncated: true}, expected: "1 caf"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := S3PreviewContent(tc.preview); !strings.Contains(got, tc.expected) {
+				t.Errorf("Expected %q in %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestRenderActionProgress(t *testing.T) {
+	got := renderActionProgress(&model.ActionProgress{Done: 512, Total: 2048})
+	if !strings.Contains(got, " 25% 512 bytes of 2.00 KB") {
+		t.Errorf("Expected a quarter done, got %q", got)
+	}
+	if filled := strings.Count(got, "█"); filled != 7 {
+		t.Errorf("Expected 7 of 30 cells filled, got %d", filled)
+	}
+}
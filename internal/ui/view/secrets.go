@@ -0,0 +1,205 @@
+package view
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// hiddenSecretValue is shown in place of a secret value, or the value of a key of a JSON secret,
+// that isn't revealed
+const hiddenSecretValue = "••••••••"
+
+// SecretField is a key of a JSON secret with its value
+type SecretField struct {
+	Key   string
+	Value string
+}
+
+// getSecretsColumns returns the columns of the secrets list
+func getSecretsColumns() []table.Column {
+	return []table.Column{
+		{Title: "Secret", Width: constants.TableWideWidth},
+		{Title: "Rotation", Width: constants.TableNarrowWidth},
+		{Title: "Last Changed", Width: constants.TableNarrowWidth},
+		{Title: "Last Rotated", Width: constants.TableNarrowWidth},
+		{Title: "Next Rotation", Width: constants.TableNarrowWidth},
+	}
+}
+
+// getSecretsRows returns a row for each secret with its rotation status
+func getSecretsRows(m *model.Model) []table.Row {
+	rows := make([]table.Row, 0, len(m.Secrets))
+	for i := range m.Secrets {
+		secret := &m.Secrets[i]
+		rows = append(rows, table.Row{
+			secret.Name,
+			SecretRotation(secret),
+			formatTimestamp(secret.LastChanged),
+			formatTimestamp(secret.LastRotated),
+			formatTimestamp(secret.NextRotation),
+		})
+	}
+	return rows
+}
+
+// getSecretDetailsRows returns the properties of the selected secret and its value, masked unless
+// it's revealed, ending with its rotate action. The keys of a JSON secret are listed as rows of
+// their own, and stay listed with masked values once it's hidden again.
+func getSecretDetailsRows(m *model.Model) []table.Row {
+	secret := m.SelectedSecret
+	if secret == nil {
+		return []table.Row{}
+	}
+
+	rows := []table.Row{
+		{"Name", secret.Name},
+		{"Description", valueOrNone(secret.Description)},
+		{"ARN", secret.ARN},
+		{"KMS Key", valueOrNone(secret.KMSKeyID)},
+	}
+	if secret.OwningService != "" {
+		rows = append(rows, table.Row{"Managed By", secret.OwningService})
+	}
+	rows = append(rows,
+		table.Row{"Created", formatTimestamp(secret.CreatedDate)},
+		table.Row{"Last Changed", formatTimestamp(secret.LastChanged)},
+		table.Row{"Last Accessed", formatTimestamp(secret.LastAccessed)},
+		table.Row{"Rotation", SecretRotation(secret)},
+		table.Row{"Last Rotated", formatTimestamp(secret.LastRotated)},
+		table.Row{"Next Rotation", formatTimestamp(secret.NextRotation)},
+	)
+	if secret.RotationWindow != "" {
+		rows = append(rows, table.Row{"Rotation Window", secret.RotationWindow})
+	}
+	if secret.RotationLambdaARN != "" {
+		rows = append(rows, table.Row{"Rotation Function", secret.RotationLambdaARN})
+	}
+
+	value := m.SecretValue
+	switch {
+	case value == nil && len(m.SecretKeys) > 0:
+		for _, key := range m.SecretKeys {
+			rows = append(rows, table.Row{constants.SecretKeyRowPrefix + key, hiddenSecretValue})
+		}
+	case value == nil:
+		rows = append(rows, table.Row{"Value", hiddenSecretValue})
+	case value.Binary:
+		rows = append(rows, table.Row{"Value", fmt.Sprintf("(binary, %d bytes)", value.Size)})
+	default:
+		if fields := SecretFields(value.Value); fields != nil {
+			for _, field := range fields {
+				rows = append(rows, table.Row{
+					constants.SecretKeyRowPrefix + field.Key,
+					strings.Join(strings.Fields(field.Value), " "),
+				})
+			}
+		} else {
+			rows = append(rows, table.Row{"Value", strings.Join(strings.Fields(value.Value), " ")})
+		}
+	}
+	if value != nil {
+		version := value.VersionID
+		if len(value.Stages) > 0 {
+			version += fmt.Sprintf(" (%s)", strings.Join(value.Stages, ", "))
+		}
+		rows = append(rows, table.Row{"Version", version})
+	}
+
+	if secret.RotationEnabled {
+		rows = append(rows, table.Row{constants.ActionRotateSecret, ""})
+	}
+	return rows
+}
+
+// SecretFields returns the keys of a secret stored as a JSON object with their values, in key
+// order, or nil for any other secret. String values are returned without their quotes.
+func SecretFields(value string) []SecretField {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(value), &object); err != nil || len(object) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := make([]SecretField, 0, len(keys))
+	for _, key := range keys {
+		raw := object[key]
+		var text string
+		if err := json.Unmarshal(raw, &text); err != nil {
+			var compact bytes.Buffer
+			if json.Compact(&compact, raw) == nil {
+				text = compact.String()
+			} else {
+				text = string(raw)
+			}
+		}
+		fields = append(fields, SecretField{Key: key, Value: text})
+	}
+	return fields
+}
+
+// SecretRotation returns how a secret is rotated
+func SecretRotation(secret *cloud.Secret) string {
+	switch {
+	case !secret.RotationEnabled:
+		return "Disabled"
+	case secret.RotationSchedule != "":
+		return secret.RotationSchedule
+	case secret.RotationAfterDays > 0:
+		return fmt.Sprintf("Every %d days", secret.RotationAfterDays)
+	default:
+		return "Enabled"
+	}
+}
+
+// secretRotationOverdue returns whether a secret should have been rotated by now
+func secretRotationOverdue(secret *cloud.Secret) bool {
+	return secret.RotationEnabled && !secret.NextRotation.IsZero() && secret.NextRotation.Before(time.Now())
+}
+
+// getSecretsContextText returns the context text for the Secrets Manager views
+func getSecretsContextText(m *model.Model) string {
+	context := fmt.Sprintf("Profile: %s\nRegion: %s", m.AwsProfile, m.AwsRegion)
+	if m.CurrentView == constants.ViewSecrets {
+		context += fmt.Sprintf("\nSecrets: %d", len(m.Secrets))
+		overdue := 0
+		for i := range m.Secrets {
+			if secretRotationOverdue(&m.Secrets[i]) {
+				overdue++
+			}
+		}
+		if overdue > 0 {
+			context += "\n" + logWarningStyle.Render(fmt.Sprintf("Rotation overdue: %d", overdue))
+		}
+		return context
+	}
+
+	secret := m.SelectedSecret
+	if secret == nil {
+		return context
+	}
+	context += fmt.Sprintf("\nSecret: %s\nRotation: %s", secret.Name, SecretRotation(secret))
+	if secretRotationOverdue(secret) {
+		context += "\n" + logWarningStyle.Render("Rotation is overdue")
+	}
+	if m.SecretValue != nil {
+		context += "\n" + logWarningStyle.Render(fmt.Sprintf("The value is hidden again after %s", constants.SecretRevealTimeout))
+	} else {
+		context += "\n" + logRequestStyle.Render("The value is hidden until it's revealed")
+	}
+	return context
+}
@@ -0,0 +1,44 @@
+package view
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSecretFields(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		want  []SecretField
+	}{
+		{
+			name:  "Keys in order with strings unquoted",
+			value: `{"username":"app","port":5432,"options":{"ssl": true}}`,
+			want: []SecretField{
+				{Key: "options", Value: `{"ssl":true}`},
+				{Key: "port", Value: "5432"},
+				{Key: "username", Value: "app"},
+			},
+		},
+		{
+			name:  "Plain text",
+			value: "hunter2",
+		},
+		{
+			name:  "JSON that isn't an object",
+			value: `["a","b"]`,
+		},
+		{
+			name:  "Empty object",
+			value: `{}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := SecretFields(tc.value); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
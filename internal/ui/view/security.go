@@ -0,0 +1,125 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// getFunctionSecurityColumns returns the columns of the function security view
+func getFunctionSecurityColumns() []table.Column {
+	return []table.Column{
+		{Title: "Section", Width: constants.TableCompactWidth},
+		{Title: "Name", Width: constants.TableDefaultWidth},
+		{Title: "Detail", Width: constants.TableDescWidth},
+	}
+}
+
+// getFunctionSecurityRows returns the findings of a function's security review, followed by its
+// function URL, the statements of its resource-based policy and the policies of its execution role
+func getFunctionSecurityRows(m *model.Model) []table.Row {
+	security := m.FunctionSecurity
+	if security == nil {
+		return []table.Row{}
+	}
+
+	var rows []table.Row
+	for _, finding := range security.Findings {
+		rows = append(rows, table.Row{"! " + finding.Severity, finding.Subject, finding.Detail})
+	}
+
+	rows = append(rows, getFunctionURLRows(security.FunctionURL)...)
+
+	if len(security.PolicyStatements) == 0 {
+		rows = append(rows, table.Row{"Policy", "No resource-based policy", ""})
+	}
+	for i, statement := range security.PolicyStatements {
+		sid := statement.Sid
+		if sid == "" {
+			sid = fmt.Sprintf("Statement %d", i+1)
+		}
+		rows = append(rows,
+			table.Row{"Policy", sid, statement.Effect},
+			table.Row{"", "  Principals", joinOrNone(statement.Principals)},
+			table.Row{"", "  Actions", joinOrNone(statement.Actions)},
+		)
+		if len(statement.Conditions) == 0 {
+			rows = append(rows, table.Row{"", "  Conditions", "none"})
+		}
+		for _, condition := range statement.Conditions {
+			rows = append(rows, table.Row{"", "  Condition", condition})
+		}
+	}
+
+	if len(security.RolePolicies) == 0 {
+		rows = append(rows, table.Row{"Role", "No policies", ""})
+	}
+	for _, policy := range security.RolePolicies {
+		detail := policy.Arn
+		if policy.IsInline() {
+			detail = "Inline policy"
+		}
+		rows = append(rows, table.Row{"Role", policy.Name, detail})
+	}
+
+	return rows
+}
+
+// getFunctionURLRows returns the rows describing a function URL and its CORS settings
+func getFunctionURLRows(functionURL *cloud.FunctionURLConfig) []table.Row {
+	if functionURL == nil {
+		return []table.Row{{"URL", "No function URL", ""}}
+	}
+
+	rows := []table.Row{
+		{"URL", "Function URL", functionURL.URL},
+		{"URL", "Auth type", functionURL.AuthType},
+		{"URL", "Invoke mode", functionURL.InvokeMode},
+	}
+	cors := functionURL.Cors
+	if cors == nil {
+		return append(rows, table.Row{"CORS", "Not configured", ""})
+	}
+	return append(rows,
+		table.Row{"CORS", "Allow origins", joinOrNone(cors.AllowOrigins)},
+		table.Row{"CORS", "Allow methods", joinOrNone(cors.AllowMethods)},
+		table.Row{"CORS", "Allow headers", joinOrNone(cors.AllowHeaders)},
+		table.Row{"CORS", "Expose headers", joinOrNone(cors.ExposeHeaders)},
+		table.Row{"CORS", "Allow credentials", fmt.Sprintf("%t", cors.AllowCredentials)},
+		table.Row{"CORS", "Max age", fmt.Sprintf("%d seconds", cors.MaxAge)},
+	)
+}
+
+// getFunctionSecurityContextText returns the context text for the function security view
+func getFunctionSecurityContextText(m *model.Model) string {
+	security := m.FunctionSecurity
+	if security == nil {
+		return ""
+	}
+
+	counts := make(map[string]int)
+	for _, finding := range security.Findings {
+		counts[finding.Severity]++
+	}
+
+	context := fmt.Sprintf("Profile: %s\nRegion: %s\nFunction: %s\nRole: %s\nFindings: %d high, %d medium",
+		m.AwsProfile, m.AwsRegion, security.FunctionName, security.Role,
+		counts[cloud.SeverityHigh], counts[cloud.SeverityMedium])
+	for _, err := range security.Errors {
+		context += "\n" + logWarningStyle.Render("Skipped "+err)
+	}
+	return context
+}
+
+// joinOrNone joins a list with commas, or returns "none" for an empty list
+func joinOrNone(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	return strings.Join(values, ", ")
+}
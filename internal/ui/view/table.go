@@ -98,16 +98,195 @@ func getColumnsForView(m *model.Model) []table.Column {
 			{Title: "Last Updated", Width: constants.TableNarrowWidth},
 		}
 	case constants.ViewFunctionStatus:
+		if m.ShowFunctionMetrics {
+			return getFunctionMetricsColumns()
+		}
 		return []table.Column{
 			{Title: "Function", Width: constants.TableWideWidth},
 			{Title: "Runtime", Width: constants.TableNarrowWidth},
-			{Title: "Last Updated", Width: constants.TableDefaultWidth},
+			{Title: "State", Width: constants.TableNarrowWidth},
+			{Title: "Last Updated", Width: constants.TableNarrowWidth},
 		}
 	case constants.ViewFunctionDetails:
 		return []table.Column{
 			{Title: "Property", Width: constants.TableDefaultWidth},
 			{Title: "Value", Width: constants.TableWideWidth},
 		}
+	case constants.ViewLambdaConfig:
+		return []table.Column{
+			{Title: "Setting", Width: constants.TableDefaultWidth},
+			{Title: "Current", Width: constants.TableNarrowWidth},
+			{Title: "New", Width: constants.TableNarrowWidth},
+		}
+	case constants.ViewLambdaDeploy:
+		return []table.Column{
+			{Title: "Setting", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableWideWidth},
+		}
+	case constants.ViewEventSourceScope:
+		return []table.Column{
+			{Title: "Scope", Width: constants.TableDefaultWidth},
+			{Title: "Description", Width: constants.TableDescWidth},
+		}
+	case constants.ViewEventSourceMappings:
+		return getEventSourceMappingColumns()
+	case constants.ViewEventSourceDetails:
+		return []table.Column{
+			{Title: "Property", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableDescWidth},
+		}
+	case constants.ViewHygieneOptions:
+		return []table.Column{
+			{Title: "Setting", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableWideWidth},
+		}
+	case constants.ViewHygieneReport:
+		return getHygieneReportColumns()
+	case constants.ViewLambdaBench:
+		return []table.Column{
+			{Title: "Setting", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableWideWidth},
+		}
+	case constants.ViewLambdaBenchResult:
+		return []table.Column{
+			{Title: "Metric", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableWideWidth},
+		}
+	case constants.ViewPackageBrowser:
+		return getPackageBrowserColumns()
+	case constants.ViewFunctionSecurity:
+		return getFunctionSecurityColumns()
+	case constants.ViewCompareOptions:
+		return []table.Column{
+			{Title: "Setting", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableDescWidth},
+		}
+	case constants.ViewFunctionCompare:
+		return getFunctionCompareColumns(m)
+	case constants.ViewAsyncConfig:
+		return []table.Column{
+			{Title: "Setting", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableDescWidth},
+		}
+	case constants.ViewFailedEvents:
+		return getFailedEventsColumns()
+	case constants.ViewS3Buckets:
+		return getS3BucketsColumns()
+	case constants.ViewS3Objects:
+		return getS3ObjectsColumns()
+	case constants.ViewS3Presign:
+		return []table.Column{
+			{Title: "Setting", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableDescWidth},
+		}
+	case constants.ViewS3ObjectDetails:
+		return []table.Column{
+			{Title: "Property", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableDescWidth},
+		}
+	case constants.ViewEC2Filters:
+		return []table.Column{
+			{Title: "Filter", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableDescWidth},
+		}
+	case constants.ViewEC2Instances:
+		return getEC2InstancesColumns()
+	case constants.ViewEC2InstanceDetails:
+		return []table.Column{
+			{Title: "Property", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableDescWidth},
+		}
+	case constants.ViewLogGroups:
+		return getLogGroupsColumns()
+	case constants.ViewSavedQueries:
+		return getSavedQueriesColumns()
+	case constants.ViewLogsResults:
+		return getLogsResultsColumns(m)
+	case constants.ViewAlarms:
+		return getAlarmsColumns()
+	case constants.ViewAlarmDetails:
+		return []table.Column{
+			{Title: "Property", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableDescWidth + 10},
+		}
+	case constants.ViewAlarmHistory:
+		return getAlarmHistoryColumns()
+	case constants.ViewECSClusters:
+		return getECSClustersColumns()
+	case constants.ViewECSServices:
+		return getECSServicesColumns()
+	case constants.ViewECSServiceDetails, constants.ViewECSTaskDetails:
+		return []table.Column{
+			{Title: "Property", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableDescWidth + 10},
+		}
+	case constants.ViewECSTasks:
+		return getECSTasksColumns()
+	case constants.ViewBuildProjects:
+		return getBuildProjectsColumns()
+	case constants.ViewBuilds:
+		return getBuildsColumns()
+	case constants.ViewStartBuild:
+		return []table.Column{
+			{Title: "Setting", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableDescWidth},
+		}
+	case constants.ViewBuildDetails:
+		return []table.Column{
+			{Title: "Property", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableDescWidth + 10},
+		}
+	case constants.ViewBuildLogs:
+		return getBuildLogsColumns(m)
+	case constants.ViewStacks:
+		return getStacksColumns()
+	case constants.ViewStackDetails:
+		return []table.Column{
+			{Title: "Property", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableDescWidth + 10},
+		}
+	case constants.ViewStackDrift:
+		return getStackDriftColumns()
+	case constants.ViewResourceDrift:
+		return getResourceDriftColumns()
+	case constants.ViewChangeSets:
+		return getChangeSetsColumns()
+	case constants.ViewChangeSetDetails:
+		return getChangeSetDetailsColumns()
+	case constants.ViewParameters:
+		return getParametersColumns()
+	case constants.ViewParameterDetails:
+		return []table.Column{
+			{Title: "Property", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableDescWidth + 10},
+		}
+	case constants.ViewParameterHistory:
+		return getParameterHistoryColumns()
+	case constants.ViewSecrets:
+		return getSecretsColumns()
+	case constants.ViewQueues:
+		return getQueuesColumns()
+	case constants.ViewQueueDetails:
+		return []table.Column{
+			{Title: "Property", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableDescWidth + 10},
+		}
+	case constants.ViewQueueMessages:
+		return getQueueMessagesColumns()
+	case constants.ViewTopics:
+		return getTopicsColumns()
+	case constants.ViewTopicDetails:
+		return []table.Column{
+			{Title: "Property", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableDescWidth + 10},
+		}
+	case constants.ViewSubscriptions:
+		return getSubscriptionsColumns()
+	case constants.ViewSecretDetails:
+		return []table.Column{
+			{Title: "Property", Width: constants.TableDefaultWidth},
+			{Title: "Value", Width: constants.TableDescWidth + 10},
+		}
 	case constants.ViewSummary:
 		return []table.Column{
 			{Title: "Type", Width: constants.TableDefaultWidth},
@@ -321,6 +500,12 @@ func getRowsForView(m *model.Model) []table.Row {
 			{"Reject", "Reject the pipeline stage"},
 		}
 	case constants.ViewExecutingAction:
+		if m.PendingAction != nil {
+			return []table.Row{
+				{"Execute", m.PendingAction.Description},
+				{"Cancel", "Cancel and return to main menu"},
+			}
+		}
 		if m.SelectedOperation != nil && m.SelectedOperation.Name == "Start Pipeline" {
 			return []table.Row{
 				{"Execute", "Start pipeline with latest commit"},
@@ -359,6 +544,10 @@ func getRowsForView(m *model.Model) []table.Row {
 				stage.LastUpdated,
 			}
 		}
+
+		// Add the alarms that share a prefix with the pipeline
+		rows = append(rows, getLinkedAlarmStageRows(m)...)
+
 		return rows
 	case constants.ViewFunctionStatus:
 		if m.Functions == nil {
@@ -366,6 +555,11 @@ func getRowsForView(m *model.Model) []table.Row {
 		}
 		rows := make([]table.Row, len(m.Functions))
 		for i, function := range m.Functions {
+			if m.ShowFunctionMetrics {
+				rows[i] = getFunctionMetricsRow(m, function)
+				continue
+			}
+
 			// Clean up timestamp by removing the milliseconds and timezone offset
 			lastUpdate := function.LastUpdate
 			if len(lastUpdate) > 19 { // Format: "2024-06-29T07:10:02.331+0000"
@@ -377,6 +571,7 @@ func getRowsForView(m *model.Model) []table.Row {
 			rows[i] = table.Row{
 				function.Name,
 				function.Runtime,
+				functionStateLabel(function),
 				lastUpdate,
 			}
 		}
@@ -413,6 +608,7 @@ func getRowsForView(m *model.Model) []table.Row {
 			{"Handler", function.Handler},
 			{"Memory", fmt.Sprintf("%d MB", function.Memory)},
 			{"Timeout", fmt.Sprintf("%d seconds", function.Timeout)},
+			{"Ephemeral Storage", fmt.Sprintf("%d MB", function.EphemeralStorage)},
 			{"Code Size", codeSizeFormatted},
 			{"Last Updated", lastUpdate},
 			{"Version", function.Version},
@@ -426,7 +622,126 @@ func getRowsForView(m *model.Model) []table.Row {
 			rows = append(rows, table.Row{"Log Group", function.LogGroup})
 		}
 
+		// Add the lifecycle state, dependencies and tags
+		rows = append(rows, getFunctionInventoryRows(function)...)
+
+		// Add the alarms that share a prefix with the function
+		rows = append(rows, getLinkedAlarmDetailRows(m)...)
+
+		// Add recent metrics once they've been requested
+		rows = append(rows, getFunctionMetricsDetailRows(m, function.Name)...)
+
 		return rows
+	case constants.ViewLambdaConfig:
+		return getLambdaConfigRows(m)
+	case constants.ViewLambdaDeploy:
+		return getLambdaDeployRows(m)
+	case constants.ViewEventSourceScope:
+		return getEventSourceScopeRows()
+	case constants.ViewEventSourceMappings:
+		return getEventSourceMappingRows(m)
+	case constants.ViewEventSourceDetails:
+		return getEventSourceDetailRows(m)
+	case constants.ViewHygieneOptions:
+		return getHygieneOptionsRows(m)
+	case constants.ViewHygieneReport:
+		return getHygieneReportRows(m)
+	case constants.ViewLambdaBench:
+		return getLambdaBenchOptionsRows(m)
+	case constants.ViewLambdaBenchResult:
+		return getLambdaBenchResultRows(m)
+	case constants.ViewPackageBrowser:
+		return getPackageBrowserRows(m)
+	case constants.ViewFunctionSecurity:
+		return getFunctionSecurityRows(m)
+	case constants.ViewCompareOptions:
+		return getCompareOptionsRows(m)
+	case constants.ViewFunctionCompare:
+		return getFunctionCompareRows(m)
+	case constants.ViewAsyncConfig:
+		return getAsyncConfigRows(m)
+	case constants.ViewFailedEvents:
+		return getFailedEventsRows(m)
+	case constants.ViewS3Buckets:
+		return getS3BucketsRows(m)
+	case constants.ViewS3Objects:
+		return getS3ObjectsRows(m)
+	case constants.ViewS3ObjectDetails:
+		return getS3ObjectDetailsRows(m)
+	case constants.ViewS3Presign:
+		return getS3PresignRows(m)
+	case constants.ViewEC2Filters:
+		return getEC2FilterRows(m)
+	case constants.ViewEC2Instances:
+		return getEC2InstancesRows(m)
+	case constants.ViewEC2InstanceDetails:
+		return getEC2InstanceDetailsRows(m)
+	case constants.ViewLogGroups:
+		return getLogGroupsRows(m)
+	case constants.ViewSavedQueries:
+		return getSavedQueriesRows(m)
+	case constants.ViewLogsResults:
+		return getLogsResultsRows(m)
+	case constants.ViewAlarms:
+		return getAlarmsRows(m)
+	case constants.ViewAlarmDetails:
+		return getAlarmDetailsRows(m)
+	case constants.ViewAlarmHistory:
+		return getAlarmHistoryRows(m)
+	case constants.ViewECSClusters:
+		return getECSClustersRows(m)
+	case constants.ViewECSServices:
+		return getECSServicesRows(m)
+	case constants.ViewECSServiceDetails:
+		return getECSServiceDetailsRows(m)
+	case constants.ViewECSTasks:
+		return getECSTasksRows(m)
+	case constants.ViewECSTaskDetails:
+		return getECSTaskDetailsRows(m)
+	case constants.ViewBuildProjects:
+		return getBuildProjectsRows(m)
+	case constants.ViewBuilds:
+		return getBuildsRows(m)
+	case constants.ViewStartBuild:
+		return getStartBuildRows(m)
+	case constants.ViewBuildDetails:
+		return getBuildDetailsRows(m)
+	case constants.ViewBuildLogs:
+		return getBuildLogsRows(m)
+	case constants.ViewStacks:
+		return getStacksRows(m)
+	case constants.ViewStackDetails:
+		return getStackDetailsRows(m)
+	case constants.ViewStackDrift:
+		return getStackDriftRows(m)
+	case constants.ViewResourceDrift:
+		return getResourceDriftRows(m)
+	case constants.ViewChangeSets:
+		return getChangeSetsRows(m)
+	case constants.ViewChangeSetDetails:
+		return getChangeSetDetailsRows(m)
+	case constants.ViewParameters:
+		return getParametersRows(m)
+	case constants.ViewParameterDetails:
+		return getParameterDetailsRows(m)
+	case constants.ViewParameterHistory:
+		return getParameterHistoryRows(m)
+	case constants.ViewSecrets:
+		return getSecretsRows(m)
+	case constants.ViewQueues:
+		return getQueuesRows(m)
+	case constants.ViewQueueDetails:
+		return getQueueDetailsRows(m)
+	case constants.ViewQueueMessages:
+		return getQueueMessagesRows(m)
+	case constants.ViewTopics:
+		return getTopicsRows(m)
+	case constants.ViewTopicDetails:
+		return getTopicDetailsRows(m)
+	case constants.ViewSubscriptions:
+		return getSubscriptionsRows(m)
+	case constants.ViewSecretDetails:
+		return getSecretDetailsRows(m)
 	case constants.ViewSummary:
 		if m.SelectedOperation != nil && m.SelectedOperation.Name == "Start Pipeline" {
 			if m.SelectedPipeline == nil {
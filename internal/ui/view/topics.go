@@ -0,0 +1,254 @@
+package view
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+	"github.com/HenryOwenz/cloudgate/internal/ui/constants"
+	"github.com/HenryOwenz/cloudgate/internal/ui/model"
+)
+
+// getTopicsColumns returns the columns of the topics list
+func getTopicsColumns() []table.Column {
+	return []table.Column{
+		{Title: "Topic", Width: constants.TableWideWidth},
+		{Title: "Type", Width: constants.TableCompactWidth},
+		{Title: "Confirmed", Width: constants.TableCompactWidth},
+		{Title: "Pending", Width: constants.TableCompactWidth},
+		{Title: "Display Name", Width: constants.TableDefaultWidth},
+	}
+}
+
+// getTopicsRows returns a row for each topic with its subscription counts
+func getTopicsRows(m *model.Model) []table.Row {
+	rows := make([]table.Row, 0, len(m.Topics))
+	for i := range m.Topics {
+		topic := &m.Topics[i]
+		rows = append(rows, table.Row{
+			topic.Name,
+			topicType(topic),
+			fmt.Sprintf("%d", topic.Confirmed),
+			fmt.Sprintf("%d", topic.Pending),
+			valueOrNone(topic.DisplayName),
+		})
+	}
+	return rows
+}
+
+// getTopicDetailsRows returns the settings and subscription counts of the selected topic, and its
+// actions
+func getTopicDetailsRows(m *model.Model) []table.Row {
+	topic := m.SelectedTopic
+	if topic == nil {
+		return []table.Row{}
+	}
+
+	rows := []table.Row{
+		{"Name", topic.Name},
+		{"ARN", topic.ARN},
+		{"Type", topicType(topic)},
+		{"Display Name", valueOrNone(topic.DisplayName)},
+		{"KMS Key", valueOrNone(topic.KMSKeyID)},
+	}
+	if topic.FIFO {
+		deduplication := "Disabled"
+		if topic.ContentBasedDeduplication {
+			deduplication = "Enabled"
+		}
+		rows = append(rows, table.Row{"Content-Based Deduplication", deduplication})
+	}
+	rows = append(rows,
+		table.Row{"Confirmed Subscriptions", fmt.Sprintf("%d", topic.Confirmed)},
+		table.Row{"Pending Subscriptions", fmt.Sprintf("%d", topic.Pending)},
+		table.Row{"Deleted Subscriptions", fmt.Sprintf("%d", topic.Deleted)},
+		table.Row{constants.ActionViewSubscriptions, "List the subscriptions with their protocol, endpoint and filter policy"},
+		table.Row{constants.ActionPublishMessage, "Publish a message with attributes to the topic"},
+	)
+	return rows
+}
+
+// getSubscriptionsColumns returns the columns of the subscriptions to a topic
+func getSubscriptionsColumns() []table.Column {
+	return []table.Column{
+		{Title: "Protocol", Width: constants.TableCompactWidth},
+		{Title: "Endpoint", Width: constants.TableWideWidth},
+		{Title: "Status", Width: constants.TableNarrowWidth},
+		{Title: "Filter Policy", Width: constants.TableDescWidth},
+	}
+}
+
+// getSubscriptionsRows returns a row for each subscription to the selected topic, in the order of
+// m.Subscriptions
+func getSubscriptionsRows(m *model.Model) []table.Row {
+	rows := make([]table.Row, 0, len(m.Subscriptions))
+	for i := range m.Subscriptions {
+		subscription := &m.Subscriptions[i]
+		policy := "-"
+		if subscription.FilterPolicy != "" {
+			policy = payloadPreview(subscription.FilterPolicy)
+		}
+		rows = append(rows, table.Row{
+			subscription.Protocol,
+			subscription.Endpoint,
+			subscriptionStatus(subscription),
+			policy,
+		})
+	}
+	return rows
+}
+
+// SubscriptionContent returns the content of the subscription viewer: the settings of a
+// subscription followed by its filter policy, formatted
+func SubscriptionContent(subscription *cloud.Subscription) string {
+	if subscription == nil {
+		return ""
+	}
+
+	rawDelivery := "Disabled"
+	if subscription.RawMessageDelivery {
+		rawDelivery = "Enabled"
+	}
+	lines := []string{
+		fmt.Sprintf("Protocol: %s", subscription.Protocol),
+		fmt.Sprintf("Endpoint: %s", subscription.Endpoint),
+		fmt.Sprintf("Status: %s", subscriptionStatus(subscription)),
+		fmt.Sprintf("ARN: %s", valueOrNone(subscription.ARN)),
+		fmt.Sprintf("Owner: %s", valueOrNone(subscription.Owner)),
+		fmt.Sprintf("Raw Message Delivery: %s", rawDelivery),
+		fmt.Sprintf("Dead-Letter Queue: %s", valueOrNone(subscription.DeadLetterQueue)),
+	}
+	for i, line := range lines {
+		lines[i] = logRequestStyle.Render(line)
+	}
+	lines = append(lines, "")
+
+	switch {
+	case subscription.PendingConfirmation && subscription.ARN == "":
+		lines = append(lines, "The filter policy can't be read until the subscription is confirmed")
+	case subscription.FilterPolicy == "":
+		lines = append(lines, "No filter policy: every message published to the topic is delivered")
+	default:
+		lines = append(lines, fmt.Sprintf("Filter policy on the %s:", subscriptionFilterScope(subscription)), "")
+		policy := subscription.FilterPolicy
+		if node, err := parseJSON(policy); err == nil {
+			policy = renderJSON(node, 0)
+		}
+		lines = append(lines, policy)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// TopicPublishDetails returns the details of a test message shown before it's published
+func TopicPublishDetails(topic *cloud.Topic, message cloud.TopicMessage) []string {
+	details := []string{fmt.Sprintf("Topic: %s", topic.Name)}
+	if topic.FIFO {
+		details = append(details, fmt.Sprintf("Message Group: %s", cloud.TestMessageGroup))
+	}
+	if message.Subject != "" {
+		details = append(details, fmt.Sprintf("Subject: %s", message.Subject))
+	}
+
+	names := make([]string, 0, len(message.Attributes))
+	for name := range message.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		attribute := message.Attributes[name]
+		details = append(details, fmt.Sprintf("Attribute %s (%s): %s", name, attribute.DataType, attribute.Value))
+	}
+
+	details = append(details,
+		fmt.Sprintf("Message: %s", payloadPreview(message.Body)),
+		fmt.Sprintf("Subscriptions: %d confirmed", topic.Confirmed),
+	)
+	return details
+}
+
+// renderSubscription renders the viewer of a subscription
+func renderSubscription(m *model.Model) string {
+	if m.SelectedSubscription == nil {
+		return ""
+	}
+	return renderFileViewer(m, m.SelectedSubscription.Endpoint)
+}
+
+// renderTopicPublish renders the editor of a test message
+func renderTopicPublish(m *model.Model) string {
+	return renderTextEditor(m, constants.TitleTopicPublish, m.IsTopicInputMode)
+}
+
+// topicType returns whether a topic is a standard or a FIFO topic
+func topicType(topic *cloud.Topic) string {
+	if topic.FIFO {
+		return "FIFO"
+	}
+	return "Standard"
+}
+
+// subscriptionStatus returns whether a subscription is confirmed
+func subscriptionStatus(subscription *cloud.Subscription) string {
+	if subscription.PendingConfirmation {
+		return "Pending Confirmation"
+	}
+	return "Confirmed"
+}
+
+// subscriptionFilterScope returns what the filter policy of a subscription applies to
+func subscriptionFilterScope(subscription *cloud.Subscription) string {
+	if subscription.FilterPolicyScope == "MessageBody" {
+		return "message body"
+	}
+	return "message attributes"
+}
+
+// getTopicsContextText returns the context text for the SNS views
+func getTopicsContextText(m *model.Model) string {
+	context := fmt.Sprintf("Profile: %s\nRegion: %s", m.AwsProfile, m.AwsRegion)
+	if m.CurrentView == constants.ViewTopics {
+		var pending int64
+		for _, topic := range m.Topics {
+			pending += topic.Pending
+		}
+		context += fmt.Sprintf("\nTopics: %d", len(m.Topics))
+		if pending > 0 {
+			context += "\n" + logWarningStyle.Render(fmt.Sprintf("Subscriptions pending confirmation: %d", pending))
+		}
+		return context
+	}
+
+	topic := m.SelectedTopic
+	if topic == nil {
+		return context
+	}
+	context += fmt.Sprintf("\nTopic: %s\nType: %s", topic.Name, topicType(topic))
+
+	switch m.CurrentView {
+	case constants.ViewTopicDetails:
+		if topic.Pending > 0 {
+			context += "\n" + logWarningStyle.Render(fmt.Sprintf("%d subscriptions don't get messages until they're confirmed", topic.Pending))
+		}
+	case constants.ViewSubscriptions:
+		filtered := 0
+		for _, subscription := range m.Subscriptions {
+			if subscription.FilterPolicy != "" {
+				filtered++
+			}
+		}
+		context += fmt.Sprintf("\nSubscriptions: %d\nWith a Filter Policy: %d", len(m.Subscriptions), filtered)
+	case constants.ViewSubscription:
+		if subscription := m.SelectedSubscription; subscription != nil {
+			context += fmt.Sprintf("\nSubscription: %s\nStatus: %s", subscription.Protocol, subscriptionStatus(subscription))
+		}
+	case constants.ViewTopicPublish:
+		context += "\n" + logRequestStyle.Render("Subscriptions with a filter policy only get the messages it matches")
+		if topic.FIFO {
+			context += "\n" + logRequestStyle.Render(fmt.Sprintf("Sent to the %s message group", cloud.TestMessageGroup))
+		}
+	}
+	return context
+}
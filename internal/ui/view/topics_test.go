@@ -0,0 +1,33 @@
+package view
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/HenryOwenz/cloudgate/internal/cloud"
+)
+
+func TestTopicPublishDetails(t *testing.T) {
+	topic := &cloud.Topic{Name: "orders.fifo", FIFO: true, Confirmed: 3}
+	message := cloud.TopicMessage{
+		Subject: "Test",
+		Body:    "{\n  \"id\": 42\n}",
+		Attributes: map[string]cloud.TopicMessageAttribute{
+			"regions":   {DataType: "String.Array", Value: `["eu","us"]`},
+			"eventType": {DataType: "String", Value: "order.created"},
+		},
+	}
+
+	want := []string{
+		"Topic: orders.fifo",
+		"Message Group: " + cloud.TestMessageGroup,
+		"Subject: Test",
+		"Attribute eventType (String): order.created",
+		`Attribute regions (String.Array): ["eu","us"]`,
+		`Message: {"id":42}`,
+		"Subscriptions: 3 confirmed",
+	}
+	if got := TopicPublishDetails(topic, message); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
@@ -63,12 +63,30 @@ func renderContext(m *model.Model) string {
 	return m.Styles.Context.Render(getContextText(m))
 }
 
-// renderLoadingSpinner renders the loading spinner if needed
+// renderLoadingSpinner renders the loading spinner if needed, followed by the progress of an
+// action that reports it or of a running query
 func renderLoadingSpinner(m *model.Model) string {
-	if m.IsLoading {
-		return m.Spinner.View()
+	if !m.IsLoading {
+		return ""
+	}
+	if m.ActionProgress != nil {
+		return m.Spinner.View() + " " + renderActionProgress(m.ActionProgress)
 	}
-	return ""
+	if m.LogsQueryID != "" {
+		return m.Spinner.View() + " " + renderLogsQueryProgress(m.LogsQueryResults)
+	}
+	return m.Spinner.View()
+}
+
+// renderActionProgress renders a progress bar with the share of an action that's done
+func renderActionProgress(progress *model.ActionProgress) string {
+	fraction := 1.0
+	if progress.Total > 0 {
+		fraction = min(float64(progress.Done)/float64(progress.Total), 1)
+	}
+	filled := int(fraction * constants.ProgressBarWidth)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", constants.ProgressBarWidth-filled)
+	return fmt.Sprintf("%s %3.f%% %s of %s", bar, fraction*100, formatBytes(progress.Done), formatBytes(progress.Total))
 }
 
 // renderMainContent renders the main content area based on the current view
@@ -104,6 +122,12 @@ func renderMainContent(m *model.Model) string {
 		return renderTable(m)
 	case constants.ViewFunctionDetails:
 		return renderTable(m)
+	case constants.ViewEventSourceScope, constants.ViewEventSourceMappings, constants.ViewEventSourceDetails:
+		return renderTable(m)
+	case constants.ViewFunctionSecurity, constants.ViewFunctionCompare, constants.ViewAsyncConfig, constants.ViewFailedEvents:
+		return renderTable(m)
+	case constants.ViewS3Buckets:
+		return renderTable(m)
 	case constants.ViewLambdaExecute:
 		// Set fixed height to match standard table views
 		height := constants.TableHeight
@@ -122,8 +146,11 @@ func renderMainContent(m *model.Model) string {
 		line := strings.Repeat("─", max(0, m.Width-constants.ViewportMarginX*2-lipgloss.Width(title)))
 		header := lipgloss.JoinHorizontal(lipgloss.Center, title, line)
 
-		// Get the main content (TextArea view)
+		// Get the main content (TextArea view), followed by any payload validation error
 		content := m.TextArea.View()
+		if m.LambdaPayloadError != nil {
+			content += "\n" + renderPayloadError(m)
+		}
 
 		// Add a footer line
 		footerText := ""
@@ -132,6 +159,7 @@ func renderMainContent(m *model.Model) string {
 		} else {
 			footerText = "COMMAND MODE"
 		}
+		footerText += " • INVOKE: " + m.LambdaInvokeMode
 		footer := lipgloss.NewStyle().
 			Foreground(lipgloss.Color(constants.ColorPrimary)).
 			Render(footerText)
@@ -151,8 +179,15 @@ func renderMainContent(m *model.Model) string {
 		line := strings.Repeat("─", max(0, m.Viewport.Width-lipgloss.Width(title)))
 		header := lipgloss.JoinHorizontal(lipgloss.Center, title, line)
 
-		// Add a footer with scroll percentage
-		footerText := fmt.Sprintf("%3.f%%", m.Viewport.ScrollPercent()*100)
+		// Add a footer with the shown pane and scroll percentage
+		pane := "RESPONSE"
+		if m.ShowLambdaLogs {
+			pane = "LOGS"
+		}
+		if m.IsLambdaStreaming {
+			pane = "STREAMING " + pane
+		}
+		footerText := fmt.Sprintf("%s %3.f%%", pane, m.Viewport.ScrollPercent()*100)
 		footer := lipgloss.NewStyle().
 			Foreground(lipgloss.Color(constants.ColorPrimary)).
 			Render(footerText)
@@ -162,8 +197,62 @@ func renderMainContent(m *model.Model) string {
 		// Set viewport height to match table height
 		m.Viewport.Height = constants.TableHeight
 
+		// Show the query input below the viewport while a query is being entered
+		if m.ManualInput {
+			return fmt.Sprintf("%s\n%s\n%s\n%s", header, m.Viewport.View(), footer, m.TextInput.View())
+		}
+
 		// Return the complete view
 		return fmt.Sprintf("%s\n%s\n%s", header, m.Viewport.View(), footer)
+	case constants.ViewLambdaConfig, constants.ViewLambdaDeploy, constants.ViewHygieneOptions, constants.ViewHygieneReport,
+		constants.ViewLambdaBench, constants.ViewLambdaBenchResult, constants.ViewPackageBrowser, constants.ViewCompareOptions,
+		constants.ViewS3Objects, constants.ViewS3ObjectDetails, constants.ViewEC2Filters, constants.ViewLogsResults,
+		constants.ViewECSServiceDetails, constants.ViewStartBuild, constants.ViewQueueDetails:
+		if m.ManualInput {
+			return fmt.Sprintf("%s\n%s", renderTable(m), m.TextInput.View())
+		}
+		return renderTable(m)
+	case constants.ViewPackageFile:
+		return renderPackageFile(m)
+	case constants.ViewS3Presign:
+		return renderS3Presign(m)
+	case constants.ViewS3Preview:
+		return renderS3Preview(m)
+	case constants.ViewEC2Instances, constants.ViewEC2InstanceDetails:
+		return renderTable(m)
+	case constants.ViewLogGroups, constants.ViewSavedQueries:
+		return renderTable(m)
+	case constants.ViewAlarms, constants.ViewAlarmDetails, constants.ViewAlarmHistory:
+		return renderTable(m)
+	case constants.ViewECSClusters, constants.ViewECSServices, constants.ViewECSTasks, constants.ViewECSTaskDetails:
+		return renderTable(m)
+	case constants.ViewBuildProjects, constants.ViewBuilds, constants.ViewBuildDetails, constants.ViewBuildLogs:
+		return renderTable(m)
+	case constants.ViewStacks, constants.ViewStackDetails, constants.ViewStackDrift, constants.ViewResourceDrift,
+		constants.ViewChangeSets, constants.ViewChangeSetDetails:
+		return renderTable(m)
+	case constants.ViewStackEvents:
+		return renderStackEvents(m)
+	case constants.ViewParameters, constants.ViewParameterDetails, constants.ViewParameterHistory:
+		return renderTable(m)
+	case constants.ViewParameterEdit:
+		return renderParameterEditor(m)
+	case constants.ViewSecrets, constants.ViewSecretDetails:
+		return renderTable(m)
+	case constants.ViewQueues, constants.ViewQueueMessages:
+		return renderTable(m)
+	case constants.ViewQueueMessage:
+		return renderQueueMessage(m)
+	case constants.ViewQueueSend:
+		return renderQueueSend(m)
+	case constants.ViewTopics, constants.ViewTopicDetails, constants.ViewSubscriptions:
+		return renderTable(m)
+	case constants.ViewSubscription:
+		return renderSubscription(m)
+	case constants.ViewTopicPublish:
+		return renderTopicPublish(m)
+	case constants.ViewLogsQuery:
+		return renderLogsQuery(m)
 	case constants.ViewExecutingAction:
 		// Show the table instead of just the loading message
 		return renderTable(m)
@@ -235,6 +324,51 @@ func getContextText(m *model.Model) string {
 		return getLambdaExecuteContextText(m)
 	case constants.ViewLambdaResponse:
 		return getLambdaResponseContextText(m)
+	case constants.ViewLambdaConfig:
+		return getLambdaConfigContextText(m)
+	case constants.ViewLambdaDeploy:
+		return getLambdaDeployContextText(m)
+	case constants.ViewEventSourceScope, constants.ViewEventSourceMappings, constants.ViewEventSourceDetails:
+		return getEventSourceContextText(m)
+	case constants.ViewHygieneOptions, constants.ViewHygieneReport:
+		return getHygieneContextText(m)
+	case constants.ViewLambdaBench, constants.ViewLambdaBenchResult:
+		return getLambdaBenchContextText(m)
+	case constants.ViewPackageBrowser, constants.ViewPackageFile:
+		return getPackageContextText(m)
+	case constants.ViewFunctionSecurity:
+		return getFunctionSecurityContextText(m)
+	case constants.ViewCompareOptions, constants.ViewFunctionCompare:
+		return getCompareContextText(m)
+	case constants.ViewAsyncConfig, constants.ViewFailedEvents:
+		return getAsyncContextText(m)
+	case constants.ViewS3Buckets, constants.ViewS3Objects, constants.ViewS3ObjectDetails, constants.ViewS3Presign, constants.ViewS3Preview:
+		return getS3ContextText(m)
+	case constants.ViewEC2Filters, constants.ViewEC2Instances, constants.ViewEC2InstanceDetails:
+		return getEC2ContextText(m)
+	case constants.ViewLogGroups, constants.ViewLogsQuery, constants.ViewSavedQueries, constants.ViewLogsResults:
+		return getLogsContextText(m)
+	case constants.ViewAlarms, constants.ViewAlarmDetails, constants.ViewAlarmHistory:
+		return getAlarmsContextText(m)
+	case constants.ViewECSClusters, constants.ViewECSServices, constants.ViewECSServiceDetails, constants.ViewECSTasks,
+		constants.ViewECSTaskDetails:
+		return getECSContextText(m)
+	case constants.ViewBuildProjects, constants.ViewBuilds, constants.ViewStartBuild, constants.ViewBuildDetails,
+		constants.ViewBuildLogs:
+		return getCodeBuildContextText(m)
+	case constants.ViewStacks, constants.ViewStackDetails, constants.ViewStackEvents, constants.ViewStackDrift,
+		constants.ViewResourceDrift, constants.ViewChangeSets, constants.ViewChangeSetDetails:
+		return getCloudFormationContextText(m)
+	case constants.ViewParameters, constants.ViewParameterDetails, constants.ViewParameterHistory, constants.ViewParameterEdit:
+		return getParametersContextText(m)
+	case constants.ViewSecrets, constants.ViewSecretDetails:
+		return getSecretsContextText(m)
+	case constants.ViewQueues, constants.ViewQueueDetails, constants.ViewQueueMessages, constants.ViewQueueMessage,
+		constants.ViewQueueSend:
+		return getQueuesContextText(m)
+	case constants.ViewTopics, constants.ViewTopicDetails, constants.ViewSubscriptions, constants.ViewSubscription,
+		constants.ViewTopicPublish:
+		return getTopicsContextText(m)
 	default:
 		return ""
 	}
@@ -322,6 +456,12 @@ func getConfirmationSummaryContextText(m *model.Model) string {
 
 // getExecutingActionContextText returns the context text for the executing action view
 func getExecutingActionContextText(m *model.Model) string {
+	if m.PendingAction != nil {
+		return fmt.Sprintf("Profile: %s\nRegion: %s\n\n%s",
+			m.AwsProfile,
+			m.AwsRegion,
+			strings.Join(m.PendingAction.Details, "\n"))
+	}
 	if m.SelectedOperation != nil && m.SelectedOperation.Name == "Start Pipeline" {
 		if m.SelectedPipeline == nil {
 			return ""
@@ -370,11 +510,39 @@ func getPipelineStagesContextText(m *model.Model) string {
 
 // getFunctionStatusContextText returns the context text for the function status view
 func getFunctionStatusContextText(m *model.Model) string {
-	return fmt.Sprintf("Profile: %s\nRegion: %s\nService: %s\nCategory: %s",
+	context := fmt.Sprintf("Profile: %s\nRegion: %s\nService: %s\nCategory: %s",
 		m.AwsProfile,
 		m.AwsRegion,
 		m.SelectedService.Name,
 		m.SelectedCategory.Name)
+
+	if m.ShowFunctionMetrics {
+		context += fmt.Sprintf("\nMetrics: last %s", formatWindow(m.FunctionMetricsWindow))
+	}
+
+	// Functions that couldn't be described show what ListFunctions returned, without state or tags
+	var undescribed []string
+	for _, function := range m.Functions {
+		if len(function.Errors) > 0 {
+			undescribed = append(undescribed, function.Name)
+		}
+	}
+	if len(undescribed) > 0 {
+		context += "\n" + logWarningStyle.Render(fmt.Sprintf("Not fully described: %s", summarizeNames(undescribed, maxListedNames)))
+	}
+
+	return context
+}
+
+// maxListedNames is how many names a warning in the context pane lists before counting the rest
+const maxListedNames = 3
+
+// summarizeNames joins up to limit names, and counts the names left out, e.g. "a, b, c and 4 more"
+func summarizeNames(names []string, limit int) string {
+	if len(names) <= limit {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:limit], ", "), len(names)-limit)
 }
 
 // getFunctionDetailsContextText returns the context text for the function details view
@@ -382,10 +550,14 @@ func getFunctionDetailsContextText(m *model.Model) string {
 	if m.SelectedFunction == nil {
 		return ""
 	}
-	return fmt.Sprintf("Profile: %s\nRegion: %s\nFunction: %s",
+	context := fmt.Sprintf("Profile: %s\nRegion: %s\nFunction: %s",
 		m.AwsProfile,
 		m.AwsRegion,
 		m.SelectedFunction.Name)
+	for _, err := range m.SelectedFunction.Errors {
+		context += "\n" + logWarningStyle.Render(err)
+	}
+	return context
 }
 
 // getLambdaExecuteContextText returns the context text for the Lambda execution view
@@ -422,23 +594,87 @@ func getLambdaResponseContextText(m *model.Model) string {
 func getTitleText(m *model.Model) string {
 	// Map of view types to their corresponding titles
 	titleMap := map[constants.View]string{
-		constants.ViewProviders:       constants.TitleProviders,
-		constants.ViewSelectService:   constants.TitleSelectService,
-		constants.ViewSelectCategory:  constants.TitleSelectCategory,
-		constants.ViewSelectOperation: constants.TitleSelectOperation,
-		constants.ViewApprovals:       constants.TitleApprovals,
-		constants.ViewConfirmation:    constants.TitleConfirmation,
-		constants.ViewSummary:         constants.TitleSummary,
-		constants.ViewExecutingAction: constants.TitleExecutingAction,
-		constants.ViewPipelineStatus:  constants.TitlePipelineStatus,
-		constants.ViewPipelineStages:  constants.TitlePipelineStages,
-		constants.ViewError:           constants.TitleError,
-		constants.ViewSuccess:         constants.TitleSuccess,
-		constants.ViewHelp:            constants.TitleHelp,
-		constants.ViewFunctionStatus:  constants.TitleFunctionStatus,
-		constants.ViewFunctionDetails: constants.TitleFunctionDetails,
-		constants.ViewLambdaExecute:   constants.TitleLambdaExecute,
-		constants.ViewLambdaResponse:  constants.TitleLambdaResponse,
+		constants.ViewProviders:           constants.TitleProviders,
+		constants.ViewSelectService:       constants.TitleSelectService,
+		constants.ViewSelectCategory:      constants.TitleSelectCategory,
+		constants.ViewSelectOperation:     constants.TitleSelectOperation,
+		constants.ViewApprovals:           constants.TitleApprovals,
+		constants.ViewConfirmation:        constants.TitleConfirmation,
+		constants.ViewSummary:             constants.TitleSummary,
+		constants.ViewExecutingAction:     constants.TitleExecutingAction,
+		constants.ViewPipelineStatus:      constants.TitlePipelineStatus,
+		constants.ViewPipelineStages:      constants.TitlePipelineStages,
+		constants.ViewError:               constants.TitleError,
+		constants.ViewSuccess:             constants.TitleSuccess,
+		constants.ViewHelp:                constants.TitleHelp,
+		constants.ViewFunctionStatus:      constants.TitleFunctionStatus,
+		constants.ViewFunctionDetails:     constants.TitleFunctionDetails,
+		constants.ViewLambdaExecute:       constants.TitleLambdaExecute,
+		constants.ViewLambdaResponse:      constants.TitleLambdaResponse,
+		constants.ViewLambdaConfig:        constants.TitleLambdaConfig,
+		constants.ViewLambdaDeploy:        constants.TitleLambdaDeploy,
+		constants.ViewEventSourceScope:    constants.TitleEventSourceScope,
+		constants.ViewEventSourceMappings: constants.TitleEventSourceMappings,
+		constants.ViewEventSourceDetails:  constants.TitleEventSourceDetails,
+		constants.ViewHygieneOptions:      constants.TitleHygieneOptions,
+		constants.ViewHygieneReport:       constants.TitleHygieneReport,
+		constants.ViewLambdaBench:         constants.TitleLambdaBench,
+		constants.ViewLambdaBenchResult:   constants.TitleLambdaBenchResult,
+		constants.ViewPackageBrowser:      constants.TitlePackageBrowser,
+		constants.ViewPackageFile:         constants.TitlePackageBrowser,
+		constants.ViewFunctionSecurity:    constants.TitleFunctionSecurity,
+		constants.ViewCompareOptions:      constants.TitleCompareOptions,
+		constants.ViewFunctionCompare:     constants.TitleFunctionCompare,
+		constants.ViewAsyncConfig:         constants.TitleAsyncConfig,
+		constants.ViewFailedEvents:        constants.TitleFailedEvents,
+		constants.ViewS3Buckets:           constants.TitleS3Buckets,
+		constants.ViewS3Objects:           constants.TitleS3Objects,
+		constants.ViewS3ObjectDetails:     constants.TitleS3ObjectDetails,
+		constants.ViewS3Presign:           constants.TitleS3Presign,
+		constants.ViewS3Preview:           constants.TitleS3Preview,
+		constants.ViewEC2Filters:          constants.TitleEC2Filters,
+		constants.ViewEC2Instances:        constants.TitleEC2Instances,
+		constants.ViewEC2InstanceDetails:  constants.TitleEC2InstanceDetails,
+		constants.ViewLogGroups:           constants.TitleLogGroups,
+		constants.ViewLogsQuery:           constants.TitleLogsQuery,
+		constants.ViewSavedQueries:        constants.TitleSavedQueries,
+		constants.ViewLogsResults:         constants.TitleLogsResults,
+		constants.ViewAlarms:              constants.TitleAlarms,
+		constants.ViewAlarmDetails:        constants.TitleAlarmDetails,
+		constants.ViewAlarmHistory:        constants.TitleAlarmHistory,
+		constants.ViewECSClusters:         constants.TitleECSClusters,
+		constants.ViewECSServices:         constants.TitleECSServices,
+		constants.ViewECSServiceDetails:   constants.TitleECSServiceDetails,
+		constants.ViewECSTasks:            constants.TitleECSTasks,
+		constants.ViewECSTaskDetails:      constants.TitleECSTaskDetails,
+		constants.ViewBuildProjects:       constants.TitleBuildProjects,
+		constants.ViewBuilds:              constants.TitleBuilds,
+		constants.ViewStartBuild:          constants.TitleStartBuild,
+		constants.ViewBuildDetails:        constants.TitleBuildDetails,
+		constants.ViewBuildLogs:           constants.TitleBuildLogs,
+		constants.ViewStacks:              constants.TitleStacks,
+		constants.ViewStackDetails:        constants.TitleStackDetails,
+		constants.ViewStackEvents:         constants.TitleStackEvents,
+		constants.ViewStackDrift:          constants.TitleStackDrift,
+		constants.ViewResourceDrift:       constants.TitleResourceDrift,
+		constants.ViewChangeSets:          constants.TitleChangeSets,
+		constants.ViewChangeSetDetails:    constants.TitleChangeSetDetails,
+		constants.ViewParameters:          constants.TitleParameters,
+		constants.ViewParameterDetails:    constants.TitleParameterDetails,
+		constants.ViewParameterHistory:    constants.TitleParameterHistory,
+		constants.ViewParameterEdit:       constants.TitleParameterEdit,
+		constants.ViewSecrets:             constants.TitleSecrets,
+		constants.ViewSecretDetails:       constants.TitleSecretDetails,
+		constants.ViewQueues:              constants.TitleQueues,
+		constants.ViewQueueDetails:        constants.TitleQueueDetails,
+		constants.ViewQueueMessages:       constants.TitleQueueMessages,
+		constants.ViewQueueMessage:        constants.TitleQueueMessage,
+		constants.ViewQueueSend:           constants.TitleQueueSend,
+		constants.ViewTopics:              constants.TitleTopics,
+		constants.ViewTopicDetails:        constants.TitleTopicDetails,
+		constants.ViewSubscriptions:       constants.TitleSubscriptions,
+		constants.ViewSubscription:        constants.TitleSubscription,
+		constants.ViewTopicPublish:        constants.TitleTopicPublish,
 	}
 
 	// Special case for AWS config view
@@ -492,10 +728,25 @@ func getHelpText(m *model.Model) string {
 		manualInputHelpText    = "%s: confirm • %s: cancel • %s: quit"
 		summaryHelpText        = "j/k: navigate • %s: select • %s: back • %s: quit"
 		providersHelpText      = "j/k: navigate • %s: select • %s: quit"
-		lambdaCommandModeText  = "-- COMMAND MODE -- • i: enter input mode • enter: execute • %s: back • %s: quit"
+		lambdaCommandModeText  = "-- COMMAND MODE -- • i: enter input mode • enter: execute • %s: invoke mode • %s: benchmark • %s: back • %s: quit"
 		lambdaInputModeText    = "-- INPUT MODE -- • enter: new line • ctrl+c/esc: exit input mode • %s: back • %s: quit"
-		lambdaResponseHelpText = "j/k: scroll • b/f: page • g/G: top/bottom • %s: back to editor • %s: quit"
+		lambdaResponseHelpText = "j/k: scroll • b/f: page • g/G: top/bottom • %s: query • %s/%s: collapse/expand • %s: logs • %s: back to editor • %s: quit"
 		paginatedViewHelpText  = "j/k: navigate • h: prev page • l: next page • %s: select • %s: back • %s: quit"
+		functionStatusHelpText = "j/k: navigate • h/l: page • %s: metrics • %s: 1h/24h • %s: select • %s: back • %s: quit"
+		functionDetailHelpText = "j/k: navigate • %s: 1h/24h metrics • %s: browse package • %s: security • %s: compare • %s: async • %s: back • %s: quit"
+		packageBrowserHelpText = "j/k: navigate • %s: open • %s: extract • %s: up/back • %s: quit"
+		packageFileHelpText    = "j/k: scroll • b/f: page • g/G: top/bottom • %s: back • %s: quit"
+		hygieneReportHelpText  = "j/k: navigate • %s: export as CSV or JSON • %s: back • %s: quit"
+		s3ObjectsHelpText      = "j/k: navigate • %s/%s: page • %s: open • %s: download • %s: upload • %s: presign • %s: preview • %s: up/back • %s: quit"
+		s3DetailsHelpText      = "j/k: navigate • %s: download • %s: presign • %s: preview • %s: back • %s: quit"
+		logGroupsHelpText      = "j/k: navigate • %s: select log group or write query • %s: back • %s: quit"
+		logsCommandModeText    = "-- COMMAND MODE -- • i: enter input mode • %s: run • %s: time range • %s: saved queries • %s: save • %s: back • %s: quit"
+		logsInputModeText      = "-- INPUT MODE -- • enter: new line • %s: exit input mode • %s: quit"
+		logsResultsHelpText    = "j/k: navigate • %s: export as CSV • %s: back to editor • %s: quit"
+		parameterEditHelpText  = "-- COMMAND MODE -- • i: enter input mode • %s: review changes • %s: back • %s: quit"
+		parametersHelpText     = "j/k: navigate • %s: open • %s: up/back • %s: quit"
+		secretDetailsHelpText  = "j/k: navigate • %s: reveal/hide value • %s: rotate • %s: back • %s: quit"
+		testMessageHelpText    = "-- COMMAND MODE -- • i: enter input mode • %s: review message • %s: back • %s: quit"
 	)
 
 	// Special cases based on view and state
@@ -504,6 +755,15 @@ func getHelpText(m *model.Model) string {
 		return fmt.Sprintf(providersHelpText, constants.KeyEnter, constants.KeyQ)
 	case m.CurrentView == constants.ViewAWSConfig && m.ManualInput:
 		return fmt.Sprintf(manualInputHelpText, constants.KeyEnter, constants.KeyEsc, constants.KeyCtrlC)
+	case (m.CurrentView == constants.ViewLambdaConfig || m.CurrentView == constants.ViewLambdaDeploy ||
+		m.CurrentView == constants.ViewHygieneOptions || m.CurrentView == constants.ViewHygieneReport ||
+		m.CurrentView == constants.ViewLambdaBench || m.CurrentView == constants.ViewPackageBrowser ||
+		m.CurrentView == constants.ViewCompareOptions || m.CurrentView == constants.ViewS3Objects ||
+		m.CurrentView == constants.ViewS3ObjectDetails || m.CurrentView == constants.ViewS3Presign ||
+		m.CurrentView == constants.ViewEC2Filters || m.CurrentView == constants.ViewLogsQuery ||
+		m.CurrentView == constants.ViewLogsResults || m.CurrentView == constants.ViewECSServiceDetails ||
+		m.CurrentView == constants.ViewStartBuild || m.CurrentView == constants.ViewQueueDetails) && m.ManualInput:
+		return fmt.Sprintf(manualInputHelpText, constants.KeyEnter, constants.KeyEsc, constants.KeyCtrlC)
 	case m.CurrentView == constants.ViewSummary && m.ManualInput:
 		return fmt.Sprintf(manualInputHelpText, constants.KeyEnter, constants.KeyEsc, constants.KeyCtrlC)
 	case m.CurrentView == constants.ViewSummary:
@@ -512,9 +772,56 @@ func getHelpText(m *model.Model) string {
 		if m.IsLambdaInputMode {
 			return fmt.Sprintf(lambdaInputModeText, constants.KeyEsc, constants.KeyQ)
 		}
-		return fmt.Sprintf(lambdaCommandModeText, constants.KeyEsc, constants.KeyQ)
+		return fmt.Sprintf(lambdaCommandModeText, constants.KeyInvokeMode, constants.KeyBenchmark, constants.KeyEsc, constants.KeyQ)
+	case m.CurrentView == constants.ViewLambdaResponse && m.ManualInput:
+		return fmt.Sprintf(manualInputHelpText, constants.KeyEnter, constants.KeyEsc, constants.KeyCtrlC)
 	case m.CurrentView == constants.ViewLambdaResponse:
-		return fmt.Sprintf(lambdaResponseHelpText, constants.KeyEsc, constants.KeyQ)
+		return fmt.Sprintf(lambdaResponseHelpText, constants.KeySearch, constants.KeyCollapse, constants.KeyExpand, constants.KeyToggleLogs, constants.KeyEsc, constants.KeyQ)
+	case m.CurrentView == constants.ViewFunctionStatus && m.Pagination.Type != model.PaginationTypeNone:
+		return fmt.Sprintf(functionStatusHelpText, constants.KeyToggleMetrics, constants.KeyMetricsWindow, constants.KeyEnter, constants.KeyEsc, constants.KeyQ)
+	case m.CurrentView == constants.ViewHygieneReport:
+		return fmt.Sprintf(hygieneReportHelpText, constants.KeyExport, constants.KeyEsc, constants.KeyQ)
+	case m.CurrentView == constants.ViewFunctionDetails:
+		return fmt.Sprintf(functionDetailHelpText, constants.KeyMetricsWindow, constants.KeyBrowsePackage, constants.KeySecurity, constants.KeyCompare, constants.KeyAsyncInvoke, constants.KeyEsc, constants.KeyQ)
+	case m.CurrentView == constants.ViewPackageBrowser:
+		return fmt.Sprintf(packageBrowserHelpText, constants.KeyEnter, constants.KeyExport, constants.KeyEsc, constants.KeyQ)
+	case m.CurrentView == constants.ViewS3Objects:
+		return fmt.Sprintf(s3ObjectsHelpText, constants.KeyPreviousPage, constants.KeyNextPage, constants.KeyEnter, constants.KeyExport,
+			constants.KeyUpload, constants.KeyPresign, constants.KeyPreview, constants.KeyEsc, constants.KeyQ)
+	case m.CurrentView == constants.ViewS3ObjectDetails:
+		return fmt.Sprintf(s3DetailsHelpText, constants.KeyExport, constants.KeyPresign, constants.KeyPreview, constants.KeyEsc, constants.KeyQ)
+	case m.CurrentView == constants.ViewLogGroups:
+		return fmt.Sprintf(logGroupsHelpText, constants.KeyEnter, constants.KeyEsc, constants.KeyQ)
+	case m.CurrentView == constants.ViewLogsQuery:
+		if m.IsLogsQueryInputMode {
+			return fmt.Sprintf(logsInputModeText, constants.KeyEsc, constants.KeyCtrlC)
+		}
+		return fmt.Sprintf(logsCommandModeText, constants.KeyEnter, constants.KeyTimeRange, constants.KeySavedQueries, constants.KeySaveQuery, constants.KeyEsc, constants.KeyQ)
+	case m.CurrentView == constants.ViewLogsResults:
+		return fmt.Sprintf(logsResultsHelpText, constants.KeyExport, constants.KeyEsc, constants.KeyQ)
+	case m.CurrentView == constants.ViewParameters:
+		return fmt.Sprintf(parametersHelpText, constants.KeyEnter, constants.KeyEsc, constants.KeyQ)
+	case m.CurrentView == constants.ViewParameterEdit:
+		if m.IsParameterInputMode {
+			return fmt.Sprintf(logsInputModeText, constants.KeyEsc, constants.KeyCtrlC)
+		}
+		return fmt.Sprintf(parameterEditHelpText, constants.KeyEnter, constants.KeyEsc, constants.KeyQ)
+	case m.CurrentView == constants.ViewQueueSend:
+		if m.IsQueueInputMode {
+			return fmt.Sprintf(logsInputModeText, constants.KeyEsc, constants.KeyCtrlC)
+		}
+		return fmt.Sprintf(testMessageHelpText, constants.KeyEnter, constants.KeyEsc, constants.KeyQ)
+	case m.CurrentView == constants.ViewTopicPublish:
+		if m.IsTopicInputMode {
+			return fmt.Sprintf(logsInputModeText, constants.KeyEsc, constants.KeyCtrlC)
+		}
+		return fmt.Sprintf(testMessageHelpText, constants.KeyEnter, constants.KeyEsc, constants.KeyQ)
+	case m.CurrentView == constants.ViewSecretDetails:
+		return fmt.Sprintf(secretDetailsHelpText, constants.KeyRevealSecret, constants.KeyEnter, constants.KeyEsc, constants.KeyQ)
+	case m.CurrentView == constants.ViewPackageFile || m.CurrentView == constants.ViewS3Preview ||
+		m.CurrentView == constants.ViewStackEvents || m.CurrentView == constants.ViewQueueMessage ||
+		m.CurrentView == constants.ViewSubscription:
+		return fmt.Sprintf(packageFileHelpText, constants.KeyEsc, constants.KeyQ)
 	case IsPaginatedView(m.CurrentView) && m.Pagination.Type != model.PaginationTypeNone:
 		return fmt.Sprintf(paginatedViewHelpText, constants.KeyEnter, constants.KeyEsc, constants.KeyQ)
 	default:
@@ -522,6 +829,31 @@ func getHelpText(m *model.Model) string {
 	}
 }
 
+// renderTextEditor renders the text area as an editor with a title, and the mode it's in below it
+func renderTextEditor(m *model.Model, titleText string, inputMode bool) string {
+	m.TextArea.SetWidth(m.Width - constants.ViewportMarginX*2)
+	m.TextArea.SetHeight(constants.TableHeight)
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(constants.ColorTitle)).
+		Render(titleText)
+	line := strings.Repeat("─", max(0, m.Width-constants.ViewportMarginX*2-lipgloss.Width(title)))
+	header := lipgloss.JoinHorizontal(lipgloss.Center, title, line)
+
+	footerText := "COMMAND MODE"
+	if inputMode {
+		footerText = "INPUT MODE"
+	}
+	footer := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(constants.ColorPrimary)).
+		Render(footerText)
+	footerLine := strings.Repeat("─", max(0, m.Width-constants.ViewportMarginX*2-lipgloss.Width(footerText)))
+	footer = lipgloss.JoinHorizontal(lipgloss.Center, footerLine, footer)
+
+	return fmt.Sprintf("%s\n%s\n%s", header, m.TextArea.View(), footer)
+}
+
 // renderTable renders the table for the current view
 func renderTable(m *model.Model) string {
 	if m.Table.Rows() == nil {
@@ -415,6 +415,110 @@ func TestRenderMainContent(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "ViewFunctionSecurity",
+			setupModel: func() *model.Model {
+				m := model.New()
+				m.CurrentView = constants.ViewFunctionSecurity
+				m.FunctionSecurity = &cloud.FunctionSecurity{
+					FunctionName: "test-function",
+					FunctionURL:  &cloud.FunctionURLConfig{URL: "https://abc.lambda-url.us-east-1.on.aws/", AuthType: "NONE"},
+					Findings: []cloud.SecurityFinding{
+						{Severity: cloud.SeverityHigh, Subject: "Function URL", Detail: "Auth type is NONE"},
+					},
+				}
+				UpdateTableForView(m)
+				return m
+			},
+			expectedChecks: func(t *testing.T, content string) {
+				if !strings.Contains(content, "Function URL") {
+					t.Errorf("Expected content to contain the findings, got '%s'", content)
+				}
+			},
+		},
+		{
+			name: "ViewCompareOptions",
+			setupModel: func() *model.Model {
+				m := model.New()
+				m.CurrentView = constants.ViewCompareOptions
+				m.CompareOptions.Targets = []cloud.CompareTarget{{Profile: "staging", Region: "us-east-1"}}
+				UpdateTableForView(m)
+				return m
+			},
+			expectedChecks: func(t *testing.T, content string) {
+				if !strings.Contains(content, constants.SettingTargets) {
+					t.Errorf("Expected content to contain the options, got '%s'", content)
+				}
+			},
+		},
+		{
+			name: "ViewFunctionCompare",
+			setupModel: func() *model.Model {
+				m := model.New()
+				m.CurrentView = constants.ViewFunctionCompare
+				m.FunctionComparison = cloud.NewFunctionComparison("orders", []cloud.FunctionSnapshot{
+					{Target: cloud.CompareTarget{Profile: "staging", Region: "us-east-1"}, Status: &cloud.FunctionStatus{Runtime: "nodejs20.x", Memory: 512}},
+					{Target: cloud.CompareTarget{Profile: "prod", Region: "us-east-1"}, Status: &cloud.FunctionStatus{Runtime: "nodejs20.x", Memory: 1024}},
+				})
+				UpdateTableForView(m)
+				return m
+			},
+			expectedChecks: func(t *testing.T, content string) {
+				if !strings.Contains(content, "1024") {
+					t.Errorf("Expected content to contain the compared settings, got '%s'", content)
+				}
+			},
+		},
+		{
+			name: "ViewAsyncConfig",
+			setupModel: func() *model.Model {
+				m := model.New()
+				m.CurrentView = constants.ViewAsyncConfig
+				m.AsyncConfig = &cloud.AsyncInvokeConfig{
+					FunctionName:     "orders",
+					MaxRetryAttempts: 2,
+					MaxEventAge:      3600,
+					OnFailure:        "arn:aws:sqs:us-east-1:123456789012:orders-failures",
+				}
+				UpdateTableForView(m)
+				return m
+			},
+			expectedChecks: func(t *testing.T, content string) {
+				if !strings.Contains(content, "orders-failures") {
+					t.Errorf("Expected content to contain the destinations, got '%s'", content)
+				}
+			},
+		},
+		{
+			name: "ViewFailedEvents",
+			setupModel: func() *model.Model {
+				m := model.New()
+				m.CurrentView = constants.ViewFailedEvents
+				m.FailedEvents = []cloud.FailedEvent{{Condition: "RetriesExhausted", ErrorMessage: "timeout", Payload: `{"id":1}`}}
+				UpdateTableForView(m)
+				return m
+			},
+			expectedChecks: func(t *testing.T, content string) {
+				if !strings.Contains(content, "RetriesExhausted") {
+					t.Errorf("Expected content to contain the failed events, got '%s'", content)
+				}
+			},
+		},
+		{
+			name: "ViewS3Buckets",
+			setupModel: func() *model.Model {
+				m := model.New()
+				m.CurrentView = constants.ViewS3Buckets
+				m.S3Buckets = []cloud.S3Bucket{{Name: "assets", Region: "eu-west-1"}}
+				UpdateTableForView(m)
+				return m
+			},
+			expectedChecks: func(t *testing.T, content string) {
+				if !strings.Contains(content, "assets") {
+					t.Errorf("Expected content to contain the bucket, got '%s'", content)
+				}
+			},
+		},
 	}
 
 	for _, tc := range testCases {